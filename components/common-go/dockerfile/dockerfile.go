@@ -0,0 +1,260 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package dockerfile
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+var escapeDirective = regexp.MustCompile(`^#\s*escape\s*=\s*(\S)\s*$`)
+
+// BaseImages returns the images a Dockerfile builds from, i.e. the FROM refs of all its stages
+// which do not refer to an earlier stage. Global ARGs are replaced by their defaults because image builds
+// receive no build args. Returns an error if the Dockerfile has no FROM or one of its images cannot be determined.
+func BaseImages(dockerfile string) ([]string, error) {
+	froms, err := parseFroms(dockerfile)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		seen = make(map[string]struct{})
+		res  []string
+	)
+	for _, f := range froms {
+		if !f.Base {
+			continue
+		}
+		if _, ok := seen[f.Image]; ok {
+			continue
+		}
+		seen[f.Image] = struct{}{}
+		res = append(res, f.Image)
+	}
+	return res, nil
+}
+
+// PinBaseImages replaces the images a Dockerfile builds from, as returned by BaseImages, with the refs they map to
+// in pinned, e.g. to build from the digests which were verified. Images which map to an empty ref are kept as they
+// are. Returns an error if the Dockerfile builds from an image which is not in pinned.
+func PinBaseImages(dockerfile string, pinned map[string]string) (string, error) {
+	froms, err := parseFroms(dockerfile)
+	if err != nil {
+		return "", err
+	}
+
+	lines := splitLines(dockerfile)
+	for _, f := range froms {
+		if !f.Base {
+			continue
+		}
+		ref, ok := pinned[f.Image]
+		if !ok {
+			return "", xerrors.Errorf("base image %s is not pinned", f.Image)
+		}
+		if ref == "" {
+			continue
+		}
+
+		inst := append([]string{"FROM"}, f.Flags...)
+		inst = append(inst, ref)
+		if f.Stage != "" {
+			inst = append(inst, "AS", f.Stage)
+		}
+		lines[f.Start] = strings.Join(inst, " ")
+		for i := f.Start + 1; i <= f.End; i++ {
+			lines[i] = ""
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// instruction is a Dockerfile instruction with its continued lines joined and comments dropped
+type instruction struct {
+	Text string
+	// Start and End are the first and last line of the instruction in the Dockerfile
+	Start, End int
+}
+
+// from is a FROM instruction
+type from struct {
+	instruction
+
+	// Flags are the flags of the instruction, e.g. --platform=linux/amd64
+	Flags []string
+	// Image is the image of the instruction with the global ARGs replaced
+	Image string
+	// Stage is the name of the stage the instruction starts, if any
+	Stage string
+	// Base is true if Image neither refers to an earlier stage nor is scratch
+	Base bool
+}
+
+// parseFroms returns the FROM instructions of a Dockerfile
+func parseFroms(dockerfile string) ([]from, error) {
+	var (
+		args   = make(map[string]*string)
+		stages = make(map[string]struct{})
+		res    []from
+	)
+	for _, inst := range instructions(dockerfile) {
+		fields := strings.Fields(inst.Text)
+		switch strings.ToUpper(fields[0]) {
+		case "ARG":
+			if len(res) > 0 {
+				// ARGs of a stage cannot be used in FROM
+				continue
+			}
+			for _, arg := range fields[1:] {
+				name, value, ok := strings.Cut(arg, "=")
+				if !ok {
+					args[name] = nil
+					continue
+				}
+				value = strings.Trim(value, `"'`)
+				args[name] = &value
+			}
+
+		case "FROM":
+			f := from{instruction: inst}
+			var params []string
+			for _, p := range fields[1:] {
+				if strings.HasPrefix(p, "--") {
+					f.Flags = append(f.Flags, p)
+				} else {
+					params = append(params, p)
+				}
+			}
+			if len(params) == 0 {
+				return nil, xerrors.Errorf("FROM without an image: %s", inst.Text)
+			}
+			img, err := expandArgs(params[0], args)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot determine image of %s: %w", inst.Text, err)
+			}
+			if img == "" {
+				return nil, xerrors.Errorf("FROM without an image: %s", inst.Text)
+			}
+			f.Image = img
+			_, isStage := stages[strings.ToLower(img)]
+			f.Base = !isStage && img != "scratch"
+			if len(params) == 3 && strings.EqualFold(params[1], "as") {
+				f.Stage = params[2]
+				stages[strings.ToLower(f.Stage)] = struct{}{}
+			}
+			res = append(res, f)
+		}
+	}
+	if len(res) == 0 {
+		return nil, xerrors.Errorf("Dockerfile has no FROM instruction")
+	}
+	return res, nil
+}
+
+func splitLines(dockerfile string) []string {
+	return strings.Split(strings.ReplaceAll(dockerfile, "\r\n", "\n"), "\n")
+}
+
+// instructions splits a Dockerfile into its instructions, joining continued lines and dropping comments
+func instructions(dockerfile string) []instruction {
+	var (
+		lines  = splitLines(dockerfile)
+		escape = `\`
+		res    []instruction
+		cur    strings.Builder
+		start  int
+	)
+	add := func(end int) {
+		if text := strings.TrimSpace(cur.String()); text != "" {
+			res = append(res, instruction{Text: text, Start: start, End: end})
+		}
+		cur.Reset()
+	}
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if i == 0 {
+			if m := escapeDirective.FindStringSubmatch(trimmed); m != nil && (m[1] == "`" || m[1] == `\`) {
+				escape = m[1]
+			}
+		}
+		if strings.HasPrefix(trimmed, "#") || (trimmed == "" && cur.Len() > 0) {
+			// comments and empty lines do not end continued instructions
+			continue
+		}
+		if cur.Len() == 0 {
+			start = i
+		}
+		if strings.HasSuffix(trimmed, escape) {
+			cur.WriteString(strings.TrimSuffix(trimmed, escape))
+			cur.WriteString(" ")
+			continue
+		}
+		cur.WriteString(trimmed)
+		add(i)
+	}
+	add(len(lines) - 1)
+	return res
+}
+
+// expandArgs replaces $name, ${name}, ${name:-word} and ${name:+word} with the values of args
+func expandArgs(s string, args map[string]*string) (string, error) {
+	var res strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i == len(s)-1 {
+			res.WriteByte(s[i])
+			continue
+		}
+
+		var name, modifier, word string
+		if s[i+1] == '{' {
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return "", xerrors.Errorf("unterminated variable in %s", s)
+			}
+			expr := s[i+2 : i+end]
+			i += end
+			name = expr
+			if idx := strings.Index(expr, ":"); idx >= 0 {
+				name, modifier = expr[:idx], expr[idx:]
+				if len(modifier) < 2 || (modifier[1] != '-' && modifier[1] != '+') {
+					return "", xerrors.Errorf("unsupported variable modifier in %s", s)
+				}
+				modifier, word = modifier[:2], modifier[2:]
+			}
+		} else {
+			end := i + 1
+			for end < len(s) && (s[end] == '_' || isAlphaNum(s[end])) {
+				end++
+			}
+			name = s[i+1 : end]
+			i = end - 1
+		}
+		if name == "" {
+			return "", xerrors.Errorf("empty variable name in %s", s)
+		}
+
+		value, ok := args[name]
+		set := ok && value != nil && *value != ""
+		switch {
+		case modifier == ":-" && !set:
+			res.WriteString(word)
+		case modifier == ":+" && set:
+			res.WriteString(word)
+		case modifier == ":+":
+		case !ok || value == nil:
+			return "", xerrors.Errorf("build arg %s has no default value", name)
+		default:
+			res.WriteString(*value)
+		}
+	}
+	return res.String(), nil
+}
+
+func isAlphaNum(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package dockerfile
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBaseImages(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Dockerfile    string
+		Expectation   []string
+		ExpectedError bool
+	}{
+		{
+			Name:        "single stage",
+			Dockerfile:  "FROM gitpod/workspace-full:latest\nRUN echo hello\n",
+			Expectation: []string{"gitpod/workspace-full:latest"},
+		},
+		{
+			Name: "multi stage",
+			Dockerfile: `# syntax=docker/dockerfile:1
+FROM --platform=linux/amd64 golang:1.19 AS Build
+RUN go build ./...
+
+from alpine:3.17 as runtime
+COPY --from=build /app /app
+
+FROM build AS test
+FROM runtime
+`,
+			Expectation: []string{"golang:1.19", "alpine:3.17"},
+		},
+		{
+			Name: "global args",
+			Dockerfile: `ARG REGISTRY=docker.io
+ARG BASE="gitpod/workspace-base" TAG
+FROM ${REGISTRY}/$BASE:${TAG:-latest}
+ARG STAGE_ARG=foo
+FROM ${REGISTRY}/library/alpine${DIGEST:+@sha256:foo}
+`,
+			Expectation: []string{"docker.io/gitpod/workspace-base:latest", "docker.io/library/alpine"},
+		},
+		{
+			Name:          "arg without default",
+			Dockerfile:    "ARG BASE\nFROM $BASE\n",
+			ExpectedError: true,
+		},
+		{
+			Name:          "stage arg",
+			Dockerfile:    "FROM alpine\nARG BASE=ubuntu\nFROM $BASE\n",
+			ExpectedError: true,
+		},
+		{
+			Name:        "continued lines and comments",
+			Dockerfile:  "FROM \\\n# the base image\n  ubuntu:22.04 \\\n\n  AS base\nFROM base\n",
+			Expectation: []string{"ubuntu:22.04"},
+		},
+		{
+			Name:        "escape directive",
+			Dockerfile:  "# escape=`\r\nFROM `\r\n  mcr.microsoft.com/windows/servercore\r\n",
+			Expectation: []string{"mcr.microsoft.com/windows/servercore"},
+		},
+		{
+			Name:       "scratch",
+			Dockerfile: "FROM scratch\nCOPY foo /\n",
+		},
+		{
+			Name:          "no from",
+			Dockerfile:    "RUN echo hello\n",
+			ExpectedError: true,
+		},
+		{
+			Name:          "empty",
+			ExpectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := BaseImages(test.Dockerfile)
+			if (err != nil) != test.ExpectedError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("BaseImages() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPinBaseImages(t *testing.T) {
+	const digest = "@sha256:7b3ccabffc97de872a30dfd234fd972a66d247c8cfc69b0550f276481852627c"
+	tests := []struct {
+		Name          string
+		Dockerfile    string
+		Pinned        map[string]string
+		Expectation   string
+		ExpectedError bool
+	}{
+		{
+			Name:        "single stage",
+			Dockerfile:  "FROM gitpod/workspace-full:latest\nRUN echo hello\n",
+			Pinned:      map[string]string{"gitpod/workspace-full:latest": "docker.io/gitpod/workspace-full" + digest},
+			Expectation: "FROM docker.io/gitpod/workspace-full" + digest + "\nRUN echo hello\n",
+		},
+		{
+			Name: "multi stage",
+			Dockerfile: `ARG BASE=golang:1.19
+FROM --platform=linux/amd64 \
+  ${BASE} AS build
+RUN go build ./...
+
+FROM alpine:3.17
+COPY --from=build /app /app
+`,
+			Pinned: map[string]string{
+				"golang:1.19": "docker.io/library/golang" + digest,
+				"alpine:3.17": "",
+			},
+			Expectation: `ARG BASE=golang:1.19
+FROM --platform=linux/amd64 docker.io/library/golang` + digest + ` AS build
+
+RUN go build ./...
+
+FROM alpine:3.17
+COPY --from=build /app /app
+`,
+		},
+		{
+			Name:          "image not pinned",
+			Dockerfile:    "FROM alpine AS base\nFROM ubuntu\nFROM base\n",
+			Pinned:        map[string]string{"alpine": "docker.io/library/alpine" + digest},
+			ExpectedError: true,
+		},
+		{
+			Name:          "no from",
+			Dockerfile:    "RUN echo hello\n",
+			ExpectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := PinBaseImages(test.Dockerfile, test.Pinned)
+			if (err != nil) != test.ExpectedError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("PinBaseImages() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
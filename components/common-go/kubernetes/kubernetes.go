@@ -11,6 +11,8 @@ package kubernetes
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"math"
 
 	"github.com/sirupsen/logrus"
@@ -75,8 +77,25 @@ const (
 
 	// workspacePressureStallInfo indicates if pressure stall information should be retrieved for the workspace
 	WorkspacePressureStallInfoAnnotation = "gitpod.io/psi"
+
+	// WorkspaceImageHashLabel contains the hash of the workspace image ref (see WorkspaceImageHash).
+	// ws-daemon uses it to publish which workspace images a node has cached.
+	WorkspaceImageHashLabel = "gitpod.io/workspaceImageHash"
+
+	// NodeImageHintLabelPrefix prefixes node labels which signal that a node has recently run a workspace image.
+	// The label name is the hash of the workspace image ref.
+	NodeImageHintLabelPrefix = "image.hints.gitpod.io/"
+
+	// NodeFreeDiskHintLabel contains the disk space in GiB a node has available for workspaces, rounded down to 10 GiB
+	NodeFreeDiskHintLabel = "hints.gitpod.io/freeDiskGiB"
 )
 
+// WorkspaceImageHash produces a hash of an image ref which is short enough to be used as label value and label name
+func WorkspaceImageHash(ref string) string {
+	h := sha256.Sum256([]byte(ref))
+	return hex.EncodeToString(h[:16])
+}
+
 // GetOWIFromObject finds the owner, workspace and instance information on a Kubernetes object using labels
 func GetOWIFromObject(pod *metav1.ObjectMeta) logrus.Fields {
 	owner := pod.Labels[OwnerLabel]
@@ -0,0 +1,69 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/classifier"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/prometheus/procfs"
+	"github.com/spf13/cobra"
+)
+
+// behaviourRecordCmd represents the behaviour record command
+var behaviourRecordCmd = &cobra.Command{
+	Use:   "record <pid> ...",
+	Short: "Records snapshots of running processes for replay",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			src = classifier.ProcfsSnapshotSource{}
+			res = make([]classifier.ProcessSnapshot, 0, len(args))
+		)
+		for _, pid := range args {
+			exe := filepath.Join(procfs.DefaultMountPoint, pid, "exe")
+			cmdline, err := procfsCmdline(pid)
+			if err != nil {
+				log.WithError(err).WithField("pid", pid).Fatal("cannot read commandline")
+			}
+			s, err := src.Snapshot(exe, cmdline, classifier.SnapshotAll)
+			if err != nil {
+				log.WithError(err).WithField("pid", pid).Fatal("cannot record snapshot")
+			}
+			res = append(res, *s)
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(res)
+		if err != nil {
+			log.WithError(err).Fatal("cannot encode snapshots")
+		}
+	},
+}
+
+func procfsCmdline(pid string) ([]string, error) {
+	id, err := strconv.Atoi(pid)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := procfs.NewFS(procfs.DefaultMountPoint)
+	if err != nil {
+		return nil, err
+	}
+	proc, err := fs.Proc(id)
+	if err != nil {
+		return nil, err
+	}
+	return proc.CmdLine()
+}
+
+func init() {
+	behaviourCmd.AddCommand(behaviourRecordCmd)
+}
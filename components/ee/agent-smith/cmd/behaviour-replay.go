@@ -0,0 +1,56 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/classifier"
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/config"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/spf13/cobra"
+)
+
+// behaviourReplayCmd represents the behaviour replay command
+var behaviourReplayCmd = &cobra.Command{
+	Use:   "replay <snapshots.json>",
+	Short: "Classifies recorded process snapshots using the configured blocklists",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.GetConfig(cfgFile)
+		if err != nil {
+			log.WithError(err).Fatal("cannot get config")
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			log.WithError(err).Fatal("cannot open snapshots")
+		}
+		defer f.Close()
+
+		var snapshots []classifier.ProcessSnapshot
+		err = json.NewDecoder(f).Decode(&snapshots)
+		if err != nil {
+			log.WithError(err).Fatal("cannot decode snapshots")
+		}
+
+		class, err := cfg.Blocklists.ClassifierWithSnapshots(classifier.NewReplaySnapshotSource(snapshots))
+		if err != nil {
+			log.WithError(err).Fatal("cannot create classifier")
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(classifier.Replay(class, snapshots))
+		if err != nil {
+			log.WithError(err).Fatal("cannot encode results")
+		}
+	},
+}
+
+func init() {
+	behaviourCmd.AddCommand(behaviourReplayCmd)
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// behaviourCmd represents the behaviour command
+var behaviourCmd = &cobra.Command{
+	Use:   "behaviour",
+	Short: "records process snapshots and replays them against behaviour rules",
+	Args:  cobra.MinimumNArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(behaviourCmd)
+}
@@ -66,6 +66,35 @@ var runCmd = &cobra.Command{
 			log.WithError(err).Fatal("cannot register metrics")
 		}
 
+		if cfg.ReportAddr != "" {
+			addr, err := cfg.ReportListenAddr()
+			if err != nil {
+				log.WithError(err).Fatal("cannot start classification report server")
+			}
+			var token string
+			if cfg.ReportTokenFile != "" {
+				b, err := os.ReadFile(cfg.ReportTokenFile)
+				if err != nil {
+					log.WithError(err).Fatal("cannot read classification report token")
+				}
+				token = strings.TrimSpace(string(b))
+				if token == "" {
+					log.Fatal("classification report token is empty")
+				}
+			}
+
+			handler := http.NewServeMux()
+			handler.Handle("/classifications", smith.ReportHandler(token))
+
+			go func() {
+				err := http.ListenAndServe(addr, handler)
+				if err != nil {
+					log.WithError(err).Error("classification report server failed")
+				}
+			}()
+			log.WithField("addr", addr).Info("started classification report server")
+		}
+
 		ctx := context.Background()
 		ctx, cancel := context.WithCancel(ctx)
 		defer cancel()
@@ -33,6 +33,13 @@ const (
 	notificationCacheSize = 1000
 )
 
+// notifiedInfringement identifies an infringement of a process which was acted on already
+type notifiedInfringement struct {
+	Process     uint64
+	Kind        config.GradedInfringementKind
+	Description string
+}
+
 // Smith can perform operations within a users workspace and judge a user
 type Smith struct {
 	Config           config.Config
@@ -46,6 +53,7 @@ type Smith struct {
 
 	detector   detector.ProcessDetector
 	classifier classifier.ProcessClassifier
+	reports    *reportStore
 }
 
 // NewAgentSmith creates a new agent smith
@@ -121,6 +129,7 @@ func NewAgentSmith(cfg config.Config) (*Smith, error) {
 
 		detector:   detec,
 		classifier: class,
+		reports:    newReportStore(),
 
 		notifiedInfringements: lru.New(notificationCacheSize),
 		metrics:               m,
@@ -138,6 +147,11 @@ func NewAgentSmith(cfg config.Config) (*Smith, error) {
 		}
 		res.EnforcementRules[repo] = rules
 	}
+	if cfg.Enforcement.Shadow != nil {
+		if err := cfg.Enforcement.Shadow.Validate(); err != nil {
+			return nil, err
+		}
+	}
 
 	return res, nil
 }
@@ -185,6 +199,20 @@ type Infringement struct {
 	Description string
 	Kind        config.GradedInfringementKind
 	CommandLine []string
+	// Shadow is true if the infringement was found by a shadow rule. Its penalties are only recorded.
+	Shadow bool
+}
+
+// partitionInfringements splits the infringements into those found by enforced rules and those found by shadow rules
+func (ws InfringingWorkspace) partitionInfringements() (enforced, shadow []Infringement) {
+	for _, v := range ws.Infringements {
+		if v.Shadow {
+			shadow = append(shadow, v)
+		} else {
+			enforced = append(enforced, v)
+		}
+	}
+	return
 }
 
 // defaultRuleset is the name ("remote origin URL") of the default enforcement rules
@@ -275,9 +303,10 @@ func (agent *Smith) Start(ctx context.Context, callback func(InfringingWorkspace
 				continue
 			}
 
-			_, _ = agent.Penalize(InfringingWorkspace{
+			ws := InfringingWorkspace{
 				SupervisorPID: proc.Workspace.PID,
 				Owner:         proc.Workspace.OwnerID,
+				WorkspaceID:   proc.Workspace.WorkspaceID,
 				InstanceID:    proc.Workspace.InstanceID,
 				GitRemoteURL:  []string{proc.Workspace.GitURL},
 				Infringements: []Infringement{
@@ -285,13 +314,56 @@ func (agent *Smith) Start(ctx context.Context, callback func(InfringingWorkspace
 						Kind:        config.GradeKind(config.InfringementExec, common.Severity(cl.Level)),
 						Description: fmt.Sprintf("%s: %s", cl.Classifier, cl.Message),
 						CommandLine: proc.CommandLine,
+						Shadow:      cl.Shadow,
 					},
 				},
-			})
+			}
+			if agent.alreadyNotified(proc, ws.Infringements[0]) {
+				// the detector hands us known processes again so that behaviour rules can catch up with them,
+				// but we must not act on the same infringement twice
+				continue
+			}
+			penalties, err := agent.Penalize(ws)
+
+			report := ClassificationReport{
+				Time:            time.Now(),
+				OwnerID:         proc.Workspace.OwnerID,
+				WorkspaceID:     proc.Workspace.WorkspaceID,
+				InstanceID:      proc.Workspace.InstanceID,
+				GitURL:          proc.Workspace.GitURL,
+				Executable:      proc.Path,
+				CommandLine:     proc.CommandLine,
+				Level:           cl.Level,
+				Classifier:      cl.Classifier,
+				Message:         cl.Message,
+				Shadow:          cl.Shadow,
+				Penalties:       penalties,
+				DryRun:          agent.Config.Enforcement.DryRun,
+				ShadowPenalties: agent.shadowPenalties(ws),
+			}
+			if err != nil {
+				report.Error = err.Error()
+			}
+			agent.reports.Add(report)
 		}
 	}
 }
 
+// alreadyNotified returns true if the infringement of the process was acted on already,
+// and remembers it otherwise
+func (agent *Smith) alreadyNotified(proc detector.Process, infr Infringement) bool {
+	key := notifiedInfringement{
+		Process:     proc.ID,
+		Kind:        infr.Kind,
+		Description: infr.Description,
+	}
+	if _, ok := agent.notifiedInfringements.Get(key); ok {
+		return true
+	}
+	agent.notifiedInfringements.Add(key, struct{}{})
+	return false
+}
+
 // Penalize acts on infringements and e.g. stops pods
 func (agent *Smith) Penalize(ws InfringingWorkspace) ([]config.PenaltyKind, error) {
 	var remoteURL string
@@ -301,7 +373,16 @@ func (agent *Smith) Penalize(ws InfringingWorkspace) ([]config.PenaltyKind, erro
 
 	owi := log.OWI(ws.Owner, ws.WorkspaceID, ws.InstanceID)
 
-	penalty := getPenalty(agent.EnforcementRules[defaultRuleset], agent.EnforcementRules[remoteURL], ws.Infringements)
+	enforced, _ := ws.partitionInfringements()
+	penalty := getPenalty(agent.EnforcementRules[defaultRuleset], agent.EnforcementRules[remoteURL], enforced)
+	if agent.Config.Enforcement.DryRun {
+		for _, p := range penalty {
+			log.WithField("infringement", ws.Infringements).WithFields(owi).WithField("penalty", p).Info("dry run - not applying penalty")
+			agent.metrics.penaltyRecorded.WithLabelValues(string(p), penaltyRecordedDryRun).Inc()
+		}
+		return penalty, nil
+	}
+
 	for _, p := range penalty {
 		switch p {
 		case config.PenaltyStopWorkspace:
@@ -337,6 +418,28 @@ func (agent *Smith) Penalize(ws InfringingWorkspace) ([]config.PenaltyKind, erro
 	return penalty, nil
 }
 
+// shadowPenalties returns the penalties which are only recorded for ws: those the shadow enforcement rules would apply
+// if there are any, and otherwise those the enforcement rules would apply to infringements found by shadow rules.
+func (agent *Smith) shadowPenalties(ws InfringingWorkspace) []config.PenaltyKind {
+	var penalty []config.PenaltyKind
+	if agent.Config.Enforcement.Shadow != nil {
+		penalty = getPenalty(*agent.Config.Enforcement.Shadow, nil, ws.Infringements)
+	} else {
+		var remoteURL string
+		if len(ws.GitRemoteURL) > 0 {
+			remoteURL = ws.GitRemoteURL[0]
+		}
+		_, shadow := ws.partitionInfringements()
+		penalty = getPenalty(agent.EnforcementRules[defaultRuleset], agent.EnforcementRules[remoteURL], shadow)
+	}
+
+	for _, p := range penalty {
+		log.WithField("infringement", ws.Infringements).WithFields(log.OWI(ws.Owner, ws.WorkspaceID, ws.InstanceID)).WithField("penalty", p).Info("shadow rules would apply penalty")
+		agent.metrics.penaltyRecorded.WithLabelValues(string(p), penaltyRecordedShadow).Inc()
+	}
+	return penalty
+}
+
 func findEnforcementRules(rules map[string]config.EnforcementRules, remoteURL string) config.EnforcementRules {
 	res, ok := rules[remoteURL]
 	if ok {
@@ -10,7 +10,9 @@ import (
 
 	"github.com/gitpod-io/gitpod/agent-smith/pkg/common"
 	"github.com/gitpod-io/gitpod/agent-smith/pkg/config"
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/detector"
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/lru"
 )
 
 func TestGetPenalty(t *testing.T) {
@@ -54,6 +56,80 @@ func TestGetPenalty(t *testing.T) {
 	}
 }
 
+func TestPenalizeDryRun(t *testing.T) {
+	audit := config.GradeKind(config.InfringementExec, common.SeverityAudit)
+	shadow := config.EnforcementRules{audit: config.PenaltyStopWorkspaceAndBlockUser}
+	agent := &Smith{
+		Config: config.Config{
+			Enforcement: config.Enforcement{DryRun: true, Shadow: &shadow},
+		},
+		// limiting CPU fails without Kubernetes, so we'd notice if the penalty was applied
+		EnforcementRules: map[string]config.EnforcementRules{
+			defaultRuleset: {audit: config.PenaltyLimitCPU},
+		},
+		metrics: newAgentMetrics(),
+	}
+	ws := InfringingWorkspace{Infringements: []Infringement{{Kind: audit}}}
+
+	penalties, err := agent.Penalize(ws)
+	if err != nil {
+		t.Fatalf("penalty was applied in dry-run mode: %v", err)
+	}
+	if diff := cmp.Diff([]config.PenaltyKind{config.PenaltyLimitCPU}, penalties); diff != "" {
+		t.Errorf("unexpected penalties (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]config.PenaltyKind{config.PenaltyStopWorkspaceAndBlockUser}, agent.shadowPenalties(ws)); diff != "" {
+		t.Errorf("unexpected shadow penalties (-want +got):\n%s", diff)
+	}
+}
+
+func TestPenalizeShadowInfringement(t *testing.T) {
+	audit := config.GradeKind(config.InfringementExec, common.SeverityAudit)
+	agent := &Smith{
+		// limiting CPU fails without Kubernetes, so we'd notice if the penalty was applied
+		EnforcementRules: map[string]config.EnforcementRules{
+			defaultRuleset: {audit: config.PenaltyLimitCPU},
+		},
+		metrics: newAgentMetrics(),
+	}
+	ws := InfringingWorkspace{Infringements: []Infringement{{Kind: audit, Shadow: true}}}
+
+	penalties, err := agent.Penalize(ws)
+	if err != nil {
+		t.Fatalf("penalty was applied for a shadow infringement: %v", err)
+	}
+	if len(penalties) > 0 {
+		t.Errorf("unexpected penalties for a shadow infringement: %v", penalties)
+	}
+	if diff := cmp.Diff([]config.PenaltyKind{config.PenaltyLimitCPU}, agent.shadowPenalties(ws)); diff != "" {
+		t.Errorf("unexpected shadow penalties (-want +got):\n%s", diff)
+	}
+}
+
+func TestAlreadyNotified(t *testing.T) {
+	audit := config.GradeKind(config.InfringementExec, common.SeverityAudit)
+	very := config.GradeKind(config.InfringementExec, common.SeverityVery)
+	agent := &Smith{notifiedInfringements: lru.New(notificationCacheSize)}
+
+	tests := []struct {
+		Desc        string
+		Process     uint64
+		Infr        Infringement
+		Expectation bool
+	}{
+		{"new infringement", 1, Infringement{Kind: audit, Description: "signature: miner"}, false},
+		{"re-classified process", 1, Infringement{Kind: audit, Description: "signature: miner"}, true},
+		{"different classification", 1, Infringement{Kind: very, Description: "behaviour: pool miner"}, false},
+		{"different process", 2, Infringement{Kind: audit, Description: "signature: miner"}, false},
+	}
+	for _, test := range tests {
+		act := agent.alreadyNotified(detector.Process{ID: test.Process}, test.Infr)
+		if act != test.Expectation {
+			t.Errorf("%s: alreadyNotified = %v, want %v", test.Desc, act, test.Expectation)
+		}
+	}
+}
+
 func TestFindEnforcementRules(t *testing.T) {
 	ra := config.EnforcementRules{config.GradeKind(config.InfringementExec, common.SeverityAudit): config.PenaltyLimitCPU}
 	tests := []struct {
@@ -11,9 +11,15 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+const (
+	penaltyRecordedDryRun = "dry_run"
+	penaltyRecordedShadow = "shadow"
+)
+
 type metrics struct {
 	penaltyAttempts                    *prometheus.CounterVec
 	penaltyFailures                    *prometheus.CounterVec
+	penaltyRecorded                    *prometheus.CounterVec
 	classificationBackpressureInCount  prometheus.GaugeFunc
 	classificationBackpressureOutCount prometheus.GaugeFunc
 	classificationBackpressureInDrop   prometheus.Counter
@@ -41,6 +47,14 @@ func newAgentMetrics() *metrics {
 			Help:      "The total amount of failed attempts that agent-smith is trying to apply a penalty.",
 		}, []string{"penalty", "reason"},
 	)
+	m.penaltyRecorded = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "gitpod",
+			Subsystem: "agent_smith",
+			Name:      "penalty_recorded_total",
+			Help:      "The total amount of penalties that agent-smith recorded instead of applying them, in dry-run mode or because of shadow rules.",
+		}, []string{"penalty", "mode"},
+	)
 	m.classificationBackpressureInDrop = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "gitpod",
 		Subsystem: "agent_smith",
@@ -50,6 +64,7 @@ func newAgentMetrics() *metrics {
 	m.cl = []prometheus.Collector{
 		m.penaltyAttempts,
 		m.penaltyFailures,
+		m.penaltyRecorded,
 		m.classificationBackpressureInDrop,
 	}
 	return m
@@ -0,0 +1,156 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package agent
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/classifier"
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/config"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	// reportWorkspaceCacheSize is the number of workspaces we keep classifications for
+	reportWorkspaceCacheSize = 1000
+	// reportsPerWorkspace is the number of classifications we keep per workspace
+	reportsPerWorkspace = 50
+)
+
+// ClassificationReport records a classification and the penalties it led to
+type ClassificationReport struct {
+	Time        time.Time `json:"time"`
+	OwnerID     string    `json:"ownerId"`
+	WorkspaceID string    `json:"workspaceId"`
+	InstanceID  string    `json:"instanceId"`
+	GitURL      string    `json:"gitURL,omitempty"`
+
+	Executable  string   `json:"executable"`
+	CommandLine []string `json:"commandLine,omitempty"`
+
+	Level      classifier.Level `json:"level"`
+	Classifier string           `json:"classifier"`
+	Message    string           `json:"message,omitempty"`
+	// Shadow is true if the process matched a shadow rule, hence its penalties are only listed in ShadowPenalties
+	Shadow bool `json:"shadow,omitempty"`
+
+	// Penalties are the penalties the enforcement rules call for
+	Penalties []config.PenaltyKind `json:"penalties,omitempty"`
+	// DryRun is true if the penalties were only recorded but not applied
+	DryRun bool `json:"dryRun,omitempty"`
+	// ShadowPenalties are the penalties the shadow rules, or the enforcement rules for a match of a shadow rule, would have applied
+	ShadowPenalties []config.PenaltyKind `json:"shadowPenalties,omitempty"`
+	// Error is set if applying the penalties failed
+	Error string `json:"error,omitempty"`
+}
+
+// WorkspaceReport lists the recent classifications of a workspace, oldest first
+type WorkspaceReport struct {
+	InstanceID      string                 `json:"instanceId"`
+	Classifications []ClassificationReport `json:"classifications"`
+}
+
+func newReportStore() *reportStore {
+	workspaces, _ := lru.New(reportWorkspaceCacheSize)
+	return &reportStore{
+		workspaces: workspaces,
+	}
+}
+
+// reportStore keeps the recent classifications of recently infringing workspaces.
+// Reading reports does not affect which workspaces are evicted first.
+type reportStore struct {
+	mu         sync.Mutex
+	workspaces *lru.Cache
+}
+
+func (s *reportStore) Add(r ClassificationReport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var reports []ClassificationReport
+	if v, ok := s.workspaces.Peek(r.InstanceID); ok {
+		reports = v.([]ClassificationReport)
+	}
+	reports = append(reports, r)
+	if len(reports) > reportsPerWorkspace {
+		reports = reports[len(reports)-reportsPerWorkspace:]
+	}
+	s.workspaces.Add(r.InstanceID, reports)
+}
+
+// Get returns the reports of a single workspace instance
+func (s *reportStore) Get(instanceID string) (res WorkspaceReport, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.workspaces.Peek(instanceID)
+	if !ok {
+		return WorkspaceReport{}, false
+	}
+	reports := v.([]ClassificationReport)
+	return WorkspaceReport{
+		InstanceID:      instanceID,
+		Classifications: append([]ClassificationReport(nil), reports...),
+	}, true
+}
+
+// List returns the reports of all workspace instances, most recently classified first
+func (s *reportStore) List() []WorkspaceReport {
+	keys := s.workspaces.Keys()
+
+	res := make([]WorkspaceReport, 0, len(keys))
+	for i := len(keys) - 1; i >= 0; i-- {
+		r, ok := s.Get(keys[i].(string))
+		if !ok {
+			continue
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+// ReportHandler serves the recent classifications per workspace as JSON.
+// Use the instanceId query parameter to get the classifications of a single workspace instance.
+// If token is not empty, requests must present it as bearer token.
+func (agent *Smith) ReportHandler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" {
+			presented := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var res interface{}
+		if instanceID := r.URL.Query().Get("instanceId"); instanceID != "" {
+			report, ok := agent.reports.Get(instanceID)
+			if !ok {
+				http.Error(w, "no classifications for "+instanceID, http.StatusNotFound)
+				return
+			}
+			res = report
+		} else {
+			res = agent.reports.List()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(res)
+		if err != nil {
+			log.WithError(err).Warn("cannot write classification report")
+		}
+	})
+}
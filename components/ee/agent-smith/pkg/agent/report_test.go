@@ -0,0 +1,136 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/classifier"
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/config"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReportStore(t *testing.T) {
+	store := newReportStore()
+	start := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < reportsPerWorkspace+5; i++ {
+		store.Add(ClassificationReport{Time: start.Add(time.Duration(i) * time.Second), InstanceID: "a", Message: fmt.Sprint(i)})
+	}
+	store.Add(ClassificationReport{Time: start, InstanceID: "b"})
+
+	a, ok := store.Get("a")
+	if !ok {
+		t.Fatal("expected reports for a")
+	}
+	if len(a.Classifications) != reportsPerWorkspace {
+		t.Errorf("expected %d classifications, got %d", reportsPerWorkspace, len(a.Classifications))
+	}
+	if a.Classifications[0].Message != "5" {
+		t.Errorf("expected the oldest classifications to be dropped, got %s first", a.Classifications[0].Message)
+	}
+
+	var ids []string
+	for _, r := range store.List() {
+		ids = append(ids, r.InstanceID)
+	}
+	if diff := cmp.Diff([]string{"b", "a"}, ids); diff != "" {
+		t.Errorf("unexpected workspace order (-want +got):\n%s", diff)
+	}
+}
+
+func TestReportHandler(t *testing.T) {
+	agent := &Smith{reports: newReportStore()}
+	report := ClassificationReport{
+		Time:        time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		InstanceID:  "instance",
+		Executable:  "proc/42/exe",
+		CommandLine: []string{"xmrig"},
+		Level:       classifier.LevelVery,
+		Classifier:  "graded.composite.commandline",
+		Message:     `matched "xmrig"`,
+		Penalties:   []config.PenaltyKind{config.PenaltyStopWorkspaceAndBlockUser},
+		DryRun:      true,
+	}
+	agent.reports.Add(report)
+
+	tests := []struct {
+		Desc         string
+		Query        string
+		StatusCode   int
+		Expectation  interface{}
+		Unmarshalled func() interface{}
+	}{
+		{
+			Desc:         "all workspaces",
+			StatusCode:   http.StatusOK,
+			Expectation:  &[]WorkspaceReport{{InstanceID: "instance", Classifications: []ClassificationReport{report}}},
+			Unmarshalled: func() interface{} { return &[]WorkspaceReport{} },
+		},
+		{
+			Desc:         "single workspace",
+			Query:        "?instanceId=instance",
+			StatusCode:   http.StatusOK,
+			Expectation:  &WorkspaceReport{InstanceID: "instance", Classifications: []ClassificationReport{report}},
+			Unmarshalled: func() interface{} { return &WorkspaceReport{} },
+		},
+		{
+			Desc:       "unknown workspace",
+			Query:      "?instanceId=unknown",
+			StatusCode: http.StatusNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			agent.ReportHandler("").ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/classifications"+test.Query, nil))
+			if rec.Code != test.StatusCode {
+				t.Fatalf("unexpected status code %d", rec.Code)
+			}
+			if test.Unmarshalled == nil {
+				return
+			}
+
+			act := test.Unmarshalled()
+			err := json.Unmarshal(rec.Body.Bytes(), act)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected report (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReportHandlerToken(t *testing.T) {
+	agent := &Smith{reports: newReportStore()}
+	tests := []struct {
+		Desc          string
+		Authorization string
+		StatusCode    int
+	}{
+		{Desc: "no token", StatusCode: http.StatusUnauthorized},
+		{Desc: "wrong token", Authorization: "Bearer wrong", StatusCode: http.StatusUnauthorized},
+		{Desc: "valid token", Authorization: "Bearer secret", StatusCode: http.StatusOK},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/classifications", nil)
+			if test.Authorization != "" {
+				req.Header.Set("Authorization", test.Authorization)
+			}
+			rec := httptest.NewRecorder()
+			agent.ReportHandler("secret").ServeHTTP(rec, req)
+			if rec.Code != test.StatusCode {
+				t.Errorf("unexpected status code %d, expected %d", rec.Code, test.StatusCode)
+			}
+		})
+	}
+}
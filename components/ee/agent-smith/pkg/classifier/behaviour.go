@@ -0,0 +1,238 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package classifier
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+const ClassifierBehaviour string = "behaviour"
+
+// BehaviourRule matches processes based on what they do rather than what they are called.
+// All conditions set on a rule must hold for the rule to match.
+type BehaviourRule struct {
+	// Name is a description of the rule
+	Name string `json:"name"`
+
+	// MinCPU is the minimum average CPU utilisation since the process was last classified, or since
+	// process start when it is classified for the first time, where 1 is one fully used core
+	MinCPU float64 `json:"minCPU,omitempty"`
+
+	// PoolPorts matches processes with an outbound TCP connection to any of these remote ports
+	PoolPorts []int `json:"poolPorts,omitempty"`
+
+	// SHA256 matches processes whose executable has any of these hex encoded hashes
+	SHA256 []string `json:"sha256,omitempty"`
+
+	// Shadow rules are matched, but the penalties for their matches are only recorded.
+	// This way new rules can be reviewed before they are enforced.
+	Shadow bool `json:"shadow,omitempty"`
+}
+
+// Validate ensures the rule is valid and thus a process can be matched against it
+func (r *BehaviourRule) Validate() error {
+	if r.Name == "" {
+		return xerrors.Errorf("behaviour rule has no name")
+	}
+	if r.MinCPU == 0 && len(r.PoolPorts) == 0 && len(r.SHA256) == 0 {
+		return xerrors.Errorf("behaviour rule %s has no conditions", r.Name)
+	}
+	if r.MinCPU < 0 {
+		return xerrors.Errorf("behaviour rule %s: minCPU must be positive", r.Name)
+	}
+	for _, p := range r.PoolPorts {
+		if p <= 0 || p > 65535 {
+			return xerrors.Errorf("behaviour rule %s: invalid pool port %d", r.Name, p)
+		}
+	}
+	for i, h := range r.SHA256 {
+		b, err := hex.DecodeString(h)
+		if err != nil || len(b) != 32 {
+			return xerrors.Errorf("behaviour rule %s: invalid sha256 %s", r.Name, h)
+		}
+		r.SHA256[i] = strings.ToLower(h)
+	}
+	return nil
+}
+
+// fields returns the snapshot fields this rule needs
+func (r *BehaviourRule) fields() SnapshotField {
+	var res SnapshotField
+	if r.MinCPU > 0 {
+		res |= SnapshotCPU
+	}
+	if len(r.PoolPorts) > 0 {
+		res |= SnapshotConnections
+	}
+	if len(r.SHA256) > 0 {
+		res |= SnapshotSHA256
+	}
+	return res
+}
+
+// Matches checks if the process behaves as described by the rule. If it does, the evidence lists why.
+func (r *BehaviourRule) Matches(snapshot *ProcessSnapshot) (match bool, evidence []string) {
+	if r.MinCPU > 0 {
+		if snapshot.CPU < r.MinCPU {
+			return false, nil
+		}
+		evidence = append(evidence, fmt.Sprintf("cpu %.2f", snapshot.CPU))
+	}
+	if len(r.PoolPorts) > 0 {
+		port, ok := firstCommonPort(r.PoolPorts, snapshot.RemotePorts)
+		if !ok {
+			return false, nil
+		}
+		evidence = append(evidence, fmt.Sprintf("connected to port %d", port))
+	}
+	if len(r.SHA256) > 0 {
+		var found bool
+		for _, h := range r.SHA256 {
+			if h == snapshot.SHA256 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+		evidence = append(evidence, "sha256 "+snapshot.SHA256)
+	}
+	return true, evidence
+}
+
+func firstCommonPort(ports, remotePorts []int) (int, bool) {
+	for _, rp := range remotePorts {
+		for _, p := range ports {
+			if rp == p {
+				return p, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func NewBehaviourClassifier(name string, defaultLevel Level, rules []*BehaviourRule, src SnapshotSource) (*BehaviourClassifier, error) {
+	var fields SnapshotField
+	for _, r := range rules {
+		err := r.Validate()
+		if err != nil {
+			return nil, err
+		}
+		fields |= r.fields()
+	}
+
+	return &BehaviourClassifier{
+		Rules:        rules,
+		DefaultLevel: defaultLevel,
+		Source:       src,
+		fields:       fields,
+		processMissTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gitpod_agent_smith",
+			Subsystem: "classifier_behaviour",
+			Name:      "process_miss_total",
+			Help:      "total count of processes which could not be inspected",
+			ConstLabels: prometheus.Labels{
+				"classifier_name": name,
+			},
+		}, []string{"reason"}),
+		ruleHitTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gitpod_agent_smith",
+			Subsystem: "classifier_behaviour",
+			Name:      "rule_hit_total",
+			Help:      "total count of behaviour rule hits",
+			ConstLabels: prometheus.Labels{
+				"classifier_name": name,
+			},
+		}, []string{"rule"}),
+	}, nil
+}
+
+// BehaviourClassifier matches processes against behaviour rules, using snapshots of the process taken from Source.
+// The first matching rule wins, unless it is a shadow rule and a later rule which is not matches too.
+type BehaviourClassifier struct {
+	Rules        []*BehaviourRule
+	DefaultLevel Level
+	Source       SnapshotSource
+
+	fields           SnapshotField
+	processMissTotal *prometheus.CounterVec
+	ruleHitTotal     *prometheus.CounterVec
+}
+
+var _ ProcessClassifier = &BehaviourClassifier{}
+
+var behNoMatch = &Classification{Level: LevelNoMatch, Classifier: ClassifierBehaviour}
+
+func (cl *BehaviourClassifier) Matches(executable string, cmdline []string) (*Classification, error) {
+	if len(cl.Rules) == 0 {
+		return behNoMatch, nil
+	}
+
+	snapshot, err := cl.Source.Snapshot(executable, cmdline, cl.fields)
+	if err != nil {
+		var reason string
+		if errors.Is(err, fs.ErrNotExist) {
+			reason = processMissNotFound
+		} else if errors.Is(err, os.ErrPermission) {
+			reason = processMissPermissionDenied
+		} else {
+			reason = processMissOther
+		}
+		cl.processMissTotal.WithLabelValues(reason).Inc()
+		log.WithFields(logrus.Fields{
+			"executable": executable,
+			"cmdline":    cmdline,
+			"reason":     reason,
+		}).WithError(err).Debug("behaviour classification miss")
+		return behNoMatch, nil
+	}
+
+	var shadow *Classification
+	for _, r := range cl.Rules {
+		match, evidence := r.Matches(snapshot)
+		if !match {
+			continue
+		}
+		cl.ruleHitTotal.WithLabelValues(r.Name).Inc()
+		c := &Classification{
+			Level:      cl.DefaultLevel,
+			Classifier: ClassifierBehaviour,
+			Message:    fmt.Sprintf("behaves like %s (%s)", r.Name, strings.Join(evidence, ", ")),
+			Shadow:     r.Shadow,
+		}
+		if !c.Shadow {
+			return c, nil
+		}
+		if shadow == nil {
+			shadow = c
+		}
+	}
+	if shadow != nil {
+		return shadow, nil
+	}
+
+	return behNoMatch, nil
+}
+
+func (cl *BehaviourClassifier) Describe(d chan<- *prometheus.Desc) {
+	cl.processMissTotal.Describe(d)
+	cl.ruleHitTotal.Describe(d)
+}
+
+func (cl *BehaviourClassifier) Collect(m chan<- prometheus.Metric) {
+	cl.processMissTotal.Collect(m)
+	cl.ruleHitTotal.Collect(m)
+}
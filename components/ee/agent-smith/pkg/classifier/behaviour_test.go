@@ -0,0 +1,165 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package classifier_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/agent-smith/pkg/classifier"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBehaviourRuleValidate(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Rule  classifier.BehaviourRule
+		Valid bool
+	}{
+		{Name: "no name", Rule: classifier.BehaviourRule{MinCPU: 1}},
+		{Name: "no conditions", Rule: classifier.BehaviourRule{Name: "empty"}},
+		{Name: "negative cpu", Rule: classifier.BehaviourRule{Name: "cpu", MinCPU: -1}},
+		{Name: "invalid port", Rule: classifier.BehaviourRule{Name: "port", PoolPorts: []int{70000}}},
+		{Name: "invalid hash", Rule: classifier.BehaviourRule{Name: "hash", SHA256: []string{"abc"}}},
+		{Name: "valid", Rule: classifier.BehaviourRule{Name: "miner", MinCPU: 0.9, PoolPorts: []int{3333}}, Valid: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := test.Rule.Validate()
+			if test.Valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !test.Valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestBehaviourReplay(t *testing.T) {
+	fc, err := os.ReadFile("testdata/snapshots.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var snapshots []classifier.ProcessSnapshot
+	err = json.Unmarshal(fc, &snapshots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := classifier.NewReplaySnapshotSource(snapshots)
+
+	very, err := classifier.NewBehaviourClassifier("very", classifier.LevelVery, []*classifier.BehaviourRule{
+		{Name: "pool miner", MinCPU: 0.9, PoolPorts: []int{3333, 4444}},
+		{Name: "known miner", SHA256: []string{"9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08"}},
+	}, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	audit, err := classifier.NewBehaviourClassifier("audit", classifier.LevelAudit, []*classifier.BehaviourRule{
+		{Name: "busy", MinCPU: 3},
+	}, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	class := classifier.GradedClassifier{
+		classifier.LevelVery:  very,
+		classifier.LevelAudit: audit,
+	}
+
+	var act []*classifier.Classification
+	for _, r := range classifier.Replay(class, snapshots) {
+		if r.Error != "" {
+			t.Fatalf("cannot classify %s: %s", r.Snapshot.Executable, r.Error)
+		}
+		act = append(act, r.Classification)
+	}
+
+	graded := classifier.ClassifierGraded + "." + classifier.ClassifierBehaviour
+	expectation := []*classifier.Classification{
+		{Level: classifier.LevelVery, Classifier: graded, Message: "behaves like pool miner (cpu 3.90, connected to port 3333)"},
+		{Level: classifier.LevelNoMatch, Classifier: classifier.ClassifierGraded},
+		{Level: classifier.LevelAudit, Classifier: graded, Message: "behaves like busy (cpu 3.50)"},
+		{Level: classifier.LevelVery, Classifier: graded, Message: "behaves like known miner (sha256 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08)"},
+	}
+	if diff := cmp.Diff(expectation, act); diff != "" {
+		t.Errorf("unexpected classification (-want +got):\n%s", diff)
+	}
+}
+
+func TestBehaviourClassifierMissingProcess(t *testing.T) {
+	class, err := classifier.NewBehaviourClassifier("test", classifier.LevelAudit, []*classifier.BehaviourRule{
+		{Name: "busy", MinCPU: 1},
+	}, classifier.NewReplaySnapshotSource(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	act, err := class.Matches("proc/1/exe", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&classifier.Classification{Level: classifier.LevelNoMatch, Classifier: classifier.ClassifierBehaviour}, act); diff != "" {
+		t.Errorf("unexpected classification (-want +got):\n%s", diff)
+	}
+}
+
+func TestBehaviourClassifierShadow(t *testing.T) {
+	snapshots := []classifier.ProcessSnapshot{
+		{Executable: "/proc/1/exe", CPU: 2, RemotePorts: []int{3333}},
+		{Executable: "/proc/2/exe", CPU: 2},
+	}
+	veryBusy, err := classifier.NewBehaviourClassifier("very", classifier.LevelVery, []*classifier.BehaviourRule{
+		{Name: "busy", MinCPU: 1, Shadow: true},
+	}, classifier.NewReplaySnapshotSource(snapshots))
+	if err != nil {
+		t.Fatal(err)
+	}
+	audit, err := classifier.NewBehaviourClassifier("audit", classifier.LevelAudit, []*classifier.BehaviourRule{
+		{Name: "miner", PoolPorts: []int{3333}, Shadow: true},
+		{Name: "busy miner", MinCPU: 1, PoolPorts: []int{3333}},
+	}, classifier.NewReplaySnapshotSource(snapshots))
+	if err != nil {
+		t.Fatal(err)
+	}
+	class := classifier.GradedClassifier{
+		classifier.LevelVery:  veryBusy,
+		classifier.LevelAudit: audit,
+	}
+
+	tests := []struct {
+		Executable  string
+		Expectation *classifier.Classification
+	}{
+		{
+			Executable: "/proc/1/exe",
+			Expectation: &classifier.Classification{
+				Level:      classifier.LevelAudit,
+				Classifier: classifier.ClassifierGraded + "." + classifier.ClassifierBehaviour,
+				Message:    "behaves like busy miner (cpu 2.00, connected to port 3333)",
+			},
+		},
+		{
+			Executable: "/proc/2/exe",
+			Expectation: &classifier.Classification{
+				Level:      classifier.LevelVery,
+				Classifier: classifier.ClassifierGraded + "." + classifier.ClassifierBehaviour,
+				Message:    "behaves like busy (cpu 2.00)",
+				Shadow:     true,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Executable, func(t *testing.T) {
+			act, err := class.Matches(test.Executable, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected classification (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
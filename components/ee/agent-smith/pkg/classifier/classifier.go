@@ -30,6 +30,8 @@ type Classification struct {
 	Level      Level
 	Classifier string
 	Message    string
+	// Shadow is true if the process matched a shadow rule only. Penalties for such matches are recorded but not applied.
+	Shadow bool
 }
 
 type Level string
@@ -202,20 +204,32 @@ func (sigcl *SignatureMatchClassifier) Matches(executable string, cmdline []stri
 	src := SignatureReadCache{
 		Reader: r,
 	}
+	var shadow *Classification
 	for _, sig := range sigcl.Signatures {
 		match, err := sig.Matches(&src)
 		if match {
 			sigcl.signatureHitTotal.Inc()
-			return &Classification{
+			c := &Classification{
 				Level:      sigcl.DefaultLevel,
 				Classifier: ClassifierSignature,
 				Message:    fmt.Sprintf("matches %s", sig.Name),
-			}, nil
+				Shadow:     sig.Shadow,
+			}
+			if !c.Shadow {
+				return c, nil
+			}
+			if shadow == nil {
+				shadow = c
+			}
+			continue
 		}
 		if err != nil {
 			serr = err
 		}
 	}
+	if shadow != nil {
+		return shadow, nil
+	}
 	if serr != nil {
 		return nil, err
 	}
@@ -240,7 +254,8 @@ func (sigcl *SignatureMatchClassifier) Collect(m chan<- prometheus.Metric) {
 	sigcl.signatureHitTotal.Collect(m)
 }
 
-// CompositeClassifier combines multiple classifiers into one. The first match wins.
+// CompositeClassifier combines multiple classifiers into one. The first match wins, unless it is a shadow match
+// and a later classifier has a match which is not.
 type CompositeClassifier []ProcessClassifier
 
 var _ ProcessClassifier = CompositeClassifier{}
@@ -249,12 +264,20 @@ var cmpNoMatch = &Classification{Level: LevelNoMatch, Classifier: ClassifierComp
 
 func (cl CompositeClassifier) Matches(executable string, cmdline []string) (*Classification, error) {
 	var (
-		c   *Classification
-		err error
+		c      *Classification
+		shadow *Classification
+		err    error
 	)
 	for _, class := range cl {
 		var cerr error
 		c, cerr = class.Matches(executable, cmdline)
+		if c != nil && c.Level != LevelNoMatch && c.Shadow {
+			// keep looking for a match which is enforced
+			if shadow == nil {
+				shadow = c
+			}
+			continue
+		}
 		if c != nil && c.Level != LevelNoMatch {
 			// we've found a match - ignore previous errors
 			err = nil
@@ -264,6 +287,9 @@ func (cl CompositeClassifier) Matches(executable string, cmdline []string) (*Cla
 			err = cerr
 		}
 	}
+	if shadow != nil && (c == nil || c.Level == LevelNoMatch || c.Shadow) {
+		c, err = shadow, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -301,7 +327,8 @@ func (cl CompositeClassifier) Collect(m chan<- prometheus.Metric) {
 	}
 }
 
-// GradedClassifier classifies processes based on a grading, in the order of "very", "barely", "audit"
+// GradedClassifier classifies processes based on a grading, in the order of "very", "barely", "audit".
+// Shadow matches only count if no level has a match which is enforced.
 type GradedClassifier map[Level]ProcessClassifier
 
 var _ ProcessClassifier = GradedClassifier{}
@@ -312,8 +339,9 @@ func (cl GradedClassifier) Matches(executable string, cmdline []string) (*Classi
 	order := []Level{LevelVery, LevelBarely, LevelAudit}
 
 	var (
-		c   *Classification
-		err error
+		c      *Classification
+		shadow *Classification
+		err    error
 	)
 	for _, lvl := range order {
 		class, ok := cl[lvl]
@@ -323,6 +351,13 @@ func (cl GradedClassifier) Matches(executable string, cmdline []string) (*Classi
 
 		var cerr error
 		c, cerr = class.Matches(executable, cmdline)
+		if c != nil && c.Level != LevelNoMatch && c.Shadow {
+			// keep looking for a match which is enforced
+			if shadow == nil {
+				shadow = c
+			}
+			continue
+		}
 		if c != nil && c.Level != LevelNoMatch {
 			// we've found a match - ignore previous errors
 			err = nil
@@ -332,6 +367,9 @@ func (cl GradedClassifier) Matches(executable string, cmdline []string) (*Classi
 			err = cerr
 		}
 	}
+	if shadow != nil && (c == nil || c.Level == LevelNoMatch || c.Shadow) {
+		c, err = shadow, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +62,10 @@ type Signature struct {
 	// Filenames is a list of filenames this signature can match to
 	Filename []string `json:"filenames,omitempty"`
 
+	// Shadow signatures are matched, but the penalties for their matches are only recorded.
+	// This way new signatures can be reviewed before they are enforced.
+	Shadow bool `json:"shadow,omitempty"`
+
 	// compiledRegexp is an optimization so that we don't have to re-compile the regexp every time we use it
 	compiledRegexp *regexp.Regexp
 }
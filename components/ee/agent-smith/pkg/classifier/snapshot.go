@@ -0,0 +1,349 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package classifier
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/procfs"
+	"golang.org/x/xerrors"
+)
+
+// ProcessSnapshot describes the behaviour of a process at one point in time
+type ProcessSnapshot struct {
+	Executable string   `json:"executable"`
+	Cmdline    []string `json:"cmdline,omitempty"`
+
+	// CPU is the average CPU utilisation since the previous snapshot of the process, or since process start
+	// if there is none, where 1 is one fully used core
+	CPU float64 `json:"cpu,omitempty"`
+
+	// RemotePorts are the remote ports of the outbound TCP connections of the process
+	RemotePorts []int `json:"remotePorts,omitempty"`
+
+	// SHA256 is the hex encoded hash of the executable
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// SnapshotField selects the parts of a snapshot a classifier needs
+type SnapshotField int
+
+const (
+	SnapshotCPU SnapshotField = 1 << iota
+	SnapshotConnections
+	SnapshotSHA256
+
+	SnapshotAll = SnapshotCPU | SnapshotConnections | SnapshotSHA256
+)
+
+// SnapshotSource takes snapshots of processes
+type SnapshotSource interface {
+	// Snapshot returns a snapshot of the process with the given executable, with at least fields filled in
+	Snapshot(executable string, cmdline []string, fields SnapshotField) (*ProcessSnapshot, error)
+}
+
+// ProcfsSnapshotSource takes snapshots of live processes. It expects the executable
+// to be the exe link of a process in procfs, i.e. <procfs>/<pid>/exe, which is what the procfs detector produces.
+// The zero value neither remembers CPU samples nor caches executable hashes.
+type ProcfsSnapshotSource struct {
+	nowFunc func() time.Time
+
+	// cpuSamples holds the last CPU sample of each process, so that a process which is classified again
+	// reports its CPU utilisation since then rather than since it started
+	cpuSamples *lru.Cache
+
+	// hashes holds the hashes of executables by device, inode, size and modification time
+	hashes *lru.Cache
+}
+
+var _ SnapshotSource = &ProcfsSnapshotSource{}
+
+// snapshotCacheSize is the number of CPU samples and executable hashes a ProcfsSnapshotSource keeps
+const snapshotCacheSize = 2000
+
+func NewProcfsSnapshotSource() (*ProcfsSnapshotSource, error) {
+	cpuSamples, err := lru.New(snapshotCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	hashes, err := lru.New(snapshotCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &ProcfsSnapshotSource{
+		cpuSamples: cpuSamples,
+		hashes:     hashes,
+	}, nil
+}
+
+const (
+	tcpEstablished = 1
+	tcpSynSent     = 2
+)
+
+func (src *ProcfsSnapshotSource) Snapshot(executable string, cmdline []string, fields SnapshotField) (*ProcessSnapshot, error) {
+	pidDir := filepath.Dir(executable)
+	pid, err := strconv.Atoi(filepath.Base(pidDir))
+	if err != nil {
+		return nil, xerrors.Errorf("%s is not the executable of a process in procfs", executable)
+	}
+	pfs, err := procfs.NewFS(filepath.Dir(pidDir))
+	if err != nil {
+		return nil, err
+	}
+	proc, err := pfs.Proc(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ProcessSnapshot{
+		Executable: executable,
+		Cmdline:    cmdline,
+	}
+	if fields&SnapshotCPU != 0 {
+		res.CPU, err = src.cpu(proc)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot get CPU usage of %d: %w", pid, err)
+		}
+	}
+	if fields&SnapshotConnections != 0 {
+		res.RemotePorts, err = remotePorts(proc, pidDir)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot get connections of %d: %w", pid, err)
+		}
+	}
+	if fields&SnapshotSHA256 != 0 {
+		res.SHA256, err = src.hash(executable)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot hash executable of %d: %w", pid, err)
+		}
+	}
+	return res, nil
+}
+
+type cpuSampleKey struct {
+	PID       int
+	Starttime uint64
+}
+
+type cpuSample struct {
+	// CPUTime is the CPU time the process has used in seconds
+	CPUTime float64
+	// At is the time of the sample in seconds since the epoch
+	At float64
+}
+
+func (src *ProcfsSnapshotSource) cpu(proc procfs.Proc) (float64, error) {
+	stat, err := proc.Stat()
+	if err != nil {
+		return 0, err
+	}
+	start, err := stat.StartTime()
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now
+	if src.nowFunc != nil {
+		now = src.nowFunc
+	}
+	sample := cpuSample{
+		CPUTime: stat.CPUTime(),
+		At:      float64(now().UnixNano()) / float64(time.Second),
+	}
+
+	prev := cpuSample{At: start}
+	if src.cpuSamples != nil {
+		// PIDs are reused, the start time tells processes apart
+		key := cpuSampleKey{PID: proc.PID, Starttime: stat.Starttime}
+		if s, ok := src.cpuSamples.Get(key); ok {
+			prev = s.(cpuSample)
+		}
+		src.cpuSamples.Add(key, sample)
+	}
+
+	window := sample.At - prev.At
+	if window <= 0 {
+		return 0, nil
+	}
+	return (sample.CPUTime - prev.CPUTime) / window, nil
+}
+
+// remotePorts finds the outbound TCP connections of proc by matching its socket file descriptors
+// against the connections listed in the network namespace of the process.
+func remotePorts(proc procfs.Proc, pidDir string) ([]int, error) {
+	targets, err := proc.FileDescriptorTargets()
+	if err != nil {
+		return nil, err
+	}
+	inodes := make(map[uint64]struct{})
+	for _, t := range targets {
+		if !strings.HasPrefix(t, "socket:[") {
+			continue
+		}
+		inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(t, "socket:["), "]"), 10, 64)
+		if err != nil {
+			continue
+		}
+		inodes[inode] = struct{}{}
+	}
+	if len(inodes) == 0 {
+		return nil, nil
+	}
+
+	netfs, err := procfs.NewFS(pidDir)
+	if err != nil {
+		return nil, err
+	}
+	conns, err := netfs.NetTCP()
+	if err != nil {
+		return nil, err
+	}
+	conns6, err := netfs.NetTCP6()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	conns = append(conns, conns6...)
+
+	var (
+		res  []int
+		seen = make(map[int]struct{})
+	)
+	for _, c := range conns {
+		if c.St != tcpEstablished && c.St != tcpSynSent {
+			continue
+		}
+		if _, ok := inodes[c.Inode]; !ok {
+			continue
+		}
+		port := int(c.RemPort)
+		if _, ok := seen[port]; ok {
+			continue
+		}
+		seen[port] = struct{}{}
+		res = append(res, port)
+	}
+	sort.Ints(res)
+	return res, nil
+}
+
+type fileKey struct {
+	Dev     uint64
+	Ino     uint64
+	Size    int64
+	ModTime int64
+}
+
+func statFileKey(fn string) (fileKey, bool) {
+	fi, err := os.Stat(fn)
+	if err != nil {
+		return fileKey{}, false
+	}
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileKey{}, false
+	}
+	return fileKey{
+		Dev:     uint64(st.Dev),
+		Ino:     st.Ino,
+		Size:    fi.Size(),
+		ModTime: fi.ModTime().UnixNano(),
+	}, true
+}
+
+// hash returns the hash of the executable, hashing every file only once
+func (src *ProcfsSnapshotSource) hash(fn string) (string, error) {
+	if src.hashes == nil {
+		return hashFile(fn)
+	}
+
+	key, ok := statFileKey(fn)
+	if !ok {
+		return hashFile(fn)
+	}
+	if h, ok := src.hashes.Get(key); ok {
+		return h.(string), nil
+	}
+
+	h, err := hashFile(fn)
+	if err != nil {
+		return "", err
+	}
+	// don't cache the hash if the file changed while we were hashing it
+	if after, ok := statFileKey(fn); ok && after == key {
+		src.hashes.Add(key, h)
+	}
+	return h, nil
+}
+
+func hashFile(fn string) (string, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ReplaySnapshotSource serves recorded snapshots, keyed by their executable
+type ReplaySnapshotSource map[string]*ProcessSnapshot
+
+var _ SnapshotSource = ReplaySnapshotSource{}
+
+func NewReplaySnapshotSource(snapshots []ProcessSnapshot) ReplaySnapshotSource {
+	res := make(ReplaySnapshotSource, len(snapshots))
+	for i := range snapshots {
+		res[snapshots[i].Executable] = &snapshots[i]
+	}
+	return res
+}
+
+func (src ReplaySnapshotSource) Snapshot(executable string, cmdline []string, fields SnapshotField) (*ProcessSnapshot, error) {
+	s, ok := src[executable]
+	if !ok {
+		return nil, xerrors.Errorf("no snapshot recorded for %s: %w", executable, fs.ErrNotExist)
+	}
+	return s, nil
+}
+
+// ReplayResult is the classification of a recorded snapshot
+type ReplayResult struct {
+	Snapshot       ProcessSnapshot `json:"snapshot"`
+	Classification *Classification `json:"classification,omitempty"`
+	Error          string          `json:"error,omitempty"`
+}
+
+// Replay classifies recorded snapshots. Behaviour rules in cl are expected to take their snapshots
+// from a ReplaySnapshotSource serving the same snapshots.
+func Replay(cl ProcessClassifier, snapshots []ProcessSnapshot) []ReplayResult {
+	res := make([]ReplayResult, 0, len(snapshots))
+	for _, s := range snapshots {
+		c, err := cl.Matches(s.Executable, s.Cmdline)
+		r := ReplayResult{Snapshot: s, Classification: c}
+		if err != nil {
+			r.Error = err.Error()
+		}
+		res = append(res, r)
+	}
+	return res
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package classifier
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProcfsSnapshotSource(t *testing.T) {
+	root := t.TempDir()
+	pidDir := filepath.Join(root, "42")
+	for _, d := range []string{filepath.Join(pidDir, "fd"), filepath.Join(pidDir, "net")} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// utime=3000 and stime=1000 ticks, started 100 ticks after boot
+	statFields := make([]string, 52)
+	for i := range statFields {
+		statFields[i] = "0"
+	}
+	statFields[0], statFields[1], statFields[2], statFields[3] = "42", "(kworker)", "R", "1"
+	statFields[13], statFields[14], statFields[21] = "3000", "1000", "100"
+
+	files := map[string]string{
+		filepath.Join(root, "stat"):   "cpu  0 0 0 0 0 0 0 0 0 0\nbtime 1000\n",
+		filepath.Join(pidDir, "stat"): strings.Join(statFields, " ") + "\n",
+		filepath.Join(pidDir, "exe"):  "test",
+		filepath.Join(pidDir, "net", "tcp"): strings.Join([]string{
+			"  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode",
+			// established connection to port 3333 owned by the process
+			"   0: 0100007F:A1B2 0A000001:0D05 01 00000000:00000000 00:00000000 00000000  1000        0 555 1 0000000000000000 20 4 30 10 -1",
+			// listening socket owned by the process
+			"   1: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 556 1 0000000000000000 20 4 30 10 -1",
+			// connection to port 4444 owned by another process
+			"   2: 0100007F:A1B3 0A000001:115C 01 00000000:00000000 00:00000000 00000000  1000        0 999 1 0000000000000000 20 4 30 10 -1",
+		}, "\n") + "\n",
+	}
+	for fn, content := range files {
+		if err := os.WriteFile(fn, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for fd, target := range map[string]string{"3": "socket:[555]", "4": "socket:[556]", "5": "/dev/null"} {
+		if err := os.Symlink(target, filepath.Join(pidDir, "fd", fd)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	src, err := NewProcfsSnapshotSource()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1041, 0)
+	src.nowFunc = func() time.Time { return now }
+	act, err := src.Snapshot(filepath.Join(pidDir, "exe"), []string{"kworker"}, SnapshotAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectation := &ProcessSnapshot{
+		Executable:  filepath.Join(pidDir, "exe"),
+		Cmdline:     []string{"kworker"},
+		CPU:         1,
+		RemotePorts: []int{3333},
+		SHA256:      "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}
+	if diff := cmp.Diff(expectation, act); diff != "" {
+		t.Errorf("unexpected snapshot (-want +got):\n%s", diff)
+	}
+
+	// the process has been idle since the last snapshot and its executable changed
+	now = now.Add(10 * time.Second)
+	if err := os.WriteFile(filepath.Join(pidDir, "exe"), []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(filepath.Join(pidDir, "exe"), now, now); err != nil {
+		t.Fatal(err)
+	}
+	act, err = src.Snapshot(filepath.Join(pidDir, "exe"), []string{"kworker"}, SnapshotCPU|SnapshotSHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectation = &ProcessSnapshot{
+		Executable: filepath.Join(pidDir, "exe"),
+		Cmdline:    []string{"kworker"},
+		SHA256:     "d67e2e944994496c8d8ec76eed0cf9f09679448d584b532bebf941852a37f5ed",
+	}
+	if diff := cmp.Diff(expectation, act); diff != "" {
+		t.Errorf("unexpected snapshot (-want +got):\n%s", diff)
+	}
+}
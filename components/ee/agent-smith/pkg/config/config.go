@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"strings"
 
 	"github.com/gitpod-io/gitpod/agent-smith/pkg/classifier"
@@ -50,6 +51,12 @@ type ServiceConfig struct {
 
 	PProfAddr      string `json:"pprofAddr,omitempty"`
 	PrometheusAddr string `json:"prometheusAddr,omitempty"`
+	// ReportAddr is the address of the HTTP server listing recent classifications per workspace.
+	// The classifications contain owner IDs and command lines, hence the server listens on localhost
+	// if the address has no host, and requires ReportTokenFile for addresses other than localhost.
+	ReportAddr string `json:"reportAddr,omitempty"`
+	// ReportTokenFile contains the bearer token clients of the report server must present
+	ReportTokenFile string `json:"reportTokenFile,omitempty"`
 
 	// We have had memory leak issues with agent smith in the past due to experimental gRPC use.
 	// This upper limit causes agent smith to stop itself should it go above this limit.
@@ -60,11 +67,33 @@ type Enforcement struct {
 	Default         *EnforcementRules           `json:"default,omitempty"`
 	PerRepo         map[string]EnforcementRules `json:"perRepo,omitempty"`
 	CPULimitPenalty string                      `json:"cpuLimitPenalty,omitempty"`
+
+	// DryRun records the penalties agent smith would apply instead of applying them
+	DryRun bool `json:"dryRun,omitempty"`
+	// Shadow rules are evaluated in addition to the default and per-repo rules, but their penalties are only recorded.
+	// This way new penalties can be reviewed before they are enforced. Signatures and behaviour rules of the
+	// blocklists can be marked as shadow themselves to review them the same way.
+	Shadow *EnforcementRules `json:"shadow,omitempty"`
 }
 
 // EnforcementRules matches a infringement with a particular penalty
 type EnforcementRules map[GradedInfringementKind]PenaltyKind
 
+// ReportListenAddr returns the address the report server listens on
+func (c ServiceConfig) ReportListenAddr() (string, error) {
+	host, port, err := net.SplitHostPort(c.ReportAddr)
+	if err != nil {
+		return "", xerrors.Errorf("invalid reportAddr: %w", err)
+	}
+	if host == "" {
+		return net.JoinHostPort("localhost", port), nil
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) && c.ReportTokenFile == "" {
+		return "", xerrors.Errorf("reportAddr %s is not a localhost address, reportTokenFile is required", c.ReportAddr)
+	}
+	return c.ReportAddr, nil
+}
+
 // Validate returns an error if the enforcement rules are invalid for some reason
 func (er EnforcementRules) Validate() error {
 	for k := range er {
@@ -191,7 +220,17 @@ type Blocklists struct {
 	Very   *PerLevelBlocklist `json:"very,omitempty"`
 }
 
-func (b *Blocklists) Classifier() (res classifier.ProcessClassifier, err error) {
+func (b *Blocklists) Classifier() (classifier.ProcessClassifier, error) {
+	src, err := classifier.NewProcfsSnapshotSource()
+	if err != nil {
+		return nil, err
+	}
+	return b.ClassifierWithSnapshots(src)
+}
+
+// ClassifierWithSnapshots builds the classifier with behaviour rules that inspect processes through src,
+// e.g. to replay recorded process snapshots.
+func (b *Blocklists) ClassifierWithSnapshots(src classifier.SnapshotSource) (res classifier.ProcessClassifier, err error) {
 	defer func() {
 		if res == nil {
 			return
@@ -206,7 +245,7 @@ func (b *Blocklists) Classifier() (res classifier.ProcessClassifier, err error)
 	gres := make(classifier.GradedClassifier)
 	for level, bl := range b.Levels() {
 		lvl := classifier.Level(level)
-		gres[lvl], err = bl.Classifier(string(level), lvl)
+		gres[lvl], err = bl.Classifier(string(level), lvl, src)
 		if err != nil {
 			return nil, err
 		}
@@ -236,12 +275,13 @@ type AllowList struct {
 
 // PerLevelBlocklist lists blacklists for level of infringement
 type PerLevelBlocklist struct {
-	Binaries   []string                `json:"binaries,omitempty"`
-	AllowList  []string                `json:"allowlist,omitempty"`
-	Signatures []*classifier.Signature `json:"signatures,omitempty"`
+	Binaries   []string                    `json:"binaries,omitempty"`
+	AllowList  []string                    `json:"allowlist,omitempty"`
+	Signatures []*classifier.Signature     `json:"signatures,omitempty"`
+	Behaviour  []*classifier.BehaviourRule `json:"behaviour,omitempty"`
 }
 
-func (p *PerLevelBlocklist) Classifier(name string, level classifier.Level) (classifier.ProcessClassifier, error) {
+func (p *PerLevelBlocklist) Classifier(name string, level classifier.Level, src classifier.SnapshotSource) (classifier.ProcessClassifier, error) {
 	if p == nil {
 		return classifier.CompositeClassifier{}, nil
 	}
@@ -256,5 +296,14 @@ func (p *PerLevelBlocklist) Classifier(name string, level classifier.Level) (cla
 		classifier.NewSignatureMatchClassifier(name, level, p.Signatures),
 	)
 
-	return classifier.CompositeClassifier{cmdlc, sigsc}, nil
+	res := classifier.CompositeClassifier{cmdlc, sigsc}
+	if len(p.Behaviour) > 0 {
+		beh, err := classifier.NewBehaviourClassifier(name, level, p.Behaviour, src)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, classifier.NewCountingMetricsClassifier("beh_"+name, beh))
+	}
+
+	return res, nil
 }
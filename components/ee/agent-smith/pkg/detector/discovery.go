@@ -13,6 +13,8 @@ import (
 
 // Process describes a process ont the node that might warant closer inspection
 type Process struct {
+	// ID identifies the process across scans. It changes when the PID is reused.
+	ID          uint64
 	Path        string
 	CommandLine []string
 	Kind        ProcessKind
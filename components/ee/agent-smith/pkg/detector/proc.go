@@ -244,6 +244,11 @@ func scanFixedSpace(fixedBufferSize int) func(data []byte, atEOF bool) (advance
 
 var _ ProcessDetector = &ProcfsDetector{}
 
+// reclassificationInterval is how often known processes are classified again, so that behaviour rules
+// catch processes which only start misbehaving after a while. Consumers must expect the same process
+// more than once.
+const reclassificationInterval = 10 * time.Minute
+
 // ProcfsDetector detects processes and workspaces on this node by scanning procfs
 type ProcfsDetector struct {
 	mu sync.RWMutex
@@ -367,14 +372,19 @@ func (det *ProcfsDetector) run(processes chan<- Process) {
 			continue
 		}
 
-		if _, ok := det.cache.Get(p.Hash); ok {
-			det.cacheUseCounterVec.WithLabelValues("hit").Inc()
-			continue
+		if seen, ok := det.cache.Get(p.Hash); ok {
+			if time.Since(seen.(time.Time)) < reclassificationInterval {
+				det.cacheUseCounterVec.WithLabelValues("hit").Inc()
+				continue
+			}
+			det.cacheUseCounterVec.WithLabelValues("expired").Inc()
+		} else {
+			det.cacheUseCounterVec.WithLabelValues("miss").Inc()
 		}
-		det.cacheUseCounterVec.WithLabelValues("miss").Inc()
-		det.cache.Add(p.Hash, struct{}{})
+		det.cache.Add(p.Hash, time.Now())
 
 		proc := Process{
+			ID:          p.Hash,
 			Path:        p.Path,
 			CommandLine: p.Cmdline,
 			Kind:        p.Kind,
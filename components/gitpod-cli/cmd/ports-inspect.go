@@ -0,0 +1,126 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+var portsInspectOpts struct {
+	NoFollow bool
+	Verbose  bool
+	JSON     bool
+}
+
+// portsInspectCmd streams the requests made to a port
+var portsInspectCmd = &cobra.Command{
+	Use:   "inspect <port>",
+	Short: "Show the HTTP requests made to an exposed port",
+	Long: `Show the HTTP requests made to an exposed port.
+
+Requests are captured while the port is being inspected and for a while afterwards.
+Session cookies are never captured and bodies are truncated.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		port, err := strconv.ParseUint(args[0], 10, 16)
+		if err != nil {
+			log.Fatalf("port cannot be parsed as int: %s", err)
+		}
+
+		ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		client, err := supervisor.New(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer client.Close()
+
+		stream, err := client.Port.InspectPort(ctx, &api.InspectPortRequest{
+			Port:   uint32(port),
+			Follow: !portsInspectOpts.NoFollow,
+		})
+		if err != nil {
+			log.Fatalf("cannot inspect port %d: %s", port, err)
+		}
+		if !portsInspectOpts.NoFollow && !portsInspectOpts.JSON {
+			fmt.Fprintf(os.Stderr, "Inspecting requests to port %d, press Ctrl+C to stop.\n", port)
+		}
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				log.Fatalf("cannot inspect port %d: %s", port, err)
+			}
+			printCapture(os.Stdout, resp.Capture)
+		}
+	},
+}
+
+func printCapture(out io.Writer, c *api.HTTPCapture) {
+	if c == nil {
+		return
+	}
+	if portsInspectOpts.JSON {
+		fmt.Fprintln(out, protojson.Format(c))
+		return
+	}
+
+	status := strconv.Itoa(int(c.StatusCode))
+	if c.Upgraded {
+		status += " (upgraded)"
+	}
+	fmt.Fprintf(out, "%s  %-7s %s  %s  %s\n",
+		time.UnixMilli(c.Time).Format("15:04:05.000"),
+		c.Method,
+		c.Url,
+		status,
+		time.Duration(c.DurationMs)*time.Millisecond,
+	)
+	if !portsInspectOpts.Verbose {
+		return
+	}
+
+	printHeadersAndBody := func(prefix string, headers []*api.HTTPHeader, body []byte, truncated bool) {
+		for _, h := range headers {
+			for _, v := range h.Values {
+				fmt.Fprintf(out, "  %s %s: %s\n", prefix, h.Name, v)
+			}
+		}
+		if len(body) > 0 {
+			fmt.Fprintf(out, "  %s\n", prefix)
+			fmt.Fprintf(out, "%s\n", body)
+		}
+		if truncated {
+			fmt.Fprintf(out, "  %s (body truncated)\n", prefix)
+		}
+	}
+	printHeadersAndBody(">", c.RequestHeaders, c.RequestBody, c.RequestBodyTruncated)
+	printHeadersAndBody("<", c.ResponseHeaders, c.ResponseBody, c.ResponseBodyTruncated)
+	fmt.Fprintln(out)
+}
+
+func init() {
+	portsInspectCmd.Flags().BoolVar(&portsInspectOpts.NoFollow, "no-follow", false, "print the captured requests and exit")
+	portsInspectCmd.Flags().BoolVarP(&portsInspectOpts.Verbose, "verbose", "v", false, "print headers and bodies")
+	portsInspectCmd.Flags().BoolVar(&portsInspectOpts.JSON, "json", false, "print the captures as JSON, one per line")
+	portsCmd.AddCommand(portsInspectCmd)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	gitpod "github.com/gitpod-io/gitpod/gitpod-cli/pkg/gitpod"
+	serverapi "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/spf13/cobra"
+)
+
+var portsShareOpts struct {
+	Expires     time.Duration
+	Users       []string
+	TeamMembers bool
+}
+
+// portsShareCmd mints a time-limited link to a port
+var portsShareCmd = &cobra.Command{
+	Use:   "share <port>",
+	Short: "Create a time-limited link that grants access to a port",
+	Long: `Create a time-limited link that grants access to a port.
+
+The port becomes shared: besides you, only those holding a valid link can access it.
+By default anyone with the link can access the port. Use --user or --team to restrict
+the link to specific users or the members of the workspace's team.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		port, err := strconv.Atoi(args[0])
+		if err != nil {
+			log.Fatal("port should be integer")
+		}
+		if portsShareOpts.Expires < time.Minute {
+			log.Fatal("expires should be at least one minute")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		wsInfo, err := gitpod.GetWSInfo(ctx)
+		if err != nil {
+			log.Fatalf("cannot get workspace info, %s", err.Error())
+		}
+		client, err := gitpod.ConnectToServer(ctx, wsInfo, []string{
+			"function:sharePort",
+			"resource:workspace::" + wsInfo.WorkspaceId + "::get/update",
+		})
+		if err != nil {
+			log.Fatalf("cannot connect to server, %s", err.Error())
+		}
+		res, err := client.SharePort(ctx, wsInfo.WorkspaceId, float32(port), &serverapi.SharePortOptions{
+			ExpiresInSeconds: portsShareOpts.Expires.Seconds(),
+			UserIDs:          portsShareOpts.Users,
+			TeamMembers:      portsShareOpts.TeamMembers,
+		})
+		if err != nil {
+			log.Fatalf("failed to share port: %s", err.Error())
+		}
+		fmt.Println(res.URL)
+		if expiresAt, err := time.Parse(time.RFC3339, res.ExpiresAt); err == nil {
+			fmt.Printf("the link expires at %s\n", expiresAt.Local().Format(time.RFC1123))
+		}
+	},
+}
+
+func init() {
+	portsShareCmd.Flags().DurationVar(&portsShareOpts.Expires, "expires", 1*time.Hour, "how long the link stays valid")
+	portsShareCmd.Flags().StringSliceVar(&portsShareOpts.Users, "user", nil, "restrict the link to these user IDs")
+	portsShareCmd.Flags().BoolVar(&portsShareOpts.TeamMembers, "team", false, "allow the members of the workspace's team to use the link")
+	portsCmd.AddCommand(portsShareCmd)
+}
@@ -19,8 +19,8 @@ import (
 
 // portsVisibilityCmd change visibility of port
 var portsVisibilityCmd = &cobra.Command{
-	Use:   "visibility <port:{private|public}>",
-	Short: "Make a port public or private",
+	Use:   "visibility <port:{private|shared|public}>",
+	Short: "Make a port public, shared or private",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		portVisibility := args[0]
@@ -33,8 +33,8 @@ var portsVisibilityCmd = &cobra.Command{
 			log.Fatal("port should be integer")
 		}
 		visibility := s[1]
-		if visibility != serverapi.PortVisibilityPublic && visibility != serverapi.PortVisibilityPrivate {
-			log.Fatalf("visibility should be `%s` or `%s`", serverapi.PortVisibilityPublic, serverapi.PortVisibilityPrivate)
+		if visibility != serverapi.PortVisibilityPublic && visibility != serverapi.PortVisibilityPrivate && visibility != serverapi.PortVisibilityShared {
+			log.Fatalf("visibility should be `%s`, `%s` or `%s`", serverapi.PortVisibilityPublic, serverapi.PortVisibilityShared, serverapi.PortVisibilityPrivate)
 		}
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -24,6 +24,7 @@ type SupervisorClient struct {
 	Status   api.StatusServiceClient
 	Terminal api.TerminalServiceClient
 	Info     api.InfoServiceClient
+	Port     api.PortServiceClient
 }
 
 type SupervisorClientOption struct {
@@ -47,6 +48,7 @@ func New(ctx context.Context, options ...*SupervisorClientOption) (*SupervisorCl
 		Status:   api.NewStatusServiceClient(conn),
 		Terminal: api.NewTerminalServiceClient(conn),
 		Info:     api.NewInfoServiceClient(conn),
+		Port:     api.NewPortServiceClient(conn),
 	}, nil
 }
 
@@ -0,0 +1,114 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BillingInvoiceStatus string
+
+const (
+	BillingInvoiceStatus_Open      BillingInvoiceStatus = "open"
+	BillingInvoiceStatus_Finalized BillingInvoiceStatus = "finalized"
+)
+
+// BillingInvoice is an invoice of a billing cycle which is handed to downstream billing systems as a document
+type BillingInvoice struct {
+	ID                string               `gorm:"primary_key;column:id;type:varchar;size:255;" json:"id"`
+	AttributionID     AttributionID        `gorm:"column:attributionId;type:varchar;size:255;" json:"attributionId"`
+	CustomerID        string               `gorm:"column:customerId;type:varchar;size:255;" json:"customerId"`
+	Status            BillingInvoiceStatus `gorm:"column:status;type:varchar;size:16;" json:"status"`
+	Credits           int64                `gorm:"column:credits;type:bigint;" json:"credits"`
+	BillingCycleStart VarcharTime          `gorm:"column:billingCycleStart;type:varchar;size:255;" json:"billingCycleStart"`
+	BillingCycleEnd   VarcharTime          `gorm:"column:billingCycleEnd;type:varchar;size:255;" json:"billingCycleEnd"`
+	FinalizedTime     VarcharTime          `gorm:"column:finalizedTime;type:varchar;size:255;" json:"finalizedTime"`
+
+	// Read-only (-> property).
+	LastModified time.Time `gorm:"->;column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
+}
+
+// TableName sets the insert table name for this struct type
+func (i *BillingInvoice) TableName() string {
+	return "d_b_billing_invoice"
+}
+
+// StoreOpenBillingInvoice creates or updates an open invoice. It returns false without storing anything
+// if the invoice has been finalized already.
+func StoreOpenBillingInvoice(ctx context.Context, conn *gorm.DB, invoice BillingInvoice) (bool, error) {
+	invoice.Status = BillingInvoiceStatus_Open
+	stored := false
+	err := conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing BillingInvoice
+		err := tx.Where("id = ?", invoice.ID).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil && existing.Status != BillingInvoiceStatus_Open {
+			return nil
+		}
+
+		err = tx.Save(&invoice).Error
+		if err != nil {
+			return err
+		}
+		stored = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to store invoice %s: %w", invoice.ID, err)
+	}
+	return stored, nil
+}
+
+func GetBillingInvoice(ctx context.Context, conn *gorm.DB, id string) (BillingInvoice, error) {
+	var invoice BillingInvoice
+	tx := conn.WithContext(ctx).Where("id = ?", id).First(&invoice)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return BillingInvoice{}, fmt.Errorf("invoice %s does not exist: %w", id, ErrorNotFound)
+		}
+		return BillingInvoice{}, fmt.Errorf("failed to get invoice %s: %w", id, tx.Error)
+	}
+	return invoice, nil
+}
+
+// FinalizeBillingInvoice marks an open invoice as finalized. It returns ErrorNotFound if there is no open invoice with id.
+func FinalizeBillingInvoice(ctx context.Context, conn *gorm.DB, id string, finalizedTime time.Time) error {
+	tx := conn.WithContext(ctx).
+		Model(&BillingInvoice{}).
+		Where("id = ?", id).
+		Where("status = ?", BillingInvoiceStatus_Open).
+		Updates(map[string]interface{}{
+			"status":        BillingInvoiceStatus_Finalized,
+			"finalizedTime": TimeToISO8601(finalizedTime),
+		})
+	if tx.Error != nil {
+		return fmt.Errorf("failed to finalize invoice %s: %w", id, tx.Error)
+	}
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("open invoice %s does not exist: %w", id, ErrorNotFound)
+	}
+	return nil
+}
+
+// ListOpenBillingInvoicesEndedBy returns the open invoices whose billing cycle ended at or before t
+func ListOpenBillingInvoicesEndedBy(ctx context.Context, conn *gorm.DB, t time.Time) ([]BillingInvoice, error) {
+	var invoices []BillingInvoice
+	tx := conn.WithContext(ctx).
+		Where("status = ?", BillingInvoiceStatus_Open).
+		Where("billingCycleEnd <= ?", TimeToISO8601(t)).
+		Order("billingCycleEnd, id").
+		Find(&invoices)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list open invoices: %w", tx.Error)
+	}
+	return invoices, nil
+}
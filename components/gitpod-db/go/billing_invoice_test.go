@@ -0,0 +1,68 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBillingInvoice_StoreAndFinalize(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+	ctx := context.Background()
+
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	cycleStart := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	cycleEnd := cycleStart.AddDate(0, 1, 0)
+	t.Cleanup(func() {
+		conn.Where("attributionId = ?", attributionID).Delete(&db.BillingInvoice{})
+	})
+
+	invoice := db.BillingInvoice{
+		ID:                uuid.New().String(),
+		AttributionID:     attributionID,
+		CustomerID:        string(attributionID),
+		Credits:           100,
+		BillingCycleStart: db.NewVarCharTime(cycleStart),
+		BillingCycleEnd:   db.NewVarCharTime(cycleEnd),
+	}
+	stored, err := db.StoreOpenBillingInvoice(ctx, conn, invoice)
+	require.NoError(t, err)
+	require.True(t, stored)
+
+	invoice.Credits = 150
+	stored, err = db.StoreOpenBillingInvoice(ctx, conn, invoice)
+	require.NoError(t, err)
+	require.True(t, stored)
+
+	open, err := db.ListOpenBillingInvoicesEndedBy(ctx, conn, cycleEnd.Add(-time.Second))
+	require.NoError(t, err)
+	require.Empty(t, open)
+	open, err = db.ListOpenBillingInvoicesEndedBy(ctx, conn, cycleEnd)
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	require.Equal(t, int64(150), open[0].Credits)
+
+	require.NoError(t, db.FinalizeBillingInvoice(ctx, conn, invoice.ID, cycleEnd.Add(time.Hour)))
+	require.ErrorIs(t, db.FinalizeBillingInvoice(ctx, conn, invoice.ID, cycleEnd.Add(time.Hour)), db.ErrorNotFound)
+
+	// finalized invoices are not updated anymore
+	invoice.Credits = 200
+	stored, err = db.StoreOpenBillingInvoice(ctx, conn, invoice)
+	require.NoError(t, err)
+	require.False(t, stored)
+
+	finalized, err := db.GetBillingInvoice(ctx, conn, invoice.ID)
+	require.NoError(t, err)
+	require.Equal(t, db.BillingInvoiceStatus_Finalized, finalized.Status)
+	require.Equal(t, int64(150), finalized.Credits)
+	require.Equal(t, db.NewVarCharTime(cycleEnd.Add(time.Hour)).Time(), finalized.FinalizedTime.Time())
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BillingSubscription is a subscription which is handed to downstream billing systems as a document
+type BillingSubscription struct {
+	ID            string        `gorm:"primary_key;column:id;type:varchar;size:255;" json:"id"`
+	AttributionID AttributionID `gorm:"column:attributionId;type:varchar;size:255;" json:"attributionId"`
+	CustomerID    string        `gorm:"column:customerId;type:varchar;size:255;" json:"customerId"`
+	PriceID       string        `gorm:"column:priceId;type:varchar;size:255;" json:"priceId"`
+	CreationTime  VarcharTime   `gorm:"column:creationTime;type:varchar;size:255;" json:"creationTime"`
+
+	// Read-only (-> property).
+	LastModified time.Time `gorm:"->;column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
+}
+
+// TableName sets the insert table name for this struct type
+func (s *BillingSubscription) TableName() string {
+	return "d_b_billing_subscription"
+}
+
+func CreateBillingSubscription(ctx context.Context, conn *gorm.DB, subscription BillingSubscription) error {
+	tx := conn.WithContext(ctx).Create(&subscription)
+	if tx.Error != nil {
+		return fmt.Errorf("failed to create subscription %s: %w", subscription.ID, tx.Error)
+	}
+	return nil
+}
+
+func GetBillingSubscription(ctx context.Context, conn *gorm.DB, id string) (BillingSubscription, error) {
+	var subscription BillingSubscription
+	tx := conn.WithContext(ctx).Where("id = ?", id).First(&subscription)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return BillingSubscription{}, fmt.Errorf("subscription %s does not exist: %w", id, ErrorNotFound)
+		}
+		return BillingSubscription{}, fmt.Errorf("failed to get subscription %s: %w", id, tx.Error)
+	}
+	return subscription, nil
+}
+
+// GetBillingSubscriptionByCustomerID returns the subscription of customerID, or ErrorNotFound if it has none
+func GetBillingSubscriptionByCustomerID(ctx context.Context, conn *gorm.DB, customerID string) (BillingSubscription, error) {
+	var subscription BillingSubscription
+	tx := conn.WithContext(ctx).Where("customerId = ?", customerID).Order("creationTime DESC").First(&subscription)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return BillingSubscription{}, fmt.Errorf("subscription of customer %s does not exist: %w", customerID, ErrorNotFound)
+		}
+		return BillingSubscription{}, fmt.Errorf("failed to get subscription of customer %s: %w", customerID, tx.Error)
+	}
+	return subscription, nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBillingSubscription_CreateAndGet(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+	ctx := context.Background()
+
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	t.Cleanup(func() {
+		conn.Where("attributionId = ?", attributionID).Delete(&db.BillingSubscription{})
+	})
+
+	_, err := db.GetBillingSubscriptionByCustomerID(ctx, conn, string(attributionID))
+	require.ErrorIs(t, err, db.ErrorNotFound)
+
+	subscription := db.BillingSubscription{
+		ID:            string(attributionID),
+		AttributionID: attributionID,
+		CustomerID:    string(attributionID),
+		PriceID:       "price",
+		CreationTime:  db.NewVarCharTime(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	require.NoError(t, db.CreateBillingSubscription(ctx, conn, subscription))
+	require.Error(t, db.CreateBillingSubscription(ctx, conn, subscription), "subscriptions are created only once")
+
+	stored, err := db.GetBillingSubscription(ctx, conn, subscription.ID)
+	require.NoError(t, err)
+	require.Equal(t, "price", stored.PriceID)
+
+	stored, err = db.GetBillingSubscriptionByCustomerID(ctx, conn, string(attributionID))
+	require.NoError(t, err)
+	require.Equal(t, subscription.ID, stored.ID)
+}
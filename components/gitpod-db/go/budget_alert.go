@@ -0,0 +1,57 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SentBudgetAlert records that a budget alert of a cost center has been sent through a notifier in a billing cycle
+type SentBudgetAlert struct {
+	AttributionID     AttributionID `gorm:"primary_key;column:attributionId;type:varchar;size:255;" json:"attributionId"`
+	BillingCycleStart VarcharTime   `gorm:"primary_key;column:billingCycleStart;type:varchar;size:255;" json:"billingCycleStart"`
+	Kind              string        `gorm:"primary_key;column:kind;type:varchar;size:16;" json:"kind"`
+	Threshold         int32         `gorm:"primary_key;column:threshold;type:int;" json:"threshold"`
+	Notifier          string        `gorm:"primary_key;column:notifier;type:varchar;size:64;" json:"notifier"`
+	SentTime          VarcharTime   `gorm:"column:sentTime;type:varchar;size:255;" json:"sentTime"`
+
+	// Read-only (-> property).
+	LastModified time.Time `gorm:"->;column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
+}
+
+// TableName sets the insert table name for this struct type
+func (a *SentBudgetAlert) TableName() string {
+	return "d_b_budget_alert"
+}
+
+// RecordSentBudgetAlert stores that an alert has been sent. Recording an alert again is a no-op.
+func RecordSentBudgetAlert(ctx context.Context, conn *gorm.DB, alert SentBudgetAlert) error {
+	tx := conn.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&alert)
+	if tx.Error != nil {
+		return fmt.Errorf("failed to record %s budget alert at %d%% for %s sent through %s: %w", alert.Kind, alert.Threshold, alert.AttributionID, alert.Notifier, tx.Error)
+	}
+	return nil
+}
+
+// ListSentBudgetAlerts returns the alerts which have been sent for attributionID in the billing cycle starting at billingCycleStart
+func ListSentBudgetAlerts(ctx context.Context, conn *gorm.DB, attributionID AttributionID, billingCycleStart time.Time) ([]SentBudgetAlert, error) {
+	var alerts []SentBudgetAlert
+	tx := conn.WithContext(ctx).
+		Where("attributionId = ?", attributionID).
+		Where("billingCycleStart = ?", TimeToISO8601(billingCycleStart)).
+		Order("kind, threshold, notifier").
+		Find(&alerts)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list sent budget alerts of %s: %w", attributionID, tx.Error)
+	}
+	return alerts, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSentBudgetAlert_RecordAndList(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+	ctx := context.Background()
+
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	cycleStart := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	nextCycleStart := cycleStart.AddDate(0, 1, 0)
+	t.Cleanup(func() {
+		conn.Where("attributionId = ?", attributionID).Delete(&db.SentBudgetAlert{})
+	})
+
+	record := func(start time.Time, kind string, threshold int32, notifier string) {
+		require.NoError(t, db.RecordSentBudgetAlert(ctx, conn, db.SentBudgetAlert{
+			AttributionID:     attributionID,
+			BillingCycleStart: db.NewVarCharTime(start),
+			Kind:              kind,
+			Threshold:         threshold,
+			Notifier:          notifier,
+			SentTime:          db.NewVarCharTime(start.Add(time.Hour)),
+		}))
+	}
+	record(cycleStart, "spending", 50, "log")
+	record(cycleStart, "spending", 50, "webhook")
+	record(cycleStart, "forecast", 100, "log")
+	// recording an alert again is a no-op
+	record(cycleStart, "spending", 50, "log")
+	record(nextCycleStart, "spending", 80, "log")
+
+	alerts, err := db.ListSentBudgetAlerts(ctx, conn, attributionID, cycleStart)
+	require.NoError(t, err)
+	require.Len(t, alerts, 3)
+	require.Equal(t, "forecast", alerts[0].Kind)
+	require.Equal(t, "spending", alerts[1].Kind)
+	require.Equal(t, int32(50), alerts[1].Threshold)
+	require.Equal(t, "log", alerts[1].Notifier)
+	require.Equal(t, "webhook", alerts[2].Notifier)
+
+	alerts, err = db.ListSentBudgetAlerts(ctx, conn, attributionID, nextCycleStart)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+}
@@ -122,6 +122,12 @@ func (c *CostCenterManager) GetOrCreateCostCenter(ctx context.Context, attributi
 	return result, nil
 }
 
+// GetCostCenter returns the latest version of cost center for the given attributionID, without creating
+// or resetting it. Returns CostCenterNotFound if there is none.
+func (c *CostCenterManager) GetCostCenter(ctx context.Context, attributionID AttributionID) (CostCenter, error) {
+	return getCostCenter(ctx, c.conn, attributionID)
+}
+
 func getCostCenter(ctx context.Context, conn *gorm.DB, attributionId AttributionID) (CostCenter, error) {
 	db := conn.WithContext(ctx)
 
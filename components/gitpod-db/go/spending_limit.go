@@ -0,0 +1,144 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SpendingLimitScope string
+
+const (
+	SpendingLimitScope_Project SpendingLimitScope = "project"
+	SpendingLimitScope_User    SpendingLimitScope = "user"
+)
+
+// NestedSpendingLimit limits the spending of a project or a user within the cost center of AttributionID
+type NestedSpendingLimit struct {
+	AttributionID AttributionID      `gorm:"primary_key;column:attributionId;type:varchar;size:255;" json:"attributionId"`
+	Scope         SpendingLimitScope `gorm:"primary_key;column:scope;type:varchar;size:16;" json:"scope"`
+	ScopeID       string             `gorm:"primary_key;column:scopeId;type:char;size:36;" json:"scopeId"`
+	SpendingLimit int32              `gorm:"column:spendingLimit;type:int;default:0;" json:"spendingLimit"`
+
+	// Read-only (-> property).
+	LastModified time.Time `gorm:"->;column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
+
+	// deleted is reserved for use by db-sync.
+	_ bool `gorm:"column:deleted;type:tinyint;default:0;" json:"deleted"`
+}
+
+// TableName sets the insert table name for this struct type
+func (l *NestedSpendingLimit) TableName() string {
+	return "d_b_cost_center_spending_limit"
+}
+
+// SetNestedSpendingLimit creates or updates the spending limit of a scope
+func SetNestedSpendingLimit(ctx context.Context, conn *gorm.DB, limit NestedSpendingLimit) error {
+	if limit.Scope != SpendingLimitScope_Project && limit.Scope != SpendingLimitScope_User {
+		return fmt.Errorf("unknown spending limit scope %q", limit.Scope)
+	}
+	if limit.ScopeID == "" {
+		return fmt.Errorf("spending limit scope ID must be set")
+	}
+	if limit.SpendingLimit < 0 {
+		return fmt.Errorf("spending limit cannot be below zero")
+	}
+
+	tx := conn.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"spendingLimit": limit.SpendingLimit,
+				"deleted":       0,
+			}),
+		}).
+		Create(&limit)
+	if tx.Error != nil {
+		return fmt.Errorf("failed to set spending limit for %s %s of %s: %w", limit.Scope, limit.ScopeID, limit.AttributionID, tx.Error)
+	}
+	return nil
+}
+
+// DeleteNestedSpendingLimit removes the spending limit of a scope
+func DeleteNestedSpendingLimit(ctx context.Context, conn *gorm.DB, attributionID AttributionID, scope SpendingLimitScope, scopeID string) error {
+	tx := conn.WithContext(ctx).
+		Table((&NestedSpendingLimit{}).TableName()).
+		Where("attributionId = ?", attributionID).
+		Where("scope = ?", scope).
+		Where("scopeId = ?", scopeID).
+		Where("deleted = ?", 0).
+		Update("deleted", 1)
+	if tx.Error != nil {
+		return fmt.Errorf("failed to delete spending limit for %s %s of %s: %w", scope, scopeID, attributionID, tx.Error)
+	}
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("spending limit for %s %s of %s does not exist: %w", scope, scopeID, attributionID, ErrorNotFound)
+	}
+	return nil
+}
+
+// ListNestedSpendingLimits returns all spending limits within the cost center of attributionID
+func ListNestedSpendingLimits(ctx context.Context, conn *gorm.DB, attributionID AttributionID) ([]NestedSpendingLimit, error) {
+	var limits []NestedSpendingLimit
+	tx := conn.WithContext(ctx).
+		Where("attributionId = ?", attributionID).
+		Where("deleted = ?", 0).
+		Order("scope, scopeId").
+		Find(&limits)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list spending limits of %s: %w", attributionID, tx.Error)
+	}
+	return limits, nil
+}
+
+// NestedUsage is the usage of the projects and users within a cost center
+type NestedUsage struct {
+	Projects map[string]CreditCents
+	Users    map[string]CreditCents
+}
+
+// CreditCentsUsedBy returns the usage of a scope
+func (u NestedUsage) CreditCentsUsedBy(scope SpendingLimitScope, scopeID string) CreditCents {
+	switch scope {
+	case SpendingLimitScope_Project:
+		return u.Projects[scopeID]
+	case SpendingLimitScope_User:
+		return u.Users[scopeID]
+	default:
+		return 0
+	}
+}
+
+// GetNestedUsage sums up the workspace instance usage of attributionID between from and to by project and by user
+func GetNestedUsage(ctx context.Context, conn *gorm.DB, attributionID AttributionID, from, to time.Time) (NestedUsage, error) {
+	records, err := FindUsage(ctx, conn, &FindUsageParams{
+		AttributionId: attributionID,
+		From:          from,
+		To:            to,
+	})
+	if err != nil {
+		return NestedUsage{}, err
+	}
+
+	res := NestedUsage{
+		Projects: make(map[string]CreditCents),
+		Users:    make(map[string]CreditCents),
+	}
+	for _, record := range records {
+		metadata, err := record.GetMetadataAsWorkspaceInstanceData()
+		if err != nil {
+			return NestedUsage{}, fmt.Errorf("failed to parse metadata of usage record %s: %w", record.ID, err)
+		}
+		if metadata.ProjectID != "" {
+			res.Projects[metadata.ProjectID] += record.CreditCents
+		}
+		res.Users[metadata.UserID.String()] += record.CreditCents
+	}
+	return res, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestNestedSpendingLimit_SetListDelete(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+	ctx := context.Background()
+
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	projectID := uuid.New().String()
+	userID := uuid.New().String()
+	cleanUpNestedSpendingLimits(t, conn, attributionID)
+
+	require.NoError(t, db.SetNestedSpendingLimit(ctx, conn, db.NestedSpendingLimit{
+		AttributionID: attributionID,
+		Scope:         db.SpendingLimitScope_Project,
+		ScopeID:       projectID,
+		SpendingLimit: 100,
+	}))
+	require.NoError(t, db.SetNestedSpendingLimit(ctx, conn, db.NestedSpendingLimit{
+		AttributionID: attributionID,
+		Scope:         db.SpendingLimitScope_User,
+		ScopeID:       userID,
+		SpendingLimit: 50,
+	}))
+	// updates the existing limit
+	require.NoError(t, db.SetNestedSpendingLimit(ctx, conn, db.NestedSpendingLimit{
+		AttributionID: attributionID,
+		Scope:         db.SpendingLimitScope_Project,
+		ScopeID:       projectID,
+		SpendingLimit: 200,
+	}))
+
+	limits, err := db.ListNestedSpendingLimits(ctx, conn, attributionID)
+	require.NoError(t, err)
+	require.Len(t, limits, 2)
+	require.Equal(t, db.SpendingLimitScope_Project, limits[0].Scope)
+	require.Equal(t, int32(200), limits[0].SpendingLimit)
+	require.Equal(t, db.SpendingLimitScope_User, limits[1].Scope)
+	require.Equal(t, int32(50), limits[1].SpendingLimit)
+
+	require.NoError(t, db.DeleteNestedSpendingLimit(ctx, conn, attributionID, db.SpendingLimitScope_User, userID))
+	err = db.DeleteNestedSpendingLimit(ctx, conn, attributionID, db.SpendingLimitScope_User, userID)
+	require.True(t, errors.Is(err, db.ErrorNotFound))
+
+	limits, err = db.ListNestedSpendingLimits(ctx, conn, attributionID)
+	require.NoError(t, err)
+	require.Len(t, limits, 1)
+	require.Equal(t, projectID, limits[0].ScopeID)
+
+	// a deleted limit can be set again
+	require.NoError(t, db.SetNestedSpendingLimit(ctx, conn, db.NestedSpendingLimit{
+		AttributionID: attributionID,
+		Scope:         db.SpendingLimitScope_User,
+		ScopeID:       userID,
+		SpendingLimit: 10,
+	}))
+	limits, err = db.ListNestedSpendingLimits(ctx, conn, attributionID)
+	require.NoError(t, err)
+	require.Len(t, limits, 2)
+	require.Equal(t, int32(10), limits[1].SpendingLimit)
+}
+
+func TestNestedSpendingLimit_InvalidScope(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+
+	err := db.SetNestedSpendingLimit(context.Background(), conn, db.NestedSpendingLimit{
+		AttributionID: db.NewTeamAttributionID(uuid.New().String()),
+		Scope:         "workspace",
+		ScopeID:       uuid.New().String(),
+		SpendingLimit: 10,
+	})
+	require.Error(t, err)
+}
+
+func TestGetNestedUsage(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	start := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	projectID := uuid.New().String()
+	alice, bob := uuid.New(), uuid.New()
+
+	newUsage := func(user uuid.UUID, project string, credits float64) db.Usage {
+		usage := dbtest.NewUsage(t, db.Usage{
+			AttributionID: attributionID,
+			CreditCents:   db.NewCreditCents(credits),
+			EffectiveTime: db.NewVarCharTime(start.Add(time.Hour)),
+			Kind:          db.WorkspaceInstanceUsageKind,
+		})
+		require.NoError(t, usage.SetMetadataWithWorkspaceInstance(db.WorkspaceInstanceUsageData{
+			UserID:    user,
+			ProjectID: project,
+		}))
+		return usage
+	}
+	dbtest.CreateUsageRecords(t, conn,
+		newUsage(alice, projectID, 1),
+		newUsage(alice, "", 2),
+		newUsage(bob, projectID, 4),
+	)
+
+	usage, err := db.GetNestedUsage(context.Background(), conn, attributionID, start, start.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, db.NewCreditCents(5), usage.CreditCentsUsedBy(db.SpendingLimitScope_Project, projectID))
+	require.Equal(t, db.NewCreditCents(3), usage.CreditCentsUsedBy(db.SpendingLimitScope_User, alice.String()))
+	require.Equal(t, db.NewCreditCents(4), usage.CreditCentsUsedBy(db.SpendingLimitScope_User, bob.String()))
+}
+
+func cleanUpNestedSpendingLimits(t *testing.T, conn *gorm.DB, attributionID db.AttributionID) {
+	t.Helper()
+	t.Cleanup(func() {
+		conn.Where("attributionId = ?", attributionID).Delete(&db.NestedSpendingLimit{})
+	})
+}
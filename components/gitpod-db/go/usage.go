@@ -90,6 +90,23 @@ type WorkspaceInstanceUsageData struct {
 	UserID         uuid.UUID     `json:"userId"`
 	UserName       string        `json:"userName"`
 	UserAvatarURL  string        `json:"userAvatarURL"`
+	ProjectID      string        `json:"projectId,omitempty"`
+
+	// Pricing describes how the credits of the usage entry were computed
+	Pricing *UsagePriceBreakdown `json:"pricing,omitempty"`
+}
+
+// UsagePriceBreakdown describes how the credits of a usage entry break down into pricing dimensions
+type UsagePriceBreakdown struct {
+	Pricer     string                `json:"pricer"`
+	Components []UsagePriceComponent `json:"components"`
+}
+
+// UsagePriceComponent is the price of a single pricing dimension
+type UsagePriceComponent struct {
+	Dimension string  `json:"dimension"`
+	Quantity  float64 `json:"quantity"`
+	Credits   float64 `json:"credits"`
 }
 
 type CreditNoteMetaData struct {
@@ -311,6 +311,27 @@ export class GitpodTableDescriptionProvider implements TableDescriptionProvider
             deletionColumn: "deleted",
             timeColumn: "_lastModified",
         },
+        {
+            name: "d_b_cost_center_spending_limit",
+            primaryKeys: ["attributionId", "scope", "scopeId"],
+            deletionColumn: "deleted",
+            timeColumn: "_lastModified",
+        },
+        {
+            name: "d_b_budget_alert",
+            primaryKeys: ["attributionId", "billingCycleStart", "kind", "threshold", "notifier"],
+            timeColumn: "_lastModified",
+        },
+        {
+            name: "d_b_billing_invoice",
+            primaryKeys: ["id"],
+            timeColumn: "_lastModified",
+        },
+        {
+            name: "d_b_billing_subscription",
+            primaryKeys: ["id"],
+            timeColumn: "_lastModified",
+        },
         {
             name: "d_b_usage",
             primaryKeys: ["id"],
@@ -0,0 +1,26 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+import { MigrationInterface, QueryRunner } from "typeorm";
+import { tableExists } from "./helper/helper";
+
+const table = "d_b_cost_center_spending_limit";
+
+export class CreateCostCenterSpendingLimitTable1678273826914 implements MigrationInterface {
+    public async up(queryRunner: QueryRunner): Promise<void> {
+        if (!(await tableExists(queryRunner, table))) {
+            await queryRunner.query(
+                `CREATE TABLE IF NOT EXISTS \`${table}\` (\`attributionId\` varchar(255) NOT NULL, \`scope\` varchar(16) NOT NULL, \`scopeId\` char(36) NOT NULL, \`spendingLimit\` int(11) NOT NULL DEFAULT '0', \`_lastModified\` timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6), \`deleted\` tinyint(4) NOT NULL DEFAULT '0', PRIMARY KEY (attributionId, scope, scopeId))`,
+            );
+        }
+    }
+
+    public async down(queryRunner: QueryRunner): Promise<void> {
+        if (await tableExists(queryRunner, table)) {
+            await queryRunner.query(`DROP TABLE \`${table}\``);
+        }
+    }
+}
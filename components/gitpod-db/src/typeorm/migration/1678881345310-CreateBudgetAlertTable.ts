@@ -0,0 +1,26 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+import { MigrationInterface, QueryRunner } from "typeorm";
+import { tableExists } from "./helper/helper";
+
+const table = "d_b_budget_alert";
+
+export class CreateBudgetAlertTable1678881345310 implements MigrationInterface {
+    public async up(queryRunner: QueryRunner): Promise<void> {
+        if (!(await tableExists(queryRunner, table))) {
+            await queryRunner.query(
+                `CREATE TABLE IF NOT EXISTS \`${table}\` (\`attributionId\` varchar(255) NOT NULL, \`billingCycleStart\` varchar(255) NOT NULL, \`kind\` varchar(16) NOT NULL, \`threshold\` int(11) NOT NULL, \`sentTime\` varchar(255) NOT NULL, \`_lastModified\` timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6), PRIMARY KEY (attributionId, billingCycleStart, kind, threshold))`,
+            );
+        }
+    }
+
+    public async down(queryRunner: QueryRunner): Promise<void> {
+        if (await tableExists(queryRunner, table)) {
+            await queryRunner.query(`DROP TABLE \`${table}\``);
+        }
+    }
+}
@@ -0,0 +1,26 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+import { MigrationInterface, QueryRunner } from "typeorm";
+import { tableExists } from "./helper/helper";
+
+const table = "d_b_billing_invoice";
+
+export class CreateBillingInvoiceTable1679049373204 implements MigrationInterface {
+    public async up(queryRunner: QueryRunner): Promise<void> {
+        if (!(await tableExists(queryRunner, table))) {
+            await queryRunner.query(
+                `CREATE TABLE IF NOT EXISTS \`${table}\` (\`id\` varchar(255) NOT NULL, \`attributionId\` varchar(255) NOT NULL, \`customerId\` varchar(255) NOT NULL, \`status\` varchar(16) NOT NULL, \`credits\` bigint NOT NULL DEFAULT 0, \`billingCycleStart\` varchar(255) NOT NULL, \`billingCycleEnd\` varchar(255) NOT NULL, \`finalizedTime\` varchar(255) NOT NULL DEFAULT '', \`_lastModified\` timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6), PRIMARY KEY (id), KEY \`ind_status_billingCycleEnd\` (status, billingCycleEnd))`,
+            );
+        }
+    }
+
+    public async down(queryRunner: QueryRunner): Promise<void> {
+        if (await tableExists(queryRunner, table)) {
+            await queryRunner.query(`DROP TABLE \`${table}\``);
+        }
+    }
+}
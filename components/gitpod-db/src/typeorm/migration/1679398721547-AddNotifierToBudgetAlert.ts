@@ -0,0 +1,26 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+import { MigrationInterface, QueryRunner } from "typeorm";
+import { columnExists } from "./helper/helper";
+
+const D_B_BUDGET_ALERT = "d_b_budget_alert";
+const COL_NOTIFIER = "notifier";
+
+export class AddNotifierToBudgetAlert1679398721547 implements MigrationInterface {
+    public async up(queryRunner: QueryRunner): Promise<void> {
+        if (!(await columnExists(queryRunner, D_B_BUDGET_ALERT, COL_NOTIFIER))) {
+            await queryRunner.query(
+                `ALTER TABLE ${D_B_BUDGET_ALERT} ADD COLUMN ${COL_NOTIFIER} varchar(64) NOT NULL DEFAULT '', ALGORITHM=INPLACE, LOCK=NONE `,
+            );
+            await queryRunner.query(
+                `ALTER TABLE ${D_B_BUDGET_ALERT} DROP PRIMARY KEY, ADD PRIMARY KEY(attributionId, billingCycleStart, kind, threshold, ${COL_NOTIFIER}), ALGORITHM=INPLACE, LOCK=NONE `,
+            );
+        }
+    }
+
+    public async down(queryRunner: QueryRunner): Promise<void> {}
+}
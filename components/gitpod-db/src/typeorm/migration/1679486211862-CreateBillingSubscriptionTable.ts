@@ -0,0 +1,26 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+import { MigrationInterface, QueryRunner } from "typeorm";
+import { tableExists } from "./helper/helper";
+
+const table = "d_b_billing_subscription";
+
+export class CreateBillingSubscriptionTable1679486211862 implements MigrationInterface {
+    public async up(queryRunner: QueryRunner): Promise<void> {
+        if (!(await tableExists(queryRunner, table))) {
+            await queryRunner.query(
+                `CREATE TABLE IF NOT EXISTS \`${table}\` (\`id\` varchar(255) NOT NULL, \`attributionId\` varchar(255) NOT NULL, \`customerId\` varchar(255) NOT NULL, \`priceId\` varchar(255) NOT NULL DEFAULT '', \`creationTime\` varchar(255) NOT NULL, \`_lastModified\` timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6), PRIMARY KEY (id), KEY \`ind_customerId\` (customerId))`,
+            );
+        }
+    }
+
+    public async down(queryRunner: QueryRunner): Promise<void> {
+        if (await tableExists(queryRunner, table)) {
+            await queryRunner.query(`DROP TABLE \`${table}\``);
+        }
+    }
+}
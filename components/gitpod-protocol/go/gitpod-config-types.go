@@ -71,6 +71,9 @@ type GitpodConfig struct {
 	// List of exposed ports.
 	Ports []*PortsItems `yaml:"ports,omitempty" json:"ports,omitempty"`
 
+	// List of additional containers to run next to the workspace, e.g. databases. They can be reached from the workspace on the host in $GITPOD_SIDECAR_HOST and must listen on all interfaces.
+	Sidecars []*SidecarsItems `yaml:"sidecars,omitempty" json:"sidecars,omitempty"`
+
 	// List of tasks to run on start. Each task will open a terminal in the IDE.
 	Tasks []*TasksItems `yaml:"tasks,omitempty" json:"tasks,omitempty"`
 
@@ -189,6 +192,48 @@ type Prebuilds_object struct {
 	PullRequestsFromForks bool `yaml:"pullRequestsFromForks,omitempty" json:"pullRequestsFromForks,omitempty"`
 }
 
+// Resources Resources of the container. Containers which don't specify any get the defaults of the workspace class.
+type Resources struct {
+
+	// Maximum resources the container may use.
+	Limits *SidecarResourceList `yaml:"limits,omitempty" json:"limits,omitempty"`
+
+	// Resources which are guaranteed to the container.
+	Requests *SidecarResourceList `yaml:"requests,omitempty" json:"requests,omitempty"`
+}
+
+// SidecarResourceList
+type SidecarResourceList struct {
+
+	// CPU quantity, e.g. '500m' or '1'.
+	Cpu string `yaml:"cpu,omitempty" json:"cpu,omitempty"`
+
+	// Memory quantity, e.g. '512Mi' or '2Gi'.
+	Memory string `yaml:"memory,omitempty" json:"memory,omitempty"`
+}
+
+// SidecarsItems
+type SidecarsItems struct {
+
+	// Overrides the arguments of the image.
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+
+	// Overrides the entrypoint of the image.
+	Command []string `yaml:"command,omitempty" json:"command,omitempty"`
+
+	// Environment variables to set in the container.
+	Env map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+
+	// The Docker image to run, e.g. 'postgres:14'.
+	Image string `yaml:"image" json:"image"`
+
+	// Name of the container. Must be a lowercase DNS label, e.g. 'postgres'.
+	Name string `yaml:"name" json:"name"`
+
+	// Resources of the container. Containers which don't specify any get the defaults of the workspace class.
+	Resources *Resources `yaml:"resources,omitempty" json:"resources,omitempty"`
+}
+
 // TasksItems
 type TasksItems struct {
 
@@ -63,6 +63,7 @@ type APIInterface interface {
 	GetOpenPorts(ctx context.Context, workspaceID string) (res []*WorkspaceInstancePort, err error)
 	OpenPort(ctx context.Context, workspaceID string, port *WorkspaceInstancePort) (res *WorkspaceInstancePort, err error)
 	ClosePort(ctx context.Context, workspaceID string, port float32) (err error)
+	SharePort(ctx context.Context, workspaceID string, port float32, options *SharePortOptions) (res *SharePortResult, err error)
 	GetUserStorageResource(ctx context.Context, options *GetUserStorageResourceOptions) (res string, err error)
 	UpdateUserStorageResource(ctx context.Context, options *UpdateUserStorageResourceOptions) (err error)
 	GetEnvVars(ctx context.Context) (res []*UserEnvVarValue, err error)
@@ -182,6 +183,8 @@ const (
 	FunctionOpenPort FunctionName = "openPort"
 	// FunctionClosePort is the name of the closePort function
 	FunctionClosePort FunctionName = "closePort"
+	// FunctionSharePort is the name of the sharePort function
+	FunctionSharePort FunctionName = "sharePort"
 	// FunctionGetUserStorageResource is the name of the getUserStorageResource function
 	FunctionGetUserStorageResource FunctionName = "getUserStorageResource"
 	// FunctionUpdateUserStorageResource is the name of the updateUserStorageResource function
@@ -1071,6 +1074,28 @@ func (gp *APIoverJSONRPC) OpenPort(ctx context.Context, workspaceID string, port
 	return
 }
 
+// SharePort calls sharePort on the server
+func (gp *APIoverJSONRPC) SharePort(ctx context.Context, workspaceID string, port float32, options *SharePortOptions) (res *SharePortResult, err error) {
+	if gp == nil {
+		err = errNotConnected
+		return
+	}
+	var _params []interface{}
+
+	_params = append(_params, workspaceID)
+	_params = append(_params, port)
+	_params = append(_params, options)
+
+	var result SharePortResult
+	err = gp.C.Call(ctx, "sharePort", _params, &result)
+	if err != nil {
+		return
+	}
+	res = &result
+
+	return
+}
+
 // ClosePort calls closePort on the server
 func (gp *APIoverJSONRPC) ClosePort(ctx context.Context, workspaceID string, port float32) (err error) {
 	if gp == nil {
@@ -1912,8 +1937,22 @@ type WorkspaceInstancePort struct {
 const (
 	PortVisibilityPublic  = "public"
 	PortVisibilityPrivate = "private"
+	PortVisibilityShared  = "shared"
 )
 
+// SharePortOptions is the SharePortOptions message type
+type SharePortOptions struct {
+	ExpiresInSeconds float64  `json:"expiresInSeconds,omitempty"`
+	UserIDs          []string `json:"userIds,omitempty"`
+	TeamMembers      bool     `json:"teamMembers,omitempty"`
+}
+
+// SharePortResult is the SharePortResult message type
+type SharePortResult struct {
+	URL       string `json:"url,omitempty"`
+	ExpiresAt string `json:"expiresAt,omitempty"`
+}
+
 // GithubAppConfig is the GithubAppConfig message type
 type GithubAppConfig struct {
 	Prebuilds *GithubAppPrebuildConfig `json:"prebuilds,omitempty"`
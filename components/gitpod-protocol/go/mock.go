@@ -966,6 +966,21 @@ func (mr *MockAPIInterfaceMockRecorder) SetWorkspaceTimeout(ctx, workspaceID, du
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkspaceTimeout", reflect.TypeOf((*MockAPIInterface)(nil).SetWorkspaceTimeout), ctx, workspaceID, duration)
 }
 
+// SharePort mocks base method.
+func (m *MockAPIInterface) SharePort(ctx context.Context, workspaceID string, port float32, options *SharePortOptions) (*SharePortResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharePort", ctx, workspaceID, port, options)
+	ret0, _ := ret[0].(*SharePortResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharePort indicates an expected call of SharePort.
+func (mr *MockAPIInterfaceMockRecorder) SharePort(ctx, workspaceID, port, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharePort", reflect.TypeOf((*MockAPIInterface)(nil).SharePort), ctx, workspaceID, port, options)
+}
+
 // StartWorkspace mocks base method.
 func (m *MockAPIInterface) StartWorkspace(ctx context.Context, id string, options *StartWorkspaceOptions) (*StartWorkspaceResult, error) {
 	m.ctrl.T.Helper()
@@ -134,6 +134,7 @@ export interface GitpodServer extends JsonRpcServer<GitpodClient>, AdminServer,
     watchWorkspaceImageBuildLogs(workspaceId: string): Promise<void>;
     isPrebuildDone(pwsid: string): Promise<boolean>;
     getHeadlessLog(instanceId: string): Promise<HeadlessLogUrls>;
+    getSidecarLogs(workspaceId: string, sidecar: string): Promise<string>;
 
     // Workspace timeout
     setWorkspaceTimeout(workspaceId: string, duration: WorkspaceTimeoutDuration): Promise<SetWorkspaceTimeoutResult>;
@@ -146,6 +147,11 @@ export interface GitpodServer extends JsonRpcServer<GitpodClient>, AdminServer,
     getOpenPorts(workspaceId: string): Promise<WorkspaceInstancePort[]>;
     openPort(workspaceId: string, port: WorkspaceInstancePort): Promise<WorkspaceInstancePort | undefined>;
     closePort(workspaceId: string, port: number): Promise<void>;
+    sharePort(
+        workspaceId: string,
+        port: number,
+        options: GitpodServer.SharePortOptions,
+    ): Promise<GitpodServer.SharePortResult>;
 
     // User storage
     getUserStorageResource(options: GitpodServer.GetUserStorageResourceOptions): Promise<string>;
@@ -436,6 +442,18 @@ export namespace GitpodServer {
         workspaceClass?: string;
         ideSettings?: IDESettings;
     }
+    export interface SharePortOptions {
+        // how long the link stays valid
+        expiresInSeconds: number;
+        // restricts the link to these users. If neither userIds nor teamMembers are set, anyone holding the link can access the port.
+        userIds?: string[];
+        // allows members of the workspace's project team to use the link
+        teamMembers?: boolean;
+    }
+    export interface SharePortResult {
+        url: string;
+        expiresAt: string;
+    }
     export interface TakeSnapshotOptions {
         workspaceId: string;
         /* this is here to enable backwards-compatibility and untangling rollout between workspace, IDE and meta */
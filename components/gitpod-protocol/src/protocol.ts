@@ -816,6 +816,7 @@ export interface WorkspaceConfig {
     image?: ImageConfig;
     ports?: PortConfig[];
     tasks?: TaskConfig[];
+    sidecars?: SidecarConfig[];
     checkoutLocation?: string;
     workspaceLocation?: string;
     gitConfig?: { [config: string]: string };
@@ -993,6 +994,23 @@ export namespace TaskConfig {
     }
 }
 
+export interface SidecarConfig {
+    name: string;
+    image: string;
+    command?: string[];
+    args?: string[];
+    env?: { [env: string]: string };
+    resources?: {
+        requests?: SidecarResourceList;
+        limits?: SidecarResourceList;
+    };
+}
+
+export interface SidecarResourceList {
+    cpu?: string;
+    memory?: string;
+}
+
 export namespace WorkspaceImageBuild {
     export type Phase = "BaseImage" | "GitpodLayer" | "Error" | "Done";
     export interface StateInfo {
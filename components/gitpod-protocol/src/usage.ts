@@ -62,6 +62,19 @@ export interface WorkspaceInstanceUsageData {
     userId: string;
     userName: string;
     userAvatarURL: string;
+    projectId?: string;
+    pricing?: UsagePriceBreakdown;
+}
+
+export interface UsagePriceBreakdown {
+    pricer: string;
+    components: UsagePriceComponent[];
+}
+
+export interface UsagePriceComponent {
+    dimension: string;
+    quantity: number;
+    credits: number;
 }
 
 export interface InvoiceUsageData {
@@ -183,8 +183,9 @@ export interface WorkspaceInstanceConditions {
 // AdmissionLevel describes who can access a workspace instance and its ports.
 export type AdmissionLevel = "owner_only" | "everyone";
 
-// PortVisibility describes how a port can be accessed
-export type PortVisibility = "public" | "private";
+// PortVisibility describes how a port can be accessed. Shared ports are only
+// reachable by the owner and by holders of a signed share link.
+export type PortVisibility = "public" | "private" | "shared";
 
 // WorkspaceInstancePort describes a port exposed on a workspace instance
 export interface WorkspaceInstancePort {
@@ -229,6 +230,8 @@ export interface WorkspaceInstanceRepoStatus {
 export interface ConfigurationIdeConfig {
     useLatest?: boolean;
     desktopIdeAlias?: string;
+    // version is the version of the IDE config the instance was configured with
+    version?: string;
 }
 
 // WorkspaceInstanceConfiguration contains all per-instance configuration
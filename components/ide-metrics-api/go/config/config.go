@@ -10,6 +10,7 @@ import (
 	"os"
 
 	"github.com/gitpod-io/gitpod/common-go/grpc"
+	"github.com/gitpod-io/gitpod/common-go/util"
 	"golang.org/x/xerrors"
 )
 
@@ -26,6 +27,7 @@ type MetricsServerConfiguration struct {
 	HistogramMetrics           []HistogramMetricsConfiguration `json:"histogramMetrics"`
 	AggregatedHistogramMetrics []HistogramMetricsConfiguration `json:"aggregatedHistogramMetrics"`
 	ErrorReporting             ErrorReportingConfiguration     `json:"errorReporting"`
+	RemoteWrite                *RemoteWriteConfiguration       `json:"remoteWrite,omitempty"`
 }
 
 type CounterMetricsConfiguration struct {
@@ -43,6 +45,27 @@ type HistogramMetricsConfiguration struct {
 
 type ErrorReportingConfiguration struct {
 	AllowComponents []string `json:"allowComponents"`
+	// MaxAggregatedErrors is the number of distinct error fingerprints kept in memory,
+	// the least recently seen are dropped first. Defaults to 1000.
+	MaxAggregatedErrors int `json:"maxAggregatedErrors,omitempty"`
+}
+
+// RemoteWriteConfiguration configures the export of the allow-listed metrics
+// to a Prometheus remote-write endpoint
+type RemoteWriteConfiguration struct {
+	URL string `json:"url"`
+	// BearerTokenFile is read on every request, so that the token can be rotated
+	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+	// ExternalLabels are added to every exported series
+	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
+	// Interval is how often the metrics are sampled and exported. Defaults to 1m.
+	Interval util.Duration `json:"interval,omitempty"`
+	// Timeout of a single remote-write request. Defaults to 30s.
+	Timeout util.Duration `json:"timeout,omitempty"`
+	// MaxSamplesPerSend is the maximum number of samples sent in one request. Defaults to 500.
+	MaxSamplesPerSend int `json:"maxSamplesPerSend,omitempty"`
+	// MaxRetries is how often a failed request is retried before its samples are dropped. Defaults to 3.
+	MaxRetries int `json:"maxRetries,omitempty"`
 }
 
 type ServiceConfiguration struct {
@@ -5,10 +5,12 @@
 package cmd
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/ide-metrics-api/config"
+	"github.com/gitpod-io/gitpod/ide-metrics/pkg/remotewrite"
 	"github.com/gitpod-io/gitpod/ide-metrics/pkg/server"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -33,6 +35,7 @@ var runCommand = &cobra.Command{
 
 		handler := http.NewServeMux()
 		handler.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+		handler.Handle("/errors", s.ErrorsHandler())
 
 		go func() {
 			err := http.ListenAndServe(cfg.Prometheus.Addr, handler)
@@ -42,6 +45,15 @@ var runCommand = &cobra.Command{
 		}()
 		log.WithField("addr", cfg.Prometheus.Addr).Info("started prometheus metrics server")
 
+		if cfg.Server.RemoteWrite != nil {
+			exporter, err := remotewrite.NewExporter(*cfg.Server.RemoteWrite, registry, s.IsAllowListed, registry)
+			if err != nil {
+				log.WithError(err).Fatal("cannot create remote-write exporter")
+			}
+			go exporter.Run(context.Background())
+			log.WithField("url", cfg.Server.RemoteWrite.URL).Info("started remote-write exporter")
+		}
+
 		if err := s.Start(); err != nil {
 			log.WithError(err).Fatal("cannot start server")
 		}
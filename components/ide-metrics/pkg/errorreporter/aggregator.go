@@ -0,0 +1,234 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package errorreporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+const (
+	defaultMaxAggregatedErrors = 1000
+
+	// maxErrorStackSize is the size up to which error stacks are stored and fingerprinted
+	maxErrorStackSize = 4 * 1024
+	// maxMessageSize is the size up to which the first line of error stacks is stored
+	maxMessageSize = 256
+	// maxQueryResponseSize is the approximate maximum size of query responses
+	maxQueryResponseSize = 1024 * 1024
+)
+
+// AggregatedError are all reports of an error with the same fingerprint
+type AggregatedError struct {
+	Fingerprint string    `json:"fingerprint"`
+	Component   string    `json:"component"`
+	Message     string    `json:"message"`
+	ErrorStack  string    `json:"errorStack"`
+	Version     string    `json:"version,omitempty"`
+	Count       uint64    `json:"count"`
+	FirstSeen   time.Time `json:"firstSeen"`
+	LastSeen    time.Time `json:"lastSeen"`
+}
+
+// Aggregator groups reported errors by their fingerprint
+type Aggregator struct {
+	maxErrors int
+	now       func() time.Time
+
+	mu     sync.RWMutex
+	errors map[string]*AggregatedError
+}
+
+// NewAggregator creates a new aggregator which keeps at most maxErrors fingerprints.
+// If maxErrors is not positive, 1000 fingerprints are kept.
+func NewAggregator(maxErrors int) *Aggregator {
+	if maxErrors <= 0 {
+		maxErrors = defaultMaxAggregatedErrors
+	}
+	return &Aggregator{
+		maxErrors: maxErrors,
+		now:       time.Now,
+		errors:    make(map[string]*AggregatedError),
+	}
+}
+
+var (
+	// urlOrigin matches the scheme and host of URLs in stack traces, they contain workspace specific hosts
+	urlOrigin = regexp.MustCompile(`[a-z][a-z0-9+.-]*://[^/\s)]+`)
+	// sourcePosition matches line and column numbers, they differ between builds
+	sourcePosition = regexp.MustCompile(`:\d+(:\d+)?\b`)
+)
+
+// Fingerprint identifies an error independently of where and in which build it was reported
+func Fingerprint(component, errorStack string) string {
+	stack := urlOrigin.ReplaceAllString(errorStack, "")
+	stack = sourcePosition.ReplaceAllString(stack, "")
+	lines := strings.Split(stack, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSpace(l)
+	}
+	h := sha256.Sum256([]byte(component + "\n" + strings.Join(lines, "\n")))
+	return hex.EncodeToString(h[:8])
+}
+
+// truncate shortens s to at most n bytes without splitting a UTF-8 sequence
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func (a *Aggregator) Report(event ReportedErrorEvent) {
+	// error reports are sent by clients, limit how much memory they can occupy
+	component := truncate(event.ServiceContext.Service, maxMessageSize)
+	version := truncate(event.ServiceContext.Version, maxMessageSize)
+	stack := truncate(event.Message, maxErrorStackSize)
+	fingerprint := Fingerprint(component, stack)
+	now := a.now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if e, ok := a.errors[fingerprint]; ok {
+		e.Count++
+		e.LastSeen = now
+		if version != "" {
+			e.Version = version
+		}
+		return
+	}
+	if len(a.errors) >= a.maxErrors {
+		a.evictLeastRecentlySeen()
+	}
+	message := stack
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		message = message[:i]
+	}
+	a.errors[fingerprint] = &AggregatedError{
+		Fingerprint: fingerprint,
+		Component:   component,
+		Message:     truncate(message, maxMessageSize),
+		ErrorStack:  stack,
+		Version:     version,
+		Count:       1,
+		FirstSeen:   now,
+		LastSeen:    now,
+	}
+}
+
+func (a *Aggregator) evictLeastRecentlySeen() {
+	var oldest *AggregatedError
+	for _, e := range a.errors {
+		if oldest == nil || e.LastSeen.Before(oldest.LastSeen) {
+			oldest = e
+		}
+	}
+	if oldest != nil {
+		delete(a.errors, oldest.Fingerprint)
+	}
+}
+
+// Query selects aggregated errors
+type Query struct {
+	// Component only selects errors of this component if not empty
+	Component string
+	// Since only selects errors which were seen after this time if not zero
+	Since time.Time
+	// Limit is the maximum number of errors returned if positive
+	Limit int
+}
+
+// Query returns the aggregated errors matching q, the most frequent first.
+// The result is cut off at about 1MiB.
+func (a *Aggregator) Query(q Query) []AggregatedError {
+	a.mu.RLock()
+	result := make([]AggregatedError, 0, len(a.errors))
+	for _, e := range a.errors {
+		if q.Component != "" && e.Component != q.Component {
+			continue
+		}
+		if !q.Since.IsZero() && e.LastSeen.Before(q.Since) {
+			continue
+		}
+		result = append(result, *e)
+	}
+	a.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		if !result[i].LastSeen.Equal(result[j].LastSeen) {
+			return result[i].LastSeen.After(result[j].LastSeen)
+		}
+		return result[i].Fingerprint < result[j].Fingerprint
+	})
+	if q.Limit > 0 && len(result) > q.Limit {
+		result = result[:q.Limit]
+	}
+	// keep responses small, the most frequent errors are the most relevant
+	var size int
+	for i, e := range result {
+		size += len(e.Fingerprint) + len(e.Component) + len(e.Message) + len(e.ErrorStack) + len(e.Version)
+		if size > maxQueryResponseSize {
+			return result[:i]
+		}
+	}
+	return result
+}
+
+// ServeHTTP serves the aggregated errors as JSON. They can be filtered with the
+// component, since (RFC 3339) and limit query parameters.
+func (a *Aggregator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	params := r.URL.Query()
+	q := Query{
+		Component: params.Get("component"),
+	}
+	if since := params.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			http.Error(w, "invalid since: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		q.Since = t
+	}
+	if limit := params.Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		q.Limit = l
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(struct {
+		Errors []AggregatedError `json:"errors"`
+	}{
+		Errors: a.Query(q),
+	})
+	if err != nil {
+		log.WithError(err).Error("cannot write aggregated errors")
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package errorreporter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFingerprint(t *testing.T) {
+	stack := "TypeError: cannot read property 'foo' of undefined\n    at bar (https://3000-gitpodio-gitpod-abc.ws-eu.gitpod.io/_supervisor/frontend/main.js:12:345)"
+	tests := []struct {
+		Name      string
+		Component string
+		Stack     string
+		Same      bool
+	}{
+		{Name: "same stack", Component: "supervisor-frontend", Stack: stack, Same: true},
+		{Name: "other workspace", Component: "supervisor-frontend", Stack: "TypeError: cannot read property 'foo' of undefined\n    at bar (https://8080-foo-bar-xyz.ws-us.gitpod.io/_supervisor/frontend/main.js:12:345)", Same: true},
+		{Name: "other build", Component: "supervisor-frontend", Stack: "TypeError: cannot read property 'foo' of undefined\n  at bar (https://3000-gitpodio-gitpod-abc.ws-eu.gitpod.io/_supervisor/frontend/main.js:13:1)", Same: true},
+		{Name: "other component", Component: "vscode-web", Stack: stack, Same: false},
+		{Name: "other message", Component: "supervisor-frontend", Stack: "TypeError: cannot read property 'baz' of undefined\n    at bar (https://3000-gitpodio-gitpod-abc.ws-eu.gitpod.io/_supervisor/frontend/main.js:12:345)", Same: false},
+		{Name: "other function", Component: "supervisor-frontend", Stack: "TypeError: cannot read property 'foo' of undefined\n    at qux (https://3000-gitpodio-gitpod-abc.ws-eu.gitpod.io/_supervisor/frontend/main.js:12:345)", Same: false},
+	}
+	expected := Fingerprint("supervisor-frontend", stack)
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := Fingerprint(test.Component, test.Stack)
+			if same := act == expected; same != test.Same {
+				t.Errorf("unexpected fingerprint %s, expected same as %s: %v", act, expected, test.Same)
+			}
+		})
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	var (
+		start = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		now   = start
+	)
+	report := func(a *Aggregator, component, stack, version string) {
+		now = now.Add(time.Minute)
+		a.Report(ReportedErrorEvent{
+			Message:        stack,
+			ServiceContext: ReportedErrorServiceContext{Service: component, Version: version},
+		})
+	}
+	newAggregator := func(maxErrors int) *Aggregator {
+		a := NewAggregator(maxErrors)
+		a.now = func() time.Time { return now }
+		return a
+	}
+
+	t.Run("group by fingerprint", func(t *testing.T) {
+		now = start
+		a := newAggregator(0)
+		report(a, "supervisor-frontend", "Error: foo\n    at a (main.js:1:2)", "v1")
+		report(a, "vscode-web", "Error: bar", "")
+		report(a, "supervisor-frontend", "Error: foo\n    at a (main.js:3:4)", "v2")
+
+		act := a.Query(Query{})
+		expectation := []AggregatedError{
+			{
+				Fingerprint: Fingerprint("supervisor-frontend", "Error: foo\n    at a (main.js:1:2)"),
+				Component:   "supervisor-frontend",
+				Message:     "Error: foo",
+				ErrorStack:  "Error: foo\n    at a (main.js:1:2)",
+				Version:     "v2",
+				Count:       2,
+				FirstSeen:   start.Add(1 * time.Minute),
+				LastSeen:    start.Add(3 * time.Minute),
+			},
+			{
+				Fingerprint: Fingerprint("vscode-web", "Error: bar"),
+				Component:   "vscode-web",
+				Message:     "Error: bar",
+				ErrorStack:  "Error: bar",
+				Count:       1,
+				FirstSeen:   start.Add(2 * time.Minute),
+				LastSeen:    start.Add(2 * time.Minute),
+			},
+		}
+		if diff := cmp.Diff(expectation, act); diff != "" {
+			t.Errorf("unexpected aggregated errors (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("query", func(t *testing.T) {
+		now = start
+		a := newAggregator(0)
+		report(a, "supervisor-frontend", "Error: foo", "")
+		report(a, "vscode-web", "Error: bar", "")
+		report(a, "vscode-web", "Error: baz", "")
+
+		tests := []struct {
+			Name     string
+			Query    Query
+			Messages []string
+		}{
+			{Name: "all", Query: Query{}, Messages: []string{"Error: baz", "Error: bar", "Error: foo"}},
+			{Name: "component", Query: Query{Component: "vscode-web"}, Messages: []string{"Error: baz", "Error: bar"}},
+			{Name: "since", Query: Query{Since: start.Add(2 * time.Minute)}, Messages: []string{"Error: baz", "Error: bar"}},
+			{Name: "limit", Query: Query{Limit: 1}, Messages: []string{"Error: baz"}},
+		}
+		for _, test := range tests {
+			t.Run(test.Name, func(t *testing.T) {
+				var act []string
+				for _, e := range a.Query(test.Query) {
+					act = append(act, e.Message)
+				}
+				if diff := cmp.Diff(test.Messages, act); diff != "" {
+					t.Errorf("unexpected errors (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("evict least recently seen", func(t *testing.T) {
+		now = start
+		a := newAggregator(2)
+		report(a, "vscode-web", "Error: foo", "")
+		report(a, "vscode-web", "Error: bar", "")
+		report(a, "vscode-web", "Error: foo", "")
+		report(a, "vscode-web", "Error: baz", "")
+
+		var act []string
+		for _, e := range a.Query(Query{}) {
+			act = append(act, e.Message)
+		}
+		if diff := cmp.Diff([]string{"Error: foo", "Error: baz"}, act); diff != "" {
+			t.Errorf("unexpected errors (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("serve", func(t *testing.T) {
+		now = start
+		a := newAggregator(0)
+		report(a, "supervisor-frontend", "Error: foo", "")
+		report(a, "vscode-web", "Error: bar", "")
+
+		rec := httptest.NewRecorder()
+		a.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/errors?component=vscode-web&since=2023-01-01T00:00:00Z&limit=10", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp struct {
+			Errors []AggregatedError `json:"errors"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Errors) != 1 || resp.Errors[0].Message != "Error: bar" || resp.Errors[0].Count != 1 {
+			t.Errorf("unexpected response: %s", rec.Body.String())
+		}
+
+		rec = httptest.NewRecorder()
+		a.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/errors?since=yesterday", nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("unexpected status for invalid since %d", rec.Code)
+		}
+	})
+}
+
+func TestAggregatorLimitsSize(t *testing.T) {
+	a := NewAggregator(0)
+	for i := 0; i < 500; i++ {
+		a.Report(ReportedErrorEvent{
+			Message:        strings.Repeat("x", i) + "\n" + strings.Repeat("y", 1024*1024),
+			ServiceContext: ReportedErrorServiceContext{Service: "vscode-web"},
+		})
+	}
+
+	errs := a.Query(Query{})
+	if len(errs) == 0 || len(errs) == 500 {
+		t.Fatalf("unexpected number of errors %d, expected the response to be cut off", len(errs))
+	}
+	var size int
+	for _, e := range errs {
+		if len(e.ErrorStack) > maxErrorStackSize || len(e.Message) > maxMessageSize {
+			t.Errorf("error is not truncated: stack %d bytes, message %d bytes", len(e.ErrorStack), len(e.Message))
+		}
+		size += len(e.ErrorStack)
+	}
+	if size > maxQueryResponseSize {
+		t.Errorf("response contains %d bytes of error stacks", size)
+	}
+}
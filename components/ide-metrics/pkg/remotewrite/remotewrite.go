@@ -0,0 +1,312 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package remotewrite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/util"
+	"github.com/gitpod-io/gitpod/ide-metrics-api/config"
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	defaultInterval          = time.Minute
+	defaultTimeout           = 30 * time.Second
+	defaultMaxSamplesPerSend = 500
+	defaultMaxRetries        = 3
+
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+)
+
+type label struct {
+	Name  string
+	Value string
+}
+
+// timeSeries is a single sample of a series, it is all we need from the remote-write TimeSeries message
+type timeSeries struct {
+	Labels    []label
+	Value     float64
+	Timestamp int64
+}
+
+// Exporter samples the allow-listed metrics in an interval and sends them to a remote-write endpoint
+type Exporter struct {
+	cfg      config.RemoteWriteConfiguration
+	gatherer prometheus.Gatherer
+	allowed  func(name string) bool
+	client   *http.Client
+	backoff  time.Duration
+
+	samples *prometheus.CounterVec
+}
+
+// NewExporter creates a new exporter for the metric families of gatherer for which allowed returns true.
+// The metrics of the exporter itself are registered with reg.
+func NewExporter(cfg config.RemoteWriteConfiguration, gatherer prometheus.Gatherer, allowed func(name string) bool, reg prometheus.Registerer) (*Exporter, error) {
+	if cfg.URL == "" {
+		return nil, xerrors.Errorf("remote-write url is required")
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = util.Duration(defaultInterval)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = util.Duration(defaultTimeout)
+	}
+	if cfg.MaxSamplesPerSend <= 0 {
+		cfg.MaxSamplesPerSend = defaultMaxSamplesPerSend
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	} else if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaultMaxRetries
+	}
+
+	e := &Exporter{
+		cfg:      cfg,
+		gatherer: gatherer,
+		allowed:  allowed,
+		client: &http.Client{
+			Timeout: time.Duration(cfg.Timeout),
+		},
+		backoff: minBackoff,
+		samples: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gitpod_ide_metrics_remote_write_samples_total",
+			Help: "Number of samples exported via remote-write",
+		}, []string{"outcome"}),
+	}
+	err := reg.Register(e.samples)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot register remote-write metrics: %w", err)
+	}
+	return e, nil
+}
+
+// Run exports the metrics in the configured interval until ctx is done
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(e.cfg.Interval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.Export(ctx)
+		}
+	}
+}
+
+// Export samples the metrics once and sends them in batches
+func (e *Exporter) Export(ctx context.Context) {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		// Gather returns what it could gather even if some collectors failed
+		log.WithError(err).Warn("remote-write: cannot gather all metrics")
+	}
+	series := e.toTimeSeries(families, time.Now())
+
+	for start := 0; start < len(series); start += e.cfg.MaxSamplesPerSend {
+		end := start + e.cfg.MaxSamplesPerSend
+		if end > len(series) {
+			end = len(series)
+		}
+		batch := series[start:end]
+		err := e.sendWithRetries(ctx, batch)
+		if err != nil {
+			log.WithError(err).WithField("samples", len(batch)).Error("remote-write: dropping samples")
+			e.samples.WithLabelValues("failed").Add(float64(len(batch)))
+			continue
+		}
+		e.samples.WithLabelValues("success").Add(float64(len(batch)))
+	}
+}
+
+func (e *Exporter) toTimeSeries(families []*dto.MetricFamily, now time.Time) []timeSeries {
+	var result []timeSeries
+	for _, f := range families {
+		name := f.GetName()
+		if !e.allowed(name) {
+			continue
+		}
+		for _, m := range f.Metric {
+			timestamp := now.UnixMilli()
+			if m.TimestampMs != nil {
+				timestamp = m.GetTimestampMs()
+			}
+			add := func(name string, value float64, extra ...label) {
+				labels := make([]label, 0, len(m.Label)+len(extra)+len(e.cfg.ExternalLabels)+1)
+				labels = append(labels, label{Name: "__name__", Value: name})
+				for k, v := range e.cfg.ExternalLabels {
+					labels = append(labels, label{Name: k, Value: v})
+				}
+				for _, l := range m.Label {
+					labels = append(labels, label{Name: l.GetName(), Value: l.GetValue()})
+				}
+				labels = append(labels, extra...)
+				// remote-write requires labels to be sorted by name
+				sort.SliceStable(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+				result = append(result, timeSeries{
+					Labels:    dedupLabels(labels),
+					Value:     value,
+					Timestamp: timestamp,
+				})
+			}
+
+			switch f.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, m.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add(name, m.GetUntyped().GetValue())
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				hasInf := false
+				for _, b := range h.Bucket {
+					if math.IsInf(b.GetUpperBound(), +1) {
+						hasInf = true
+					}
+					add(name+"_bucket", float64(b.GetCumulativeCount()), label{Name: "le", Value: formatFloat(b.GetUpperBound())})
+				}
+				if !hasInf {
+					add(name+"_bucket", float64(h.GetSampleCount()), label{Name: "le", Value: "+Inf"})
+				}
+				add(name+"_sum", h.GetSampleSum())
+				add(name+"_count", float64(h.GetSampleCount()))
+			default:
+				log.WithField("metricName", name).WithField("type", f.GetType().String()).Debug("remote-write: unsupported metric type")
+			}
+		}
+	}
+	return result
+}
+
+// dedupLabels removes labels which are set more than once from sorted labels,
+// the labels of a metric take precedence over external labels
+func dedupLabels(labels []label) []label {
+	result := labels[:0]
+	for i, l := range labels {
+		if i+1 < len(labels) && labels[i+1].Name == l.Name {
+			continue
+		}
+		result = append(result, l)
+	}
+	return result
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, +1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func (e *Exporter) sendWithRetries(ctx context.Context, series []timeSeries) error {
+	body := snappy.Encode(nil, encodeWriteRequest(series))
+
+	backoff := e.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := e.send(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= e.cfg.MaxRetries {
+			return err
+		}
+		log.WithError(err).WithField("attempt", attempt+1).Debug("remote-write: retrying")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// send sends a single remote-write request and reports whether a failed request can be retried
+func (e *Exporter) send(ctx context.Context, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	req.Header.Set("User-Agent", "gitpod-ide-metrics")
+	if e.cfg.BearerTokenFile != "" {
+		token, err := os.ReadFile(e.cfg.BearerTokenFile)
+		if err != nil {
+			return false, xerrors.Errorf("cannot read bearer token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+	err = fmt.Errorf("server returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	// client errors will fail again, except for rate limiting
+	return resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests, err
+}
+
+// encodeWriteRequest encodes series as a prometheus.WriteRequest protobuf message, see
+// https://github.com/prometheus/prometheus/blob/main/prompb/remote.proto
+func encodeWriteRequest(series []timeSeries) []byte {
+	var b []byte
+	for _, s := range series {
+		var ts []byte
+		for _, l := range s.Labels {
+			var lb []byte
+			lb = protowire.AppendTag(lb, 1, protowire.BytesType)
+			lb = protowire.AppendString(lb, l.Name)
+			lb = protowire.AppendTag(lb, 2, protowire.BytesType)
+			lb = protowire.AppendString(lb, l.Value)
+
+			ts = protowire.AppendTag(ts, 1, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, lb)
+		}
+		var sample []byte
+		sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+		sample = protowire.AppendFixed64(sample, math.Float64bits(s.Value))
+		sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, uint64(s.Timestamp))
+
+		ts = protowire.AppendTag(ts, 2, protowire.BytesType)
+		ts = protowire.AppendBytes(ts, sample)
+
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, ts)
+	}
+	return b
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package remotewrite
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/ide-metrics-api/config"
+	"github.com/google/go-cmp/cmp"
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestExport(t *testing.T) {
+	type request struct {
+		Status int
+		Series []string
+	}
+	var (
+		mu       sync.Mutex
+		requests []request
+		statuses = []int{http.StatusServiceUnavailable, http.StatusOK, http.StatusBadRequest}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "snappy" || r.Header.Get("Authorization") != "" {
+			t.Errorf("unexpected headers: %v", r.Header)
+		}
+		compressed, _ := io.ReadAll(r.Body)
+		b, err := snappy.Decode(nil, compressed)
+		if err != nil {
+			t.Errorf("cannot decode request: %v", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		status := statuses[0]
+		statuses = statuses[1:]
+		requests = append(requests, request{Status: status, Series: decodeWriteRequest(t, b)})
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "gitpod_test_counter"}, []string{"ide"})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "gitpod_test_hist", Buckets: []float64{1, 10}})
+	ignored := prometheus.NewCounter(prometheus.CounterOpts{Name: "gitpod_not_allowed"})
+	reg.MustRegister(counter, histogram, ignored)
+	counter.WithLabelValues("vscode").Add(3)
+	histogram.Observe(5)
+	ignored.Inc()
+
+	e, err := NewExporter(config.RemoteWriteConfiguration{
+		URL:               srv.URL,
+		ExternalLabels:    map[string]string{"cluster": "eu01", "ide": "none"},
+		MaxSamplesPerSend: 4,
+		MaxRetries:        2,
+	}, reg, func(name string) bool { return name == "gitpod_test_counter" || name == "gitpod_test_hist" }, reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.backoff = time.Millisecond
+
+	e.Export(context.Background())
+
+	expectation := []request{
+		{
+			Status: http.StatusServiceUnavailable,
+			Series: []string{
+				`{__name__="gitpod_test_counter",cluster="eu01",ide="vscode"} 3`,
+				`{__name__="gitpod_test_hist_bucket",cluster="eu01",ide="none",le="1"} 0`,
+				`{__name__="gitpod_test_hist_bucket",cluster="eu01",ide="none",le="10"} 1`,
+				`{__name__="gitpod_test_hist_bucket",cluster="eu01",ide="none",le="+Inf"} 1`,
+			},
+		},
+		{
+			Status: http.StatusOK,
+			Series: []string{
+				`{__name__="gitpod_test_counter",cluster="eu01",ide="vscode"} 3`,
+				`{__name__="gitpod_test_hist_bucket",cluster="eu01",ide="none",le="1"} 0`,
+				`{__name__="gitpod_test_hist_bucket",cluster="eu01",ide="none",le="10"} 1`,
+				`{__name__="gitpod_test_hist_bucket",cluster="eu01",ide="none",le="+Inf"} 1`,
+			},
+		},
+		{
+			Status: http.StatusBadRequest,
+			Series: []string{
+				`{__name__="gitpod_test_hist_count",cluster="eu01",ide="none"} 1`,
+				`{__name__="gitpod_test_hist_sum",cluster="eu01",ide="none"} 5`,
+			},
+		},
+	}
+	for _, r := range expectation {
+		sort.Strings(r.Series)
+	}
+	if diff := cmp.Diff(expectation, requests); diff != "" {
+		t.Errorf("unexpected requests (-want +got):\n%s", diff)
+	}
+}
+
+// decodeWriteRequest decodes the series of a remote-write request in text format
+func decodeWriteRequest(t *testing.T, b []byte) []string {
+	var result []string
+	forEachField(t, b, func(num protowire.Number, ts []byte) {
+		var (
+			labels []string
+			value  float64
+		)
+		forEachField(t, ts, func(num protowire.Number, v []byte) {
+			switch num {
+			case 1:
+				var name, value string
+				forEachField(t, v, func(num protowire.Number, v []byte) {
+					if num == 1 {
+						name = string(v)
+					} else {
+						value = string(v)
+					}
+				})
+				labels = append(labels, name+`="`+value+`"`)
+			case 2:
+				bits, n := protowire.ConsumeFixed64(v[1:])
+				if n < 0 {
+					t.Fatalf("invalid sample")
+				}
+				value = math.Float64frombits(bits)
+			}
+		})
+		result = append(result, "{"+strings.Join(labels, ",")+"} "+formatFloat(value))
+	})
+	sort.Strings(result)
+	return result
+}
+
+func forEachField(t *testing.T, b []byte, f func(num protowire.Number, v []byte)) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 || typ != protowire.BytesType {
+			t.Fatalf("unexpected field %d of type %d", num, typ)
+		}
+		b = b[n:]
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			t.Fatalf("invalid field %d", num)
+		}
+		b = b[n:]
+		f(num, v)
+	}
+}
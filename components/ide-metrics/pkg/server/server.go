@@ -39,7 +39,8 @@ type IDEMetricsServer struct {
 	aggregatedHistogramMap   map[string]*allowListCollector
 	reportedUnexpectedMetric map[string]struct{}
 
-	errorReporter errorreporter.ErrorReporter
+	errorReporter   errorreporter.ErrorReporter
+	errorAggregator *errorreporter.Aggregator
 
 	api.UnimplementedMetricsServiceServer
 }
@@ -214,7 +215,7 @@ func (s *IDEMetricsServer) ReportError(ctx context.Context, req *api.ReportError
 	if !allow {
 		return nil, errors.New("invalid component name")
 	}
-	s.errorReporter.Report(errorreporter.ReportedErrorEvent{
+	event := errorreporter.ReportedErrorEvent{
 		Type:        "type.googleapis.com/google.devtools.clouderrorreporting.v1beta1.ReportedErrorEvent",
 		Severity:    "ERROR",
 		Message:     req.ErrorStack,
@@ -226,10 +227,29 @@ func (s *IDEMetricsServer) ReportError(ctx context.Context, req *api.ReportError
 			Service: req.Component,
 			Version: req.Version,
 		},
-	})
+	}
+	s.errorReporter.Report(event)
+	s.errorAggregator.Report(event)
 	return &api.ReportErrorResponse{}, nil
 }
 
+// ErrorsHandler serves the reported errors aggregated by fingerprint
+func (s *IDEMetricsServer) ErrorsHandler() http.Handler {
+	return s.errorAggregator
+}
+
+// IsAllowListed returns true if name is a configured counter, histogram or aggregated histogram
+func (s *IDEMetricsServer) IsAllowListed(name string) bool {
+	if _, ok := s.counterMap[name]; ok {
+		return true
+	}
+	if _, ok := s.histogramMap[name]; ok {
+		return true
+	}
+	_, ok := s.aggregatedHistogramMap[name]
+	return ok
+}
+
 func (s *IDEMetricsServer) registerCounterMetrics() {
 	for _, m := range s.config.Server.CounterMetrics {
 		if _, ok := s.counterMap[m.Name]; ok {
@@ -311,6 +331,7 @@ func NewMetricsServer(cfg *config.ServiceConfiguration, reg prometheus.Registere
 		aggregatedHistogramMap:   make(map[string]*allowListCollector),
 		reportedUnexpectedMetric: make(map[string]struct{}),
 		errorReporter:            r,
+		errorAggregator:          errorreporter.NewAggregator(cfg.Server.ErrorReporting.MaxAggregatedErrors),
 	}
 	s.prepareMetrics()
 	return s
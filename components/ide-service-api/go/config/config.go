@@ -16,6 +16,11 @@ import (
 type ServiceConfiguration struct {
 	Server        *baseserver.Configuration `json:"server,omitempty"`
 	IDEConfigPath string                    `json:"ideConfigPath"`
+	// RolloutPath is the file which configures the staged rollout of further IDE config versions.
+	// Without it, the IDE config at IDEConfigPath is served to everyone.
+	RolloutPath string `json:"rolloutPath,omitempty"`
+	// IDEPluginsPath is a directory of IDE plugin manifests, which are merged into the IDE options
+	IDEPluginsPath string `json:"idePluginsPath,omitempty"`
 }
 
 func Read(fn string) (*ServiceConfiguration, error) {
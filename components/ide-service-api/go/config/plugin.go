@@ -0,0 +1,73 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package config
+
+// IDEPluginManifest registers an IDE with ide-service without changing the IDE config.
+// The IDE is merged into the IDE options of everyone it is available to.
+// Manifests are read from the idePluginsPath directory, the installer mounts the ConfigMap
+// configured in components.ide.pluginsConfigMap there.
+type IDEPluginManifest struct {
+	// ID of the IDE in the IDE options, e.g. "acme-theia". It must not be used by the IDE config.
+	ID string `json:"id"`
+	// Title with human readable text of the IDE (plain text only).
+	Title string `json:"title"`
+	// Type of the IDE, currently 'browser' or 'desktop'.
+	Type IDEType `json:"type"`
+	// Logo URL for the IDE.
+	Logo string `json:"logo"`
+	// Tooltip plain text only
+	Tooltip string `json:"tooltip,omitempty"`
+	// Label is next to the IDE option like “Browser” (plain text only).
+	Label string `json:"label,omitempty"`
+	// Notes to the IDE option that are rendered in the preferences when a user chooses this IDE.
+	Notes []string `json:"notes,omitempty"`
+	// OrderKey to ensure a stable order one can set an `orderKey`.
+	OrderKey string `json:"orderKey,omitempty"`
+
+	// Image ref to the IDE image, e.g. from the registry of an organization.
+	Image string `json:"image"`
+	// ImageLayers for additional ide layers and dependencies
+	ImageLayers []string `json:"imageLayers,omitempty"`
+	// LatestImage ref to the IDE image users get who opt into latest IDE versions.
+	LatestImage string `json:"latestImage,omitempty"`
+	// LatestImageLayers for latest additional ide layers and dependencies
+	LatestImageLayers []string `json:"latestImageLayers,omitempty"`
+
+	// Entrypoint is the command supervisor starts the IDE with.
+	Entrypoint string `json:"entrypoint"`
+	// EntrypointArgs are passed to the entrypoint.
+	EntrypointArgs []string `json:"entrypointArgs,omitempty"`
+	// ReadinessProbe tells supervisor when the IDE is ready.
+	ReadinessProbe IDEPluginReadinessProbe `json:"readinessProbe"`
+	// Ports the IDE serves besides the IDE port. They are not exposed or shown to the user.
+	Ports []uint32 `json:"ports,omitempty"`
+
+	// Clients supporting the IDE, e.g. "jetbrains-gateway". Desktop IDEs only.
+	Clients []string `json:"clients,omitempty"`
+	// Organizations the IDE is available to, by organization ID. The IDE is available to everyone if empty.
+	Organizations []string `json:"organizations,omitempty"`
+}
+
+type IDEPluginReadinessProbeType string
+
+const (
+	// IDEPluginReadinessProcess is ready once the IDE process has been started.
+	IDEPluginReadinessProcess IDEPluginReadinessProbeType = "process"
+	// IDEPluginReadinessHTTP is ready once a single HTTP request against the IDE was successful.
+	IDEPluginReadinessHTTP IDEPluginReadinessProbeType = "http"
+)
+
+type IDEPluginReadinessProbe struct {
+	Type IDEPluginReadinessProbeType `json:"type"`
+	// HTTP configures the HTTP readiness probe
+	HTTP *IDEPluginHTTPProbe `json:"http,omitempty"`
+}
+
+type IDEPluginHTTPProbe struct {
+	// Path is the path to make requests to. Defaults to "/".
+	Path string `json:"path,omitempty"`
+	// Port is the port to make requests to. Defaults to the IDE port.
+	Port int `json:"port,omitempty"`
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package config
+
+// DefaultIDEConfigVersion is the version of the IDE config at ServiceConfiguration.IDEConfigPath,
+// which is served to everyone no rollout applies to.
+const DefaultIDEConfigVersion = "default"
+
+type RolloutConfig struct {
+	// Versions are IDE config versions rolled out on top of the default IDE config.
+	// The first version whose rollout rules match a user applies.
+	Versions []IDEConfigVersion `json:"versions"`
+}
+
+type IDEConfigVersion struct {
+	// Version names the IDE config, e.g. "2023-02-25"
+	Version string `json:"version"`
+	// Path of the IDE config file, relative paths are resolved against the rollout file
+	Path string `json:"path"`
+	// Disabled rolls back everyone to the default IDE config, e.g. to take back a broken release
+	Disabled bool `json:"disabled,omitempty"`
+	// Rollout selects the users this version is served to
+	Rollout RolloutRules `json:"rollout"`
+}
+
+// RolloutRules select users, a user is selected if any rule matches
+type RolloutRules struct {
+	// Percentage of users, between 0 and 100. Users are assigned to a stable bucket per version.
+	Percentage int `json:"percentage,omitempty"`
+	// Organizations whose members are selected
+	Organizations []string `json:"organizations,omitempty"`
+	// OptInFlag is a feature flag users opt in with
+	OptInFlag string `json:"optInFlag,omitempty"`
+}
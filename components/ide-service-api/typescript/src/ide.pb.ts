@@ -56,6 +56,8 @@ export function workspaceTypeToNumber(object: WorkspaceType): number {
 }
 
 export interface GetConfigRequest {
+  /** user selects the IDE config version rolled out to them, the default IDE config is served if unset */
+  user: User | undefined;
 }
 
 export interface GetConfigResponse {
@@ -74,6 +76,8 @@ export interface EnvironmentVariable {
 export interface User {
   id: string;
   email?: string | undefined;
+  /** organization_ids are the organizations the user is a member of */
+  organizationIds: string[];
 }
 
 export interface ResolveWorkspaceConfigRequest {
@@ -92,14 +96,19 @@ export interface ResolveWorkspaceConfigResponse {
   /** control whether to configure default IDE for a user */
   refererIde: string;
   tasks: string;
+  /** ide_config_version is the version of the IDE config the response was resolved with */
+  ideConfigVersion: string;
 }
 
 function createBaseGetConfigRequest(): GetConfigRequest {
-  return {};
+  return { user: undefined };
 }
 
 export const GetConfigRequest = {
-  encode(_: GetConfigRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  encode(message: GetConfigRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.user !== undefined) {
+      User.encode(message.user, writer.uint32(10).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -110,6 +119,9 @@ export const GetConfigRequest = {
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
+        case 1:
+          message.user = User.decode(reader, reader.uint32());
+          break;
         default:
           reader.skipType(tag & 7);
           break;
@@ -118,17 +130,19 @@ export const GetConfigRequest = {
     return message;
   },
 
-  fromJSON(_: any): GetConfigRequest {
-    return {};
+  fromJSON(object: any): GetConfigRequest {
+    return { user: isSet(object.user) ? User.fromJSON(object.user) : undefined };
   },
 
-  toJSON(_: GetConfigRequest): unknown {
+  toJSON(message: GetConfigRequest): unknown {
     const obj: any = {};
+    message.user !== undefined && (obj.user = message.user ? User.toJSON(message.user) : undefined);
     return obj;
   },
 
-  fromPartial(_: DeepPartial<GetConfigRequest>): GetConfigRequest {
+  fromPartial(object: DeepPartial<GetConfigRequest>): GetConfigRequest {
     const message = createBaseGetConfigRequest();
+    message.user = (object.user !== undefined && object.user !== null) ? User.fromPartial(object.user) : undefined;
     return message;
   },
 };
@@ -239,7 +253,7 @@ export const EnvironmentVariable = {
 };
 
 function createBaseUser(): User {
-  return { id: "", email: undefined };
+  return { id: "", email: undefined, organizationIds: [] };
 }
 
 export const User = {
@@ -250,6 +264,9 @@ export const User = {
     if (message.email !== undefined) {
       writer.uint32(18).string(message.email);
     }
+    for (const v of message.organizationIds) {
+      writer.uint32(26).string(v!);
+    }
     return writer;
   },
 
@@ -266,6 +283,9 @@ export const User = {
         case 2:
           message.email = reader.string();
           break;
+        case 3:
+          message.organizationIds.push(reader.string());
+          break;
         default:
           reader.skipType(tag & 7);
           break;
@@ -278,6 +298,7 @@ export const User = {
     return {
       id: isSet(object.id) ? String(object.id) : "",
       email: isSet(object.email) ? String(object.email) : undefined,
+      organizationIds: Array.isArray(object?.organizationIds) ? object.organizationIds.map((e: any) => String(e)) : [],
     };
   },
 
@@ -285,6 +306,11 @@ export const User = {
     const obj: any = {};
     message.id !== undefined && (obj.id = message.id);
     message.email !== undefined && (obj.email = message.email);
+    if (message.organizationIds) {
+      obj.organizationIds = message.organizationIds.map((e) => e);
+    } else {
+      obj.organizationIds = [];
+    }
     return obj;
   },
 
@@ -292,6 +318,7 @@ export const User = {
     const message = createBaseUser();
     message.id = object.id ?? "";
     message.email = object.email ?? undefined;
+    message.organizationIds = object.organizationIds?.map((e) => e) || [];
     return message;
   },
 };
@@ -382,7 +409,7 @@ export const ResolveWorkspaceConfigRequest = {
 };
 
 function createBaseResolveWorkspaceConfigResponse(): ResolveWorkspaceConfigResponse {
-  return { envvars: [], supervisorImage: "", webImage: "", ideImageLayers: [], refererIde: "", tasks: "" };
+  return { envvars: [], supervisorImage: "", webImage: "", ideImageLayers: [], refererIde: "", tasks: "", ideConfigVersion: "" };
 }
 
 export const ResolveWorkspaceConfigResponse = {
@@ -405,6 +432,9 @@ export const ResolveWorkspaceConfigResponse = {
     if (message.tasks !== "") {
       writer.uint32(50).string(message.tasks);
     }
+    if (message.ideConfigVersion !== "") {
+      writer.uint32(58).string(message.ideConfigVersion);
+    }
     return writer;
   },
 
@@ -433,6 +463,9 @@ export const ResolveWorkspaceConfigResponse = {
         case 6:
           message.tasks = reader.string();
           break;
+        case 7:
+          message.ideConfigVersion = reader.string();
+          break;
         default:
           reader.skipType(tag & 7);
           break;
@@ -449,6 +482,7 @@ export const ResolveWorkspaceConfigResponse = {
       ideImageLayers: Array.isArray(object?.ideImageLayers) ? object.ideImageLayers.map((e: any) => String(e)) : [],
       refererIde: isSet(object.refererIde) ? String(object.refererIde) : "",
       tasks: isSet(object.tasks) ? String(object.tasks) : "",
+      ideConfigVersion: isSet(object.ideConfigVersion) ? String(object.ideConfigVersion) : "",
     };
   },
 
@@ -468,6 +502,7 @@ export const ResolveWorkspaceConfigResponse = {
     }
     message.refererIde !== undefined && (obj.refererIde = message.refererIde);
     message.tasks !== undefined && (obj.tasks = message.tasks);
+    message.ideConfigVersion !== undefined && (obj.ideConfigVersion = message.ideConfigVersion);
     return obj;
   },
 
@@ -479,6 +514,7 @@ export const ResolveWorkspaceConfigResponse = {
     message.ideImageLayers = object.ideImageLayers?.map((e) => e) || [];
     message.refererIde = object.refererIde ?? "";
     message.tasks = object.tasks ?? "";
+    message.ideConfigVersion = object.ideConfigVersion ?? "";
     return message;
   },
 };
@@ -0,0 +1,256 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	api "github.com/gitpod-io/gitpod/ide-service-api"
+	"github.com/gitpod-io/gitpod/ide-service-api/config"
+	"github.com/xeipuuv/gojsonschema"
+	"golang.org/x/xerrors"
+)
+
+// reservedPorts are used by supervisor and the IDEs it starts, IDE plugins cannot claim them
+var reservedPorts = map[uint32]string{
+	22999: "supervisor",
+	23000: "IDE",
+	23001: "SSH",
+	24000: "desktop IDE",
+}
+
+// pluginState are the IDE plugins which are currently registered, ordered by ID
+type pluginState struct {
+	Manifests []*config.IDEPluginManifest
+}
+
+// ParseIDEPluginManifest parses and validates an IDE plugin manifest
+func ParseIDEPluginManifest(b []byte) (*config.IDEPluginManifest, error) {
+	var manifest config.IDEPluginManifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return nil, xerrors.Errorf("cannot parse ide plugin manifest: %w", err)
+	}
+
+	// validate with the idePluginManifest definition of the json schema
+	var schema struct {
+		Definitions map[string]interface{} `json:"definitions"`
+	}
+	if err := json.Unmarshal(jsonScheme, &schema); err != nil {
+		return nil, xerrors.Errorf("invalid schema: %w", err)
+	}
+	schemaLoader := gojsonschema.NewGoLoader(map[string]interface{}{
+		"definitions": schema.Definitions,
+		"$ref":        "#/definitions/idePluginManifest",
+	})
+	result, err := gojsonschema.Validate(schemaLoader, gojsonschema.NewBytesLoader(b))
+	if err != nil {
+		return nil, xerrors.Errorf("invalid: %w", err)
+	}
+	if !result.Valid() {
+		descs := make([]string, 0, len(result.Errors()))
+		for _, desc := range result.Errors() {
+			descs = append(descs, desc.String())
+		}
+		return nil, xerrors.Errorf("invalid ide plugin manifest: %s", strings.Join(descs, "; "))
+	}
+
+	for _, port := range manifest.Ports {
+		if name, reserved := reservedPorts[port]; reserved {
+			return nil, xerrors.Errorf("invalid ide plugin manifest %s: port %d is reserved for the %s", manifest.ID, port, name)
+		}
+	}
+	if manifest.Type != config.IDETypeDesktop && len(manifest.Clients) > 0 {
+		return nil, xerrors.Errorf("invalid ide plugin manifest %s: only desktop IDEs can support clients", manifest.ID)
+	}
+	if manifest.ReadinessProbe.Type != config.IDEPluginReadinessHTTP && manifest.ReadinessProbe.HTTP != nil {
+		return nil, xerrors.Errorf("invalid ide plugin manifest %s: http probe is configured for readiness probe type %s", manifest.ID, manifest.ReadinessProbe.Type)
+	}
+	return &manifest, nil
+}
+
+func (s *IDEServiceServer) readIDEPlugins() {
+	entries, err := os.ReadDir(s.idePluginsDir)
+	if err != nil {
+		log.WithError(err).Warn("cannot read ide plugins")
+		return
+	}
+
+	var (
+		state pluginState
+		ids   = make(map[string]struct{})
+	)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		fn := filepath.Join(s.idePluginsDir, entry.Name())
+		b, err := os.ReadFile(fn)
+		if err != nil {
+			log.WithError(err).WithField("path", fn).Error("cannot read ide plugin manifest")
+			continue
+		}
+		manifest, err := ParseIDEPluginManifest(b)
+		if err != nil {
+			log.WithError(err).WithField("path", fn).Error("cannot parse ide plugin manifest")
+			continue
+		}
+		if _, exists := ids[manifest.ID]; exists {
+			log.WithField("path", fn).WithField("ide", manifest.ID).Error("ide plugin is registered more than once")
+			continue
+		}
+		ids[manifest.ID] = struct{}{}
+		state.Manifests = append(state.Manifests, manifest)
+	}
+	sort.Slice(state.Manifests, func(i, j int) bool { return state.Manifests[i].ID < state.Manifests[j].ID })
+	s.idePlugins.Store(&state)
+
+	log.WithField("plugins", len(state.Manifests)).Info("ide plugins updated")
+}
+
+// watchIDEPlugins re-reads the IDE plugins whenever a manifest is added, changed or removed
+func (s *IDEServiceServer) watchIDEPlugins(ctx context.Context) {
+	s.readIDEPlugins()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.WithError(err).Fatal("cannot start watch of ide plugins")
+	}
+	defer watcher.Close()
+	err = watcher.Add(s.idePluginsDir)
+	if err != nil {
+		log.WithError(err).Fatal("cannot start watch of ide plugins")
+	}
+
+	for {
+		select {
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			s.readIDEPlugins()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.WithError(err).Warn("ide plugins watch failed")
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// mergeIDEPlugins adds the IDE plugins available to user to the IDE options of ideConfig.
+// ideConfig is returned unchanged if no plugin is available to the user.
+func (s *IDEServiceServer) mergeIDEPlugins(ideConfig *config.IDEConfig, user *api.User) (*config.IDEConfig, map[string]*config.IDEPluginManifest) {
+	state := s.idePlugins.Load()
+	if state == nil || ideConfig == nil {
+		return ideConfig, nil
+	}
+
+	plugins := make(map[string]*config.IDEPluginManifest)
+	for _, p := range state.Manifests {
+		if _, exists := ideConfig.IdeOptions.Options[p.ID]; exists {
+			continue
+		}
+		if !pluginAvailableTo(p, user) {
+			continue
+		}
+		plugins[p.ID] = p
+	}
+	if len(plugins) == 0 {
+		return ideConfig, nil
+	}
+
+	merged := *ideConfig
+	merged.IdeOptions.Options = make(map[string]config.IDEOption, len(ideConfig.IdeOptions.Options)+len(plugins))
+	for id, option := range ideConfig.IdeOptions.Options {
+		merged.IdeOptions.Options[id] = option
+	}
+	merged.IdeOptions.Clients = make(map[string]config.IDEClient, len(ideConfig.IdeOptions.Clients))
+	for id, client := range ideConfig.IdeOptions.Clients {
+		merged.IdeOptions.Clients[id] = client
+	}
+	for _, p := range state.Manifests {
+		if _, ok := plugins[p.ID]; !ok {
+			continue
+		}
+		merged.IdeOptions.Options[p.ID] = config.IDEOption{
+			OrderKey:          p.OrderKey,
+			Title:             p.Title,
+			Type:              p.Type,
+			Logo:              p.Logo,
+			Tooltip:           p.Tooltip,
+			Label:             p.Label,
+			Notes:             p.Notes,
+			Image:             p.Image,
+			ImageLayers:       p.ImageLayers,
+			LatestImage:       p.LatestImage,
+			LatestImageLayers: p.LatestImageLayers,
+		}
+		for _, id := range p.Clients {
+			client, ok := merged.IdeOptions.Clients[id]
+			if !ok {
+				continue
+			}
+			client.DesktopIDEs = append(append([]string(nil), client.DesktopIDEs...), p.ID)
+			merged.IdeOptions.Clients[id] = client
+		}
+	}
+	return &merged, plugins
+}
+
+func pluginAvailableTo(p *config.IDEPluginManifest, user *api.User) bool {
+	if len(p.Organizations) == 0 {
+		return true
+	}
+	for _, org := range user.GetOrganizationIds() {
+		for _, o := range p.Organizations {
+			if o == org {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// supervisorIDEConfig is the IDE config supervisor expects, see components/supervisor/pkg/supervisor/config.go
+type supervisorIDEConfig struct {
+	Entrypoint     string   `json:"entrypoint"`
+	EntrypointArgs []string `json:"entrypointArgs,omitempty"`
+	InternalPorts  []uint32 `json:"internalPorts,omitempty"`
+	ReadinessProbe struct {
+		Type string                     `json:"type"`
+		HTTP *config.IDEPluginHTTPProbe `json:"http,omitempty"`
+	} `json:"readinessProbe"`
+}
+
+// pluginConfigEnvVar passes the entrypoint and readiness probe of an IDE plugin to supervisor
+func pluginConfigEnvVar(name string, p *config.IDEPluginManifest) (*api.EnvironmentVariable, error) {
+	cfg := supervisorIDEConfig{
+		Entrypoint:     p.Entrypoint,
+		EntrypointArgs: p.EntrypointArgs,
+		InternalPorts:  p.Ports,
+	}
+	// supervisor's process probe is the empty type
+	if p.ReadinessProbe.Type == config.IDEPluginReadinessHTTP {
+		cfg.ReadinessProbe.Type = string(config.IDEPluginReadinessHTTP)
+		cfg.ReadinessProbe.HTTP = p.ReadinessProbe.HTTP
+	}
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &api.EnvironmentVariable{
+		Name:  name,
+		Value: string(b),
+	}, nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/common-go/baseserver"
+	api "github.com/gitpod-io/gitpod/ide-service-api"
+	"github.com/gitpod-io/gitpod/ide-service-api/config"
+)
+
+func newPluginTestServer() *IDEServiceServer {
+	server := New(&config.ServiceConfiguration{
+		Server:         &baseserver.Configuration{},
+		IDEConfigPath:  "../../example-ide-config.json",
+		IDEPluginsPath: "testdata/plugins",
+	})
+	server.readIDEConfig(context.Background(), true)
+	server.readIDEPlugins()
+	return server
+}
+
+func TestParseIDEPluginManifest(t *testing.T) {
+	valid := `{"id":"acme","title":"ACME","type":"desktop","logo":"logo.svg","image":"acme:1","entrypoint":"/bin/acme","readinessProbe":{"type":"process"}`
+	tests := []struct {
+		Desc    string
+		Content string
+		Error   string
+	}{
+		{"valid", valid + `}`, ""},
+		{"missing entrypoint", `{"id":"acme","title":"ACME","type":"desktop","logo":"logo.svg","image":"acme:1","readinessProbe":{"type":"process"}}`, "entrypoint is required"},
+		{"invalid id", strings.Replace(valid, `"acme"`, `"ACME IDE"`, 1) + `}`, "id: Does not match pattern"},
+		{"unknown field", valid + `,"command":"acme"}`, "Additional property command is not allowed"},
+		{"reserved port", valid + `,"ports":[23001]}`, "port 23001 is reserved for the SSH"},
+		{"browser with clients", strings.Replace(valid, `"desktop"`, `"browser"`, 1) + `,"clients":["jetbrains-gateway"]}`, "only desktop IDEs can support clients"},
+		{"http probe for process", strings.Replace(valid, `{"type":"process"}`, `{"type":"process","http":{"path":"/"}}`, 1) + `}`, "http probe is configured"},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			_, err := ParseIDEPluginManifest([]byte(test.Content))
+			if test.Error == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.Error) {
+				t.Errorf("expected error containing %q, got %v", test.Error, err)
+			}
+		})
+	}
+}
+
+func TestGetConfigMergesIDEPlugins(t *testing.T) {
+	server := newPluginTestServer()
+
+	tests := []struct {
+		Desc        string
+		User        *api.User
+		Expectation []string
+	}{
+		{"no user", nil, []string{"acme-fleet"}},
+		{"organization member", &api.User{Id: "foo", OrganizationIds: []string{"org-acme"}}, []string{"acme-fleet", "acme-theia"}},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			resp, err := server.GetConfig(context.Background(), &api.GetConfigRequest{User: test.User})
+			if err != nil {
+				t.Fatalf("cannot get config: %v", err)
+			}
+			var cfg config.IDEConfig
+			err = json.Unmarshal([]byte(resp.Content), &cfg)
+			if err != nil {
+				t.Fatalf("cannot unmarshal config: %v", err)
+			}
+
+			var act []string
+			for id := range cfg.IdeOptions.Options {
+				if strings.HasPrefix(id, "acme-") {
+					act = append(act, id)
+				}
+			}
+			sort.Strings(act)
+			if strings.Join(act, ",") != strings.Join(test.Expectation, ",") {
+				t.Errorf("expected plugins %v, got %v", test.Expectation, act)
+			}
+			if desktopIDEs := cfg.IdeOptions.Clients["jetbrains-gateway"].DesktopIDEs; desktopIDEs[len(desktopIDEs)-1] != "acme-fleet" {
+				t.Errorf("expected acme-fleet to be supported by jetbrains-gateway, got %v", desktopIDEs)
+			}
+		})
+	}
+
+	// the IDE config itself must not change
+	if _, ok := server.ideConfig.IdeOptions.Options["acme-fleet"]; ok {
+		t.Errorf("merging plugins changed the IDE config")
+	}
+}
+
+func TestResolveWorkspaceConfigWithIDEPlugin(t *testing.T) {
+	server := newPluginTestServer()
+
+	resp, err := server.ResolveWorkspaceConfig(context.Background(), &api.ResolveWorkspaceConfigRequest{
+		Type:        api.WorkspaceType_REGULAR,
+		IdeSettings: `{"defaultIde":"acme-theia"}`,
+		User:        &api.User{Id: "foo", OrganizationIds: []string{"org-acme"}},
+	})
+	if err != nil {
+		t.Fatalf("cannot resolve workspace config: %v", err)
+	}
+	if resp.WebImage != "registry.acme.com/ide/theia:1.35.0" {
+		t.Errorf("unexpected web image %s", resp.WebImage)
+	}
+	var pluginConfig string
+	for _, e := range resp.Envvars {
+		if e.Name == "GITPOD_IDE_PLUGIN_CONFIG" {
+			pluginConfig = e.Value
+		}
+	}
+	expectation := `{"entrypoint":"/ide/bin/theia","entrypointArgs":["--port","{IDEPORT}"],"internalPorts":[23010],"readinessProbe":{"type":"http","http":{"path":"/health"}}}`
+	if pluginConfig != expectation {
+		t.Errorf("unexpected plugin config:\n%s\nexpected:\n%s", pluginConfig, expectation)
+	}
+
+	// users outside the organization cannot use the plugin and get the default IDE
+	resp, err = server.ResolveWorkspaceConfig(context.Background(), &api.ResolveWorkspaceConfigRequest{
+		Type:        api.WorkspaceType_REGULAR,
+		IdeSettings: `{"defaultIde":"acme-theia"}`,
+		User:        &api.User{Id: "bar"},
+	})
+	if err != nil {
+		t.Fatalf("cannot resolve workspace config: %v", err)
+	}
+	if resp.WebImage == "registry.acme.com/ide/theia:1.35.0" {
+		t.Errorf("expected default IDE for users outside the organization")
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+
+	"github.com/gitpod-io/gitpod/common-go/experiments"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/watch"
+	api "github.com/gitpod-io/gitpod/ide-service-api"
+	"github.com/gitpod-io/gitpod/ide-service-api/config"
+	"golang.org/x/xerrors"
+)
+
+// rolloutState are the IDE config versions which are currently rolled out, in order of precedence
+type rolloutState struct {
+	Versions []*ideConfigVersion
+}
+
+type ideConfigVersion struct {
+	Version                string
+	Rollout                config.RolloutRules
+	ideConfig              *config.IDEConfig
+	parsedIDEConfigContent string
+}
+
+// ParseRolloutConfig parses and validates a rollout config
+func ParseRolloutConfig(b []byte) (*config.RolloutConfig, error) {
+	var cfg config.RolloutConfig
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, xerrors.Errorf("cannot parse rollout config: %w", err)
+	}
+
+	versions := make(map[string]struct{}, len(cfg.Versions))
+	for _, v := range cfg.Versions {
+		if v.Version == "" {
+			return nil, xerrors.Errorf("invalid rollout config: version has no name")
+		}
+		if v.Version == config.DefaultIDEConfigVersion {
+			return nil, xerrors.Errorf("invalid rollout config: version %s is reserved for the default ide config", v.Version)
+		}
+		if _, exists := versions[v.Version]; exists {
+			return nil, xerrors.Errorf("invalid rollout config: version %s is configured more than once", v.Version)
+		}
+		versions[v.Version] = struct{}{}
+		if v.Path == "" {
+			return nil, xerrors.Errorf("invalid rollout config: version %s has no path", v.Version)
+		}
+		if v.Rollout.Percentage < 0 || v.Rollout.Percentage > 100 {
+			return nil, xerrors.Errorf("invalid rollout config: version %s: percentage must be between 0 and 100", v.Version)
+		}
+	}
+	return &cfg, nil
+}
+
+func (s *IDEServiceServer) readRollout(ctx context.Context) {
+	b, err := os.ReadFile(s.rolloutFileName)
+	if err != nil {
+		log.WithError(err).Warn("cannot read ide config rollout file")
+		return
+	}
+	cfg, err := ParseRolloutConfig(b)
+	if err != nil {
+		log.WithError(err).Error("cannot parse ide config rollout")
+		return
+	}
+
+	var (
+		state    rolloutState
+		versions []string
+	)
+	for _, v := range cfg.Versions {
+		if v.Disabled {
+			log.WithField("version", v.Version).Info("ide config version is disabled")
+			continue
+		}
+		version, err := s.readIDEConfigVersion(ctx, v)
+		if err != nil {
+			// users of this version fall back to the default ide config
+			log.WithError(err).WithField("version", v.Version).Error("cannot read ide config version")
+			continue
+		}
+		state.Versions = append(state.Versions, version)
+		versions = append(versions, v.Version)
+	}
+	s.rollout.Store(&state)
+
+	log.WithField("versions", versions).Info("ide config rollout updated")
+}
+
+func (s *IDEServiceServer) readIDEConfigVersion(ctx context.Context, v config.IDEConfigVersion) (*ideConfigVersion, error) {
+	fn := v.Path
+	if !filepath.IsAbs(fn) {
+		fn = filepath.Join(filepath.Dir(s.rolloutFileName), fn)
+	}
+	b, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read ide config: %w", err)
+	}
+	ideConfig, err := ParseConfig(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	parsedConfig, err := json.Marshal(ideConfig)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot marshal ide config: %w", err)
+	}
+	return &ideConfigVersion{
+		Version:                v.Version,
+		Rollout:                v.Rollout,
+		ideConfig:              ideConfig,
+		parsedIDEConfigContent: string(parsedConfig),
+	}, nil
+}
+
+func (s *IDEServiceServer) watchRollout(ctx context.Context) {
+	s.readRollout(ctx)
+
+	if err := watch.File(ctx, s.rolloutFileName, func() {
+		s.readRollout(ctx)
+	}); err != nil {
+		log.WithError(err).Fatal("cannot start watch of ide config rollout file")
+	}
+}
+
+// chooseIDEConfig returns the IDE config version rolled out to user, or the default IDE config
+func (s *IDEServiceServer) chooseIDEConfig(ctx context.Context, user *api.User) (version string, ideConfig *config.IDEConfig, content string) {
+	if rollout := s.rollout.Load(); rollout != nil && user != nil {
+		for _, v := range rollout.Versions {
+			if s.rolloutMatches(ctx, v.Version, v.Rollout, user) {
+				return v.Version, v.ideConfig, v.parsedIDEConfigContent
+			}
+		}
+	}
+	return config.DefaultIDEConfigVersion, s.ideConfig, s.parsedIDEConfigContent
+}
+
+func (s *IDEServiceServer) rolloutMatches(ctx context.Context, version string, rules config.RolloutRules, user *api.User) bool {
+	for _, org := range user.OrganizationIds {
+		for _, o := range rules.Organizations {
+			if o == org {
+				return true
+			}
+		}
+	}
+	if rules.Percentage > 0 && user.Id != "" && rolloutBucket(version, user.Id) < rules.Percentage {
+		return true
+	}
+	if rules.OptInFlag != "" {
+		attributes := experiments.Attributes{
+			UserID:    user.Id,
+			UserEmail: user.GetEmail(),
+		}
+		return s.experiemntsClient.GetBoolValue(ctx, rules.OptInFlag, false, attributes)
+	}
+	return false
+}
+
+// rolloutBucket assigns a user to one of 100 buckets. The buckets differ per version,
+// so that not always the same users are the first to get a new version.
+func rolloutBucket(version, userID string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(version + "/" + userID))
+	return int(h.Sum32() % 100)
+}
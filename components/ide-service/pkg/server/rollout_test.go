@@ -0,0 +1,105 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/common-go/baseserver"
+	"github.com/gitpod-io/gitpod/common-go/experiments"
+	"github.com/gitpod-io/gitpod/common-go/experiments/experimentstest"
+	api "github.com/gitpod-io/gitpod/ide-service-api"
+	"github.com/gitpod-io/gitpod/ide-service-api/config"
+)
+
+func TestChooseIDEConfig(t *testing.T) {
+	cfg := &config.ServiceConfiguration{
+		Server:        &baseserver.Configuration{},
+		IDEConfigPath: "testdata/ideconfig_happypath.json",
+		RolloutPath:   "testdata/rollout.json",
+	}
+	server := New(cfg)
+	server.experiemntsClient = &experimentstest.Client{
+		BoolMatcher: func(ctx context.Context, experiment string, defaultValue bool, attributes experiments.Attributes) bool {
+			return experiment == "ide_config_beta" && attributes.UserID == "beta-user"
+		},
+	}
+	// resolving the IDE images requires a registry, the canceled context keeps the test offline
+	offline, cancel := context.WithCancel(context.Background())
+	cancel()
+	server.readIDEConfig(offline, true)
+	server.readRollout(offline)
+
+	tests := []struct {
+		Desc        string
+		User        *api.User
+		Expectation string
+	}{
+		{"no user", nil, config.DefaultIDEConfigVersion},
+		{"organization member", &api.User{Id: "foo", OrganizationIds: []string{"org-other", "org-canary"}}, "canary"},
+		{"opted in", &api.User{Id: "beta-user"}, "beta"},
+		{"disabled and broken versions are skipped", &api.User{Id: "foo"}, config.DefaultIDEConfigVersion},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			resp, err := server.ResolveWorkspaceConfig(context.Background(), &api.ResolveWorkspaceConfigRequest{
+				Type: api.WorkspaceType_REGULAR,
+				User: test.User,
+			})
+			if err != nil {
+				t.Fatalf("cannot resolve workspace config: %v", err)
+			}
+			if resp.IdeConfigVersion != test.Expectation {
+				t.Errorf("expected ide config version %s, got %s", test.Expectation, resp.IdeConfigVersion)
+			}
+		})
+	}
+}
+
+func TestRolloutPercentage(t *testing.T) {
+	rules := config.RolloutRules{Percentage: 10}
+	server := &IDEServiceServer{experiemntsClient: experiments.NewAlwaysReturningDefaultValueClient()}
+
+	var selected int
+	for i := 0; i < 1000; i++ {
+		user := &api.User{Id: fmt.Sprintf("user-%d", i)}
+		matches := server.rolloutMatches(context.Background(), "v1", rules, user)
+		if matches != server.rolloutMatches(context.Background(), "v1", rules, user) {
+			t.Fatalf("rollout of %s is not stable", user.Id)
+		}
+		if matches {
+			selected++
+		}
+	}
+	if selected < 50 || selected > 150 {
+		t.Errorf("expected about 100 of 1000 users to be selected, got %d", selected)
+	}
+}
+
+func TestParseRolloutConfig(t *testing.T) {
+	tests := []struct {
+		Desc    string
+		Content string
+		Error   bool
+	}{
+		{"valid", `{"versions":[{"version":"v1","path":"v1.json","rollout":{"percentage":50}}]}`, false},
+		{"no name", `{"versions":[{"path":"v1.json"}]}`, true},
+		{"no path", `{"versions":[{"version":"v1"}]}`, true},
+		{"default version", `{"versions":[{"version":"default","path":"v1.json"}]}`, true},
+		{"duplicate version", `{"versions":[{"version":"v1","path":"v1.json"},{"version":"v1","path":"v2.json"}]}`, true},
+		{"invalid percentage", `{"versions":[{"version":"v1","path":"v1.json","rollout":{"percentage":150}}]}`, true},
+		{"unknown field", `{"versions":[{"version":"v1","path":"v1.json","percentage":50}]}`, true},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			_, err := ParseRolloutConfig([]byte(test.Content))
+			if (err != nil) != test.Error {
+				t.Errorf("expected error: %v, got %v", test.Error, err)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gitpod-io/gitpod/common-go/baseserver"
@@ -38,6 +39,14 @@ type IDEServiceServer struct {
 	ideConfigFileName      string
 	experiemntsClient      experiments.Client
 
+	rolloutFileName string
+	rollout         atomic.Pointer[rolloutState]
+
+	idePluginsDir string
+	idePlugins    atomic.Pointer[pluginState]
+
+	resolvedConfigVersions *prometheus.CounterVec
+
 	api.UnimplementedIDEServiceServer
 }
 
@@ -61,8 +70,15 @@ func Start(logger *logrus.Entry, cfg *config.ServiceConfiguration) error {
 
 	s := New(cfg)
 	go s.watchIDEConfig(ctx)
+	if s.rolloutFileName != "" {
+		go s.watchRollout(ctx)
+	}
+	if s.idePluginsDir != "" {
+		go s.watchIDEPlugins(ctx)
+	}
 	go s.scheduleUpdate(ctx)
 	s.register(srv.GRPC())
+	registry.MustRegister(s.resolvedConfigVersions)
 
 	health.AddReadinessCheck("ide-service", func() error {
 		if s.ideConfig == nil {
@@ -88,6 +104,24 @@ func New(cfg *config.ServiceConfiguration) *IDEServiceServer {
 		config:            cfg,
 		ideConfigFileName: fn,
 		experiemntsClient: experiments.NewClient(),
+		resolvedConfigVersions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gitpod",
+			Subsystem: "ide_service",
+			Name:      "resolved_config_versions_total",
+			Help:      "Number of workspace configs resolved per IDE config version",
+		}, []string{"version"}),
+	}
+	if cfg.RolloutPath != "" {
+		s.rolloutFileName, err = filepath.Abs(cfg.RolloutPath)
+		if err != nil {
+			log.WithField("path", cfg.RolloutPath).WithError(err).Fatal("cannot convert ide config rollout path to abs path")
+		}
+	}
+	if cfg.IDEPluginsPath != "" {
+		s.idePluginsDir, err = filepath.Abs(cfg.IDEPluginsPath)
+		if err != nil {
+			log.WithField("path", cfg.IDEPluginsPath).WithError(err).Fatal("cannot convert ide plugins path to abs path")
+		}
 	}
 	return s
 }
@@ -97,8 +131,17 @@ func (s *IDEServiceServer) register(grpcServer *grpc.Server) {
 }
 
 func (s *IDEServiceServer) GetConfig(ctx context.Context, req *api.GetConfigRequest) (*api.GetConfigResponse, error) {
+	_, ideConfig, content := s.chooseIDEConfig(ctx, req.User)
+	if merged, plugins := s.mergeIDEPlugins(ideConfig, req.User); len(plugins) > 0 {
+		parsedConfig, err := json.Marshal(merged)
+		if err != nil {
+			log.WithError(err).Error("cannot marshal ide config")
+			return nil, fmt.Errorf("cannot marshal ide config")
+		}
+		content = string(parsedConfig)
+	}
 	return &api.GetConfigResponse{
-		Content: s.parsedIDEConfigContent,
+		Content: content,
 	}, nil
 }
 
@@ -148,6 +191,9 @@ func (s *IDEServiceServer) scheduleUpdate(ctx context.Context) {
 		case <-t.C:
 			log.Info("schedule update config")
 			s.readIDEConfig(ctx, false)
+			if s.rolloutFileName != "" {
+				s.readRollout(ctx)
+			}
 		case <-ctx.Done():
 			t.Stop()
 			return
@@ -236,7 +282,10 @@ func (s *IDEServiceServer) ResolveWorkspaceConfig(ctx context.Context, req *api.
 	log.WithField("req", req).Debug("receive ResolveWorkspaceConfig request")
 
 	// make a copy for ref ideConfig, it's safe because we replace ref in update config
-	ideConfig := s.ideConfig
+	version, ideConfig, _ := s.chooseIDEConfig(ctx, req.User)
+	log.WithField("userId", req.User.GetId()).WithField("version", version).Debug("resolving workspace config with ide config version")
+	s.resolvedConfigVersions.WithLabelValues(version).Inc()
+	ideConfig, plugins := s.mergeIDEPlugins(ideConfig, req.User)
 
 	var defaultIde *config.IDEOption
 
@@ -249,8 +298,9 @@ func (s *IDEServiceServer) ResolveWorkspaceConfig(ctx context.Context, req *api.
 	}
 
 	resp = &api.ResolveWorkspaceConfigResponse{
-		SupervisorImage: ideConfig.SupervisorImage,
-		WebImage:        defaultIde.Image,
+		SupervisorImage:  ideConfig.SupervisorImage,
+		WebImage:         defaultIde.Image,
+		IdeConfigVersion: version,
 	}
 
 	var wsConfig *gitpodapi.GitpodConfig
@@ -286,6 +336,7 @@ func (s *IDEServiceServer) ResolveWorkspaceConfig(ctx context.Context, req *api.
 		}
 
 		chosenIDE := defaultIde
+		chosenIDEName := ideConfig.IdeOptions.DefaultIde
 
 		getUserIDEImage := func(ideOption *config.IDEOption) string {
 			if useLatest && ideOption.LatestImage != "" {
@@ -306,6 +357,7 @@ func (s *IDEServiceServer) ResolveWorkspaceConfig(ctx context.Context, req *api.
 		if userIdeName != "" {
 			if ide, ok := ideConfig.IdeOptions.Options[userIdeName]; ok {
 				chosenIDE = &ide
+				chosenIDEName = userIdeName
 
 				// TODO: Currently this variable reflects the IDE selected in
 				// user's settings for backward compatibility but in the future
@@ -324,12 +376,16 @@ func (s *IDEServiceServer) ResolveWorkspaceConfig(ctx context.Context, req *api.
 
 		var desktopImageLayer string
 		var desktopUserImageLayers []string
+		webIDEName := ideConfig.IdeOptions.DefaultIde
+		var desktopIDEName string
 		if chosenIDE.Type == config.IDETypeDesktop {
 			desktopImageLayer = getUserIDEImage(chosenIDE)
 			desktopUserImageLayers = getUserImageLayers(chosenIDE)
+			desktopIDEName = chosenIDEName
 		} else {
 			resp.WebImage = getUserIDEImage(chosenIDE)
 			webUserImageLayers = getUserImageLayers(chosenIDE)
+			webIDEName = chosenIDEName
 		}
 
 		ideName, referrer := s.resolveReferrerIDE(ideConfig, wsContext, userIdeName)
@@ -337,6 +393,24 @@ func (s *IDEServiceServer) ResolveWorkspaceConfig(ctx context.Context, req *api.
 			resp.RefererIde = ideName
 			desktopImageLayer = getUserIDEImage(referrer)
 			desktopUserImageLayers = getUserImageLayers(referrer)
+			desktopIDEName = ideName
+		}
+
+		// IDE plugins don't ship a supervisor IDE config, we pass theirs instead
+		for _, ide := range []struct{ EnvVar, Name string }{
+			{"GITPOD_IDE_PLUGIN_CONFIG", webIDEName},
+			{"GITPOD_DESKTOP_IDE_PLUGIN_CONFIG", desktopIDEName},
+		} {
+			plugin, ok := plugins[ide.Name]
+			if !ok {
+				continue
+			}
+			envvar, err := pluginConfigEnvVar(ide.EnvVar, plugin)
+			if err != nil {
+				log.WithError(err).WithField("ide", ide.Name).Error("cannot marshal ide plugin config")
+				return nil, fmt.Errorf("cannot resolve ide plugin %s", ide.Name)
+			}
+			resp.Envvars = append(resp.Envvars, envvar)
 		}
 
 		resp.IdeImageLayers = append(resp.IdeImageLayers, webUserImageLayers...)
@@ -39,8 +39,52 @@ type Configuration struct {
 	// Note that the workspace nodes/kubelets need access to this repository.
 	WorkspaceImageRepository string `json:"workspaceImageRepository"`
 
+	// BuildCacheRepository configures the repository where base image builds import their build cache from
+	// and export it to. Each cache scope (e.g. project) gets its own tag. If empty, builds don't use a registry cache.
+	// The credentials in the PullSecret need push access to this repository.
+	BuildCacheRepository string `json:"buildCacheRepository,omitempty"`
+
 	// BuilderImage is an image ref to the workspace builder image
 	BuilderImage string `json:"builderImage"`
+
+	// ImageVerification configures signature and provenance checks of base images
+	ImageVerification *ImageVerificationConfig `json:"imageVerification,omitempty"`
+}
+
+// ImageVerificationConfig configures signature and provenance checks of base images
+type ImageVerificationConfig struct {
+	// Default is the policy for organizations without a policy of their own.
+	// If there's no default, base images of such organizations aren't verified.
+	Default *ImageVerificationPolicy `json:"default,omitempty"`
+
+	// Organizations maps organization IDs to their policy
+	Organizations map[string]ImageVerificationPolicy `json:"organizations,omitempty"`
+}
+
+type ImageVerificationMode string
+
+const (
+	// ImageVerificationModeEnforce fails the build of base images which don't pass verification
+	ImageVerificationModeEnforce ImageVerificationMode = "enforce"
+	// ImageVerificationModeAudit logs base images which don't pass verification, but builds them nonetheless
+	ImageVerificationModeAudit ImageVerificationMode = "audit"
+)
+
+// ImageVerificationPolicy describes which base images an organization trusts
+type ImageVerificationPolicy struct {
+	// Mode defaults to enforce
+	Mode ImageVerificationMode `json:"mode,omitempty"`
+
+	// Repositories limits the policy to images from these repositories, e.g. "docker.io/library/".
+	// If empty, the policy applies to all base images.
+	Repositories []string `json:"repositories,omitempty"`
+
+	// PublicKeys are PEM-encoded public keys. Base images must carry a cosign signature of one of these keys.
+	PublicKeys []string `json:"publicKeys"`
+
+	// RequiredAttestations are in-toto predicate types, e.g. "https://slsa.dev/provenance/v0.2", which
+	// base images must carry an attestation for, signed by one of the public keys.
+	RequiredAttestations []string `json:"requiredAttestations,omitempty"`
 }
 
 type TLS struct {
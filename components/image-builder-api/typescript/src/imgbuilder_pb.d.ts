@@ -83,6 +83,8 @@ export class BuildSourceDockerfile extends jspb.Message {
     setDockerfilePath(value: string): BuildSourceDockerfile;
     getContextPath(): string;
     setContextPath(value: string): BuildSourceDockerfile;
+    getDockerfileContent(): string;
+    setDockerfileContent(value: string): BuildSourceDockerfile;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): BuildSourceDockerfile.AsObject;
@@ -100,6 +102,7 @@ export namespace BuildSourceDockerfile {
         dockerfileVersion: string,
         dockerfilePath: string,
         contextPath: string,
+        dockerfileContent: string,
     }
 }
 
@@ -111,6 +114,8 @@ export class ResolveBaseImageRequest extends jspb.Message {
     clearAuth(): void;
     getAuth(): BuildRegistryAuth | undefined;
     setAuth(value?: BuildRegistryAuth): ResolveBaseImageRequest;
+    getOrganizationId(): string;
+    setOrganizationId(value: string): ResolveBaseImageRequest;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): ResolveBaseImageRequest.AsObject;
@@ -126,6 +131,7 @@ export namespace ResolveBaseImageRequest {
     export type AsObject = {
         ref: string,
         auth?: BuildRegistryAuth.AsObject,
+        organizationId: string,
     }
 }
 
@@ -160,6 +166,8 @@ export class ResolveWorkspaceImageRequest extends jspb.Message {
     clearAuth(): void;
     getAuth(): BuildRegistryAuth | undefined;
     setAuth(value?: BuildRegistryAuth): ResolveWorkspaceImageRequest;
+    getOrganizationId(): string;
+    setOrganizationId(value: string): ResolveWorkspaceImageRequest;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): ResolveWorkspaceImageRequest.AsObject;
@@ -175,6 +183,7 @@ export namespace ResolveWorkspaceImageRequest {
     export type AsObject = {
         source?: BuildSource.AsObject,
         auth?: BuildRegistryAuth.AsObject,
+        organizationId: string,
     }
 }
 
@@ -221,6 +230,10 @@ export class BuildRequest extends jspb.Message {
     setTriggeredBy(value: string): BuildRequest;
     getSupervisorRef(): string;
     setSupervisorRef(value: string): BuildRequest;
+    getOrganizationId(): string;
+    setOrganizationId(value: string): BuildRequest;
+    getCacheScope(): string;
+    setCacheScope(value: string): BuildRequest;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): BuildRequest.AsObject;
@@ -239,6 +252,8 @@ export namespace BuildRequest {
         forceRebuild: boolean,
         triggeredBy: string,
         supervisorRef: string,
+        organizationId: string,
+        cacheScope: string,
     }
 }
 
@@ -471,6 +486,10 @@ export class BuildInfo extends jspb.Message {
     clearLogInfo(): void;
     getLogInfo(): LogInfo | undefined;
     setLogInfo(value?: LogInfo): BuildInfo;
+    getCachedSteps(): number;
+    setCachedSteps(value: number): BuildInfo;
+    getTotalSteps(): number;
+    setTotalSteps(value: number): BuildInfo;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): BuildInfo.AsObject;
@@ -490,6 +509,8 @@ export namespace BuildInfo {
         startedAt: number,
         buildId: string,
         logInfo?: LogInfo.AsObject,
+        cachedSteps: number,
+        totalSteps: number,
     }
 }
 
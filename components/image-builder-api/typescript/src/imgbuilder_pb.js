@@ -815,7 +815,8 @@ proto.builder.BuildSourceDockerfile.toObject = function(includeInstance, msg) {
     source: (f = msg.getSource()) && content$service$api_initializer_pb.WorkspaceInitializer.toObject(includeInstance, f),
     dockerfileVersion: jspb.Message.getFieldWithDefault(msg, 2, ""),
     dockerfilePath: jspb.Message.getFieldWithDefault(msg, 3, ""),
-    contextPath: jspb.Message.getFieldWithDefault(msg, 4, "")
+    contextPath: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    dockerfileContent: jspb.Message.getFieldWithDefault(msg, 5, "")
   };
 
   if (includeInstance) {
@@ -869,6 +870,10 @@ proto.builder.BuildSourceDockerfile.deserializeBinaryFromReader = function(msg,
       var value = /** @type {string} */ (reader.readString());
       msg.setContextPath(value);
       break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDockerfileContent(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -927,6 +932,13 @@ proto.builder.BuildSourceDockerfile.serializeBinaryToWriter = function(message,
       f
     );
   }
+  f = message.getDockerfileContent();
+  if (f.length > 0) {
+    writer.writeString(
+      5,
+      f
+    );
+  }
 };
 
 
@@ -1021,6 +1033,24 @@ proto.builder.BuildSourceDockerfile.prototype.setContextPath = function(value) {
 };
 
 
+/**
+ * optional string dockerfile_content = 5;
+ * @return {string}
+ */
+proto.builder.BuildSourceDockerfile.prototype.getDockerfileContent = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.builder.BuildSourceDockerfile} returns this
+ */
+proto.builder.BuildSourceDockerfile.prototype.setDockerfileContent = function(value) {
+  return jspb.Message.setProto3StringField(this, 5, value);
+};
+
+
 
 
 
@@ -1054,7 +1084,8 @@ proto.builder.ResolveBaseImageRequest.prototype.toObject = function(opt_includeI
 proto.builder.ResolveBaseImageRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
     ref: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    auth: (f = msg.getAuth()) && proto.builder.BuildRegistryAuth.toObject(includeInstance, f)
+    auth: (f = msg.getAuth()) && proto.builder.BuildRegistryAuth.toObject(includeInstance, f),
+    organizationId: jspb.Message.getFieldWithDefault(msg, 3, "")
   };
 
   if (includeInstance) {
@@ -1100,6 +1131,10 @@ proto.builder.ResolveBaseImageRequest.deserializeBinaryFromReader = function(msg
       reader.readMessage(value,proto.builder.BuildRegistryAuth.deserializeBinaryFromReader);
       msg.setAuth(value);
       break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setOrganizationId(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1144,6 +1179,13 @@ proto.builder.ResolveBaseImageRequest.serializeBinaryToWriter = function(message
       proto.builder.BuildRegistryAuth.serializeBinaryToWriter
     );
   }
+  f = message.getOrganizationId();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
 };
 
 
@@ -1202,6 +1244,22 @@ proto.builder.ResolveBaseImageRequest.prototype.hasAuth = function() {
 };
 
 
+/**
+ * optional string organization_id = 3;
+ * @return {string}
+ */
+proto.builder.ResolveBaseImageRequest.prototype.getOrganizationId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.builder.ResolveBaseImageRequest} returns this
+ */
+proto.builder.ResolveBaseImageRequest.prototype.setOrganizationId = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
 
 
 
@@ -1365,7 +1423,8 @@ proto.builder.ResolveWorkspaceImageRequest.prototype.toObject = function(opt_inc
 proto.builder.ResolveWorkspaceImageRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
     source: (f = msg.getSource()) && proto.builder.BuildSource.toObject(includeInstance, f),
-    auth: (f = msg.getAuth()) && proto.builder.BuildRegistryAuth.toObject(includeInstance, f)
+    auth: (f = msg.getAuth()) && proto.builder.BuildRegistryAuth.toObject(includeInstance, f),
+    organizationId: jspb.Message.getFieldWithDefault(msg, 3, "")
   };
 
   if (includeInstance) {
@@ -1412,6 +1471,10 @@ proto.builder.ResolveWorkspaceImageRequest.deserializeBinaryFromReader = functio
       reader.readMessage(value,proto.builder.BuildRegistryAuth.deserializeBinaryFromReader);
       msg.setAuth(value);
       break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setOrganizationId(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1457,6 +1520,13 @@ proto.builder.ResolveWorkspaceImageRequest.serializeBinaryToWriter = function(me
       proto.builder.BuildRegistryAuth.serializeBinaryToWriter
     );
   }
+  f = message.getOrganizationId();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
 };
 
 
@@ -1534,6 +1604,22 @@ proto.builder.ResolveWorkspaceImageRequest.prototype.hasAuth = function() {
 };
 
 
+/**
+ * optional string organization_id = 3;
+ * @return {string}
+ */
+proto.builder.ResolveWorkspaceImageRequest.prototype.getOrganizationId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.builder.ResolveWorkspaceImageRequest} returns this
+ */
+proto.builder.ResolveWorkspaceImageRequest.prototype.setOrganizationId = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
 
 
 
@@ -1760,7 +1846,9 @@ proto.builder.BuildRequest.toObject = function(includeInstance, msg) {
     auth: (f = msg.getAuth()) && proto.builder.BuildRegistryAuth.toObject(includeInstance, f),
     forceRebuild: jspb.Message.getBooleanFieldWithDefault(msg, 3, false),
     triggeredBy: jspb.Message.getFieldWithDefault(msg, 4, ""),
-    supervisorRef: jspb.Message.getFieldWithDefault(msg, 5, "")
+    supervisorRef: jspb.Message.getFieldWithDefault(msg, 5, ""),
+    organizationId: jspb.Message.getFieldWithDefault(msg, 6, ""),
+    cacheScope: jspb.Message.getFieldWithDefault(msg, 7, "")
   };
 
   if (includeInstance) {
@@ -1819,6 +1907,14 @@ proto.builder.BuildRequest.deserializeBinaryFromReader = function(msg, reader) {
       var value = /** @type {string} */ (reader.readString());
       msg.setSupervisorRef(value);
       break;
+    case 6:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setOrganizationId(value);
+      break;
+    case 7:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setCacheScope(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1885,6 +1981,20 @@ proto.builder.BuildRequest.serializeBinaryToWriter = function(message, writer) {
       f
     );
   }
+  f = message.getOrganizationId();
+  if (f.length > 0) {
+    writer.writeString(
+      6,
+      f
+    );
+  }
+  f = message.getCacheScope();
+  if (f.length > 0) {
+    writer.writeString(
+      7,
+      f
+    );
+  }
 };
 
 
@@ -2016,6 +2126,40 @@ proto.builder.BuildRequest.prototype.setSupervisorRef = function(value) {
 };
 
 
+/**
+ * optional string organization_id = 6;
+ * @return {string}
+ */
+proto.builder.BuildRequest.prototype.getOrganizationId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 6, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.builder.BuildRequest} returns this
+ */
+proto.builder.BuildRequest.prototype.setOrganizationId = function(value) {
+  return jspb.Message.setProto3StringField(this, 6, value);
+};
+
+
+/**
+ * optional string cache_scope = 7;
+ * @return {string}
+ */
+proto.builder.BuildRequest.prototype.getCacheScope = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 7, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.builder.BuildRequest} returns this
+ */
+proto.builder.BuildRequest.prototype.setCacheScope = function(value) {
+  return jspb.Message.setProto3StringField(this, 7, value);
+};
 
 /**
  * Oneof group definitions for this message. Each group defines the field
@@ -3536,7 +3680,9 @@ proto.builder.BuildInfo.toObject = function(includeInstance, msg) {
     status: jspb.Message.getFieldWithDefault(msg, 2, 0),
     startedAt: jspb.Message.getFieldWithDefault(msg, 3, 0),
     buildId: jspb.Message.getFieldWithDefault(msg, 5, ""),
-    logInfo: (f = msg.getLogInfo()) && proto.builder.LogInfo.toObject(includeInstance, f)
+    logInfo: (f = msg.getLogInfo()) && proto.builder.LogInfo.toObject(includeInstance, f),
+    cachedSteps: jspb.Message.getFieldWithDefault(msg, 7, 0),
+    totalSteps: jspb.Message.getFieldWithDefault(msg, 8, 0)
   };
 
   if (includeInstance) {
@@ -3598,6 +3744,14 @@ proto.builder.BuildInfo.deserializeBinaryFromReader = function(msg, reader) {
       reader.readMessage(value,proto.builder.LogInfo.deserializeBinaryFromReader);
       msg.setLogInfo(value);
       break;
+    case 7:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setCachedSteps(value);
+      break;
+    case 8:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setTotalSteps(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -3670,6 +3824,20 @@ proto.builder.BuildInfo.serializeBinaryToWriter = function(message, writer) {
       proto.builder.LogInfo.serializeBinaryToWriter
     );
   }
+  f = message.getCachedSteps();
+  if (f !== 0) {
+    writer.writeInt32(
+      7,
+      f
+    );
+  }
+  f = message.getTotalSteps();
+  if (f !== 0) {
+    writer.writeInt32(
+      8,
+      f
+    );
+  }
 };
 
 
@@ -3800,6 +3968,42 @@ proto.builder.BuildInfo.prototype.hasLogInfo = function() {
 };
 
 
+/**
+ * optional int32 cached_steps = 7;
+ * @return {number}
+ */
+proto.builder.BuildInfo.prototype.getCachedSteps = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.builder.BuildInfo} returns this
+ */
+proto.builder.BuildInfo.prototype.setCachedSteps = function(value) {
+  return jspb.Message.setProto3IntField(this, 7, value);
+};
+
+
+/**
+ * optional int32 total_steps = 8;
+ * @return {number}
+ */
+proto.builder.BuildInfo.prototype.getTotalSteps = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 8, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.builder.BuildInfo} returns this
+ */
+proto.builder.BuildInfo.prototype.setTotalSteps = function(value) {
+  return jspb.Message.setProto3IntField(this, 8, value);
+};
+
+
 
 
 
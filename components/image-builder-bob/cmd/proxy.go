@@ -19,6 +19,7 @@ import (
 
 var proxyOpts struct {
 	BaseRef, TargetRef string
+	CacheRef           string
 	Auth               string
 	AdditionalAuth     string
 }
@@ -59,7 +60,7 @@ var proxyCmd = &cobra.Command{
 		}
 
 		auth := func() docker.Authorizer { return docker.NewDockerAuthorizer(docker.WithAuthCreds(authP.Authorize)) }
-		prx, err := proxy.NewProxy(&url.URL{Host: "localhost:8080", Scheme: "http"}, map[string]proxy.Repo{
+		aliases := map[string]proxy.Repo{
 			"base": {
 				Host: reference.Domain(baseref),
 				Repo: reference.Path(baseref),
@@ -72,7 +73,24 @@ var proxyCmd = &cobra.Command{
 				Tag:  targettag,
 				Auth: auth,
 			},
-		})
+		}
+		if proxyOpts.CacheRef != "" {
+			cacheref, err := reference.ParseNormalizedNamed(proxyOpts.CacheRef)
+			if err != nil {
+				log.WithError(err).Fatal("cannot parse cache ref")
+			}
+			var cachetag string
+			if r, ok := cacheref.(reference.NamedTagged); ok {
+				cachetag = r.Tag()
+			}
+			aliases["cache"] = proxy.Repo{
+				Host: reference.Domain(cacheref),
+				Repo: reference.Path(cacheref),
+				Tag:  cachetag,
+				Auth: auth,
+			}
+		}
+		prx, err := proxy.NewProxy(&url.URL{Host: "localhost:8080", Scheme: "http"}, aliases)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -92,6 +110,7 @@ func init() {
 	// These env vars start with `WORKSPACEKIT_` so that they aren't passed on to ring2
 	proxyCmd.Flags().StringVar(&proxyOpts.BaseRef, "base-ref", os.Getenv("WORKSPACEKIT_BOBPROXY_BASEREF"), "ref of the base image")
 	proxyCmd.Flags().StringVar(&proxyOpts.TargetRef, "target-ref", os.Getenv("WORKSPACEKIT_BOBPROXY_TARGETREF"), "ref of the target image")
+	proxyCmd.Flags().StringVar(&proxyOpts.CacheRef, "cache-ref", os.Getenv("WORKSPACEKIT_BOBPROXY_CACHEREF"), "ref of the build cache")
 	proxyCmd.Flags().StringVar(&proxyOpts.Auth, "auth", os.Getenv("WORKSPACEKIT_BOBPROXY_AUTH"), "authentication to use")
 	proxyCmd.Flags().StringVar(&proxyOpts.AdditionalAuth, "additional-auth", os.Getenv("WORKSPACEKIT_BOBPROXY_ADDITIONALAUTH"), "additional authentication to use")
 }
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -15,6 +16,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/gitpod-io/gitpod/common-go/dockerfile"
 	"github.com/gitpod-io/gitpod/common-go/log"
 
 	"github.com/docker/cli/cli/config/configfile"
@@ -80,7 +82,54 @@ func (b *Builder) buildBaseLayer(ctx context.Context, cl *client.Client) error {
 	}
 
 	log.Info("building base image")
-	return buildImage(ctx, b.Config.ContextDir, b.Config.Dockerfile, b.Config.WorkspaceLayerAuth, b.Config.BaseRef)
+	if b.Config.CacheRef != "" {
+		log.WithField("cacheRef", b.Config.CacheRef).Info("using build cache")
+	}
+
+	dockerfilePath := b.Config.Dockerfile
+	if b.Config.PinnedBaseImages != nil {
+		var err error
+		dockerfilePath, err = pinBaseImages(b.Config.Dockerfile, b.Config.PinnedBaseImages)
+		if err != nil {
+			return err
+		}
+	}
+
+	stats := newCacheStats()
+	err := buildImage(ctx, b.Config.ContextDir, dockerfilePath, b.Config.WorkspaceLayerAuth, b.Config.BaseRef, b.Config.CacheRef, stats)
+	if err != nil {
+		return err
+	}
+
+	// image-builder-mk3 picks this line up from the build logs to report the cache statistics
+	cached, total := stats.Result()
+	log.Infof("build cache: %d of %d steps cached", cached, total)
+	return nil
+}
+
+// pinBaseImages writes a copy of the Dockerfile which builds from the pinned digests of its base images
+// and returns its path. image-builder verified the base images at these digests.
+func pinBaseImages(dockerfilePath string, pinned map[string]string) (string, error) {
+	content, err := os.ReadFile(dockerfilePath)
+	if err != nil {
+		return "", xerrors.Errorf("cannot read Dockerfile: %w", err)
+	}
+	res, err := dockerfile.PinBaseImages(string(content), pinned)
+	if err != nil {
+		return "", xerrors.Errorf("cannot build from the verified base images: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "dockerfile-*")
+	if err != nil {
+		return "", err
+	}
+	fn := filepath.Join(dir, filepath.Base(dockerfilePath))
+	err = os.WriteFile(fn, []byte(res), 0644)
+	if err != nil {
+		return "", xerrors.Errorf("cannot write pinned Dockerfile: %w", err)
+	}
+	log.WithField("pinned", pinned).Info("building from the verified base images")
+	return fn, nil
 }
 
 func (b *Builder) buildWorkspaceImage(ctx context.Context, cl *client.Client) (err error) {
@@ -96,10 +145,13 @@ func (b *Builder) buildWorkspaceImage(ctx context.Context, cl *client.Client) (e
 		return xerrors.Errorf("unexpected error creating temporal directory: %w", err)
 	}
 
-	return buildImage(ctx, contextDir, filepath.Join(contextDir, "Dockerfile"), b.Config.WorkspaceLayerAuth, b.Config.TargetRef)
+	return buildImage(ctx, contextDir, filepath.Join(contextDir, "Dockerfile"), b.Config.WorkspaceLayerAuth, b.Config.TargetRef, "", nil)
 }
 
-func buildImage(ctx context.Context, contextDir, dockerfile, authLayer, target string) (err error) {
+// buildImage builds and pushes an image using buildctl. If cacheRef is not empty, the build cache of all
+// stages is imported from and exported to that ref. If progress is not nil, buildctl's progress output is
+// written to it.
+func buildImage(ctx context.Context, contextDir, dockerfile, authLayer, target, cacheRef string, progress io.Writer) (err error) {
 	log.Info("waiting for build context")
 	waitctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
 	defer cancel()
@@ -141,18 +193,25 @@ func buildImage(ctx context.Context, contextDir, dockerfile, authLayer, target s
 		"build",
 		"--progress=plain",
 		"--output=type=image,name=" + target + ",push=true,oci-mediatypes=true",
-		//"--export-cache=type=inline",
 		"--local=context=" + contextdir,
-		//"--export-cache=type=registry,ref=" + target + "-cache",
-		//"--import-cache=type=registry,ref=" + target + "-cache",
 		"--frontend=dockerfile.v0",
 		"--local=dockerfile=" + filepath.Dir(dockerfile),
 		"--opt=filename=" + filepath.Base(dockerfile),
 	}
+	if cacheRef != "" {
+		buildctlArgs = append(buildctlArgs,
+			"--import-cache=type=registry,ref="+cacheRef,
+			// mode=max exports the layers of all stages, not just the final one
+			"--export-cache=type=registry,ref="+cacheRef+",mode=max,oci-mediatypes=true",
+		)
+	}
 
 	buildctlCmd := exec.Command("buildctl", buildctlArgs...)
 
 	buildctlCmd.Stderr = os.Stderr
+	if progress != nil {
+		buildctlCmd.Stderr = io.MultiWriter(os.Stderr, progress)
+	}
 	buildctlCmd.Stdout = os.Stdout
 
 	env := os.Environ()
@@ -0,0 +1,84 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package builder
+
+import (
+	"bytes"
+	"regexp"
+	"sync"
+)
+
+var (
+	// progressLine matches a vertex line of buildctl's plain progress output, e.g. "#5 [2/3] RUN make"
+	progressLine = regexp.MustCompile(`^#(\d+) (.*)$`)
+	// buildStep matches the name of a vertex that is a Dockerfile step, e.g. "[2/3] RUN make" or "[builder 1/4] FROM alpine"
+	buildStep = regexp.MustCompile(`^\[(\S+ )?\d+/\d+\] `)
+)
+
+// cacheStats counts the Dockerfile steps of a build and how many of them were served from the build cache.
+// It consumes buildctl's plain progress output.
+type cacheStats struct {
+	mu      sync.Mutex
+	steps   map[string]bool
+	cached  map[string]struct{}
+	partial []byte
+}
+
+func newCacheStats() *cacheStats {
+	return &cacheStats{
+		steps:  make(map[string]bool),
+		cached: make(map[string]struct{}),
+	}
+}
+
+// Write implements io.Writer
+func (s *cacheStats) Write(p []byte) (n int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buf := append(s.partial, p...)
+	for {
+		idx := bytes.IndexByte(buf, '\n')
+		if idx < 0 {
+			break
+		}
+		s.parseLine(string(bytes.TrimRight(buf[:idx], "\r")))
+		buf = buf[idx+1:]
+	}
+	s.partial = append([]byte(nil), buf...)
+
+	return len(p), nil
+}
+
+func (s *cacheStats) parseLine(line string) {
+	m := progressLine.FindStringSubmatch(line)
+	if m == nil {
+		return
+	}
+	id, msg := m[1], m[2]
+
+	isStep, seen := s.steps[id]
+	if !seen {
+		// the first line of a vertex carries its name
+		isStep = buildStep.MatchString(msg)
+		s.steps[id] = isStep
+	}
+	if isStep && msg == "CACHED" {
+		s.cached[id] = struct{}{}
+	}
+}
+
+// Result returns the number of cached and total build steps
+func (s *cacheStats) Result() (cached, total int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, isStep := range s.steps {
+		if isStep {
+			total++
+		}
+	}
+	return len(s.cached), total
+}
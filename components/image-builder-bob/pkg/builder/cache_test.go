@@ -0,0 +1,62 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package builder
+
+import (
+	"testing"
+)
+
+func TestCacheStats(t *testing.T) {
+	type result struct {
+		Cached, Total int
+	}
+	tests := []struct {
+		Name        string
+		Progress    []string
+		Expectation result
+	}{
+		{
+			Name:     "empty",
+			Progress: []string{""},
+		},
+		{
+			Name: "multi-stage build",
+			Progress: []string{
+				"#1 [internal] load build definition from Dockerfile\n#1 transferring dockerfile: 120B done\n#1 DONE 0.0s\n\n",
+				"#2 [internal] load metadata for docker.io/library/alpine:latest\n#2 DONE 0.5s\n\n",
+				"#3 importing cache manifest from localhost:8080/cache:latest\n#3 DONE 0.1s\n\n",
+				"#4 [builder 1/2] FROM docker.io/library/alpine@sha256:abc\n#4 CACHED\n\n",
+				"#5 [builder 2/2] RUN make\n#5 CACHED\n\n",
+				"#6 [stage-1 2/3] RUN apk add git\n#6 0.512 fetch https://dl-cdn.alpinelinux.org\n#6 DONE 1.2s\n\n",
+				"#7 [stage-1 3/3] COPY --from=builder /app /app\n#7 DONE 0.1s\n\n",
+				"#8 exporting cache\n#8 CACHED\n",
+			},
+			Expectation: result{Cached: 2, Total: 4},
+		},
+		{
+			Name: "split lines",
+			Progress: []string{
+				"#1 [1/2] FROM docker.io/libr",
+				"ary/alpine\n#1 CAC",
+				"HED\r\n#2 [2/2] RUN echo\n#2 DONE 0.1s\n",
+			},
+			Expectation: result{Cached: 1, Total: 2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			stats := newCacheStats()
+			for _, p := range test.Progress {
+				_, _ = stats.Write([]byte(p))
+			}
+
+			var act result
+			act.Cached, act.Total = stats.Result()
+			if act != test.Expectation {
+				t.Errorf("unexpected result: want %+v, got %+v", test.Expectation, act)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"encoding/base64"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -26,7 +27,12 @@ type Config struct {
 	Dockerfile         string
 	ContextDir         string
 	ExternalBuildkitd  string
+	CacheRef           string
 	localCacheImport   string
+
+	// PinnedBaseImages maps the images the Dockerfile builds from to the digests image-builder verified them at.
+	// If set, the base image is built from those digests and images which are not listed are rejected.
+	PinnedBaseImages map[string]string
 }
 
 // GetConfigFromEnv extracts configuration from environment variables
@@ -41,6 +47,7 @@ func GetConfigFromEnv() (*Config, error) {
 		Dockerfile:         os.Getenv("BOB_DOCKERFILE_PATH"),
 		ContextDir:         os.Getenv("BOB_CONTEXT_DIR"),
 		ExternalBuildkitd:  os.Getenv("BOB_EXTERNAL_BUILDKITD"),
+		CacheRef:           os.Getenv("BOB_CACHE_REF"),
 		localCacheImport:   os.Getenv("BOB_LOCAL_CACHE_IMPORT"),
 	}
 
@@ -66,6 +73,12 @@ func GetConfigFromEnv() (*Config, error) {
 			return nil, xerrors.Errorf("BOB_DOCKERFILE_PATH does not exist or isn't a file")
 		}
 	}
+	if pinned := os.Getenv("BOB_PINNED_BASE_IMAGES"); pinned != "" {
+		err := json.Unmarshal([]byte(pinned), &cfg.PinnedBaseImages)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot unmarshal BOB_PINNED_BASE_IMAGES: %w", err)
+		}
+	}
 
 	var authKey = os.Getenv("BOB_AUTH_KEY")
 	if authKey != "" {
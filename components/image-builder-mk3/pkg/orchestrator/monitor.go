@@ -5,6 +5,7 @@
 package orchestrator
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -12,6 +13,8 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -29,8 +32,14 @@ const (
 	annotationRef       = "ref"
 	annotationBaseRef   = "baseref"
 	annotationManagedBy = "managed-by"
+
+	// maxPartialLogLine is the number of bytes of an incomplete log line we keep around to find the cache statistics
+	maxPartialLogLine = 1024
 )
 
+// cacheStatsLine matches the build cache statistics bob logs after building the base image
+var cacheStatsLine = regexp.MustCompile(`build cache: (\d+) of (\d+) steps cached`)
+
 type orchestrator interface {
 	PublishStatus(buildID string, resp *api.BuildResponse)
 	PublishLog(buildID string, message string)
@@ -42,6 +51,7 @@ func newBuildMonitor(o orchestrator, wsman wsmanapi.WorkspaceManagerClient) *bui
 		wsman:         wsman,
 		runningBuilds: make(map[string]*runningBuild),
 		logs:          map[string]context.CancelFunc{},
+		cacheStats:    make(map[string]buildCacheStats),
 	}
 }
 
@@ -53,6 +63,14 @@ type buildMonitor struct {
 	runningBuildsMu sync.RWMutex
 
 	logs map[string]context.CancelFunc
+
+	cacheStats   map[string]buildCacheStats
+	cacheStatsMu sync.Mutex
+}
+
+type buildCacheStats struct {
+	CachedSteps int32
+	TotalSteps  int32
 }
 
 type runningBuild struct {
@@ -125,6 +143,16 @@ func (m *buildMonitor) handleStatusUpdate(status *wsmanapi.WorkspaceStatus) {
 		bld  = extractRunningBuild(status)
 		resp = extractBuildResponse(status)
 	)
+	m.cacheStatsMu.Lock()
+	if stats, ok := m.cacheStats[status.Id]; ok {
+		bld.Info.CachedSteps, bld.Info.TotalSteps = stats.CachedSteps, stats.TotalSteps
+		resp.Info.CachedSteps, resp.Info.TotalSteps = stats.CachedSteps, stats.TotalSteps
+	}
+	if resp.Status != api.BuildStatus_running {
+		delete(m.cacheStats, status.Id)
+	}
+	m.cacheStatsMu.Unlock()
+
 	m.runningBuildsMu.Lock()
 	if resp.Status != api.BuildStatus_running {
 		delete(m.runningBuilds, status.Id)
@@ -154,6 +182,7 @@ func (m *buildMonitor) handleStatusUpdate(status *wsmanapi.WorkspaceStatus) {
 }
 
 func (m *buildMonitor) handleHeadlessLogs(buildID string) listenToHeadlessLogsCallback {
+	var partial []byte
 	return func(content []byte, err error) {
 		if err != nil && !errors.Is(err, context.Canceled) {
 			log.WithError(err).WithField("buildID", buildID).Warn("headless log listener failed")
@@ -162,11 +191,47 @@ func (m *buildMonitor) handleHeadlessLogs(buildID string) listenToHeadlessLogsCa
 		}
 
 		if len(content) > 0 {
+			partial = m.handleCacheStats(buildID, append(partial, content...))
 			m.O.PublishLog(buildID, string(content))
 		}
 	}
 }
 
+// handleCacheStats looks for the build cache statistics in the complete lines of the log content
+// and returns the trailing incomplete line.
+func (m *buildMonitor) handleCacheStats(buildID string, content []byte) (partial []byte) {
+	idx := bytes.LastIndexByte(content, '\n')
+	if stats, ok := parseCacheStats(content[:idx+1]); ok {
+		m.cacheStatsMu.Lock()
+		m.cacheStats[buildID] = stats
+		m.cacheStatsMu.Unlock()
+	}
+
+	partial = content[idx+1:]
+	if len(partial) > maxPartialLogLine {
+		partial = partial[len(partial)-maxPartialLogLine:]
+	}
+	return append([]byte(nil), partial...)
+}
+
+// parseCacheStats returns the last build cache statistics found in the log content
+func parseCacheStats(content []byte) (stats buildCacheStats, ok bool) {
+	matches := cacheStatsLine.FindAllSubmatch(content, -1)
+	if len(matches) == 0 {
+		return buildCacheStats{}, false
+	}
+	m := matches[len(matches)-1]
+	cached, err := strconv.ParseInt(string(m[1]), 10, 32)
+	if err != nil {
+		return buildCacheStats{}, false
+	}
+	total, err := strconv.ParseInt(string(m[2]), 10, 32)
+	if err != nil {
+		return buildCacheStats{}, false
+	}
+	return buildCacheStats{CachedSteps: int32(cached), TotalSteps: int32(total)}, true
+}
+
 var errOutOfRetries = xerrors.Errorf("out of retries")
 
 // retry makes multiple attempts to execute op if op returns an UNAVAILABLE gRPC status code
@@ -131,3 +131,49 @@ func TestExtractBuildResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleCacheStats(t *testing.T) {
+	const buildID = "build-id"
+	tests := []struct {
+		Name        string
+		Content     []string
+		Expectation *buildCacheStats
+	}{
+		{
+			Name:    "no stats",
+			Content: []string{"#1 [1/2] FROM alpine\r\n", "#1 DONE 0.1s\r\n"},
+		},
+		{
+			Name:        "stats",
+			Content:     []string{"#1 [1/2] FROM alpine\r\n", `{"level":"info","message":"build cache: 3 of 4 steps cached"}` + "\r\n"},
+			Expectation: &buildCacheStats{CachedSteps: 3, TotalSteps: 4},
+		},
+		{
+			Name:        "split line",
+			Content:     []string{"build cache: 3 of", " 4 steps cached\r\n"},
+			Expectation: &buildCacheStats{CachedSteps: 3, TotalSteps: 4},
+		},
+		{
+			Name:    "incomplete line",
+			Content: []string{"build cache: 3 of 4 steps cached"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			m := newBuildMonitor(nil, nil)
+
+			var partial []byte
+			for _, c := range test.Content {
+				partial = m.handleCacheStats(buildID, append(partial, c...))
+			}
+
+			var act *buildCacheStats
+			if stats, ok := m.cacheStats[buildID]; ok {
+				act = &stats
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("handleCacheStats() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 
+	"github.com/gitpod-io/gitpod/common-go/dockerfile"
 	common_grpc "github.com/gitpod-io/gitpod/common-go/grpc"
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/common-go/tracing"
@@ -39,6 +40,7 @@ import (
 	"github.com/gitpod-io/gitpod/image-builder/api/config"
 	"github.com/gitpod-io/gitpod/image-builder/pkg/auth"
 	"github.com/gitpod-io/gitpod/image-builder/pkg/resolve"
+	"github.com/gitpod-io/gitpod/image-builder/pkg/verify"
 	wsmanapi "github.com/gitpod-io/gitpod/ws-manager/api"
 )
 
@@ -96,6 +98,14 @@ func NewOrchestratingBuilder(cfg config.Configuration) (res *Orchestrator, err e
 		wsman = wsmanapi.NewWorkspaceManagerClient(conn)
 	}
 
+	var verifier *verify.Verifier
+	if cfg.ImageVerification != nil {
+		verifier, err = verify.NewVerifier(*cfg.ImageVerification)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid image verification config: %w", err)
+		}
+	}
+
 	o := &Orchestrator{
 		Config: cfg,
 		Auth:   authentication,
@@ -104,6 +114,7 @@ func NewOrchestratingBuilder(cfg config.Configuration) (res *Orchestrator, err e
 			WorkspaceImageRepository: cfg.WorkspaceImageRepository,
 		},
 		RefResolver: &resolve.StandaloneRefResolver{},
+		Verifier:    verifier,
 
 		wsman:         wsman,
 		buildListener: make(map[string]map[buildListener]struct{}),
@@ -122,6 +133,7 @@ type Orchestrator struct {
 	Auth         auth.RegistryAuthenticator
 	AuthResolver auth.Resolver
 	RefResolver  resolve.DockerRefResolver
+	Verifier     *verify.Verifier
 
 	wsman wsmanapi.WorkspaceManagerClient
 
@@ -161,6 +173,10 @@ func (o *Orchestrator) ResolveBaseImage(ctx context.Context, req *protocol.Resol
 	if err != nil {
 		return nil, err
 	}
+	err = o.verifyBaseImage(ctx, req.OrganizationId, req.Ref, refstr, reqauth)
+	if err != nil {
+		return nil, err
+	}
 
 	return &protocol.ResolveBaseImageResponse{
 		Ref: refstr,
@@ -180,7 +196,7 @@ func (o *Orchestrator) ResolveWorkspaceImage(ctx context.Context, req *protocol.
 	log.WithFields(safeReqsLog).Debug("ResolveWorkspaceImage")
 
 	reqauth := o.AuthResolver.ResolveRequestAuth(req.Auth)
-	baseref, err := o.getBaseImageRef(ctx, req.Source, reqauth)
+	baseref, _, err := o.getBaseImageRef(ctx, req.Source, reqauth, req.OrganizationId)
 	if _, ok := status.FromError(err); err != nil && ok {
 		return nil, err
 	}
@@ -231,7 +247,7 @@ func (o *Orchestrator) Build(req *protocol.BuildRequest, resp protocol.ImageBuil
 	// resolve build request authentication
 	reqauth := o.AuthResolver.ResolveRequestAuth(req.Auth)
 
-	baseref, err := o.getBaseImageRef(ctx, req.Source, reqauth)
+	baseref, pinnedBaseImages, err := o.getBaseImageRef(ctx, req.Source, reqauth, req.OrganizationId)
 	if _, ok := status.FromError(err); err != nil && ok {
 		return err
 	}
@@ -309,12 +325,21 @@ func (o *Orchestrator) Build(req *protocol.BuildRequest, resp protocol.ImageBuil
 	}
 	contextPath = filepath.Join("/workspace", strings.TrimPrefix(contextPath, "/workspace"))
 
-	o.censor(buildID, []string{
+	var cacheref string
+	if buildBase == "true" {
+		cacheref = o.getBuildCacheRef(req.CacheScope)
+	}
+
+	censored := []string{
 		wsrefstr,
 		baseref,
 		strings.Split(wsrefstr, ":")[0],
 		strings.Split(baseref, ":")[0],
-	})
+	}
+	if cacheref != "" {
+		censored = append(censored, censoredCacheRef(cacheref)...)
+	}
+	o.censor(buildID, censored)
 
 	// push some log to the client before starting the job, just in case the build workspace takes a while to start up
 	o.PublishLog(buildID, "starting image build")
@@ -371,7 +396,7 @@ func (o *Orchestrator) Build(req *protocol.BuildRequest, resp protocol.ImageBuil
 					SupervisorRef: req.SupervisorRef,
 				},
 				WorkspaceLocation: contextPath,
-				Envvars: []*wsmanapi.EnvironmentVariable{
+				Envvars: append([]*wsmanapi.EnvironmentVariable{
 					{Name: "BOB_TARGET_REF", Value: "localhost:8080/target:latest"},
 					{Name: "BOB_BASE_REF", Value: bobBaseref},
 					{Name: "BOB_BUILD_BASE", Value: buildBase},
@@ -393,7 +418,7 @@ func (o *Orchestrator) Build(req *protocol.BuildRequest, resp protocol.ImageBuil
 						Value: string(additionalAuth),
 					},
 					{Name: "SUPERVISOR_DEBUG_ENABLE", Value: fmt.Sprintf("%v", log.Log.Logger.IsLevelEnabled(logrus.DebugLevel))},
-				},
+				}, append(buildCacheEnvvars(cacheref), pinnedBaseImagesEnvvars(pinnedBaseImages)...)...),
 			},
 			Type: wsmanapi.WorkspaceType_IMAGEBUILD,
 		})
@@ -577,15 +602,88 @@ func (o *Orchestrator) getAbsoluteImageRef(ctx context.Context, ref string, allo
 	return ref, nil
 }
 
-func (o *Orchestrator) getBaseImageRef(ctx context.Context, bs *protocol.BuildSource, allowedAuth auth.AllowedAuthFor) (res string, err error) {
+// verifyBaseImage checks the signatures and attestations of a base image against the policy of the organization.
+func (o *Orchestrator) verifyBaseImage(ctx context.Context, organizationID, ref, resolvedRef string, allowedAuth auth.AllowedAuthFor) error {
+	if o.Verifier == nil {
+		return nil
+	}
+
+	auth, err := allowedAuth.GetAuthFor(o.Auth, ref)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "cannot verify base image: %v", err)
+	}
+	err = o.Verifier.Verify(ctx, organizationID, ref, resolvedRef, auth)
+	if xerrors.Is(err, verify.ErrVerificationFailed) {
+		return status.Errorf(codes.FailedPrecondition, "base image is not trusted: %v", err)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "cannot verify base image: %v", err)
+	}
+	return nil
+}
+
+// verifyDockerfileBaseImages verifies every image a Dockerfile builds from. Images which cannot be determined
+// or resolved are rejected if the policy of the organization is enforced, and skipped otherwise.
+// The images are pinned to the digests which were verified: bob builds from those digests and refuses to build
+// from images which were not checked, e.g. because the Dockerfile changed in the meantime. Images which were skipped
+// map to an empty ref. If there is nothing to pin, pinned is nil.
+func (o *Orchestrator) verifyDockerfileBaseImages(ctx context.Context, organizationID, dockerfileContent string, allowedAuth auth.AllowedAuthFor) (pinned map[string]string, err error) {
+	if o.Verifier == nil {
+		return nil, nil
+	}
+
+	refs, err := dockerfile.BaseImages(dockerfileContent)
+	if err != nil {
+		if o.Verifier.Enforces(organizationID, "") {
+			return nil, status.Errorf(codes.FailedPrecondition, "cannot verify the base images of the Dockerfile: %v", err)
+		}
+		log.WithError(err).WithField("organizationID", organizationID).Warn("cannot determine the base images of the Dockerfile - building it nonetheless because the policy is not enforced")
+		return nil, nil
+	}
+	pinned = make(map[string]string, len(refs))
+	for _, ref := range refs {
+		resolved, err := o.getAbsoluteImageRef(ctx, ref, allowedAuth)
+		if err != nil {
+			if o.Verifier.Enforces(organizationID, ref) {
+				return nil, status.Errorf(codes.FailedPrecondition, "cannot verify base image %s of the Dockerfile: %v", ref, err)
+			}
+			log.WithError(err).WithField("organizationID", organizationID).WithField("ref", ref).Warn("cannot resolve base image of the Dockerfile - building it nonetheless because the policy is not enforced")
+			pinned[ref] = ""
+			continue
+		}
+		err = o.verifyBaseImage(ctx, organizationID, ref, resolved, allowedAuth)
+		if err != nil {
+			return nil, err
+		}
+		pinned[ref] = resolved
+	}
+	return pinned, nil
+}
+
+// getBaseImageRef returns the ref of the base image of a build. For Dockerfile builds, pinnedBaseImages
+// are the digests the images the Dockerfile builds from were verified at, see verifyDockerfileBaseImages.
+func (o *Orchestrator) getBaseImageRef(ctx context.Context, bs *protocol.BuildSource, allowedAuth auth.AllowedAuthFor, organizationID string) (res string, pinnedBaseImages map[string]string, err error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "getBaseImageRef")
 	defer tracing.FinishSpan(span, &err)
 
 	switch src := bs.From.(type) {
 	case *protocol.BuildSource_Ref:
-		return o.getAbsoluteImageRef(ctx, src.Ref.Ref, allowedAuth)
+		ref, err := o.getAbsoluteImageRef(ctx, src.Ref.Ref, allowedAuth)
+		if err != nil {
+			return "", nil, err
+		}
+		err = o.verifyBaseImage(ctx, organizationID, src.Ref.Ref, ref, allowedAuth)
+		if err != nil {
+			return "", nil, err
+		}
+		return ref, nil, nil
 
 	case *protocol.BuildSource_File:
+		pinnedBaseImages, err = o.verifyDockerfileBaseImages(ctx, organizationID, src.File.DockerfileContent, allowedAuth)
+		if err != nil {
+			return "", nil, err
+		}
+
 		manifest := map[string]string{
 			"DockerfilePath":    src.File.DockerfilePath,
 			"DockerfileVersion": src.File.DockerfileVersion,
@@ -599,7 +697,7 @@ func (o *Orchestrator) getBaseImageRef(ctx context.Context, bs *protocol.BuildSo
 			manifest["CloneTarget"] = fsrc.CloneTaget
 			manifest["RemoteURI"] = fsrc.RemoteUri
 		} else {
-			return "", xerrors.Errorf("unsupported context initializer")
+			return "", nil, xerrors.Errorf("unsupported context initializer")
 		}
 		// Go maps do NOT maintain their order - we must sort the keys to maintain a stable order
 		var keys []string
@@ -616,10 +714,10 @@ func (o *Orchestrator) getBaseImageRef(ctx context.Context, bs *protocol.BuildSo
 		hash := sha256.New()
 		n, err := hash.Write([]byte(dfl))
 		if err != nil {
-			return "", xerrors.Errorf("cannot compute src image ref: %w", err)
+			return "", nil, xerrors.Errorf("cannot compute src image ref: %w", err)
 		}
 		if n < len(dfl) {
-			return "", xerrors.Errorf("cannot compute src image ref: short write")
+			return "", nil, xerrors.Errorf("cannot compute src image ref: short write")
 		}
 
 		// the mkII image builder supported an image hash salt. That salt broke other assumptions,
@@ -628,13 +726,60 @@ func (o *Orchestrator) getBaseImageRef(ctx context.Context, bs *protocol.BuildSo
 		// basically defaulting to an empty salt string.
 		_, err = fmt.Fprintln(hash, "")
 		if err != nil {
-			return "", xerrors.Errorf("cannot compute src image ref: %w", err)
+			return "", nil, xerrors.Errorf("cannot compute src image ref: %w", err)
 		}
 
-		return fmt.Sprintf("%s:%x", o.Config.BaseImageRepository, hash.Sum([]byte{})), nil
+		return fmt.Sprintf("%s:%x", o.Config.BaseImageRepository, hash.Sum([]byte{})), pinnedBaseImages, nil
 
 	default:
-		return "", xerrors.Errorf("invalid base image")
+		return "", nil, xerrors.Errorf("invalid base image")
+	}
+}
+
+// getBuildCacheRef returns the ref of the build cache for a cache scope, or an empty string
+// if the build should not use a registry cache.
+func (o *Orchestrator) getBuildCacheRef(scope string) string {
+	if o.Config.BuildCacheRepository == "" || scope == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%x", o.Config.BuildCacheRepository, sha256.Sum256([]byte(scope)))
+}
+
+// censoredCacheRef returns the words which give away the build cache ref in build logs:
+// the ref itself and its repository, whose host may include a port
+func censoredCacheRef(cacheref string) []string {
+	res := []string{cacheref}
+	named, err := reference.ParseNormalizedNamed(cacheref)
+	if err != nil {
+		return res
+	}
+	res = append(res, named.Name())
+	if familiar := reference.FamiliarName(named); familiar != named.Name() {
+		res = append(res, familiar)
+	}
+	return res
+}
+
+// pinnedBaseImagesEnvvars makes bob build from the digests the base images were verified at
+func pinnedBaseImagesEnvvars(pinned map[string]string) []*wsmanapi.EnvironmentVariable {
+	if pinned == nil {
+		return nil
+	}
+	// marshalling a map of strings cannot fail
+	b, _ := json.Marshal(pinned)
+	return []*wsmanapi.EnvironmentVariable{
+		{Name: "BOB_PINNED_BASE_IMAGES", Value: string(b)},
+	}
+}
+
+// buildCacheEnvvars configures bob and its proxy to use the build cache
+func buildCacheEnvvars(cacheref string) []*wsmanapi.EnvironmentVariable {
+	if cacheref == "" {
+		return nil
+	}
+	return []*wsmanapi.EnvironmentVariable{
+		{Name: "BOB_CACHE_REF", Value: "localhost:8080/cache:latest"},
+		{Name: "WORKSPACEKIT_BOBPROXY_CACHEREF", Value: cacheref},
 	}
 }
 
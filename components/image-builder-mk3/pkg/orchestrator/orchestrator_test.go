@@ -6,11 +6,17 @@ package orchestrator
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/image-builder/api"
 	"github.com/gitpod-io/gitpod/image-builder/api/config"
 	apimock "github.com/gitpod-io/gitpod/image-builder/api/mock"
@@ -205,3 +211,89 @@ func TestResolveBaseImage(t *testing.T) {
 	}
 
 }
+
+func TestResolveWorkspaceImageVerifiesDockerfile(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	tests := []struct {
+		Name       string
+		Mode       config.ImageVerificationMode
+		Dockerfile string
+		Code       codes.Code
+	}{
+		{Name: "unresolvable arg", Mode: config.ImageVerificationModeEnforce, Dockerfile: "ARG BASE\nFROM $BASE\n", Code: codes.FailedPrecondition},
+		{Name: "unresolvable image", Mode: config.ImageVerificationModeEnforce, Dockerfile: "FROM alpine AS build\nFROM build\nFROM some-image:latest\n", Code: codes.FailedPrecondition},
+		{Name: "missing Dockerfile", Mode: config.ImageVerificationModeEnforce, Code: codes.FailedPrecondition},
+		{Name: "no base image", Mode: config.ImageVerificationModeEnforce, Dockerfile: "FROM scratch\n", Code: codes.OK},
+		{Name: "audit", Mode: config.ImageVerificationModeAudit, Dockerfile: "ARG BASE\nFROM $BASE\nFROM some-image:latest\n", Code: codes.OK},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			o, err := NewOrchestratingBuilder(config.Configuration{
+				WorkspaceManager: config.WorkspaceManagerConfig{
+					Client: wsmock.NewMockWorkspaceManagerClient(ctrl),
+				},
+				ImageVerification: &config.ImageVerificationConfig{
+					Default: &config.ImageVerificationPolicy{Mode: test.Mode, PublicKeys: []string{publicKey}},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			o.RefResolver = resolve.MockRefResolver{}
+
+			_, err = o.ResolveWorkspaceImage(context.Background(), &api.ResolveWorkspaceImageRequest{
+				Source: &api.BuildSource{
+					From: &api.BuildSource_File{File: &api.BuildSourceDockerfile{
+						Source: &csapi.WorkspaceInitializer{
+							Spec: &csapi.WorkspaceInitializer_Git{Git: &csapi.GitInitializer{RemoteUri: "https://github.com/gitpod-io/gitpod"}},
+						},
+						DockerfilePath:    ".gitpod.Dockerfile",
+						DockerfileContent: test.Dockerfile,
+					}},
+				},
+			})
+			if code := status.Code(err); code != test.Code {
+				t.Errorf("unexpected code %v: %v", code, err)
+			}
+		})
+	}
+}
+
+func TestCensoredCacheRef(t *testing.T) {
+	tests := []struct {
+		Name        string
+		CacheRef    string
+		Expectation []string
+	}{
+		{
+			Name:        "registry with port",
+			CacheRef:    "registry.local:5000/build-cache:abc",
+			Expectation: []string{"registry.local:5000/build-cache:abc", "registry.local:5000/build-cache"},
+		},
+		{
+			Name:        "docker hub",
+			CacheRef:    "gitpod/build-cache:abc",
+			Expectation: []string{"gitpod/build-cache:abc", "docker.io/gitpod/build-cache", "gitpod/build-cache"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := censoredCacheRef(test.CacheRef)
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected censored words (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,517 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package verify
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/remotes"
+	dockerremote "github.com/containerd/containerd/remotes/docker"
+	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	"github.com/gitpod-io/gitpod/image-builder/api/config"
+	"github.com/gitpod-io/gitpod/image-builder/pkg/auth"
+)
+
+var (
+	// ErrVerificationFailed is returned when an image does not satisfy the verification policy
+	ErrVerificationFailed = xerrors.Errorf("image verification failed")
+)
+
+const (
+	// cosignSignatureAnnotation carries the base64 encoded signature of a cosign signature layer
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// dsseMediaType is the media type of attestation layers
+	dsseMediaType = "application/vnd.dsse.envelope.v1+json"
+	// inTotoPayloadType is the DSSE payload type of in-toto statements
+	inTotoPayloadType = "application/vnd.in-toto+json"
+
+	// maxBlobSize limits the size of signature and attestation blobs we're willing to download
+	maxBlobSize = 4 * 1024 * 1024
+)
+
+// Verifier checks cosign signatures and in-toto attestations of images against the configured public keys.
+// It does not consult a transparency log, hence works without access to sigstore's public infrastructure.
+type Verifier struct {
+	// ResolverFactory produces the resolver used to download signatures. Used for testing.
+	ResolverFactory func(auth *auth.Authentication) remotes.Resolver
+
+	dflt          *policy
+	organizations map[string]*policy
+}
+
+type policy struct {
+	Mode                 config.ImageVerificationMode
+	Repositories         []string
+	Keys                 []crypto.PublicKey
+	RequiredAttestations []string
+}
+
+// NewVerifier produces a new verifier
+func NewVerifier(cfg config.ImageVerificationConfig) (*Verifier, error) {
+	res := &Verifier{
+		organizations: make(map[string]*policy, len(cfg.Organizations)),
+	}
+	if cfg.Default != nil {
+		p, err := newPolicy(*cfg.Default)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid default policy: %w", err)
+		}
+		res.dflt = p
+	}
+	for org, pcfg := range cfg.Organizations {
+		p, err := newPolicy(pcfg)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid policy for organization %s: %w", org, err)
+		}
+		res.organizations[org] = p
+	}
+	return res, nil
+}
+
+func newPolicy(cfg config.ImageVerificationPolicy) (*policy, error) {
+	switch cfg.Mode {
+	case config.ImageVerificationModeEnforce, config.ImageVerificationModeAudit:
+	case "":
+		cfg.Mode = config.ImageVerificationModeEnforce
+	default:
+		return nil, xerrors.Errorf("unknown mode %q", cfg.Mode)
+	}
+	if len(cfg.PublicKeys) == 0 {
+		return nil, xerrors.Errorf("at least one public key is required")
+	}
+
+	res := &policy{
+		Mode:                 cfg.Mode,
+		Repositories:         cfg.Repositories,
+		RequiredAttestations: cfg.RequiredAttestations,
+	}
+	for i, k := range cfg.PublicKeys {
+		key, err := parsePublicKey(k)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid public key %d: %w", i, err)
+		}
+		res.Keys = append(res.Keys, key)
+	}
+	return res, nil
+}
+
+func parsePublicKey(pemKey string) (crypto.PublicKey, error) {
+	blk, _ := pem.Decode([]byte(pemKey))
+	if blk == nil {
+		return nil, xerrors.Errorf("no PEM data found")
+	}
+	key, err := x509.ParsePKIXPublicKey(blk.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, xerrors.Errorf("unsupported key type %T", key)
+	}
+}
+
+// applies returns true if the policy covers images from the named repository
+func (p *policy) applies(name reference.Named) bool {
+	if len(p.Repositories) == 0 {
+		return true
+	}
+	for _, r := range p.Repositories {
+		if strings.HasPrefix(name.Name(), r) {
+			return true
+		}
+	}
+	return false
+}
+
+// verify checks a signature against the trusted keys
+func (p *policy) verify(payload, sig []byte) bool {
+	for _, k := range p.Keys {
+		switch key := k.(type) {
+		case *ecdsa.PublicKey:
+			h := sha256.Sum256(payload)
+			if ecdsa.VerifyASN1(key, h[:], sig) {
+				return true
+			}
+		case *rsa.PublicKey:
+			h := sha256.Sum256(payload)
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, h[:], sig) == nil {
+				return true
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(key, payload, sig) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Verify checks if the image is signed and attested as the policy of the organization demands.
+// ref is the image reference as the user specified it, resolvedRef its digested form we'll build from.
+// Returns an error wrapping ErrVerificationFailed if the image does not satisfy the policy.
+func (v *Verifier) Verify(ctx context.Context, organizationID, ref, resolvedRef string, authentication *auth.Authentication) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Verifier.Verify")
+	defer tracing.FinishSpan(span, &err)
+	span.SetTag("organizationID", organizationID)
+	span.SetTag("ref", resolvedRef)
+
+	p, ok := v.organizations[organizationID]
+	if !ok {
+		p = v.dflt
+	}
+	if p == nil {
+		return nil
+	}
+
+	pref, err := reference.ParseNormalizedNamed(resolvedRef)
+	if err != nil {
+		return xerrors.Errorf("cannot parse image ref: %w", err)
+	}
+	cref, ok := pref.(reference.Canonical)
+	if !ok {
+		return xerrors.Errorf("image ref %s is not in digest form", resolvedRef)
+	}
+	if !p.applies(pref) {
+		return nil
+	}
+
+	err = v.verify(ctx, p, ref, cref, authentication)
+	if xerrors.Is(err, ErrVerificationFailed) && p.Mode == config.ImageVerificationModeAudit {
+		log.WithError(err).WithField("organizationID", organizationID).WithField("ref", resolvedRef).Warn("base image failed verification - building it nonetheless because the policy is in audit mode")
+		return nil
+	}
+	return err
+}
+
+// Enforces returns true if the policy of the organization rejects images from ref which fail verification.
+// An empty ref stands for an image we cannot name, which any enforcing policy would have to cover.
+func (v *Verifier) Enforces(organizationID, ref string) bool {
+	p, ok := v.organizations[organizationID]
+	if !ok {
+		p = v.dflt
+	}
+	if p == nil || p.Mode != config.ImageVerificationModeEnforce {
+		return false
+	}
+	if ref == "" {
+		return true
+	}
+
+	pref, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return true
+	}
+	return p.applies(pref)
+}
+
+func (v *Verifier) verify(ctx context.Context, p *policy, ref string, cref reference.Canonical, authentication *auth.Authentication) error {
+	resolver := v.resolver(authentication)
+	repo := reference.TrimNamed(cref)
+
+	subjects := []digest.Digest{cref.Digest()}
+	if idx, err := v.resolveIndex(ctx, resolver, ref, cref); err != nil {
+		return err
+	} else if idx != "" {
+		// multi-arch images are commonly signed by their index digest
+		subjects = append(subjects, idx)
+	}
+
+	var signed bool
+	for _, dgst := range subjects {
+		ok, err := v.verifySignatures(ctx, resolver, p, repo, dgst)
+		if err != nil {
+			return err
+		}
+		if ok {
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return xerrors.Errorf("%s carries no signature of a trusted key: %w", cref.String(), ErrVerificationFailed)
+	}
+
+	if len(p.RequiredAttestations) == 0 {
+		return nil
+	}
+	attested := make(map[string]struct{})
+	for _, dgst := range subjects {
+		predicates, err := v.verifyAttestations(ctx, resolver, p, repo, dgst)
+		if err != nil {
+			return err
+		}
+		for _, pt := range predicates {
+			attested[pt] = struct{}{}
+		}
+	}
+	var missing []string
+	for _, pt := range p.RequiredAttestations {
+		if _, ok := attested[pt]; !ok {
+			missing = append(missing, pt)
+		}
+	}
+	if len(missing) > 0 {
+		return xerrors.Errorf("%s carries no attestation of a trusted key for %s: %w", cref.String(), strings.Join(missing, ", "), ErrVerificationFailed)
+	}
+	return nil
+}
+
+func (v *Verifier) resolver(authentication *auth.Authentication) remotes.Resolver {
+	if v.ResolverFactory != nil {
+		return v.ResolverFactory(authentication)
+	}
+	return dockerremote.NewResolver(dockerremote.ResolverOptions{
+		Authorizer: dockerremote.NewDockerAuthorizer(dockerremote.WithAuthCreds(func(host string) (username, password string, err error) {
+			if authentication == nil {
+				return
+			}
+			return authentication.Username, authentication.Password, nil
+		})),
+	})
+}
+
+// resolveIndex returns the digest of the image index ref points to, if it contains the resolved manifest
+func (v *Verifier) resolveIndex(ctx context.Context, resolver remotes.Resolver, ref string, cref reference.Canonical) (digest.Digest, error) {
+	if ref == "" {
+		return "", nil
+	}
+	pref, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", xerrors.Errorf("cannot parse image ref: %w", err)
+	}
+	pref = reference.TagNameOnly(pref)
+
+	name, desc, err := resolver.Resolve(ctx, pref.String())
+	if err != nil {
+		return "", xerrors.Errorf("cannot resolve %s: %w", ref, err)
+	}
+	if desc.Digest == cref.Digest() || !images.IsIndexType(desc.MediaType) {
+		return "", nil
+	}
+
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	buf, err := fetchBlob(ctx, fetcher, desc)
+	if err != nil {
+		return "", xerrors.Errorf("cannot fetch image index: %w", err)
+	}
+	var idx ociv1.Index
+	err = json.Unmarshal(buf, &idx)
+	if err != nil {
+		return "", xerrors.Errorf("cannot unmarshal image index: %w", err)
+	}
+	for _, mf := range idx.Manifests {
+		if mf.Digest == cref.Digest() {
+			return desc.Digest, nil
+		}
+	}
+	return "", nil
+}
+
+// fetchArtifact downloads the manifest cosign stores next to an image, e.g. its signatures.
+// Returns a nil manifest if there is no such artifact.
+func fetchArtifact(ctx context.Context, resolver remotes.Resolver, repo reference.Named, dgst digest.Digest, suffix string) (*ociv1.Manifest, remotes.Fetcher, error) {
+	ref := fmt.Sprintf("%s:%s-%s.%s", repo.Name(), dgst.Algorithm(), dgst.Encoded(), suffix)
+	name, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		// registries are not consistent in how they report missing tags, hence we treat every resolution error as absence
+		log.WithError(err).WithField("ref", ref).Debug("cannot resolve cosign artifact")
+		return nil, nil, nil
+	}
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	buf, err := fetchBlob(ctx, fetcher, desc)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("cannot fetch %s: %w", ref, err)
+	}
+	var mf ociv1.Manifest
+	err = json.Unmarshal(buf, &mf)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("cannot unmarshal %s: %w", ref, err)
+	}
+	return &mf, fetcher, nil
+}
+
+func fetchBlob(ctx context.Context, fetcher remotes.Fetcher, desc ociv1.Descriptor) ([]byte, error) {
+	if desc.Size > maxBlobSize {
+		return nil, xerrors.Errorf("blob %s is too large (%d bytes)", desc.Digest, desc.Size)
+	}
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	buf, err := io.ReadAll(io.LimitReader(rc, maxBlobSize))
+	if err != nil {
+		return nil, err
+	}
+	if desc.Digest != "" && digest.FromBytes(buf) != desc.Digest {
+		return nil, xerrors.Errorf("blob does not match digest %s", desc.Digest)
+	}
+	return buf, nil
+}
+
+// simpleSigningPayload is what cosign signs
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// verifySignatures returns true if the image carries a cosign signature of a trusted key
+func (v *Verifier) verifySignatures(ctx context.Context, resolver remotes.Resolver, p *policy, repo reference.Named, dgst digest.Digest) (bool, error) {
+	mf, fetcher, err := fetchArtifact(ctx, resolver, repo, dgst, "sig")
+	if err != nil || mf == nil {
+		return false, err
+	}
+
+	for _, l := range mf.Layers {
+		sig, err := base64.StdEncoding.DecodeString(l.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(sig) == 0 {
+			continue
+		}
+		payload, err := fetchBlob(ctx, fetcher, l)
+		if err != nil {
+			return false, xerrors.Errorf("cannot fetch signature payload: %w", err)
+		}
+		if !p.verify(payload, sig) {
+			continue
+		}
+
+		var sp simpleSigningPayload
+		err = json.Unmarshal(payload, &sp)
+		if err != nil {
+			continue
+		}
+		if sp.Critical.Image.DockerManifestDigest != dgst.String() {
+			// a valid signature for another image, e.g. copied over to make this one look signed
+			continue
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+	Signatures  []struct {
+		KeyID string `json:"keyid"`
+		Sig   string `json:"sig"`
+	} `json:"signatures"`
+}
+
+type inTotoStatement struct {
+	PredicateType string `json:"predicateType"`
+	Subject       []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+// verifyAttestations returns the predicate types of all attestations of the image which are signed by a trusted key
+func (v *Verifier) verifyAttestations(ctx context.Context, resolver remotes.Resolver, p *policy, repo reference.Named, dgst digest.Digest) ([]string, error) {
+	mf, fetcher, err := fetchArtifact(ctx, resolver, repo, dgst, "att")
+	if err != nil || mf == nil {
+		return nil, err
+	}
+
+	var res []string
+	for _, l := range mf.Layers {
+		if l.MediaType != dsseMediaType {
+			continue
+		}
+		buf, err := fetchBlob(ctx, fetcher, l)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot fetch attestation: %w", err)
+		}
+		stmt, ok := verifyDSSE(p, buf)
+		if !ok {
+			continue
+		}
+		for _, s := range stmt.Subject {
+			if s.Digest[dgst.Algorithm().String()] == dgst.Encoded() {
+				res = append(res, stmt.PredicateType)
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+// verifyDSSE returns the in-toto statement of a DSSE envelope if it's signed by a trusted key
+func verifyDSSE(p *policy, envelope []byte) (*inTotoStatement, bool) {
+	var env dsseEnvelope
+	err := json.Unmarshal(envelope, &env)
+	if err != nil || env.PayloadType != inTotoPayloadType {
+		return nil, false
+	}
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, false
+	}
+
+	pae := dssePAE(env.PayloadType, payload)
+	var signed bool
+	for _, s := range env.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			continue
+		}
+		if p.verify(pae, sig) {
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return nil, false
+	}
+
+	var stmt inTotoStatement
+	err = json.Unmarshal(payload, &stmt)
+	if err != nil {
+		return nil, false
+	}
+	return &stmt, true
+}
+
+// dssePAE computes the pre-authentication encoding DSSE signatures are computed over
+func dssePAE(payloadType string, payload []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))
+	buf.Write(payload)
+	return buf.Bytes()
+}
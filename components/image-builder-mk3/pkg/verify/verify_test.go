@@ -0,0 +1,329 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package verify
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/remotes"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/image-builder/api/config"
+	"github.com/gitpod-io/gitpod/image-builder/pkg/auth"
+)
+
+const repo = "docker.io/library/alpine"
+
+// fakeRegistry serves manifests by reference and blobs by digest
+type fakeRegistry struct {
+	refs  map[string]ociv1.Descriptor
+	blobs map[digest.Digest][]byte
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		refs:  make(map[string]ociv1.Descriptor),
+		blobs: make(map[digest.Digest][]byte),
+	}
+}
+
+func (r *fakeRegistry) add(mediaType string, content []byte, annotations map[string]string) ociv1.Descriptor {
+	dgst := digest.FromBytes(content)
+	r.blobs[dgst] = content
+	return ociv1.Descriptor{MediaType: mediaType, Digest: dgst, Size: int64(len(content)), Annotations: annotations}
+}
+
+func (r *fakeRegistry) tag(ref string, mediaType string, obj interface{}) ociv1.Descriptor {
+	content, _ := json.Marshal(obj)
+	desc := r.add(mediaType, content, nil)
+	r.refs[ref] = desc
+	return desc
+}
+
+func (r *fakeRegistry) Resolve(ctx context.Context, ref string) (name string, desc ociv1.Descriptor, err error) {
+	desc, ok := r.refs[ref]
+	if !ok {
+		return "", ociv1.Descriptor{}, errdefs.ErrNotFound
+	}
+	return ref, desc, nil
+}
+
+func (r *fakeRegistry) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	return r, nil
+}
+
+func (r *fakeRegistry) Pusher(ctx context.Context, ref string) (remotes.Pusher, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (r *fakeRegistry) Fetch(ctx context.Context, desc ociv1.Descriptor) (io.ReadCloser, error) {
+	b, ok := r.blobs[desc.Digest]
+	if !ok {
+		return nil, errdefs.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+type signer struct {
+	key *ecdsa.PrivateKey
+}
+
+func newSigner(t *testing.T) *signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &signer{key: key}
+}
+
+func (s *signer) PublicKey() string {
+	der, _ := x509.MarshalPKIXPublicKey(&s.key.PublicKey)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func (s *signer) Sign(payload []byte) string {
+	h := sha256.Sum256(payload)
+	sig, _ := ecdsa.SignASN1(rand.Reader, s.key, h[:])
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+// SignImage adds a cosign signature for the subject to the registry
+func (s *signer) SignImage(reg *fakeRegistry, subject digest.Digest) {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, repo, subject))
+	layer := reg.add("application/vnd.dev.cosign.simplesigning.v1+json", payload, map[string]string{
+		cosignSignatureAnnotation: s.Sign(payload),
+	})
+	reg.tag(fmt.Sprintf("%s:sha256-%s.sig", repo, subject.Encoded()), ociv1.MediaTypeImageManifest, ociv1.Manifest{Layers: []ociv1.Descriptor{layer}})
+}
+
+// Attest adds an in-toto attestation for the subject to the registry
+func (s *signer) Attest(reg *fakeRegistry, subject digest.Digest, predicateType string) {
+	stmt := []byte(fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":%q,"subject":[{"name":%q,"digest":{"sha256":%q}}],"predicate":{}}`, predicateType, repo, subject.Encoded()))
+	env, _ := json.Marshal(map[string]interface{}{
+		"payloadType": inTotoPayloadType,
+		"payload":     base64.StdEncoding.EncodeToString(stmt),
+		"signatures":  []map[string]string{{"sig": s.Sign(dssePAE(inTotoPayloadType, stmt))}},
+	})
+	layer := reg.add(dsseMediaType, env, nil)
+	reg.tag(fmt.Sprintf("%s:sha256-%s.att", repo, subject.Encoded()), ociv1.MediaTypeImageManifest, ociv1.Manifest{Layers: []ociv1.Descriptor{layer}})
+}
+
+func TestVerify(t *testing.T) {
+	const (
+		org        = "org"
+		provenance = "https://slsa.dev/provenance/v0.2"
+	)
+	var (
+		trusted   = newSigner(t)
+		untrusted = newSigner(t)
+	)
+
+	type registry struct {
+		Reg      *fakeRegistry
+		Manifest digest.Digest
+		Index    digest.Digest
+	}
+	newRegistry := func() registry {
+		reg := newFakeRegistry()
+		mf := reg.add(ociv1.MediaTypeImageManifest, []byte(`{"schemaVersion":2}`), nil)
+		idx := reg.tag(repo+":latest", images.MediaTypeDockerSchema2ManifestList, ociv1.Index{Manifests: []ociv1.Descriptor{mf}})
+		return registry{Reg: reg, Manifest: mf.Digest, Index: idx.Digest}
+	}
+	policy := func(mods ...func(*config.ImageVerificationPolicy)) config.ImageVerificationConfig {
+		p := config.ImageVerificationPolicy{PublicKeys: []string{trusted.PublicKey()}}
+		for _, m := range mods {
+			m(&p)
+		}
+		return config.ImageVerificationConfig{Organizations: map[string]config.ImageVerificationPolicy{org: p}}
+	}
+
+	tests := []struct {
+		Name          string
+		Config        config.ImageVerificationConfig
+		Organization  string
+		Setup         func(r registry)
+		ExpectedError bool
+	}{
+		{
+			Name:         "no policy",
+			Config:       policy(),
+			Organization: "other-org",
+		},
+		{
+			Name:          "default policy",
+			Config:        config.ImageVerificationConfig{Default: &config.ImageVerificationPolicy{PublicKeys: []string{trusted.PublicKey()}}},
+			Organization:  "other-org",
+			ExpectedError: true,
+		},
+		{
+			Name:         "signed manifest",
+			Config:       policy(),
+			Organization: org,
+			Setup:        func(r registry) { trusted.SignImage(r.Reg, r.Manifest) },
+		},
+		{
+			Name:         "signed index",
+			Config:       policy(),
+			Organization: org,
+			Setup:        func(r registry) { trusted.SignImage(r.Reg, r.Index) },
+		},
+		{
+			Name:          "unsigned",
+			Config:        policy(),
+			Organization:  org,
+			ExpectedError: true,
+		},
+		{
+			Name:          "untrusted signature",
+			Config:        policy(),
+			Organization:  org,
+			Setup:         func(r registry) { untrusted.SignImage(r.Reg, r.Manifest) },
+			ExpectedError: true,
+		},
+		{
+			Name:         "signature of another image",
+			Config:       policy(),
+			Organization: org,
+			Setup: func(r registry) {
+				other := digest.FromString("other")
+				trusted.SignImage(r.Reg, other)
+				r.Reg.refs[fmt.Sprintf("%s:sha256-%s.sig", repo, r.Manifest.Encoded())] = r.Reg.refs[fmt.Sprintf("%s:sha256-%s.sig", repo, other.Encoded())]
+			},
+			ExpectedError: true,
+		},
+		{
+			Name:         "audit mode",
+			Config:       policy(func(p *config.ImageVerificationPolicy) { p.Mode = config.ImageVerificationModeAudit }),
+			Organization: org,
+		},
+		{
+			Name:         "other repository",
+			Config:       policy(func(p *config.ImageVerificationPolicy) { p.Repositories = []string{"docker.io/gitpod/"} }),
+			Organization: org,
+		},
+		{
+			Name:         "required attestation",
+			Config:       policy(func(p *config.ImageVerificationPolicy) { p.RequiredAttestations = []string{provenance} }),
+			Organization: org,
+			Setup: func(r registry) {
+				trusted.SignImage(r.Reg, r.Index)
+				trusted.Attest(r.Reg, r.Index, provenance)
+			},
+		},
+		{
+			Name:         "missing attestation",
+			Config:       policy(func(p *config.ImageVerificationPolicy) { p.RequiredAttestations = []string{provenance} }),
+			Organization: org,
+			Setup: func(r registry) {
+				trusted.SignImage(r.Reg, r.Manifest)
+				trusted.Attest(r.Reg, r.Manifest, "https://spdx.dev/Document")
+			},
+			ExpectedError: true,
+		},
+		{
+			Name:         "untrusted attestation",
+			Config:       policy(func(p *config.ImageVerificationPolicy) { p.RequiredAttestations = []string{provenance} }),
+			Organization: org,
+			Setup: func(r registry) {
+				trusted.SignImage(r.Reg, r.Manifest)
+				untrusted.Attest(r.Reg, r.Manifest, provenance)
+			},
+			ExpectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			r := newRegistry()
+			if test.Setup != nil {
+				test.Setup(r)
+			}
+
+			v, err := NewVerifier(test.Config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.ResolverFactory = func(*auth.Authentication) remotes.Resolver { return r.Reg }
+
+			err = v.Verify(context.Background(), test.Organization, "alpine", repo+"@"+r.Manifest.String(), nil)
+			if test.ExpectedError {
+				if !xerrors.Is(err, ErrVerificationFailed) {
+					t.Errorf("expected verification to fail, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Policy        config.ImageVerificationPolicy
+		ExpectedError bool
+	}{
+		{Name: "valid", Policy: config.ImageVerificationPolicy{PublicKeys: []string{newSigner(t).PublicKey()}}},
+		{Name: "no keys", Policy: config.ImageVerificationPolicy{}, ExpectedError: true},
+		{Name: "invalid key", Policy: config.ImageVerificationPolicy{PublicKeys: []string{"foo"}}, ExpectedError: true},
+		{Name: "unknown mode", Policy: config.ImageVerificationPolicy{Mode: "foo", PublicKeys: []string{newSigner(t).PublicKey()}}, ExpectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := NewVerifier(config.ImageVerificationConfig{Default: &test.Policy})
+			if (err != nil) != test.ExpectedError {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestEnforces(t *testing.T) {
+	key := newSigner(t).PublicKey()
+	v, err := NewVerifier(config.ImageVerificationConfig{
+		Default: &config.ImageVerificationPolicy{PublicKeys: []string{key}, Repositories: []string{"docker.io/library/"}},
+		Organizations: map[string]config.ImageVerificationPolicy{
+			"audited": {Mode: config.ImageVerificationModeAudit, PublicKeys: []string{key}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name         string
+		Organization string
+		Ref          string
+		Expectation  bool
+	}{
+		{Name: "covered repository", Ref: "alpine:latest", Expectation: true},
+		{Name: "other repository", Ref: "quay.io/foo/bar", Expectation: false},
+		{Name: "unknown image", Ref: "", Expectation: true},
+		{Name: "invalid ref", Ref: "Not A Ref", Expectation: true},
+		{Name: "audit mode", Organization: "audited", Ref: "alpine:latest", Expectation: false},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if act := v.Enforces(test.Organization, test.Ref); act != test.Expectation {
+				t.Errorf("Enforces() = %v, want %v", act, test.Expectation)
+			}
+		})
+	}
+}
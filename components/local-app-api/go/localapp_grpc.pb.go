@@ -29,6 +29,7 @@ type LocalAppClient interface {
 	TunnelStatus(ctx context.Context, in *TunnelStatusRequest, opts ...grpc.CallOption) (LocalApp_TunnelStatusClient, error)
 	AutoTunnel(ctx context.Context, in *AutoTunnelRequest, opts ...grpc.CallOption) (*AutoTunnelResponse, error)
 	ResolveSSHConnection(ctx context.Context, in *ResolveSSHConnectionRequest, opts ...grpc.CallOption) (*ResolveSSHConnectionResponse, error)
+	ResolveProxy(ctx context.Context, in *ResolveProxyRequest, opts ...grpc.CallOption) (*ResolveProxyResponse, error)
 }
 
 type localAppClient struct {
@@ -89,6 +90,15 @@ func (c *localAppClient) ResolveSSHConnection(ctx context.Context, in *ResolveSS
 	return out, nil
 }
 
+func (c *localAppClient) ResolveProxy(ctx context.Context, in *ResolveProxyRequest, opts ...grpc.CallOption) (*ResolveProxyResponse, error) {
+	out := new(ResolveProxyResponse)
+	err := c.cc.Invoke(ctx, "/localapp.LocalApp/ResolveProxy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LocalAppServer is the server API for LocalApp service.
 // All implementations must embed UnimplementedLocalAppServer
 // for forward compatibility
@@ -96,6 +106,7 @@ type LocalAppServer interface {
 	TunnelStatus(*TunnelStatusRequest, LocalApp_TunnelStatusServer) error
 	AutoTunnel(context.Context, *AutoTunnelRequest) (*AutoTunnelResponse, error)
 	ResolveSSHConnection(context.Context, *ResolveSSHConnectionRequest) (*ResolveSSHConnectionResponse, error)
+	ResolveProxy(context.Context, *ResolveProxyRequest) (*ResolveProxyResponse, error)
 	mustEmbedUnimplementedLocalAppServer()
 }
 
@@ -112,6 +123,9 @@ func (UnimplementedLocalAppServer) AutoTunnel(context.Context, *AutoTunnelReques
 func (UnimplementedLocalAppServer) ResolveSSHConnection(context.Context, *ResolveSSHConnectionRequest) (*ResolveSSHConnectionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResolveSSHConnection not implemented")
 }
+func (UnimplementedLocalAppServer) ResolveProxy(context.Context, *ResolveProxyRequest) (*ResolveProxyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveProxy not implemented")
+}
 func (UnimplementedLocalAppServer) mustEmbedUnimplementedLocalAppServer() {}
 
 // UnsafeLocalAppServer may be embedded to opt out of forward compatibility for this service.
@@ -182,6 +196,24 @@ func _LocalApp_ResolveSSHConnection_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LocalApp_ResolveProxy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveProxyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalAppServer).ResolveProxy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localapp.LocalApp/ResolveProxy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalAppServer).ResolveProxy(ctx, req.(*ResolveProxyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LocalApp_ServiceDesc is the grpc.ServiceDesc for LocalApp service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -197,6 +229,10 @@ var LocalApp_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ResolveSSHConnection",
 			Handler:    _LocalApp_ResolveSSHConnection_Handler,
 		},
+		{
+			MethodName: "ResolveProxy",
+			Handler:    _LocalApp_ResolveProxy_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -37,6 +37,12 @@ func main() {
 	if sshConfig == "" {
 		sshConfig = filepath.Join(os.TempDir(), "gitpod_ssh_config")
 	}
+	profiles := os.Getenv("GITPOD_LCA_PROFILES")
+	if profiles == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			profiles = filepath.Join(home, ".gitpod", "local-app-profiles.json")
+		}
+	}
 
 	app := cli.App{
 		Name:                 "gitpod-local-companion",
@@ -77,6 +83,14 @@ func main() {
 				},
 				Value: true,
 			},
+			&cli.BoolFlag{
+				Name:  "proxy",
+				Usage: "Serve a SOCKS5 and HTTP proxy into the network of each running workspace",
+				EnvVars: []string{
+					"GITPOD_LCA_PROXY",
+				},
+				Value: false,
+			},
 			&cli.StringFlag{
 				Name: "auth-redirect-url",
 				EnvVars: []string{
@@ -121,10 +135,12 @@ func main() {
 						apiPort:           c.Int("api-port"),
 						allowCORSFromPort: c.Bool("allow-cors-from-port"),
 						autoTunnel:        c.Bool("auto-tunnel"),
+						proxy:             c.Bool("proxy"),
 						authRedirectURL:   c.String("auth-redirect-url"),
 						verbose:           c.Bool("verbose"),
 						authTimeout:       c.Duration("auth-timeout"),
 						localAppTimeout:   c.Duration("timeout"),
+						profilesPath:      c.String("profiles"),
 					})
 				},
 				Flags: []cli.Flag{
@@ -133,6 +149,11 @@ func main() {
 						Usage: "produce and update an OpenSSH compatible ssh_config file (defaults to $GITPOD_LCA_SSH_CONFIG)",
 						Value: sshConfig,
 					},
+					&cli.PathFlag{
+						Name:  "profiles",
+						Usage: "port-forward profiles applied to workspaces (defaults to $GITPOD_LCA_PROFILES)",
+						Value: profiles,
+					},
 				},
 			},
 		},
@@ -155,10 +176,12 @@ type runOptions struct {
 	apiPort           int
 	allowCORSFromPort bool
 	autoTunnel        bool
+	proxy             bool
 	authRedirectURL   string
 	verbose           bool
 	authTimeout       time.Duration
 	localAppTimeout   time.Duration
+	profilesPath      string
 }
 
 func run(opts runOptions) error {
@@ -208,6 +231,8 @@ func run(opts runOptions) error {
 
 	b = bastion.New(client, opts.localAppTimeout, cb)
 	b.EnableAutoTunnel = opts.autoTunnel
+	b.ProfilesPath = opts.profilesPath
+	b.EnableProxy = opts.proxy
 	grpcServer := grpc.NewServer()
 	appapi.RegisterLocalAppServer(grpcServer, bastion.NewLocalAppService(b, s))
 	allowOrigin := func(origin string) bool {
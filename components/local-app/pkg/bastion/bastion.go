@@ -67,6 +67,11 @@ type TunnelListener struct {
 	Visibility supervisor.TunnelVisiblity
 	Ctx        context.Context
 	Cancel     func()
+
+	// Profile is the name of the port-forward profile which configured the tunnel
+	Profile string
+	// Conflict describes why the tunnel could not be established as configured
+	Conflict string
 }
 
 type Workspace struct {
@@ -88,6 +93,8 @@ type Workspace struct {
 	SSHPrivateFN     string
 	SSHPublicKey     string
 
+	proxyListener *ProxyListener
+
 	ctx    context.Context
 	cancel context.CancelFunc
 
@@ -104,6 +111,9 @@ func (ws *Workspace) Status() []*app.TunnelStatus {
 			RemotePort: listener.RemotePort,
 			LocalPort:  listener.LocalPort,
 			Visibility: listener.Visibility,
+			LocalAddr:  listener.LocalAddr,
+			Profile:    listener.Profile,
+			Conflict:   listener.Conflict,
 		})
 	}
 	return res
@@ -205,6 +215,10 @@ type Bastion struct {
 	subscriptions   map[*StatusSubscription]struct{}
 
 	EnableAutoTunnel bool
+	// ProfilesPath is the port-forward profiles file, which is re-read whenever ports tunneling (re)starts
+	ProfilesPath string
+	// EnableProxy starts a SOCKS5 and HTTP proxy into the network of every running workspace
+	EnableProxy bool
 }
 
 func (b *Bastion) Run() error {
@@ -229,6 +243,7 @@ func (b *Bastion) Run() error {
 	}()
 
 	go b.handleTimeout()
+	go b.watchWake()
 	if b.localAppTimeout != 0 {
 		b.workspaceMapChangeChan <- 0
 	}
@@ -281,6 +296,31 @@ func (b *Bastion) Update(workspaceID string) *Workspace {
 	return <-done
 }
 
+// wakeCheckInterval is how often we check whether the machine woke up from sleep
+const wakeCheckInterval = 5 * time.Second
+
+// watchWake refreshes all workspaces when the machine wakes up from sleep, so that tunnel clients reconnect right away
+func (b *Bastion) watchWake() {
+	t := time.NewTicker(wakeCheckInterval)
+	defer t.Stop()
+
+	// we compare wall clock times because the monotonic clock does not advance during sleep
+	last := time.Now().Round(0)
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-t.C:
+		}
+		now := time.Now().Round(0)
+		if now.Sub(last) > 3*wakeCheckInterval {
+			logrus.WithField("asleep", now.Sub(last)).Info("woke up from sleep, refreshing workspaces")
+			b.FullUpdate()
+		}
+		last = now
+	}
+}
+
 func (b *Bastion) handleTimeout() {
 	if b.localAppTimeout == 0 {
 		return
@@ -355,6 +395,14 @@ func (b *Bastion) handleUpdate(ur *WorkspaceUpdateRequest) {
 			}
 		}
 
+		if ws.proxyListener == nil && ws.tunnelClientConnected && b.EnableProxy {
+			var err error
+			ws.proxyListener, err = b.establishProxy(ws.ctx, ws)
+			if err != nil {
+				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).Error("cannot establish proxy")
+			}
+		}
+
 		if ws.supervisorClient == nil && ws.supervisorListener != nil {
 			var err error
 			ws.supervisorClient, err = grpc.Dial(ws.supervisorListener.LocalAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -557,7 +605,20 @@ func newTunnelClient(ctx context.Context, ws *Workspace, reconnecting *gitpod.Re
 	return client, closed, err
 }
 
+func tunnelHost(visibility supervisor.TunnelVisiblity) string {
+	if visibility == supervisor.TunnelVisiblity_network {
+		return "0.0.0.0"
+	}
+	return "127.0.0.1"
+}
+
 func (b *Bastion) establishTunnel(ctx context.Context, ws *Workspace, logprefix string, remotePort int, targetPort int, visibility supervisor.TunnelVisiblity) (*TunnelListener, error) {
+	return b.establishTunnelOn(ctx, ws, logprefix, remotePort, tunnelHost(visibility), targetPort, visibility)
+}
+
+// establishTunnelOn tunnels remotePort to targetHost:targetPort. If the target port is in use,
+// the tunnel listens on a random port instead and the listener reports the conflict.
+func (b *Bastion) establishTunnelOn(ctx context.Context, ws *Workspace, logprefix string, remotePort int, targetHost string, targetPort int, visibility supervisor.TunnelVisiblity) (*TunnelListener, error) {
 	if !ws.tunnelClientConnected {
 		return nil, xerrors.Errorf("tunnel client is not connected")
 	}
@@ -565,21 +626,23 @@ func (b *Bastion) establishTunnel(ctx context.Context, ws *Workspace, logprefix
 		return nil, xerrors.Errorf("tunnel visibility is none")
 	}
 
-	targetHost := "127.0.0.1"
-	if visibility == supervisor.TunnelVisiblity_network {
-		targetHost = "0.0.0.0"
-	}
-
-	netListener, err := net.Listen("tcp", targetHost+":"+strconv.Itoa(targetPort))
-	var localPort int
+	netListener, err := net.Listen("tcp", net.JoinHostPort(targetHost, strconv.Itoa(targetPort)))
+	var (
+		localPort int
+		conflict  string
+	)
 	if err == nil {
 		localPort = netListener.(*net.TCPListener).Addr().(*net.TCPAddr).Port
 	} else {
-		netListener, err = net.Listen("tcp", targetHost+":0")
+		netListener, err = net.Listen("tcp", net.JoinHostPort(targetHost, "0"))
 		if err != nil {
 			return nil, err
 		}
 		localPort = netListener.(*net.TCPListener).Addr().(*net.TCPAddr).Port
+		if targetPort != 0 {
+			conflict = fmt.Sprintf("local port %d is in use, listening on %d instead", targetPort, localPort)
+			logrus.WithField("workspace", ws.WorkspaceID).Warn(logprefix + ": " + conflict)
+		}
 	}
 	logrus.WithField("workspace", ws.WorkspaceID).Info(logprefix + ": listening on " + netListener.Addr().String() + "...")
 	listenerCtx, cancel := context.WithCancel(ctx)
@@ -648,6 +711,7 @@ func (b *Bastion) establishTunnel(ctx context.Context, ws *Workspace, logprefix
 		Visibility: visibility,
 		Ctx:        listenerCtx,
 		Cancel:     cancel,
+		Conflict:   conflict,
 	}, nil
 }
 
@@ -733,18 +797,25 @@ func (b *Bastion) tunnelPorts(ws *Workspace) {
 	ws.cancelTunnel = cancel
 	ws.tunnelMu.Unlock()
 
+	defer b.notify(ws)
 	defer func() {
 		ws.tunnelMu.Lock()
 		defer ws.tunnelMu.Unlock()
 
+		// tunnels are kept while we reconnect, e.g. after the machine slept, and only closed once we're done
+		for port, t := range ws.tunnelListeners {
+			delete(ws.tunnelListeners, port)
+			t.Cancel()
+		}
 		ws.cancelTunnel = nil
 		logrus.WithField("workspace", ws.WorkspaceID).Info("ports tunneling finished")
 	}()
 
+	contextURL := b.contextURL(ctx, ws)
 	for {
 		logrus.WithField("workspace", ws.WorkspaceID).Info("tunneling ports...")
 
-		err := b.doTunnelPorts(ctx, ws)
+		err := b.doTunnelPorts(ctx, ws, b.profile(contextURL))
 		if ws.ctx.Err() != nil {
 			return
 		}
@@ -759,7 +830,36 @@ func (b *Bastion) tunnelPorts(ws *Workspace) {
 	}
 }
 
-func (b *Bastion) doTunnelPorts(ctx context.Context, ws *Workspace) error {
+// contextURL returns the context URL of the workspace, which selects its port-forward profile
+func (b *Bastion) contextURL(ctx context.Context, ws *Workspace) string {
+	if b.ProfilesPath == "" {
+		return ""
+	}
+	info, err := b.Client.GetWorkspace(ctx, ws.WorkspaceID)
+	if err != nil {
+		logrus.WithError(err).WithField("workspace", ws.WorkspaceID).Warn("cannot get workspace context URL, port-forward profiles do not apply")
+		return ""
+	}
+	if info.Workspace == nil {
+		return ""
+	}
+	return info.Workspace.ContextURL
+}
+
+// profile returns the port-forward profile matching contextURL, or nil
+func (b *Bastion) profile(contextURL string) *Profile {
+	if b.ProfilesPath == "" {
+		return nil
+	}
+	profiles, err := LoadProfiles(b.ProfilesPath)
+	if err != nil {
+		logrus.WithError(err).WithField("path", b.ProfilesPath).Warn("cannot load port-forward profiles")
+		return nil
+	}
+	return profiles.Match(contextURL)
+}
+
+func (b *Bastion) doTunnelPorts(ctx context.Context, ws *Workspace, profile *Profile) error {
 	statusService := supervisor.NewStatusServiceClient(ws.supervisorClient)
 	status, err := statusService.PortsStatus(ctx, &supervisor.PortsStatusRequest{
 		Observe: true,
@@ -767,27 +867,32 @@ func (b *Bastion) doTunnelPorts(ctx context.Context, ws *Workspace) error {
 	if err != nil {
 		return err
 	}
-	defer b.notify(ws)
-	defer func() {
-		ws.tunnelMu.Lock()
-		defer ws.tunnelMu.Unlock()
-		for port, t := range ws.tunnelListeners {
-			delete(ws.tunnelListeners, port)
-			t.Cancel()
-		}
-	}()
+	portService := supervisor.NewPortServiceClient(ws.supervisorClient)
+	var profileName string
+	if profile != nil {
+		profileName = profile.Name
+		logrus.WithField("workspace", ws.WorkspaceID).WithField("profile", profileName).Info("applying port-forward profile")
+	}
 	for {
 		resp, err := status.Recv()
 		if err != nil {
 			return err
 		}
 		ws.tunnelMu.Lock()
+		var unregistered []*PortRule
 		currentTunneled := make(map[uint32]struct{})
 		for _, port := range resp.Ports {
 			visibility := supervisor.TunnelVisiblity_none
 			if port.Tunneled != nil {
 				visibility = port.Tunneled.Visibility
 			}
+			rule := profile.rule(port.LocalPort)
+			if profile.excludes(port.LocalPort) {
+				visibility = supervisor.TunnelVisiblity_none
+			} else if rule != nil && port.Served && port.Tunneled == nil {
+				// supervisor only accepts tunnels for registered ports - once registered, the port is tunneled with the next update
+				unregistered = append(unregistered, rule)
+			}
 			listener, alreadyTunneled := ws.tunnelListeners[port.LocalPort]
 			if alreadyTunneled && listener.Visibility != visibility {
 				listener.Cancel()
@@ -801,16 +906,27 @@ func (b *Bastion) doTunnelPorts(ctx context.Context, ws *Workspace) error {
 			if alreadyTunneled {
 				continue
 			}
-			_, alreadyTunneled = port.Tunneled.Clients[b.id]
-			if alreadyTunneled {
-				continue
+			var targetPort uint32
+			if port.Tunneled != nil {
+				if _, alreadyTunneled = port.Tunneled.Clients[b.id]; alreadyTunneled {
+					continue
+				}
+				targetPort = port.Tunneled.TargetPort
+			}
+			if rule != nil {
+				targetPort = rule.localPort()
+			}
+			targetHost := tunnelHost(visibility)
+			if addr := profile.bindAddress(rule); addr != "" {
+				targetHost = addr
 			}
 
-			logprefix := "tunnel[" + supervisor.TunnelVisiblity_name[int32(port.Tunneled.Visibility)] + ":" + strconv.Itoa(int(port.LocalPort)) + "]"
-			listener, err := b.establishTunnel(ws.ctx, ws, logprefix, int(port.LocalPort), int(port.Tunneled.TargetPort), port.Tunneled.Visibility)
+			logprefix := "tunnel[" + supervisor.TunnelVisiblity_name[int32(visibility)] + ":" + strconv.Itoa(int(port.LocalPort)) + "]"
+			listener, err := b.establishTunnelOn(ws.ctx, ws, logprefix, int(port.LocalPort), targetHost, int(targetPort), visibility)
 			if err != nil {
 				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("port", port.LocalPort).Error("cannot establish port tunnel")
 			} else {
+				listener.Profile = profileName
 				ws.tunnelListeners[port.LocalPort] = listener
 			}
 		}
@@ -823,6 +939,17 @@ func (b *Bastion) doTunnelPorts(ctx context.Context, ws *Workspace) error {
 		}
 		ws.tunnelMu.Unlock()
 		b.notify(ws)
+
+		for _, rule := range unregistered {
+			_, err := portService.Tunnel(ctx, &supervisor.TunnelPortRequest{
+				Port:       rule.Port,
+				TargetPort: rule.localPort(),
+				Visibility: supervisor.TunnelVisiblity_host,
+			})
+			if err != nil {
+				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("port", rule.Port).Warn("cannot register port tunnel")
+			}
+		}
 	}
 }
 
@@ -0,0 +1,174 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package bastion
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"io/ioutil"
+	"net"
+	"path"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Profiles configure how ports of workspaces are forwarded locally.
+// The first profile matching a workspace applies.
+type Profiles struct {
+	Profiles []*Profile `json:"profiles"`
+}
+
+// Profile maps workspaces to port-forward rules
+type Profile struct {
+	Name string `json:"name"`
+	// Repositories are glob patterns matched against the context URL of a workspace without its scheme,
+	// e.g. "github.com/gitpod-io/*". A pattern matches if it matches the context URL or one of its parent paths.
+	Repositories []string `json:"repositories"`
+	// BindAddress is the local address tunnels listen on, unless a rule overrides it
+	BindAddress string `json:"bindAddress,omitempty"`
+	// Ports are tunneled and forwarded whenever they are served in the workspace
+	Ports []*PortRule `json:"ports,omitempty"`
+	// Exclude lists workspace ports which are never forwarded
+	Exclude []uint32 `json:"exclude,omitempty"`
+}
+
+// PortRule forwards a workspace port to a fixed local port
+type PortRule struct {
+	Port uint32 `json:"port"`
+	// LocalPort defaults to Port
+	LocalPort   uint32 `json:"localPort,omitempty"`
+	BindAddress string `json:"bindAddress,omitempty"`
+}
+
+// LoadProfiles reads the profiles from fn. A missing file yields no profiles.
+func LoadProfiles(fn string) (*Profiles, error) {
+	fc, err := ioutil.ReadFile(fn)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Profiles{}, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read port-forward profiles: %w", err)
+	}
+
+	var res Profiles
+	err = json.Unmarshal(fc, &res)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal port-forward profiles: %w", err)
+	}
+	err = res.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Validate returns an error if the profiles are invalid
+func (p *Profiles) Validate() error {
+	for _, profile := range p.Profiles {
+		if profile.Name == "" {
+			return xerrors.Errorf("port-forward profile has no name")
+		}
+		for _, r := range profile.Repositories {
+			if _, err := path.Match(r, ""); err != nil {
+				return xerrors.Errorf("profile %s: invalid repository pattern %s: %w", profile.Name, r, err)
+			}
+		}
+		if err := validateBindAddress(profile.BindAddress); err != nil {
+			return xerrors.Errorf("profile %s: %w", profile.Name, err)
+		}
+
+		localPorts := make(map[uint32]uint32)
+		for _, rule := range profile.Ports {
+			if rule.Port == 0 {
+				return xerrors.Errorf("profile %s: port rule has no port", profile.Name)
+			}
+			if err := validateBindAddress(rule.BindAddress); err != nil {
+				return xerrors.Errorf("profile %s: port %d: %w", profile.Name, rule.Port, err)
+			}
+			local := rule.localPort()
+			if other, exists := localPorts[local]; exists {
+				return xerrors.Errorf("profile %s: ports %d and %d are both forwarded to local port %d", profile.Name, other, rule.Port, local)
+			}
+			localPorts[local] = rule.Port
+		}
+	}
+	return nil
+}
+
+func validateBindAddress(addr string) error {
+	if addr != "" && net.ParseIP(addr) == nil {
+		return xerrors.Errorf("invalid bind address %s", addr)
+	}
+	return nil
+}
+
+// Match returns the first profile matching the workspace context URL, or nil
+func (p *Profiles) Match(contextURL string) *Profile {
+	if p == nil || contextURL == "" {
+		return nil
+	}
+
+	repo := contextURL
+	if i := strings.Index(repo, "://"); i >= 0 {
+		repo = repo[i+len("://"):]
+	}
+	repo = strings.Trim(repo, "/")
+	segments := strings.Split(repo, "/")
+
+	for _, profile := range p.Profiles {
+		for _, pattern := range profile.Repositories {
+			for i := range segments {
+				if ok, _ := path.Match(pattern, strings.Join(segments[:i+1], "/")); ok {
+					return profile
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Profile) excludes(port uint32) bool {
+	if p == nil {
+		return false
+	}
+	for _, e := range p.Exclude {
+		if e == port {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Profile) rule(port uint32) *PortRule {
+	if p == nil {
+		return nil
+	}
+	for _, r := range p.Ports {
+		if r.Port == port {
+			return r
+		}
+	}
+	return nil
+}
+
+// bindAddress returns the local address of the tunnel for rule, or "" for the default
+func (p *Profile) bindAddress(rule *PortRule) string {
+	if rule != nil && rule.BindAddress != "" {
+		return rule.BindAddress
+	}
+	if p == nil {
+		return ""
+	}
+	return p.BindAddress
+}
+
+func (r *PortRule) localPort() uint32 {
+	if r.LocalPort != 0 {
+		return r.LocalPort
+	}
+	return r.Port
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package bastion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfilesMatch(t *testing.T) {
+	profiles := &Profiles{Profiles: []*Profile{
+		{Name: "gitpod", Repositories: []string{"github.com/gitpod-io/*"}},
+		{Name: "gitlab", Repositories: []string{"gitlab.com/acme/website"}},
+	}}
+
+	tests := []struct {
+		Desc        string
+		ContextURL  string
+		Expectation string
+	}{
+		{"org glob", "https://github.com/gitpod-io/gitpod", "gitpod"},
+		{"parent path", "https://github.com/gitpod-io/gitpod/pull/123", "gitpod"},
+		{"exact repository", "https://gitlab.com/acme/website/-/tree/main", "gitlab"},
+		{"other org", "https://github.com/acme/gitpod", ""},
+		{"no context URL", "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			var act string
+			if p := profiles.Match(test.ContextURL); p != nil {
+				act = p.Name
+			}
+			if act != test.Expectation {
+				t.Errorf("expected profile %q, got %q", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestLoadProfiles(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := LoadProfiles(filepath.Join(dir, "missing.json"))
+	if err != nil {
+		t.Fatalf("missing profiles file should not fail: %v", err)
+	}
+	if len(p.Profiles) != 0 {
+		t.Errorf("expected no profiles, got %d", len(p.Profiles))
+	}
+
+	tests := []struct {
+		Desc    string
+		Content string
+		Valid   bool
+	}{
+		{"valid", `{"profiles":[{"name":"a","repositories":["github.com/*"],"bindAddress":"127.0.0.1","ports":[{"port":3000,"localPort":13000},{"port":5432}],"exclude":[9229]}]}`, true},
+		{"no name", `{"profiles":[{"repositories":["github.com/*"]}]}`, false},
+		{"invalid bind address", `{"profiles":[{"name":"a","bindAddress":"localhost"}]}`, false},
+		{"conflicting local ports", `{"profiles":[{"name":"a","ports":[{"port":3000},{"port":3001,"localPort":3000}]}]}`, false},
+		{"invalid json", `{`, false},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			fn := filepath.Join(dir, "profiles.json")
+			err := os.WriteFile(fn, []byte(test.Content), 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = LoadProfiles(fn)
+			if test.Valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !test.Valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package bastion
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/xerrors"
+)
+
+// ProxyListener is a SOCKS5 and HTTP proxy which resolves and dials from within a workspace
+type ProxyListener struct {
+	LocalAddr string
+	Ctx       context.Context
+	Cancel    func()
+}
+
+// dialFunc connects to host:port from within the workspace
+type dialFunc func(ctx context.Context, host string, port uint32) (io.ReadWriteCloser, error)
+
+// directTCPIPRequest is the payload of a direct-tcpip channel, see RFC 4254 section 7.2
+type directTCPIPRequest struct {
+	Host       string
+	Port       uint32
+	OriginHost string
+	OriginPort uint32
+}
+
+func (b *Bastion) establishProxy(ctx context.Context, ws *Workspace) (*ProxyListener, error) {
+	if !ws.tunnelClientConnected {
+		return nil, xerrors.Errorf("tunnel client is not connected")
+	}
+
+	netListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	logrus.WithField("workspace", ws.WorkspaceID).Info("proxy: listening on " + netListener.Addr().String() + "...")
+	listenerCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		<-listenerCtx.Done()
+		netListener.Close()
+		logrus.WithField("workspace", ws.WorkspaceID).Info("proxy: closed")
+	}()
+
+	dial := func(ctx context.Context, host string, port uint32) (io.ReadWriteCloser, error) {
+		return b.dialWorkspace(ctx, ws, host, port)
+	}
+	go func() {
+		for {
+			conn, err := netListener.Accept()
+			if listenerCtx.Err() != nil {
+				return
+			}
+			if err != nil {
+				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).Warn("proxy: failed to accept connection")
+				continue
+			}
+			go func() {
+				err := serveProxyConn(listenerCtx, conn, dial)
+				if err != nil {
+					logrus.WithError(err).WithField("workspace", ws.WorkspaceID).Debug("proxy: connection failed")
+				}
+			}()
+		}
+	}()
+	return &ProxyListener{
+		LocalAddr: netListener.Addr().String(),
+		Ctx:       listenerCtx,
+		Cancel:    cancel,
+	}, nil
+}
+
+// dialWorkspace opens a direct-tcpip channel through the tunnel client, which supervisor connects to host:port
+func (b *Bastion) dialWorkspace(ctx context.Context, ws *Workspace, host string, port uint32) (io.ReadWriteCloser, error) {
+	clientCh := make(chan *TunnelClient, 1)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case ws.tunnelClient <- clientCh:
+	}
+	client := <-clientCh
+
+	sshChan, reqs, err := client.Conn.OpenChannel("direct-tcpip", ssh.Marshal(&directTCPIPRequest{
+		Host:       host,
+		Port:       port,
+		OriginHost: "127.0.0.1",
+	}))
+	if err != nil {
+		return nil, err
+	}
+	go ssh.DiscardRequests(reqs)
+	return sshChan, nil
+}
+
+const socks5Version = 0x05
+
+// serveProxyConn serves a single SOCKS5 or HTTP proxy connection, depending on what the client speaks
+func serveProxyConn(ctx context.Context, conn net.Conn, dial dialFunc) error {
+	defer conn.Close()
+
+	br := bufio.NewReader(conn)
+	version, err := br.Peek(1)
+	if err != nil {
+		return err
+	}
+
+	var remote io.ReadWriteCloser
+	if version[0] == socks5Version {
+		remote, err = handshakeSOCKS5(ctx, br, conn, dial)
+	} else {
+		remote, err = handshakeHTTP(ctx, br, conn, dial)
+	}
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		_, _ = io.Copy(remote, br)
+		cancel()
+	}()
+	go func() {
+		_, _ = io.Copy(conn, remote)
+		cancel()
+	}()
+	<-ctx.Done()
+	return nil
+}
+
+const (
+	socks5MethodNoAuth       = 0x00
+	socks5MethodNoAcceptable = 0xff
+
+	socks5CmdConnect = 0x01
+
+	socks5AddrIPv4   = 0x01
+	socks5AddrDomain = 0x03
+	socks5AddrIPv6   = 0x04
+
+	socks5ReplySucceeded           = 0x00
+	socks5ReplyGeneralFailure      = 0x01
+	socks5ReplyConnectionRefused   = 0x05
+	socks5ReplyCmdNotSupported     = 0x07
+	socks5ReplyAddrTypeUnsupported = 0x08
+)
+
+// handshakeSOCKS5 negotiates a SOCKS5 CONNECT without authentication, see RFC 1928
+func handshakeSOCKS5(ctx context.Context, r *bufio.Reader, w io.Writer, dial dialFunc) (io.ReadWriteCloser, error) {
+	var greeting [2]byte
+	if _, err := io.ReadFull(r, greeting[:]); err != nil {
+		return nil, err
+	}
+	methods := make([]byte, greeting[1])
+	if _, err := io.ReadFull(r, methods); err != nil {
+		return nil, err
+	}
+	var noAuth bool
+	for _, m := range methods {
+		if m == socks5MethodNoAuth {
+			noAuth = true
+			break
+		}
+	}
+	if !noAuth {
+		_, _ = w.Write([]byte{socks5Version, socks5MethodNoAcceptable})
+		return nil, xerrors.Errorf("socks5: client does not support unauthenticated connections")
+	}
+	if _, err := w.Write([]byte{socks5Version, socks5MethodNoAuth}); err != nil {
+		return nil, err
+	}
+
+	var req [4]byte
+	if _, err := io.ReadFull(r, req[:]); err != nil {
+		return nil, err
+	}
+	if req[1] != socks5CmdConnect {
+		writeSOCKS5Reply(w, socks5ReplyCmdNotSupported)
+		return nil, xerrors.Errorf("socks5: unsupported command %d", req[1])
+	}
+
+	var host string
+	switch req[3] {
+	case socks5AddrIPv4, socks5AddrIPv6:
+		ip := make(net.IP, net.IPv4len)
+		if req[3] == socks5AddrIPv6 {
+			ip = make(net.IP, net.IPv6len)
+		}
+		if _, err := io.ReadFull(r, ip); err != nil {
+			return nil, err
+		}
+		host = ip.String()
+	case socks5AddrDomain:
+		l, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		domain := make([]byte, l)
+		if _, err := io.ReadFull(r, domain); err != nil {
+			return nil, err
+		}
+		host = string(domain)
+	default:
+		writeSOCKS5Reply(w, socks5ReplyAddrTypeUnsupported)
+		return nil, xerrors.Errorf("socks5: unsupported address type %d", req[3])
+	}
+	var port [2]byte
+	if _, err := io.ReadFull(r, port[:]); err != nil {
+		return nil, err
+	}
+
+	remote, err := dial(ctx, host, uint32(binary.BigEndian.Uint16(port[:])))
+	if err != nil {
+		reply := byte(socks5ReplyGeneralFailure)
+		var openErr *ssh.OpenChannelError
+		if errors.As(err, &openErr) && openErr.Reason == ssh.ConnectionFailed {
+			reply = socks5ReplyConnectionRefused
+		}
+		writeSOCKS5Reply(w, reply)
+		return nil, err
+	}
+	writeSOCKS5Reply(w, socks5ReplySucceeded)
+	return remote, nil
+}
+
+func writeSOCKS5Reply(w io.Writer, reply byte) {
+	// we don't know the address supervisor bound to, hence we report 0.0.0.0:0
+	_, _ = w.Write([]byte{socks5Version, reply, 0x00, socks5AddrIPv4, 0, 0, 0, 0, 0, 0})
+}
+
+// handshakeHTTP handles HTTP CONNECT requests, and forwards plain HTTP requests with an absolute URL
+func handshakeHTTP(ctx context.Context, r *bufio.Reader, w io.Writer, dial dialFunc) (io.ReadWriteCloser, error) {
+	req, err := http.ReadRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	hostport := req.Host
+	if req.Method != http.MethodConnect {
+		if req.URL.Host == "" {
+			writeHTTPError(w, http.StatusBadRequest)
+			return nil, xerrors.Errorf("http proxy: request without absolute URL")
+		}
+		hostport = req.URL.Host
+	}
+	host, port, err := splitHostPort(hostport, req.URL.Scheme)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest)
+		return nil, err
+	}
+
+	remote, err := dial(ctx, host, port)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadGateway)
+		return nil, err
+	}
+
+	if req.Method == http.MethodConnect {
+		_, err = io.WriteString(w, "HTTP/1.1 200 Connection Established\r\n\r\n")
+	} else {
+		req.Header.Del("Proxy-Connection")
+		req.Header.Del("Proxy-Authorization")
+		// we dial a new connection for every request, hence we cannot keep the connection alive
+		req.Close = true
+		err = req.Write(remote)
+	}
+	if err != nil {
+		remote.Close()
+		return nil, err
+	}
+	return remote, nil
+}
+
+func splitHostPort(hostport, scheme string) (host string, port uint32, err error) {
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		if scheme != "http" {
+			return "", 0, err
+		}
+		return hostport, 80, nil
+	}
+	p, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return "", 0, xerrors.Errorf("invalid port %s: %w", portStr, err)
+	}
+	return host, uint32(p), nil
+}
+
+func writeHTTPError(w io.Writer, code int) {
+	_, _ = fmt.Fprintf(w, "HTTP/1.1 %d %s\r\nConnection: close\r\n\r\n", code, http.StatusText(code))
+}
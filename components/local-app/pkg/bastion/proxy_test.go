@@ -0,0 +1,192 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package bastion
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"golang.org/x/xerrors"
+)
+
+// echoDialer records the address it was asked to dial and echoes everything back
+type echoDialer struct {
+	Addr string
+	Fail bool
+}
+
+func (d *echoDialer) dial(ctx context.Context, host string, port uint32) (io.ReadWriteCloser, error) {
+	d.Addr = net.JoinHostPort(host, strconv.FormatUint(uint64(port), 10))
+	if d.Fail {
+		return nil, xerrors.Errorf("cannot dial %s", d.Addr)
+	}
+	local, remote := net.Pipe()
+	go func() {
+		defer remote.Close()
+		_, _ = io.Copy(remote, remote)
+	}()
+	return local, nil
+}
+
+func startProxyConn(t *testing.T, d *echoDialer) net.Conn {
+	client, server := net.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		client.Close()
+	})
+	go func() {
+		_ = serveProxyConn(ctx, server, d.dial)
+	}()
+	return client
+}
+
+func expectEcho(t *testing.T, conn io.ReadWriter) {
+	t.Helper()
+	msg := []byte("hello workspace")
+	go func() {
+		_, _ = conn.Write(msg)
+	}()
+	buf := make([]byte, len(msg))
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		t.Fatalf("cannot read echo: %v", err)
+	}
+	if !bytes.Equal(buf, msg) {
+		t.Errorf("expected echo %q, got %q", msg, buf)
+	}
+}
+
+func TestProxySOCKS5(t *testing.T) {
+	tests := []struct {
+		Desc    string
+		Request []byte
+		Addr    string
+	}{
+		{"domain", append(append([]byte{0x05, 0x01, 0x00, 0x03, 9}, "localhost"...), 0x1f, 0x90), "localhost:8080"},
+		{"ipv4", []byte{0x05, 0x01, 0x00, 0x01, 10, 0, 0, 1, 0x00, 0x50}, "10.0.0.1:80"},
+		{"ipv6", append(append([]byte{0x05, 0x01, 0x00, 0x04}, net.IPv6loopback...), 0x01, 0xbb), "[::1]:443"},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			d := &echoDialer{}
+			conn := startProxyConn(t, d)
+
+			go func() {
+				_, _ = conn.Write(append([]byte{0x05, 0x01, 0x00}, test.Request...))
+			}()
+			var reply [12]byte
+			if _, err := io.ReadFull(conn, reply[:]); err != nil {
+				t.Fatalf("cannot read reply: %v", err)
+			}
+			if !bytes.Equal(reply[:2], []byte{0x05, 0x00}) {
+				t.Fatalf("unexpected method selection %v", reply[:2])
+			}
+			if reply[3] != socks5ReplySucceeded {
+				t.Fatalf("expected success, got reply %d", reply[3])
+			}
+			if d.Addr != test.Addr {
+				t.Errorf("expected to dial %s, got %s", test.Addr, d.Addr)
+			}
+			expectEcho(t, conn)
+		})
+	}
+}
+
+func TestProxySOCKS5Failures(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Fail        bool
+		Request     []byte
+		Expectation []byte
+	}{
+		{"requires auth", false, []byte{0x05, 0x01, 0x02}, []byte{0x05, socks5MethodNoAcceptable}},
+		{"bind", false, []byte{0x05, 0x01, 0x00, 0x05, 0x02, 0x00, 0x01, 127, 0, 0, 1, 0, 80}, []byte{0x05, 0x00, 0x05, socks5ReplyCmdNotSupported}},
+		{"dial fails", true, []byte{0x05, 0x01, 0x00, 0x05, 0x01, 0x00, 0x01, 127, 0, 0, 1, 0, 80}, []byte{0x05, 0x00, 0x05, socks5ReplyGeneralFailure}},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			conn := startProxyConn(t, &echoDialer{Fail: test.Fail})
+
+			go func() {
+				_, _ = conn.Write(test.Request)
+			}()
+			reply := make([]byte, len(test.Expectation))
+			if _, err := io.ReadFull(conn, reply); err != nil {
+				t.Fatalf("cannot read reply: %v", err)
+			}
+			if !bytes.Equal(reply, test.Expectation) {
+				t.Errorf("expected reply %v, got %v", test.Expectation, reply)
+			}
+		})
+	}
+}
+
+func TestProxyHTTPConnect(t *testing.T) {
+	d := &echoDialer{}
+	conn := startProxyConn(t, d)
+
+	go func() {
+		_, _ = io.WriteString(conn, "CONNECT db.internal:5432 HTTP/1.1\r\nHost: db.internal:5432\r\n\r\n")
+	}()
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatalf("cannot read response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if d.Addr != "db.internal:5432" {
+		t.Errorf("expected to dial db.internal:5432, got %s", d.Addr)
+	}
+	expectEcho(t, struct {
+		io.Reader
+		io.Writer
+	}{br, conn})
+}
+
+func TestProxyHTTPForward(t *testing.T) {
+	d := &echoDialer{}
+	conn := startProxyConn(t, d)
+
+	go func() {
+		_, _ = io.WriteString(conn, "GET http://api.internal/status HTTP/1.1\r\nHost: api.internal\r\nProxy-Connection: keep-alive\r\n\r\n")
+	}()
+	// the echo dialer sends the forwarded request back to us
+	req, err := http.ReadRequest(bufio.NewReader(conn))
+	if err != nil {
+		t.Fatalf("cannot read forwarded request: %v", err)
+	}
+	if d.Addr != "api.internal:80" {
+		t.Errorf("expected to dial api.internal:80, got %s", d.Addr)
+	}
+	if req.RequestURI != "/status" {
+		t.Errorf("expected request URI /status, got %s", req.RequestURI)
+	}
+	if req.Header.Get("Proxy-Connection") != "" {
+		t.Errorf("expected Proxy-Connection header to be removed")
+	}
+}
+
+func TestProxyHTTPDialFails(t *testing.T) {
+	conn := startProxyConn(t, &echoDialer{Fail: true})
+
+	go func() {
+		_, _ = io.WriteString(conn, "CONNECT db.internal:5432 HTTP/1.1\r\nHost: db.internal:5432\r\n\r\n")
+	}()
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		t.Fatalf("cannot read response: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected status 502, got %d", resp.StatusCode)
+	}
+}
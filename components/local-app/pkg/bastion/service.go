@@ -78,3 +78,16 @@ func (s *LocalAppService) ResolveSSHConnection(ctx context.Context, req *api.Res
 		ConfigFile: s.s.Path,
 	}, nil
 }
+
+func (s *LocalAppService) ResolveProxy(ctx context.Context, req *api.ResolveProxyRequest) (*api.ResolveProxyResponse, error) {
+	ws := s.b.Update(req.WorkspaceId)
+	if ws == nil || ws.InstanceID != req.InstanceId {
+		return nil, status.Error(codes.NotFound, "workspace not found")
+	}
+	if ws.proxyListener == nil {
+		return nil, status.Error(codes.NotFound, "workspace proxy not enabled")
+	}
+	return &api.ResolveProxyResponse{
+		Address: ws.proxyListener.LocalAddr,
+	}, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/allegro/bigcache"
 	"github.com/eko/gocache/cache"
 	"github.com/eko/gocache/store"
+	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/go-redis/redis/v7"
 	"golang.org/x/xerrors"
 )
@@ -54,6 +55,14 @@ func (o *OpenVSXProxy) SetupCache() error {
 
 	o.cacheManager = cache.New(cacheStore)
 
+	if o.Config.DiskCacheEnabled() {
+		diskCache, err := NewDiskCache(o.Config.DiskCacheDir, o.Config.DiskCacheMaxSize)
+		if err != nil {
+			return err
+		}
+		o.diskCache = diskCache
+	}
+
 	return nil
 }
 
@@ -88,3 +97,15 @@ func (o *OpenVSXProxy) ReadCache(key string) (obj *CacheObject, ok bool, err err
 	ok = true
 	return
 }
+
+// ReadBackupCache reads from the cache and falls back to the disk cache
+func (o *OpenVSXProxy) ReadBackupCache(key string) (obj *CacheObject, ok bool, err error) {
+	obj, ok, err = o.ReadCache(key)
+	if ok || o.diskCache == nil {
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField(LOG_FIELD_REQUEST, key).Error("cannot read from cache - trying disk cache")
+	}
+	return o.diskCache.ReadCache(key)
+}
@@ -25,6 +25,15 @@ type Config struct {
 	RedisAddr            string        `json:"redis_addr"`
 	PrometheusAddr       string        `json:"prometheusAddr"`
 	AllowCacheDomain     []string      `json:"allow_cache_domain"`
+
+	// DiskCacheDir enables the persistent cache of extension packages and metadata in this directory
+	DiskCacheDir string `json:"disk_cache_dir,omitempty"`
+	// DiskCacheMaxSize is the maximum size of the disk cache in bytes, the least recently used entries are removed first
+	DiskCacheMaxSize int64 `json:"disk_cache_max_size,omitempty"`
+	// PreseedExtensions are downloaded to the disk cache on startup, in the form of publisher.name or publisher.name@version
+	PreseedExtensions []string `json:"preseed_extensions,omitempty"`
+	// AirGapped serves from the disk cache only and never contacts the upstream
+	AirGapped bool `json:"air_gapped,omitempty"`
 }
 
 // Validate validates the configuration to catch issues during startup and not at runtime
@@ -33,12 +42,24 @@ func (c *Config) Validate() error {
 		return xerrors.Errorf("config is missing")
 	}
 
-	return validation.ValidateStruct(c,
+	err := validation.ValidateStruct(c,
 		validation.Field(&c.CacheDurationRegular, validation.Required),
 		validation.Field(&c.CacheDurationBackup, validation.Required),
 		validation.Field(&c.URLUpstream, validation.Required, is.URL),
 		validation.Field(&c.URLLocal, validation.Required, is.URL),
+		validation.Field(&c.DiskCacheMaxSize, validation.Min(int64(0))),
+		validation.Field(&c.PreseedExtensions, validation.Each(validation.Match(extensionIDPattern))),
 	)
+	if err != nil {
+		return err
+	}
+	if c.AirGapped && c.DiskCacheDir == "" {
+		return xerrors.Errorf("air-gapped mode requires a disk cache")
+	}
+	if len(c.PreseedExtensions) > 0 && c.DiskCacheDir == "" {
+		return xerrors.Errorf("pre-seeding extensions requires a disk cache")
+	}
+	return nil
 }
 
 // ReadConfig loads and validates the configuration
@@ -70,6 +91,10 @@ func (cfg *Config) ToJson() []byte {
 	return b
 }
 
+func (cfg *Config) DiskCacheEnabled() bool {
+	return len(cfg.DiskCacheDir) > 0
+}
+
 func (cfg *Config) RedisEnabled() bool {
 	return len(cfg.RedisAddr) > 0
 }
@@ -0,0 +1,353 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package pkg
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// DefaultDiskCacheMaxSize is used if no disk cache size is configured
+	DefaultDiskCacheMaxSize int64 = 10 * 1024 * 1024 * 1024
+
+	diskCacheMetaExt = ".meta"
+	diskCacheDataExt = ".data"
+	diskCacheTmpExt  = ".tmp"
+)
+
+var errDiskCacheEntryTooLarge = xerrors.Errorf("entry is larger than the disk cache")
+
+// DiskCache stores responses in a directory. The total size is limited, the least recently used
+// entries are removed first. Every entry consists of a metadata file and a data file named after the hash of the key.
+type DiskCache struct {
+	dir     string
+	maxSize int64
+
+	mu      sync.Mutex
+	entries map[string]*diskCacheEntry
+	size    int64
+}
+
+type diskCacheEntry struct {
+	name       string
+	key        string
+	size       int64
+	lastAccess time.Time
+}
+
+type diskCacheMeta struct {
+	Key        string
+	Header     http.Header
+	StatusCode int
+	StoredAt   time.Time
+}
+
+// DiskCacheObject is an entry of the disk cache, Body must be closed by the caller
+type DiskCacheObject struct {
+	Header     http.Header
+	StatusCode int
+	StoredAt   time.Time
+	Size       int64
+	Body       *os.File
+}
+
+// NewDiskCache opens the disk cache in dir and removes the least recently used entries if it is larger than maxSize
+func NewDiskCache(dir string, maxSize int64) (*DiskCache, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultDiskCacheMaxSize
+	}
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create disk cache directory: %w", err)
+	}
+	c := &DiskCache{
+		dir:     dir,
+		maxSize: maxSize,
+		entries: make(map[string]*diskCacheEntry),
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read disk cache directory: %w", err)
+	}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		fn := filepath.Join(dir, f.Name())
+		switch filepath.Ext(f.Name()) {
+		case diskCacheTmpExt:
+			// left over from an interrupted write
+			_ = os.Remove(fn)
+		case diskCacheMetaExt:
+			name := strings.TrimSuffix(f.Name(), diskCacheMetaExt)
+			metaInfo, err := f.Info()
+			if err != nil {
+				continue
+			}
+			dataInfo, err := os.Stat(c.path(name, diskCacheDataExt))
+			if err != nil {
+				// incomplete entry
+				_ = os.Remove(fn)
+				continue
+			}
+			var meta diskCacheMeta
+			b, err := os.ReadFile(fn)
+			if err == nil {
+				err = json.Unmarshal(b, &meta)
+			}
+			if err != nil {
+				log.WithError(err).WithField("entry", name).Warn("removing disk cache entry with invalid metadata")
+				_ = os.Remove(fn)
+				_ = os.Remove(c.path(name, diskCacheDataExt))
+				continue
+			}
+			c.entries[name] = &diskCacheEntry{
+				name:       name,
+				key:        meta.Key,
+				size:       metaInfo.Size() + dataInfo.Size(),
+				lastAccess: dataInfo.ModTime(),
+			}
+			c.size += metaInfo.Size() + dataInfo.Size()
+		}
+	}
+
+	c.mu.Lock()
+	c.evict()
+	c.mu.Unlock()
+
+	log.WithField("dir", dir).WithField("entries", len(c.entries)).WithField("size", c.size).Info("opened disk cache")
+	return c, nil
+}
+
+func (c *DiskCache) path(name, ext string) string {
+	return filepath.Join(c.dir, name+ext)
+}
+
+func (c *DiskCache) name(key string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(key)))
+}
+
+// Store adds an entry to the cache. The body is always read until the end, even if it cannot be stored,
+// so that it can be teed to a client.
+func (c *DiskCache) Store(key string, header http.Header, statusCode int, body io.Reader) error {
+	name := c.name(key)
+	tmp, err := os.CreateTemp(c.dir, name+"-*"+diskCacheTmpExt)
+	if err != nil {
+		_, _ = io.Copy(io.Discard, body)
+		return xerrors.Errorf("cannot create disk cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := &limitedWriter{w: tmp, limit: c.maxSize}
+	_, err = io.Copy(w, body)
+	if cerr := tmp.Close(); err == nil && w.err == nil {
+		w.err = cerr
+	}
+	if err != nil {
+		return xerrors.Errorf("cannot read body: %w", err)
+	}
+	if w.err != nil {
+		return w.err
+	}
+
+	meta, err := json.Marshal(diskCacheMeta{
+		Key:        key,
+		Header:     header,
+		StatusCode: statusCode,
+		StoredAt:   time.Now(),
+	})
+	if err != nil {
+		return xerrors.Errorf("cannot marshal disk cache metadata: %w", err)
+	}
+	size := w.n + int64(len(meta))
+	if size > c.maxSize {
+		return errDiskCacheEntryTooLarge
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// the data file is written before the metadata file, so that only complete entries are found on startup
+	err = os.Rename(tmp.Name(), c.path(name, diskCacheDataExt))
+	if err != nil {
+		return xerrors.Errorf("cannot store disk cache file: %w", err)
+	}
+	err = os.WriteFile(c.path(name, diskCacheMetaExt), meta, 0644)
+	if err != nil {
+		_ = os.Remove(c.path(name, diskCacheDataExt))
+		c.remove(name)
+		return xerrors.Errorf("cannot store disk cache metadata: %w", err)
+	}
+	if e, ok := c.entries[name]; ok {
+		c.size -= e.size
+	}
+	c.entries[name] = &diskCacheEntry{
+		name:       name,
+		key:        key,
+		size:       size,
+		lastAccess: time.Now(),
+	}
+	c.size += size
+	c.evict()
+	return nil
+}
+
+// Open returns the entry for key if it exists
+func (c *DiskCache) Open(key string) (obj *DiskCacheObject, ok bool, err error) {
+	name := c.name(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[name]
+	if !ok {
+		return nil, false, nil
+	}
+
+	b, err := os.ReadFile(c.path(name, diskCacheMetaExt))
+	if err != nil {
+		c.remove(name)
+		return nil, false, xerrors.Errorf("cannot read disk cache metadata: %w", err)
+	}
+	var meta diskCacheMeta
+	err = json.Unmarshal(b, &meta)
+	if err != nil {
+		c.remove(name)
+		return nil, false, xerrors.Errorf("cannot parse disk cache metadata: %w", err)
+	}
+	if meta.Key != key {
+		// hash collision
+		return nil, false, nil
+	}
+	f, err := os.Open(c.path(name, diskCacheDataExt))
+	if err != nil {
+		c.remove(name)
+		return nil, false, xerrors.Errorf("cannot open disk cache file: %w", err)
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, false, xerrors.Errorf("cannot stat disk cache file: %w", err)
+	}
+
+	// the modification time of the data file is the last access time on startup
+	now := time.Now()
+	e.lastAccess = now
+	_ = os.Chtimes(c.path(name, diskCacheDataExt), now, now)
+
+	return &DiskCacheObject{
+		Header:     meta.Header,
+		StatusCode: meta.StatusCode,
+		StoredAt:   meta.StoredAt,
+		Size:       stat.Size(),
+		Body:       f,
+	}, true, nil
+}
+
+// ReadCache returns the entry for key read into memory
+func (c *DiskCache) ReadCache(key string) (obj *CacheObject, ok bool, err error) {
+	dobj, ok, err := c.Open(key)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	defer dobj.Body.Close()
+
+	body, err := io.ReadAll(dobj.Body)
+	if err != nil {
+		return nil, false, xerrors.Errorf("cannot read disk cache file: %w", err)
+	}
+	return &CacheObject{
+		Header:     dobj.Header,
+		Body:       body,
+		StatusCode: dobj.StatusCode,
+	}, true, nil
+}
+
+// Keys returns the keys of all entries which start with prefix
+func (c *DiskCache) Keys(prefix string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var keys []string
+	for _, e := range c.entries {
+		if strings.HasPrefix(e.key, prefix) {
+			keys = append(keys, e.key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Size returns the current size of the disk cache in bytes
+func (c *DiskCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// evict removes the least recently used entries until the cache fits into its max size. c.mu must be held.
+func (c *DiskCache) evict() {
+	if c.size <= c.maxSize {
+		return
+	}
+	entries := make([]*diskCacheEntry, 0, len(c.entries))
+	for _, e := range c.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].lastAccess.Before(entries[j].lastAccess) })
+	for _, e := range entries {
+		if c.size <= c.maxSize {
+			return
+		}
+		log.WithField("entry", e.name).WithField("size", e.size).Debug("removing least recently used entry from disk cache")
+		c.remove(e.name)
+	}
+}
+
+// remove deletes an entry. c.mu must be held.
+func (c *DiskCache) remove(name string) {
+	_ = os.Remove(c.path(name, diskCacheMetaExt))
+	_ = os.Remove(c.path(name, diskCacheDataExt))
+	if e, ok := c.entries[name]; ok {
+		c.size -= e.size
+		delete(c.entries, name)
+	}
+}
+
+// limitedWriter stops writing after an error or when limit is exceeded, but keeps accepting writes
+type limitedWriter struct {
+	w     io.Writer
+	limit int64
+	n     int64
+	err   error
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return len(p), nil
+	}
+	if w.n+int64(len(p)) > w.limit {
+		w.err = errDiskCacheEntryTooLarge
+		return len(p), nil
+	}
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	if err != nil {
+		w.err = xerrors.Errorf("cannot write disk cache file: %w", err)
+	}
+	return len(p), nil
+}
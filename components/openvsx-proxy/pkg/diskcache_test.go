@@ -0,0 +1,271 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package pkg
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func createDiskCacheFrontend(t *testing.T, backendURL string, airGapped bool, dir string) (*httptest.Server, *OpenVSXProxy) {
+	u, _ := url.Parse(backendURL)
+	cfg := &Config{
+		URLUpstream:      backendURL,
+		AllowCacheDomain: []string{u.Host},
+		DiskCacheDir:     dir,
+		AirGapped:        airGapped,
+	}
+	openVSXProxy := &OpenVSXProxy{Config: cfg}
+	if err := openVSXProxy.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(openVSXProxy.defaultUpstreamURL)
+	proxy.ModifyResponse = openVSXProxy.ModifyResponse
+	handler := http.HandlerFunc(openVSXProxy.Handler(proxy))
+	frontend := httptest.NewServer(handler)
+	cfg.URLLocal = frontend.URL
+	return frontend, openVSXProxy
+}
+
+func get(t *testing.T, u string) (int, string) {
+	req, _ := http.NewRequest("GET", u, nil)
+	req.Close = true
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	return res.StatusCode, string(body)
+}
+
+func TestDiskCacheEviction(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := func(key string) {
+		if err := c.Store(key, nil, http.StatusOK, strings.NewReader(strings.Repeat("x", 200))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	has := func(c *DiskCache, key string) bool {
+		obj, ok, err := c.Open(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			obj.Body.Close()
+		}
+		return ok
+	}
+
+	store("a")
+	store("b")
+	store("c")
+	// a is used more recently than b
+	has(c, "a")
+	store("d")
+
+	for key, expected := range map[string]bool{"a": true, "b": false, "c": true, "d": true} {
+		if act := has(c, key); act != expected {
+			t.Errorf("entry %s is cached: %v, expected %v", key, act, expected)
+		}
+	}
+
+	err = c.Store("too large", nil, http.StatusOK, strings.NewReader(strings.Repeat("x", 2000)))
+	if err != errDiskCacheEntryTooLarge {
+		t.Errorf("unexpected error storing a too large entry: %v", err)
+	}
+
+	reopened, err := NewDiskCache(dir, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Size() != c.Size() {
+		t.Errorf("reopened disk cache has size %d, expected %d", reopened.Size(), c.Size())
+	}
+	if obj, ok, err := reopened.ReadCache("d"); !ok || err != nil || len(obj.Body) != 200 {
+		t.Errorf("entry not found after reopening the disk cache: %v", err)
+	}
+}
+
+func TestServeVSIXFromDiskCacheOnUpstreamError(t *testing.T) {
+	var (
+		downloads   int
+		upstreamURL string
+	)
+	content := []byte("vsix content")
+	backend := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/vscode/gallery/publishers/foo/vsextensions/bar/1.0.0/vspackage":
+			http.Redirect(rw, r, "/files/foo.bar-1.0.0.vsix", http.StatusFound)
+		case "/files/foo.bar-1.0.0.vsix":
+			downloads++
+			rw.Header().Set("Content-Type", "application/octet-stream")
+			rw.Header().Set("X-Unrelated", "cdn")
+			rw.Write(content)
+		case "/api/foo/bar/1.0.0":
+			fmt.Fprintf(rw, `{"namespace":"foo","name":"bar","version":"1.0.0","files":{"sha256":"%s/files/foo.bar-1.0.0.sha256"}}`, upstreamURL)
+		case "/files/foo.bar-1.0.0.sha256":
+			fmt.Fprintf(rw, "%x foo.bar-1.0.0.vsix", sha256.Sum256(content))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	upstreamURL = backend.URL
+
+	frontend, _ := createDiskCacheFrontend(t, backend.URL, false, t.TempDir())
+	defer frontend.Close()
+
+	status, body := get(t, frontend.URL+"/vscode/gallery/publishers/foo/vsextensions/bar/1.0.0/vspackage")
+	if status != http.StatusOK || body != "vsix content" {
+		t.Fatalf("got %d '%s'; expected the extension package", status, body)
+	}
+	if downloads != 1 {
+		t.Errorf("upstream got %d downloads; expected the redirect to be followed", downloads)
+	}
+	backend.Close()
+
+	// same package with a different URL
+	status, body = get(t, frontend.URL+"/api/foo/bar/1.0.0/file/foo.bar-1.0.0.vsix")
+	if status != http.StatusOK || body != "vsix content" {
+		t.Errorf("got %d '%s'; expected the extension package from the disk cache", status, body)
+	}
+	status, _ = get(t, frontend.URL+"/api/foo/bar/2.0.0/file/foo.bar-2.0.0.vsix")
+	if status != http.StatusBadGateway {
+		t.Errorf("got status %d for an uncached package; expected %d", status, http.StatusBadGateway)
+	}
+}
+
+func TestVSIXChecksumMismatch(t *testing.T) {
+	var upstreamURL string
+	backend := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/foo/bar/1.0.0/file/foo.bar-1.0.0.vsix":
+			rw.Write([]byte("tampered content"))
+		case "/api/foo/bar/1.0.0":
+			fmt.Fprintf(rw, `{"namespace":"foo","name":"bar","version":"1.0.0","files":{"sha256":"%s/sha256"}}`, upstreamURL)
+		case "/sha256":
+			fmt.Fprintf(rw, "%x", sha256.Sum256([]byte("vsix content")))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer backend.Close()
+	upstreamURL = backend.URL
+
+	frontend, openVSXProxy := createDiskCacheFrontend(t, backend.URL, false, t.TempDir())
+	defer frontend.Close()
+
+	status, body := get(t, frontend.URL+"/api/foo/bar/1.0.0/file/foo.bar-1.0.0.vsix")
+	if status != http.StatusOK || body != "tampered content" {
+		t.Fatalf("got %d '%s'; expected the package to be passed through", status, body)
+	}
+	ref := vsixRef{Namespace: "foo", Name: "bar", Version: "1.0.0"}
+	if _, ok, _ := openVSXProxy.diskCache.ReadCache(ref.Key()); ok {
+		t.Errorf("package which doesn't match its checksum was stored in the disk cache")
+	}
+}
+
+func TestPreseedAndAirGapped(t *testing.T) {
+	var upstreamURL string
+	upstream := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/foo/bar":
+			rw.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(rw, `{"namespace":"foo","name":"bar","version":"1.2.3","targetPlatform":"universal","files":{"download":"%[1]s/api/foo/bar/1.2.3/file/foo.bar-1.2.3.vsix","sha256":"%[1]s/api/foo/bar/1.2.3/file/foo.bar-1.2.3.sha256"}}`, upstreamURL)
+		case "/api/foo/bar/1.2.3/file/foo.bar-1.2.3.vsix":
+			rw.Write([]byte("vsix content"))
+		case "/api/foo/bar/1.2.3/file/foo.bar-1.2.3.sha256":
+			fmt.Fprintf(rw, "%x", sha256.Sum256([]byte("vsix content")))
+		case "/vscode/gallery/extensionquery":
+			rw.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(rw, `{"results":[{"extensions":[{"extensionId":"1234","extensionName":"bar","displayName":"Bar","shortDescription":"Does bar things","publisher":{"publisherName":"foo"},"versions":[{"version":"1.2.3","files":[{"assetType":"Microsoft.VisualStudio.Services.VSIXPackage","source":"%s/api/foo/bar/1.2.3/file/foo.bar-1.2.3.vsix"}]}]}]}]}`, upstreamURL)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+	upstreamURL = upstream.URL
+
+	dir := t.TempDir()
+	_, openVSXProxy := createDiskCacheFrontend(t, upstream.URL, false, dir)
+	openVSXProxy.Config.PreseedExtensions = []string{"foo.bar", "foo.missing"}
+	openVSXProxy.Preseed(context.Background())
+
+	backend := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		t.Errorf("upstream must not be contacted in air-gapped mode: %s", r.URL)
+	}))
+	defer backend.Close()
+	frontend, airGappedProxy := createDiskCacheFrontend(t, backend.URL, true, dir)
+	defer frontend.Close()
+	airGappedProxy.Config.URLUpstream = upstream.URL
+
+	tests := []struct {
+		Path   string
+		Status int
+		Body   string
+	}{
+		{Path: "/api/foo/bar", Status: http.StatusOK, Body: "1.2.3"},
+		{Path: "/api/foo/bar/1.2.3", Status: http.StatusOK, Body: "1.2.3"},
+		{Path: "/vscode/asset/foo/bar/1.2.3/Microsoft.VisualStudio.Services.VSIXPackage", Status: http.StatusOK, Body: "vsix content"},
+		{Path: "/api/foo/missing", Status: http.StatusNotFound},
+		{Path: "/api/foo/bar/2.0.0/file/foo.bar-2.0.0.vsix", Status: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.Path, func(t *testing.T) {
+			status, body := get(t, frontend.URL+test.Path)
+			if status != test.Status {
+				t.Errorf("got status %d; expected %d", status, test.Status)
+			}
+			if !strings.Contains(body, test.Body) {
+				t.Errorf("got body '%s'; expected it to contain '%s'", body, test.Body)
+			}
+		})
+	}
+
+	queries := []struct {
+		Name   string
+		Query  string
+		Result string
+	}{
+		{Name: "by name", Query: `{"filters":[{"criteria":[{"filterType":8,"value":"Microsoft.VisualStudio.Code"},{"filterType":7,"value":"Foo.Bar"}],"pageNumber":1,"pageSize":1}],"flags":950}`, Result: `"extensionName":"bar"`},
+		{Name: "by id", Query: `{"filters":[{"criteria":[{"filterType":4,"value":"1234"}]}]}`, Result: `"extensionName":"bar"`},
+		{Name: "by search text", Query: `{"filters":[{"criteria":[{"filterType":10,"value":"bar things"}]}]}`, Result: `"extensionName":"bar"`},
+		{Name: "no match", Query: `{"filters":[{"criteria":[{"filterType":10,"value":"python"}]}]}`, Result: `"extensions":[]`},
+		{Name: "out of page", Query: `{"filters":[{"criteria":[],"pageNumber":2,"pageSize":1}]}`, Result: `"extensions":[]`},
+	}
+	for _, test := range queries {
+		t.Run(test.Name, func(t *testing.T) {
+			res, err := http.Post(frontend.URL+"/vscode/gallery/extensionquery", "application/json", strings.NewReader(test.Query))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+			body := string(b)
+			if res.StatusCode != http.StatusOK {
+				t.Fatalf("got status %d; expected %d", res.StatusCode, http.StatusOK)
+			}
+			if !strings.Contains(body, test.Result) {
+				t.Errorf("got body '%s'; expected it to contain '%s'", body, test.Result)
+			}
+			if strings.Contains(body, upstream.URL) {
+				t.Errorf("got body '%s'; expected asset URLs to point to the proxy", body)
+			}
+		})
+	}
+}
@@ -46,7 +46,7 @@ func (o *OpenVSXProxy) ErrorHandler(rw http.ResponseWriter, r *http.Request, e e
 		return
 	}
 
-	cached, ok, err := o.ReadCache(key)
+	cached, ok, err := o.ReadBackupCache(key)
 	if err != nil {
 		log.WithFields(logFields).WithError(err).Error("cannot read from cache")
 		rw.WriteHeader(http.StatusBadGateway)
@@ -0,0 +1,285 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// galleryExtensionKeyPrefix prefixes the disk cache keys of the extensions found in extension queries
+	galleryExtensionKeyPrefix = "gallery "
+
+	galleryFilterTypeExtensionID   = 4
+	galleryFilterTypeExtensionName = 7
+	galleryFilterTypeSearchText    = 10
+
+	// galleryQueryFlags asks for all versions with their files, properties and asset URIs
+	galleryQueryFlags = 0x1 | 0x2 | 0x10 | 0x80
+
+	galleryDefaultPageSize = 50
+	maxGalleryQuerySize    = 64 * 1024
+)
+
+// galleryQuery is a query of the extension gallery API VS Code uses
+type galleryQuery struct {
+	Filters []galleryQueryFilter `json:"filters"`
+	Flags   int                  `json:"flags"`
+}
+
+type galleryQueryFilter struct {
+	Criteria   []galleryQueryCriterion `json:"criteria"`
+	PageNumber int                     `json:"pageNumber"`
+	PageSize   int                     `json:"pageSize"`
+}
+
+type galleryQueryCriterion struct {
+	FilterType int    `json:"filterType"`
+	Value      string `json:"value"`
+}
+
+type galleryQueryResponse struct {
+	Results []galleryQueryResult `json:"results"`
+}
+
+type galleryQueryResult struct {
+	Extensions     []json.RawMessage       `json:"extensions"`
+	PagingToken    *string                 `json:"pagingToken"`
+	ResultMetadata []galleryResultMetadata `json:"resultMetadata"`
+}
+
+type galleryResultMetadata struct {
+	MetadataType  string                      `json:"metadataType"`
+	MetadataItems []galleryResultMetadataItem `json:"metadataItems"`
+}
+
+type galleryResultMetadataItem struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// galleryExtension is the part of an extension in a gallery query result needed to find it
+type galleryExtension struct {
+	ExtensionID      string `json:"extensionId"`
+	ExtensionName    string `json:"extensionName"`
+	DisplayName      string `json:"displayName"`
+	ShortDescription string `json:"shortDescription"`
+	Publisher        struct {
+		PublisherName string `json:"publisherName"`
+	} `json:"publisher"`
+	Versions []struct {
+		Files []json.RawMessage `json:"files"`
+	} `json:"versions"`
+}
+
+func (e *galleryExtension) id() string {
+	return strings.ToLower(e.Publisher.PublisherName + "." + e.ExtensionName)
+}
+
+type cachedGalleryExtension struct {
+	galleryExtension
+	Raw json.RawMessage
+}
+
+func isGalleryQuery(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/vscode/gallery/extensionquery")
+}
+
+// storeGalleryExtensions stores every extension of a gallery query response in the disk cache,
+// so that gallery queries can be answered in air-gapped mode
+func (o *OpenVSXProxy) storeGalleryExtensions(body []byte) error {
+	var resp galleryQueryResponse
+	err := json.Unmarshal(body, &resp)
+	if err != nil {
+		return xerrors.Errorf("cannot parse extension query response: %w", err)
+	}
+	for _, result := range resp.Results {
+		for _, raw := range result.Extensions {
+			var ext galleryExtension
+			err := json.Unmarshal(raw, &ext)
+			if err != nil || ext.ExtensionName == "" || ext.Publisher.PublisherName == "" {
+				continue
+			}
+			if len(ext.Versions) == 0 || len(ext.Versions[0].Files) == 0 {
+				// queries without the files of an extension must not replace a complete entry
+				continue
+			}
+			err = o.diskCache.Store(galleryExtensionKeyPrefix+ext.id(), http.Header{"Content-Type": {"application/json"}}, http.StatusOK, bytes.NewReader(raw))
+			if err != nil {
+				return xerrors.Errorf("cannot store extension %s: %w", ext.id(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// preseedGalleryExtension stores the gallery entry of an extension in the disk cache
+func (o *OpenVSXProxy) preseedGalleryExtension(ctx context.Context, namespace, name string) error {
+	query, err := json.Marshal(galleryQuery{
+		Filters: []galleryQueryFilter{{
+			Criteria:   []galleryQueryCriterion{{FilterType: galleryFilterTypeExtensionName, Value: namespace + "." + name}},
+			PageNumber: 1,
+			PageSize:   1,
+		}},
+		Flags: galleryQueryFlags,
+	})
+	if err != nil {
+		return err
+	}
+	u := *o.defaultUpstreamURL
+	u.Path, u.RawPath = joinURLPath(o.defaultUpstreamURL, &url.URL{Path: "/vscode/gallery/extensionquery"})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(query))
+	if err != nil {
+		return xerrors.Errorf("cannot create extension query: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json;api-version=3.0-preview.1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return xerrors.Errorf("cannot query extension: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return xerrors.Errorf("cannot query extension: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return xerrors.Errorf("cannot read extension query response: %w", err)
+	}
+	return o.storeGalleryExtensions(body)
+}
+
+// serveGalleryQuery answers a gallery query with the extensions in the disk cache
+func (o *OpenVSXProxy) serveGalleryQuery(rw http.ResponseWriter, r *http.Request, logFields logrus.Fields) {
+	var query galleryQuery
+	err := json.NewDecoder(io.LimitReader(r.Body, maxGalleryQuerySize)).Decode(&query)
+	if err != nil {
+		o.metrics.IncStatusCounter(r, strconv.Itoa(http.StatusBadRequest))
+		http.Error(rw, "invalid extension query", http.StatusBadRequest)
+		return
+	}
+
+	var extensions []*cachedGalleryExtension
+	for _, key := range o.diskCache.Keys(galleryExtensionKeyPrefix) {
+		cached, ok, err := o.diskCache.ReadCache(key)
+		if err != nil {
+			log.WithFields(logFields).WithError(err).WithField("entry", key).Warn("cannot read extension from disk cache")
+			continue
+		}
+		if !ok {
+			continue
+		}
+		ext := &cachedGalleryExtension{Raw: o.localGalleryURLs(cached.Body)}
+		if err := json.Unmarshal(ext.Raw, &ext.galleryExtension); err != nil {
+			continue
+		}
+		extensions = append(extensions, ext)
+	}
+
+	resp := galleryQueryResponse{Results: make([]galleryQueryResult, 0, len(query.Filters))}
+	for _, filter := range query.Filters {
+		matches := filterGalleryExtensions(extensions, filter.Criteria)
+
+		pageSize := filter.PageSize
+		if pageSize <= 0 {
+			pageSize = galleryDefaultPageSize
+		}
+		start := (filter.PageNumber - 1) * pageSize
+		if start < 0 {
+			start = 0
+		}
+		page := make([]json.RawMessage, 0, pageSize)
+		for i := start; i < len(matches) && i < start+pageSize; i++ {
+			page = append(page, matches[i].Raw)
+		}
+		resp.Results = append(resp.Results, galleryQueryResult{
+			Extensions: page,
+			ResultMetadata: []galleryResultMetadata{{
+				MetadataType:  "ResultCount",
+				MetadataItems: []galleryResultMetadataItem{{Name: "TotalCount", Count: len(matches)}},
+			}},
+		})
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("X-Cache", "HIT")
+	err = json.NewEncoder(rw).Encode(resp)
+	if err != nil {
+		log.WithFields(logFields).WithError(err).Warn("cannot write extension query response")
+	}
+	o.metrics.IncStatusCounter(r, strconv.Itoa(http.StatusOK))
+	o.metrics.DiskCacheServeCounter.Inc()
+}
+
+// localGalleryURLs points the asset URLs of a cached extension to the proxy
+func (o *OpenVSXProxy) localGalleryURLs(raw []byte) []byte {
+	upstream := strings.TrimSuffix(o.Config.URLUpstream, "/")
+	local := strings.TrimSuffix(o.Config.URLLocal, "/")
+	if upstream == "" || local == "" {
+		return raw
+	}
+	return bytes.ReplaceAll(raw, []byte(upstream), []byte(local))
+}
+
+// filterGalleryExtensions returns the extensions matching the extension names, IDs or search text of the criteria,
+// or all extensions if there are none of them
+func filterGalleryExtensions(extensions []*cachedGalleryExtension, criteria []galleryQueryCriterion) []*cachedGalleryExtension {
+	var (
+		names  = make(map[string]struct{})
+		ids    = make(map[string]struct{})
+		search []string
+	)
+	for _, c := range criteria {
+		switch c.FilterType {
+		case galleryFilterTypeExtensionName:
+			names[strings.ToLower(c.Value)] = struct{}{}
+		case galleryFilterTypeExtensionID:
+			ids[strings.ToLower(c.Value)] = struct{}{}
+		case galleryFilterTypeSearchText:
+			for _, term := range strings.Fields(strings.ToLower(c.Value)) {
+				// category:, tag: and other qualifiers are not supported
+				if !strings.Contains(term, ":") {
+					search = append(search, term)
+				}
+			}
+		}
+	}
+
+	var res []*cachedGalleryExtension
+	for _, ext := range extensions {
+		if len(names) > 0 || len(ids) > 0 {
+			_, nameMatches := names[ext.id()]
+			_, idMatches := ids[strings.ToLower(ext.ExtensionID)]
+			if nameMatches || idMatches {
+				res = append(res, ext)
+			}
+			continue
+		}
+
+		text := strings.ToLower(ext.id() + " " + ext.DisplayName + " " + ext.ShortDescription)
+		matches := true
+		for _, term := range search {
+			if !strings.Contains(text, term) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			res = append(res, ext)
+		}
+	}
+	return res
+}
@@ -49,7 +49,14 @@ func (o *OpenVSXProxy) Handler(p *httputil.ReverseProxy) func(http.ResponseWrite
 		upstream := o.GetUpstreamUrl(r)
 		r = r.WithContext(context.WithValue(r.Context(), UPSTREAM_CTX, upstream))
 
-		if o.IsDisabledCache(upstream) {
+		if ref, ok := parseVSIXRequest(r); ok && o.diskCache != nil && (o.Config.AirGapped || !o.IsDisabledCache(upstream)) {
+			logFields[LOG_FIELD_REQUEST] = ref.Key()
+			hit := o.serveVSIX(rw, r, upstream, ref, logFields)
+			o.finishLog(logFields, start, hit, hit)
+			return
+		}
+
+		if o.IsDisabledCache(upstream) && !o.Config.AirGapped {
 			log.WithFields(logFields).WithField("upstream", upstream.String()).Debug("go without cache")
 			p.ServeHTTP(rw, r)
 			o.finishLog(logFields, start, false, false)
@@ -57,9 +64,21 @@ func (o *OpenVSXProxy) Handler(p *httputil.ReverseProxy) func(http.ResponseWrite
 			return
 		}
 
+		if o.Config.AirGapped && isGalleryQuery(r) {
+			// queries differ in their flags and paging, they're answered from the extensions in the disk cache
+			o.serveGalleryQuery(rw, r, logFields)
+			o.finishLog(logFields, start, false, true)
+			return
+		}
+
 		key, err := o.key(r)
 		if err != nil {
 			log.WithFields(logFields).WithError(err).Error("cannot create cache key")
+			if o.Config.AirGapped {
+				rw.WriteHeader(http.StatusInternalServerError)
+				o.finishLog(logFields, start, false, false)
+				return
+			}
 			p.ServeHTTP(rw, r)
 			o.finishLog(logFields, start, hitCacheRegular, hitCacheBackup)
 			o.metrics.DurationRequestProcessingHistogram.Observe(time.Since(start).Seconds())
@@ -68,6 +87,12 @@ func (o *OpenVSXProxy) Handler(p *httputil.ReverseProxy) func(http.ResponseWrite
 		r = r.WithContext(context.WithValue(r.Context(), REQUEST_CACHE_KEY_CTX, key))
 		logFields[LOG_FIELD_REQUEST] = key
 
+		if o.Config.AirGapped {
+			hit := o.serveAirGapped(rw, r, key, logFields)
+			o.finishLog(logFields, start, false, hit)
+			return
+		}
+
 		if o.Config.CacheDurationRegular > 0 {
 			cached, ok, err := o.ReadCache(key)
 			if err != nil {
@@ -120,6 +145,38 @@ func (o *OpenVSXProxy) Handler(p *httputil.ReverseProxy) func(http.ResponseWrite
 	}
 }
 
+// serveAirGapped answers with a cached response regardless of its age, the upstream is never contacted
+func (o *OpenVSXProxy) serveAirGapped(rw http.ResponseWriter, r *http.Request, key string, logFields logrus.Fields) (hit bool) {
+	cached, ok, err := o.ReadBackupCache(key)
+	if err != nil {
+		log.WithFields(logFields).WithError(err).Error("cannot read from cache")
+	}
+	if !ok {
+		log.WithFields(logFields).Debug("cache has no entry for key")
+		o.metrics.IncStatusCounter(r, strconv.Itoa(http.StatusNotFound))
+		http.Error(rw, "not available in air-gapped mode", http.StatusNotFound)
+		return false
+	}
+	for k, v := range cached.Header {
+		for i, val := range v {
+			if i == 0 {
+				rw.Header().Set(k, val)
+			} else {
+				rw.Header().Add(k, val)
+			}
+		}
+	}
+	if v := rw.Header().Get("Access-Control-Allow-Origin"); v != "" && v != "*" {
+		rw.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+	}
+	rw.Header().Set("X-Cache", "HIT")
+	rw.WriteHeader(cached.StatusCode)
+	rw.Write(cached.Body)
+	o.metrics.IncStatusCounter(r, strconv.Itoa(cached.StatusCode))
+	o.metrics.BackupCacheServeCounter.Inc()
+	return true
+}
+
 func (o *OpenVSXProxy) finishLog(logFields logrus.Fields, start time.Time, hitCacheRegular, hitCacheBackup bool) {
 	duration := time.Since(start)
 	o.metrics.DurationOverallHistogram.Observe(duration.Seconds())
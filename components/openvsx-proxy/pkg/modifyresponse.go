@@ -73,7 +73,7 @@ func (o *OpenVSXProxy) ModifyResponse(r *http.Response) error {
 			WithFields(logFields).
 			WithField("body", bodyLogField).
 			Warn("error from upstream server - trying to use cached response")
-		cached, ok, err := o.ReadCache(key)
+		cached, ok, err := o.ReadBackupCache(key)
 		if err != nil {
 			log.WithFields(logFields).WithError(err).Error("cannot read from cache")
 			return nil
@@ -106,6 +106,18 @@ func (o *OpenVSXProxy) ModifyResponse(r *http.Response) error {
 	} else {
 		log.WithFields(logFields).Debug("successfully stored response to cache")
 	}
+	if o.diskCache != nil && r.StatusCode >= 200 && r.StatusCode < 300 {
+		err = o.diskCache.Store(key, r.Header, r.StatusCode, bytes.NewReader(rawBody))
+		if err != nil {
+			log.WithFields(logFields).WithError(err).Error("error storing response to disk cache")
+		}
+		if isGalleryQuery(r.Request) {
+			err = o.storeGalleryExtensions(rawBody)
+			if err != nil {
+				log.WithFields(logFields).WithError(err).Warn("cannot store extensions of extension query in disk cache")
+			}
+		}
+	}
 
 	r.Body = ioutil.NopCloser(bytes.NewBuffer(rawBody))
 	r.ContentLength = int64(len(rawBody))
@@ -0,0 +1,157 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"golang.org/x/xerrors"
+)
+
+// extensionIDPattern matches extension IDs of the form publisher.name or publisher.name@version
+var extensionIDPattern = regexp.MustCompile(`^[\w-]+\.[\w-]+(@[\w.+-]+)?$`)
+
+// extensionMetadata is the part of the Open VSX extension metadata needed to download the package
+type extensionMetadata struct {
+	Namespace      string `json:"namespace"`
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	TargetPlatform string `json:"targetPlatform"`
+	Files          struct {
+		Download string `json:"download"`
+		SHA256   string `json:"sha256"`
+	} `json:"files"`
+}
+
+// Preseed downloads the metadata and packages of the configured extensions to the disk cache
+func (o *OpenVSXProxy) Preseed(ctx context.Context) {
+	for _, id := range o.Config.PreseedExtensions {
+		err := o.preseedExtension(ctx, id)
+		if err != nil {
+			log.WithError(err).WithField("extension", id).Error("cannot pre-seed extension")
+			continue
+		}
+		log.WithField("extension", id).Info("pre-seeded extension")
+	}
+}
+
+func (o *OpenVSXProxy) preseedExtension(ctx context.Context, id string) error {
+	if !extensionIDPattern.MatchString(id) {
+		return xerrors.Errorf("invalid extension ID %s", id)
+	}
+	id, version, _ := strings.Cut(id, "@")
+	namespace, name, _ := strings.Cut(id, ".")
+
+	path := "/api/" + namespace + "/" + name
+	if version != "" {
+		path += "/" + version
+	}
+	b, header, err := o.fetchUpstream(ctx, path)
+	if err != nil {
+		return err
+	}
+	var metadata extensionMetadata
+	err = json.Unmarshal(b, &metadata)
+	if err != nil {
+		return xerrors.Errorf("cannot parse extension metadata: %w", err)
+	}
+	if metadata.Version == "" || metadata.Files.Download == "" {
+		return xerrors.Errorf("extension metadata has no package")
+	}
+
+	paths := []string{path}
+	if version == "" {
+		paths = append(paths, path+"/"+metadata.Version)
+	}
+	for _, p := range paths {
+		key, err := o.preseedKey(p)
+		if err != nil {
+			return err
+		}
+		err = o.diskCache.Store(key, header, http.StatusOK, bytes.NewReader(b))
+		if err != nil {
+			return xerrors.Errorf("cannot store extension metadata: %w", err)
+		}
+	}
+
+	ref := vsixRef{Namespace: metadata.Namespace, Name: metadata.Name, Version: metadata.Version, TargetPlatform: metadata.TargetPlatform}
+	// VS Code finds extensions with gallery queries
+	err = o.preseedGalleryExtension(ctx, metadata.Namespace, metadata.Name)
+	if err != nil {
+		return err
+	}
+
+	key := ref.Key()
+	if cached, ok, _ := o.diskCache.Open(key); ok {
+		cached.Body.Close()
+		return nil
+	}
+	checksum, err := fetchChecksum(ctx, metadata.Files.SHA256)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadata.Files.Download, nil)
+	if err != nil {
+		return xerrors.Errorf("cannot create download request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return xerrors.Errorf("cannot download extension package: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return xerrors.Errorf("cannot download extension package: %s", resp.Status)
+	}
+	header = make(http.Header)
+	copyVSIXHeaders(header, resp.Header, nil)
+	err = o.diskCache.Store(key, header, http.StatusOK, newChecksumReader(resp.Body, checksum))
+	if err != nil {
+		return xerrors.Errorf("cannot store extension package: %w", err)
+	}
+	return nil
+}
+
+// preseedKey is the cache key of a GET request of path
+func (o *OpenVSXProxy) preseedKey(path string) (string, error) {
+	r, err := http.NewRequest(http.MethodGet, path, http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	return o.key(r)
+}
+
+func (o *OpenVSXProxy) fetchUpstream(ctx context.Context, path string) ([]byte, http.Header, error) {
+	return fetch(ctx, o.defaultUpstreamURL, path)
+}
+
+func fetch(ctx context.Context, upstream *url.URL, path string) ([]byte, http.Header, error) {
+	u := *upstream
+	u.Path, u.RawPath = joinURLPath(upstream, &url.URL{Path: path})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("cannot create request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("cannot fetch %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, xerrors.Errorf("cannot fetch %s: %s", path, resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("cannot read %s: %w", path, err)
+	}
+	return b, resp.Header, nil
+}
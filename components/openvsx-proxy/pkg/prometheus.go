@@ -20,6 +20,7 @@ type Prometheus struct {
 	BackupCacheHitCounter               prometheus.Counter
 	BackupCacheMissCounter              prometheus.Counter
 	BackupCacheServeCounter             prometheus.Counter
+	DiskCacheServeCounter               prometheus.Counter
 	RegularCacheHitServeCounter         prometheus.Counter
 	RegularCacheMissCounter             prometheus.Counter
 	RequestsCounter                     *prometheus.CounterVec
@@ -55,6 +56,7 @@ func (p *Prometheus) Start(cfg *Config) {
 		p.BackupCacheHitCounter,
 		p.BackupCacheMissCounter,
 		p.BackupCacheServeCounter,
+		p.DiskCacheServeCounter,
 		p.RegularCacheHitServeCounter,
 		p.RegularCacheMissCounter,
 		p.RequestsCounter,
@@ -92,6 +94,12 @@ func (p *Prometheus) createMetrics() {
 		Name:      "backup_cache_serve_total",
 		Help:      "The total amount of requests where we actually answered with a cached response because the upstream server is down.",
 	})
+	p.DiskCacheServeCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "disk_cache_serve_total",
+		Help:      "The total amount of extension packages we answered from the disk cache.",
+	})
 	p.RegularCacheHitServeCounter = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: namespace,
 		Subsystem: subsystem,
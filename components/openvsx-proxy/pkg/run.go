@@ -33,11 +33,16 @@ type OpenVSXProxy struct {
 	Config             *Config
 	defaultUpstreamURL *url.URL
 	cacheManager       *cache.Cache
+	diskCache          *DiskCache
 	metrics            *Prometheus
 	experiments        experiments.Client
 }
 
 func (o *OpenVSXProxy) GetUpstreamUrl(r *http.Request) *url.URL {
+	if o.Config.AirGapped {
+		return o.defaultUpstreamURL
+	}
+
 	reqid := r.Context().Value(REQUEST_ID_CTX).(string)
 
 	clientID := r.Header.Get("x-market-client-id")
@@ -94,6 +99,14 @@ func (o *OpenVSXProxy) Start() (shutdown func(context.Context) error, err error)
 	proxy.ModifyResponse = o.ModifyResponse
 	proxy.Transport = &DurationTrackingTransport{o: o}
 
+	if len(o.Config.PreseedExtensions) > 0 {
+		if o.Config.AirGapped {
+			log.Warn("extensions are not pre-seeded in air-gapped mode")
+		} else {
+			go o.Preseed(context.Background())
+		}
+	}
+
 	http.HandleFunc("/", o.Handler(proxy))
 	http.HandleFunc("/openvsx-proxy-status", func(rw http.ResponseWriter, r *http.Request) {
 		if _, _, err := o.ReadCache("does-not-exist"); err != nil {
@@ -0,0 +1,270 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package pkg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// vsixHeaders are the headers of an extension package response which are stored in the disk cache.
+// Packages are often served from a CDN, most of its headers are meaningless for clients of the proxy.
+var vsixHeaders = []string{
+	"Access-Control-Allow-Origin",
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Type",
+	"Last-Modified",
+}
+
+// vsixRef identifies an extension package
+type vsixRef struct {
+	Namespace      string
+	Name           string
+	Version        string
+	TargetPlatform string
+}
+
+// parseVSIXRequest returns the extension package an extension package request is for. All URLs of the same
+// package share a disk cache key, since VS Code and the Open VSX API use different URLs to download packages:
+//
+//	/vscode/gallery/publishers/{namespace}/vsextensions/{name}/{version}/vspackage
+//	/vscode/asset/{namespace}/{name}/{version}/Microsoft.VisualStudio.Services.VSIXPackage
+//	/api/{namespace}/{name}/{version}/file/{file}.vsix
+//	/api/{namespace}/{name}/{targetPlatform}/{version}/file/{file}.vsix
+func parseVSIXRequest(r *http.Request) (ref vsixRef, ok bool) {
+	if r.Method != http.MethodGet {
+		return vsixRef{}, false
+	}
+	var (
+		segments       = strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		targetPlatform = r.URL.Query().Get("targetPlatform")
+		namespace      string
+		name           string
+		version        string
+	)
+	switch {
+	case len(segments) == 8 && segments[0] == "vscode" && segments[1] == "gallery" && segments[2] == "publishers" && segments[4] == "vsextensions" && segments[7] == "vspackage":
+		namespace, name, version = segments[3], segments[5], segments[6]
+	case len(segments) == 6 && segments[0] == "vscode" && segments[1] == "asset" && segments[5] == "Microsoft.VisualStudio.Services.VSIXPackage":
+		namespace, name, version = segments[2], segments[3], segments[4]
+	case len(segments) == 6 && segments[0] == "api" && segments[4] == "file" && strings.HasSuffix(segments[5], ".vsix"):
+		namespace, name, version = segments[1], segments[2], segments[3]
+	case len(segments) == 7 && segments[0] == "api" && segments[5] == "file" && strings.HasSuffix(segments[6], ".vsix"):
+		namespace, name, targetPlatform, version = segments[1], segments[2], segments[3], segments[4]
+	default:
+		return vsixRef{}, false
+	}
+	if version == "latest" {
+		// only immutable packages can be cached
+		return vsixRef{}, false
+	}
+	return vsixRef{Namespace: namespace, Name: name, Version: version, TargetPlatform: targetPlatform}, true
+}
+
+func (ref vsixRef) hasTargetPlatform() bool {
+	return ref.TargetPlatform != "" && ref.TargetPlatform != "universal"
+}
+
+// Key returns the disk cache key of the package
+func (ref vsixRef) Key() string {
+	key := fmt.Sprintf("vsix %s.%s@%s", strings.ToLower(ref.Namespace), strings.ToLower(ref.Name), ref.Version)
+	if ref.hasTargetPlatform() {
+		key += "+" + ref.TargetPlatform
+	}
+	return key
+}
+
+// MetadataPath returns the path of the Open VSX API which describes the package
+func (ref vsixRef) MetadataPath() string {
+	if ref.hasTargetPlatform() {
+		return "/api/" + ref.Namespace + "/" + ref.Name + "/" + ref.TargetPlatform + "/" + ref.Version
+	}
+	return "/api/" + ref.Namespace + "/" + ref.Name + "/" + ref.Version
+}
+
+// serveVSIX serves an extension package from the disk cache. Packages which are not cached yet are downloaded
+// from upstream, following redirects, and stored in the disk cache while they are sent to the client.
+func (o *OpenVSXProxy) serveVSIX(rw http.ResponseWriter, r *http.Request, upstream *url.URL, ref vsixRef, logFields logrus.Fields) (hit bool) {
+	key := ref.Key()
+	cached, ok, err := o.diskCache.Open(key)
+	if err != nil {
+		log.WithFields(logFields).WithError(err).Error("cannot read from disk cache")
+	}
+	if ok {
+		defer cached.Body.Close()
+		copyVSIXHeaders(rw.Header(), cached.Header, r)
+		rw.Header().Set("X-Cache", "HIT")
+		http.ServeContent(rw, r, "", cached.StoredAt, cached.Body)
+		log.WithFields(logFields).Debug("served extension package from disk cache")
+		o.metrics.DiskCacheServeCounter.Inc()
+		return true
+	}
+
+	if o.Config.AirGapped {
+		log.WithFields(logFields).Debug("extension package is not in the disk cache")
+		o.metrics.IncStatusCounter(r, strconv.Itoa(http.StatusNotFound))
+		http.Error(rw, "extension package is not available in air-gapped mode", http.StatusNotFound)
+		return false
+	}
+
+	target := *upstream
+	target.Path, target.RawPath = joinURLPath(upstream, r.URL)
+	if upstream.RawQuery == "" || r.URL.RawQuery == "" {
+		target.RawQuery = upstream.RawQuery + r.URL.RawQuery
+	} else {
+		target.RawQuery = upstream.RawQuery + "&" + r.URL.RawQuery
+	}
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, target.String(), nil)
+	if err != nil {
+		log.WithFields(logFields).WithError(err).Error("cannot create upstream request")
+		rw.WriteHeader(http.StatusBadGateway)
+		return false
+	}
+	req.Header.Set("User-Agent", r.Header.Get("User-Agent"))
+
+	client := &http.Client{Transport: &DurationTrackingTransport{o: o}}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.WithFields(logFields).WithError(err).Warn("cannot download extension package")
+		o.metrics.IncStatusCounter(r, "error")
+		rw.WriteHeader(http.StatusBadGateway)
+		return false
+	}
+	defer resp.Body.Close()
+	o.metrics.IncStatusCounter(r, strconv.Itoa(resp.StatusCode))
+
+	if resp.StatusCode != http.StatusOK {
+		log.WithFields(logFields).WithField(LOG_FIELD_STATUS, resp.StatusCode).Debug("upstream did not return an extension package")
+		copyVSIXHeaders(rw.Header(), resp.Header, r)
+		rw.WriteHeader(resp.StatusCode)
+		_, _ = io.Copy(rw, resp.Body)
+		return false
+	}
+
+	// the package is only stored if it matches the checksum published by Open VSX
+	checksum, checksumErr := o.fetchVSIXChecksum(r.Context(), upstream, ref)
+	if checksumErr != nil {
+		log.WithFields(logFields).WithError(checksumErr).Warn("cannot get the checksum of the extension package, it is not stored in the disk cache")
+	}
+
+	header := make(http.Header)
+	copyVSIXHeaders(header, resp.Header, nil)
+	copyVSIXHeaders(rw.Header(), resp.Header, r)
+	if resp.ContentLength >= 0 {
+		rw.Header().Set("Content-Length", strconv.FormatInt(resp.ContentLength, 10))
+	}
+	rw.Header().Set("X-Cache", "MISS")
+	rw.WriteHeader(http.StatusOK)
+
+	if checksumErr != nil {
+		_, _ = io.Copy(rw, resp.Body)
+		return false
+	}
+	err = o.diskCache.Store(key, header, http.StatusOK, newChecksumReader(io.TeeReader(resp.Body, rw), checksum))
+	if err != nil {
+		log.WithFields(logFields).WithError(err).Warn("cannot store extension package in disk cache")
+	} else {
+		log.WithFields(logFields).Debug("stored extension package in disk cache")
+	}
+	return false
+}
+
+// fetchVSIXChecksum returns the SHA-256 checksum Open VSX publishes for the package. An empty checksum is
+// returned for packages without one, which were published before Open VSX started to compute them.
+func (o *OpenVSXProxy) fetchVSIXChecksum(ctx context.Context, upstream *url.URL, ref vsixRef) (string, error) {
+	b, _, err := fetch(ctx, upstream, ref.MetadataPath())
+	if err != nil {
+		return "", err
+	}
+	var metadata extensionMetadata
+	err = json.Unmarshal(b, &metadata)
+	if err != nil {
+		return "", xerrors.Errorf("cannot parse extension metadata: %w", err)
+	}
+	return fetchChecksum(ctx, metadata.Files.SHA256)
+}
+
+// fetchChecksum downloads a checksum file of Open VSX, which contains the hex encoded checksum optionally followed by the file name
+func fetchChecksum(ctx context.Context, checksumURL string) (string, error) {
+	if checksumURL == "" {
+		return "", nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checksumURL, nil)
+	if err != nil {
+		return "", xerrors.Errorf("cannot create checksum request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", xerrors.Errorf("cannot download checksum: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", xerrors.Errorf("cannot download checksum: %s", resp.Status)
+	}
+	b, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", xerrors.Errorf("cannot read checksum: %w", err)
+	}
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 {
+		return "", xerrors.Errorf("checksum file is empty")
+	}
+	checksum := strings.ToLower(fields[0])
+	if _, err := hex.DecodeString(checksum); err != nil || len(checksum) != sha256.Size*2 {
+		return "", xerrors.Errorf("invalid checksum %q", fields[0])
+	}
+	return checksum, nil
+}
+
+var errChecksumMismatch = xerrors.Errorf("checksum mismatch")
+
+// checksumReader fails at the end of the content if it doesn't match the SHA-256 checksum.
+// No checksum is verified if it's empty.
+type checksumReader struct {
+	r        io.Reader
+	hash     hash.Hash
+	expected string
+}
+
+func newChecksumReader(r io.Reader, checksum string) io.Reader {
+	if checksum == "" {
+		return r
+	}
+	return &checksumReader{r: r, hash: sha256.New(), expected: checksum}
+}
+
+func (c *checksumReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.hash.Write(p[:n])
+	if err == io.EOF && hex.EncodeToString(c.hash.Sum(nil)) != c.expected {
+		return n, errChecksumMismatch
+	}
+	return n, err
+}
+
+func copyVSIXHeaders(dst, src http.Header, r *http.Request) {
+	for _, h := range vsixHeaders {
+		if v := src.Get(h); v != "" {
+			dst.Set(h, v)
+		}
+	}
+	if v := dst.Get("Access-Control-Allow-Origin"); r != nil && v != "" && v != "*" {
+		dst.Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+	}
+}
@@ -104,6 +104,14 @@ func (s *SSHTunnel) handle(conn net.Conn) {
 		return
 	}
 	defer tconn.Close()
+	if os.Getenv("SSH_TUNNEL_PROXY_PROTOCOL") == "true" {
+		// tell ws-proxy where the client connects from, it only sees us otherwise
+		_, err = io.WriteString(tconn, proxyProtocolHeader(conn.RemoteAddr(), conn.LocalAddr()))
+		if err != nil {
+			fmt.Printf("writing PROXY protocol header to %s failed with:%v\n", addr, err)
+			return
+		}
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
 		io.Copy(conn, tconn)
@@ -117,4 +125,18 @@ func (s *SSHTunnel) handle(conn net.Conn) {
 	<-ctx.Done()
 }
 
+// proxyProtocolHeader produces the PROXY protocol v1 header of a connection from src to dst
+func proxyProtocolHeader(src, dst net.Addr) string {
+	s, sok := src.(*net.TCPAddr)
+	d, dok := dst.(*net.TCPAddr)
+	if !sok || !dok {
+		return "PROXY UNKNOWN\r\n"
+	}
+	family, sip, dip := "TCP4", s.IP.To4(), d.IP.To4()
+	if sip == nil || dip == nil {
+		family, sip, dip = "TCP6", s.IP.To16(), d.IP.To16()
+	}
+	return fmt.Sprintf("PROXY %s %s %s %d %d\r\n", family, sip, dip, s.Port, d.Port)
+}
+
 var _ caddy.App = (*SSHTunnel)(nil)
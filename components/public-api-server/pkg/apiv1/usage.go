@@ -0,0 +1,129 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	connect "github.com/bufbuild/connect-go"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	usage "github.com/gitpod-io/gitpod/usage-api/v1"
+	"google.golang.org/grpc/status"
+)
+
+func NewUsageService(pool proxy.ServerConnectionPool, usageClient usage.UsageServiceClient) *UsageService {
+	return &UsageService{
+		connectionPool: pool,
+		usageClient:    usageClient,
+	}
+}
+
+var _ v1connect.UsageServiceHandler = (*UsageService)(nil)
+
+type UsageService struct {
+	connectionPool proxy.ServerConnectionPool
+	usageClient    usage.UsageServiceClient
+
+	v1connect.UnimplementedUsageServiceHandler
+}
+
+func (s *UsageService) ExportUsage(ctx context.Context, req *connect.Request[v1.ExportUsageRequest], stream *connect.ServerStream[v1.ExportUsageResponse]) error {
+	teamID, err := validateTeamID(req.Msg.GetTeamId())
+	if err != nil {
+		return err
+	}
+	if req.Msg.GetFrom() == nil || req.Msg.GetTo() == nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("From and To are required."))
+	}
+
+	var format usage.ExportUsageRequest_Format
+	switch req.Msg.GetFormat() {
+	case v1.UsageExportFormat_USAGE_EXPORT_FORMAT_UNSPECIFIED, v1.UsageExportFormat_USAGE_EXPORT_FORMAT_CSV:
+		format = usage.ExportUsageRequest_FORMAT_CSV
+	case v1.UsageExportFormat_USAGE_EXPORT_FORMAT_PARQUET:
+		format = usage.ExportUsageRequest_FORMAT_PARQUET
+	default:
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unsupported export format."))
+	}
+
+	logger := log.WithField("team_id", teamID.String())
+
+	conn, err := getConnection(ctx, s.connectionPool)
+	if err != nil {
+		return err
+	}
+
+	err = requireTeamOwner(ctx, conn, teamID.String())
+	if err != nil {
+		return err
+	}
+
+	export, err := s.usageClient.ExportUsage(ctx, &usage.ExportUsageRequest{
+		AttributionId: string(db.NewTeamAttributionID(teamID.String())),
+		From:          req.Msg.GetFrom(),
+		To:            req.Msg.GetTo(),
+		Format:        format,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to export usage.")
+		return connect.NewError(connect.CodeInternal, errors.New("Failed to export usage."))
+	}
+
+	for {
+		chunk, err := export.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			logger.WithError(err).Error("Failed to receive usage export.")
+			return convertUsageError(err)
+		}
+
+		err = stream.Send(&v1.ExportUsageResponse{
+			Chunk: chunk.GetChunk(),
+		})
+		if err != nil {
+			logger.WithError(err).Error("Failed to stream usage export.")
+			return proxy.ConvertError(err)
+		}
+	}
+}
+
+// requireTeamOwner returns a permission denied error unless the authenticated user owns the team
+func requireTeamOwner(ctx context.Context, conn protocol.APIInterface, teamID string) error {
+	user, err := conn.GetLoggedInUser(ctx)
+	if err != nil {
+		return proxy.ConvertError(err)
+	}
+
+	members, err := conn.GetTeamMembers(ctx, teamID)
+	if err != nil {
+		return proxy.ConvertError(err)
+	}
+
+	for _, member := range members {
+		if member.UserId == user.ID && member.Role == protocol.TeamMember_Owner {
+			return nil
+		}
+	}
+	return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Only team owners can export usage."))
+}
+
+// convertUsageError maps gRPC status errors of the usage service to connect errors
+func convertUsageError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return connect.NewError(connect.CodeInternal, errors.New("Failed to export usage."))
+	}
+	return connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+}
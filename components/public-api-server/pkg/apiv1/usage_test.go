@@ -0,0 +1,155 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
+	usage "github.com/gitpod-io/gitpod/usage-api/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUsageService_ExportUsage(t *testing.T) {
+	var (
+		teamID = uuid.New().String()
+		user   = newUser(&protocol.User{})
+		from   = timestamppb.New(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC))
+		to     = timestamppb.New(time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC))
+	)
+
+	t.Run("returns invalid argument when team ID is invalid", func(t *testing.T) {
+		_, _, client := setupUsageService(t)
+
+		err := exportUsage(t, client, &v1.ExportUsageRequest{TeamId: "foo-bar", From: from, To: to}, nil)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("returns invalid argument when time range is missing", func(t *testing.T) {
+		_, _, client := setupUsageService(t)
+
+		err := exportUsage(t, client, &v1.ExportUsageRequest{TeamId: teamID}, nil)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("returns permission denied when user is not a team owner", func(t *testing.T) {
+		serverMock, _, client := setupUsageService(t)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+		serverMock.EXPECT().GetTeamMembers(gomock.Any(), teamID).Return([]*protocol.TeamMemberInfo{
+			newTeamMember(&protocol.TeamMemberInfo{UserId: user.ID, Role: protocol.TeamMember_Member}),
+		}, nil)
+
+		err := exportUsage(t, client, &v1.ExportUsageRequest{TeamId: teamID, From: from, To: to}, nil)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("streams usage export of the team", func(t *testing.T) {
+		serverMock, usageClient, client := setupUsageService(t)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+		serverMock.EXPECT().GetTeamMembers(gomock.Any(), teamID).Return([]*protocol.TeamMemberInfo{
+			newTeamMember(&protocol.TeamMemberInfo{UserId: user.ID, Role: protocol.TeamMember_Owner}),
+		}, nil)
+		usageClient.chunks = [][]byte{[]byte("project_id,"), []byte("user_id\n")}
+
+		var received []byte
+		err := exportUsage(t, client, &v1.ExportUsageRequest{
+			TeamId: teamID,
+			From:   from,
+			To:     to,
+			Format: v1.UsageExportFormat_USAGE_EXPORT_FORMAT_PARQUET,
+		}, func(chunk []byte) {
+			received = append(received, chunk...)
+		})
+		require.NoError(t, err)
+		require.Equal(t, "project_id,user_id\n", string(received))
+
+		require.Equal(t, "team:"+teamID, usageClient.request.GetAttributionId())
+		require.Equal(t, usage.ExportUsageRequest_FORMAT_PARQUET, usageClient.request.GetFormat())
+		require.True(t, from.AsTime().Equal(usageClient.request.GetFrom().AsTime()))
+		require.True(t, to.AsTime().Equal(usageClient.request.GetTo().AsTime()))
+	})
+}
+
+func exportUsage(t *testing.T, client v1connect.UsageServiceClient, req *v1.ExportUsageRequest, onChunk func([]byte)) error {
+	t.Helper()
+
+	stream, err := client.ExportUsage(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	for stream.Receive() {
+		if onChunk != nil {
+			onChunk(stream.Msg().GetChunk())
+		}
+	}
+	return stream.Err()
+}
+
+type fakeUsageClient struct {
+	usage.UsageServiceClient
+
+	chunks  [][]byte
+	request *usage.ExportUsageRequest
+}
+
+func (c *fakeUsageClient) ExportUsage(ctx context.Context, in *usage.ExportUsageRequest, opts ...grpc.CallOption) (usage.UsageService_ExportUsageClient, error) {
+	c.request = in
+	return &fakeExportUsageClient{chunks: c.chunks}, nil
+}
+
+type fakeExportUsageClient struct {
+	grpc.ClientStream
+
+	chunks [][]byte
+}
+
+func (c *fakeExportUsageClient) Recv() (*usage.ExportUsageResponse, error) {
+	if len(c.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := c.chunks[0]
+	c.chunks = c.chunks[1:]
+	return &usage.ExportUsageResponse{Chunk: chunk}, nil
+}
+
+func setupUsageService(t *testing.T) (*protocol.MockAPIInterface, *fakeUsageClient, v1connect.UsageServiceClient) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	serverMock := protocol.NewMockAPIInterface(ctrl)
+	usageClient := &fakeUsageClient{}
+
+	svc := NewUsageService(&FakeServerConnPool{
+		api: serverMock,
+	}, usageClient)
+
+	_, handler := v1connect.NewUsageServiceHandler(svc, connect.WithInterceptors(auth.NewServerInterceptor()))
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := v1connect.NewUsageServiceClient(http.DefaultClient, srv.URL, connect.WithInterceptors(
+		auth.NewClientInterceptor("auth-token"),
+	))
+
+	return serverMock, usageClient, client
+}
@@ -30,7 +30,10 @@ import (
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/oidc"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/webhooks"
+	usage "github.com/gitpod-io/gitpod/usage-api/v1"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 func Start(logger *logrus.Entry, version string, cfg *config.Configuration) error {
@@ -68,11 +71,21 @@ func Start(logger *logrus.Entry, version string, cfg *config.Configuration) erro
 	}
 
 	var billingService billingservice.Interface = &billingservice.NoOpClient{}
+	var usageClient usage.UsageServiceClient
 	if cfg.BillingServiceAddress != "" {
 		billingService, err = billingservice.New(cfg.BillingServiceAddress)
 		if err != nil {
 			return fmt.Errorf("failed to initialize billing service client: %w", err)
 		}
+
+		// The usage component serves both the billing and the usage service
+		usageConn, err := grpc.Dial(cfg.BillingServiceAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return fmt.Errorf("failed to dial usage service gRPC server: %w", err)
+		}
+		usageClient = usage.NewUsageServiceClient(usageConn)
+	} else {
+		log.Info("No billing service address is configured, Usage service will be disabled.")
 	}
 
 	if cfg.OIDCClientJWTSigningSecretPath != "" {
@@ -118,6 +131,7 @@ func Start(logger *logrus.Entry, version string, cfg *config.Configuration) erro
 		signer:      signer,
 		cipher:      cipherSet,
 		oidcService: oidcService,
+		usageClient: usageClient,
 	}); registerErr != nil {
 		return fmt.Errorf("failed to register services: %w", registerErr)
 	}
@@ -136,6 +150,7 @@ type registerDependencies struct {
 	signer      auth.Signer
 	cipher      db.Cipher
 	oidcService *oidc.Service
+	usageClient usage.UsageServiceClient
 }
 
 func register(srv *baseserver.Server, deps *registerDependencies) error {
@@ -180,6 +195,11 @@ func register(srv *baseserver.Server, deps *registerDependencies) error {
 	oidcRoute, oidcServiceHandler := v1connect.NewOIDCServiceHandler(apiv1.NewOIDCService(deps.connPool, deps.expClient, deps.dbConn, deps.cipher), handlerOptions...)
 	rootHandler.Mount(oidcRoute, oidcServiceHandler)
 
+	if deps.usageClient != nil {
+		usageRoute, usageServiceHandler := v1connect.NewUsageServiceHandler(apiv1.NewUsageService(deps.connPool, deps.usageClient), handlerOptions...)
+		rootHandler.Mount(usageRoute, usageServiceHandler)
+	}
+
 	// OIDC sign-in handlers
 	rootHandler.Mount("/oidc", oidc.Router(deps.oidcService))
 
@@ -0,0 +1,140 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: gitpod/experimental/v1/usage.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// UsageServiceClient is the client API for UsageService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UsageServiceClient interface {
+	// ExportUsage streams the usage of a team within a time range as a CSV or Parquet file.
+	// Usage is grouped by project, user and workspace class.
+	ExportUsage(ctx context.Context, in *ExportUsageRequest, opts ...grpc.CallOption) (UsageService_ExportUsageClient, error)
+}
+
+type usageServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUsageServiceClient(cc grpc.ClientConnInterface) UsageServiceClient {
+	return &usageServiceClient{cc}
+}
+
+func (c *usageServiceClient) ExportUsage(ctx context.Context, in *ExportUsageRequest, opts ...grpc.CallOption) (UsageService_ExportUsageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UsageService_ServiceDesc.Streams[0], "/gitpod.experimental.v1.UsageService/ExportUsage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &usageServiceExportUsageClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UsageService_ExportUsageClient interface {
+	Recv() (*ExportUsageResponse, error)
+	grpc.ClientStream
+}
+
+type usageServiceExportUsageClient struct {
+	grpc.ClientStream
+}
+
+func (x *usageServiceExportUsageClient) Recv() (*ExportUsageResponse, error) {
+	m := new(ExportUsageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UsageServiceServer is the server API for UsageService service.
+// All implementations must embed UnimplementedUsageServiceServer
+// for forward compatibility
+type UsageServiceServer interface {
+	// ExportUsage streams the usage of a team within a time range as a CSV or Parquet file.
+	// Usage is grouped by project, user and workspace class.
+	ExportUsage(*ExportUsageRequest, UsageService_ExportUsageServer) error
+	mustEmbedUnimplementedUsageServiceServer()
+}
+
+// UnimplementedUsageServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedUsageServiceServer struct {
+}
+
+func (UnimplementedUsageServiceServer) ExportUsage(*ExportUsageRequest, UsageService_ExportUsageServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUsage not implemented")
+}
+func (UnimplementedUsageServiceServer) mustEmbedUnimplementedUsageServiceServer() {}
+
+// UnsafeUsageServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UsageServiceServer will
+// result in compilation errors.
+type UnsafeUsageServiceServer interface {
+	mustEmbedUnimplementedUsageServiceServer()
+}
+
+func RegisterUsageServiceServer(s grpc.ServiceRegistrar, srv UsageServiceServer) {
+	s.RegisterService(&UsageService_ServiceDesc, srv)
+}
+
+func _UsageService_ExportUsage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUsageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UsageServiceServer).ExportUsage(m, &usageServiceExportUsageServer{stream})
+}
+
+type UsageService_ExportUsageServer interface {
+	Send(*ExportUsageResponse) error
+	grpc.ServerStream
+}
+
+type usageServiceExportUsageServer struct {
+	grpc.ServerStream
+}
+
+func (x *usageServiceExportUsageServer) Send(m *ExportUsageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// UsageService_ServiceDesc is the grpc.ServiceDesc for UsageService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UsageService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gitpod.experimental.v1.UsageService",
+	HandlerType: (*UsageServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportUsage",
+			Handler:       _UsageService_ExportUsage_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gitpod/experimental/v1/usage.proto",
+}
@@ -0,0 +1,34 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// @generated by protoc-gen-connect-web v0.2.1 with parameter "target=ts"
+// @generated from file gitpod/experimental/v1/usage.proto (package gitpod.experimental.v1, syntax proto3)
+/* eslint-disable */
+/* @ts-nocheck */
+
+import {ExportUsageRequest, ExportUsageResponse} from "./usage_pb.js";
+import {MethodKind} from "@bufbuild/protobuf";
+
+/**
+ * @generated from service gitpod.experimental.v1.UsageService
+ */
+export const UsageService = {
+  typeName: "gitpod.experimental.v1.UsageService",
+  methods: {
+    /**
+     * ExportUsage streams the usage of a team within a time range as a CSV or Parquet file.
+     * Usage is grouped by project, user and workspace class.
+     *
+     * @generated from rpc gitpod.experimental.v1.UsageService.ExportUsage
+     */
+    exportUsage: {
+      name: "ExportUsage",
+      I: ExportUsageRequest,
+      O: ExportUsageResponse,
+      kind: MethodKind.ServerStreaming,
+    },
+  }
+} as const;
@@ -0,0 +1,150 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// @generated by protoc-gen-es v0.1.1 with parameter "target=ts"
+// @generated from file gitpod/experimental/v1/usage.proto (package gitpod.experimental.v1, syntax proto3)
+/* eslint-disable */
+/* @ts-nocheck */
+
+import type {BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage} from "@bufbuild/protobuf";
+import {Message, proto3, Timestamp} from "@bufbuild/protobuf";
+
+/**
+ * @generated from enum gitpod.experimental.v1.UsageExportFormat
+ */
+export enum UsageExportFormat {
+  /**
+   * USAGE_EXPORT_FORMAT_UNSPECIFIED exports usage as CSV.
+   *
+   * @generated from enum value: USAGE_EXPORT_FORMAT_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * USAGE_EXPORT_FORMAT_CSV exports usage as a CSV file with a header row.
+   *
+   * @generated from enum value: USAGE_EXPORT_FORMAT_CSV = 1;
+   */
+  CSV = 1,
+
+  /**
+   * USAGE_EXPORT_FORMAT_PARQUET exports usage as an Apache Parquet file.
+   *
+   * @generated from enum value: USAGE_EXPORT_FORMAT_PARQUET = 2;
+   */
+  PARQUET = 2,
+}
+// Retrieve enum metadata with: proto3.getEnumType(UsageExportFormat)
+proto3.util.setEnumType(UsageExportFormat, "gitpod.experimental.v1.UsageExportFormat", [
+  { no: 0, name: "USAGE_EXPORT_FORMAT_UNSPECIFIED" },
+  { no: 1, name: "USAGE_EXPORT_FORMAT_CSV" },
+  { no: 2, name: "USAGE_EXPORT_FORMAT_PARQUET" },
+]);
+
+/**
+ * @generated from message gitpod.experimental.v1.ExportUsageRequest
+ */
+export class ExportUsageRequest extends Message<ExportUsageRequest> {
+  /**
+   * team_id is the ID of the team whose usage is exported.
+   * Required.
+   *
+   * @generated from field: string team_id = 1;
+   */
+  teamId = "";
+
+  /**
+   * from is the start of the time range, inclusive.
+   * Required.
+   *
+   * @generated from field: google.protobuf.Timestamp from = 2;
+   */
+  from?: Timestamp;
+
+  /**
+   * to is the end of the time range, exclusive.
+   * Required.
+   *
+   * @generated from field: google.protobuf.Timestamp to = 3;
+   */
+  to?: Timestamp;
+
+  /**
+   * format is the file format of the export.
+   *
+   * @generated from field: gitpod.experimental.v1.UsageExportFormat format = 4;
+   */
+  format = UsageExportFormat.UNSPECIFIED;
+
+  constructor(data?: PartialMessage<ExportUsageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ExportUsageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "team_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "from", kind: "message", T: Timestamp },
+    { no: 3, name: "to", kind: "message", T: Timestamp },
+    { no: 4, name: "format", kind: "enum", T: proto3.getEnumType(UsageExportFormat) },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ExportUsageRequest {
+    return new ExportUsageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ExportUsageRequest {
+    return new ExportUsageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ExportUsageRequest {
+    return new ExportUsageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ExportUsageRequest | PlainMessage<ExportUsageRequest> | undefined, b: ExportUsageRequest | PlainMessage<ExportUsageRequest> | undefined): boolean {
+    return proto3.util.equals(ExportUsageRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ExportUsageResponse
+ */
+export class ExportUsageResponse extends Message<ExportUsageResponse> {
+  /**
+   * chunk is the next part of the exported file.
+   *
+   * @generated from field: bytes chunk = 1;
+   */
+  chunk = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<ExportUsageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ExportUsageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "chunk", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ExportUsageResponse {
+    return new ExportUsageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ExportUsageResponse {
+    return new ExportUsageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ExportUsageResponse {
+    return new ExportUsageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ExportUsageResponse | PlainMessage<ExportUsageResponse> | undefined, b: ExportUsageResponse | PlainMessage<ExportUsageResponse> | undefined): boolean {
+    return proto3.util.equals(ExportUsageResponse, a, b);
+  }
+}
@@ -39,6 +39,15 @@ func GetConfig(fn string) (*ServiceConfig, error) {
 		}
 	}
 
+	if dc := cfg.Registry.DiskCache; dc != nil && dc.Enabled {
+		if dc.Path == "" {
+			return nil, xerrors.Errorf("disk cache requires a path")
+		}
+		if dc.MaxSizeBytes <= 0 {
+			return nil, xerrors.Errorf("disk cache requires a positive maxSizeBytes")
+		}
+	}
+
 	if cfg.Registry.RedisCache != nil {
 		rd := cfg.Registry.RedisCache
 		rd.Password = os.Getenv("REDIS_PASSWORD")
@@ -73,6 +82,21 @@ type Config struct {
 	IPFSCache *IPFSCacheConfig `json:"ipfs,omitempty"`
 
 	RedisCache *RedisCacheConfig `json:"redis,omitempty"`
+
+	DiskCache *DiskCacheConfig `json:"diskCache,omitempty"`
+
+	// RPCServer serves the prefetch API if configured
+	RPCServer *RPCServerConfig `json:"rpcServer,omitempty"`
+}
+
+type RPCServerConfig struct {
+	Addr string `json:"addr"`
+	// TLS is required, the RPC server is not started without it
+	TLS *TLS `json:"tls,omitempty"`
+	// MaxPrefetchJobs is the number of prefetches which run at the same time
+	MaxPrefetchJobs int `json:"maxPrefetchJobs,omitempty"`
+	// MaxQueuedPrefetchJobs is the number of prefetches which wait for a running one, further prefetches are rejected
+	MaxQueuedPrefetchJobs int `json:"maxQueuedPrefetchJobs,omitempty"`
 }
 
 type RedisCacheConfig struct {
@@ -87,6 +111,14 @@ type RedisCacheConfig struct {
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
+// DiskCacheConfig configures the node-local blob cache
+type DiskCacheConfig struct {
+	Enabled bool   `json:"enabled"`
+	Path    string `json:"path"`
+	// MaxSizeBytes is the size beyond which the least recently used blobs are evicted
+	MaxSizeBytes int64 `json:"maxSizeBytes"`
+}
+
 type IPFSCacheConfig struct {
 	Enabled  bool   `json:"enabled"`
 	IPFSAddr string `json:"ipfsAddr"`
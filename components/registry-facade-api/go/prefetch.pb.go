@@ -0,0 +1,477 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.20.1
+// source: prefetch.proto
+
+package api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PrefetchBlobState int32
+
+const (
+	PrefetchBlobState_PREFETCH_BLOB_STATE_UNSPECIFIED PrefetchBlobState = 0
+	PrefetchBlobState_PREFETCH_BLOB_STATE_CACHED      PrefetchBlobState = 1
+	PrefetchBlobState_PREFETCH_BLOB_STATE_FETCHED     PrefetchBlobState = 2
+	PrefetchBlobState_PREFETCH_BLOB_STATE_FAILED      PrefetchBlobState = 3
+)
+
+// Enum value maps for PrefetchBlobState.
+var (
+	PrefetchBlobState_name = map[int32]string{
+		0: "PREFETCH_BLOB_STATE_UNSPECIFIED",
+		1: "PREFETCH_BLOB_STATE_CACHED",
+		2: "PREFETCH_BLOB_STATE_FETCHED",
+		3: "PREFETCH_BLOB_STATE_FAILED",
+	}
+	PrefetchBlobState_value = map[string]int32{
+		"PREFETCH_BLOB_STATE_UNSPECIFIED": 0,
+		"PREFETCH_BLOB_STATE_CACHED":      1,
+		"PREFETCH_BLOB_STATE_FETCHED":     2,
+		"PREFETCH_BLOB_STATE_FAILED":      3,
+	}
+)
+
+func (x PrefetchBlobState) Enum() *PrefetchBlobState {
+	p := new(PrefetchBlobState)
+	*p = x
+	return p
+}
+
+func (x PrefetchBlobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PrefetchBlobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_prefetch_proto_enumTypes[0].Descriptor()
+}
+
+func (PrefetchBlobState) Type() protoreflect.EnumType {
+	return &file_prefetch_proto_enumTypes[0]
+}
+
+func (x PrefetchBlobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PrefetchBlobState.Descriptor instead.
+func (PrefetchBlobState) EnumDescriptor() ([]byte, []int) {
+	return file_prefetch_proto_rawDescGZIP(), []int{0}
+}
+
+type PrefetchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// spec prefetches the images of a workspace, i.e. its base, IDE and supervisor images
+	Spec *ImageSpec `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	// refs prefetches the layers of these images, e.g. of a new IDE release
+	Refs []string `protobuf:"bytes,2,rep,name=refs,proto3" json:"refs,omitempty"`
+}
+
+func (x *PrefetchRequest) Reset() {
+	*x = PrefetchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prefetch_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrefetchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefetchRequest) ProtoMessage() {}
+
+func (x *PrefetchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prefetch_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefetchRequest.ProtoReflect.Descriptor instead.
+func (*PrefetchRequest) Descriptor() ([]byte, []int) {
+	return file_prefetch_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PrefetchRequest) GetSpec() *ImageSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *PrefetchRequest) GetRefs() []string {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+type PrefetchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// total_blobs is the number of blobs to prefetch. It is zero until all manifests are resolved.
+	TotalBlobs int32 `protobuf:"varint,1,opt,name=total_blobs,json=totalBlobs,proto3" json:"total_blobs,omitempty"`
+	// cached_blobs is the number of blobs which were cached already
+	CachedBlobs int32 `protobuf:"varint,2,opt,name=cached_blobs,json=cachedBlobs,proto3" json:"cached_blobs,omitempty"`
+	// fetched_blobs is the number of blobs which were fetched into the cache
+	FetchedBlobs int32 `protobuf:"varint,3,opt,name=fetched_blobs,json=fetchedBlobs,proto3" json:"fetched_blobs,omitempty"`
+	// failed_blobs is the number of blobs which could not be fetched
+	FailedBlobs int32 `protobuf:"varint,4,opt,name=failed_blobs,json=failedBlobs,proto3" json:"failed_blobs,omitempty"`
+	// total_bytes is the size of all blobs to prefetch
+	TotalBytes int64 `protobuf:"varint,5,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	// done_bytes is the size of all cached, fetched and failed blobs
+	DoneBytes int64 `protobuf:"varint,6,opt,name=done_bytes,json=doneBytes,proto3" json:"done_bytes,omitempty"`
+	// blob is the blob this update is about, if any
+	Blob *PrefetchBlob `protobuf:"bytes,7,opt,name=blob,proto3" json:"blob,omitempty"`
+	// done is true for the last response
+	Done bool `protobuf:"varint,8,opt,name=done,proto3" json:"done,omitempty"`
+}
+
+func (x *PrefetchResponse) Reset() {
+	*x = PrefetchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prefetch_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrefetchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefetchResponse) ProtoMessage() {}
+
+func (x *PrefetchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prefetch_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefetchResponse.ProtoReflect.Descriptor instead.
+func (*PrefetchResponse) Descriptor() ([]byte, []int) {
+	return file_prefetch_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PrefetchResponse) GetTotalBlobs() int32 {
+	if x != nil {
+		return x.TotalBlobs
+	}
+	return 0
+}
+
+func (x *PrefetchResponse) GetCachedBlobs() int32 {
+	if x != nil {
+		return x.CachedBlobs
+	}
+	return 0
+}
+
+func (x *PrefetchResponse) GetFetchedBlobs() int32 {
+	if x != nil {
+		return x.FetchedBlobs
+	}
+	return 0
+}
+
+func (x *PrefetchResponse) GetFailedBlobs() int32 {
+	if x != nil {
+		return x.FailedBlobs
+	}
+	return 0
+}
+
+func (x *PrefetchResponse) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *PrefetchResponse) GetDoneBytes() int64 {
+	if x != nil {
+		return x.DoneBytes
+	}
+	return 0
+}
+
+func (x *PrefetchResponse) GetBlob() *PrefetchBlob {
+	if x != nil {
+		return x.Blob
+	}
+	return nil
+}
+
+func (x *PrefetchResponse) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+type PrefetchBlob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Digest string            `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	Size   int64             `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	State  PrefetchBlobState `protobuf:"varint,3,opt,name=state,proto3,enum=registryfacade.PrefetchBlobState" json:"state,omitempty"`
+	// error explains why fetching the blob failed
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *PrefetchBlob) Reset() {
+	*x = PrefetchBlob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prefetch_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrefetchBlob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefetchBlob) ProtoMessage() {}
+
+func (x *PrefetchBlob) ProtoReflect() protoreflect.Message {
+	mi := &file_prefetch_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefetchBlob.ProtoReflect.Descriptor instead.
+func (*PrefetchBlob) Descriptor() ([]byte, []int) {
+	return file_prefetch_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PrefetchBlob) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *PrefetchBlob) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *PrefetchBlob) GetState() PrefetchBlobState {
+	if x != nil {
+		return x.State
+	}
+	return PrefetchBlobState_PREFETCH_BLOB_STATE_UNSPECIFIED
+}
+
+func (x *PrefetchBlob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_prefetch_proto protoreflect.FileDescriptor
+
+var file_prefetch_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x70, 0x72, 0x65, 0x66, 0x65, 0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x66, 0x61, 0x63, 0x61, 0x64, 0x65,
+	0x1a, 0x0f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x70, 0x65, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x54, 0x0a, 0x0f, 0x50, 0x72, 0x65, 0x66, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x66, 0x61, 0x63,
+	0x61, 0x64, 0x65, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73,
+	0x70, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x22, 0xa4, 0x02, 0x0a, 0x10, 0x50, 0x72, 0x65, 0x66,
+	0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x62, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x66, 0x65, 0x74, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x62,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x66, 0x65, 0x74, 0x63, 0x68, 0x65, 0x64,
+	0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f,
+	0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6f, 0x6e,
+	0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64,
+	0x6f, 0x6e, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x04, 0x62, 0x6c, 0x6f, 0x62,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x79, 0x66, 0x61, 0x63, 0x61, 0x64, 0x65, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x65, 0x74, 0x63, 0x68,
+	0x42, 0x6c, 0x6f, 0x62, 0x52, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f,
+	0x6e, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x22, 0x89,
+	0x01, 0x0a, 0x0c, 0x50, 0x72, 0x65, 0x66, 0x65, 0x74, 0x63, 0x68, 0x42, 0x6c, 0x6f, 0x62, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x37, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x79, 0x66, 0x61, 0x63, 0x61, 0x64, 0x65, 0x2e, 0x50, 0x72, 0x65, 0x66,
+	0x65, 0x74, 0x63, 0x68, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x2a, 0x99, 0x01, 0x0a, 0x11, 0x50,
+	0x72, 0x65, 0x66, 0x65, 0x74, 0x63, 0x68, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x23, 0x0a, 0x1f, 0x50, 0x52, 0x45, 0x46, 0x45, 0x54, 0x43, 0x48, 0x5f, 0x42, 0x4c, 0x4f,
+	0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x50, 0x52, 0x45, 0x46, 0x45, 0x54, 0x43,
+	0x48, 0x5f, 0x42, 0x4c, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x43, 0x41, 0x43,
+	0x48, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x50, 0x52, 0x45, 0x46, 0x45, 0x54, 0x43,
+	0x48, 0x5f, 0x42, 0x4c, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x46, 0x45, 0x54,
+	0x43, 0x48, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x50, 0x52, 0x45, 0x46, 0x45, 0x54,
+	0x43, 0x48, 0x5f, 0x42, 0x4c, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x46, 0x41,
+	0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x32, 0x5f, 0x0a, 0x0a, 0x50, 0x72, 0x65, 0x66, 0x65, 0x74,
+	0x63, 0x68, 0x65, 0x72, 0x12, 0x51, 0x0a, 0x08, 0x50, 0x72, 0x65, 0x66, 0x65, 0x74, 0x63, 0x68,
+	0x12, 0x1f, 0x2e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x66, 0x61, 0x63, 0x61, 0x64,
+	0x65, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x20, 0x2e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x66, 0x61, 0x63, 0x61,
+	0x64, 0x65, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x69, 0x74, 0x70, 0x6f, 0x64, 0x2d, 0x69, 0x6f, 0x2f,
+	0x67, 0x69, 0x74, 0x70, 0x6f, 0x64, 0x2f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2d,
+	0x66, 0x61, 0x63, 0x61, 0x64, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_prefetch_proto_rawDescOnce sync.Once
+	file_prefetch_proto_rawDescData = file_prefetch_proto_rawDesc
+)
+
+func file_prefetch_proto_rawDescGZIP() []byte {
+	file_prefetch_proto_rawDescOnce.Do(func() {
+		file_prefetch_proto_rawDescData = protoimpl.X.CompressGZIP(file_prefetch_proto_rawDescData)
+	})
+	return file_prefetch_proto_rawDescData
+}
+
+var file_prefetch_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_prefetch_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_prefetch_proto_goTypes = []interface{}{
+	(PrefetchBlobState)(0),   // 0: registryfacade.PrefetchBlobState
+	(*PrefetchRequest)(nil),  // 1: registryfacade.PrefetchRequest
+	(*PrefetchResponse)(nil), // 2: registryfacade.PrefetchResponse
+	(*PrefetchBlob)(nil),     // 3: registryfacade.PrefetchBlob
+	(*ImageSpec)(nil),        // 4: registryfacade.ImageSpec
+}
+var file_prefetch_proto_depIdxs = []int32{
+	4, // 0: registryfacade.PrefetchRequest.spec:type_name -> registryfacade.ImageSpec
+	3, // 1: registryfacade.PrefetchResponse.blob:type_name -> registryfacade.PrefetchBlob
+	0, // 2: registryfacade.PrefetchBlob.state:type_name -> registryfacade.PrefetchBlobState
+	1, // 3: registryfacade.Prefetcher.Prefetch:input_type -> registryfacade.PrefetchRequest
+	2, // 4: registryfacade.Prefetcher.Prefetch:output_type -> registryfacade.PrefetchResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_prefetch_proto_init() }
+func file_prefetch_proto_init() {
+	if File_prefetch_proto != nil {
+		return
+	}
+	file_imagespec_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_prefetch_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrefetchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_prefetch_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrefetchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_prefetch_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrefetchBlob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_prefetch_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_prefetch_proto_goTypes,
+		DependencyIndexes: file_prefetch_proto_depIdxs,
+		EnumInfos:         file_prefetch_proto_enumTypes,
+		MessageInfos:      file_prefetch_proto_msgTypes,
+	}.Build()
+	File_prefetch_proto = out.File
+	file_prefetch_proto_rawDesc = nil
+	file_prefetch_proto_goTypes = nil
+	file_prefetch_proto_depIdxs = nil
+}
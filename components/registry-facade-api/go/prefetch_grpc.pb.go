@@ -0,0 +1,144 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.20.1
+// source: prefetch.proto
+
+package api
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PrefetcherClient is the client API for Prefetcher service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PrefetcherClient interface {
+	// Prefetch warms the blob caches of registry-facade with the layers of images. Blobs which are already
+	// cached are skipped. The progress is streamed until all blobs are cached. Prefetching continues in the
+	// background if the client goes away, i.e. clients which aren't interested in the progress can close the stream
+	// after the first response.
+	Prefetch(ctx context.Context, in *PrefetchRequest, opts ...grpc.CallOption) (Prefetcher_PrefetchClient, error)
+}
+
+type prefetcherClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPrefetcherClient(cc grpc.ClientConnInterface) PrefetcherClient {
+	return &prefetcherClient{cc}
+}
+
+func (c *prefetcherClient) Prefetch(ctx context.Context, in *PrefetchRequest, opts ...grpc.CallOption) (Prefetcher_PrefetchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Prefetcher_ServiceDesc.Streams[0], "/registryfacade.Prefetcher/Prefetch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &prefetcherPrefetchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Prefetcher_PrefetchClient interface {
+	Recv() (*PrefetchResponse, error)
+	grpc.ClientStream
+}
+
+type prefetcherPrefetchClient struct {
+	grpc.ClientStream
+}
+
+func (x *prefetcherPrefetchClient) Recv() (*PrefetchResponse, error) {
+	m := new(PrefetchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PrefetcherServer is the server API for Prefetcher service.
+// All implementations must embed UnimplementedPrefetcherServer
+// for forward compatibility
+type PrefetcherServer interface {
+	// Prefetch warms the blob caches of registry-facade with the layers of images. Blobs which are already
+	// cached are skipped. The progress is streamed until all blobs are cached. Prefetching continues in the
+	// background if the client goes away, i.e. clients which aren't interested in the progress can close the stream
+	// after the first response.
+	Prefetch(*PrefetchRequest, Prefetcher_PrefetchServer) error
+	mustEmbedUnimplementedPrefetcherServer()
+}
+
+// UnimplementedPrefetcherServer must be embedded to have forward compatible implementations.
+type UnimplementedPrefetcherServer struct {
+}
+
+func (UnimplementedPrefetcherServer) Prefetch(*PrefetchRequest, Prefetcher_PrefetchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Prefetch not implemented")
+}
+func (UnimplementedPrefetcherServer) mustEmbedUnimplementedPrefetcherServer() {}
+
+// UnsafePrefetcherServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PrefetcherServer will
+// result in compilation errors.
+type UnsafePrefetcherServer interface {
+	mustEmbedUnimplementedPrefetcherServer()
+}
+
+func RegisterPrefetcherServer(s grpc.ServiceRegistrar, srv PrefetcherServer) {
+	s.RegisterService(&Prefetcher_ServiceDesc, srv)
+}
+
+func _Prefetcher_Prefetch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PrefetchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PrefetcherServer).Prefetch(m, &prefetcherPrefetchServer{stream})
+}
+
+type Prefetcher_PrefetchServer interface {
+	Send(*PrefetchResponse) error
+	grpc.ServerStream
+}
+
+type prefetcherPrefetchServer struct {
+	grpc.ServerStream
+}
+
+func (x *prefetcherPrefetchServer) Send(m *PrefetchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Prefetcher_ServiceDesc is the grpc.ServiceDesc for Prefetcher service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Prefetcher_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "registryfacade.Prefetcher",
+	HandlerType: (*PrefetcherServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Prefetch",
+			Handler:       _Prefetcher_Prefetch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "prefetch.proto",
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	common_grpc "github.com/gitpod-io/gitpod/common-go/grpc"
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+	"github.com/gitpod-io/gitpod/registry-facade/api/config"
+)
+
+var prefetchOpts struct {
+	Config   string
+	Addr     string
+	TLSCA    string
+	TLSCrt   string
+	TLSKey   string
+	Insecure bool
+	Detach   bool
+}
+
+// prefetchCmd warms the blob cache of a registry facade
+var prefetchCmd = &cobra.Command{
+	Use:   "prefetch <ref> [<ref>...]",
+	Short: "Warms the blob cache of a registry facade with the layers of images, e.g. of a new IDE release",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		addr, creds, err := prefetchConnection(cmd)
+		if err != nil {
+			return err
+		}
+		conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		stream, err := api.NewPrefetcherClient(conn).Prefetch(ctx, &api.PrefetchRequest{Refs: args})
+		if err != nil {
+			return err
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if b := resp.Blob; b != nil {
+				fmt.Printf("%s %s (%d bytes) %s\n", prefetchStateName(b.State), b.Digest, b.Size, b.Error)
+			}
+			fmt.Printf("%d/%d blobs, %d/%d bytes\n", resp.CachedBlobs+resp.FetchedBlobs+resp.FailedBlobs, resp.TotalBlobs, resp.DoneBytes, resp.TotalBytes)
+			if prefetchOpts.Detach {
+				fmt.Println("prefetching continues in the background")
+				return nil
+			}
+			if resp.Done && resp.FailedBlobs > 0 {
+				return fmt.Errorf("%d blobs could not be prefetched", resp.FailedBlobs)
+			}
+		}
+	},
+}
+
+// prefetchConnection determines the address and credentials of the RPC server. Unless they're set through flags,
+// they are taken from the RPC server config of the registry facade. The RPC server requires mutual TLS,
+// hence insecure connections must be asked for explicitly.
+func prefetchConnection(cmd *cobra.Command) (addr string, creds credentials.TransportCredentials, err error) {
+	addr = prefetchOpts.Addr
+	ca, crt, key := prefetchOpts.TLSCA, prefetchOpts.TLSCrt, prefetchOpts.TLSKey
+	useConfig := prefetchOpts.Config != ""
+	if useConfig && !cmd.Flags().Changed("config") {
+		// outside of the registry facade pod, there's no config at the default location
+		if _, err := os.Stat(prefetchOpts.Config); os.IsNotExist(err) {
+			useConfig = false
+		}
+	}
+	if useConfig {
+		cfg, err := config.GetConfig(prefetchOpts.Config)
+		if err != nil {
+			return "", nil, fmt.Errorf("cannot load config %s: %w", prefetchOpts.Config, err)
+		}
+		if rpcCfg := cfg.Registry.RPCServer; rpcCfg != nil {
+			if !cmd.Flags().Changed("addr") && rpcCfg.Addr != "" {
+				addr = rpcCfg.Addr
+			}
+			if rpcCfg.TLS != nil {
+				if ca == "" {
+					ca = rpcCfg.TLS.Authority
+				}
+				if crt == "" {
+					crt = rpcCfg.TLS.Certificate
+				}
+				if key == "" {
+					key = rpcCfg.TLS.PrivateKey
+				}
+			}
+		}
+	}
+
+	if prefetchOpts.Insecure {
+		return addr, insecure.NewCredentials(), nil
+	}
+	if ca == "" || crt == "" || key == "" {
+		return "", nil, fmt.Errorf("the RPC server requires TLS: use --config, or --tls-ca, --tls-cert and --tls-key, or --insecure for servers without TLS")
+	}
+	tlsConfig, err := common_grpc.ClientAuthTLSConfig(
+		ca, crt, key,
+		common_grpc.WithSetRootCAs(true),
+		common_grpc.WithServerName("registry-facade"),
+	)
+	if err != nil {
+		return "", nil, err
+	}
+	return addr, credentials.NewTLS(tlsConfig), nil
+}
+
+func prefetchStateName(s api.PrefetchBlobState) string {
+	switch s {
+	case api.PrefetchBlobState_PREFETCH_BLOB_STATE_CACHED:
+		return "cached "
+	case api.PrefetchBlobState_PREFETCH_BLOB_STATE_FETCHED:
+		return "fetched"
+	case api.PrefetchBlobState_PREFETCH_BLOB_STATE_FAILED:
+		return "failed "
+	default:
+		return "unknown"
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(prefetchCmd)
+
+	prefetchCmd.Flags().StringVar(&prefetchOpts.Config, "config", "/mnt/config/config.json", "registry facade config to take the RPC server address and TLS certificates from, empty to not use any")
+	prefetchCmd.Flags().StringVar(&prefetchOpts.Addr, "addr", "localhost:9999", "address of the registry facade RPC server")
+	prefetchCmd.Flags().StringVar(&prefetchOpts.TLSCA, "tls-ca", "", "CA certificate for the RPC connection")
+	prefetchCmd.Flags().StringVar(&prefetchOpts.TLSCrt, "tls-cert", "", "client certificate for the RPC connection")
+	prefetchCmd.Flags().StringVar(&prefetchOpts.TLSKey, "tls-key", "", "client key for the RPC connection")
+	prefetchCmd.Flags().BoolVar(&prefetchOpts.Insecure, "insecure", false, "connect without TLS, e.g. to a registry facade without TLS configured")
+	prefetchCmd.Flags().BoolVar(&prefetchOpts.Detach, "detach", false, "start prefetching and return immediately")
+}
@@ -25,12 +25,15 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	common_grpc "github.com/gitpod-io/gitpod/common-go/grpc"
 	"github.com/gitpod-io/gitpod/common-go/kubernetes"
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/common-go/pprof"
 	"github.com/gitpod-io/gitpod/common-go/watch"
+	"github.com/gitpod-io/gitpod/registry-facade/api"
 	"github.com/gitpod-io/gitpod/registry-facade/api/config"
 	"github.com/gitpod-io/gitpod/registry-facade/pkg/registry"
 )
@@ -180,6 +183,42 @@ var runCmd = &cobra.Command{
 			reg.MustServe()
 		}()
 
+		if rpcCfg := cfg.Registry.RPCServer; rpcCfg != nil && rpcCfg.TLS == nil {
+			// the prefetch API makes registry-facade pull arbitrary images, it must only be reachable by authenticated clients
+			log.WithField("addr", rpcCfg.Addr).Error("no TLS configured - not starting the RPC server")
+		} else if rpcCfg != nil {
+			tlsConfig, err := common_grpc.ClientAuthTLSConfig(
+				rpcCfg.TLS.Authority, rpcCfg.TLS.Certificate, rpcCfg.TLS.PrivateKey,
+				common_grpc.WithSetClientCAs(true),
+				common_grpc.WithServerName("registry-facade"),
+			)
+			if err != nil {
+				log.WithError(err).Fatal("cannot load registry-facade RPC certs")
+			}
+			grpcOpts := common_grpc.ServerOptionsWithInterceptors(nil, nil)
+			grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+
+			prefetcher := reg.Prefetcher()
+			prefetcher.MaxJobs = rpcCfg.MaxPrefetchJobs
+			prefetcher.MaxQueuedJobs = rpcCfg.MaxQueuedPrefetchJobs
+
+			grpcServer := grpc.NewServer(grpcOpts...)
+			defer grpcServer.Stop()
+			api.RegisterPrefetcherServer(grpcServer, prefetcher)
+
+			lis, err := net.Listen("tcp", rpcCfg.Addr)
+			if err != nil {
+				log.WithError(err).WithField("addr", rpcCfg.Addr).Fatal("cannot start RPC server")
+			}
+			go func() {
+				err := grpcServer.Serve(lis)
+				if err != nil {
+					log.WithError(err).Error("RPC server failed")
+				}
+			}()
+			log.WithField("addr", rpcCfg.Addr).Info("started RPC server")
+		}
+
 		log.Info("🏪 registry facade is up and running")
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -34,20 +34,37 @@ import (
 )
 
 func (reg *Registry) handleBlob(ctx context.Context, r *http.Request) http.Handler {
+	blobHandler, err := reg.newBlobHandler(ctx)
+	if err != nil {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			respondWithError(w, err)
+		})
+	}
+
+	mhandler := handlers.MethodHandler{
+		"GET":  http.HandlerFunc(blobHandler.getBlob),
+		"HEAD": http.HandlerFunc(blobHandler.getBlob),
+	}
+	res := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reg.metrics.BlobCounter.Inc()
+		mhandler.ServeHTTP(w, r)
+	})
+
+	return res
+}
+
+// newBlobHandler creates a blob handler for the spec and digest of the request
+func (reg *Registry) newBlobHandler(ctx context.Context) (*blobHandler, error) {
 	spname, name := getSpecProviderName(ctx)
 	sp, ok := reg.SpecProvider[spname]
 	if !ok {
 		log.WithField("specProvName", spname).Error("unknown spec provider")
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			respondWithError(w, distv2.ErrorCodeManifestUnknown)
-		})
+		return nil, distv2.ErrorCodeManifestUnknown
 	}
 	spec, err := sp.GetSpec(ctx, name)
 	if err != nil {
 		log.WithError(err).WithField("specProvName", spname).WithField("name", name).Error("cannot get spec")
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			respondWithError(w, distv2.ErrorCodeManifestUnknown)
-		})
+		return nil, distv2.ErrorCodeManifestUnknown
 	}
 
 	dgst, err := digest.Parse(getDigest(ctx))
@@ -55,33 +72,23 @@ func (reg *Registry) handleBlob(ctx context.Context, r *http.Request) http.Handl
 		log.WithError(err).WithField("instanceId", name).Error("cannot get workspace details")
 	}
 
-	blobHandler := &blobHandler{
+	return &blobHandler{
 		Context: ctx,
 		Digest:  dgst,
 		Name:    name,
 
-		Spec:     spec,
-		Resolver: reg.Resolver(),
-		Store:    reg.Store,
-		IPFS:     reg.IPFS,
+		Spec:      spec,
+		Resolver:  reg.Resolver(),
+		Store:     reg.Store,
+		IPFS:      reg.IPFS,
+		DiskCache: reg.DiskCache,
 		AdditionalSources: []BlobSource{
 			reg.LayerSource,
 		},
 		ConfigModifier: reg.ConfigModifier,
 
 		Metrics: reg.metrics,
-	}
-
-	mhandler := handlers.MethodHandler{
-		"GET":  http.HandlerFunc(blobHandler.getBlob),
-		"HEAD": http.HandlerFunc(blobHandler.getBlob),
-	}
-	res := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		reg.metrics.BlobCounter.Inc()
-		mhandler.ServeHTTP(w, r)
-	})
-
-	return res
+	}, nil
 }
 
 type blobHandler struct {
@@ -93,6 +100,7 @@ type blobHandler struct {
 	Resolver          remotes.Resolver
 	Store             BlobStore
 	IPFS              *IPFSBlobCache
+	DiskCache         *DiskBlobCache
 	AdditionalSources []BlobSource
 	ConfigModifier    ConfigModifier
 
@@ -117,40 +125,9 @@ func (bh *blobHandler) getBlob(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	err := func() error {
-		// TODO: rather than download the same manifest over and over again,
-		//       we should add it to the store and try and fetch it from there.
-		//		 Only if the store fetch fails should we attetmpt to download it.
-		manifest, fetcher, err := bh.downloadManifest(ctx, bh.Spec.BaseRef)
+		src, err := bh.findBlobSource(ctx)
 		if err != nil {
-			return xerrors.Errorf("cannnot fetch the manifest: %w", err)
-		}
-
-		var srcs []BlobSource
-
-		// 1. local store (faster)
-		srcs = append(srcs, storeBlobSource{Store: bh.Store})
-
-		// 2. IPFS (if configured)
-		if bh.IPFS != nil {
-			ipfsSrc := ipfsBlobSource{source: bh.IPFS}
-			srcs = append(srcs, ipfsSrc)
-		}
-
-		// 3. upstream registry
-		srcs = append(srcs, proxyingBlobSource{Fetcher: fetcher, Blobs: manifest.Layers})
-
-		srcs = append(srcs, &configBlobSource{Fetcher: fetcher, Spec: bh.Spec, Manifest: manifest, ConfigModifier: bh.ConfigModifier})
-		srcs = append(srcs, bh.AdditionalSources...)
-
-		var src BlobSource
-		for _, s := range srcs {
-			if s.HasBlob(ctx, bh.Spec, bh.Digest) {
-				src = s
-				break
-			}
-		}
-		if src == nil {
-			return distv2.ErrorCodeBlobUnknown
+			return err
 		}
 
 		t0 := time.Now()
@@ -171,6 +148,20 @@ func (bh *blobHandler) getBlob(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", mediaType)
 		w.Header().Set("Etag", bh.Digest.String())
 
+		// Seekable blobs support ranged requests, so that lazy-pulling snapshotters can fetch
+		// individual files from eStargz layers instead of the whole layer.
+		if rs, ok := rc.(io.ReadSeeker); ok {
+			w.Header().Set("Accept-Ranges", "bytes")
+			if r.Header.Get("Range") != "" {
+				http.ServeContent(w, r, "", time.Time{}, rs)
+				if bh.Metrics != nil {
+					bh.Metrics.BlobRangeCounter.WithLabelValues(src.Name()).Inc()
+				}
+				// we don't cache partial reads - the next full read will do that
+				return nil
+			}
+		}
+
 		bp := bufPool.Get().(*[]byte)
 		defer bufPool.Put(bp)
 
@@ -201,28 +192,23 @@ func (bh *blobHandler) getBlob(w http.ResponseWriter, r *http.Request) {
 			bh.Metrics.BlobDownloadSizeCounter.WithLabelValues(src.Name()).Add(float64(n))
 		}
 
-		if dontCache {
+		// dontCache refers to the shared IPFS cache. The disk cache only serves this registry-facade,
+		// so it's populated with blobs from IPFS, too.
+		_, fromDisk := src.(diskBlobSource)
+		cacheOnDisk := bh.DiskCache != nil && !fromDisk
+		cacheInIPFS := bh.IPFS != nil && !dontCache
+		if !cacheOnDisk && !cacheInIPFS {
 			return nil
 		}
 
 		go func() {
 			// we can do this only after the io.Copy above. Otherwise we might expect the blob
 			// to be in the blobstore when in reality it isn't.
-			_, _, _, rc, err := src.GetBlob(context.Background(), bh.Spec, bh.Digest)
-			if err != nil {
-				log.WithError(err).WithField("digest", bh.Digest).Warn("cannot push to IPFS - unable to get blob")
-				return
-			}
-			if rc == nil {
-				log.WithField("digest", bh.Digest).Warn("cannot push to IPFS - blob is nil")
-				return
+			if cacheOnDisk {
+				bh.cacheBlob(src, "disk cache", bh.DiskCache, mediaType)
 			}
-
-			defer rc.Close()
-
-			err = bh.IPFS.Store(context.Background(), bh.Digest, rc, mediaType)
-			if err != nil {
-				log.WithError(err).WithField("digest", bh.Digest).Warn("cannot push to IPFS")
+			if cacheInIPFS {
+				bh.cacheBlob(src, "IPFS", bh.IPFS, mediaType)
 			}
 		}()
 
@@ -236,6 +222,66 @@ func (bh *blobHandler) getBlob(w http.ResponseWriter, r *http.Request) {
 	tracing.FinishSpan(span, &err)
 }
 
+// cacheBlob reads the blob from its source once more and stores it in the cache
+func (bh *blobHandler) cacheBlob(src BlobSource, name string, cache BlobCache, mediaType string) {
+	_, _, _, rc, err := src.GetBlob(context.Background(), bh.Spec, bh.Digest)
+	if err != nil {
+		log.WithError(err).WithField("digest", bh.Digest).Warnf("cannot push to %s - unable to get blob", name)
+		return
+	}
+	if rc == nil {
+		log.WithField("digest", bh.Digest).Warnf("cannot push to %s - blob is nil", name)
+		return
+	}
+
+	defer rc.Close()
+
+	err = cache.Store(context.Background(), bh.Digest, rc, mediaType)
+	if err != nil {
+		log.WithError(err).WithField("digest", bh.Digest).Warnf("cannot push to %s", name)
+	}
+}
+
+// findBlobSource returns the first source which can provide the blob
+func (bh *blobHandler) findBlobSource(ctx context.Context) (BlobSource, error) {
+	// TODO: rather than download the same manifest over and over again,
+	//       we should add it to the store and try and fetch it from there.
+	//		 Only if the store fetch fails should we attetmpt to download it.
+	manifest, fetcher, err := bh.downloadManifest(ctx, bh.Spec.BaseRef)
+	if err != nil {
+		return nil, xerrors.Errorf("cannnot fetch the manifest: %w", err)
+	}
+
+	var srcs []BlobSource
+
+	// 0. disk cache (if configured)
+	if bh.DiskCache != nil {
+		srcs = append(srcs, diskBlobSource{Cache: bh.DiskCache, Metrics: bh.Metrics})
+	}
+
+	// 1. local store (faster)
+	srcs = append(srcs, storeBlobSource{Store: bh.Store})
+
+	// 2. IPFS (if configured)
+	if bh.IPFS != nil {
+		ipfsSrc := ipfsBlobSource{source: bh.IPFS}
+		srcs = append(srcs, ipfsSrc)
+	}
+
+	// 3. upstream registry
+	srcs = append(srcs, proxyingBlobSource{Fetcher: fetcher, Blobs: manifest.Layers})
+
+	srcs = append(srcs, &configBlobSource{Fetcher: fetcher, Spec: bh.Spec, Manifest: manifest, ConfigModifier: bh.ConfigModifier})
+	srcs = append(srcs, bh.AdditionalSources...)
+
+	for _, s := range srcs {
+		if s.HasBlob(ctx, bh.Spec, bh.Digest) {
+			return s, nil
+		}
+	}
+	return nil, distv2.ErrorCodeBlobUnknown
+}
+
 func (bh *blobHandler) downloadManifest(ctx context.Context, ref string) (res *ociv1.Manifest, fetcher remotes.Fetcher, err error) {
 	_, desc, err := bh.Resolver.Resolve(ctx, ref)
 	if err != nil {
@@ -263,6 +309,23 @@ func (r *reader) Read(b []byte) (n int, err error) {
 	return
 }
 
+func (r *reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.off
+	case io.SeekEnd:
+		offset += r.Size()
+	default:
+		return 0, xerrors.Errorf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, xerrors.Errorf("negative offset %d", offset)
+	}
+	r.off = offset
+	return offset, nil
+}
+
 // BlobSource can provide blobs for download
 type BlobSource interface {
 	// HasBlob checks if a digest can be served by this blob source
@@ -45,6 +45,16 @@ func (store *IPFSBlobCache) Get(ctx context.Context, dgst digest.Digest) (ipfsUR
 	return "ipfs://" + res, nil
 }
 
+// Has returns true if the blob is stored in IPFS
+func (store *IPFSBlobCache) Has(ctx context.Context, dgst digest.Digest) bool {
+	if store == nil || store.IPFS == nil || store.Redis == nil {
+		return false
+	}
+
+	_, err := store.Redis.Get(ctx, dgst.String()).Result()
+	return err == nil
+}
+
 // Store stores a blob in IPFS. Will happily overwrite/re-upload a blob.
 func (store *IPFSBlobCache) Store(ctx context.Context, dgst digest.Digest, content io.Reader, mediaType string) (err error) {
 	if store == nil || store.IPFS == nil || store.Redis == nil {
@@ -76,6 +86,8 @@ func (store *IPFSBlobCache) Store(ctx context.Context, dgst digest.Digest, conte
 	return nil
 }
 
+var _ BlobCache = &IPFSBlobCache{}
+
 type RedisBlobStore struct {
 	Client *redis.Client
 }
@@ -122,6 +134,9 @@ var _ content.ReaderAt = stringReader("")
 func (r stringReader) Size() int64  { return int64(len(r)) }
 func (r stringReader) Close() error { return nil }
 func (r stringReader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off >= int64(len(r)) {
+		return 0, io.EOF
+	}
 	n = copy(p, r[off:])
 	if n < len(p) {
 		return n, io.EOF
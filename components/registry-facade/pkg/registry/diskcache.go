@@ -0,0 +1,525 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+)
+
+const (
+	diskCacheBlobsDir  = "blobs"
+	diskCacheIngestDir = "ingest"
+	diskCacheLabelsExt = ".labels"
+)
+
+// DiskBlobCache caches blobs on the local filesystem. Writes are verified against the blob digest
+// and committed atomically, so that readers never see partial blobs. Once the cache grows beyond
+// its maximum size the least recently used blobs are evicted.
+//
+// DiskBlobCache can be used on its own, or as a node-local cache in front of IPFS.
+type DiskBlobCache struct {
+	Path    string
+	MaxSize int64
+
+	metrics *metrics
+
+	mu    sync.Mutex
+	lru   *list.List
+	index map[digest.Digest]*list.Element
+	size  int64
+}
+
+type diskCacheEntry struct {
+	Digest digest.Digest
+	Size   int64
+}
+
+var (
+	_ BlobStore = &DiskBlobCache{}
+	_ BlobCache = &DiskBlobCache{}
+)
+
+// NewDiskBlobCache creates a new disk cache and indexes the blobs which are already in path
+func NewDiskBlobCache(path string, maxSize int64, metrics *metrics) (*DiskBlobCache, error) {
+	if maxSize <= 0 {
+		return nil, xerrors.Errorf("max size must be positive")
+	}
+
+	res := &DiskBlobCache{
+		Path:    path,
+		MaxSize: maxSize,
+		metrics: metrics,
+		lru:     list.New(),
+		index:   make(map[digest.Digest]*list.Element),
+	}
+
+	// writes which weren't committed before we stopped are useless now
+	err := os.RemoveAll(filepath.Join(path, diskCacheIngestDir))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot clean ingest directory: %w", err)
+	}
+	for _, dir := range []string{diskCacheBlobsDir, diskCacheIngestDir} {
+		err = os.MkdirAll(filepath.Join(path, dir), 0755)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create cache directory: %w", err)
+		}
+	}
+
+	err = res.load()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// load indexes the blobs in the cache directory, using their modification time as last access
+func (c *DiskBlobCache) load() error {
+	type blobFile struct {
+		Entry   diskCacheEntry
+		ModTime time.Time
+	}
+
+	var blobs []blobFile
+	root := filepath.Join(c.Path, diskCacheBlobsDir)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(path, diskCacheLabelsExt) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		segs := strings.Split(rel, string(filepath.Separator))
+		if len(segs) != 2 {
+			return nil
+		}
+		dgst := digest.NewDigestFromEncoded(digest.Algorithm(segs[0]), segs[1])
+		if dgst.Validate() != nil {
+			log.WithField("path", path).Warn("ignoring unknown file in blob cache")
+			return nil
+		}
+
+		stat, err := d.Info()
+		if err != nil {
+			return err
+		}
+		blobs = append(blobs, blobFile{
+			Entry:   diskCacheEntry{Digest: dgst, Size: stat.Size()},
+			ModTime: stat.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return xerrors.Errorf("cannot index blob cache: %w", err)
+	}
+
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].ModTime.After(blobs[j].ModTime) })
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, b := range blobs {
+		c.index[b.Entry.Digest] = c.lru.PushBack(b.Entry)
+		c.size += b.Entry.Size
+	}
+	c.evict()
+	c.reportSize()
+
+	log.WithField("blobs", len(c.index)).WithField("size", c.size).WithField("path", c.Path).Info("indexed blob cache")
+	return nil
+}
+
+func (c *DiskBlobCache) blobPath(dgst digest.Digest) string {
+	return filepath.Join(c.Path, diskCacheBlobsDir, dgst.Algorithm().String(), dgst.Encoded())
+}
+
+// touch marks a blob as recently used
+func (c *DiskBlobCache) touch(dgst digest.Digest) bool {
+	c.mu.Lock()
+	e, ok := c.index[dgst]
+	if ok {
+		c.lru.MoveToFront(e)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	// the modification time preserves the LRU order across restarts. It's updated without holding the lock,
+	// if the blob is evicted in the meantime this fails, which is fine.
+	now := time.Now()
+	_ = os.Chtimes(c.blobPath(dgst), now, now)
+	return true
+}
+
+// add indexes a committed blob and evicts blobs if the cache is full
+func (c *DiskBlobCache) add(entry diskCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.index[entry.Digest]; ok {
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.index[entry.Digest] = c.lru.PushFront(entry)
+	c.size += entry.Size
+	c.evict()
+	c.reportSize()
+}
+
+// evict removes the least recently used blobs until the cache fits its max size. Callers must hold the lock.
+func (c *DiskBlobCache) evict() {
+	for c.size > c.MaxSize {
+		e := c.lru.Back()
+		if e == nil {
+			return
+		}
+		entry := e.Value.(diskCacheEntry)
+		c.lru.Remove(e)
+		delete(c.index, entry.Digest)
+		c.size -= entry.Size
+
+		// open readers keep the file content until they're closed
+		fn := c.blobPath(entry.Digest)
+		err := os.Remove(fn)
+		if err != nil && !os.IsNotExist(err) {
+			log.WithError(err).WithField("digest", entry.Digest).Warn("cannot evict blob from cache")
+		}
+		_ = os.Remove(fn + diskCacheLabelsExt)
+
+		if c.metrics != nil {
+			c.metrics.BlobDiskCacheEvictionCounter.Inc()
+		}
+	}
+}
+
+// reportSize updates the cache size metric. Callers must hold the lock.
+func (c *DiskBlobCache) reportSize() {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.BlobDiskCacheSize.Set(float64(c.size))
+}
+
+// Has returns true if the blob is in the cache
+func (c *DiskBlobCache) Has(ctx context.Context, dgst digest.Digest) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.index[dgst]
+	return ok
+}
+
+// Store adds a blob to the cache
+func (c *DiskBlobCache) Store(ctx context.Context, dgst digest.Digest, blob io.Reader, mediaType string) error {
+	w, err := c.Writer(ctx, content.WithDescriptor(ociv1.Descriptor{Digest: dgst}))
+	if errdefs.IsAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	_, err = io.Copy(w, blob)
+	if err != nil {
+		return err
+	}
+	return w.Commit(ctx, 0, dgst, content.WithLabels(contentTypeLabel(mediaType)))
+}
+
+// Info will return metadata about content available in the content store.
+//
+// If the content is not present, ErrNotFound will be returned.
+func (c *DiskBlobCache) Info(ctx context.Context, dgst digest.Digest) (content.Info, error) {
+	if !c.Has(ctx, dgst) {
+		return content.Info{}, errdefs.ErrNotFound
+	}
+
+	fn := c.blobPath(dgst)
+	stat, err := os.Stat(fn)
+	if os.IsNotExist(err) {
+		return content.Info{}, errdefs.ErrNotFound
+	}
+	if err != nil {
+		return content.Info{}, err
+	}
+
+	var labels map[string]string
+	if fc, err := os.ReadFile(fn + diskCacheLabelsExt); err == nil {
+		err = json.Unmarshal(fc, &labels)
+		if err != nil {
+			return content.Info{}, xerrors.Errorf("cannot unmarshal blob labels: %w", err)
+		}
+	}
+
+	return content.Info{
+		Digest:    dgst,
+		Size:      stat.Size(),
+		UpdatedAt: stat.ModTime(),
+		Labels:    labels,
+	}, nil
+}
+
+// ReaderAt provides access to a cached blob
+func (c *DiskBlobCache) ReaderAt(ctx context.Context, desc ociv1.Descriptor) (content.ReaderAt, error) {
+	if !c.touch(desc.Digest) {
+		return nil, errdefs.ErrNotFound
+	}
+
+	f, err := os.Open(c.blobPath(desc.Digest))
+	if os.IsNotExist(err) {
+		return nil, errdefs.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &fileReaderAt{File: f, size: stat.Size()}, nil
+}
+
+// Writer adds a blob to the cache. The blob digest must be included in opts.
+func (c *DiskBlobCache) Writer(ctx context.Context, opts ...content.WriterOpt) (content.Writer, error) {
+	var wOpts content.WriterOpts
+	for _, opt := range opts {
+		if err := opt(&wOpts); err != nil {
+			return nil, err
+		}
+	}
+	dgst := wOpts.Desc.Digest
+	if dgst == "" {
+		return nil, xerrors.Errorf("desc.digest must not be empty: %w", errdefs.ErrInvalidArgument)
+	}
+	if err := dgst.Validate(); err != nil {
+		return nil, xerrors.Errorf("invalid digest %s: %w", dgst, errdefs.ErrInvalidArgument)
+	}
+	if c.Has(ctx, dgst) {
+		return nil, xerrors.Errorf("blob %s: %w", dgst, errdefs.ErrAlreadyExists)
+	}
+
+	f, err := os.CreateTemp(filepath.Join(c.Path, diskCacheIngestDir), dgst.Encoded()+"-")
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create ingest file: %w", err)
+	}
+	digester := dgst.Algorithm().Digester()
+	return &diskBlobWriter{
+		cache:     c,
+		f:         f,
+		w:         io.MultiWriter(f, digester.Hash()),
+		digester:  digester,
+		desc:      wOpts.Desc,
+		ref:       wOpts.Ref,
+		startedAt: time.Now(),
+	}, nil
+}
+
+type diskBlobWriter struct {
+	cache    *DiskBlobCache
+	f        *os.File
+	w        io.Writer
+	digester digest.Digester
+	desc     ociv1.Descriptor
+	ref      string
+
+	offset    int64
+	startedAt time.Time
+	updatedAt time.Time
+	closed    bool
+}
+
+var _ content.Writer = &diskBlobWriter{}
+
+func (w *diskBlobWriter) Write(b []byte) (n int, err error) {
+	n, err = w.w.Write(b)
+	w.offset += int64(n)
+	w.updatedAt = time.Now()
+	return
+}
+
+// Close aborts the write unless it was committed
+func (w *diskBlobWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	w.f.Close()
+	return os.Remove(w.f.Name())
+}
+
+// Digest returns the digest of the content written so far
+func (w *diskBlobWriter) Digest() digest.Digest {
+	return w.digester.Digest()
+}
+
+// Commit verifies the written content and moves it into the cache.
+// size and expected can be zero-value when unknown.
+// Commit always closes the writer, even on error.
+func (w *diskBlobWriter) Commit(ctx context.Context, size int64, expected digest.Digest, opts ...content.Opt) error {
+	defer w.Close()
+
+	act := w.digester.Digest()
+	if expected == "" {
+		expected = w.desc.Digest
+	}
+	if act != expected || act != w.desc.Digest {
+		return xerrors.Errorf("unexpected commit digest %s, expected %s: %w", act, expected, errdefs.ErrFailedPrecondition)
+	}
+	if size > 0 && size != w.offset {
+		return xerrors.Errorf("unexpected commit size %d, expected %d: %w", w.offset, size, errdefs.ErrFailedPrecondition)
+	}
+
+	var base content.Info
+	for _, opt := range opts {
+		if err := opt(&base); err != nil {
+			return err
+		}
+	}
+
+	err := w.f.Sync()
+	if err != nil {
+		return err
+	}
+	err = w.f.Close()
+	if err != nil {
+		return err
+	}
+
+	fn := w.cache.blobPath(act)
+	err = os.MkdirAll(filepath.Dir(fn), 0755)
+	if err != nil {
+		return err
+	}
+	if len(base.Labels) > 0 {
+		err = writeFileAtomically(fn+diskCacheLabelsExt, base.Labels)
+		if err != nil {
+			return xerrors.Errorf("cannot write blob labels: %w", err)
+		}
+	}
+
+	// The rename is what makes the blob visible. Up until then readers cannot observe a partial write.
+	err = os.Rename(w.f.Name(), fn)
+	if err != nil {
+		return xerrors.Errorf("cannot commit blob: %w", err)
+	}
+	w.closed = true
+
+	w.cache.add(diskCacheEntry{Digest: act, Size: w.offset})
+	return nil
+}
+
+// Status returns the current state of write
+func (w *diskBlobWriter) Status() (content.Status, error) {
+	return content.Status{
+		Ref:       w.ref,
+		Offset:    w.offset,
+		Total:     w.desc.Size,
+		Expected:  w.desc.Digest,
+		StartedAt: w.startedAt,
+		UpdatedAt: w.updatedAt,
+	}, nil
+}
+
+// Truncate updates the size of the target blob
+func (w *diskBlobWriter) Truncate(size int64) error {
+	if size != 0 {
+		return xerrors.Errorf("can only truncate to zero: %w", errdefs.ErrNotImplemented)
+	}
+	_, err := w.f.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	err = w.f.Truncate(0)
+	if err != nil {
+		return err
+	}
+	w.offset = 0
+	w.digester = w.desc.Digest.Algorithm().Digester()
+	w.w = io.MultiWriter(w.f, w.digester.Hash())
+	return nil
+}
+
+func writeFileAtomically(fn string, obj interface{}) error {
+	fc, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	tmp := fn + ".tmp"
+	err = os.WriteFile(tmp, fc, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, fn)
+}
+
+type fileReaderAt struct {
+	*os.File
+	size int64
+}
+
+func (r *fileReaderAt) Size() int64 { return r.size }
+
+const diskBlobSourceName = "disk"
+
+// diskBlobSource serves blobs from the disk cache
+type diskBlobSource struct {
+	Cache   *DiskBlobCache
+	Metrics *metrics
+}
+
+func (dbs diskBlobSource) Name() string {
+	return diskBlobSourceName
+}
+
+func (dbs diskBlobSource) HasBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) bool {
+	ok := dbs.Cache.Has(ctx, dgst)
+	if dbs.Metrics != nil {
+		result := "miss"
+		if ok {
+			result = "hit"
+		}
+		dbs.Metrics.BlobDiskCacheCounter.WithLabelValues(result).Inc()
+	}
+	return ok
+}
+
+func (dbs diskBlobSource) GetBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) (dontCache bool, mediaType string, url string, data io.ReadCloser, err error) {
+	info, err := dbs.Cache.Info(ctx, dgst)
+	if err != nil {
+		return
+	}
+	r, err := dbs.Cache.ReaderAt(ctx, ociv1.Descriptor{Digest: dgst})
+	if err != nil {
+		return
+	}
+
+	// the blob came from a cache - there's no point in caching it again
+	return true, info.Labels["Content-Type"], "", &reader{ReaderAt: r}, nil
+}
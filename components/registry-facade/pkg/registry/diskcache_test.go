@@ -0,0 +1,150 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/google/go-cmp/cmp"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestDiskBlobCacheStore(t *testing.T) {
+	ctx := context.Background()
+	blob := "hello world"
+	dgst := digest.FromString(blob)
+
+	tests := []struct {
+		Name          string
+		Digest        digest.Digest
+		ExpectedError bool
+	}{
+		{Name: "valid", Digest: dgst},
+		{Name: "digest mismatch", Digest: digest.FromString("something else"), ExpectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			path := t.TempDir()
+			cache, err := NewDiskBlobCache(path, 1024, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = cache.Store(ctx, test.Digest, strings.NewReader(blob), "application/foo")
+			if test.ExpectedError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if cache.Has(ctx, test.Digest) {
+					t.Error("cache has blob despite failed write")
+				}
+				if _, err := os.Stat(cache.blobPath(test.Digest)); !os.IsNotExist(err) {
+					t.Error("failed write left blob on disk")
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			ingest, err := os.ReadDir(filepath.Join(path, diskCacheIngestDir))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ingest) != 0 {
+				t.Errorf("expected ingest directory to be empty, found %d files", len(ingest))
+			}
+			if test.ExpectedError {
+				return
+			}
+
+			info, err := cache.Info(ctx, dgst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(contentTypeLabel("application/foo"), info.Labels); diff != "" {
+				t.Errorf("unexpected labels (-want +got):\n%s", diff)
+			}
+
+			r, err := cache.ReaderAt(ctx, ociv1.Descriptor{Digest: dgst})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			act, err := io.ReadAll(&reader{ReaderAt: r})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(blob, string(act)); diff != "" {
+				t.Errorf("unexpected content (-want +got):\n%s", diff)
+			}
+
+			_, err = cache.Writer(ctx, content.WithDescriptor(ociv1.Descriptor{Digest: dgst}))
+			if !errdefs.IsAlreadyExists(err) {
+				t.Errorf("expected already exists error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDiskBlobCacheEviction(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	cache, err := NewDiskBlobCache(path, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blobs := []string{"aaaa", "bbbb", "cccc"}
+	store := func(b string) {
+		err := cache.Store(ctx, digest.FromString(b), strings.NewReader(b), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	cached := func(c *DiskBlobCache) []string {
+		var res []string
+		for _, b := range blobs {
+			if c.Has(ctx, digest.FromString(b)) {
+				res = append(res, b)
+			}
+		}
+		return res
+	}
+
+	store("aaaa")
+	store("bbbb")
+	// reading aaaa makes bbbb the least recently used blob
+	r, err := cache.ReaderAt(ctx, ociv1.Descriptor{Digest: digest.FromString("aaaa")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+	store("cccc")
+
+	if diff := cmp.Diff([]string{"aaaa", "cccc"}, cached(cache)); diff != "" {
+		t.Errorf("unexpected cache content (-want +got):\n%s", diff)
+	}
+	if _, err := os.Stat(cache.blobPath(digest.FromString("bbbb"))); !os.IsNotExist(err) {
+		t.Error("evicted blob is still on disk")
+	}
+
+	reloaded, err := NewDiskBlobCache(path, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"aaaa", "cccc"}, cached(reloaded)); diff != "" {
+		t.Errorf("unexpected cache content after reload (-want +got):\n%s", diff)
+	}
+	if reloaded.size != 8 {
+		t.Errorf("expected size 8 after reload, got %d", reloaded.size)
+	}
+}
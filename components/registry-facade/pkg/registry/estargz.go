@@ -0,0 +1,199 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	distv2 "github.com/docker/distribution/registry/api/v2"
+	"github.com/gorilla/handlers"
+	"github.com/opencontainers/go-digest"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+)
+
+// eStargz layers are regular gzip'ed tar layers which carry a table of contents (TOC) and a footer pointing to it.
+// Lazy-pulling snapshotters (e.g. the stargz-snapshotter) read the TOC first and then fetch the files they need
+// using ranged blob requests. Registry-facade serves eStargz layers as they are in the upstream image, i.e. images
+// have to be built with eStargz compression for workspaces to benefit from lazy pulling.
+const (
+	// estargzFooterSize is the size of the footer of eStargz layers
+	estargzFooterSize = 51
+	// legacyStargzFooterSize is the size of the footer of stargz layers which predate eStargz
+	legacyStargzFooterSize = 47
+	// estargzTOCName is the name of the tar entry which contains the TOC
+	estargzTOCName = "stargz.index.json"
+	// maxEStargzTOCSize limits the size of the TOC we're willing to read
+	maxEStargzTOCSize = 50 * 1024 * 1024
+
+	// EStargzTOCDigestHeader carries the digest of the uncompressed TOC, which snapshotters verify against
+	// the "containerd.io/snapshot/stargz/toc.digest" layer annotation.
+	EStargzTOCDigestHeader = "Gitpod-Estargz-Toc-Digest"
+)
+
+// ErrNotEStargz is returned when a blob is not an eStargz layer
+var ErrNotEStargz = errors.New("not an eStargz layer")
+
+// ReadEStargzTOC reads the table of contents of an eStargz layer.
+func ReadEStargzTOC(r io.ReadSeeker) (toc []byte, err error) {
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tocOffset  int64
+		footerSize int64
+		found      bool
+	)
+	for _, fs := range []int64{estargzFooterSize, legacyStargzFooterSize} {
+		if size < fs {
+			continue
+		}
+		footer := make([]byte, fs)
+		_, err = r.Seek(size-fs, io.SeekStart)
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.ReadFull(r, footer)
+		if err != nil {
+			return nil, err
+		}
+		tocOffset, err = parseEStargzFooter(footer)
+		if err == nil {
+			footerSize, found = fs, true
+			break
+		}
+	}
+	if !found || tocOffset >= size-footerSize {
+		return nil, ErrNotEStargz
+	}
+
+	_, err = r.Seek(tocOffset, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(io.LimitReader(r, size-footerSize-tocOffset))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read TOC: %w", err)
+	}
+	defer zr.Close()
+
+	tr := tar.NewReader(zr)
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read TOC: %w", err)
+	}
+	if hdr.Name != estargzTOCName {
+		return nil, xerrors.Errorf("unexpected TOC entry %s: %w", hdr.Name, ErrNotEStargz)
+	}
+	if hdr.Size > maxEStargzTOCSize {
+		return nil, xerrors.Errorf("TOC is too large (%d bytes)", hdr.Size)
+	}
+	return io.ReadAll(tr)
+}
+
+// parseEStargzFooter returns the offset of the TOC from the footer of an (e)stargz layer.
+// The footer is an empty gzip stream whose extra field contains the TOC offset.
+func parseEStargzFooter(footer []byte) (tocOffset int64, err error) {
+	zr, err := gzip.NewReader(bytes.NewReader(footer))
+	if err != nil {
+		return 0, err
+	}
+	defer zr.Close()
+
+	extra := zr.Header.Extra
+	payloadLen := 16 + len("STARGZ")
+	if len(extra) == 4+payloadLen {
+		// eStargz wraps the payload in a subfield with ID "SG"
+		if extra[0] != 'S' || extra[1] != 'G' || int(binary.LittleEndian.Uint16(extra[2:4])) != payloadLen {
+			return 0, ErrNotEStargz
+		}
+		extra = extra[4:]
+	}
+	if len(extra) != payloadLen || string(extra[16:]) != "STARGZ" {
+		return 0, ErrNotEStargz
+	}
+	tocOffset, err = strconv.ParseInt(string(extra[:16]), 16, 64)
+	if err != nil {
+		return 0, ErrNotEStargz
+	}
+	return tocOffset, nil
+}
+
+func (reg *Registry) handleTOC(ctx context.Context, r *http.Request) http.Handler {
+	blobHandler, err := reg.newBlobHandler(ctx)
+	if err != nil {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			respondWithError(w, err)
+		})
+	}
+
+	return handlers.MethodHandler{
+		"GET":  http.HandlerFunc(blobHandler.getTOC),
+		"HEAD": http.HandlerFunc(blobHandler.getTOC),
+	}
+}
+
+// getTOC serves the table of contents of an eStargz layer
+func (bh *blobHandler) getTOC(w http.ResponseWriter, r *http.Request) {
+	span, ctx := opentracing.StartSpanFromContext(r.Context(), "getTOC")
+
+	err := func() error {
+		src, err := bh.findBlobSource(ctx)
+		if err != nil {
+			return err
+		}
+		_, _, url, rc, err := src.GetBlob(ctx, bh.Spec, bh.Digest)
+		if err != nil {
+			return xerrors.Errorf("cannnot fetch the blob: %w", err)
+		}
+		if rc != nil {
+			defer rc.Close()
+		}
+		rs, ok := rc.(io.ReadSeeker)
+		if url != "" || !ok {
+			return distv2.ErrorCodeBlobUnknown.WithDetail("blob does not support ranged reads")
+		}
+
+		toc, err := ReadEStargzTOC(rs)
+		if errors.Is(err, ErrNotEStargz) {
+			return distv2.ErrorCodeBlobUnknown.WithDetail(ErrNotEStargz.Error())
+		}
+		if err != nil {
+			return err
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", fmt.Sprint(len(toc)))
+		w.Header().Set(EStargzTOCDigestHeader, digest.FromBytes(toc).String())
+		if r.Method == http.MethodHead {
+			return nil
+		}
+		_, err = w.Write(toc)
+		if err != nil {
+			log.WithError(err).WithField("digest", bh.Digest).Debug("cannot write TOC")
+		}
+		return nil
+	}()
+
+	if err != nil {
+		log.WithError(err).WithField("digest", bh.Digest).Error("cannot get TOC")
+		respondWithError(w, err)
+	}
+	tracing.FinishSpan(span, &err)
+}
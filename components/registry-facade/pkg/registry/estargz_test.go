@@ -0,0 +1,103 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadEStargzTOC(t *testing.T) {
+	const toc = `{"version":1,"entries":[{"name":"hello.txt","type":"reg","size":5}]}`
+
+	gzipTar := func(name, content string) []byte {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		_, _ = tw.Write([]byte(content))
+		_ = tw.Close()
+		_ = zw.Close()
+		return buf.Bytes()
+	}
+	footer := func(tocOffset int, legacy bool) []byte {
+		payload := []byte(fmt.Sprintf("%016xSTARGZ", tocOffset))
+		extra := payload
+		if !legacy {
+			extra = append([]byte{'S', 'G', 0, 0}, payload...)
+			binary.LittleEndian.PutUint16(extra[2:4], uint16(len(payload)))
+		}
+		// An empty gzip stream with the extra field, and an empty stored block as written by the eStargz tooling.
+		// We can't use gzip.Writer because depending on the Go version it writes a shorter empty block.
+		res := []byte{0x1f, 0x8b, 8, 4, 0, 0, 0, 0, 0, 255, byte(len(extra)), byte(len(extra) >> 8)}
+		res = append(res, extra...)
+		res = append(res, 1, 0, 0, 0xff, 0xff)
+		return append(res, 0, 0, 0, 0, 0, 0, 0, 0)
+	}
+	estargz := func(legacy bool) []byte {
+		var blob []byte
+		blob = append(blob, gzipTar("hello.txt", "hello")...)
+		tocOffset := len(blob)
+		blob = append(blob, gzipTar(estargzTOCName, toc)...)
+		return append(blob, footer(tocOffset, legacy)...)
+	}
+
+	tests := []struct {
+		Name          string
+		Blob          []byte
+		Expectation   string
+		ExpectedError error
+	}{
+		{
+			Name:        "estargz",
+			Blob:        estargz(false),
+			Expectation: toc,
+		},
+		{
+			Name:        "legacy stargz",
+			Blob:        estargz(true),
+			Expectation: toc,
+		},
+		{
+			Name:          "plain layer",
+			Blob:          gzipTar("hello.txt", "hello"),
+			ExpectedError: ErrNotEStargz,
+		},
+		{
+			Name:          "too short",
+			Blob:          []byte("foo"),
+			ExpectedError: ErrNotEStargz,
+		},
+		{
+			Name:          "wrong TOC entry",
+			Blob:          append(gzipTar("other.json", toc), footer(0, false)...),
+			ExpectedError: ErrNotEStargz,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := ReadEStargzTOC(&reader{ReaderAt: stringReader(test.Blob)})
+			if test.ExpectedError != nil {
+				if !errors.Is(err, test.ExpectedError) {
+					t.Fatalf("expected error %v, got %v", test.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, string(act)); diff != "" {
+				t.Errorf("unexpected TOC (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
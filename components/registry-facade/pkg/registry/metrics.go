@@ -57,6 +57,12 @@ type metrics struct {
 	BlobDownloadSizeCounter *prometheus.CounterVec
 	BlobDownloadCounter     *prometheus.CounterVec
 	BlobDownloadSpeedHist   *prometheus.HistogramVec
+	BlobRangeCounter        *prometheus.CounterVec
+	BlobPrefetchCounter     *prometheus.CounterVec
+
+	BlobDiskCacheCounter         *prometheus.CounterVec
+	BlobDiskCacheEvictionCounter prometheus.Counter
+	BlobDiskCacheSize            prometheus.Gauge
 }
 
 func newMetrics(reg prometheus.Registerer, upstream bool) (*metrics, error) {
@@ -113,11 +119,51 @@ func newMetrics(reg prometheus.Registerer, upstream bool) (*metrics, error) {
 		Help:    "blob download speed in bytes per second",
 		Buckets: prometheus.ExponentialBuckets(1024*1024, 2, 15),
 	}, []string{"blobSource"})
+	blobRangeCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "blob_req_range_total",
+		Help: "number of ranged blob requests",
+	}, []string{"blobSource"})
+	blobPrefetchCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "blob_prefetch_total",
+		Help: "number of prefetched blobs",
+	}, []string{"state"})
+	blobDiskCacheCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "blob_disk_cache_req_total",
+		Help: "number of blob lookups in the disk cache",
+	}, []string{"result"})
+	blobDiskCacheEvictionCounter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "blob_disk_cache_evictions_total",
+		Help: "number of blobs evicted from the disk cache",
+	})
+	blobDiskCacheSize := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "blob_disk_cache_bytes",
+		Help: "size of all blobs in the disk cache",
+	})
 	if upstream {
+		err = reg.Register(blobDiskCacheCounter)
+		if err != nil {
+			return nil, err
+		}
+		err = reg.Register(blobDiskCacheEvictionCounter)
+		if err != nil {
+			return nil, err
+		}
+		err = reg.Register(blobDiskCacheSize)
+		if err != nil {
+			return nil, err
+		}
+		err = reg.Register(blobPrefetchCounter)
+		if err != nil {
+			return nil, err
+		}
 		err = reg.Register(blobDownloadSpeedHist)
 		if err != nil {
 			return nil, err
 		}
+		err = reg.Register(blobRangeCounter)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &metrics{
@@ -127,5 +173,11 @@ func newMetrics(reg prometheus.Registerer, upstream bool) (*metrics, error) {
 		BlobDownloadSpeedHist:   blobDownloadSpeedHist,
 		BlobDownloadSizeCounter: blobDownloadSizeCounter,
 		BlobDownloadCounter:     blobDownloadCounter,
+		BlobRangeCounter:        blobRangeCounter,
+		BlobPrefetchCounter:     blobPrefetchCounter,
+
+		BlobDiskCacheCounter:         blobDiskCacheCounter,
+		BlobDiskCacheEvictionCounter: blobDiskCacheEvictionCounter,
+		BlobDiskCacheSize:            blobDiskCacheSize,
 	}, nil
 }
@@ -0,0 +1,444 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+)
+
+const (
+	// defaultPrefetchConcurrency is the number of blobs a prefetch fetches in parallel
+	defaultPrefetchConcurrency = 4
+	// prefetchBlobTimeout limits the time we spend fetching a single blob
+	prefetchBlobTimeout = 15 * time.Minute
+	// defaultMaxPrefetchJobs is the number of prefetches which run at the same time
+	defaultMaxPrefetchJobs = 4
+	// defaultMaxQueuedPrefetchJobs is the number of prefetches which wait for a running one to finish
+	defaultMaxQueuedPrefetchJobs = 16
+)
+
+// BlobCache is a blob cache which can be warmed ahead of time
+type BlobCache interface {
+	// Has returns true if the blob is cached
+	Has(ctx context.Context, dgst digest.Digest) bool
+	// Store adds a blob to the cache
+	Store(ctx context.Context, dgst digest.Digest, content io.Reader, mediaType string) error
+}
+
+// Prefetcher warms the blob cache with the layers of images
+type Prefetcher struct {
+	api.UnimplementedPrefetcherServer
+
+	Resolver ResolverProvider
+	Store    BlobStore
+	Cache    BlobCache
+	// Concurrency is the number of blobs a prefetch fetches in parallel
+	Concurrency int
+	// MaxJobs is the number of prefetches which run at the same time.
+	// Prefetches continue in the background when their client goes away, so they count until they finish.
+	MaxJobs int
+	// MaxQueuedJobs is the number of prefetches which wait for a running one to finish,
+	// further prefetches are rejected
+	MaxQueuedJobs int
+
+	Metrics *metrics
+
+	mu       sync.Mutex
+	inflight map[digest.Digest]*prefetchFlight
+	jobsOnce sync.Once
+	running  chan struct{}
+	queued   int
+}
+
+// prefetchFlight is a blob which is currently being fetched
+type prefetchFlight struct {
+	done chan struct{}
+	err  error
+}
+
+// prefetchBlob is a blob and the fetcher which can download it
+type prefetchBlob struct {
+	Desc    ociv1.Descriptor
+	Fetcher remotes.Fetcher
+}
+
+// Prefetcher produces a prefetcher which warms the blob cache of this registry
+func (reg *Registry) Prefetcher() *Prefetcher {
+	res := &Prefetcher{
+		Resolver: reg.Resolver,
+		Store:    reg.Store,
+		Metrics:  reg.metrics,
+	}
+	// IPFS is shared by all registry-facades, whereas the disk cache only helps this one
+	if reg.IPFS != nil {
+		res.Cache = reg.IPFS
+	} else if reg.DiskCache != nil {
+		res.Cache = reg.DiskCache
+	}
+	return res
+}
+
+// Prefetch warms the blob cache with the layers of images
+func (p *Prefetcher) Prefetch(req *api.PrefetchRequest, srv api.Prefetcher_PrefetchServer) error {
+	refs := prefetchRefs(req)
+	if len(refs) == 0 {
+		return status.Error(codes.InvalidArgument, "either spec or refs are required")
+	}
+	if p.Cache == nil {
+		return status.Error(codes.FailedPrecondition, "no blob cache configured")
+	}
+
+	release, err := p.acquireJob(srv.Context())
+	if err != nil {
+		return err
+	}
+
+	blobs, err := p.resolveBlobs(srv.Context(), refs)
+	if err != nil {
+		release()
+		return status.Errorf(codes.NotFound, "cannot resolve images: %v", err)
+	}
+
+	job := newPrefetchJob(blobs)
+	// the prefetch continues in the background if the client goes away
+	go func() {
+		defer release()
+		p.run(context.Background(), job, blobs)
+	}()
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			job.Detach()
+			log.WithField("refs", refs).Debug("client detached from prefetch")
+			return nil
+		case <-job.notify:
+		}
+
+		updates, done := job.Updates()
+		for _, u := range updates {
+			err := srv.Send(u)
+			if err != nil {
+				job.Detach()
+				return err
+			}
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// acquireJob waits until fewer than MaxJobs prefetches are running and returns a function
+// which must be called once the prefetch is done
+func (p *Prefetcher) acquireJob(ctx context.Context) (release func(), err error) {
+	p.jobsOnce.Do(func() {
+		maxJobs := p.MaxJobs
+		if maxJobs <= 0 {
+			maxJobs = defaultMaxPrefetchJobs
+		}
+		p.running = make(chan struct{}, maxJobs)
+	})
+	release = func() { <-p.running }
+
+	select {
+	case p.running <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	maxQueued := p.MaxQueuedJobs
+	if maxQueued <= 0 {
+		maxQueued = defaultMaxQueuedPrefetchJobs
+	}
+	p.mu.Lock()
+	if p.queued >= maxQueued {
+		p.mu.Unlock()
+		return nil, status.Error(codes.ResourceExhausted, "too many prefetches, try again later")
+	}
+	p.queued++
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		p.queued--
+		p.mu.Unlock()
+	}()
+
+	select {
+	case p.running <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, status.Error(codes.Canceled, "prefetch canceled while queued")
+	}
+}
+
+// prefetchRefs returns the image references a prefetch request asks for
+func prefetchRefs(req *api.PrefetchRequest) []string {
+	var res []string
+	if spec := req.Spec; spec != nil {
+		res = append(res, spec.BaseRef, spec.IdeRef, spec.SupervisorRef)
+		res = append(res, spec.IdeLayerRef...)
+	}
+	res = append(res, req.Refs...)
+
+	var (
+		idx  = make(map[string]struct{}, len(res))
+		refs = make([]string, 0, len(res))
+	)
+	for _, ref := range res {
+		if ref == "" {
+			continue
+		}
+		if _, exists := idx[ref]; exists {
+			continue
+		}
+		idx[ref] = struct{}{}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// resolveBlobs downloads the manifests of the images and returns their layers
+func (p *Prefetcher) resolveBlobs(ctx context.Context, refs []string) ([]prefetchBlob, error) {
+	var (
+		res []prefetchBlob
+		idx = make(map[digest.Digest]struct{})
+	)
+	for _, ref := range refs {
+		resolver := p.Resolver()
+		name, desc, err := resolver.Resolve(ctx, ref)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot resolve %s: %w", ref, err)
+		}
+		fetcher, err := resolver.Fetcher(ctx, name)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot get fetcher for %s: %w", ref, err)
+		}
+		manifest, _, err := DownloadManifest(ctx, AsFetcherFunc(fetcher), desc, WithStore(p.Store))
+		if err != nil {
+			return nil, xerrors.Errorf("cannot download manifest of %s: %w", ref, err)
+		}
+		for _, l := range manifest.Layers {
+			if _, exists := idx[l.Digest]; exists {
+				continue
+			}
+			idx[l.Digest] = struct{}{}
+			res = append(res, prefetchBlob{Desc: l, Fetcher: fetcher})
+		}
+	}
+	return res, nil
+}
+
+func (p *Prefetcher) run(ctx context.Context, job *prefetchJob, blobs []prefetchBlob) {
+	concurrency := p.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultPrefetchConcurrency
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+	for _, b := range blobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(b prefetchBlob) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			state, err := p.fetch(ctx, b)
+			if err != nil {
+				log.WithError(err).WithField("digest", b.Desc.Digest).Warn("cannot prefetch blob")
+			}
+			if p.Metrics != nil {
+				p.Metrics.BlobPrefetchCounter.WithLabelValues(prefetchBlobStateLabel(state)).Inc()
+			}
+			job.BlobDone(b.Desc, state, err)
+		}(b)
+	}
+	wg.Wait()
+	job.Finish()
+}
+
+// fetch adds a blob to the cache unless it's cached already
+func (p *Prefetcher) fetch(ctx context.Context, b prefetchBlob) (api.PrefetchBlobState, error) {
+	ctx, cancel := context.WithTimeout(ctx, prefetchBlobTimeout)
+	defer cancel()
+
+	dgst := b.Desc.Digest
+	if p.Cache.Has(ctx, dgst) {
+		return api.PrefetchBlobState_PREFETCH_BLOB_STATE_CACHED, nil
+	}
+
+	p.mu.Lock()
+	if p.inflight == nil {
+		p.inflight = make(map[digest.Digest]*prefetchFlight)
+	}
+	flight, inflight := p.inflight[dgst]
+	if !inflight {
+		flight = &prefetchFlight{done: make(chan struct{})}
+		p.inflight[dgst] = flight
+	}
+	p.mu.Unlock()
+
+	if inflight {
+		// another prefetch is fetching this blob already
+		select {
+		case <-flight.done:
+		case <-ctx.Done():
+			return api.PrefetchBlobState_PREFETCH_BLOB_STATE_FAILED, ctx.Err()
+		}
+		if flight.err != nil {
+			return api.PrefetchBlobState_PREFETCH_BLOB_STATE_FAILED, flight.err
+		}
+		return api.PrefetchBlobState_PREFETCH_BLOB_STATE_CACHED, nil
+	}
+
+	err := func() error {
+		rc, err := b.Fetcher.Fetch(ctx, b.Desc)
+		if err != nil {
+			return xerrors.Errorf("cannot fetch blob: %w", err)
+		}
+		defer rc.Close()
+
+		err = p.Cache.Store(ctx, dgst, rc, b.Desc.MediaType)
+		if err != nil {
+			return xerrors.Errorf("cannot cache blob: %w", err)
+		}
+		return nil
+	}()
+
+	p.mu.Lock()
+	flight.err = err
+	delete(p.inflight, dgst)
+	p.mu.Unlock()
+	close(flight.done)
+
+	if err != nil {
+		return api.PrefetchBlobState_PREFETCH_BLOB_STATE_FAILED, err
+	}
+	return api.PrefetchBlobState_PREFETCH_BLOB_STATE_FETCHED, nil
+}
+
+func prefetchBlobStateLabel(s api.PrefetchBlobState) string {
+	switch s {
+	case api.PrefetchBlobState_PREFETCH_BLOB_STATE_CACHED:
+		return "cached"
+	case api.PrefetchBlobState_PREFETCH_BLOB_STATE_FETCHED:
+		return "fetched"
+	default:
+		return "failed"
+	}
+}
+
+// prefetchJob tracks the progress of a prefetch and queues the updates for the client
+type prefetchJob struct {
+	notify chan struct{}
+
+	mu       sync.Mutex
+	progress api.PrefetchResponse
+	updates  []*api.PrefetchResponse
+	detached bool
+	done     bool
+}
+
+func newPrefetchJob(blobs []prefetchBlob) *prefetchJob {
+	res := &prefetchJob{
+		notify: make(chan struct{}, 1),
+	}
+	res.progress.TotalBlobs = int32(len(blobs))
+	for _, b := range blobs {
+		res.progress.TotalBytes += b.Desc.Size
+	}
+	res.push(nil)
+	return res
+}
+
+// BlobDone records the outcome of fetching a blob
+func (j *prefetchJob) BlobDone(desc ociv1.Descriptor, state api.PrefetchBlobState, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	switch state {
+	case api.PrefetchBlobState_PREFETCH_BLOB_STATE_CACHED:
+		j.progress.CachedBlobs++
+	case api.PrefetchBlobState_PREFETCH_BLOB_STATE_FETCHED:
+		j.progress.FetchedBlobs++
+	default:
+		j.progress.FailedBlobs++
+	}
+	j.progress.DoneBytes += desc.Size
+
+	blob := &api.PrefetchBlob{
+		Digest: desc.Digest.String(),
+		Size:   desc.Size,
+		State:  state,
+	}
+	if err != nil {
+		blob.Error = err.Error()
+	}
+	j.push(blob)
+}
+
+// Finish marks the prefetch as done
+func (j *prefetchJob) Finish() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.done = true
+	j.push(nil)
+}
+
+// Detach stops queueing updates because no one listens anymore
+func (j *prefetchJob) Detach() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.detached = true
+	j.updates = nil
+}
+
+// Updates returns the queued updates and whether the prefetch is done
+func (j *prefetchJob) Updates() (updates []*api.PrefetchResponse, done bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	updates, j.updates = j.updates, nil
+	return updates, j.done
+}
+
+// push queues an update with the current progress. Callers must hold the lock.
+func (j *prefetchJob) push(blob *api.PrefetchBlob) {
+	if j.detached {
+		return
+	}
+	j.updates = append(j.updates, &api.PrefetchResponse{
+		TotalBlobs:   j.progress.TotalBlobs,
+		CachedBlobs:  j.progress.CachedBlobs,
+		FetchedBlobs: j.progress.FetchedBlobs,
+		FailedBlobs:  j.progress.FailedBlobs,
+		TotalBytes:   j.progress.TotalBytes,
+		DoneBytes:    j.progress.DoneBytes,
+		Blob:         blob,
+		Done:         j.done,
+	})
+	select {
+	case j.notify <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/google/go-cmp/cmp"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+)
+
+type memoryBlobCache struct {
+	mu    sync.Mutex
+	blobs map[digest.Digest][]byte
+}
+
+func (c *memoryBlobCache) Has(ctx context.Context, dgst digest.Digest) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.blobs[dgst]
+	return ok
+}
+
+func (c *memoryBlobCache) Store(ctx context.Context, dgst digest.Digest, content io.Reader, mediaType string) error {
+	b, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blobs[dgst] = b
+	return nil
+}
+
+type blobFetcher map[digest.Digest][]byte
+
+func (f blobFetcher) Fetch(ctx context.Context, desc ociv1.Descriptor) (io.ReadCloser, error) {
+	b, ok := f[desc.Digest]
+	if !ok {
+		return nil, errdefs.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func TestPrefetcherRun(t *testing.T) {
+	var (
+		cached  = []byte("cached")
+		fetched = []byte("fetched")
+		missing = []byte("missing")
+	)
+	desc := func(b []byte) ociv1.Descriptor {
+		return ociv1.Descriptor{Digest: digest.FromBytes(b), Size: int64(len(b))}
+	}
+	fetcher := blobFetcher{
+		digest.FromBytes(cached):  cached,
+		digest.FromBytes(fetched): fetched,
+	}
+	cache := &memoryBlobCache{blobs: map[digest.Digest][]byte{
+		digest.FromBytes(cached): cached,
+	}}
+	blobs := []prefetchBlob{
+		{Desc: desc(cached), Fetcher: fetcher},
+		{Desc: desc(fetched), Fetcher: fetcher},
+		{Desc: desc(missing), Fetcher: fetcher},
+	}
+
+	p := &Prefetcher{Cache: cache, Concurrency: 1}
+	job := newPrefetchJob(blobs)
+	p.run(context.Background(), job, blobs)
+
+	updates, done := job.Updates()
+	if !done {
+		t.Fatal("expected prefetch to be done")
+	}
+	total := int64(len(cached) + len(fetched) + len(missing))
+	expected := []*api.PrefetchResponse{
+		{TotalBlobs: 3, TotalBytes: total},
+		{TotalBlobs: 3, TotalBytes: total, CachedBlobs: 1, DoneBytes: 6, Blob: &api.PrefetchBlob{Digest: desc(cached).Digest.String(), Size: 6, State: api.PrefetchBlobState_PREFETCH_BLOB_STATE_CACHED}},
+		{TotalBlobs: 3, TotalBytes: total, CachedBlobs: 1, FetchedBlobs: 1, DoneBytes: 13, Blob: &api.PrefetchBlob{Digest: desc(fetched).Digest.String(), Size: 7, State: api.PrefetchBlobState_PREFETCH_BLOB_STATE_FETCHED}},
+		{TotalBlobs: 3, TotalBytes: total, CachedBlobs: 1, FetchedBlobs: 1, FailedBlobs: 1, DoneBytes: 20, Blob: &api.PrefetchBlob{Digest: desc(missing).Digest.String(), Size: 7, State: api.PrefetchBlobState_PREFETCH_BLOB_STATE_FAILED}},
+		{TotalBlobs: 3, TotalBytes: total, CachedBlobs: 1, FetchedBlobs: 1, FailedBlobs: 1, DoneBytes: 20, Done: true},
+	}
+	if diff := cmp.Diff(expected, updates, protocmp.Transform(), protocmp.IgnoreFields(&api.PrefetchBlob{}, "error")); diff != "" {
+		t.Errorf("unexpected updates (-want +got):\n%s", diff)
+	}
+	if !cache.Has(context.Background(), digest.FromBytes(fetched)) {
+		t.Errorf("expected fetched blob to be cached")
+	}
+}
+
+func TestPrefetchRefs(t *testing.T) {
+	act := prefetchRefs(&api.PrefetchRequest{
+		Spec: &api.ImageSpec{
+			BaseRef:       "base",
+			IdeRef:        "ide",
+			SupervisorRef: "supervisor",
+			IdeLayerRef:   []string{"ide-layer", "ide"},
+		},
+		Refs: []string{"other", "base"},
+	})
+	if diff := cmp.Diff([]string{"base", "ide", "supervisor", "ide-layer", "other"}, act); diff != "" {
+		t.Errorf("unexpected refs (-want +got):\n%s", diff)
+	}
+}
+
+func TestPrefetcherAcquireJob(t *testing.T) {
+	queuedJobs := func(p *Prefetcher) int {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.queued
+	}
+
+	p := &Prefetcher{MaxJobs: 1, MaxQueuedJobs: 1}
+	release, err := p.acquireJob(context.Background())
+	if err != nil {
+		t.Fatalf("cannot acquire first job: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queued := make(chan error, 1)
+	go func() {
+		release, err := p.acquireJob(ctx)
+		if err == nil {
+			release()
+		}
+		queued <- err
+	}()
+	for queuedJobs(p) != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	_, err = p.acquireJob(context.Background())
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected a full queue to be rejected, got %v", err)
+	}
+
+	cancel()
+	if err := <-queued; status.Code(err) != codes.Canceled {
+		t.Errorf("expected a canceled prefetch to leave the queue, got %v", err)
+	}
+	if n := queuedJobs(p); n != 0 {
+		t.Errorf("expected empty queue, got %d queued jobs", n)
+	}
+
+	release()
+	release, err = p.acquireJob(context.Background())
+	if err != nil {
+		t.Errorf("expected a job to run once a slot is free, got %v", err)
+	} else {
+		release()
+	}
+}
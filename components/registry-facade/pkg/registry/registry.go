@@ -33,6 +33,7 @@ import (
 	"github.com/gorilla/mux"
 	httpapi "github.com/ipfs/go-ipfs-http-client"
 	ma "github.com/multiformats/go-multiaddr"
+	"github.com/opencontainers/go-digest"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/xerrors"
 	"google.golang.org/grpc"
@@ -73,6 +74,7 @@ type Registry struct {
 	Resolver       ResolverProvider
 	Store          BlobStore
 	IPFS           *IPFSBlobCache
+	DiskCache      *DiskBlobCache
 	LayerSource    LayerSource
 	ConfigModifier ConfigModifier
 	SpecProvider   map[string]ImageSpecProvider
@@ -239,12 +241,22 @@ func NewRegistry(cfg config.Config, newResolver ResolverProvider, reg prometheus
 		log.WithField("config", cfg.IPFSCache).Info("enabling IPFS caching")
 	}
 
+	var diskCache *DiskBlobCache
+	if cfg.DiskCache != nil && cfg.DiskCache.Enabled {
+		diskCache, err = NewDiskBlobCache(cfg.DiskCache.Path, cfg.DiskCache.MaxSizeBytes, metrics)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create disk cache: %w", err)
+		}
+		log.WithField("config", cfg.DiskCache).Info("enabling disk caching")
+	}
+
 	layerSource := CompositeLayerSource(layerSources)
 	return &Registry{
 		Config:            cfg,
 		Resolver:          newResolver,
 		Store:             mfStore,
 		IPFS:              ipfs,
+		DiskCache:         diskCache,
 		SpecProvider:      specProvider,
 		LayerSource:       layerSource,
 		staticLayerSource: staticLayer,
@@ -392,6 +404,8 @@ func (reg *Registry) registerHandler(routes *mux.Router) {
 	// routes.Get(v2.RouteNameCatalog).Handler(dispatcher(reg.handleCatalog))
 	// routes.Get(v2.RouteNameTags).Handler(dispatcher(reg.handleTags))
 	routes.Get(distv2.RouteNameBlob).Handler(dispatcher(reg.handleBlob))
+	// not part of the distribution API: serves the TOC of eStargz layers for lazy-pulling snapshotters
+	routes.Path(reg.Config.Prefix + "/v2/{name:" + reference.NameRegexp.String() + "}/blobs/{digest:" + digest.DigestRegexp.String() + "}/toc").Handler(dispatcher(reg.handleTOC))
 	// routes.Get(v2.RouteNameBlobUpload).Handler(dispatcher(reg.handleBlobUpload))
 	// routes.Get(v2.RouteNameBlobUploadChunk).Handler(dispatcher(reg.handleBlobUploadChunk))
 	routes.NotFoundHandler = http.HandlerFunc(reg.handleAPIBase)
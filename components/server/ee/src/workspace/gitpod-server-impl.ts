@@ -75,6 +75,7 @@ import {
     CostCenter,
     CostCenter_BillingStrategy,
     ListUsageRequest_Ordering,
+    NestedSpendingLimit_Scope,
     UsageServiceClient,
     Usage_Kind,
 } from "@gitpod/usage-api/lib/usage/v1/usage.pb";
@@ -88,7 +89,7 @@ import {
 import { Plans } from "@gitpod/gitpod-protocol/lib/plans";
 import * as pThrottle from "p-throttle";
 import { formatDate } from "@gitpod/gitpod-protocol/lib/util/date-time";
-import { FindUserByIdentityStrResult, UserService } from "../../../src/user/user-service";
+import { FindUserByIdentityStrResult, UsageLimitReachedResult, UserService } from "../../../src/user/user-service";
 import {
     AccountService,
     SubscriptionService,
@@ -2348,7 +2349,21 @@ export class GitpodServerEEImpl extends GitpodServerImpl {
         try {
             const billingMode = await this.billingModes.getBillingModeForUser(user, new Date());
             if (billingMode.mode === "usage-based") {
-                const limit = await this.userService.checkUsageLimitReached(user);
+                // nested spending limits depend on the project of a workspace, so check the running workspaces first
+                const running = await this.workspaceDb
+                    .trace(ctx)
+                    .findRunningInstancesWithWorkspaces(undefined, user.id);
+                let limit: UsageLimitReachedResult | undefined;
+                for (const { workspace } of running) {
+                    const workspaceLimit = await this.userService.checkUsageLimitReached(user, workspace);
+                    if (workspaceLimit.reached) {
+                        limit = workspaceLimit;
+                        break;
+                    }
+                }
+                if (!limit) {
+                    limit = await this.userService.checkUsageLimitReached(user);
+                }
                 await this.guardCostCenterAccess(ctx, user.id, limit.attributionId, "get");
 
                 switch (limit.attributionId.kind) {
@@ -2363,7 +2378,16 @@ export class GitpodServerEEImpl extends GitpodServerImpl {
                     case "team": {
                         const teamOrUser = await this.teamDB.findTeamById(limit.attributionId.teamId);
                         if (teamOrUser) {
-                            if (limit.reached) {
+                            if (limit.nestedSpendingLimit) {
+                                const scope =
+                                    limit.nestedSpendingLimit.scope === NestedSpendingLimit_Scope.SCOPE_USER
+                                        ? "your"
+                                        : "a project's";
+                                result.push(teamOrUser?.name);
+                                result.unshift(
+                                    `You have reached ${scope} spending limit in team '${teamOrUser?.name}'.`,
+                                );
+                            } else if (limit.reached) {
                                 result.push(teamOrUser?.name);
                                 result.unshift(`Your team '${teamOrUser?.name}' has reached its usage limit.`);
                             } else if (limit.almostReached) {
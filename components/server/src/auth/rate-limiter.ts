@@ -78,11 +78,13 @@ const defaultFunctions: FunctionsConfig = {
     watchWorkspaceImageBuildLogs: { group: "default", points: 1 },
     isPrebuildDone: { group: "default", points: 1 },
     getHeadlessLog: { group: "default", points: 1 },
+    getSidecarLogs: { group: "default", points: 1 },
     setWorkspaceTimeout: { group: "default", points: 1 },
     getWorkspaceTimeout: { group: "default", points: 1 },
     getOpenPorts: { group: "default", points: 1 },
     openPort: { group: "default", points: 1 },
     closePort: { group: "default", points: 1 },
+    sharePort: { group: "default", points: 1 },
     getUserStorageResource: { group: "default", points: 1 },
     updateUserStorageResource: { group: "default", points: 1 },
     getEnvVars: { group: "default", points: 1 },
@@ -0,0 +1,95 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+import { injectable, inject } from "inversify";
+import * as opentracing from "opentracing";
+import { DBWithTracing, TracedWorkspaceDB, UserDB, WorkspaceDB } from "@gitpod/gitpod-db/lib";
+import { Disposable, RunningWorkspaceInfo } from "@gitpod/gitpod-protocol";
+import { AttributionId } from "@gitpod/gitpod-protocol/lib/attribution";
+import { TraceContext } from "@gitpod/gitpod-protocol/lib/util/tracing";
+import { log } from "@gitpod/gitpod-protocol/lib/util/logging";
+import { repeat } from "@gitpod/gitpod-protocol/lib/util/repeat";
+import { NestedSpendingLimit_Scope } from "@gitpod/usage-api/lib/usage/v1/usage.pb";
+import { StopWorkspacePolicy } from "@gitpod/ws-manager/lib/core_pb";
+import { ConsensusLeaderQorum } from "../consensus/consensus-leader-quorum";
+import { UserService } from "../user/user-service";
+import { WorkspaceStarter } from "../workspace/workspace-starter";
+
+/**
+ * The SpendingLimitEnforcer stops running workspaces of projects and users which reached
+ * the spending limit their team set for them.
+ */
+@injectable()
+export class SpendingLimitEnforcer {
+    static readonly CHECK_INTERVAL_SECONDS = 5 * 60; // every 5 minutes
+
+    @inject(ConsensusLeaderQorum) protected readonly leaderQuorum: ConsensusLeaderQorum;
+    @inject(TracedWorkspaceDB) protected readonly workspaceDB: DBWithTracing<WorkspaceDB>;
+    @inject(UserDB) protected readonly userDB: UserDB;
+    @inject(UserService) protected readonly userService: UserService;
+    @inject(WorkspaceStarter) protected readonly workspaceStarter: WorkspaceStarter;
+
+    public async start(intervalSeconds?: number): Promise<Disposable> {
+        const intervalSecs = intervalSeconds || SpendingLimitEnforcer.CHECK_INTERVAL_SECONDS;
+        return repeat(async () => {
+            try {
+                if (await this.leaderQuorum.areWeLeader()) {
+                    await this.stopWorkspacesExceedingSpendingLimits();
+                }
+            } catch (err) {
+                log.error("spending limit enforcer", err);
+            }
+        }, intervalSecs * 1000);
+    }
+
+    protected async stopWorkspacesExceedingSpendingLimits() {
+        const span = opentracing.globalTracer().startSpan("stopWorkspacesExceedingSpendingLimits");
+        const ctx = { span };
+        try {
+            const running = await this.workspaceDB.trace(ctx).findRunningInstancesWithWorkspaces();
+            for (const info of running) {
+                try {
+                    await this.stopIfSpendingLimitReached(ctx, info);
+                } catch (err) {
+                    log.error(
+                        { instanceId: info.latestInstance.id },
+                        "spending-limit-enforcer: cannot check spending limit",
+                        err,
+                    );
+                }
+            }
+        } catch (err) {
+            TraceContext.setError(ctx, err);
+            throw err;
+        } finally {
+            span.finish();
+        }
+    }
+
+    protected async stopIfSpendingLimitReached(ctx: TraceContext, { workspace, latestInstance }: RunningWorkspaceInfo) {
+        // only team cost centers have nested spending limits
+        const attributionId = AttributionId.parse(latestInstance.usageAttributionId || "");
+        if (workspace.type !== "regular" || attributionId?.kind !== "team") {
+            return;
+        }
+        const user = await this.userDB.findUserById(workspace.ownerId);
+        if (!user) {
+            return;
+        }
+        const limit = await this.userService.checkUsageLimitReached(user, workspace);
+        if (!limit.nestedSpendingLimit) {
+            return;
+        }
+        const scope = limit.nestedSpendingLimit.scope === NestedSpendingLimit_Scope.SCOPE_USER ? "user" : "project";
+        await this.workspaceStarter.stopWorkspaceInstance(
+            ctx,
+            latestInstance.id,
+            latestInstance.region,
+            `spending limit of the ${scope} reached`,
+            StopWorkspacePolicy.NORMALLY,
+        );
+    }
+}
@@ -56,6 +56,7 @@ import { ImageSourceProvider } from "./workspace/image-source-provider";
 import { WorkspaceGarbageCollector } from "./workspace/garbage-collector";
 import { TokenGarbageCollector } from "./user/token-garbage-collector";
 import { WorkspaceDownloadService } from "./workspace/workspace-download-service";
+import { PortShareService } from "./workspace/port-share-service";
 import { WebsocketConnectionManager } from "./websocket/websocket-connection-manager";
 import { OneTimeSecretServer } from "./one-time-secret-server";
 import { HostContainerMapping } from "./auth/host-container-mapping";
@@ -102,6 +103,7 @@ import {
 } from "@gitpod/gitpod-protocol/lib/experiments/configcat-server";
 import { VerificationService } from "./auth/verification-service";
 import { WebhookEventGarbageCollector } from "./projects/webhook-event-garbage-collector";
+import { SpendingLimitEnforcer } from "./billing/spending-limit-enforcer";
 import { LivenessController } from "./liveness/liveness-controller";
 import { FeatureFlagController } from "./feature-flag/featureflag-controller";
 import { IDEServiceClient, IDEServiceDefinition } from "@gitpod/ide-service-api/lib/ide.pb";
@@ -214,6 +216,7 @@ export const productionContainerModule = new ContainerModule((bind, unbind, isBo
 
     bind(WorkspaceGarbageCollector).toSelf().inSingletonScope();
     bind(WorkspaceDownloadService).toSelf().inSingletonScope();
+    bind(PortShareService).toSelf().inSingletonScope();
     bind(LivenessController).toSelf().inSingletonScope();
     bind(FeatureFlagController).toSelf().inSingletonScope();
 
@@ -300,6 +303,7 @@ export const productionContainerModule = new ContainerModule((bind, unbind, isBo
     bind(VerificationService).toSelf().inSingletonScope();
 
     bind(WebhookEventGarbageCollector).toSelf().inSingletonScope();
+    bind(SpendingLimitEnforcer).toSelf().inSingletonScope();
 
     bind(UsageServiceImpl).toSelf().inSingletonScope();
     bind(UsageService).toService(UsageServiceImpl);
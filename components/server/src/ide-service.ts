@@ -4,6 +4,7 @@
  * See License.AGPL.txt in the project root for license information.
  */
 
+import { TeamDB } from "@gitpod/gitpod-db/lib";
 import { IDESettings, TaskConfig, User, Workspace } from "@gitpod/gitpod-protocol";
 import { ConfigCatClientFactory } from "@gitpod/gitpod-protocol/lib/experiments/configcat-server";
 import { IDEClient, IDEOptions } from "@gitpod/gitpod-protocol/lib/ide-protocol";
@@ -32,24 +33,49 @@ export class IDEService {
     @inject(ConfigCatClientFactory)
     protected readonly configCatClientFactory: ConfigCatClientFactory;
 
-    private cacheConfig?: IDEConfig;
+    @inject(TeamDB)
+    protected readonly teamDB: TeamDB;
 
-    async getIDEConfig(): Promise<IDEConfig> {
+    // the IDE config depends on the version rolled out to the user, so the last config is cached per user
+    private readonly cachedConfigs = new Map<string, IDEConfig>();
+    private readonly maxCachedConfigs = 1000;
+
+    async getIDEConfig(user?: User): Promise<IDEConfig> {
+        const cacheKey = user?.id || "";
         try {
-            let resp = await this.ideService.getConfig({});
-            let config: IDEConfig = JSON.parse(resp.content);
-            this.cacheConfig = config;
+            const resp = await this.ideService.getConfig({
+                user: user && (await this.toIDEServiceUser(user)),
+            });
+            const config: IDEConfig = JSON.parse(resp.content);
+            this.cachedConfigs.delete(cacheKey);
+            this.cachedConfigs.set(cacheKey, config);
+            if (this.cachedConfigs.size > this.maxCachedConfigs) {
+                // maps iterate in insertion order, the first key is the least recently refreshed one
+                const [oldest] = this.cachedConfigs.keys();
+                this.cachedConfigs.delete(oldest);
+            }
             return config;
         } catch (e) {
             console.error("failed get ide config:", e);
-            if (this.cacheConfig == null) {
+            const cached = this.cachedConfigs.get(cacheKey);
+            if (!cached) {
                 throw new Error("failed get ide config:" + e.message);
-            } else {
-                return this.cacheConfig;
             }
+            return cached;
         }
     }
 
+    private async toIDEServiceUser(user: User): Promise<IdeServiceApi.User> {
+        // organizations select the IDE config version which is rolled out to the user. Without them, the user
+        // could get another IDE config version, hence we fail rather than pretend the user has no organizations.
+        const organizationIds = (await this.teamDB.findTeamsByUser(user.id)).map((team) => team.id);
+        return {
+            id: user.id,
+            email: User.getPrimaryEmail(user),
+            organizationIds,
+        };
+    }
+
     migrateSettings(user: User): IDESettings | undefined {
         if (!user?.additionalData?.ideSettings || user.additionalData.ideSettings.settingVersion === "2.0") {
             return undefined;
@@ -89,10 +115,7 @@ export class IDEService {
             context: JSON.stringify(workspace.context),
             ideSettings: JSON.stringify(userSelectedIdeSettings || user.additionalData?.ideSettings),
             workspaceConfig: JSON.stringify(workspace.config),
-            user: {
-                id: user.id,
-                email: User.getPrimaryEmail(user),
-            },
+            user: await this.toIDEServiceUser(user),
         };
         for (let attempt = 0; attempt < 15; attempt++) {
             if (attempt != 0) {
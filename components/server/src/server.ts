@@ -27,6 +27,7 @@ import { ConsensusLeaderQorum } from "./consensus/consensus-leader-quorum";
 import { RabbitMQConsensusLeaderMessenger } from "./consensus/rabbitmq-consensus-leader-messenger";
 import { WorkspaceGarbageCollector } from "./workspace/garbage-collector";
 import { WorkspaceDownloadService } from "./workspace/workspace-download-service";
+import { PortShareService } from "./workspace/port-share-service";
 import { MonitoringEndpointsApp } from "./monitoring-endpoints";
 import { WebsocketConnectionManager } from "./websocket/websocket-connection-manager";
 import { PeriodicDbDeleter, TypeORM } from "@gitpod/gitpod-db/lib";
@@ -49,6 +50,7 @@ import { LocalMessageBroker } from "./messaging/local-message-broker";
 import { WsConnectionHandler } from "./express/ws-connection-handler";
 import { InstallationAdminController } from "./installation-admin/installation-admin-controller";
 import { WebhookEventGarbageCollector } from "./projects/webhook-event-garbage-collector";
+import { SpendingLimitEnforcer } from "./billing/spending-limit-enforcer";
 import { LivenessController } from "./liveness/liveness-controller";
 import { FeatureFlagController } from "./feature-flag/featureflag-controller";
 import { IamSessionApp } from "./iam/iam-session-app";
@@ -68,6 +70,7 @@ export class Server<C extends GitpodClient, S extends GitpodServer> {
     @inject(MessageBusIntegration) protected readonly messagebus: MessageBusIntegration;
     @inject(LocalMessageBroker) protected readonly localMessageBroker: LocalMessageBroker;
     @inject(WorkspaceDownloadService) protected readonly workspaceDownloadService: WorkspaceDownloadService;
+    @inject(PortShareService) protected readonly portShareService: PortShareService;
     @inject(LivenessController) protected readonly livenessController: LivenessController;
     @inject(FeatureFlagController) protected readonly featureFlagController: FeatureFlagController;
     @inject(MonitoringEndpointsApp) protected readonly monitoringEndpointsApp: MonitoringEndpointsApp;
@@ -82,6 +85,7 @@ export class Server<C extends GitpodClient, S extends GitpodServer> {
 
     @inject(PeriodicDbDeleter) protected readonly periodicDbDeleter: PeriodicDbDeleter;
     @inject(WebhookEventGarbageCollector) protected readonly webhookEventGarbageCollector: WebhookEventGarbageCollector;
+    @inject(SpendingLimitEnforcer) protected readonly spendingLimitEnforcer: SpendingLimitEnforcer;
 
     @inject(BearerAuth) protected readonly bearerAuth: BearerAuth;
 
@@ -288,6 +292,11 @@ export class Server<C extends GitpodClient, S extends GitpodServer> {
             .start()
             .catch((err) => log.error("webhook-event-gc: error during startup", err));
 
+        // Start stopping workspaces which exceed nested spending limits
+        this.spendingLimitEnforcer
+            .start()
+            .catch((err) => log.error("spending-limit-enforcer: error during startup", err));
+
         this.app = app;
         log.info("server initialized.");
     }
@@ -307,6 +316,7 @@ export class Server<C extends GitpodClient, S extends GitpodServer> {
         app.use(this.oneTimeSecretServer.apiRouter);
         app.use("/enforcement", this.enforcementController.apiRouter);
         app.use("/workspace-download", this.workspaceDownloadService.apiRouter);
+        app.use("/port-share", this.portShareService.apiRouter);
         app.use("/code-sync", this.codeSyncService.apiRouter);
         app.use(HEADLESS_LOGS_PATH_PREFIX, this.headlessLogController.headlessLogs);
         app.use(HEADLESS_LOG_DOWNLOAD_PATH_PREFIX, this.headlessLogController.headlessLogDownload);
@@ -7,6 +7,7 @@
 import { AttributionId } from "@gitpod/gitpod-protocol/lib/attribution";
 import {
     CostCenter_BillingStrategy,
+    NestedSpendingLimit,
     UsageServiceClient,
     UsageServiceDefinition,
 } from "@gitpod/usage-api/lib/usage/v1/usage.pb";
@@ -14,8 +15,15 @@ import { inject, injectable } from "inversify";
 
 export const UsageService = Symbol("UsageService");
 
+export interface CreditBalance {
+    usedCredits: number;
+    usageLimit: number;
+    /** the spending limits of projects and users within a team's cost center */
+    nestedSpendingLimits?: NestedSpendingLimit[];
+}
+
 export interface UsageService {
-    getCurrentBalance(attributionId: AttributionId): Promise<{ usedCredits: number; usageLimit: number }>;
+    getCurrentBalance(attributionId: AttributionId): Promise<CreditBalance>;
 
     getCurrentBillingStategy(attributionId: AttributionId): Promise<CostCenter_BillingStrategy | undefined>;
 }
@@ -25,7 +33,7 @@ export class UsageServiceImpl implements UsageService {
     @inject(UsageServiceDefinition.name)
     protected readonly usageService: UsageServiceClient;
 
-    async getCurrentBalance(attributionId: AttributionId): Promise<{ usedCredits: number; usageLimit: number }> {
+    async getCurrentBalance(attributionId: AttributionId): Promise<CreditBalance> {
         const costCenterPromise = this.usageService.getCostCenter({
             attributionId: AttributionId.render(attributionId),
         });
@@ -38,6 +46,7 @@ export class UsageServiceImpl implements UsageService {
         return {
             usedCredits: currentInvoiceCredits,
             usageLimit: costCenter?.spendingLimit || 0,
+            nestedSpendingLimits: costCenter?.nestedSpendingLimits || [],
         };
     }
 
@@ -51,7 +60,7 @@ export class UsageServiceImpl implements UsageService {
 
 // TODO(gpl) Remove as part of fixing https://github.com/gitpod-io/gitpod/issues/14129
 export class NoOpUsageService implements UsageService {
-    async getCurrentBalance(attributionId: AttributionId): Promise<{ usedCredits: number; usageLimit: number }> {
+    async getCurrentBalance(attributionId: AttributionId): Promise<CreditBalance> {
         return {
             usedCredits: 0,
             usageLimit: 1000000000,
@@ -22,6 +22,7 @@ import { StripeService } from "../../ee/src/user/stripe-service";
 import { ResponseError } from "vscode-ws-jsonrpc";
 import { ErrorCodes } from "@gitpod/gitpod-protocol/lib/messaging/error";
 import { UsageService } from "./usage-service";
+import { NestedSpendingLimit, NestedSpendingLimit_Scope } from "@gitpod/usage-api/lib/usage/v1/usage.pb";
 import { UserToTeamMigrationService } from "@gitpod/gitpod-db/lib/user-to-team-migration-service";
 import { ConfigCatClientFactory } from "@gitpod/gitpod-protocol/lib/experiments/configcat-server";
 import { BillingModes } from "../../ee/src/billing/billing-mode";
@@ -57,6 +58,8 @@ export interface UsageLimitReachedResult {
     reached: boolean;
     almostReached?: boolean;
     attributionId: AttributionId;
+    /** set if the spending limit of the workspace's project or of the user within the team is reached */
+    nestedSpendingLimit?: NestedSpendingLimit;
 }
 
 @injectable()
@@ -259,7 +262,28 @@ export class UserService {
                 reached: true,
                 attributionId,
             };
-        } else if (currentInvoiceCredits > usageLimit * 0.8) {
+        }
+        const nestedSpendingLimit = (creditBalance.nestedSpendingLimits || []).find(
+            (limit) =>
+                limit.creditsUsed >= limit.spendingLimit &&
+                ((limit.scope === NestedSpendingLimit_Scope.SCOPE_PROJECT && limit.scopeId === workspace?.projectId) ||
+                    (limit.scope === NestedSpendingLimit_Scope.SCOPE_USER && limit.scopeId === user.id)),
+        );
+        if (nestedSpendingLimit) {
+            log.info({ userId: user.id }, "Nested usage limit reached", {
+                attributionId,
+                scope: nestedSpendingLimit.scope,
+                scopeId: nestedSpendingLimit.scopeId,
+                creditsUsed: nestedSpendingLimit.creditsUsed,
+                spendingLimit: nestedSpendingLimit.spendingLimit,
+            });
+            return {
+                reached: true,
+                attributionId,
+                nestedSpendingLimit,
+            };
+        }
+        if (currentInvoiceCredits > usageLimit * 0.8) {
             log.info({ userId: user.id }, "Usage limit almost reached", {
                 attributionId,
                 currentInvoiceCredits,
@@ -124,6 +124,7 @@ import { WorkspaceManagerClientProvider } from "@gitpod/ws-manager/lib/client-pr
 import {
     ControlPortRequest,
     DescribeWorkspaceRequest,
+    GetSidecarLogsRequest,
     MarkActiveRequest,
     PortSpec,
     PortVisibility as ProtoPortVisibility,
@@ -180,6 +181,7 @@ import * as grpc from "@grpc/grpc-js";
 import { CachingBlobServiceClientProvider } from "../util/content-service-sugar";
 import { CostCenterJSON } from "@gitpod/gitpod-protocol/lib/usage";
 import { createCookielessId, maskIp } from "../analytics";
+import { PORT_SHARE_TOKEN_PARAM, signPortShareGrant, signPortShareToken } from "./port-share-service";
 
 // shortcut
 export const traceWI = (ctx: TraceContext, wi: Omit<LogContext, "userId">) => TraceContext.setOWI(ctx, wi); // userId is already taken care of in WebsocketConnectionManager
@@ -191,6 +193,12 @@ export function censor<T>(obj: T, k: keyof T): T {
     return r;
 }
 
+// share links are valid for at most a week
+const MAX_PORT_SHARE_EXPIRY_SECONDS = 7 * 24 * 60 * 60;
+
+// sidecar logs are served from the pod and limited to their tail
+const SIDECAR_LOG_TAIL_LINES = 1000;
+
 export type GitpodServerWithTracing = InterfaceWithTraceContext<GitpodServer>;
 
 @injectable()
@@ -1563,6 +1571,8 @@ export class GitpodServerImpl implements GitpodServerWithTracing, Disposable {
                 return "private";
             case ProtoPortVisibility.PORT_VISIBILITY_PUBLIC:
                 return "public";
+            case ProtoPortVisibility.PORT_VISIBILITY_SHARED:
+                return "shared";
         }
     }
 
@@ -1573,6 +1583,8 @@ export class GitpodServerImpl implements GitpodServerWithTracing, Disposable {
                 return ProtoPortVisibility.PORT_VISIBILITY_PRIVATE;
             case "public":
                 return ProtoPortVisibility.PORT_VISIBILITY_PUBLIC;
+            case "shared":
+                return ProtoPortVisibility.PORT_VISIBILITY_SHARED;
         }
     }
 
@@ -1608,6 +1620,97 @@ export class GitpodServerImpl implements GitpodServerWithTracing, Disposable {
         await client.controlPort(ctx, req);
     }
 
+    public async sharePort(
+        ctx: TraceContext,
+        workspaceId: string,
+        port: number,
+        options: GitpodServer.SharePortOptions,
+    ): Promise<GitpodServer.SharePortResult> {
+        traceAPIParams(ctx, { workspaceId, port, options: censor(options, "userIds") });
+        traceWI(ctx, { workspaceId });
+
+        this.checkAndBlockUser("sharePort");
+
+        if (
+            !Number.isInteger(options.expiresInSeconds) ||
+            options.expiresInSeconds <= 0 ||
+            options.expiresInSeconds > MAX_PORT_SHARE_EXPIRY_SECONDS
+        ) {
+            throw new ResponseError(
+                ErrorCodes.BAD_REQUEST,
+                `expiresInSeconds must be between 1 and ${MAX_PORT_SHARE_EXPIRY_SECONDS}`,
+            );
+        }
+
+        const { workspace, instance } = await this.internGetCurrentWorkspaceInstance(ctx, workspaceId);
+        if (!instance || instance.status.phase !== "running" || !instance.status.ownerToken) {
+            throw new ResponseError(ErrorCodes.NOT_FOUND, "Workspace is not running.");
+        }
+        traceWI(ctx, { instanceId: instance.id });
+        // only those who may change the port's visibility may share it
+        await this.guardAccess({ kind: "workspaceInstance", subject: instance, workspace }, "update");
+
+        const exposed = instance.status.exposedPorts?.find((p) => p.port === port);
+        if (!exposed?.url) {
+            throw new ResponseError(ErrorCodes.NOT_FOUND, `Port ${port} is not exposed.`);
+        }
+        if (exposed.visibility === "public") {
+            throw new ResponseError(ErrorCodes.CONFLICT, `Port ${port} is public already.`);
+        }
+        if (exposed.visibility !== "shared") {
+            // ws-proxy only accepts share tokens on shared ports
+            const req = new ControlPortRequest();
+            req.setId(instance.id);
+            const spec = new PortSpec();
+            spec.setPort(port);
+            spec.setVisibility(ProtoPortVisibility.PORT_VISIBILITY_SHARED);
+            req.setSpec(spec);
+            req.setExpose(true);
+            try {
+                const client = await this.workspaceManagerClientProvider.get(
+                    instance.region,
+                    this.config.installationShortname,
+                );
+                await client.controlPort(ctx, req);
+            } catch (e) {
+                throw this.mapGrpcError(e);
+            }
+        }
+
+        const expires = new Date(Date.now() + options.expiresInSeconds * 1000);
+        let teamId: string | undefined;
+        if (options.teamMembers) {
+            const project = workspace.projectId ? await this.projectDB.findProjectById(workspace.projectId) : undefined;
+            teamId = project?.teamId;
+            if (!teamId) {
+                throw new ResponseError(ErrorCodes.BAD_REQUEST, "Workspace does not belong to a team project.");
+            }
+        }
+        if (!teamId && !options.userIds?.length) {
+            // anyone holding the link may access the port: hand out a token ws-proxy verifies directly
+            const url = new URL(exposed.url);
+            url.searchParams.set(
+                PORT_SHARE_TOKEN_PARAM,
+                signPortShareToken(instance.status.ownerToken, instance.id, port, expires),
+            );
+            return { url: url.toString(), expiresAt: expires.toISOString() };
+        }
+
+        // access is restricted: the link goes through the server, which checks who redeems it
+        const grant = signPortShareGrant(instance.status.ownerToken, {
+            instanceId: instance.id,
+            port,
+            expires: Math.floor(expires.getTime() / 1000),
+            userIds: options.userIds,
+            teamId,
+        });
+        const url = this.config.hostUrl.withApi({
+            pathname: "/port-share/open",
+            search: `grant=${encodeURIComponent(grant)}`,
+        });
+        return { url: url.toString(), expiresAt: expires.toISOString() };
+    }
+
     async watchWorkspaceImageBuildLogs(ctx: TraceContext, workspaceId: string): Promise<void> {
         traceAPIParams(ctx, { workspaceId });
         traceWI(ctx, { workspaceId });
@@ -1727,6 +1830,43 @@ export class GitpodServerImpl implements GitpodServerWithTracing, Disposable {
         return urls;
     }
 
+    async getSidecarLogs(ctx: TraceContext, workspaceId: string, sidecar: string): Promise<string> {
+        traceAPIParams(ctx, { workspaceId, sidecar });
+        traceWI(ctx, { workspaceId });
+
+        this.checkAndBlockUser("getSidecarLogs", { workspaceId });
+
+        const { workspace, instance } = await this.internGetCurrentWorkspaceInstance(ctx, workspaceId);
+        if (!instance) {
+            throw new ResponseError(ErrorCodes.NOT_FOUND, `Workspace ${workspaceId} has no running instance`);
+        }
+        traceWI(ctx, { instanceId: instance.id });
+        const teamMembers = await this.getTeamMembersByProject(workspace.projectId);
+        await this.guardAccess({ kind: "workspaceLog", subject: workspace, teamMembers }, "get");
+
+        if (!(workspace.config.sidecars || []).some((s) => s.name === sidecar)) {
+            throw new ResponseError(ErrorCodes.NOT_FOUND, `Workspace ${workspaceId} has no sidecar ${sidecar}`);
+        }
+
+        const req = new GetSidecarLogsRequest();
+        req.setId(instance.id);
+        req.setName(sidecar);
+        req.setTailLines(SIDECAR_LOG_TAIL_LINES);
+        const client = await this.workspaceManagerClientProvider.get(
+            instance.region,
+            this.config.installationShortname,
+        );
+        try {
+            const resp = await client.getSidecarLogs(ctx, req);
+            return resp.getLog();
+        } catch (err) {
+            if (err.code === grpc.status.NOT_FOUND || err.code === grpc.status.FAILED_PRECONDITION) {
+                throw new ResponseError(ErrorCodes.NOT_FOUND, err.details);
+            }
+            throw this.mapGrpcError(err);
+        }
+    }
+
     protected async internGetCurrentWorkspaceInstance(
         ctx: TraceContext,
         workspaceId: string,
@@ -2995,7 +3135,7 @@ export class GitpodServerImpl implements GitpodServerWithTracing, Disposable {
     }
 
     async getIDEOptions(ctx: TraceContext): Promise<IDEOptions> {
-        const ideConfig = await this.ideService.getIDEConfig();
+        const ideConfig = await this.ideService.getIDEConfig(this.user);
         return ideConfig.ideOptions;
     }
 
@@ -0,0 +1,201 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+import { injectable, inject } from "inversify";
+import * as express from "express";
+import * as crypto from "crypto";
+import { TracedWorkspaceDB, DBWithTracing, WorkspaceDB, TeamDB } from "@gitpod/gitpod-db/lib";
+import { log } from "@gitpod/gitpod-protocol/lib/util/logging";
+import { User, WorkspaceInstance } from "@gitpod/gitpod-protocol";
+import { Config } from "../config";
+
+/**
+ * The query parameter ws-proxy looks for when a shared port is accessed.
+ * Must match portShareTokenParam in components/ws-proxy/pkg/proxy/sharetoken.go.
+ */
+export const PORT_SHARE_TOKEN_PARAM = "gitpod_port_token";
+
+/**
+ * The query parameter ws-proxy looks for when a browser still has to be bound to a restricted share link.
+ * Must match portShareGrantParam in components/ws-proxy/pkg/proxy/sharetoken.go.
+ */
+export const PORT_SHARE_GRANT_PARAM = "gitpod_port_grant";
+
+// a binding is the unpadded base64url encoded SHA-256 of the nonce ws-proxy keeps in the browser
+const PORT_SHARE_BINDING_PATTERN = /^[A-Za-z0-9_-]{43}$/;
+
+/**
+ * A PortShareGrant restricts a share link to a set of users and/or the members of a team.
+ * Grants are redeemed through the server so that the accessing user can be identified.
+ */
+export interface PortShareGrant {
+    instanceId: string;
+    port: number;
+    // expiry as unix timestamp in seconds
+    expires: number;
+    userIds?: string[];
+    teamId?: string;
+}
+
+/**
+ * Creates a token ws-proxy accepts for a shared port. The token is keyed by the instance's owner token,
+ * hence it becomes invalid as soon as the instance stops. Tokens with a binding are only accepted
+ * from the browser holding the nonce the binding was derived from.
+ */
+export function signPortShareToken(
+    ownerToken: string,
+    instanceId: string,
+    port: number,
+    expires: Date,
+    binding?: string,
+): string {
+    const exp = Math.floor(expires.getTime() / 1000);
+    const hmac = crypto.createHmac("sha256", ownerToken);
+    if (!binding) {
+        return `${exp}.${hmac.update(`${instanceId}:${port}:${exp}`).digest("base64url")}`;
+    }
+    return `${exp}.${binding}.${hmac.update(`${instanceId}:${port}:${exp}:${binding}`).digest("base64url")}`;
+}
+
+export function signPortShareGrant(ownerToken: string, grant: PortShareGrant): string {
+    const payload = Buffer.from(JSON.stringify(grant)).toString("base64url");
+    return `${payload}.${portShareGrantSignature(ownerToken, payload)}`;
+}
+
+/**
+ * Decodes a grant without verifying it. Callers must verify the grant using verifyPortShareGrant
+ * once they have looked up the instance's owner token.
+ */
+export function decodePortShareGrant(grant: string): PortShareGrant | undefined {
+    const [payload] = grant.split(".");
+    try {
+        const decoded = JSON.parse(Buffer.from(payload, "base64url").toString());
+        if (typeof decoded?.instanceId !== "string" || typeof decoded?.port !== "number") {
+            return undefined;
+        }
+        return decoded;
+    } catch (err) {
+        return undefined;
+    }
+}
+
+export function verifyPortShareGrant(ownerToken: string, grant: string): boolean {
+    const [payload, sig] = grant.split(".");
+    if (!payload || !sig) {
+        return false;
+    }
+    const expected = Buffer.from(portShareGrantSignature(ownerToken, payload));
+    const actual = Buffer.from(sig);
+    return expected.length === actual.length && crypto.timingSafeEqual(expected, actual);
+}
+
+function portShareGrantSignature(ownerToken: string, payload: string): string {
+    return crypto.createHmac("sha256", ownerToken).update(`grant:${payload}`).digest("base64url");
+}
+
+@injectable()
+export class PortShareService {
+    @inject(Config) protected readonly config: Config;
+    @inject(TracedWorkspaceDB) protected readonly workspaceDB: DBWithTracing<WorkspaceDB>;
+    @inject(TeamDB) protected readonly teamDB: TeamDB;
+
+    get apiRouter(): express.Router {
+        const router = express.Router();
+        this.addOpenHandler(router);
+        return router;
+    }
+
+    protected addOpenHandler(router: express.Router) {
+        router.get("/open", async (req, res, next) => {
+            if (!req.isAuthenticated() || !User.is(req.user)) {
+                const returnTo = this.config.hostUrl.withApi({
+                    pathname: "/port-share/open",
+                    search: req.url.split("?")[1],
+                });
+                res.redirect(
+                    this.config.hostUrl
+                        .withApi({
+                            pathname: "/login",
+                            search: `returnTo=${encodeURIComponent(returnTo.toString())}`,
+                        })
+                        .toString(),
+                );
+                return;
+            }
+            const userId = req.user.id;
+
+            const encoded = req.query.grant;
+            const grant = typeof encoded === "string" ? decodePortShareGrant(encoded) : undefined;
+            if (typeof encoded !== "string" || !grant) {
+                res.sendStatus(400);
+                return;
+            }
+
+            try {
+                const instance = await this.workspaceDB.trace({}).findInstanceById(grant.instanceId);
+                const ownerToken = instance?.status.ownerToken;
+                if (!instance || instance.status.phase !== "running" || !ownerToken) {
+                    res.status(404).send("The workspace is not running.");
+                    return;
+                }
+                if (!verifyPortShareGrant(ownerToken, encoded)) {
+                    res.sendStatus(403);
+                    return;
+                }
+                const expires = new Date(grant.expires * 1000);
+                if (expires.getTime() < Date.now()) {
+                    res.status(403).send("The share link has expired.");
+                    return;
+                }
+                if (!(await this.mayAccess(userId, instance, grant))) {
+                    log.warn(
+                        { userId, instanceId: instance.id, workspaceId: instance.workspaceId },
+                        "user attempted to access a shared port without being granted access",
+                        { port: grant.port },
+                    );
+                    res.sendStatus(403);
+                    return;
+                }
+
+                const portUrl = instance.status.exposedPorts?.find((p) => p.port === grant.port)?.url;
+                if (!portUrl) {
+                    res.status(404).send("The port is not exposed.");
+                    return;
+                }
+                // The token must not be usable by whoever it is forwarded to. Hence we first let ws-proxy
+                // hand a nonce to this browser, and then mint a token bound to that nonce.
+                const url = new URL(portUrl);
+                const binding = req.query.binding;
+                if (typeof binding === "string" && PORT_SHARE_BINDING_PATTERN.test(binding)) {
+                    url.searchParams.set(
+                        PORT_SHARE_TOKEN_PARAM,
+                        signPortShareToken(ownerToken, instance.id, grant.port, expires, binding),
+                    );
+                } else {
+                    url.searchParams.set(PORT_SHARE_GRANT_PARAM, encoded);
+                }
+                res.redirect(url.toString());
+            } catch (err) {
+                log.error({ instanceId: grant.instanceId }, "cannot redeem port share grant", err);
+                res.sendStatus(500);
+            }
+        });
+    }
+
+    protected async mayAccess(userId: string, instance: WorkspaceInstance, grant: PortShareGrant): Promise<boolean> {
+        const ws = await this.workspaceDB.trace({}).findById(instance.workspaceId);
+        if (ws?.ownerId === userId) {
+            return true;
+        }
+        if (grant.userIds?.includes(userId)) {
+            return true;
+        }
+        if (grant.teamId) {
+            return !!(await this.teamDB.findTeamMembership(userId, grant.teamId));
+        }
+        return false;
+    }
+}
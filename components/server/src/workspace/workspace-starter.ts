@@ -88,6 +88,8 @@ import {
     GitSpec,
     PortSpec,
     PortVisibility,
+    SidecarResources,
+    SidecarSpec,
     StartWorkspaceRequest,
     WorkspaceMetadata,
     WorkspaceType,
@@ -806,6 +808,7 @@ export class WorkspaceStarter {
                     // We only check user setting because if code(insider) but desktopIde has no latestImage
                     // it still need to notice user that this workspace is using latest IDE
                     useLatest: user.additionalData?.ideSettings?.useLatestVersion,
+                    version: ideConfig.ideConfigVersion || undefined,
                 },
             };
 
@@ -1031,6 +1034,7 @@ export class WorkspaceStarter {
                 file.setDockerfilePath(dockerFilePath);
                 file.setSource(source);
                 file.setDockerfileVersion(imgsrc.dockerFileHash);
+                file.setDockerfileContent(await this.getDockerfileContent(workspace, imgsrc, user));
 
                 const src = new BuildSource();
                 src.setFile(file);
@@ -1054,6 +1058,40 @@ export class WorkspaceStarter {
         }
     }
 
+    /**
+     * Returns the Dockerfile a workspace image is built from, which image-builder needs to verify the images it builds from.
+     */
+    protected async getDockerfileContent(
+        workspace: Workspace,
+        imgsrc: WorkspaceImageSourceDocker,
+        user: User,
+    ): Promise<string> {
+        if (AdditionalContentContext.hasDockerConfig(workspace.context, workspace.config)) {
+            return (workspace.context as AdditionalContentContext).additionalFiles[imgsrc.dockerFilePath] || "";
+        }
+        if (!imgsrc.dockerFileSource) {
+            return "";
+        }
+        const hostContext = this.hostContextProvider.get(imgsrc.dockerFileSource.repository.host);
+        if (!hostContext?.services) {
+            return "";
+        }
+        const content = await hostContext.services.fileProvider.getFileContent(
+            imgsrc.dockerFileSource,
+            user,
+            imgsrc.dockerFilePath,
+        );
+        return content || "";
+    }
+
+    /**
+     * Returns the organization a workspace instance is attributed to, which selects the image verification policy of image-builder.
+     */
+    protected getOrganizationId(instance: WorkspaceInstance): string {
+        const attributionId = AttributionId.parse(instance.usageAttributionId || "");
+        return attributionId?.kind === "team" ? attributionId.teamId : "";
+    }
+
     protected async needsImageBuild(
         ctx: TraceContext,
         user: User,
@@ -1075,6 +1113,7 @@ export class WorkspaceStarter {
             const req = new ResolveWorkspaceImageRequest();
             req.setSource(src);
             req.setAuth(auth);
+            req.setOrganizationId(this.getOrganizationId(instance));
             const result = await client.resolveWorkspaceImage({ span }, req);
 
             if (!!disposable) {
@@ -1120,6 +1159,11 @@ export class WorkspaceStarter {
             req.setForceRebuild(forceRebuild);
             req.setTriggeredBy(user.id);
             req.setSupervisorRef(ideConfig.supervisorImage);
+            req.setOrganizationId(this.getOrganizationId(instance));
+            if (workspace.projectId) {
+                // builds of the same project share a build cache
+                req.setCacheScope(workspace.projectId);
+            }
 
             // Make sure we persist logInfo as soon as we retrieve it
             const imageBuildLogInfo = new Deferred<ImageBuildLogInfo>();
@@ -1477,6 +1521,7 @@ export class WorkspaceStarter {
         spec.setSysEnvvarsList(sysEnvvars);
         spec.setGit(this.createGitSpec(workspace, user));
         spec.setPortsList(ports);
+        spec.setSidecarsList(this.createSidecarSpecs(workspace));
         spec.setInitializer((await initializerPromise).initializer);
         const startWorkspaceSpecIDEImage = new IDEImage();
         startWorkspaceSpecIDEImage.setWebRef(ideConfig.webImage);
@@ -1513,6 +1558,7 @@ export class WorkspaceStarter {
             "function:getOpenPorts",
             "function:openPort",
             "function:closePort",
+            "function:sharePort",
             "function:generateNewGitpodToken",
             "function:takeSnapshot",
             "function:waitForSnapshot",
@@ -1583,6 +1629,33 @@ export class WorkspaceStarter {
         return scopes;
     }
 
+    protected createSidecarSpecs(workspace: Workspace): SidecarSpec[] {
+        return (workspace.config.sidecars || []).map((s) => {
+            const spec = new SidecarSpec();
+            spec.setName(s.name);
+            spec.setImage(s.image);
+            spec.setCommandList(s.command || []);
+            spec.setArgsList(s.args || []);
+            spec.setEnvvarsList(
+                Object.entries(s.env || {}).map(([name, value]) => {
+                    const ev = new EnvironmentVariable();
+                    ev.setName(name);
+                    ev.setValue(value);
+                    return ev;
+                }),
+            );
+            if (s.resources) {
+                const resources = new SidecarResources();
+                resources.setCpuRequest(s.resources.requests?.cpu || "");
+                resources.setMemoryRequest(s.resources.requests?.memory || "");
+                resources.setCpuLimit(s.resources.limits?.cpu || "");
+                resources.setMemoryLimit(s.resources.limits?.memory || "");
+                spec.setResources(resources);
+            }
+            return spec;
+        });
+    }
+
     protected createGitSpec(workspace: Workspace, user: User): GitSpec {
         const context = workspace.context;
         if (!CommitContext.is(context)) {
@@ -37,6 +37,9 @@ type PortServiceClient interface {
 	AutoTunnel(ctx context.Context, in *AutoTunnelRequest, opts ...grpc.CallOption) (*AutoTunnelResponse, error)
 	// RetryAutoExpose retries auto exposing the give port
 	RetryAutoExpose(ctx context.Context, in *RetryAutoExposeRequest, opts ...grpc.CallOption) (*RetryAutoExposeResponse, error)
+	// InspectPort streams the HTTP requests made to an exposed port.
+	// The port's traffic is captured while it is being inspected.
+	InspectPort(ctx context.Context, in *InspectPortRequest, opts ...grpc.CallOption) (PortService_InspectPortClient, error)
 }
 
 type portServiceClient struct {
@@ -114,6 +117,38 @@ func (c *portServiceClient) RetryAutoExpose(ctx context.Context, in *RetryAutoEx
 	return out, nil
 }
 
+func (c *portServiceClient) InspectPort(ctx context.Context, in *InspectPortRequest, opts ...grpc.CallOption) (PortService_InspectPortClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PortService_ServiceDesc.Streams[1], "/supervisor.PortService/InspectPort", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &portServiceInspectPortClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PortService_InspectPortClient interface {
+	Recv() (*InspectPortResponse, error)
+	grpc.ClientStream
+}
+
+type portServiceInspectPortClient struct {
+	grpc.ClientStream
+}
+
+func (x *portServiceInspectPortClient) Recv() (*InspectPortResponse, error) {
+	m := new(InspectPortResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // PortServiceServer is the server API for PortService service.
 // All implementations must embed UnimplementedPortServiceServer
 // for forward compatibility
@@ -129,6 +164,9 @@ type PortServiceServer interface {
 	AutoTunnel(context.Context, *AutoTunnelRequest) (*AutoTunnelResponse, error)
 	// RetryAutoExpose retries auto exposing the give port
 	RetryAutoExpose(context.Context, *RetryAutoExposeRequest) (*RetryAutoExposeResponse, error)
+	// InspectPort streams the HTTP requests made to an exposed port.
+	// The port's traffic is captured while it is being inspected.
+	InspectPort(*InspectPortRequest, PortService_InspectPortServer) error
 	mustEmbedUnimplementedPortServiceServer()
 }
 
@@ -151,6 +189,9 @@ func (UnimplementedPortServiceServer) AutoTunnel(context.Context, *AutoTunnelReq
 func (UnimplementedPortServiceServer) RetryAutoExpose(context.Context, *RetryAutoExposeRequest) (*RetryAutoExposeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetryAutoExpose not implemented")
 }
+func (UnimplementedPortServiceServer) InspectPort(*InspectPortRequest, PortService_InspectPortServer) error {
+	return status.Errorf(codes.Unimplemented, "method InspectPort not implemented")
+}
 func (UnimplementedPortServiceServer) mustEmbedUnimplementedPortServiceServer() {}
 
 // UnsafePortServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -262,6 +303,27 @@ func _PortService_RetryAutoExpose_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PortService_InspectPort_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InspectPortRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PortServiceServer).InspectPort(m, &portServiceInspectPortServer{stream})
+}
+
+type PortService_InspectPortServer interface {
+	Send(*InspectPortResponse) error
+	grpc.ServerStream
+}
+
+type portServiceInspectPortServer struct {
+	grpc.ServerStream
+}
+
+func (x *portServiceInspectPortServer) Send(m *InspectPortResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // PortService_ServiceDesc is the grpc.ServiceDesc for PortService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -293,6 +355,11 @@ var PortService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "InspectPort",
+			Handler:       _PortService_InspectPort_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "port.proto",
 }
@@ -135,6 +135,9 @@ type IDEConfig struct {
 	// Expressed in kb/sec. Can be overridden by the workspace config (smallest value wins).
 	LogRateLimit int `json:"logRateLimit"`
 
+	// InternalPorts are ports the IDE serves besides the IDE port. They are never exposed or shown to the user.
+	InternalPorts []uint32 `json:"internalPorts,omitempty"`
+
 	// ReadinessProbe configures the probe used to serve the IDE status
 	ReadinessProbe struct {
 		// Type determines the type of readiness probe we'll use.
@@ -221,6 +224,12 @@ type WorkspaceConfig struct {
 	// IDEAlias is the alias of the IDE to be run. Possible values: "code", "code-latest", "theia"
 	IDEAlias string `env:"GITPOD_IDE_ALIAS"`
 
+	// IDEPluginConfig is the IDE config of an IDE plugin, which replaces the IDE config shipped with the IDE image
+	IDEPluginConfig string `env:"GITPOD_IDE_PLUGIN_CONFIG"`
+
+	// DesktopIDEPluginConfig is the IDE config of a desktop IDE plugin, which replaces the IDE config shipped with the desktop IDE image
+	DesktopIDEPluginConfig string `env:"GITPOD_DESKTOP_IDE_PLUGIN_CONFIG"`
+
 	// WorkspaceRoot is the location in the filesystem where the workspace content root is located.
 	WorkspaceRoot string `env:"THEIA_WORKSPACE_ROOT"`
 
@@ -249,6 +258,9 @@ type WorkspaceConfig struct {
 	// Tokens is a JSON encoded list of WorkspaceGitpodToken
 	Tokens string `env:"THEIA_SUPERVISOR_TOKENS"`
 
+	// PortInspectorToken authenticates supervisor to the port inspector of ws-proxy
+	PortInspectorToken string `env:"THEIA_SUPERVISOR_PORT_INSPECTOR_TOKEN"`
+
 	// WorkspaceID is the ID of the workspace
 	WorkspaceID string `env:"GITPOD_WORKSPACE_ID"`
 
@@ -434,13 +446,28 @@ func GetConfig() (*Config, error) {
 		return nil, err
 	}
 
-	ide, err := loadIDEConfigFromFile(static.IDEConfigLocation)
+	workspace, err := loadWorkspaceConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	var ide *IDEConfig
+	if workspace.IDEPluginConfig != "" {
+		ide, err = loadIDEConfigFromEnv("GITPOD_IDE_PLUGIN_CONFIG", workspace.IDEPluginConfig)
+	} else {
+		ide, err = loadIDEConfigFromFile(static.IDEConfigLocation)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	var desktopIde *IDEConfig
-	if static.DesktopIDEConfigLocation != "" {
+	if workspace.DesktopIDEPluginConfig != "" {
+		desktopIde, err = loadIDEConfigFromEnv("GITPOD_DESKTOP_IDE_PLUGIN_CONFIG", workspace.DesktopIDEPluginConfig)
+		if err != nil {
+			return nil, err
+		}
+	} else if static.DesktopIDEConfigLocation != "" {
 		if _, err := os.Stat(static.DesktopIDEConfigLocation); !os.IsNotExist((err)) {
 			desktopIde, err = loadIDEConfigFromFile(static.DesktopIDEConfigLocation)
 			if err != nil {
@@ -449,11 +476,6 @@ func GetConfig() (*Config, error) {
 		}
 	}
 
-	workspace, err := loadWorkspaceConfigFromEnv()
-	if err != nil {
-		return nil, err
-	}
-
 	return &Config{
 		StaticConfig:    *static,
 		IDE:             *ide,
@@ -503,6 +525,17 @@ func loadIDEConfigFromFile(fn string) (*IDEConfig, error) {
 	return &res, nil
 }
 
+// loadIDEConfigFromEnv loads the configuration of an IDE which was registered as IDE plugin with ide-service
+func loadIDEConfigFromEnv(name, value string) (*IDEConfig, error) {
+	var res IDEConfig
+	err := json.Unmarshal([]byte(value), &res)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal IDE config from %s: %w", name, err)
+	}
+
+	return &res, nil
+}
+
 // loadWorkspaceConfigFromEnv loads the workspace configuration from environment variables.
 func loadWorkspaceConfigFromEnv() (*WorkspaceConfig, error) {
 	var res WorkspaceConfig
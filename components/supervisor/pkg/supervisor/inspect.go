@@ -0,0 +1,140 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+// maxCaptureLineSize bounds the size of a single capture received from ws-proxy.
+const maxCaptureLineSize = 4 * 1024 * 1024
+
+// portInspector streams the traffic ws-proxy captures for the ports of this workspace.
+type portInspector struct {
+	WorkspaceURL string
+	// Token authenticates supervisor to the inspector. It grants access to the captures of this workspace only.
+	Token  string
+	Client *http.Client
+}
+
+// wsproxyCapture mirrors the captures produced by ws-proxy's inspector.
+type wsproxyCapture struct {
+	ID       uint64        `json:"id"`
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration"`
+
+	Method               string      `json:"method"`
+	URL                  string      `json:"url"`
+	Proto                string      `json:"proto"`
+	RequestHeader        http.Header `json:"requestHeader,omitempty"`
+	RequestBody          []byte      `json:"requestBody,omitempty"`
+	RequestBodyTruncated bool        `json:"requestBodyTruncated,omitempty"`
+
+	StatusCode            int         `json:"statusCode"`
+	ResponseHeader        http.Header `json:"responseHeader,omitempty"`
+	ResponseBody          []byte      `json:"responseBody,omitempty"`
+	ResponseBodyTruncated bool        `json:"responseBodyTruncated,omitempty"`
+
+	Upgraded bool `json:"upgraded,omitempty"`
+}
+
+// Inspect calls onCapture for every capture ws-proxy has retained for the port. If follow is true, Inspect
+// keeps calling onCapture for new captures until the context is canceled.
+func (pi *portInspector) Inspect(ctx context.Context, port uint32, follow bool, onCapture func(*api.HTTPCapture) error) error {
+	if pi.Token == "" {
+		return xerrors.Errorf("the port inspector is not available in this workspace")
+	}
+
+	u, err := url.Parse(pi.WorkspaceURL)
+	if err != nil {
+		return xerrors.Errorf("invalid workspace URL: %w", err)
+	}
+	u = u.JoinPath("_inspect", fmt.Sprint(port))
+	if !follow {
+		u.RawQuery = url.Values{"follow": {"false"}}.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-gitpod-port-inspector-token", pi.Token)
+
+	client := pi.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return xerrors.Errorf("cannot connect to the inspector: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return xerrors.Errorf("the port inspector is not enabled in this installation")
+	default:
+		return xerrors.Errorf("cannot connect to the inspector: %s", resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxCaptureLineSize)
+	for scanner.Scan() {
+		var c wsproxyCapture
+		err := json.Unmarshal(scanner.Bytes(), &c)
+		if err != nil {
+			return xerrors.Errorf("cannot decode capture: %w", err)
+		}
+		err = onCapture(c.toAPI())
+		if err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+func (c *wsproxyCapture) toAPI() *api.HTTPCapture {
+	return &api.HTTPCapture{
+		Id:                    c.ID,
+		Time:                  c.Time.UnixMilli(),
+		DurationMs:            c.Duration.Milliseconds(),
+		Method:                c.Method,
+		Url:                   c.URL,
+		Proto:                 c.Proto,
+		RequestHeaders:        toAPIHeaders(c.RequestHeader),
+		RequestBody:           c.RequestBody,
+		RequestBodyTruncated:  c.RequestBodyTruncated,
+		StatusCode:            int32(c.StatusCode),
+		ResponseHeaders:       toAPIHeaders(c.ResponseHeader),
+		ResponseBody:          c.ResponseBody,
+		ResponseBodyTruncated: c.ResponseBodyTruncated,
+		Upgraded:              c.Upgraded,
+	}
+}
+
+func toAPIHeaders(h http.Header) []*api.HTTPHeader {
+	if len(h) == 0 {
+		return nil
+	}
+	res := make([]*api.HTTPHeader, 0, len(h))
+	for name, values := range h {
+		res = append(res, &api.HTTPHeader{Name: name, Values: values})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
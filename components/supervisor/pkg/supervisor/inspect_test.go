@@ -0,0 +1,107 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestPortInspector(t *testing.T) {
+	const capture = `{"id":1,"time":"2023-01-02T03:04:05Z","duration":2000000,"method":"POST","url":"/hook?a=b","proto":"HTTP/1.1","requestHeader":{"X-B":["b"],"Content-Type":["application/json"]},"requestBody":"e30=","statusCode":500,"responseBody":"b29wcw==","responseBodyTruncated":true}`
+
+	tests := []struct {
+		Name          string
+		Follow        bool
+		Status        int
+		Body          string
+		Expected      []*api.HTTPCapture
+		ExpectedQuery string
+		ExpectedError string
+	}{
+		{
+			Name:          "no captures",
+			Status:        http.StatusOK,
+			ExpectedQuery: "follow=false",
+		},
+		{
+			Name:   "captures",
+			Follow: true,
+			Status: http.StatusOK,
+			Body:   capture + "\n",
+			Expected: []*api.HTTPCapture{
+				{
+					Id:         1,
+					Time:       1672628645000,
+					DurationMs: 2,
+					Method:     "POST",
+					Url:        "/hook?a=b",
+					Proto:      "HTTP/1.1",
+					RequestHeaders: []*api.HTTPHeader{
+						{Name: "Content-Type", Values: []string{"application/json"}},
+						{Name: "X-B", Values: []string{"b"}},
+					},
+					RequestBody:           []byte("{}"),
+					StatusCode:            500,
+					ResponseBody:          []byte("oops"),
+					ResponseBodyTruncated: true,
+				},
+			},
+		},
+		{
+			Name:          "inspector disabled",
+			Status:        http.StatusNotFound,
+			ExpectedQuery: "follow=false",
+			ExpectedError: "the port inspector is not enabled in this installation",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/_inspect/3000" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				if diff := cmp.Diff(test.ExpectedQuery, r.URL.RawQuery); diff != "" {
+					t.Errorf("unexpected query (-want +got):\n%s", diff)
+				}
+				if tkn := r.Header.Get("x-gitpod-port-inspector-token"); tkn != "inspector-token" {
+					t.Errorf("unexpected port inspector token %q", tkn)
+				}
+				w.WriteHeader(test.Status)
+				_, _ = w.Write([]byte(test.Body))
+			}))
+			defer srv.Close()
+
+			inspector := &portInspector{
+				WorkspaceURL: srv.URL,
+				Token:        "inspector-token",
+				Client:       srv.Client(),
+			}
+			var act []*api.HTTPCapture
+			err := inspector.Inspect(context.Background(), 3000, test.Follow, func(c *api.HTTPCapture) error {
+				act = append(act, c)
+				return nil
+			})
+
+			var errMsg string
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if diff := cmp.Diff(test.ExpectedError, errMsg); diff != "" {
+				t.Errorf("unexpected error (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.Expected, act, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected captures (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
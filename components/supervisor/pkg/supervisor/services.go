@@ -825,6 +825,7 @@ func (state *InMemoryContentState) ContentSource() (src csapi.WorkspaceInitSourc
 
 type portService struct {
 	portsManager *ports.Manager
+	inspector    *portInspector
 
 	api.UnimplementedPortServiceServer
 }
@@ -935,6 +936,23 @@ func (s *portService) RetryAutoExpose(ctx context.Context, req *api.RetryAutoExp
 	return &api.RetryAutoExposeResponse{}, nil
 }
 
+// InspectPort streams the HTTP requests made to an exposed port.
+func (s *portService) InspectPort(req *api.InspectPortRequest, srv api.PortService_InspectPortServer) error {
+	if req.Port == 0 {
+		return status.Error(codes.InvalidArgument, "port is required")
+	}
+	err := s.inspector.Inspect(srv.Context(), req.Port, req.Follow, func(c *api.HTTPCapture) error {
+		return srv.Send(&api.InspectPortResponse{Capture: c})
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return nil
+}
+
 // ResourcesStatus provides workspace resources status information.
 func (s *statusService) ResourcesStatus(ctx context.Context, in *api.ResourcesStatuRequest) (*api.ResourcesStatusResponse, error) {
 	return s.topService.data, nil
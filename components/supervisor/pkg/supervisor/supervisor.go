@@ -236,8 +236,10 @@ func Run(options ...RunOption) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	internalPorts := []uint32{uint32(cfg.IDEPort), uint32(cfg.APIEndpointPort), uint32(cfg.SSHPort)}
+	internalPorts = append(internalPorts, cfg.IDE.InternalPorts...)
 	if cfg.DesktopIDE != nil {
 		internalPorts = append(internalPorts, desktopIDEPort)
+		internalPorts = append(internalPorts, cfg.DesktopIDE.InternalPorts...)
 	}
 
 	endpoint, host, err := cfg.GitpodAPIEndpoint()
@@ -358,7 +360,7 @@ func Run(options ...RunOption) {
 		notificationService,
 		&InfoService{cfg: cfg, ContentState: cstate},
 		&ControlService{portsManager: portMgmt},
-		&portService{portsManager: portMgmt},
+		&portService{portsManager: portMgmt, inspector: &portInspector{WorkspaceURL: cfg.WorkspaceUrl, Token: cfg.PortInspectorToken}},
 	}
 	apiServices = append(apiServices, additionalServices...)
 
@@ -1328,7 +1330,12 @@ func tunnelOverWebSocket(tunneled *ports.TunneledPortsService, conn *gitpod.Webs
 	go ssh.DiscardRequests(reqs)
 	go func() {
 		for ch := range chans {
-			go tunnelOverSSH(conn.Ctx, tunneled, ch)
+			switch ch.ChannelType() {
+			case "direct-tcpip":
+				go dialOverSSH(conn.Ctx, ch)
+			default:
+				go tunnelOverSSH(conn.Ctx, tunneled, ch)
+			}
 		}
 	}()
 	err = sshConn.Wait()
@@ -1383,6 +1390,56 @@ func tunnelOverSSH(ctx context.Context, tunneled *ports.TunneledPortsService, ne
 	<-ctx.Done()
 }
 
+// directTCPIPRequest is the payload of a direct-tcpip channel, see RFC 4254 section 7.2
+type directTCPIPRequest struct {
+	Host       string
+	Port       uint32
+	OriginHost string
+	OriginPort uint32
+}
+
+// dialOverSSH connects the channel to a host resolved and dialed from within the workspace,
+// e.g. for a SOCKS proxy running on the client side of the tunnel.
+func dialOverSSH(ctx context.Context, newCh ssh.NewChannel) {
+	var req directTCPIPRequest
+	err := ssh.Unmarshal(newCh.ExtraData(), &req)
+	if err != nil {
+		log.WithError(err).Error("tunnel: invalid direct-tcpip request")
+		_ = newCh.Reject(ssh.Prohibited, err.Error())
+		return
+	}
+
+	addr := net.JoinHostPort(req.Host, strconv.FormatUint(uint64(req.Port), 10))
+	dialer := net.Dialer{Timeout: 30 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		log.WithError(err).WithField("addr", addr).Debug("tunnel: failed to dial")
+		_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	defer conn.Close()
+	log.WithField("addr", addr).Debug("tunnel: dialed new connection")
+	defer log.WithField("addr", addr).Debug("tunnel: dialed connection closed")
+
+	sshChan, reqs, err := newCh.Accept()
+	if err != nil {
+		log.WithError(err).Error("tunnel: accepting ssh channel failed")
+		return
+	}
+	defer sshChan.Close()
+	go ssh.DiscardRequests(reqs)
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		_, _ = io.Copy(sshChan, conn)
+		cancel()
+	}()
+	go func() {
+		_, _ = io.Copy(conn, sshChan)
+		cancel()
+	}()
+	<-ctx.Done()
+}
+
 func stopWhenTasksAreDone(ctx context.Context, wg *sync.WaitGroup, shutdown chan ShutdownReason, successChan <-chan taskSuccess) {
 	defer wg.Done()
 	defer close(shutdown)
@@ -30,12 +30,19 @@ type UsageServiceClient interface {
 	GetCostCenter(ctx context.Context, in *GetCostCenterRequest, opts ...grpc.CallOption) (*GetCostCenterResponse, error)
 	// SetCostCenter stores the given cost center
 	SetCostCenter(ctx context.Context, in *SetCostCenterRequest, opts ...grpc.CallOption) (*SetCostCenterResponse, error)
+	// SetNestedSpendingLimit creates or updates the spending limit of a project or a user within a team's cost center
+	SetNestedSpendingLimit(ctx context.Context, in *SetNestedSpendingLimitRequest, opts ...grpc.CallOption) (*SetNestedSpendingLimitResponse, error)
+	// DeleteNestedSpendingLimit removes the spending limit of a project or a user within a team's cost center
+	DeleteNestedSpendingLimit(ctx context.Context, in *DeleteNestedSpendingLimitRequest, opts ...grpc.CallOption) (*DeleteNestedSpendingLimitResponse, error)
 	// Triggers reconciliation of usage.
 	ReconcileUsage(ctx context.Context, in *ReconcileUsageRequest, opts ...grpc.CallOption) (*ReconcileUsageResponse, error)
 	// ResetUsage resets Usage for CostCenters which have expired or will explire shortly
 	ResetUsage(ctx context.Context, in *ResetUsageRequest, opts ...grpc.CallOption) (*ResetUsageResponse, error)
 	// ListUsage retrieves all usage for the specified attributionId and theb given time range
 	ListUsage(ctx context.Context, in *ListUsageRequest, opts ...grpc.CallOption) (*ListUsageResponse, error)
+	// ExportUsage streams the usage of the specified attributionId and time range as a CSV or Parquet file,
+	// grouped by project, user and workspace class
+	ExportUsage(ctx context.Context, in *ExportUsageRequest, opts ...grpc.CallOption) (UsageService_ExportUsageClient, error)
 	// GetBalance returns the current credits balance for the given attributionId
 	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
 	// AddUsageCreditNote adds a usage credit note to the given cost center with the effective date of now
@@ -68,6 +75,24 @@ func (c *usageServiceClient) SetCostCenter(ctx context.Context, in *SetCostCente
 	return out, nil
 }
 
+func (c *usageServiceClient) SetNestedSpendingLimit(ctx context.Context, in *SetNestedSpendingLimitRequest, opts ...grpc.CallOption) (*SetNestedSpendingLimitResponse, error) {
+	out := new(SetNestedSpendingLimitResponse)
+	err := c.cc.Invoke(ctx, "/usage.v1.UsageService/SetNestedSpendingLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usageServiceClient) DeleteNestedSpendingLimit(ctx context.Context, in *DeleteNestedSpendingLimitRequest, opts ...grpc.CallOption) (*DeleteNestedSpendingLimitResponse, error) {
+	out := new(DeleteNestedSpendingLimitResponse)
+	err := c.cc.Invoke(ctx, "/usage.v1.UsageService/DeleteNestedSpendingLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *usageServiceClient) ReconcileUsage(ctx context.Context, in *ReconcileUsageRequest, opts ...grpc.CallOption) (*ReconcileUsageResponse, error) {
 	out := new(ReconcileUsageResponse)
 	err := c.cc.Invoke(ctx, "/usage.v1.UsageService/ReconcileUsage", in, out, opts...)
@@ -95,6 +120,38 @@ func (c *usageServiceClient) ListUsage(ctx context.Context, in *ListUsageRequest
 	return out, nil
 }
 
+func (c *usageServiceClient) ExportUsage(ctx context.Context, in *ExportUsageRequest, opts ...grpc.CallOption) (UsageService_ExportUsageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UsageService_ServiceDesc.Streams[0], "/usage.v1.UsageService/ExportUsage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &usageServiceExportUsageClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UsageService_ExportUsageClient interface {
+	Recv() (*ExportUsageResponse, error)
+	grpc.ClientStream
+}
+
+type usageServiceExportUsageClient struct {
+	grpc.ClientStream
+}
+
+func (x *usageServiceExportUsageClient) Recv() (*ExportUsageResponse, error) {
+	m := new(ExportUsageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *usageServiceClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
 	out := new(GetBalanceResponse)
 	err := c.cc.Invoke(ctx, "/usage.v1.UsageService/GetBalance", in, out, opts...)
@@ -121,12 +178,19 @@ type UsageServiceServer interface {
 	GetCostCenter(context.Context, *GetCostCenterRequest) (*GetCostCenterResponse, error)
 	// SetCostCenter stores the given cost center
 	SetCostCenter(context.Context, *SetCostCenterRequest) (*SetCostCenterResponse, error)
+	// SetNestedSpendingLimit creates or updates the spending limit of a project or a user within a team's cost center
+	SetNestedSpendingLimit(context.Context, *SetNestedSpendingLimitRequest) (*SetNestedSpendingLimitResponse, error)
+	// DeleteNestedSpendingLimit removes the spending limit of a project or a user within a team's cost center
+	DeleteNestedSpendingLimit(context.Context, *DeleteNestedSpendingLimitRequest) (*DeleteNestedSpendingLimitResponse, error)
 	// Triggers reconciliation of usage.
 	ReconcileUsage(context.Context, *ReconcileUsageRequest) (*ReconcileUsageResponse, error)
 	// ResetUsage resets Usage for CostCenters which have expired or will explire shortly
 	ResetUsage(context.Context, *ResetUsageRequest) (*ResetUsageResponse, error)
 	// ListUsage retrieves all usage for the specified attributionId and theb given time range
 	ListUsage(context.Context, *ListUsageRequest) (*ListUsageResponse, error)
+	// ExportUsage streams the usage of the specified attributionId and time range as a CSV or Parquet file,
+	// grouped by project, user and workspace class
+	ExportUsage(*ExportUsageRequest, UsageService_ExportUsageServer) error
 	// GetBalance returns the current credits balance for the given attributionId
 	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
 	// AddUsageCreditNote adds a usage credit note to the given cost center with the effective date of now
@@ -144,6 +208,12 @@ func (UnimplementedUsageServiceServer) GetCostCenter(context.Context, *GetCostCe
 func (UnimplementedUsageServiceServer) SetCostCenter(context.Context, *SetCostCenterRequest) (*SetCostCenterResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetCostCenter not implemented")
 }
+func (UnimplementedUsageServiceServer) SetNestedSpendingLimit(context.Context, *SetNestedSpendingLimitRequest) (*SetNestedSpendingLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetNestedSpendingLimit not implemented")
+}
+func (UnimplementedUsageServiceServer) DeleteNestedSpendingLimit(context.Context, *DeleteNestedSpendingLimitRequest) (*DeleteNestedSpendingLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteNestedSpendingLimit not implemented")
+}
 func (UnimplementedUsageServiceServer) ReconcileUsage(context.Context, *ReconcileUsageRequest) (*ReconcileUsageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReconcileUsage not implemented")
 }
@@ -153,6 +223,9 @@ func (UnimplementedUsageServiceServer) ResetUsage(context.Context, *ResetUsageRe
 func (UnimplementedUsageServiceServer) ListUsage(context.Context, *ListUsageRequest) (*ListUsageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUsage not implemented")
 }
+func (UnimplementedUsageServiceServer) ExportUsage(*ExportUsageRequest, UsageService_ExportUsageServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUsage not implemented")
+}
 func (UnimplementedUsageServiceServer) GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
 }
@@ -208,6 +281,42 @@ func _UsageService_SetCostCenter_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UsageService_SetNestedSpendingLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetNestedSpendingLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsageServiceServer).SetNestedSpendingLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usage.v1.UsageService/SetNestedSpendingLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsageServiceServer).SetNestedSpendingLimit(ctx, req.(*SetNestedSpendingLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsageService_DeleteNestedSpendingLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNestedSpendingLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsageServiceServer).DeleteNestedSpendingLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/usage.v1.UsageService/DeleteNestedSpendingLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsageServiceServer).DeleteNestedSpendingLimit(ctx, req.(*DeleteNestedSpendingLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UsageService_ReconcileUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReconcileUsageRequest)
 	if err := dec(in); err != nil {
@@ -262,6 +371,27 @@ func _UsageService_ListUsage_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UsageService_ExportUsage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUsageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UsageServiceServer).ExportUsage(m, &usageServiceExportUsageServer{stream})
+}
+
+type UsageService_ExportUsageServer interface {
+	Send(*ExportUsageResponse) error
+	grpc.ServerStream
+}
+
+type usageServiceExportUsageServer struct {
+	grpc.ServerStream
+}
+
+func (x *usageServiceExportUsageServer) Send(m *ExportUsageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _UsageService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetBalanceRequest)
 	if err := dec(in); err != nil {
@@ -313,6 +443,14 @@ var UsageService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetCostCenter",
 			Handler:    _UsageService_SetCostCenter_Handler,
 		},
+		{
+			MethodName: "SetNestedSpendingLimit",
+			Handler:    _UsageService_SetNestedSpendingLimit_Handler,
+		},
+		{
+			MethodName: "DeleteNestedSpendingLimit",
+			Handler:    _UsageService_DeleteNestedSpendingLimit_Handler,
+		},
 		{
 			MethodName: "ReconcileUsage",
 			Handler:    _UsageService_ReconcileUsage_Handler,
@@ -334,6 +472,12 @@ var UsageService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _UsageService_AddUsageCreditNote_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportUsage",
+			Handler:       _UsageService_ExportUsage_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "usage/v1/usage.proto",
 }
@@ -22,6 +22,13 @@ export interface ReconcileUsageRequest {
 }
 
 export interface ReconcileUsageResponse {
+  /** exceeded_spending_limits are the nested spending limits which are exceeded while workspaces of their scope are running */
+  exceededSpendingLimits: ExceededSpendingLimit[];
+}
+
+export interface ExceededSpendingLimit {
+  attributionId: string;
+  spendingLimit: NestedSpendingLimit | undefined;
 }
 
 export interface PaginatedRequest {
@@ -108,6 +115,67 @@ export interface ListUsageResponse {
   creditsUsed: number;
 }
 
+export interface ExportUsageRequest {
+  attributionId: string;
+  /** from specifies the start of the time range, inclusive. */
+  from:
+    | Date
+    | undefined;
+  /** to specifies the end of the time range, exclusive. */
+  to: Date | undefined;
+  format: ExportUsageRequest_Format;
+}
+
+export enum ExportUsageRequest_Format {
+  FORMAT_CSV = "FORMAT_CSV",
+  FORMAT_PARQUET = "FORMAT_PARQUET",
+  UNRECOGNIZED = "UNRECOGNIZED",
+}
+
+export function exportUsageRequest_FormatFromJSON(object: any): ExportUsageRequest_Format {
+  switch (object) {
+    case 0:
+    case "FORMAT_CSV":
+      return ExportUsageRequest_Format.FORMAT_CSV;
+    case 1:
+    case "FORMAT_PARQUET":
+      return ExportUsageRequest_Format.FORMAT_PARQUET;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return ExportUsageRequest_Format.UNRECOGNIZED;
+  }
+}
+
+export function exportUsageRequest_FormatToJSON(object: ExportUsageRequest_Format): string {
+  switch (object) {
+    case ExportUsageRequest_Format.FORMAT_CSV:
+      return "FORMAT_CSV";
+    case ExportUsageRequest_Format.FORMAT_PARQUET:
+      return "FORMAT_PARQUET";
+    case ExportUsageRequest_Format.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export function exportUsageRequest_FormatToNumber(object: ExportUsageRequest_Format): number {
+  switch (object) {
+    case ExportUsageRequest_Format.FORMAT_CSV:
+      return 0;
+    case ExportUsageRequest_Format.FORMAT_PARQUET:
+      return 1;
+    case ExportUsageRequest_Format.UNRECOGNIZED:
+    default:
+      return -1;
+  }
+}
+
+export interface ExportUsageResponse {
+  /** chunk is the next part of the exported file */
+  chunk: Uint8Array;
+}
+
 export interface Usage {
   id: string;
   attributionId: string;
@@ -179,6 +247,12 @@ export interface GetBalanceRequest {
 
 export interface GetBalanceResponse {
   credits: number;
+  /** forecast_credits is the balance projected to the end of the billing cycle, based on the recent usage trend */
+  forecastCredits: number;
+  /** spending_limit is the spending limit of the cost center */
+  spendingLimit: number;
+  /** billing_cycle_end is the end of the billing cycle the forecast projects to */
+  billingCycleEnd: Date | undefined;
 }
 
 export interface GetCostCenterRequest {
@@ -195,7 +269,11 @@ export interface CostCenter {
   billingStrategy: CostCenter_BillingStrategy;
   /** next_billing_time specifies when the next billing cycle happens. Only set when billing strategy is 'other'. This property is readonly. */
   nextBillingTime: Date | undefined;
-  billingCycleStart: Date | undefined;
+  billingCycleStart:
+    | Date
+    | undefined;
+  /** nested_spending_limits limit the spending of projects and users within a team's cost center */
+  nestedSpendingLimits: NestedSpendingLimit[];
 }
 
 export enum CostCenter_BillingStrategy {
@@ -243,6 +321,79 @@ export function costCenter_BillingStrategyToNumber(object: CostCenter_BillingStr
   }
 }
 
+export interface NestedSpendingLimit {
+  scope: NestedSpendingLimit_Scope;
+  /** scope_id is the ID of the project or user */
+  scopeId: string;
+  spendingLimit: number;
+  /** credits_used is the usage of the scope in the current billing cycle. This property is readonly. */
+  creditsUsed: number;
+}
+
+export enum NestedSpendingLimit_Scope {
+  SCOPE_PROJECT = "SCOPE_PROJECT",
+  SCOPE_USER = "SCOPE_USER",
+  UNRECOGNIZED = "UNRECOGNIZED",
+}
+
+export function nestedSpendingLimit_ScopeFromJSON(object: any): NestedSpendingLimit_Scope {
+  switch (object) {
+    case 0:
+    case "SCOPE_PROJECT":
+      return NestedSpendingLimit_Scope.SCOPE_PROJECT;
+    case 1:
+    case "SCOPE_USER":
+      return NestedSpendingLimit_Scope.SCOPE_USER;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return NestedSpendingLimit_Scope.UNRECOGNIZED;
+  }
+}
+
+export function nestedSpendingLimit_ScopeToJSON(object: NestedSpendingLimit_Scope): string {
+  switch (object) {
+    case NestedSpendingLimit_Scope.SCOPE_PROJECT:
+      return "SCOPE_PROJECT";
+    case NestedSpendingLimit_Scope.SCOPE_USER:
+      return "SCOPE_USER";
+    case NestedSpendingLimit_Scope.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export function nestedSpendingLimit_ScopeToNumber(object: NestedSpendingLimit_Scope): number {
+  switch (object) {
+    case NestedSpendingLimit_Scope.SCOPE_PROJECT:
+      return 0;
+    case NestedSpendingLimit_Scope.SCOPE_USER:
+      return 1;
+    case NestedSpendingLimit_Scope.UNRECOGNIZED:
+    default:
+      return -1;
+  }
+}
+
+export interface SetNestedSpendingLimitRequest {
+  attributionId: string;
+  spendingLimit: NestedSpendingLimit | undefined;
+}
+
+export interface SetNestedSpendingLimitResponse {
+  costCenter: CostCenter | undefined;
+}
+
+export interface DeleteNestedSpendingLimitRequest {
+  attributionId: string;
+  scope: NestedSpendingLimit_Scope;
+  scopeId: string;
+}
+
+export interface DeleteNestedSpendingLimitResponse {
+  costCenter: CostCenter | undefined;
+}
+
 export interface ResetUsageRequest {
 }
 
@@ -321,11 +472,14 @@ export const ReconcileUsageRequest = {
 };
 
 function createBaseReconcileUsageResponse(): ReconcileUsageResponse {
-  return {};
+  return { exceededSpendingLimits: [] };
 }
 
 export const ReconcileUsageResponse = {
-  encode(_: ReconcileUsageResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  encode(message: ReconcileUsageResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.exceededSpendingLimits) {
+      ExceededSpendingLimit.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -336,6 +490,9 @@ export const ReconcileUsageResponse = {
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
+        case 1:
+          message.exceededSpendingLimits.push(ExceededSpendingLimit.decode(reader, reader.uint32()));
+          break;
         default:
           reader.skipType(tag & 7);
           break;
@@ -344,17 +501,91 @@ export const ReconcileUsageResponse = {
     return message;
   },
 
-  fromJSON(_: any): ReconcileUsageResponse {
-    return {};
+  fromJSON(object: any): ReconcileUsageResponse {
+    return {
+      exceededSpendingLimits: Array.isArray(object?.exceededSpendingLimits)
+        ? object.exceededSpendingLimits.map((e: any) => ExceededSpendingLimit.fromJSON(e))
+        : [],
+    };
   },
 
-  toJSON(_: ReconcileUsageResponse): unknown {
+  toJSON(message: ReconcileUsageResponse): unknown {
     const obj: any = {};
+    if (message.exceededSpendingLimits) {
+      obj.exceededSpendingLimits = message.exceededSpendingLimits.map((e) =>
+        e ? ExceededSpendingLimit.toJSON(e) : undefined
+      );
+    } else {
+      obj.exceededSpendingLimits = [];
+    }
     return obj;
   },
 
-  fromPartial(_: DeepPartial<ReconcileUsageResponse>): ReconcileUsageResponse {
+  fromPartial(object: DeepPartial<ReconcileUsageResponse>): ReconcileUsageResponse {
     const message = createBaseReconcileUsageResponse();
+    message.exceededSpendingLimits = object.exceededSpendingLimits?.map((e) => ExceededSpendingLimit.fromPartial(e)) ||
+      [];
+    return message;
+  },
+};
+
+function createBaseExceededSpendingLimit(): ExceededSpendingLimit {
+  return { attributionId: "", spendingLimit: undefined };
+}
+
+export const ExceededSpendingLimit = {
+  encode(message: ExceededSpendingLimit, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.attributionId !== "") {
+      writer.uint32(10).string(message.attributionId);
+    }
+    if (message.spendingLimit !== undefined) {
+      NestedSpendingLimit.encode(message.spendingLimit, writer.uint32(18).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ExceededSpendingLimit {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseExceededSpendingLimit();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.attributionId = reader.string();
+          break;
+        case 2:
+          message.spendingLimit = NestedSpendingLimit.decode(reader, reader.uint32());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ExceededSpendingLimit {
+    return {
+      attributionId: isSet(object.attributionId) ? String(object.attributionId) : "",
+      spendingLimit: isSet(object.spendingLimit) ? NestedSpendingLimit.fromJSON(object.spendingLimit) : undefined,
+    };
+  },
+
+  toJSON(message: ExceededSpendingLimit): unknown {
+    const obj: any = {};
+    message.attributionId !== undefined && (obj.attributionId = message.attributionId);
+    message.spendingLimit !== undefined &&
+      (obj.spendingLimit = message.spendingLimit ? NestedSpendingLimit.toJSON(message.spendingLimit) : undefined);
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<ExceededSpendingLimit>): ExceededSpendingLimit {
+    const message = createBaseExceededSpendingLimit();
+    message.attributionId = object.attributionId ?? "";
+    message.spendingLimit = (object.spendingLimit !== undefined && object.spendingLimit !== null)
+      ? NestedSpendingLimit.fromPartial(object.spendingLimit)
+      : undefined;
     return message;
   },
 };
@@ -663,6 +894,132 @@ export const ListUsageResponse = {
   },
 };
 
+function createBaseExportUsageRequest(): ExportUsageRequest {
+  return { attributionId: "", from: undefined, to: undefined, format: ExportUsageRequest_Format.FORMAT_CSV };
+}
+
+export const ExportUsageRequest = {
+  encode(message: ExportUsageRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.attributionId !== "") {
+      writer.uint32(10).string(message.attributionId);
+    }
+    if (message.from !== undefined) {
+      Timestamp.encode(toTimestamp(message.from), writer.uint32(18).fork()).ldelim();
+    }
+    if (message.to !== undefined) {
+      Timestamp.encode(toTimestamp(message.to), writer.uint32(26).fork()).ldelim();
+    }
+    if (message.format !== ExportUsageRequest_Format.FORMAT_CSV) {
+      writer.uint32(32).int32(exportUsageRequest_FormatToNumber(message.format));
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ExportUsageRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseExportUsageRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.attributionId = reader.string();
+          break;
+        case 2:
+          message.from = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          break;
+        case 3:
+          message.to = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          break;
+        case 4:
+          message.format = exportUsageRequest_FormatFromJSON(reader.int32());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ExportUsageRequest {
+    return {
+      attributionId: isSet(object.attributionId) ? String(object.attributionId) : "",
+      from: isSet(object.from) ? fromJsonTimestamp(object.from) : undefined,
+      to: isSet(object.to) ? fromJsonTimestamp(object.to) : undefined,
+      format: isSet(object.format)
+        ? exportUsageRequest_FormatFromJSON(object.format)
+        : ExportUsageRequest_Format.FORMAT_CSV,
+    };
+  },
+
+  toJSON(message: ExportUsageRequest): unknown {
+    const obj: any = {};
+    message.attributionId !== undefined && (obj.attributionId = message.attributionId);
+    message.from !== undefined && (obj.from = message.from.toISOString());
+    message.to !== undefined && (obj.to = message.to.toISOString());
+    message.format !== undefined && (obj.format = exportUsageRequest_FormatToJSON(message.format));
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<ExportUsageRequest>): ExportUsageRequest {
+    const message = createBaseExportUsageRequest();
+    message.attributionId = object.attributionId ?? "";
+    message.from = object.from ?? undefined;
+    message.to = object.to ?? undefined;
+    message.format = object.format ?? ExportUsageRequest_Format.FORMAT_CSV;
+    return message;
+  },
+};
+
+function createBaseExportUsageResponse(): ExportUsageResponse {
+  return { chunk: new Uint8Array() };
+}
+
+export const ExportUsageResponse = {
+  encode(message: ExportUsageResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.chunk.length !== 0) {
+      writer.uint32(10).bytes(message.chunk);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ExportUsageResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseExportUsageResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.chunk = reader.bytes();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ExportUsageResponse {
+    return { chunk: isSet(object.chunk) ? bytesFromBase64(object.chunk) : new Uint8Array() };
+  },
+
+  toJSON(message: ExportUsageResponse): unknown {
+    const obj: any = {};
+    message.chunk !== undefined &&
+      (obj.chunk = base64FromBytes(message.chunk !== undefined ? message.chunk : new Uint8Array()));
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<ExportUsageResponse>): ExportUsageResponse {
+    const message = createBaseExportUsageResponse();
+    message.chunk = object.chunk ?? new Uint8Array();
+    return message;
+  },
+};
+
 function createBaseUsage(): Usage {
   return {
     id: "",
@@ -942,7 +1299,7 @@ export const GetBalanceRequest = {
 };
 
 function createBaseGetBalanceResponse(): GetBalanceResponse {
-  return { credits: 0 };
+  return { credits: 0, forecastCredits: 0, spendingLimit: 0, billingCycleEnd: undefined };
 }
 
 export const GetBalanceResponse = {
@@ -950,6 +1307,15 @@ export const GetBalanceResponse = {
     if (message.credits !== 0) {
       writer.uint32(33).double(message.credits);
     }
+    if (message.forecastCredits !== 0) {
+      writer.uint32(41).double(message.forecastCredits);
+    }
+    if (message.spendingLimit !== 0) {
+      writer.uint32(48).int32(message.spendingLimit);
+    }
+    if (message.billingCycleEnd !== undefined) {
+      Timestamp.encode(toTimestamp(message.billingCycleEnd), writer.uint32(58).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -963,6 +1329,15 @@ export const GetBalanceResponse = {
         case 4:
           message.credits = reader.double();
           break;
+        case 5:
+          message.forecastCredits = reader.double();
+          break;
+        case 6:
+          message.spendingLimit = reader.int32();
+          break;
+        case 7:
+          message.billingCycleEnd = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          break;
         default:
           reader.skipType(tag & 7);
           break;
@@ -972,18 +1347,29 @@ export const GetBalanceResponse = {
   },
 
   fromJSON(object: any): GetBalanceResponse {
-    return { credits: isSet(object.credits) ? Number(object.credits) : 0 };
+    return {
+      credits: isSet(object.credits) ? Number(object.credits) : 0,
+      forecastCredits: isSet(object.forecastCredits) ? Number(object.forecastCredits) : 0,
+      spendingLimit: isSet(object.spendingLimit) ? Number(object.spendingLimit) : 0,
+      billingCycleEnd: isSet(object.billingCycleEnd) ? fromJsonTimestamp(object.billingCycleEnd) : undefined,
+    };
   },
 
   toJSON(message: GetBalanceResponse): unknown {
     const obj: any = {};
     message.credits !== undefined && (obj.credits = message.credits);
+    message.forecastCredits !== undefined && (obj.forecastCredits = message.forecastCredits);
+    message.spendingLimit !== undefined && (obj.spendingLimit = Math.round(message.spendingLimit));
+    message.billingCycleEnd !== undefined && (obj.billingCycleEnd = message.billingCycleEnd.toISOString());
     return obj;
   },
 
   fromPartial(object: DeepPartial<GetBalanceResponse>): GetBalanceResponse {
     const message = createBaseGetBalanceResponse();
     message.credits = object.credits ?? 0;
+    message.forecastCredits = object.forecastCredits ?? 0;
+    message.spendingLimit = object.spendingLimit ?? 0;
+    message.billingCycleEnd = object.billingCycleEnd ?? undefined;
     return message;
   },
 };
@@ -1092,6 +1478,7 @@ function createBaseCostCenter(): CostCenter {
     billingStrategy: CostCenter_BillingStrategy.BILLING_STRATEGY_STRIPE,
     nextBillingTime: undefined,
     billingCycleStart: undefined,
+    nestedSpendingLimits: [],
   };
 }
 
@@ -1112,6 +1499,9 @@ export const CostCenter = {
     if (message.billingCycleStart !== undefined) {
       Timestamp.encode(toTimestamp(message.billingCycleStart), writer.uint32(42).fork()).ldelim();
     }
+    for (const v of message.nestedSpendingLimits) {
+      NestedSpendingLimit.encode(v!, writer.uint32(50).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -1137,6 +1527,9 @@ export const CostCenter = {
         case 5:
           message.billingCycleStart = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
           break;
+        case 6:
+          message.nestedSpendingLimits.push(NestedSpendingLimit.decode(reader, reader.uint32()));
+          break;
         default:
           reader.skipType(tag & 7);
           break;
@@ -1154,6 +1547,9 @@ export const CostCenter = {
         : CostCenter_BillingStrategy.BILLING_STRATEGY_STRIPE,
       nextBillingTime: isSet(object.nextBillingTime) ? fromJsonTimestamp(object.nextBillingTime) : undefined,
       billingCycleStart: isSet(object.billingCycleStart) ? fromJsonTimestamp(object.billingCycleStart) : undefined,
+      nestedSpendingLimits: Array.isArray(object?.nestedSpendingLimits)
+        ? object.nestedSpendingLimits.map((e: any) => NestedSpendingLimit.fromJSON(e))
+        : [],
     };
   },
 
@@ -1165,6 +1561,11 @@ export const CostCenter = {
       (obj.billingStrategy = costCenter_BillingStrategyToJSON(message.billingStrategy));
     message.nextBillingTime !== undefined && (obj.nextBillingTime = message.nextBillingTime.toISOString());
     message.billingCycleStart !== undefined && (obj.billingCycleStart = message.billingCycleStart.toISOString());
+    if (message.nestedSpendingLimits) {
+      obj.nestedSpendingLimits = message.nestedSpendingLimits.map((e) => e ? NestedSpendingLimit.toJSON(e) : undefined);
+    } else {
+      obj.nestedSpendingLimits = [];
+    }
     return obj;
   },
 
@@ -1175,6 +1576,315 @@ export const CostCenter = {
     message.billingStrategy = object.billingStrategy ?? CostCenter_BillingStrategy.BILLING_STRATEGY_STRIPE;
     message.nextBillingTime = object.nextBillingTime ?? undefined;
     message.billingCycleStart = object.billingCycleStart ?? undefined;
+    message.nestedSpendingLimits = object.nestedSpendingLimits?.map((e) => NestedSpendingLimit.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseNestedSpendingLimit(): NestedSpendingLimit {
+  return { scope: NestedSpendingLimit_Scope.SCOPE_PROJECT, scopeId: "", spendingLimit: 0, creditsUsed: 0 };
+}
+
+export const NestedSpendingLimit = {
+  encode(message: NestedSpendingLimit, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.scope !== NestedSpendingLimit_Scope.SCOPE_PROJECT) {
+      writer.uint32(8).int32(nestedSpendingLimit_ScopeToNumber(message.scope));
+    }
+    if (message.scopeId !== "") {
+      writer.uint32(18).string(message.scopeId);
+    }
+    if (message.spendingLimit !== 0) {
+      writer.uint32(24).int32(message.spendingLimit);
+    }
+    if (message.creditsUsed !== 0) {
+      writer.uint32(33).double(message.creditsUsed);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): NestedSpendingLimit {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseNestedSpendingLimit();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.scope = nestedSpendingLimit_ScopeFromJSON(reader.int32());
+          break;
+        case 2:
+          message.scopeId = reader.string();
+          break;
+        case 3:
+          message.spendingLimit = reader.int32();
+          break;
+        case 4:
+          message.creditsUsed = reader.double();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): NestedSpendingLimit {
+    return {
+      scope: isSet(object.scope)
+        ? nestedSpendingLimit_ScopeFromJSON(object.scope)
+        : NestedSpendingLimit_Scope.SCOPE_PROJECT,
+      scopeId: isSet(object.scopeId) ? String(object.scopeId) : "",
+      spendingLimit: isSet(object.spendingLimit) ? Number(object.spendingLimit) : 0,
+      creditsUsed: isSet(object.creditsUsed) ? Number(object.creditsUsed) : 0,
+    };
+  },
+
+  toJSON(message: NestedSpendingLimit): unknown {
+    const obj: any = {};
+    message.scope !== undefined && (obj.scope = nestedSpendingLimit_ScopeToJSON(message.scope));
+    message.scopeId !== undefined && (obj.scopeId = message.scopeId);
+    message.spendingLimit !== undefined && (obj.spendingLimit = Math.round(message.spendingLimit));
+    message.creditsUsed !== undefined && (obj.creditsUsed = message.creditsUsed);
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<NestedSpendingLimit>): NestedSpendingLimit {
+    const message = createBaseNestedSpendingLimit();
+    message.scope = object.scope ?? NestedSpendingLimit_Scope.SCOPE_PROJECT;
+    message.scopeId = object.scopeId ?? "";
+    message.spendingLimit = object.spendingLimit ?? 0;
+    message.creditsUsed = object.creditsUsed ?? 0;
+    return message;
+  },
+};
+
+function createBaseSetNestedSpendingLimitRequest(): SetNestedSpendingLimitRequest {
+  return { attributionId: "", spendingLimit: undefined };
+}
+
+export const SetNestedSpendingLimitRequest = {
+  encode(message: SetNestedSpendingLimitRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.attributionId !== "") {
+      writer.uint32(10).string(message.attributionId);
+    }
+    if (message.spendingLimit !== undefined) {
+      NestedSpendingLimit.encode(message.spendingLimit, writer.uint32(18).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): SetNestedSpendingLimitRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSetNestedSpendingLimitRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.attributionId = reader.string();
+          break;
+        case 2:
+          message.spendingLimit = NestedSpendingLimit.decode(reader, reader.uint32());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SetNestedSpendingLimitRequest {
+    return {
+      attributionId: isSet(object.attributionId) ? String(object.attributionId) : "",
+      spendingLimit: isSet(object.spendingLimit) ? NestedSpendingLimit.fromJSON(object.spendingLimit) : undefined,
+    };
+  },
+
+  toJSON(message: SetNestedSpendingLimitRequest): unknown {
+    const obj: any = {};
+    message.attributionId !== undefined && (obj.attributionId = message.attributionId);
+    message.spendingLimit !== undefined &&
+      (obj.spendingLimit = message.spendingLimit ? NestedSpendingLimit.toJSON(message.spendingLimit) : undefined);
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<SetNestedSpendingLimitRequest>): SetNestedSpendingLimitRequest {
+    const message = createBaseSetNestedSpendingLimitRequest();
+    message.attributionId = object.attributionId ?? "";
+    message.spendingLimit = (object.spendingLimit !== undefined && object.spendingLimit !== null)
+      ? NestedSpendingLimit.fromPartial(object.spendingLimit)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseSetNestedSpendingLimitResponse(): SetNestedSpendingLimitResponse {
+  return { costCenter: undefined };
+}
+
+export const SetNestedSpendingLimitResponse = {
+  encode(message: SetNestedSpendingLimitResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.costCenter !== undefined) {
+      CostCenter.encode(message.costCenter, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): SetNestedSpendingLimitResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSetNestedSpendingLimitResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.costCenter = CostCenter.decode(reader, reader.uint32());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SetNestedSpendingLimitResponse {
+    return { costCenter: isSet(object.costCenter) ? CostCenter.fromJSON(object.costCenter) : undefined };
+  },
+
+  toJSON(message: SetNestedSpendingLimitResponse): unknown {
+    const obj: any = {};
+    message.costCenter !== undefined &&
+      (obj.costCenter = message.costCenter ? CostCenter.toJSON(message.costCenter) : undefined);
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<SetNestedSpendingLimitResponse>): SetNestedSpendingLimitResponse {
+    const message = createBaseSetNestedSpendingLimitResponse();
+    message.costCenter = (object.costCenter !== undefined && object.costCenter !== null)
+      ? CostCenter.fromPartial(object.costCenter)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseDeleteNestedSpendingLimitRequest(): DeleteNestedSpendingLimitRequest {
+  return { attributionId: "", scope: NestedSpendingLimit_Scope.SCOPE_PROJECT, scopeId: "" };
+}
+
+export const DeleteNestedSpendingLimitRequest = {
+  encode(message: DeleteNestedSpendingLimitRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.attributionId !== "") {
+      writer.uint32(10).string(message.attributionId);
+    }
+    if (message.scope !== NestedSpendingLimit_Scope.SCOPE_PROJECT) {
+      writer.uint32(16).int32(nestedSpendingLimit_ScopeToNumber(message.scope));
+    }
+    if (message.scopeId !== "") {
+      writer.uint32(26).string(message.scopeId);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DeleteNestedSpendingLimitRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDeleteNestedSpendingLimitRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.attributionId = reader.string();
+          break;
+        case 2:
+          message.scope = nestedSpendingLimit_ScopeFromJSON(reader.int32());
+          break;
+        case 3:
+          message.scopeId = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DeleteNestedSpendingLimitRequest {
+    return {
+      attributionId: isSet(object.attributionId) ? String(object.attributionId) : "",
+      scope: isSet(object.scope)
+        ? nestedSpendingLimit_ScopeFromJSON(object.scope)
+        : NestedSpendingLimit_Scope.SCOPE_PROJECT,
+      scopeId: isSet(object.scopeId) ? String(object.scopeId) : "",
+    };
+  },
+
+  toJSON(message: DeleteNestedSpendingLimitRequest): unknown {
+    const obj: any = {};
+    message.attributionId !== undefined && (obj.attributionId = message.attributionId);
+    message.scope !== undefined && (obj.scope = nestedSpendingLimit_ScopeToJSON(message.scope));
+    message.scopeId !== undefined && (obj.scopeId = message.scopeId);
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<DeleteNestedSpendingLimitRequest>): DeleteNestedSpendingLimitRequest {
+    const message = createBaseDeleteNestedSpendingLimitRequest();
+    message.attributionId = object.attributionId ?? "";
+    message.scope = object.scope ?? NestedSpendingLimit_Scope.SCOPE_PROJECT;
+    message.scopeId = object.scopeId ?? "";
+    return message;
+  },
+};
+
+function createBaseDeleteNestedSpendingLimitResponse(): DeleteNestedSpendingLimitResponse {
+  return { costCenter: undefined };
+}
+
+export const DeleteNestedSpendingLimitResponse = {
+  encode(message: DeleteNestedSpendingLimitResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.costCenter !== undefined) {
+      CostCenter.encode(message.costCenter, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DeleteNestedSpendingLimitResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDeleteNestedSpendingLimitResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.costCenter = CostCenter.decode(reader, reader.uint32());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DeleteNestedSpendingLimitResponse {
+    return { costCenter: isSet(object.costCenter) ? CostCenter.fromJSON(object.costCenter) : undefined };
+  },
+
+  toJSON(message: DeleteNestedSpendingLimitResponse): unknown {
+    const obj: any = {};
+    message.costCenter !== undefined &&
+      (obj.costCenter = message.costCenter ? CostCenter.toJSON(message.costCenter) : undefined);
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<DeleteNestedSpendingLimitResponse>): DeleteNestedSpendingLimitResponse {
+    const message = createBaseDeleteNestedSpendingLimitResponse();
+    message.costCenter = (object.costCenter !== undefined && object.costCenter !== null)
+      ? CostCenter.fromPartial(object.costCenter)
+      : undefined;
     return message;
   },
 };
@@ -1395,6 +2105,24 @@ export const UsageServiceDefinition = {
       responseStream: false,
       options: {},
     },
+    /** SetNestedSpendingLimit creates or updates the spending limit of a project or a user within a team's cost center */
+    setNestedSpendingLimit: {
+      name: "SetNestedSpendingLimit",
+      requestType: SetNestedSpendingLimitRequest,
+      requestStream: false,
+      responseType: SetNestedSpendingLimitResponse,
+      responseStream: false,
+      options: {},
+    },
+    /** DeleteNestedSpendingLimit removes the spending limit of a project or a user within a team's cost center */
+    deleteNestedSpendingLimit: {
+      name: "DeleteNestedSpendingLimit",
+      requestType: DeleteNestedSpendingLimitRequest,
+      requestStream: false,
+      responseType: DeleteNestedSpendingLimitResponse,
+      responseStream: false,
+      options: {},
+    },
     /** Triggers reconciliation of usage. */
     reconcileUsage: {
       name: "ReconcileUsage",
@@ -1422,6 +2150,18 @@ export const UsageServiceDefinition = {
       responseStream: false,
       options: {},
     },
+    /**
+     * ExportUsage streams the usage of the specified attributionId and time range as a CSV or Parquet file,
+     * grouped by project, user and workspace class
+     */
+    exportUsage: {
+      name: "ExportUsage",
+      requestType: ExportUsageRequest,
+      requestStream: false,
+      responseType: ExportUsageResponse,
+      responseStream: true,
+      options: {},
+    },
     /** GetBalance returns the current credits balance for the given attributionId */
     getBalance: {
       name: "GetBalance",
@@ -1454,6 +2194,16 @@ export interface UsageServiceServiceImplementation<CallContextExt = {}> {
     request: SetCostCenterRequest,
     context: CallContext & CallContextExt,
   ): Promise<DeepPartial<SetCostCenterResponse>>;
+  /** SetNestedSpendingLimit creates or updates the spending limit of a project or a user within a team's cost center */
+  setNestedSpendingLimit(
+    request: SetNestedSpendingLimitRequest,
+    context: CallContext & CallContextExt,
+  ): Promise<DeepPartial<SetNestedSpendingLimitResponse>>;
+  /** DeleteNestedSpendingLimit removes the spending limit of a project or a user within a team's cost center */
+  deleteNestedSpendingLimit(
+    request: DeleteNestedSpendingLimitRequest,
+    context: CallContext & CallContextExt,
+  ): Promise<DeepPartial<DeleteNestedSpendingLimitResponse>>;
   /** Triggers reconciliation of usage. */
   reconcileUsage(
     request: ReconcileUsageRequest,
@@ -1466,6 +2216,14 @@ export interface UsageServiceServiceImplementation<CallContextExt = {}> {
   ): Promise<DeepPartial<ResetUsageResponse>>;
   /** ListUsage retrieves all usage for the specified attributionId and theb given time range */
   listUsage(request: ListUsageRequest, context: CallContext & CallContextExt): Promise<DeepPartial<ListUsageResponse>>;
+  /**
+   * ExportUsage streams the usage of the specified attributionId and time range as a CSV or Parquet file,
+   * grouped by project, user and workspace class
+   */
+  exportUsage(
+    request: ExportUsageRequest,
+    context: CallContext & CallContextExt,
+  ): ServerStreamingMethodResult<DeepPartial<ExportUsageResponse>>;
   /** GetBalance returns the current credits balance for the given attributionId */
   getBalance(
     request: GetBalanceRequest,
@@ -1489,6 +2247,16 @@ export interface UsageServiceClient<CallOptionsExt = {}> {
     request: DeepPartial<SetCostCenterRequest>,
     options?: CallOptions & CallOptionsExt,
   ): Promise<SetCostCenterResponse>;
+  /** SetNestedSpendingLimit creates or updates the spending limit of a project or a user within a team's cost center */
+  setNestedSpendingLimit(
+    request: DeepPartial<SetNestedSpendingLimitRequest>,
+    options?: CallOptions & CallOptionsExt,
+  ): Promise<SetNestedSpendingLimitResponse>;
+  /** DeleteNestedSpendingLimit removes the spending limit of a project or a user within a team's cost center */
+  deleteNestedSpendingLimit(
+    request: DeepPartial<DeleteNestedSpendingLimitRequest>,
+    options?: CallOptions & CallOptionsExt,
+  ): Promise<DeleteNestedSpendingLimitResponse>;
   /** Triggers reconciliation of usage. */
   reconcileUsage(
     request: DeepPartial<ReconcileUsageRequest>,
@@ -1501,6 +2269,14 @@ export interface UsageServiceClient<CallOptionsExt = {}> {
   ): Promise<ResetUsageResponse>;
   /** ListUsage retrieves all usage for the specified attributionId and theb given time range */
   listUsage(request: DeepPartial<ListUsageRequest>, options?: CallOptions & CallOptionsExt): Promise<ListUsageResponse>;
+  /**
+   * ExportUsage streams the usage of the specified attributionId and time range as a CSV or Parquet file,
+   * grouped by project, user and workspace class
+   */
+  exportUsage(
+    request: DeepPartial<ExportUsageRequest>,
+    options?: CallOptions & CallOptionsExt,
+  ): AsyncIterable<ExportUsageResponse>;
   /** GetBalance returns the current credits balance for the given attributionId */
   getBalance(
     request: DeepPartial<GetBalanceRequest>,
@@ -1541,6 +2317,31 @@ var globalThis: any = (() => {
   throw "Unable to locate global object";
 })();
 
+function bytesFromBase64(b64: string): Uint8Array {
+  if (globalThis.Buffer) {
+    return Uint8Array.from(globalThis.Buffer.from(b64, "base64"));
+  } else {
+    const bin = globalThis.atob(b64);
+    const arr = new Uint8Array(bin.length);
+    for (let i = 0; i < bin.length; ++i) {
+      arr[i] = bin.charCodeAt(i);
+    }
+    return arr;
+  }
+}
+
+function base64FromBytes(arr: Uint8Array): string {
+  if (globalThis.Buffer) {
+    return globalThis.Buffer.from(arr).toString("base64");
+  } else {
+    const bin: string[] = [];
+    arr.forEach((byte) => {
+      bin.push(String.fromCharCode(byte));
+    });
+    return globalThis.btoa(bin.join(""));
+  }
+}
+
 type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
 
 export type DeepPartial<T> = T extends Builtin ? T
@@ -1587,3 +2388,5 @@ if (_m0.util.Long !== Long) {
 function isSet(value: any): boolean {
   return value !== null && value !== undefined;
 }
+
+export type ServerStreamingMethodResult<Response> = { [Symbol.asyncIterator](): AsyncIterator<Response, void> };
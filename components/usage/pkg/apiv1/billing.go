@@ -15,6 +15,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/log"
 	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	v1 "github.com/gitpod-io/gitpod/usage-api/v1"
+	"github.com/gitpod-io/gitpod/usage/pkg/billing"
 	"github.com/gitpod-io/gitpod/usage/pkg/stripe"
 	"github.com/google/uuid"
 	stripe_api "github.com/stripe/stripe-go/v72"
@@ -23,9 +24,10 @@ import (
 	"gorm.io/gorm"
 )
 
-func NewBillingService(stripeClient *stripe.Client, conn *gorm.DB, ccManager *db.CostCenterManager, stripePrices stripe.StripePrices) *BillingService {
+// NewBillingService creates a billing service which invoices usage through provider
+func NewBillingService(provider billing.BillingProvider, conn *gorm.DB, ccManager *db.CostCenterManager, stripePrices stripe.StripePrices) *BillingService {
 	return &BillingService{
-		stripeClient: stripeClient,
+		provider:     provider,
 		conn:         conn,
 		ccManager:    ccManager,
 		stripePrices: stripePrices,
@@ -34,30 +36,28 @@ func NewBillingService(stripeClient *stripe.Client, conn *gorm.DB, ccManager *db
 
 type BillingService struct {
 	conn         *gorm.DB
-	stripeClient *stripe.Client
+	provider     billing.BillingProvider
 	ccManager    *db.CostCenterManager
 	stripePrices stripe.StripePrices
 
 	v1.UnimplementedBillingServiceServer
 }
 
+// GetStripeCustomer returns the customer of the billing provider. Customers are looked up in the database first,
+// and stored there when they are found through the billing provider.
 func (s *BillingService) GetStripeCustomer(ctx context.Context, req *v1.GetStripeCustomerRequest) (*v1.GetStripeCustomerResponse, error) {
-
-	storeStripeCustomerAndRespond := func(ctx context.Context, cus *stripe_api.Customer, attributionID db.AttributionID) (*v1.GetStripeCustomerResponse, error) {
-		logger := log.WithField("stripe_customer_id", cus.ID).WithField("attribution_id", attributionID)
-		// Store it in the DB such that subsequent lookups don't need to go to Stripe
-		result, err := s.storeStripeCustomer(ctx, cus, attributionID)
+	storeCustomerAndRespond := func(ctx context.Context, cus *billing.Customer) (*v1.GetStripeCustomerResponse, error) {
+		logger := log.WithField("stripe_customer_id", cus.ID).WithField("attribution_id", cus.AttributionID)
+		// Store it in the DB such that subsequent lookups don't need to go to the billing provider
+		err := s.storeCustomer(ctx, cus)
 		if err != nil {
 			logger.WithError(err).Error("Failed to store stripe customer in the database.")
 
 			// Storing failed, but we don't want to block the caller since we do have the data, return it as a success
-			return &v1.GetStripeCustomerResponse{
-				Customer: convertStripeCustomer(cus),
-			}, nil
 		}
 
 		return &v1.GetStripeCustomerResponse{
-			Customer: result,
+			Customer: convertCustomer(cus),
 		}, nil
 	}
 
@@ -74,12 +74,15 @@ func (s *BillingService) GetStripeCustomer(ctx context.Context, req *v1.GetStrip
 		if err != nil {
 			// We don't yet have it in the DB
 			if errors.Is(err, db.ErrorNotFound) {
-				stripeCustomer, err := s.stripeClient.GetCustomerByAttributionID(ctx, string(attributionID))
+				cus, err := s.provider.GetCustomer(ctx, attributionID)
+				if errors.Is(err, billing.ErrNotFound) {
+					return nil, status.Errorf(codes.NotFound, "Customer for attribution ID %s does not exist", attributionID)
+				}
 				if err != nil {
 					return nil, err
 				}
 
-				return storeStripeCustomerAndRespond(ctx, stripeCustomer, attributionID)
+				return storeCustomerAndRespond(ctx, cus)
 			}
 
 			logger.WithError(err).Error("Failed to lookup stripe customer from DB")
@@ -100,17 +103,15 @@ func (s *BillingService) GetStripeCustomer(ctx context.Context, req *v1.GetStrip
 		if err != nil {
 			// We don't yet have it in the DB
 			if errors.Is(err, db.ErrorNotFound) {
-				stripeCustomer, err := s.stripeClient.GetCustomer(ctx, identifier.StripeCustomerId)
-				if err != nil {
-					return nil, err
+				cus, err := s.provider.GetCustomerByID(ctx, identifier.StripeCustomerId)
+				if errors.Is(err, billing.ErrNotFound) {
+					return nil, status.Errorf(codes.NotFound, "Customer %s does not exist", identifier.StripeCustomerId)
 				}
-
-				attributionID, err := stripe.GetAttributionID(ctx, stripeCustomer)
 				if err != nil {
-					return nil, status.Errorf(codes.Internal, "Failed to parse attribution ID from Stripe customer %s", stripeCustomer.ID)
+					return nil, err
 				}
 
-				return storeStripeCustomerAndRespond(ctx, stripeCustomer, attributionID)
+				return storeCustomerAndRespond(ctx, cus)
 			}
 
 			logger.WithError(err).Error("Failed to lookup stripe customer from DB")
@@ -142,8 +143,8 @@ func (s *BillingService) CreateStripeCustomer(ctx context.Context, req *v1.Creat
 		return nil, status.Error(codes.InvalidArgument, "Invalid name specified")
 	}
 
-	customer, err := s.stripeClient.CreateCustomer(ctx, stripe.CreateCustomerParams{
-		AttributuonID: string(attributionID),
+	customer, err := s.provider.CreateCustomer(ctx, billing.CreateCustomerParams{
+		AttributionID: attributionID,
 		Currency:      req.GetCurrency(),
 		Email:         req.GetEmail(),
 		Name:          req.GetName(),
@@ -153,20 +154,15 @@ func (s *BillingService) CreateStripeCustomer(ctx context.Context, req *v1.Creat
 		return nil, status.Errorf(codes.Internal, "Failed to create stripe customer")
 	}
 
-	err = db.CreateStripeCustomer(ctx, s.conn, db.StripeCustomer{
-		StripeCustomerID: customer.ID,
-		AttributionID:    attributionID,
-		CreationTime:     db.NewVarCharTime(time.Unix(customer.Created, 0)),
-		Currency:         req.GetCurrency(),
-	})
+	err = s.storeCustomer(ctx, customer)
 	if err != nil {
 		log.WithField("attribution_id", attributionID).WithField("stripe_customer_id", customer.ID).WithError(err).Error("Failed to store Stripe Customer in the database.")
-		// We do not return an error to the caller here, as we did manage to create the stripe customer in Stripe and we can proceed with other flows
+		// We do not return an error to the caller here, as we did manage to create the customer with the billing provider and we can proceed with other flows
 		// The StripeCustomer will be backfilled in the DB on the next GetStripeCustomer call by doing a search.
 	}
 
 	return &v1.CreateStripeCustomerResponse{
-		Customer: convertStripeCustomer(customer),
+		Customer: convertCustomer(customer),
 	}, nil
 }
 
@@ -185,37 +181,36 @@ func (s *BillingService) CreateStripeSubscription(ctx context.Context, req *v1.C
 		return nil, err
 	}
 
-	_, err = s.stripeClient.SetDefaultPaymentForCustomer(ctx, customer.Customer.Id, req.SetupIntentId)
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Failed to set default payment for customer ID %s", customer.Customer.Id)
+	// if the customer has a subscription, return error
+	active, err := s.provider.GetActiveSubscription(ctx, customer.Customer.Id)
+	if err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "Customer (%s) already has an active subscription (%s)", attributionID, active.ID)
 	}
-
-	stripeCustomer, err := s.stripeClient.GetCustomer(ctx, customer.Customer.Id)
-	if err != nil {
+	if !errors.Is(err, billing.ErrNotFound) {
 		return nil, err
 	}
-	// if the customer has a subscription, return error
-	for _, subscription := range stripeCustomer.Subscriptions.Data {
-		if subscription.Status != "canceled" {
-			return nil, status.Errorf(codes.AlreadyExists, "Customer (%s) already has an active subscription (%s)", attributionID, subscription.ID)
-		}
-	}
 
-	priceID, err := getPriceIdentifier(attributionID, stripeCustomer, s)
+	priceID, err := s.getPriceIdentifier(attributionID, customer.Customer.Currency)
 	if err != nil {
 		return nil, err
 	}
 
-	var isAutomaticTaxSupported bool
-	if stripeCustomer.Tax != nil {
-		isAutomaticTaxSupported = stripeCustomer.Tax.AutomaticTax == "supported"
+	subscription, err := s.provider.CreateSubscription(ctx, &billing.Customer{
+		ID:            customer.Customer.Id,
+		AttributionID: attributionID,
+		Currency:      customer.Customer.Currency,
+	}, billing.CreateSubscriptionParams{
+		PriceID:        priceID,
+		PaymentSetupID: req.SetupIntentId,
+	})
+	if errors.Is(err, billing.ErrNotSupported) {
+		return nil, status.Errorf(codes.Unimplemented, "The billing provider does not collect payments of customer ID %s", customer.Customer.Id)
 	}
-	if !isAutomaticTaxSupported {
-		log.Warnf("Automatic Stripe tax is not supported for customer %s", stripeCustomer.ID)
+	if errors.Is(err, billing.ErrPaymentSetupFailed) {
+		return nil, status.Errorf(codes.FailedPrecondition, "Failed to set default payment for customer ID %s", customer.Customer.Id)
 	}
-
-	subscription, err := s.stripeClient.CreateSubscription(ctx, stripeCustomer.ID, priceID, isAutomaticTaxSupported)
 	if err != nil {
+		log.WithField("attribution_id", attributionID).WithError(err).Error("Failed to create subscription.")
 		return nil, status.Errorf(codes.Internal, "Failed to create subscription with customer ID %s", customer.Customer.Id)
 	}
 
@@ -226,11 +221,10 @@ func (s *BillingService) CreateStripeSubscription(ctx context.Context, req *v1.C
 	}, nil
 }
 
-func getPriceIdentifier(attributionID db.AttributionID, stripeCustomer *stripe_api.Customer, s *BillingService) (string, error) {
-	preferredCurrency := stripeCustomer.Metadata["preferredCurrency"]
-	if stripeCustomer.Metadata["preferredCurrency"] == "" {
+func (s *BillingService) getPriceIdentifier(attributionID db.AttributionID, preferredCurrency string) (string, error) {
+	if preferredCurrency == "" {
 		log.
-			WithField("stripe_customer_id", stripeCustomer.ID).
+			WithField("attribution_id", attributionID).
 			Warn("No preferred currency set. Defaulting to USD")
 	}
 
@@ -254,7 +248,7 @@ func getPriceIdentifier(attributionID db.AttributionID, stripeCustomer *stripe_a
 		}
 
 	default:
-		return "", status.Errorf(codes.InvalidArgument, "Invalid currency %s for customer ID %s", stripeCustomer.Metadata["preferredCurrency"], stripeCustomer.ID)
+		return "", status.Errorf(codes.InvalidArgument, "Invalid attribution ID %s", attributionID)
 	}
 }
 
@@ -265,20 +259,28 @@ func (s *BillingService) ReconcileInvoices(ctx context.Context, in *v1.Reconcile
 		return nil, status.Errorf(codes.Internal, "Failed to reconcile invoices.")
 	}
 
-	stripeBalances, err := balancesForStripeCostCenters(ctx, s.ccManager, balances)
+	reports, err := usageReportsForBilledCostCenters(ctx, s.ccManager, balances)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to identify stripe balances.")
+		return nil, status.Errorf(codes.Internal, "Failed to identify billed balances.")
 	}
 
-	creditCentsByAttribution := map[db.AttributionID]int64{}
-	for _, balance := range stripeBalances {
-		creditCentsByAttribution[balance.AttributionID] = int64(math.Ceil(balance.CreditCents.ToCredits()))
+	err = s.provider.ReportUsage(ctx, reports)
+	if err != nil {
+		log.WithError(err).Errorf("Failed to report usage to billing provider.")
+		return nil, status.Errorf(codes.Internal, "Failed to report usage to billing provider")
 	}
 
-	err = s.stripeClient.UpdateUsage(ctx, creditCentsByAttribution)
+	due, err := s.provider.ListDueInvoices(ctx, time.Now())
 	if err != nil {
-		log.WithError(err).Errorf("Failed to udpate usage in stripe.")
-		return nil, status.Errorf(codes.Internal, "Failed to update usage in stripe")
+		log.WithError(err).Errorf("Failed to list due invoices.")
+		return nil, status.Errorf(codes.Internal, "Failed to list due invoices")
+	}
+	for _, invoiceID := range due {
+		_, err = s.FinalizeInvoice(ctx, &v1.FinalizeInvoiceRequest{InvoiceId: invoiceID})
+		if err != nil {
+			// the invoice stays open and is finalized on the next run
+			log.WithField("invoice_id", invoiceID).WithError(err).Errorf("Failed to finalize due invoice.")
+		}
 	}
 
 	return &v1.ReconcileInvoicesResponse{}, nil
@@ -291,15 +293,12 @@ func (s *BillingService) FinalizeInvoice(ctx context.Context, in *v1.FinalizeInv
 		return nil, status.Errorf(codes.InvalidArgument, "Missing InvoiceID")
 	}
 
-	invoice, err := s.stripeClient.GetInvoiceWithCustomer(ctx, in.GetInvoiceId())
+	invoice, err := s.provider.FinalizeInvoice(ctx, in.GetInvoiceId())
 	if err != nil {
-		logger.WithError(err).Error("Failed to retrieve invoice from Stripe.")
+		logger.WithError(err).Error("Failed to finalize invoice with billing provider.")
 		return nil, status.Errorf(codes.NotFound, "Failed to get invoice with ID %s: %s", in.GetInvoiceId(), err.Error())
 	}
-	usage, err := InternalComputeInvoiceUsage(ctx, invoice)
-	if err != nil {
-		return nil, err
-	}
+	usage := invoiceUsage(invoice)
 	err = db.InsertUsage(ctx, s.conn, usage)
 	if err != nil {
 		logger.WithError(err).Errorf("Failed to insert Invoice usage record into the db.")
@@ -315,41 +314,29 @@ func (s *BillingService) FinalizeInvoice(ctx context.Context, in *v1.FinalizeInv
 	return &v1.FinalizeInvoiceResponse{}, nil
 }
 
+// InternalComputeInvoiceUsage computes the usage record which balances out the credits billed with a Stripe invoice
 func InternalComputeInvoiceUsage(ctx context.Context, invoice *stripe_api.Invoice) (db.Usage, error) {
-	logger := log.WithField("invoice_id", invoice.ID)
-	attributionID, err := stripe.GetAttributionID(ctx, invoice.Customer)
+	converted, err := stripe.ConvertInvoice(ctx, invoice)
 	if err != nil {
-		return db.Usage{}, err
-	}
-	logger = logger.WithField("attributionID", attributionID)
-	finalizedAt := time.Unix(invoice.StatusTransitions.FinalizedAt, 0)
-
-	logger = logger.
-		WithField("attribution_id", attributionID).
-		WithField("invoice_finalized_at", finalizedAt)
-
-	if invoice.Lines == nil || len(invoice.Lines.Data) == 0 {
-		logger.Errorf("Invoice %s did not contain any lines  so we cannot extract quantity to reflect it in usage.", invoice.ID)
-		return db.Usage{}, status.Errorf(codes.Internal, "Invoice did not contain any lines.")
-	}
-
-	lines := invoice.Lines.Data
-	var creditsOnInvoice int64
-	for _, line := range lines {
-		creditsOnInvoice += line.Quantity
+		log.WithField("invoice_id", invoice.ID).WithError(err).Error("Failed to convert Stripe invoice.")
+		return db.Usage{}, status.Errorf(codes.Internal, "Failed to convert invoice: %s", err.Error())
 	}
+	return invoiceUsage(converted), nil
+}
 
+// invoiceUsage computes the usage record which balances out the credits billed with invoice
+func invoiceUsage(invoice *billing.Invoice) db.Usage {
 	return db.Usage{
 		ID:            uuid.New(),
-		AttributionID: attributionID,
-		Description:   fmt.Sprintf("Invoice %s finalized in Stripe", invoice.ID),
+		AttributionID: invoice.AttributionID,
+		Description:   fmt.Sprintf("Invoice %s finalized", invoice.ID),
 		// Apply negative value of credits to reduce accrued credit usage
-		CreditCents:   db.NewCreditCents(float64(-creditsOnInvoice)),
-		EffectiveTime: db.NewVarCharTime(finalizedAt),
+		CreditCents:   db.NewCreditCents(float64(-invoice.Credits)),
+		EffectiveTime: db.NewVarCharTime(invoice.FinalizedAt),
 		Kind:          db.InvoiceUsageKind,
 		Draft:         false,
 		Metadata:      nil,
-	}, nil
+	}
 }
 
 func (s *BillingService) CancelSubscription(ctx context.Context, in *v1.CancelSubscriptionRequest) (*v1.CancelSubscriptionResponse, error) {
@@ -359,17 +346,12 @@ func (s *BillingService) CancelSubscription(ctx context.Context, in *v1.CancelSu
 		return nil, status.Errorf(codes.InvalidArgument, "subscriptionId is required")
 	}
 
-	subscription, err := s.stripeClient.GetSubscriptionWithCustomer(ctx, in.GetSubscriptionId())
-	if err != nil {
-		return nil, err
-	}
-
-	attributionID, err := stripe.GetAttributionID(ctx, subscription.Customer)
+	subscription, err := s.provider.GetSubscription(ctx, in.GetSubscriptionId())
 	if err != nil {
 		return nil, err
 	}
 
-	costCenter, err := s.ccManager.GetOrCreateCostCenter(ctx, attributionID)
+	costCenter, err := s.ccManager.GetOrCreateCostCenter(ctx, subscription.AttributionID)
 	if err != nil {
 		return nil, err
 	}
@@ -402,19 +384,16 @@ func (s *BillingService) getPriceId(ctx context.Context, attributionId string) s
 		return defaultPriceId
 	}
 
-	stripeCustomer, err := s.stripeClient.GetCustomer(ctx, customer.Customer.Id)
-	if err != nil {
-		log.Errorf("Failed to get customer infromation from stripe for customer ID %s: %s", customer.Customer.Id, err.Error())
-		return defaultPriceId
-	}
-
 	// if the customer has an active subscription, return that information
-	for _, subscription := range stripeCustomer.Subscriptions.Data {
-		if subscription.Status != "canceled" {
-			return subscription.Plan.ID
-		}
+	subscription, err := s.provider.GetActiveSubscription(ctx, customer.Customer.Id)
+	if err == nil && subscription.PriceID != "" {
+		return subscription.PriceID
 	}
-	priceID, err := getPriceIdentifier(attributionID, stripeCustomer, s)
+	if err != nil && !errors.Is(err, billing.ErrNotFound) {
+		log.Errorf("Failed to get active subscription for customer ID %s: %s", customer.Customer.Id, err.Error())
+		return defaultPriceId
+	}
+	priceID, err := s.getPriceIdentifier(attributionID, customer.Customer.Currency)
 	if err != nil {
 		log.Errorf("Failed to get price identifier for attribution ID %s: %s", attributionId, err.Error())
 		return defaultPriceId
@@ -428,11 +407,10 @@ func (s *BillingService) GetPriceInformation(ctx context.Context, req *v1.GetPri
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid attribution ID %s", req.GetAttributionId())
 	}
 	priceID := s.getPriceId(ctx, req.GetAttributionId())
-	price, err := s.stripeClient.GetPriceInformation(ctx, priceID)
+	information, err := s.provider.GetPriceDescription(ctx, priceID)
 	if err != nil {
 		return nil, err
 	}
-	information := price.Metadata["human_readable_description"]
 	if information == "" {
 		information = "No information available"
 	}
@@ -441,26 +419,20 @@ func (s *BillingService) GetPriceInformation(ctx context.Context, req *v1.GetPri
 	}, nil
 }
 
-func (s *BillingService) storeStripeCustomer(ctx context.Context, cus *stripe_api.Customer, attributionID db.AttributionID) (*v1.StripeCustomer, error) {
-	err := db.CreateStripeCustomer(ctx, s.conn, db.StripeCustomer{
+// storeCustomer records a customer of the billing provider in the database
+func (s *BillingService) storeCustomer(ctx context.Context, cus *billing.Customer) error {
+	return db.CreateStripeCustomer(ctx, s.conn, db.StripeCustomer{
 		StripeCustomerID: cus.ID,
-		AttributionID:    attributionID,
-		Currency:         cus.Metadata[stripe.PreferredCurrencyMetadataKey],
-		// We use the original Stripe supplied creation timestamp, this ensures that we stay true to our ordering of customer creation records.
-		CreationTime: db.NewVarCharTime(time.Unix(cus.Created, 0)),
+		AttributionID:    cus.AttributionID,
+		Currency:         cus.Currency,
+		// We use the creation timestamp of the billing provider, this ensures that we stay true to our ordering of customer creation records.
+		CreationTime: db.NewVarCharTime(cus.CreationTime),
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	return &v1.StripeCustomer{
-		Id:       cus.ID,
-		Currency: cus.Metadata[stripe.PreferredCurrencyMetadataKey],
-	}, nil
 }
 
-func balancesForStripeCostCenters(ctx context.Context, cm *db.CostCenterManager, balances []db.Balance) ([]db.Balance, error) {
-	var result []db.Balance
+// usageReportsForBilledCostCenters reports the balances of cost centers which are billed through the billing provider
+func usageReportsForBilledCostCenters(ctx context.Context, cm *db.CostCenterManager, balances []db.Balance) ([]billing.UsageReport, error) {
+	var result []billing.UsageReport
 	for _, balance := range balances {
 		// filter out balances for non-stripe attribution IDs
 		costCenter, err := cm.GetOrCreateCostCenter(ctx, balance.AttributionID)
@@ -468,21 +440,31 @@ func balancesForStripeCostCenters(ctx context.Context, cm *db.CostCenterManager,
 			return nil, err
 		}
 
-		// We only update Stripe usage when the AttributionID is billed against Stripe (determined through CostCenter)
+		// We only report usage when the AttributionID is billed externally (determined through CostCenter)
 		if costCenter.BillingStrategy != db.CostCenter_Stripe {
 			continue
 		}
 
-		result = append(result, balance)
+		cycleStart := costCenter.BillingCycleStart.Time()
+		cycleEnd := costCenter.NextBillingTime.Time()
+		if !costCenter.NextBillingTime.IsSet() {
+			cycleEnd = cycleStart.AddDate(0, 1, 0)
+		}
+		result = append(result, billing.UsageReport{
+			AttributionID:     balance.AttributionID,
+			Credits:           int64(math.Ceil(balance.CreditCents.ToCredits())),
+			BillingCycleStart: cycleStart,
+			BillingCycleEnd:   cycleEnd,
+		})
 	}
 
 	return result, nil
 }
 
-func convertStripeCustomer(customer *stripe_api.Customer) *v1.StripeCustomer {
+func convertCustomer(customer *billing.Customer) *v1.StripeCustomer {
 	return &v1.StripeCustomer{
 		Id:       customer.ID,
-		Currency: customer.Metadata[stripe.PreferredCurrencyMetadataKey],
+		Currency: customer.Currency,
 	}
 }
 
@@ -11,12 +11,17 @@ import (
 
 	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	v1 "github.com/gitpod-io/gitpod/usage-api/v1"
+	"github.com/gitpod-io/gitpod/usage/pkg/billing"
+	"github.com/gitpod-io/gitpod/usage/pkg/stripe"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	stripe_api "github.com/stripe/stripe-go/v72"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func TestBalancesForStripeCostCenters(t *testing.T) {
+func TestUsageReportsForBilledCostCenters(t *testing.T) {
 	attributionIDForStripe := db.NewUserAttributionID(uuid.New().String())
 	attributionIDForOther := db.NewTeamAttributionID(uuid.New().String())
 	dbconn := dbtest.ConnectForTests(t)
@@ -43,10 +48,59 @@ func TestBalancesForStripeCostCenters(t *testing.T) {
 		},
 	}
 
-	stripeBalances, err := balancesForStripeCostCenters(context.Background(), db.NewCostCenterManager(dbconn, db.DefaultSpendingLimit{}), balances)
+	reports, err := usageReportsForBilledCostCenters(context.Background(), db.NewCostCenterManager(dbconn, db.DefaultSpendingLimit{}), balances)
 	require.NoError(t, err)
-	require.Len(t, stripeBalances, 1)
-	require.Equal(t, stripeBalances[0].AttributionID, attributionIDForStripe)
+	require.Len(t, reports, 1)
+	require.Equal(t, reports[0].AttributionID, attributionIDForStripe)
+	require.Equal(t, int64(1), reports[0].Credits)
+}
+
+func TestBillingService_CustomersThroughDocumentProvider(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	t.Cleanup(func() {
+		conn.Where("attributionId = ?", attributionID).Delete(&db.StripeCustomer{})
+		conn.Where("attributionId = ?", attributionID).Delete(&db.BillingSubscription{})
+	})
+
+	provider, err := billing.NewDocumentProviderFromConfig(billing.Config{FileDrop: &billing.FileDropConfig{Directory: t.TempDir()}}, conn)
+	require.NoError(t, err)
+	service := NewBillingService(provider, conn, db.NewCostCenterManager(conn, db.DefaultSpendingLimit{}), stripe.StripePrices{})
+
+	_, err = service.GetStripeCustomer(ctx, &v1.GetStripeCustomerRequest{
+		Identifier: &v1.GetStripeCustomerRequest_AttributionId{AttributionId: string(attributionID)},
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	created, err := service.CreateStripeCustomer(ctx, &v1.CreateStripeCustomerRequest{
+		AttributionId: string(attributionID),
+		Currency:      "EUR",
+		Email:         "billing@example.com",
+		Name:          "ACME",
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(attributionID), created.Customer.Id)
+
+	customer, err := service.GetStripeCustomer(ctx, &v1.GetStripeCustomerRequest{
+		Identifier: &v1.GetStripeCustomerRequest_AttributionId{AttributionId: string(attributionID)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "EUR", customer.Customer.Currency)
+
+	price, err := service.GetPriceInformation(ctx, &v1.GetPriceInformationRequest{AttributionId: string(attributionID)})
+	require.NoError(t, err)
+	require.Equal(t, "No information available", price.HumanReadableDescription)
+
+	_, err = service.CreateStripeSubscription(ctx, &v1.CreateStripeSubscriptionRequest{AttributionId: string(attributionID), SetupIntentId: "seti_123"})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+
+	subscription, err := service.CreateStripeSubscription(ctx, &v1.CreateStripeSubscriptionRequest{AttributionId: string(attributionID)})
+	require.NoError(t, err)
+	require.Equal(t, string(attributionID), subscription.Subscription.Id)
+
+	_, err = service.CreateStripeSubscription(ctx, &v1.CreateStripeSubscriptionRequest{AttributionId: string(attributionID)})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
 }
 
 func TestFinalizeInvoiceForIndividual(t *testing.T) {
@@ -0,0 +1,256 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/usage-api/v1"
+	"github.com/xitongsys/parquet-go/writer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize is the maximum size of a single ExportUsageResponse chunk
+const exportChunkSize = 64 * 1024
+
+// UsageExportRow is the usage of a project, user and workspace class combination
+type UsageExportRow struct {
+	ProjectID          string  `parquet:"name=project_id, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	UserID             string  `parquet:"name=user_id, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	UserName           string  `parquet:"name=user_name, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	WorkspaceClass     string  `parquet:"name=workspace_class, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	WorkspaceInstances int64   `parquet:"name=workspace_instances, type=INT64"`
+	RuntimeSeconds     int64   `parquet:"name=runtime_seconds, type=INT64"`
+	Credits            float64 `parquet:"name=credits, type=DOUBLE"`
+}
+
+var usageExportCSVHeader = []string{
+	"project_id",
+	"user_id",
+	"user_name",
+	"workspace_class",
+	"workspace_instances",
+	"runtime_seconds",
+	"credits",
+}
+
+func (s *UsageService) ExportUsage(in *v1.ExportUsageRequest, stream v1.UsageService_ExportUsageServer) error {
+	ctx := stream.Context()
+
+	if in.From == nil || in.To == nil {
+		return status.Errorf(codes.InvalidArgument, "From and To timestamps are required")
+	}
+	from, to := in.From.AsTime(), in.To.AsTime()
+	if from.After(to) {
+		return status.Errorf(codes.InvalidArgument, "Specified From timestamp is after To. Please ensure From is always before To")
+	}
+	if to.Sub(from) > maxQuerySize {
+		return status.Errorf(codes.InvalidArgument, "Maximum range exceeded. Range specified can be at most %s", maxQuerySize.String())
+	}
+
+	attributionId, err := db.ParseAttributionID(in.AttributionId)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "AttributionID '%s' couldn't be parsed (error: %s).", in.AttributionId, err)
+	}
+
+	logger := log.Log.
+		WithField("attribution_id", attributionId).
+		WithField("from", from).
+		WithField("to", to).
+		WithField("format", in.Format.String())
+
+	records, err := db.FindUsage(ctx, s.conn, &db.FindUsageParams{
+		AttributionId: attributionId,
+		From:          from,
+		To:            to,
+		Order:         db.AscendingOrder,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to list usage for export.")
+		return status.Error(codes.Internal, "unable to retrieve usage")
+	}
+
+	rows, err := aggregateUsage(records)
+	if err != nil {
+		logger.WithError(err).Error("Failed to aggregate usage for export.")
+		return status.Error(codes.Internal, "unable to aggregate usage")
+	}
+
+	w := &chunkWriter{
+		size: exportChunkSize,
+		send: func(chunk []byte) error {
+			return stream.Send(&v1.ExportUsageResponse{Chunk: chunk})
+		},
+	}
+	switch in.Format {
+	case v1.ExportUsageRequest_FORMAT_CSV:
+		err = writeUsageCSV(w, rows)
+	case v1.ExportUsageRequest_FORMAT_PARQUET:
+		err = writeUsageParquet(w, rows)
+	default:
+		return status.Errorf(codes.InvalidArgument, "Unsupported export format %s", in.Format.String())
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		logger.WithError(err).Error("Failed to export usage.")
+		return status.Error(codes.Internal, "unable to export usage")
+	}
+	return nil
+}
+
+type usageExportKey struct {
+	ProjectID      string
+	UserID         string
+	WorkspaceClass string
+}
+
+// aggregateUsage groups workspace instance usage records by project, user and workspace class
+func aggregateUsage(records []db.Usage) ([]UsageExportRow, error) {
+	groups := make(map[usageExportKey]*UsageExportRow)
+	for _, record := range records {
+		metadata, err := record.GetMetadataAsWorkspaceInstanceData()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse metadata of usage record %s: %w", record.ID, err)
+		}
+
+		key := usageExportKey{
+			ProjectID:      metadata.ProjectID,
+			UserID:         metadata.UserID.String(),
+			WorkspaceClass: metadata.WorkspaceClass,
+		}
+		row, ok := groups[key]
+		if !ok {
+			row = &UsageExportRow{
+				ProjectID:      key.ProjectID,
+				UserID:         key.UserID,
+				WorkspaceClass: key.WorkspaceClass,
+			}
+			groups[key] = row
+		}
+		if metadata.UserName != "" {
+			row.UserName = metadata.UserName
+		}
+		row.WorkspaceInstances++
+		row.RuntimeSeconds += runtimeSeconds(record, metadata)
+		row.Credits += record.CreditCents.ToCredits()
+	}
+
+	res := make([]UsageExportRow, 0, len(groups))
+	for _, row := range groups {
+		res = append(res, *row)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].ProjectID != res[j].ProjectID {
+			return res[i].ProjectID < res[j].ProjectID
+		}
+		if res[i].UserID != res[j].UserID {
+			return res[i].UserID < res[j].UserID
+		}
+		return res[i].WorkspaceClass < res[j].WorkspaceClass
+	})
+	return res, nil
+}
+
+// runtimeSeconds computes the runtime of a usage record's workspace instance.
+// Instances which are still running are accounted until the effective time of the record.
+func runtimeSeconds(record db.Usage, metadata db.WorkspaceInstanceUsageData) int64 {
+	start, err := db.NewVarCharTimeFromStr(metadata.StartTime)
+	if err != nil || !start.IsSet() {
+		return 0
+	}
+	end := record.EffectiveTime
+	if stopped, err := db.NewVarCharTimeFromStr(metadata.EndTime); err == nil && stopped.IsSet() {
+		end = stopped
+	}
+	if end.Time().Before(start.Time()) {
+		return 0
+	}
+	return int64(end.Time().Sub(start.Time()) / time.Second)
+}
+
+func writeUsageCSV(w io.Writer, rows []UsageExportRow) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write(usageExportCSVHeader)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		err := cw.Write([]string{
+			row.ProjectID,
+			row.UserID,
+			row.UserName,
+			row.WorkspaceClass,
+			strconv.FormatInt(row.WorkspaceInstances, 10),
+			strconv.FormatInt(row.RuntimeSeconds, 10),
+			strconv.FormatFloat(row.Credits, 'f', -1, 64),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeUsageParquet(w io.Writer, rows []UsageExportRow) error {
+	pw, err := writer.NewParquetWriterFromWriter(w, new(UsageExportRow), 1)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		err := pw.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+	return pw.WriteStop()
+}
+
+// chunkWriter buffers writes and sends them in chunks of at most size bytes
+type chunkWriter struct {
+	size int
+	send func(chunk []byte) error
+	buf  []byte
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		free := w.size - len(w.buf)
+		if free > len(p) {
+			free = len(p)
+		}
+		w.buf = append(w.buf, p[:free]...)
+		p = p[free:]
+
+		if len(w.buf) == w.size {
+			err := w.Flush()
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Flush sends the buffered data
+func (w *chunkWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.send(w.buf)
+	w.buf = nil
+	return err
+}
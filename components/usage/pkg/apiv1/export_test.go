@@ -0,0 +1,112 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestAggregateUsage(t *testing.T) {
+	var (
+		start     = time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+		alice     = uuid.MustParse("b3f5d6a4-4a6e-4c43-9b43-0f3a7c1f2d11")
+		bob       = uuid.MustParse("d1c2b3a4-5e6f-4a7b-8c9d-0e1f2a3b4c5d")
+		newRecord = func(t *testing.T, user uuid.UUID, userName, project, class string, runtime time.Duration, credits float64, running bool) db.Usage {
+			usage := db.Usage{
+				ID:            uuid.New(),
+				CreditCents:   db.NewCreditCents(credits),
+				EffectiveTime: db.NewVarCharTime(start.Add(runtime)),
+				Kind:          db.WorkspaceInstanceUsageKind,
+				Draft:         running,
+			}
+			metadata := db.WorkspaceInstanceUsageData{
+				WorkspaceClass: class,
+				StartTime:      db.TimeToISO8601(start),
+				UserID:         user,
+				UserName:       userName,
+				ProjectID:      project,
+			}
+			if !running {
+				metadata.EndTime = db.TimeToISO8601(start.Add(runtime))
+			}
+			require.NoError(t, usage.SetMetadataWithWorkspaceInstance(metadata))
+			return usage
+		}
+	)
+
+	rows, err := aggregateUsage([]db.Usage{
+		newRecord(t, alice, "alice", "project-b", db.WorkspaceClass_Default, 10*time.Minute, 1.5, false),
+		newRecord(t, alice, "alice", "project-a", db.WorkspaceClass_Default, 20*time.Minute, 3, false),
+		newRecord(t, alice, "alice", "project-a", db.WorkspaceClass_Default, 5*time.Minute, 0.5, true),
+		newRecord(t, alice, "alice", "project-a", "g1-large", 10*time.Minute, 4, false),
+		newRecord(t, bob, "bob", "project-a", db.WorkspaceClass_Default, 30*time.Minute, 5, false),
+		newRecord(t, bob, "bob", "", db.WorkspaceClass_Default, 1*time.Minute, 0.25, false),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []UsageExportRow{
+		{UserID: bob.String(), UserName: "bob", WorkspaceClass: db.WorkspaceClass_Default, WorkspaceInstances: 1, RuntimeSeconds: 60, Credits: 0.25},
+		{ProjectID: "project-a", UserID: alice.String(), UserName: "alice", WorkspaceClass: db.WorkspaceClass_Default, WorkspaceInstances: 2, RuntimeSeconds: 1500, Credits: 3.5},
+		{ProjectID: "project-a", UserID: alice.String(), UserName: "alice", WorkspaceClass: "g1-large", WorkspaceInstances: 1, RuntimeSeconds: 600, Credits: 4},
+		{ProjectID: "project-a", UserID: bob.String(), UserName: "bob", WorkspaceClass: db.WorkspaceClass_Default, WorkspaceInstances: 1, RuntimeSeconds: 1800, Credits: 5},
+		{ProjectID: "project-b", UserID: alice.String(), UserName: "alice", WorkspaceClass: db.WorkspaceClass_Default, WorkspaceInstances: 1, RuntimeSeconds: 600, Credits: 1.5},
+	}, rows)
+}
+
+var testUsageExportRows = []UsageExportRow{
+	{ProjectID: "project-a", UserID: "user-1", UserName: "alice", WorkspaceClass: db.WorkspaceClass_Default, WorkspaceInstances: 2, RuntimeSeconds: 1500, Credits: 3.5},
+	{ProjectID: "project-b", UserID: "user-2", UserName: "bob, jr.", WorkspaceClass: "g1-large", WorkspaceInstances: 1, RuntimeSeconds: 600, Credits: 4},
+}
+
+func TestWriteUsageCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeUsageCSV(&buf, testUsageExportRows)
+	require.NoError(t, err)
+	require.Equal(t, `project_id,user_id,user_name,workspace_class,workspace_instances,runtime_seconds,credits
+project-a,user-1,alice,default,2,1500,3.5
+project-b,user-2,"bob, jr.",g1-large,1,600,4
+`, buf.String())
+}
+
+func TestWriteUsageParquet(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeUsageParquet(&buf, testUsageExportRows)
+	require.NoError(t, err)
+
+	file, err := buffer.NewBufferFile(buf.Bytes())
+	require.NoError(t, err)
+	pr, err := reader.NewParquetReader(file, new(UsageExportRow), 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+
+	actual := make([]UsageExportRow, pr.GetNumRows())
+	require.NoError(t, pr.Read(&actual))
+	require.Equal(t, testUsageExportRows, actual)
+}
+
+func TestChunkWriter(t *testing.T) {
+	var chunks []string
+	w := &chunkWriter{
+		size: 4,
+		send: func(chunk []byte) error {
+			chunks = append(chunks, string(chunk))
+			return nil
+		},
+	}
+
+	_, err := w.Write([]byte("abcdefghij"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("kl"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	require.Equal(t, []string{"abcd", "efgh", "ijkl"}, chunks)
+}
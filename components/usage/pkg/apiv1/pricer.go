@@ -19,6 +19,32 @@ var (
 	DefaultWorkspacePricer, _ = NewWorkspacePricer(map[string]float64{})
 )
 
+// Pricer computes the credits used by workspace instances
+type Pricer interface {
+	// Price returns the credits used by the instance and how they break down into pricing dimensions.
+	// used are the quantities the instance's attribution used before the instance in the current billing cycle.
+	Price(instance *db.WorkspaceInstanceForUsage, stopTimeIfStillRunning time.Time, used Quantities) (credits float64, breakdown db.UsagePriceBreakdown)
+}
+
+// Quantities maps pricing dimensions to quantities
+type Quantities map[string]float64
+
+// Add adds the quantities of a price breakdown
+func (q Quantities) Add(breakdown db.UsagePriceBreakdown) {
+	for _, c := range breakdown.Components {
+		q[c.Dimension] += c.Quantity
+	}
+}
+
+const (
+	workspaceClassPricerName = "workspaceClass"
+
+	// DimensionRuntimeMinutes is the runtime of a workspace instance, priced by its workspace class
+	DimensionRuntimeMinutes = "runtimeMinutes"
+)
+
+var _ Pricer = (*WorkspacePricer)(nil)
+
 func NewWorkspacePricer(creditMinutesByWorkspaceClass map[string]float64) (*WorkspacePricer, error) {
 	return &WorkspacePricer{creditMinutesByWorkspaceClass: creditMinutesByWorkspaceClass}, nil
 }
@@ -27,6 +53,21 @@ type WorkspacePricer struct {
 	creditMinutesByWorkspaceClass map[string]float64
 }
 
+// Price prices the runtime of the instance by its workspace class, regardless of what was used before
+func (p *WorkspacePricer) Price(instance *db.WorkspaceInstanceForUsage, stopTimeIfStillRunning time.Time, _ Quantities) (float64, db.UsagePriceBreakdown) {
+	credits := p.CreditsUsedByInstance(instance, stopTimeIfStillRunning)
+	return credits, db.UsagePriceBreakdown{
+		Pricer: workspaceClassPricerName,
+		Components: []db.UsagePriceComponent{
+			{
+				Dimension: DimensionRuntimeMinutes,
+				Quantity:  float64(instance.WorkspaceRuntimeSeconds(stopTimeIfStillRunning)) / 60,
+				Credits:   credits,
+			},
+		},
+	}
+}
+
 func (p *WorkspacePricer) CreditsUsedByInstance(instance *db.WorkspaceInstanceForUsage, stopTimeIfStillRunning time.Time) float64 {
 	runtime := instance.WorkspaceRuntimeSeconds(stopTimeIfStillRunning)
 	return p.Credits(instance.WorkspaceClass, runtime)
@@ -0,0 +1,240 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+)
+
+const (
+	resourcePricerName = "resource"
+
+	// DimensionCPUSeconds is the CPU time a workspace instance had available
+	DimensionCPUSeconds = "cpuSeconds"
+	// DimensionMemoryGBHours is the memory a workspace instance had available over its runtime
+	DimensionMemoryGBHours = "memoryGBHours"
+	// DimensionStorageGBHours is the disk a workspace instance had available over its runtime
+	DimensionStorageGBHours = "storageGBHours"
+	// DimensionBackupGBHours is the storage of a stopped workspace instance's backup over the backup retention
+	DimensionBackupGBHours = "backupGBHours"
+	// DimensionPrebuildMinutes is the runtime of prebuilds
+	DimensionPrebuildMinutes = "prebuildMinutes"
+)
+
+// resourceDimensions lists all dimensions the ResourcePricer supports, in the order they appear in a price breakdown
+var resourceDimensions = []string{
+	DimensionCPUSeconds,
+	DimensionMemoryGBHours,
+	DimensionStorageGBHours,
+	DimensionBackupGBHours,
+	DimensionPrebuildMinutes,
+}
+
+// ResourcePricingConfig configures the ResourcePricer
+type ResourcePricingConfig struct {
+	// Classes maps workspace classes to the resources they provide. The default class must be configured,
+	// instances of unknown classes are priced using its resources.
+	Classes map[string]WorkspaceClassResources `json:"classes"`
+
+	// BackupRetention is how long the backup of a stopped workspace instance is kept, e.g. until unused
+	// workspaces are garbage collected. Backups are priced as the storage of the instance's class over
+	// this duration once the instance stopped. When empty, backups are free.
+	BackupRetention string `json:"backupRetention,omitempty"`
+
+	// Rates maps pricing dimensions to their rate tiers. Dimensions without rates are free.
+	Rates PricingRates `json:"rates"`
+
+	// Overrides maps organization IDs to rates which replace the default rates of the dimensions they set
+	Overrides map[string]PricingRates `json:"overrides,omitempty"`
+}
+
+// WorkspaceClassResources are the resources a workspace class provides
+type WorkspaceClassResources struct {
+	CPU       float64 `json:"cpu"`
+	MemoryGB  float64 `json:"memoryGB"`
+	StorageGB float64 `json:"storageGB"`
+}
+
+// PricingRates maps pricing dimensions to their rate tiers
+type PricingRates map[string][]RateTier
+
+// RateTier prices the quantity of a dimension up to UpTo. Tiers are graduated, i.e. each tier
+// prices the part of the quantity which falls into it. Tiers apply to the quantity an attribution
+// used in its billing cycle, not to the quantity of a single usage entry.
+type RateTier struct {
+	// UpTo is the upper bound of the tier. Zero means unbounded, which only the last tier may be.
+	UpTo           float64 `json:"upTo,omitempty"`
+	CreditsPerUnit float64 `json:"creditsPerUnit"`
+}
+
+func (r PricingRates) validate() error {
+	for dim, tiers := range r {
+		if !isResourceDimension(dim) {
+			return fmt.Errorf("unknown pricing dimension %q", dim)
+		}
+		if len(tiers) == 0 {
+			return fmt.Errorf("dimension %q has no rate tiers", dim)
+		}
+		for i, tier := range tiers {
+			if tier.CreditsPerUnit < 0 {
+				return fmt.Errorf("dimension %q: tier %d has a negative rate", dim, i)
+			}
+			last := i == len(tiers)-1
+			if last && tier.UpTo != 0 {
+				return fmt.Errorf("dimension %q: the last tier must be unbounded", dim)
+			}
+			if !last && (tier.UpTo <= 0 || (i > 0 && tier.UpTo <= tiers[i-1].UpTo)) {
+				return fmt.Errorf("dimension %q: tier %d must have an upper bound greater than the previous tier's", dim, i)
+			}
+		}
+	}
+	return nil
+}
+
+func isResourceDimension(dim string) bool {
+	for _, d := range resourceDimensions {
+		if d == dim {
+			return true
+		}
+	}
+	return false
+}
+
+// priceAfter computes the credits of a quantity which is used after used has already been used
+func priceAfter(tiers []RateTier, used, quantity float64) float64 {
+	return price(tiers, used+quantity) - price(tiers, used)
+}
+
+// price computes the credits of a quantity across graduated tiers
+func price(tiers []RateTier, quantity float64) float64 {
+	var (
+		credits float64
+		lower   float64
+	)
+	for _, tier := range tiers {
+		if quantity <= lower {
+			break
+		}
+		upper := quantity
+		if tier.UpTo != 0 && tier.UpTo < quantity {
+			upper = tier.UpTo
+		}
+		credits += (upper - lower) * tier.CreditsPerUnit
+		lower = upper
+	}
+	return credits
+}
+
+func NewResourcePricer(cfg ResourcePricingConfig) (*ResourcePricer, error) {
+	if _, ok := cfg.Classes[db.WorkspaceClass_Default]; !ok {
+		return nil, fmt.Errorf("no resources configured for the %q workspace class", db.WorkspaceClass_Default)
+	}
+	var backupRetention time.Duration
+	if cfg.BackupRetention != "" {
+		var err error
+		backupRetention, err = time.ParseDuration(cfg.BackupRetention)
+		if err != nil {
+			return nil, fmt.Errorf("invalid backup retention: %w", err)
+		}
+		if backupRetention < 0 {
+			return nil, fmt.Errorf("backup retention cannot be negative")
+		}
+	}
+
+	err := cfg.Rates.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid rates: %w", err)
+	}
+	for org, rates := range cfg.Overrides {
+		err := rates.validate()
+		if err != nil {
+			return nil, fmt.Errorf("invalid rates for organization %s: %w", org, err)
+		}
+	}
+	return &ResourcePricer{cfg: cfg, backupRetention: backupRetention}, nil
+}
+
+var _ Pricer = (*ResourcePricer)(nil)
+
+// ResourcePricer prices workspace instances by the resources they had available
+type ResourcePricer struct {
+	cfg             ResourcePricingConfig
+	backupRetention time.Duration
+}
+
+// Price implements Pricer
+func (p *ResourcePricer) Price(instance *db.WorkspaceInstanceForUsage, stopTimeIfStillRunning time.Time, used Quantities) (float64, db.UsagePriceBreakdown) {
+	var (
+		seconds   = float64(instance.WorkspaceRuntimeSeconds(stopTimeIfStillRunning))
+		hours     = seconds / 3600
+		resources = p.resourcesForClass(instance.WorkspaceClass)
+		rates     = p.ratesFor(instance.UsageAttributionID)
+	)
+	quantities := map[string]float64{
+		DimensionCPUSeconds:     resources.CPU * seconds,
+		DimensionMemoryGBHours:  resources.MemoryGB * hours,
+		DimensionStorageGBHours: resources.StorageGB * hours,
+	}
+	stopped := instance.StoppingTime.IsSet() || instance.StoppedTime.IsSet()
+	if stopped && p.backupRetention > 0 {
+		quantities[DimensionBackupGBHours] = resources.StorageGB * p.backupRetention.Hours()
+	}
+	if instance.Type == db.WorkspaceType_Prebuild {
+		quantities[DimensionPrebuildMinutes] = seconds / 60
+	}
+
+	var (
+		credits   float64
+		breakdown = db.UsagePriceBreakdown{Pricer: resourcePricerName}
+	)
+	for _, dim := range resourceDimensions {
+		quantity, ok := quantities[dim]
+		if !ok {
+			continue
+		}
+		tiers, ok := rates[dim]
+		if !ok {
+			continue
+		}
+
+		c := priceAfter(tiers, used[dim], quantity)
+		credits += c
+		breakdown.Components = append(breakdown.Components, db.UsagePriceComponent{
+			Dimension: dim,
+			Quantity:  quantity,
+			Credits:   c,
+		})
+	}
+	return credits, breakdown
+}
+
+func (p *ResourcePricer) resourcesForClass(workspaceClass string) WorkspaceClassResources {
+	if res, ok := p.cfg.Classes[workspaceClass]; ok {
+		return res
+	}
+	log.Errorf("no resources configured for workspace class %q - using default", workspaceClass)
+	return p.cfg.Classes[db.WorkspaceClass_Default]
+}
+
+func (p *ResourcePricer) ratesFor(attributionID db.AttributionID) PricingRates {
+	entity, id := attributionID.Values()
+	override, ok := p.cfg.Overrides[id]
+	if entity != db.AttributionEntity_Team || !ok {
+		return p.cfg.Rates
+	}
+
+	res := make(PricingRates, len(p.cfg.Rates)+len(override))
+	for dim, tiers := range p.cfg.Rates {
+		res[dim] = tiers
+	}
+	for dim, tiers := range override {
+		res[dim] = tiers
+	}
+	return res
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ctesting "github.com/gitpod-io/gitpod/common-go/testing"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+)
+
+const overrideOrganizationID = "0e5ffd8a-9b2b-4b3c-8f5c-6a9d8e7f1a2b"
+
+var testResourcePricingConfig = ResourcePricingConfig{
+	Classes: map[string]WorkspaceClassResources{
+		db.WorkspaceClass_Default: {CPU: 4, MemoryGB: 8, StorageGB: 30},
+		"g1-large":                {CPU: 8, MemoryGB: 16, StorageGB: 50},
+	},
+	BackupRetention: "336h",
+	Rates: PricingRates{
+		DimensionCPUSeconds: {
+			{UpTo: 14400, CreditsPerUnit: 0.0005},
+			{CreditsPerUnit: 0.0004},
+		},
+		DimensionMemoryGBHours:   {{CreditsPerUnit: 0.25}},
+		DimensionStorageGBHours:  {{CreditsPerUnit: 0.01}},
+		DimensionBackupGBHours:   {{CreditsPerUnit: 0.001}},
+		DimensionPrebuildMinutes: {{CreditsPerUnit: 0.05}},
+	},
+	Overrides: map[string]PricingRates{
+		overrideOrganizationID: {
+			DimensionCPUSeconds: {{CreditsPerUnit: 0.0003}},
+		},
+	},
+}
+
+func TestPricerGolden(t *testing.T) {
+	type fixture struct {
+		Instance db.WorkspaceInstanceForUsage `json:"instance"`
+		Now      time.Time                    `json:"now"`
+	}
+	type pricedInstance struct {
+		Credits   float64                `json:"credits"`
+		Breakdown db.UsagePriceBreakdown `json:"breakdown"`
+	}
+	type gold struct {
+		WorkspaceClass pricedInstance `json:"workspaceClass"`
+		Resource       pricedInstance `json:"resource"`
+	}
+
+	workspaceClassPricer, err := NewWorkspacePricer(map[string]float64{
+		db.WorkspaceClass_Default: 0.1666666667,
+		"g1-large":                0.3333333333,
+	})
+	require.NoError(t, err)
+	resourcePricer, err := NewResourcePricer(testResourcePricingConfig)
+	require.NoError(t, err)
+
+	test := ctesting.FixtureTest{
+		T:    t,
+		Path: "testdata/pricing_*.json",
+		GoldPath: func(fn string) string {
+			return fmt.Sprintf("%s.golden", strings.TrimSuffix(fn, filepath.Ext(fn)))
+		},
+		Test: func(t *testing.T, input interface{}) interface{} {
+			f := input.(*fixture)
+
+			var res gold
+			res.WorkspaceClass.Credits, res.WorkspaceClass.Breakdown = workspaceClassPricer.Price(&f.Instance, f.Now, nil)
+			res.Resource.Credits, res.Resource.Breakdown = resourcePricer.Price(&f.Instance, f.Now, nil)
+			return &res
+		},
+		Fixture: func() interface{} { return &fixture{} },
+		Gold:    func() interface{} { return &gold{} },
+	}
+	test.Run()
+}
+
+func TestResourcePricer_Tiers(t *testing.T) {
+	tiers := []RateTier{
+		{UpTo: 10, CreditsPerUnit: 1},
+		{UpTo: 20, CreditsPerUnit: 0.5},
+		{CreditsPerUnit: 0.1},
+	}
+
+	testCases := []struct {
+		Name            string
+		Quantity        float64
+		ExpectedCredits float64
+	}{
+		{Name: "zero", Quantity: 0, ExpectedCredits: 0},
+		{Name: "first tier", Quantity: 5, ExpectedCredits: 5},
+		{Name: "first tier boundary", Quantity: 10, ExpectedCredits: 10},
+		{Name: "second tier", Quantity: 15, ExpectedCredits: 12.5},
+		{Name: "last tier", Quantity: 30, ExpectedCredits: 16},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actualCredits := price(tiers, tc.Quantity)
+
+			require.True(t, cmp.Equal(tc.ExpectedCredits, actualCredits, cmpopts.EquateApprox(0, 0.0000001)), "expected %f, got %f", tc.ExpectedCredits, actualCredits)
+		})
+	}
+}
+
+func TestNewResourcePricer(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Rates         PricingRates
+		ExpectedError bool
+	}{
+		{
+			Name:  "valid",
+			Rates: testResourcePricingConfig.Rates,
+		},
+		{
+			Name:          "unknown dimension",
+			Rates:         PricingRates{"gpuSeconds": {{CreditsPerUnit: 1}}},
+			ExpectedError: true,
+		},
+		{
+			Name:          "no tiers",
+			Rates:         PricingRates{DimensionCPUSeconds: {}},
+			ExpectedError: true,
+		},
+		{
+			Name:          "bounded last tier",
+			Rates:         PricingRates{DimensionCPUSeconds: {{UpTo: 10, CreditsPerUnit: 1}}},
+			ExpectedError: true,
+		},
+		{
+			Name:          "descending tiers",
+			Rates:         PricingRates{DimensionCPUSeconds: {{UpTo: 10, CreditsPerUnit: 1}, {UpTo: 5, CreditsPerUnit: 1}, {CreditsPerUnit: 1}}},
+			ExpectedError: true,
+		},
+		{
+			Name:          "negative rate",
+			Rates:         PricingRates{DimensionCPUSeconds: {{CreditsPerUnit: -1}}},
+			ExpectedError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			classes := testResourcePricingConfig.Classes
+			_, err := NewResourcePricer(ResourcePricingConfig{Classes: classes, Rates: tc.Rates})
+			if tc.ExpectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			_, err = NewResourcePricer(ResourcePricingConfig{Classes: classes, Overrides: map[string]PricingRates{overrideOrganizationID: tc.Rates}})
+			if tc.ExpectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewResourcePricer_Config(t *testing.T) {
+	_, err := NewResourcePricer(ResourcePricingConfig{
+		Classes: map[string]WorkspaceClassResources{"g1-large": {CPU: 8}},
+	})
+	require.Error(t, err, "the default class must be configured")
+
+	_, err = NewResourcePricer(ResourcePricingConfig{
+		Classes:         testResourcePricingConfig.Classes,
+		BackupRetention: "two weeks",
+	})
+	require.Error(t, err, "the backup retention must be a duration")
+}
+
+func TestResourcePricer_TiersApplyToBillingCycle(t *testing.T) {
+	pricer, err := NewResourcePricer(testResourcePricingConfig)
+	require.NoError(t, err)
+
+	start := time.Date(2022, 9, 1, 8, 0, 0, 0, time.UTC)
+	instance := db.WorkspaceInstanceForUsage{
+		WorkspaceClass:     db.WorkspaceClass_Default,
+		Type:               db.WorkspaceType_Regular,
+		UsageAttributionID: db.NewUserAttributionID("1f2e3d4c-5b6a-4978-8a9b-0c1d2e3f4a5b"),
+		StartedTime:        db.NewVarCharTime(start),
+	}
+	cpuCredits := func(breakdown db.UsagePriceBreakdown) float64 {
+		for _, c := range breakdown.Components {
+			if c.Dimension == DimensionCPUSeconds {
+				return c.Credits
+			}
+		}
+		return 0
+	}
+
+	// one hour on 4 CPUs fills the first tier of 14400 CPU seconds
+	_, first := pricer.Price(&instance, start.Add(time.Hour), nil)
+	require.InDelta(t, 14400*0.0005, cpuCredits(first), 0.0000001)
+
+	// once the first tier is used up, the next instance is priced at the second tier
+	used := make(Quantities)
+	used.Add(first)
+	_, second := pricer.Price(&instance, start.Add(time.Hour), used)
+	require.InDelta(t, 14400*0.0004, cpuCredits(second), 0.0000001)
+}
@@ -0,0 +1,309 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/usage-api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *UsageService) SetNestedSpendingLimit(ctx context.Context, in *v1.SetNestedSpendingLimitRequest) (*v1.SetNestedSpendingLimitResponse, error) {
+	attributionId, err := parseTeamAttributionID(in.AttributionId)
+	if err != nil {
+		return nil, err
+	}
+	if in.SpendingLimit == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Empty SpendingLimit")
+	}
+	scope, err := convertSpendingLimitScopeToDB(in.SpendingLimit.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if in.SpendingLimit.ScopeId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Empty ScopeId")
+	}
+	if in.SpendingLimit.SpendingLimit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "SpendingLimit must not be negative")
+	}
+
+	err = db.SetNestedSpendingLimit(ctx, s.conn, db.NestedSpendingLimit{
+		AttributionID: attributionId,
+		Scope:         scope,
+		ScopeID:       in.SpendingLimit.ScopeId,
+		SpendingLimit: in.SpendingLimit.SpendingLimit,
+	})
+	if err != nil {
+		log.WithError(err).WithField("attribution_id", attributionId).Error("Failed to set nested spending limit.")
+		return nil, status.Errorf(codes.Internal, "Failed to set spending limit")
+	}
+
+	costCenter, err := s.getCostCenter(ctx, attributionId)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.SetNestedSpendingLimitResponse{
+		CostCenter: costCenter,
+	}, nil
+}
+
+func (s *UsageService) DeleteNestedSpendingLimit(ctx context.Context, in *v1.DeleteNestedSpendingLimitRequest) (*v1.DeleteNestedSpendingLimitResponse, error) {
+	attributionId, err := parseTeamAttributionID(in.AttributionId)
+	if err != nil {
+		return nil, err
+	}
+	scope, err := convertSpendingLimitScopeToDB(in.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if in.ScopeId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Empty ScopeId")
+	}
+
+	err = db.DeleteNestedSpendingLimit(ctx, s.conn, attributionId, scope, in.ScopeId)
+	if errors.Is(err, db.ErrorNotFound) {
+		return nil, status.Errorf(codes.NotFound, "No spending limit for %s %s", scope, in.ScopeId)
+	}
+	if err != nil {
+		log.WithError(err).WithField("attribution_id", attributionId).Error("Failed to delete nested spending limit.")
+		return nil, status.Errorf(codes.Internal, "Failed to delete spending limit")
+	}
+
+	costCenter, err := s.getCostCenter(ctx, attributionId)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.DeleteNestedSpendingLimitResponse{
+		CostCenter: costCenter,
+	}, nil
+}
+
+// getCostCenter returns the cost center of attributionId including its nested spending limits and their usage
+func (s *UsageService) getCostCenter(ctx context.Context, attributionId db.AttributionID) (*v1.CostCenter, error) {
+	costCenter, err := s.costCenterManager.GetOrCreateCostCenter(ctx, attributionId)
+	if err != nil {
+		return nil, err
+	}
+	return s.costCenterToAPIWithNestedSpendingLimits(ctx, costCenter)
+}
+
+func (s *UsageService) costCenterToAPIWithNestedSpendingLimits(ctx context.Context, costCenter db.CostCenter) (*v1.CostCenter, error) {
+	res := dbCostCenterToAPI(costCenter)
+	if !costCenter.ID.IsEntity(db.AttributionEntity_Team) {
+		return res, nil
+	}
+
+	limits, err := db.ListNestedSpendingLimits(ctx, s.conn, costCenter.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(limits) == 0 {
+		return res, nil
+	}
+
+	usage, err := s.nestedUsage(ctx, costCenter.ID, costCenter.BillingCycleStart.Time(), false)
+	if err != nil {
+		return nil, err
+	}
+	for _, limit := range limits {
+		res.NestedSpendingLimits = append(res.NestedSpendingLimits, nestedSpendingLimitToAPI(limit, usage))
+	}
+	return res, nil
+}
+
+// findExceededSpendingLimits returns the nested spending limits which are exceeded by projects or users with running workspace instances.
+// Cost centers whose limits cannot be checked are logged and skipped, so that they do not prevent checking the others.
+func (s *UsageService) findExceededSpendingLimits(ctx context.Context, running []db.WorkspaceInstanceForUsage) []*v1.ExceededSpendingLimit {
+	scopes := runningSpendingLimitScopes(running)
+
+	attributionIds := make([]db.AttributionID, 0, len(scopes))
+	for attributionId := range scopes {
+		attributionIds = append(attributionIds, attributionId)
+	}
+	sort.Slice(attributionIds, func(i, j int) bool { return attributionIds[i] < attributionIds[j] })
+
+	var exceeded []*v1.ExceededSpendingLimit
+	for _, attributionId := range attributionIds {
+		res, err := s.findExceededSpendingLimitsOf(ctx, attributionId, scopes[attributionId])
+		if err != nil {
+			log.WithError(err).WithField("attribution_id", attributionId).Error("Failed to check nested spending limits.")
+			continue
+		}
+		exceeded = append(exceeded, res...)
+	}
+	return exceeded
+}
+
+func (s *UsageService) findExceededSpendingLimitsOf(ctx context.Context, attributionId db.AttributionID, running map[spendingLimitScopeKey]bool) ([]*v1.ExceededSpendingLimit, error) {
+	limits, err := db.ListNestedSpendingLimits(ctx, s.conn, attributionId)
+	if err != nil {
+		return nil, err
+	}
+	if len(limits) == 0 {
+		return nil, nil
+	}
+
+	costCenter, err := s.costCenterManager.GetCostCenter(ctx, attributionId)
+	if errors.Is(err, db.CostCenterNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !costCenter.BillingCycleStart.IsSet() {
+		// without a billing cycle, there is no usage to hold against the limits
+		log.WithField("attribution_id", attributionId).Debug("Cost center has no billing cycle, not enforcing nested spending limits.")
+		return nil, nil
+	}
+	usage, err := s.nestedUsage(ctx, attributionId, costCenter.BillingCycleStart.Time(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*v1.ExceededSpendingLimit
+	for _, limit := range exceededSpendingLimits(limits, usage, running) {
+		res = append(res, &v1.ExceededSpendingLimit{
+			AttributionId: string(attributionId),
+			SpendingLimit: nestedSpendingLimitToAPI(limit, usage),
+		})
+	}
+	return res, nil
+}
+
+// nestedUsageTTL is how long the nested usage of a cost center is reused before it is computed again.
+// ReconcileUsage refreshes the nested usage of cost centers with running workspace instances.
+const nestedUsageTTL = 5 * time.Minute
+
+type nestedUsageCache struct {
+	mu      sync.Mutex
+	entries map[db.AttributionID]nestedUsageCacheEntry
+}
+
+type nestedUsageCacheEntry struct {
+	BillingCycleStart time.Time
+	ComputedAt        time.Time
+	Usage             db.NestedUsage
+}
+
+// nestedUsage returns the usage of the projects and users of attributionId since billingCycleStart.
+// Unless refresh is set, usage computed less than nestedUsageTTL ago is reused.
+// A zero billingCycleStart means the billing cycle is unknown, there's no usage in it then.
+func (s *UsageService) nestedUsage(ctx context.Context, attributionId db.AttributionID, billingCycleStart time.Time, refresh bool) (db.NestedUsage, error) {
+	if billingCycleStart.IsZero() {
+		// rather than all usage since the epoch
+		return db.NestedUsage{}, nil
+	}
+	now := s.nowFunc()
+
+	s.nestedUsageCache.mu.Lock()
+	entry, ok := s.nestedUsageCache.entries[attributionId]
+	s.nestedUsageCache.mu.Unlock()
+	if !refresh && ok && entry.BillingCycleStart.Equal(billingCycleStart) && now.Sub(entry.ComputedAt) < nestedUsageTTL {
+		return entry.Usage, nil
+	}
+
+	usage, err := db.GetNestedUsage(ctx, s.conn, attributionId, billingCycleStart, now)
+	if err != nil {
+		return db.NestedUsage{}, err
+	}
+
+	s.nestedUsageCache.mu.Lock()
+	defer s.nestedUsageCache.mu.Unlock()
+	if s.nestedUsageCache.entries == nil {
+		s.nestedUsageCache.entries = make(map[db.AttributionID]nestedUsageCacheEntry)
+	}
+	s.nestedUsageCache.entries[attributionId] = nestedUsageCacheEntry{
+		BillingCycleStart: billingCycleStart,
+		ComputedAt:        now,
+		Usage:             usage,
+	}
+	return usage, nil
+}
+
+type spendingLimitScopeKey struct {
+	Scope   db.SpendingLimitScope
+	ScopeID string
+}
+
+// runningSpendingLimitScopes collects the projects and users with running workspace instances per team attribution ID
+func runningSpendingLimitScopes(running []db.WorkspaceInstanceForUsage) map[db.AttributionID]map[spendingLimitScopeKey]bool {
+	res := make(map[db.AttributionID]map[spendingLimitScopeKey]bool)
+	for _, instance := range running {
+		if !instance.UsageAttributionID.IsEntity(db.AttributionEntity_Team) {
+			continue
+		}
+		scopes, ok := res[instance.UsageAttributionID]
+		if !ok {
+			scopes = make(map[spendingLimitScopeKey]bool)
+			res[instance.UsageAttributionID] = scopes
+		}
+		if instance.ProjectID.Valid && instance.ProjectID.String != "" {
+			scopes[spendingLimitScopeKey{Scope: db.SpendingLimitScope_Project, ScopeID: instance.ProjectID.String}] = true
+		}
+		scopes[spendingLimitScopeKey{Scope: db.SpendingLimitScope_User, ScopeID: instance.OwnerID.String()}] = true
+	}
+	return res
+}
+
+// exceededSpendingLimits returns the limits of running scopes whose usage reached the spending limit
+func exceededSpendingLimits(limits []db.NestedSpendingLimit, usage db.NestedUsage, running map[spendingLimitScopeKey]bool) []db.NestedSpendingLimit {
+	var res []db.NestedSpendingLimit
+	for _, limit := range limits {
+		if !running[spendingLimitScopeKey{Scope: limit.Scope, ScopeID: limit.ScopeID}] {
+			continue
+		}
+		if usage.CreditCentsUsedBy(limit.Scope, limit.ScopeID) >= db.NewCreditCents(float64(limit.SpendingLimit)) {
+			res = append(res, limit)
+		}
+	}
+	return res
+}
+
+func nestedSpendingLimitToAPI(limit db.NestedSpendingLimit, usage db.NestedUsage) *v1.NestedSpendingLimit {
+	return &v1.NestedSpendingLimit{
+		Scope:         convertSpendingLimitScopeToAPI(limit.Scope),
+		ScopeId:       limit.ScopeID,
+		SpendingLimit: limit.SpendingLimit,
+		CreditsUsed:   usage.CreditCentsUsedBy(limit.Scope, limit.ScopeID).ToCredits(),
+	}
+}
+
+func convertSpendingLimitScopeToDB(in v1.NestedSpendingLimit_Scope) (db.SpendingLimitScope, error) {
+	switch in {
+	case v1.NestedSpendingLimit_SCOPE_PROJECT:
+		return db.SpendingLimitScope_Project, nil
+	case v1.NestedSpendingLimit_SCOPE_USER:
+		return db.SpendingLimitScope_User, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "Unknown spending limit scope %s", in.String())
+	}
+}
+
+func convertSpendingLimitScopeToAPI(in db.SpendingLimitScope) v1.NestedSpendingLimit_Scope {
+	if in == db.SpendingLimitScope_User {
+		return v1.NestedSpendingLimit_SCOPE_USER
+	}
+	return v1.NestedSpendingLimit_SCOPE_PROJECT
+}
+
+// parseTeamAttributionID parses s and ensures it attributes to a team, as only team cost centers have nested spending limits
+func parseTeamAttributionID(s string) (db.AttributionID, error) {
+	attributionId, err := db.ParseAttributionID(s)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "AttributionID '%s' couldn't be parsed (error: %s).", s, err)
+	}
+	if !attributionId.IsEntity(db.AttributionEntity_Team) {
+		return "", status.Errorf(codes.InvalidArgument, "Nested spending limits are only supported for teams, not '%s'.", s)
+	}
+	return attributionId, nil
+}
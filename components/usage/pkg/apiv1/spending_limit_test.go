@@ -0,0 +1,97 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunningSpendingLimitScopes(t *testing.T) {
+	team := db.NewTeamAttributionID(uuid.New().String())
+	user := db.NewUserAttributionID(uuid.New().String())
+	alice, bob := uuid.New(), uuid.New()
+	project := uuid.New().String()
+
+	scopes := runningSpendingLimitScopes([]db.WorkspaceInstanceForUsage{
+		{UsageAttributionID: team, OwnerID: alice, ProjectID: sql.NullString{String: project, Valid: true}},
+		{UsageAttributionID: team, OwnerID: bob},
+		{UsageAttributionID: user, OwnerID: alice, ProjectID: sql.NullString{String: project, Valid: true}},
+	})
+	require.Equal(t, map[db.AttributionID]map[spendingLimitScopeKey]bool{
+		team: {
+			{Scope: db.SpendingLimitScope_Project, ScopeID: project}:     true,
+			{Scope: db.SpendingLimitScope_User, ScopeID: alice.String()}: true,
+			{Scope: db.SpendingLimitScope_User, ScopeID: bob.String()}:   true,
+		},
+	}, scopes)
+}
+
+func TestExceededSpendingLimits(t *testing.T) {
+	project := uuid.New().String()
+	alice, bob := uuid.New().String(), uuid.New().String()
+
+	limits := []db.NestedSpendingLimit{
+		{Scope: db.SpendingLimitScope_Project, ScopeID: project, SpendingLimit: 100},
+		{Scope: db.SpendingLimitScope_User, ScopeID: alice, SpendingLimit: 10},
+		{Scope: db.SpendingLimitScope_User, ScopeID: bob, SpendingLimit: 10},
+	}
+	usage := db.NestedUsage{
+		Projects: map[string]db.CreditCents{project: db.NewCreditCents(100)},
+		Users: map[string]db.CreditCents{
+			alice: db.NewCreditCents(9.99),
+			bob:   db.NewCreditCents(25),
+		},
+	}
+
+	t.Run("reports limits which are reached by running scopes", func(t *testing.T) {
+		exceeded := exceededSpendingLimits(limits, usage, map[spendingLimitScopeKey]bool{
+			{Scope: db.SpendingLimitScope_Project, ScopeID: project}: true,
+			{Scope: db.SpendingLimitScope_User, ScopeID: alice}:      true,
+			{Scope: db.SpendingLimitScope_User, ScopeID: bob}:        true,
+		})
+		require.Equal(t, []db.NestedSpendingLimit{limits[0], limits[2]}, exceeded)
+	})
+
+	t.Run("ignores scopes without running workspaces", func(t *testing.T) {
+		exceeded := exceededSpendingLimits(limits, usage, map[spendingLimitScopeKey]bool{
+			{Scope: db.SpendingLimitScope_User, ScopeID: alice}: true,
+		})
+		require.Empty(t, exceeded)
+	})
+}
+
+func TestNestedUsage_ReusesRecentUsage(t *testing.T) {
+	team := db.NewTeamAttributionID(uuid.New().String())
+	now := time.Date(2023, 3, 15, 10, 0, 0, 0, time.UTC)
+	billingCycleStart := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	cached := db.NestedUsage{Users: map[string]db.CreditCents{"alice": 1000}}
+
+	// no connection is needed while the cached usage is recent enough
+	s := &UsageService{nowFunc: func() time.Time { return now }}
+	s.nestedUsageCache.entries = map[db.AttributionID]nestedUsageCacheEntry{
+		team: {BillingCycleStart: billingCycleStart, ComputedAt: now.Add(-nestedUsageTTL / 2), Usage: cached},
+	}
+
+	usage, err := s.nestedUsage(context.Background(), team, billingCycleStart, false)
+	require.NoError(t, err)
+	require.Equal(t, cached, usage)
+}
+
+func TestNestedUsage_UnknownBillingCycle(t *testing.T) {
+	team := db.NewTeamAttributionID(uuid.New().String())
+
+	// without a billing cycle, the usage since the epoch must not be queried
+	s := &UsageService{nowFunc: time.Now}
+	usage, err := s.nestedUsage(context.Background(), team, time.Time{}, true)
+	require.NoError(t, err)
+	require.Equal(t, db.NestedUsage{}, usage)
+}
@@ -6,8 +6,10 @@ package apiv1
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/log"
 	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	v1 "github.com/gitpod-io/gitpod/usage-api/v1"
+	"github.com/gitpod-io/gitpod/usage/pkg/budget"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -27,8 +30,9 @@ var _ v1.UsageServiceServer = (*UsageService)(nil)
 type UsageService struct {
 	conn              *gorm.DB
 	nowFunc           func() time.Time
-	pricer            *WorkspacePricer
+	pricer            Pricer
 	costCenterManager *db.CostCenterManager
+	nestedUsageCache  nestedUsageCache
 
 	v1.UnimplementedUsageServiceServer
 }
@@ -163,13 +167,35 @@ func (s *UsageService) GetBalance(ctx context.Context, in *v1.GetBalanceRequest)
 	if err != nil {
 		return nil, err
 	}
-	credits, err := db.GetBalance(ctx, s.conn, attrId)
+	costCenter, err := s.costCenterManager.GetCostCenter(ctx, attrId)
+	if errors.Is(err, db.CostCenterNotFound) {
+		// without a cost center there is neither a spending limit nor a billing cycle to forecast
+		credits, err := db.GetBalance(ctx, s.conn, attrId)
+		if err != nil {
+			return nil, err
+		}
+		return &v1.GetBalanceResponse{
+			Credits:         credits.ToCredits(),
+			ForecastCredits: credits.ToCredits(),
+		}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	return &v1.GetBalanceResponse{
-		Credits: credits.ToCredits(),
-	}, nil
+	forecast, err := budget.NewForecast(ctx, s.conn, costCenter, s.nowFunc())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &v1.GetBalanceResponse{
+		Credits:         forecast.Credits,
+		ForecastCredits: forecast.ForecastCredits,
+		SpendingLimit:   forecast.SpendingLimit,
+	}
+	if !forecast.CycleEnd.IsZero() {
+		res.BillingCycleEnd = timestamppb.New(forecast.CycleEnd)
+	}
+	return res, nil
 }
 
 func (s *UsageService) GetCostCenter(ctx context.Context, in *v1.GetCostCenterRequest) (*v1.GetCostCenterResponse, error) {
@@ -181,12 +207,12 @@ func (s *UsageService) GetCostCenter(ctx context.Context, in *v1.GetCostCenterRe
 		return nil, status.Errorf(codes.InvalidArgument, "Bad attributionId %s", in.AttributionId)
 	}
 
-	result, err := s.costCenterManager.GetOrCreateCostCenter(ctx, attributionId)
+	result, err := s.getCostCenter(ctx, attributionId)
 	if err != nil {
 		return nil, err
 	}
 	return &v1.GetCostCenterResponse{
-		CostCenter: dbCostCenterToAPI(result),
+		CostCenter: result,
 	}, nil
 }
 
@@ -233,8 +259,12 @@ func (s *UsageService) SetCostCenter(ctx context.Context, in *v1.SetCostCenterRe
 	if err != nil {
 		return nil, err
 	}
+	res, err := s.costCenterToAPIWithNestedSpendingLimits(ctx, result)
+	if err != nil {
+		return nil, err
+	}
 	return &v1.SetCostCenterResponse{
-		CostCenter: dbCostCenterToAPI(result),
+		CostCenter: res,
 	}, nil
 }
 
@@ -308,7 +338,12 @@ func (s *UsageService) ReconcileUsage(ctx context.Context, req *v1.ReconcileUsag
 
 	// now has to be computed after we've collected all data, to ensure that it's always greater than any of the records we fetch
 	now := s.nowFunc()
-	inserts, updates, err := reconcileUsage(instances, usageDrafts, s.pricer, now)
+	used, err := s.usedInBillingCycle(ctx, instances, now)
+	if err != nil {
+		logger.WithError(err).Errorf("Failed to find usage in billing cycles.")
+		return nil, status.Errorf(codes.Internal, "Failed to find usage in billing cycles.")
+	}
+	inserts, updates, err := reconcileUsage(instances, usageDrafts, s.pricer, used, now)
 	if err != nil {
 		logger.WithError(err).Errorf("Failed to reconcile usage with ledger.")
 		return nil, status.Errorf(codes.Internal, "Failed to reconcile usage with ledger.")
@@ -333,21 +368,114 @@ func (s *UsageService) ReconcileUsage(ctx context.Context, req *v1.ReconcileUsag
 		logger.Infof("Updated %d Usage records in the database.", len(updates))
 	}
 
-	return &v1.ReconcileUsageResponse{}, nil
+	exceeded := s.findExceededSpendingLimits(ctx, running)
+	for _, e := range exceeded {
+		logger.
+			WithField("attribution_id", e.AttributionId).
+			WithField("scope", e.SpendingLimit.Scope.String()).
+			WithField("scope_id", e.SpendingLimit.ScopeId).
+			Warn("Nested spending limit exceeded by running workspace instances.")
+	}
+
+	return &v1.ReconcileUsageResponse{
+		ExceededSpendingLimits: exceeded,
+	}, nil
+}
+
+// usedInBillingCycle returns the quantities each attribution of instances used in its current billing cycle,
+// apart from the usage of instances. It returns nil if the pricer does not price by what was used before.
+func (s *UsageService) usedInBillingCycle(ctx context.Context, instances []db.WorkspaceInstanceForUsage, now time.Time) (map[db.AttributionID]Quantities, error) {
+	if _, ok := s.pricer.(*ResourcePricer); !ok {
+		return nil, nil
+	}
+
+	instanceIDs := make(map[uuid.UUID]struct{}, len(instances))
+	for _, instance := range instances {
+		instanceIDs[instance.ID] = struct{}{}
+	}
+
+	res := make(map[db.AttributionID]Quantities)
+	for _, instance := range instances {
+		attributionID := instance.UsageAttributionID
+		if _, done := res[attributionID]; done {
+			continue
+		}
+		used := make(Quantities)
+		res[attributionID] = used
+
+		costCenter, err := s.costCenterManager.GetCostCenter(ctx, attributionID)
+		if errors.Is(err, db.CostCenterNotFound) {
+			// the billing cycle starts when the cost center is created
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !costCenter.BillingCycleStart.IsSet() {
+			continue
+		}
+		records, err := db.FindUsage(ctx, s.conn, &db.FindUsageParams{
+			AttributionId: attributionID,
+			From:          costCenter.BillingCycleStart.Time(),
+			To:            now,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if record.WorkspaceInstanceID != nil {
+				if _, reconciled := instanceIDs[*record.WorkspaceInstanceID]; reconciled {
+					continue
+				}
+			}
+			metadata, err := record.GetMetadataAsWorkspaceInstanceData()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse metadata of usage record %s: %w", record.ID, err)
+			}
+			if metadata.Pricing != nil && metadata.Pricing.Pricer == resourcePricerName {
+				used.Add(*metadata.Pricing)
+			}
+		}
+	}
+	return res, nil
 }
 
-func reconcileUsage(instances []db.WorkspaceInstanceForUsage, drafts []db.Usage, pricer *WorkspacePricer, now time.Time) (inserts []db.Usage, updates []db.Usage, err error) {
+// reconcileUsage prices instances in the order they started, adding the quantities of each instance
+// to what its attribution used, which is taken from used and may be nil.
+func reconcileUsage(instances []db.WorkspaceInstanceForUsage, drafts []db.Usage, pricer Pricer, used map[db.AttributionID]Quantities, now time.Time) (inserts []db.Usage, updates []db.Usage, err error) {
 
 	instancesByID := dedupeWorkspaceInstancesForUsage(instances)
+	sortedInstances := make([]db.WorkspaceInstanceForUsage, 0, len(instancesByID))
+	for _, instance := range instancesByID {
+		sortedInstances = append(sortedInstances, instance)
+	}
+	sort.Slice(sortedInstances, func(i, j int) bool {
+		si, sj := sortedInstances[i].StartedTime.Time(), sortedInstances[j].StartedTime.Time()
+		if !si.Equal(sj) {
+			return si.Before(sj)
+		}
+		return sortedInstances[i].ID.String() < sortedInstances[j].ID.String()
+	})
 
 	draftsByWorkspaceID := map[uuid.UUID]db.Usage{}
 	for _, draft := range drafts {
 		draftsByWorkspaceID[*draft.WorkspaceInstanceID] = draft
 	}
 
-	for instanceID, instance := range instancesByID {
-		if usage, exists := draftsByWorkspaceID[instanceID]; exists {
-			updatedUsage, err := updateUsageFromInstance(instance, usage, pricer, now)
+	usedBy := func(attributionID db.AttributionID) Quantities {
+		if used == nil {
+			used = make(map[db.AttributionID]Quantities)
+		}
+		if _, ok := used[attributionID]; !ok {
+			used[attributionID] = make(Quantities)
+		}
+		return used[attributionID]
+	}
+
+	for _, instance := range sortedInstances {
+		attributionUsed := usedBy(instance.UsageAttributionID)
+		if usage, exists := draftsByWorkspaceID[instance.ID]; exists {
+			updatedUsage, err := updateUsageFromInstance(instance, usage, pricer, attributionUsed, now)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to construct updated usage record: %w", err)
 			}
@@ -355,7 +483,7 @@ func reconcileUsage(instances []db.WorkspaceInstanceForUsage, drafts []db.Usage,
 			continue
 		}
 
-		usage, err := newUsageFromInstance(instance, pricer, now)
+		usage, err := newUsageFromInstance(instance, pricer, attributionUsed, now)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to construct usage record: %w", err)
 		}
@@ -367,7 +495,8 @@ func reconcileUsage(instances []db.WorkspaceInstanceForUsage, drafts []db.Usage,
 
 const usageDescriptionFromController = "Usage collected by automated system."
 
-func newUsageFromInstance(instance db.WorkspaceInstanceForUsage, pricer *WorkspacePricer, now time.Time) (db.Usage, error) {
+// newUsageFromInstance prices the instance after used and adds its quantities to used
+func newUsageFromInstance(instance db.WorkspaceInstanceForUsage, pricer Pricer, used Quantities, now time.Time) (db.Usage, error) {
 	stopTime := instance.StoppingTime
 	if !stopTime.IsSet() {
 		stopTime = instance.StoppedTime
@@ -383,11 +512,13 @@ func newUsageFromInstance(instance db.WorkspaceInstanceForUsage, pricer *Workspa
 		effectiveTime = stopTime.Time()
 	}
 
+	credits, pricing := pricer.Price(&instance, now, used)
+	used.Add(pricing)
 	usage := db.Usage{
 		ID:                  uuid.New(),
 		AttributionID:       instance.UsageAttributionID,
 		Description:         usageDescriptionFromController,
-		CreditCents:         db.NewCreditCents(pricer.CreditsUsedByInstance(&instance, now)),
+		CreditCents:         db.NewCreditCents(credits),
 		EffectiveTime:       db.NewVarCharTime(effectiveTime),
 		Kind:                db.WorkspaceInstanceUsageKind,
 		WorkspaceInstanceID: &instance.ID,
@@ -412,6 +543,8 @@ func newUsageFromInstance(instance db.WorkspaceInstanceForUsage, pricer *Workspa
 		UserID:         instance.UserID,
 		UserName:       instance.UserName,
 		UserAvatarURL:  instance.UserAvatarURL,
+		ProjectID:      instance.ProjectID.String,
+		Pricing:        &pricing,
 	})
 	if err != nil {
 		return db.Usage{}, fmt.Errorf("failed to serialize workspace instance metadata: %w", err)
@@ -420,9 +553,9 @@ func newUsageFromInstance(instance db.WorkspaceInstanceForUsage, pricer *Workspa
 	return usage, nil
 }
 
-func updateUsageFromInstance(instance db.WorkspaceInstanceForUsage, usage db.Usage, pricer *WorkspacePricer, now time.Time) (db.Usage, error) {
+func updateUsageFromInstance(instance db.WorkspaceInstanceForUsage, usage db.Usage, pricer Pricer, used Quantities, now time.Time) (db.Usage, error) {
 	// We construct a new record to ensure we always take the data from the source of truth - the workspace instance
-	updated, err := newUsageFromInstance(instance, pricer, now)
+	updated, err := newUsageFromInstance(instance, pricer, used, now)
 	if err != nil {
 		return db.Usage{}, fmt.Errorf("failed to construct updated usage record: %w", err)
 	}
@@ -448,7 +581,7 @@ func dedupeWorkspaceInstancesForUsage(instances []db.WorkspaceInstanceForUsage)
 	return set
 }
 
-func NewUsageService(conn *gorm.DB, pricer *WorkspacePricer, costCenterManager *db.CostCenterManager) *UsageService {
+func NewUsageService(conn *gorm.DB, pricer Pricer, costCenterManager *db.CostCenterManager) *UsageService {
 	return &UsageService{
 		conn:              conn,
 		costCenterManager: costCenterManager,
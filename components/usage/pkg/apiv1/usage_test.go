@@ -108,7 +108,7 @@ func TestReconcile(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("no action with no instances and no drafts", func(t *testing.T) {
-		inserts, updates, err := reconcileUsage(nil, nil, pricer, now)
+		inserts, updates, err := reconcileUsage(nil, nil, pricer, nil, now)
 		require.NoError(t, err)
 		require.Len(t, inserts, 0)
 		require.Len(t, updates, 0)
@@ -116,7 +116,7 @@ func TestReconcile(t *testing.T) {
 
 	t.Run("no action with no instances but existing drafts", func(t *testing.T) {
 		drafts := []db.Usage{dbtest.NewUsage(t, db.Usage{})}
-		inserts, updates, err := reconcileUsage(nil, drafts, pricer, now)
+		inserts, updates, err := reconcileUsage(nil, drafts, pricer, nil, now)
 		require.NoError(t, err)
 		require.Len(t, inserts, 0)
 		require.Len(t, updates, 0)
@@ -137,10 +137,11 @@ func TestReconcile(t *testing.T) {
 			StartedTime:        db.NewVarCharTime(now.Add(1 * time.Minute)),
 		}
 
-		inserts, updates, err := reconcileUsage([]db.WorkspaceInstanceForUsage{instance, instance}, nil, pricer, now)
+		inserts, updates, err := reconcileUsage([]db.WorkspaceInstanceForUsage{instance, instance}, nil, pricer, nil, now)
 		require.NoError(t, err)
 		require.Len(t, inserts, 1)
 		require.Len(t, updates, 0)
+		_, pricing := pricer.Price(&instance, now, nil)
 		expectedUsage := db.Usage{
 			ID:                  inserts[0].ID,
 			AttributionID:       instance.UsageAttributionID,
@@ -161,6 +162,8 @@ func TestReconcile(t *testing.T) {
 			EndTime:        "",
 			UserName:       instance.UserName,
 			UserAvatarURL:  instance.UserAvatarURL,
+			ProjectID:      instance.ProjectID.String,
+			Pricing:        &pricing,
 		}))
 		require.EqualValues(t, expectedUsage, inserts[0])
 	})
@@ -194,11 +197,12 @@ func TestReconcile(t *testing.T) {
 			Metadata:            nil,
 		})
 
-		inserts, updates, err := reconcileUsage([]db.WorkspaceInstanceForUsage{instance}, []db.Usage{draft}, pricer, now)
+		inserts, updates, err := reconcileUsage([]db.WorkspaceInstanceForUsage{instance}, []db.Usage{draft}, pricer, nil, now)
 		require.NoError(t, err)
 		require.Len(t, inserts, 0)
 		require.Len(t, updates, 1)
 
+		_, pricing := pricer.Price(&instance, now, nil)
 		expectedUsage := db.Usage{
 			ID:                  draft.ID,
 			AttributionID:       instance.UsageAttributionID,
@@ -219,6 +223,8 @@ func TestReconcile(t *testing.T) {
 			EndTime:        "",
 			UserName:       instance.UserName,
 			UserAvatarURL:  instance.UserAvatarURL,
+			ProjectID:      instance.ProjectID.String,
+			Pricing:        &pricing,
 		}))
 		require.EqualValues(t, expectedUsage, updates[0])
 	})
@@ -239,7 +245,7 @@ func TestReconcile(t *testing.T) {
 			StoppedTime:        db.NewVarCharTime(now.Add(2 * time.Minute)),
 		}
 
-		inserts, updates, err := reconcileUsage([]db.WorkspaceInstanceForUsage{instance}, []db.Usage{}, pricer, now)
+		inserts, updates, err := reconcileUsage([]db.WorkspaceInstanceForUsage{instance}, []db.Usage{}, pricer, nil, now)
 		require.NoError(t, err)
 		require.Len(t, inserts, 1)
 		require.Len(t, updates, 0)
@@ -247,6 +253,48 @@ func TestReconcile(t *testing.T) {
 		require.EqualValues(t, db.NewCreditCents(0.17), inserts[0].CreditCents)
 		require.EqualValues(t, instance.StoppedTime, inserts[0].EffectiveTime)
 	})
+
+	t.Run("prices instances of an attribution after what it used before", func(t *testing.T) {
+		resourcePricer, err := NewResourcePricer(ResourcePricingConfig{
+			Classes: map[string]WorkspaceClassResources{db.WorkspaceClass_Default: {CPU: 1}},
+			Rates: PricingRates{
+				DimensionCPUSeconds: {{UpTo: 3600, CreditsPerUnit: 0.001}, {CreditsPerUnit: 0.0005}},
+			},
+		})
+		require.NoError(t, err)
+
+		attributionID := db.NewTeamAttributionID(uuid.New().String())
+		newInstance := func(start time.Time) db.WorkspaceInstanceForUsage {
+			return db.WorkspaceInstanceForUsage{
+				ID:                 uuid.New(),
+				WorkspaceID:        dbtest.GenerateWorkspaceID(),
+				WorkspaceClass:     db.WorkspaceClass_Default,
+				Type:               db.WorkspaceType_Regular,
+				UsageAttributionID: attributionID,
+				StartedTime:        db.NewVarCharTime(start),
+				StoppedTime:        db.NewVarCharTime(start.Add(time.Hour)),
+			}
+		}
+		earlier := newInstance(now.Add(-5 * time.Hour))
+		later := newInstance(now.Add(-3 * time.Hour))
+
+		inserts, _, err := reconcileUsage([]db.WorkspaceInstanceForUsage{later, earlier}, nil, resourcePricer, nil, now)
+		require.NoError(t, err)
+		require.Len(t, inserts, 2)
+		credits := map[uuid.UUID]db.CreditCents{}
+		for _, usage := range inserts {
+			credits[*usage.WorkspaceInstanceID] = usage.CreditCents
+		}
+		require.Equal(t, db.NewCreditCents(3.6), credits[earlier.ID])
+		require.Equal(t, db.NewCreditCents(1.8), credits[later.ID])
+
+		// what was used in previous runs counts too
+		inserts, _, err = reconcileUsage([]db.WorkspaceInstanceForUsage{earlier}, nil, resourcePricer, map[db.AttributionID]Quantities{
+			attributionID: {DimensionCPUSeconds: 3600},
+		}, now)
+		require.NoError(t, err)
+		require.Equal(t, db.NewCreditCents(1.8), inserts[0].CreditCents)
+	})
 }
 
 func TestGetAndSetCostCenter(t *testing.T) {
@@ -0,0 +1,366 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package billing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"gorm.io/gorm"
+)
+
+// Config configures the document billing provider. At least one of FileDrop and Webhook must be set.
+type Config struct {
+	// FileDrop writes documents as JSON files into a directory
+	FileDrop *FileDropConfig `json:"fileDrop,omitempty"`
+	// Webhook posts documents as JSON to a URL
+	Webhook *WebhookConfig `json:"webhook,omitempty"`
+}
+
+type FileDropConfig struct {
+	Directory string `json:"directory"`
+}
+
+type WebhookConfig struct {
+	URL string `json:"url"`
+}
+
+// NewDocumentProviderFromConfig creates a document provider with the sinks configured in cfg
+func NewDocumentProviderFromConfig(cfg Config, conn *gorm.DB) (*DocumentProvider, error) {
+	var sinks MultiSink
+	if cfg.FileDrop != nil {
+		if cfg.FileDrop.Directory == "" {
+			return nil, fmt.Errorf("billing provider file drop requires a directory")
+		}
+		sinks = append(sinks, &FileSink{Directory: cfg.FileDrop.Directory})
+	}
+	if cfg.Webhook != nil {
+		if cfg.Webhook.URL == "" {
+			return nil, fmt.Errorf("billing provider webhook requires a URL")
+		}
+		sinks = append(sinks, NewWebhookSink(cfg.Webhook.URL))
+	}
+	if len(sinks) == 0 {
+		return nil, fmt.Errorf("billing provider requires a file drop or webhook")
+	}
+	return NewDocumentProvider(sinks, conn), nil
+}
+
+type DocumentKind string
+
+const (
+	DocumentKind_Customer     DocumentKind = "customer"
+	DocumentKind_Subscription DocumentKind = "subscription"
+	DocumentKind_Invoice      DocumentKind = "invoice"
+)
+
+// Document is handed to downstream systems such as an ERP
+type Document struct {
+	Kind DocumentKind `json:"kind"`
+	ID   string       `json:"id"`
+	Data interface{}  `json:"data"`
+}
+
+// Sink delivers documents to downstream systems
+type Sink interface {
+	Write(ctx context.Context, doc Document) error
+}
+
+func NewDocumentProvider(sink Sink, conn *gorm.DB) *DocumentProvider {
+	return &DocumentProvider{
+		sink:    sink,
+		conn:    conn,
+		nowFunc: time.Now,
+	}
+}
+
+var _ BillingProvider = (*DocumentProvider)(nil)
+
+// DocumentProvider hands customers, subscriptions and invoices as JSON documents to downstream systems, which do the actual invoicing.
+// Customers and subscriptions are identified by their attribution ID, and are managed by the downstream systems once handed over.
+// Each usage report updates the open invoice of the billing cycle, which is finalised once the billing cycle has ended.
+type DocumentProvider struct {
+	sink    Sink
+	conn    *gorm.DB
+	nowFunc func() time.Time
+}
+
+// GetCustomer returns ErrNotFound, as the customers handed to downstream systems are only recorded by the billing service
+func (p *DocumentProvider) GetCustomer(ctx context.Context, attributionID db.AttributionID) (*Customer, error) {
+	return nil, fmt.Errorf("customer for %s: %w", attributionID, ErrNotFound)
+}
+
+// GetCustomerByID returns ErrNotFound, as the customers handed to downstream systems are only recorded by the billing service
+func (p *DocumentProvider) GetCustomerByID(ctx context.Context, customerID string) (*Customer, error) {
+	return nil, fmt.Errorf("customer %s: %w", customerID, ErrNotFound)
+}
+
+func (p *DocumentProvider) CreateCustomer(ctx context.Context, params CreateCustomerParams) (*Customer, error) {
+	customer := &Customer{
+		ID:            string(params.AttributionID),
+		AttributionID: params.AttributionID,
+		Name:          params.Name,
+		Email:         params.Email,
+		Currency:      params.Currency,
+		CreationTime:  p.nowFunc().UTC(),
+	}
+	err := p.sink.Write(ctx, Document{Kind: DocumentKind_Customer, ID: customer.ID, Data: customer})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write customer %s: %w", customer.ID, err)
+	}
+	return customer, nil
+}
+
+// CreateSubscription returns ErrNotSupported if a payment method was set up, as downstream systems collect the payments
+func (p *DocumentProvider) CreateSubscription(ctx context.Context, customer *Customer, params CreateSubscriptionParams) (*Subscription, error) {
+	if params.PaymentSetupID != "" {
+		return nil, fmt.Errorf("payment setup %s: %w", params.PaymentSetupID, ErrNotSupported)
+	}
+
+	stored := db.BillingSubscription{
+		ID:            customer.ID,
+		CustomerID:    customer.ID,
+		AttributionID: customer.AttributionID,
+		PriceID:       params.PriceID,
+		CreationTime:  db.NewVarCharTime(p.nowFunc()),
+	}
+	subscription := convertBillingSubscription(stored)
+	// the document is written before the subscription is stored, so that a failed write is retried
+	err := p.sink.Write(ctx, Document{Kind: DocumentKind_Subscription, ID: subscription.ID, Data: subscription})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write subscription %s: %w", subscription.ID, err)
+	}
+
+	err = db.CreateBillingSubscription(ctx, p.conn, stored)
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (p *DocumentProvider) GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error) {
+	stored, err := db.GetBillingSubscription(ctx, p.conn, subscriptionID)
+	if errors.Is(err, db.ErrorNotFound) {
+		return nil, fmt.Errorf("subscription %s: %w", subscriptionID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertBillingSubscription(stored), nil
+}
+
+// GetActiveSubscription returns the subscription handed to downstream systems. Subscriptions are never cancelled
+// through the billing service, hence they stay active.
+func (p *DocumentProvider) GetActiveSubscription(ctx context.Context, customerID string) (*Subscription, error) {
+	stored, err := db.GetBillingSubscriptionByCustomerID(ctx, p.conn, customerID)
+	if errors.Is(err, db.ErrorNotFound) {
+		return nil, fmt.Errorf("active subscription of customer %s: %w", customerID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertBillingSubscription(stored), nil
+}
+
+func convertBillingSubscription(subscription db.BillingSubscription) *Subscription {
+	return &Subscription{
+		ID:            subscription.ID,
+		CustomerID:    subscription.CustomerID,
+		AttributionID: subscription.AttributionID,
+		PriceID:       subscription.PriceID,
+		Active:        true,
+	}
+}
+
+// GetPriceDescription returns no description, as downstream systems manage prices
+func (p *DocumentProvider) GetPriceDescription(ctx context.Context, priceID string) (string, error) {
+	return "", nil
+}
+
+func (p *DocumentProvider) ReportUsage(ctx context.Context, reports []UsageReport) error {
+	for _, report := range reports {
+		credits := report.Credits
+		if credits < 0 {
+			// negative invoices don't make sense, credit notes are settled within the billing cycle
+			credits = 0
+		}
+		invoice := db.BillingInvoice{
+			ID:                invoiceID(report.AttributionID, report.BillingCycleStart),
+			CustomerID:        string(report.AttributionID),
+			AttributionID:     report.AttributionID,
+			Credits:           credits,
+			BillingCycleStart: db.NewVarCharTime(report.BillingCycleStart),
+			BillingCycleEnd:   db.NewVarCharTime(report.BillingCycleEnd),
+		}
+
+		stored, err := db.StoreOpenBillingInvoice(ctx, p.conn, invoice)
+		if err != nil {
+			return err
+		}
+		if !stored {
+			// the invoice has been finalised and handed over already
+			continue
+		}
+
+		invoice.Status = db.BillingInvoiceStatus_Open
+		err = p.sink.Write(ctx, Document{Kind: DocumentKind_Invoice, ID: invoice.ID, Data: convertBillingInvoice(invoice)})
+		if err != nil {
+			return fmt.Errorf("failed to write invoice %s: %w", invoice.ID, err)
+		}
+	}
+	return nil
+}
+
+// ListDueInvoices returns the open invoices of billing cycles which have ended
+func (p *DocumentProvider) ListDueInvoices(ctx context.Context, now time.Time) ([]string, error) {
+	invoices, err := db.ListOpenBillingInvoicesEndedBy(ctx, p.conn, now)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(invoices))
+	for _, invoice := range invoices {
+		ids = append(ids, invoice.ID)
+	}
+	return ids, nil
+}
+
+func (p *DocumentProvider) FinalizeInvoice(ctx context.Context, id string) (*Invoice, error) {
+	stored, err := db.GetBillingInvoice(ctx, p.conn, id)
+	if errors.Is(err, db.ErrorNotFound) || (err == nil && stored.Status != db.BillingInvoiceStatus_Open) {
+		return nil, fmt.Errorf("open invoice %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stored.Status = db.BillingInvoiceStatus_Finalized
+	stored.FinalizedTime = db.NewVarCharTime(p.nowFunc())
+	invoice := convertBillingInvoice(stored)
+	// the document is written before the invoice is marked as finalised, so that a failed write is retried
+	err = p.sink.Write(ctx, Document{Kind: DocumentKind_Invoice, ID: invoice.ID, Data: invoice})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write invoice %s: %w", invoice.ID, err)
+	}
+
+	err = db.FinalizeBillingInvoice(ctx, p.conn, id, stored.FinalizedTime.Time())
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithField("invoice_id", id).WithField("attribution_id", invoice.AttributionID).Info("Finalized invoice.")
+	return &invoice, nil
+}
+
+func convertBillingInvoice(invoice db.BillingInvoice) Invoice {
+	res := Invoice{
+		ID:            invoice.ID,
+		CustomerID:    invoice.CustomerID,
+		AttributionID: invoice.AttributionID,
+		Status:        InvoiceStatus_Open,
+		Credits:       invoice.Credits,
+		PeriodStart:   invoice.BillingCycleStart.Time(),
+		PeriodEnd:     invoice.BillingCycleEnd.Time(),
+	}
+	if invoice.Status == db.BillingInvoiceStatus_Finalized {
+		res.Status = InvoiceStatus_Finalized
+		res.FinalizedAt = invoice.FinalizedTime.Time()
+	}
+	return res
+}
+
+// invoiceID identifies the invoice of a billing cycle
+func invoiceID(attributionID db.AttributionID, billingCycleStart time.Time) string {
+	return fmt.Sprintf("%s-%s", strings.ReplaceAll(string(attributionID), ":", "-"), billingCycleStart.UTC().Format("20060102"))
+}
+
+// FileSink writes documents to <Directory>/<kind>/<id>.json
+type FileSink struct {
+	Directory string
+}
+
+func (s *FileSink) Write(ctx context.Context, doc Document) error {
+	dir := filepath.Join(s.Directory, string(doc.Kind))
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	data, err := json.MarshalIndent(doc.Data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s %s: %w", doc.Kind, doc.ID, err)
+	}
+
+	// write to a temporary file first so that downstream systems never read partial documents
+	fn := filepath.Join(dir, doc.ID+".json")
+	tmp := fn + ".tmp"
+	err = os.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+	err = os.Rename(tmp, fn)
+	if err != nil {
+		return fmt.Errorf("failed to rename %s: %w", tmp, err)
+	}
+	return nil
+}
+
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// WebhookSink posts documents as JSON to a URL
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+func (s *WebhookSink) Write(ctx context.Context, doc Document) error {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s %s: %w", doc.Kind, doc.ID, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// MultiSink writes documents to all sinks
+type MultiSink []Sink
+
+func (m MultiSink) Write(ctx context.Context, doc Document) error {
+	for _, s := range m {
+		err := s.Write(ctx, doc)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package billing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestDocumentProvider_InvoiceLifecycle(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		dir           = t.TempDir()
+		teamID        = uuid.New().String()
+		attributionID = db.NewTeamAttributionID(teamID)
+		conn          = connectForTests(t, attributionID)
+		cycleStart    = time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+		cycleEnd      = time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+		finalizedAt   = time.Date(2023, 4, 1, 0, 5, 0, 0, time.UTC)
+		expectedID    = "team-" + teamID + "-20230301"
+	)
+
+	provider := NewDocumentProvider(&FileSink{Directory: dir}, conn)
+	provider.nowFunc = func() time.Time { return finalizedAt }
+
+	readInvoice := func(t *testing.T) Invoice {
+		t.Helper()
+		b, err := os.ReadFile(filepath.Join(dir, "invoice", expectedID+".json"))
+		require.NoError(t, err)
+		var invoice Invoice
+		require.NoError(t, json.Unmarshal(b, &invoice))
+		return invoice
+	}
+
+	report := func(credits int64) {
+		require.NoError(t, provider.ReportUsage(ctx, []UsageReport{{
+			AttributionID:     attributionID,
+			Credits:           credits,
+			BillingCycleStart: cycleStart,
+			BillingCycleEnd:   cycleEnd,
+		}}))
+	}
+
+	report(10)
+	report(25)
+
+	invoice := readInvoice(t)
+	require.Equal(t, Invoice{
+		ID:            expectedID,
+		CustomerID:    string(attributionID),
+		AttributionID: attributionID,
+		Status:        InvoiceStatus_Open,
+		Credits:       25,
+		PeriodStart:   cycleStart,
+		PeriodEnd:     cycleEnd,
+	}, invoice)
+
+	due, err := provider.ListDueInvoices(ctx, cycleEnd.Add(-time.Second))
+	require.NoError(t, err)
+	require.Empty(t, due)
+	due, err = provider.ListDueInvoices(ctx, finalizedAt)
+	require.NoError(t, err)
+	require.Equal(t, []string{expectedID}, due)
+
+	// a provider created after a restart finalises the invoice reported before
+	provider = NewDocumentProvider(&FileSink{Directory: dir}, conn)
+	provider.nowFunc = func() time.Time { return finalizedAt }
+
+	finalized, err := provider.FinalizeInvoice(ctx, expectedID)
+	require.NoError(t, err)
+	require.Equal(t, InvoiceStatus_Finalized, finalized.Status)
+	require.Equal(t, int64(25), finalized.Credits)
+	require.Equal(t, finalizedAt, finalized.FinalizedAt)
+	require.Equal(t, *finalized, readInvoice(t))
+
+	_, err = provider.FinalizeInvoice(ctx, expectedID)
+	require.True(t, errors.Is(err, ErrNotFound))
+
+	// reports after the invoice has been finalised do not change it anymore
+	report(30)
+	require.Equal(t, *finalized, readInvoice(t))
+}
+
+func TestDocumentProvider_NegativeUsageIsNotInvoiced(t *testing.T) {
+	attributionID := db.NewUserAttributionID(uuid.New().String())
+	sink := &recordingSink{}
+	provider := NewDocumentProvider(sink, connectForTests(t, attributionID))
+
+	err := provider.ReportUsage(context.Background(), []UsageReport{{
+		AttributionID:     attributionID,
+		Credits:           -5,
+		BillingCycleStart: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		BillingCycleEnd:   time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+	}})
+	require.NoError(t, err)
+	require.Len(t, sink.docs, 1)
+	require.Equal(t, int64(0), sink.docs[0].Data.(Invoice).Credits)
+}
+
+// connectForTests connects to the test database and removes the invoices and subscriptions of attributionID after the test
+func connectForTests(t *testing.T, attributionID db.AttributionID) *gorm.DB {
+	t.Helper()
+	conn := dbtest.ConnectForTests(t)
+	t.Cleanup(func() {
+		conn.Where("attributionId = ?", attributionID).Delete(&db.BillingInvoice{})
+		conn.Where("attributionId = ?", attributionID).Delete(&db.BillingSubscription{})
+	})
+	return conn
+}
+
+func TestDocumentProvider_Subscriptions(t *testing.T) {
+	ctx := context.Background()
+	sink := &recordingSink{}
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	provider := NewDocumentProvider(sink, connectForTests(t, attributionID))
+
+	customer, err := provider.CreateCustomer(ctx, CreateCustomerParams{AttributionID: attributionID, Name: "ACME", Currency: "EUR"})
+	require.NoError(t, err)
+
+	_, err = provider.GetActiveSubscription(ctx, customer.ID)
+	require.True(t, errors.Is(err, ErrNotFound))
+
+	_, err = provider.CreateSubscription(ctx, customer, CreateSubscriptionParams{PriceID: "price-1", PaymentSetupID: "seti_123"})
+	require.True(t, errors.Is(err, ErrNotSupported))
+
+	subscription, err := provider.CreateSubscription(ctx, customer, CreateSubscriptionParams{PriceID: "price-1"})
+	require.NoError(t, err)
+
+	read, err := provider.GetSubscription(ctx, subscription.ID)
+	require.NoError(t, err)
+	require.Equal(t, attributionID, read.AttributionID)
+	require.Equal(t, []DocumentKind{DocumentKind_Customer, DocumentKind_Subscription}, []DocumentKind{sink.docs[0].Kind, sink.docs[1].Kind})
+
+	// a provider created after a restart knows the subscription
+	provider = NewDocumentProvider(sink, provider.conn)
+	active, err := provider.GetActiveSubscription(ctx, customer.ID)
+	require.NoError(t, err)
+	require.Equal(t, subscription, active)
+
+	_, err = provider.GetSubscription(ctx, "sub_123")
+	require.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received Document
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(b, &received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	err := NewWebhookSink(srv.URL).Write(context.Background(), Document{Kind: DocumentKind_Invoice, ID: "inv-1", Data: map[string]string{"foo": "bar"}})
+	require.NoError(t, err)
+	require.Equal(t, DocumentKind_Invoice, received.Kind)
+	require.Equal(t, "inv-1", received.ID)
+	require.Equal(t, map[string]interface{}{"foo": "bar"}, received.Data)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	require.Error(t, NewWebhookSink(failing.URL).Write(context.Background(), Document{Kind: DocumentKind_Invoice, ID: "inv-1"}))
+}
+
+type recordingSink struct {
+	docs []Document
+}
+
+func (s *recordingSink) Write(ctx context.Context, doc Document) error {
+	s.docs = append(s.docs, doc)
+	return nil
+}
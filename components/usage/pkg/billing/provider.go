@@ -0,0 +1,107 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package billing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	// ErrNotSupported is returned for operations which the billing system does not support, such as collecting payments
+	ErrNotSupported = errors.New("not supported by the billing provider")
+	// ErrPaymentSetupFailed is returned if the payment method the customer set up cannot be used
+	ErrPaymentSetupFailed = errors.New("payment setup failed")
+)
+
+// BillingProvider invoices usage through a billing system.
+// Cost centers with the 'stripe' billing strategy are billed externally, through the configured provider.
+type BillingProvider interface {
+	// GetCustomer returns the customer billed for attributionID, or ErrNotFound
+	GetCustomer(ctx context.Context, attributionID db.AttributionID) (*Customer, error)
+	// GetCustomerByID returns the customer with customerID, or ErrNotFound
+	GetCustomerByID(ctx context.Context, customerID string) (*Customer, error)
+	CreateCustomer(ctx context.Context, params CreateCustomerParams) (*Customer, error)
+
+	// CreateSubscription subscribes the customer to usage based billing
+	CreateSubscription(ctx context.Context, customer *Customer, params CreateSubscriptionParams) (*Subscription, error)
+	// GetSubscription returns the subscription with subscriptionID, or ErrNotFound
+	GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error)
+	// GetActiveSubscription returns the subscription of customerID which has not been cancelled, or ErrNotFound
+	GetActiveSubscription(ctx context.Context, customerID string) (*Subscription, error)
+
+	// GetPriceDescription returns a human readable description of the price with priceID, or an empty string if there is none
+	GetPriceDescription(ctx context.Context, priceID string) (string, error)
+
+	// ReportUsage reports the credits used in the current billing cycle of each cost center
+	ReportUsage(ctx context.Context, reports []UsageReport) error
+
+	// ListDueInvoices returns the IDs of the invoices which are due to be finalised at now.
+	// Providers which notify about finalised invoices themselves, like Stripe through webhooks, return none.
+	ListDueInvoices(ctx context.Context, now time.Time) ([]string, error)
+	// FinalizeInvoice returns the invoice with invoiceID once it has been finalised in the billing system, or ErrNotFound
+	FinalizeInvoice(ctx context.Context, invoiceID string) (*Invoice, error)
+}
+
+type Customer struct {
+	ID            string           `json:"id"`
+	AttributionID db.AttributionID `json:"attributionId"`
+	Name          string           `json:"name,omitempty"`
+	Email         string           `json:"email,omitempty"`
+	Currency      string           `json:"currency,omitempty"`
+	CreationTime  time.Time        `json:"creationTime"`
+}
+
+type CreateCustomerParams struct {
+	AttributionID db.AttributionID
+	Name          string
+	Email         string
+	Currency      string
+}
+
+type CreateSubscriptionParams struct {
+	PriceID string
+	// PaymentSetupID identifies the payment method the customer set up, if the billing system collects payments
+	PaymentSetupID string
+}
+
+type Subscription struct {
+	ID            string           `json:"id"`
+	CustomerID    string           `json:"customerId"`
+	AttributionID db.AttributionID `json:"attributionId"`
+	PriceID       string           `json:"priceId,omitempty"`
+	Active        bool             `json:"active"`
+}
+
+type InvoiceStatus string
+
+const (
+	InvoiceStatus_Open      InvoiceStatus = "open"
+	InvoiceStatus_Finalized InvoiceStatus = "finalized"
+)
+
+type Invoice struct {
+	ID            string           `json:"id"`
+	CustomerID    string           `json:"customerId"`
+	AttributionID db.AttributionID `json:"attributionId"`
+	Status        InvoiceStatus    `json:"status"`
+	// Credits is the number of credits billed with the invoice
+	Credits     int64     `json:"credits"`
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+	FinalizedAt time.Time `json:"finalizedAt,omitempty"`
+}
+
+// UsageReport is the usage of a cost center in its current billing cycle
+type UsageReport struct {
+	AttributionID     db.AttributionID
+	Credits           int64
+	BillingCycleStart time.Time
+	BillingCycleEnd   time.Time
+}
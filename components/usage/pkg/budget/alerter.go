@@ -0,0 +1,211 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package budget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"gorm.io/gorm"
+)
+
+// DefaultThresholds are the percentages of the spending limit at which alerts are sent by default
+var DefaultThresholds = []int{50, 80, 100}
+
+// Config configures budget alerts
+type Config struct {
+	// Schedule determines how frequently cost centers are checked. When empty, budget alerts are disabled.
+	Schedule string `json:"schedule,omitempty"`
+
+	// Thresholds are the percentages of the spending limit at which alerts are sent. Defaults to DefaultThresholds.
+	Thresholds []int `json:"thresholds,omitempty"`
+
+	// WebhookURL receives alerts as JSON POST requests in addition to them being logged.
+	WebhookURL string `json:"webhookURL,omitempty"`
+}
+
+func NewAlerter(conn *gorm.DB, costCenterManager *db.CostCenterManager, notifiers []Notifier, thresholds []int) *Alerter {
+	if len(thresholds) == 0 {
+		thresholds = DefaultThresholds
+	}
+	thresholds = append([]int(nil), thresholds...)
+	sort.Ints(thresholds)
+
+	return &Alerter{
+		conn:              conn,
+		costCenterManager: costCenterManager,
+		notifiers:         notifiers,
+		thresholds:        thresholds,
+		nowFunc: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// Alerter sends alerts for cost centers whose spending reaches a threshold of their spending limit,
+// or is forecast to exceed it. Each alert is sent through each notifier once per billing cycle, which
+// alerts have been sent through which notifier is recorded in the DB. A notifier which fails is retried
+// on the next run, without sending the alert through the other notifiers again.
+type Alerter struct {
+	conn              *gorm.DB
+	costCenterManager *db.CostCenterManager
+	notifiers         []Notifier
+	thresholds        []int
+	nowFunc           func() time.Time
+}
+
+type alertKey struct {
+	Kind      AlertKind
+	Threshold int
+	Notifier  string
+}
+
+// Run checks all cost centers with a positive balance and implements scheduler.Job
+func (a *Alerter) Run() error {
+	ctx := context.Background()
+	now := a.nowFunc()
+
+	balances, err := db.ListBalance(ctx, a.conn)
+	if err != nil {
+		return fmt.Errorf("failed to list balances: %w", err)
+	}
+
+	var failed int
+	for _, balance := range balances {
+		if balance.CreditCents <= 0 {
+			continue
+		}
+		logger := log.WithField("attribution_id", balance.AttributionID)
+
+		cc, err := a.costCenterManager.GetCostCenter(ctx, balance.AttributionID)
+		if errors.Is(err, db.CostCenterNotFound) {
+			// without a cost center there is no spending limit
+			continue
+		}
+		if err != nil {
+			logger.WithError(err).Error("Failed to get cost center.")
+			failed++
+			continue
+		}
+		if !cc.BillingCycleStart.IsSet() {
+			// alerts are sent once per billing cycle, without one we couldn't tell when to send them again
+			logger.Debug("Cost center has no billing cycle, not checking its budget.")
+			continue
+		}
+		forecast, err := NewForecast(ctx, a.conn, cc, now)
+		if err != nil {
+			logger.WithError(err).Error("Failed to forecast spending.")
+			failed++
+			continue
+		}
+
+		err = a.send(ctx, forecast)
+		if err != nil {
+			logger.WithError(err).Error("Failed to send budget alerts.")
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to check budget of %d cost centers", failed)
+	}
+	return nil
+}
+
+// alertsFor returns the alerts of all thresholds the forecast reached
+func alertsFor(f Forecast, thresholds []int) []Alert {
+	if f.SpendingLimit <= 0 {
+		return nil
+	}
+
+	newAlert := func(kind AlertKind, threshold int) Alert {
+		return Alert{
+			Kind:            kind,
+			AttributionID:   string(f.AttributionID),
+			Threshold:       threshold,
+			SpendingLimit:   f.SpendingLimit,
+			Credits:         f.Credits,
+			ForecastCredits: f.ForecastCredits,
+			BillingCycleEnd: f.CycleEnd,
+		}
+	}
+
+	var res []Alert
+	limit := float64(f.SpendingLimit)
+	for _, threshold := range thresholds {
+		if f.Credits >= limit*float64(threshold)/100 {
+			res = append(res, newAlert(AlertKindSpending, threshold))
+		}
+	}
+	if f.Credits < limit && f.ForecastCredits >= limit {
+		res = append(res, newAlert(AlertKindForecast, 100))
+	}
+	return res
+}
+
+// send delivers the alerts of the forecast through every notifier which has not delivered them yet
+func (a *Alerter) send(ctx context.Context, f Forecast) error {
+	alerts := alertsFor(f, a.thresholds)
+	if len(alerts) == 0 {
+		return nil
+	}
+	sent, err := a.sent(ctx, f)
+	if err != nil {
+		return fmt.Errorf("failed to find sent budget alerts: %w", err)
+	}
+
+	var failed int
+	for _, alert := range alerts {
+		for _, notifier := range a.notifiers {
+			if _, ok := sent[alertKey{Kind: alert.Kind, Threshold: alert.Threshold, Notifier: notifier.Name()}]; ok {
+				continue
+			}
+			logger := log.WithField("alert", alert).WithField("notifier", notifier.Name())
+			err := notifier.Notify(ctx, alert)
+			if err != nil {
+				logger.WithError(err).Error("Failed to send budget alert.")
+				failed++
+				continue
+			}
+			err = a.markSent(ctx, f, alert, notifier.Name())
+			if err != nil {
+				logger.WithError(err).Error("Failed to record sent budget alert.")
+				failed++
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to send %d budget alerts", failed)
+	}
+	return nil
+}
+
+// sent returns the alerts which have already been sent through each notifier in the forecast's billing cycle
+func (a *Alerter) sent(ctx context.Context, f Forecast) (map[alertKey]struct{}, error) {
+	sent, err := db.ListSentBudgetAlerts(ctx, a.conn, f.AttributionID, f.CycleStart)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[alertKey]struct{}, len(sent))
+	for _, s := range sent {
+		res[alertKey{Kind: AlertKind(s.Kind), Threshold: int(s.Threshold), Notifier: s.Notifier}] = struct{}{}
+	}
+	return res, nil
+}
+
+func (a *Alerter) markSent(ctx context.Context, f Forecast, alert Alert, notifier string) error {
+	return db.RecordSentBudgetAlert(ctx, a.conn, db.SentBudgetAlert{
+		AttributionID:     f.AttributionID,
+		BillingCycleStart: db.NewVarCharTime(f.CycleStart),
+		Kind:              string(alert.Kind),
+		Threshold:         int32(alert.Threshold),
+		Notifier:          notifier,
+		SentTime:          db.NewVarCharTime(a.nowFunc()),
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package budget
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertsFor(t *testing.T) {
+	type expectation struct {
+		Kind      AlertKind
+		Threshold int
+	}
+
+	testCases := []struct {
+		Name     string
+		Forecast Forecast
+		Expected []expectation
+	}{
+		{
+			Name:     "no spending limit",
+			Forecast: Forecast{Credits: 1000, ForecastCredits: 2000},
+		},
+		{
+			Name:     "below all thresholds",
+			Forecast: Forecast{SpendingLimit: 100, Credits: 40, ForecastCredits: 60},
+		},
+		{
+			Name:     "reached first threshold",
+			Forecast: Forecast{SpendingLimit: 100, Credits: 50, ForecastCredits: 70},
+			Expected: []expectation{{AlertKindSpending, 50}},
+		},
+		{
+			Name:     "forecast to exceed limit",
+			Forecast: Forecast{SpendingLimit: 100, Credits: 85, ForecastCredits: 120},
+			Expected: []expectation{{AlertKindSpending, 50}, {AlertKindSpending, 80}, {AlertKindForecast, 100}},
+		},
+		{
+			Name:     "reached limit",
+			Forecast: Forecast{SpendingLimit: 100, Credits: 100, ForecastCredits: 150},
+			Expected: []expectation{{AlertKindSpending, 50}, {AlertKindSpending, 80}, {AlertKindSpending, 100}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var actual []expectation
+			for _, alert := range alertsFor(tc.Forecast, DefaultThresholds) {
+				actual = append(actual, expectation{alert.Kind, alert.Threshold})
+			}
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+// recordingNotifier records the alerts it delivers and fails while err is set
+type recordingNotifier struct {
+	name   string
+	err    error
+	alerts []Alert
+}
+
+func (n *recordingNotifier) Name() string {
+	return n.name
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, alert Alert) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+func TestAlerter_SendsAlertsOncePerCycle(t *testing.T) {
+	var (
+		conn          = dbtest.ConnectForTests(t)
+		ctx           = context.Background()
+		attributionID = db.NewTeamAttributionID(uuid.New().String())
+		cycleStart    = time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+		forecast      = Forecast{AttributionID: attributionID, SpendingLimit: 100, Credits: 60, CycleStart: cycleStart}
+		notifier      = &recordingNotifier{name: "test"}
+	)
+	t.Cleanup(func() {
+		conn.Where("attributionId = ?", attributionID).Delete(&db.SentBudgetAlert{})
+	})
+
+	alerter := NewAlerter(conn, nil, []Notifier{notifier}, nil)
+	require.NoError(t, alerter.send(ctx, forecast))
+	require.Len(t, notifier.alerts, 1)
+
+	require.NoError(t, alerter.send(ctx, forecast))
+	require.Len(t, notifier.alerts, 1, "alert must not be sent twice")
+	require.NoError(t, NewAlerter(conn, nil, []Notifier{notifier}, nil).send(ctx, forecast))
+	require.Len(t, notifier.alerts, 1, "alert must not be sent again after a restart")
+
+	forecast.Credits = 90
+	require.NoError(t, alerter.send(ctx, forecast))
+	require.Len(t, notifier.alerts, 2)
+	require.Equal(t, 80, notifier.alerts[1].Threshold)
+
+	forecast.CycleStart = cycleStart.AddDate(0, 1, 0)
+	require.NoError(t, alerter.send(ctx, forecast))
+	require.Len(t, notifier.alerts, 4, "alerts must be sent again in a new billing cycle")
+}
+
+func TestAlerter_RetriesFailedNotifiersOnly(t *testing.T) {
+	var (
+		conn          = dbtest.ConnectForTests(t)
+		ctx           = context.Background()
+		attributionID = db.NewTeamAttributionID(uuid.New().String())
+		forecast      = Forecast{AttributionID: attributionID, SpendingLimit: 100, Credits: 60, CycleStart: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)}
+		working       = &recordingNotifier{name: "working"}
+		failing       = &recordingNotifier{name: "failing", err: errors.New("unavailable")}
+	)
+	t.Cleanup(func() {
+		conn.Where("attributionId = ?", attributionID).Delete(&db.SentBudgetAlert{})
+	})
+
+	alerter := NewAlerter(conn, nil, []Notifier{working, failing}, nil)
+	require.Error(t, alerter.send(ctx, forecast))
+	require.Len(t, working.alerts, 1)
+	require.Empty(t, failing.alerts)
+
+	failing.err = nil
+	require.NoError(t, alerter.send(ctx, forecast))
+	require.Len(t, working.alerts, 1, "alert must not be sent again through the notifier which delivered it")
+	require.Len(t, failing.alerts, 1)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package budget
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"gorm.io/gorm"
+)
+
+// ForecastWindow is the period of recent usage which forecasts extrapolate
+const ForecastWindow = 7 * 24 * time.Hour
+
+// Forecast projects the spending of a cost center to the end of its billing cycle
+type Forecast struct {
+	AttributionID db.AttributionID
+	SpendingLimit int32
+
+	// Credits is the current balance
+	Credits float64
+	// ForecastCredits is the balance projected to CycleEnd
+	ForecastCredits float64
+
+	CycleStart time.Time
+	// CycleEnd is zero if the cost center has no billing cycle end
+	CycleEnd time.Time
+}
+
+// NewForecast extrapolates the usage of the cost center within the ForecastWindow, or within
+// the current billing cycle if that started more recently, to the end of the billing cycle.
+func NewForecast(ctx context.Context, conn *gorm.DB, cc db.CostCenter, now time.Time) (Forecast, error) {
+	balance, err := db.GetBalance(ctx, conn, cc.ID)
+	if err != nil {
+		return Forecast{}, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	windowStart := now.Add(-ForecastWindow)
+	if cc.BillingCycleStart.IsSet() && cc.BillingCycleStart.Time().After(windowStart) {
+		windowStart = cc.BillingCycleStart.Time()
+	}
+	recent, err := db.GetUsageSummary(ctx, conn, db.GetUsageSummaryParams{
+		AttributionId: cc.ID,
+		From:          windowStart,
+		To:            now,
+	})
+	if err != nil {
+		return Forecast{}, fmt.Errorf("failed to get recent usage: %w", err)
+	}
+
+	res := Forecast{
+		AttributionID:   cc.ID,
+		SpendingLimit:   cc.SpendingLimit,
+		Credits:         balance.ToCredits(),
+		ForecastCredits: balance.ToCredits(),
+	}
+	if cc.BillingCycleStart.IsSet() {
+		res.CycleStart = cc.BillingCycleStart.Time()
+	}
+	if cc.NextBillingTime.IsSet() {
+		res.CycleEnd = cc.NextBillingTime.Time()
+		res.ForecastCredits = extrapolate(res.Credits, recent.CreditCentsUsed.ToCredits(), now.Sub(windowStart), res.CycleEnd.Sub(now))
+	}
+	return res, nil
+}
+
+// extrapolate adds the credits which are used during the remaining time at the rate of the recent usage to the balance
+func extrapolate(balance, recent float64, window, remaining time.Duration) float64 {
+	if window <= 0 || remaining <= 0 || recent <= 0 {
+		return balance
+	}
+	return balance + recent/window.Hours()*remaining.Hours()
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package budget
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtrapolate(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Balance   float64
+		Recent    float64
+		Window    time.Duration
+		Remaining time.Duration
+		Expected  float64
+	}{
+		{
+			Name:      "extrapolates recent usage",
+			Balance:   100,
+			Recent:    70,
+			Window:    7 * 24 * time.Hour,
+			Remaining: 14 * 24 * time.Hour,
+			Expected:  240,
+		},
+		{
+			Name:      "no recent usage",
+			Balance:   100,
+			Window:    7 * 24 * time.Hour,
+			Remaining: 14 * 24 * time.Hour,
+			Expected:  100,
+		},
+		{
+			Name:     "cycle already ended",
+			Balance:  100,
+			Recent:   70,
+			Window:   7 * 24 * time.Hour,
+			Expected: 100,
+		},
+		{
+			Name:      "empty window",
+			Balance:   100,
+			Recent:    70,
+			Remaining: 14 * 24 * time.Hour,
+			Expected:  100,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := extrapolate(tc.Balance, tc.Recent, tc.Window, tc.Remaining)
+			require.InDelta(t, tc.Expected, actual, 0.0000001)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package budget
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+type AlertKind string
+
+const (
+	// AlertKindSpending alerts that the balance reached a threshold of the spending limit
+	AlertKindSpending AlertKind = "spending"
+	// AlertKindForecast alerts that the balance is forecast to exceed the spending limit by the end of the billing cycle
+	AlertKindForecast AlertKind = "forecast"
+)
+
+// Alert is sent when the spending of a cost center crosses a threshold
+type Alert struct {
+	Kind            AlertKind `json:"kind"`
+	AttributionID   string    `json:"attributionId"`
+	Threshold       int       `json:"threshold"`
+	SpendingLimit   int32     `json:"spendingLimit"`
+	Credits         float64   `json:"credits"`
+	ForecastCredits float64   `json:"forecastCredits"`
+	BillingCycleEnd time.Time `json:"billingCycleEnd"`
+}
+
+// Notifier delivers alerts
+type Notifier interface {
+	// Name identifies the notifier in the record of sent alerts, hence it must not change
+	Name() string
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// LogNotifier logs alerts
+type LogNotifier struct{}
+
+func (LogNotifier) Name() string {
+	return "log"
+}
+
+func (LogNotifier) Notify(ctx context.Context, alert Alert) error {
+	log.WithField("alert", alert).Infof("Cost center %s reached %d%% of its spending limit.", alert.AttributionID, alert.Threshold)
+	return nil
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// WebhookNotifier posts alerts as JSON to a URL
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func (n *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
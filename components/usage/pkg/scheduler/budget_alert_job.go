@@ -0,0 +1,13 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package scheduler
+
+import (
+	"time"
+)
+
+func NewBudgetAlertJobSpec(schedule time.Duration, job Job) (JobSpec, error) {
+	return NewPeriodicJobSpec(schedule, "budget_alerts", WithoutConcurrentRun(job))
+}
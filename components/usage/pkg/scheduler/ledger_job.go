@@ -43,7 +43,7 @@ func (r *LedgerJob) Run() (err error) {
 		WithField("to", now)
 
 	logger.Info("Running ledger job. Reconciling usage records.")
-	_, err = r.usageClient.ReconcileUsage(ctx, &v1.ReconcileUsageRequest{
+	reconciled, err := r.usageClient.ReconcileUsage(ctx, &v1.ReconcileUsageRequest{
 		From: timestamppb.New(hourAgo),
 		To:   timestamppb.New(now),
 	})
@@ -52,6 +52,18 @@ func (r *LedgerJob) Run() (err error) {
 		return fmt.Errorf("failed to reconcile usage with ledger: %w", err)
 	}
 
+	exceeded := reconciled.GetExceededSpendingLimits()
+	reportExceededSpendingLimits(len(exceeded))
+	for _, e := range exceeded {
+		logger.
+			WithField("attribution_id", e.GetAttributionId()).
+			WithField("scope", e.GetSpendingLimit().GetScope().String()).
+			WithField("scope_id", e.GetSpendingLimit().GetScopeId()).
+			WithField("spending_limit", e.GetSpendingLimit().GetSpendingLimit()).
+			WithField("credits_used", e.GetSpendingLimit().GetCreditsUsed()).
+			Warn("Nested spending limit exceeded by running workspaces.")
+	}
+
 	logger.Info("Starting invoice reconciliation.")
 	_, err = r.billingClient.ReconcileInvoices(ctx, &v1.ReconcileInvoicesRequest{})
 	if err != nil {
@@ -45,6 +45,13 @@ var (
 		Name:      "job_stopped_instances_without_stopping_time_count",
 		Help:      "Gauge of usage records where workpsace instance is stopped but doesn't have a stopping time",
 	})
+
+	exceededSpendingLimits = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "nested_spending_limits_exceeded_count",
+		Help:      "Gauge of project and user spending limits which are exceeded by running workspaces",
+	})
 )
 
 func RegisterMetrics(reg *prometheus.Registry) error {
@@ -53,6 +60,7 @@ func RegisterMetrics(reg *prometheus.Registry) error {
 		jobCompletedSeconds,
 		stoppedWithoutStoppingTime,
 		ledgerLastCompletedTime,
+		exceededSpendingLimits,
 	}
 	for _, metric := range metrics {
 		err := reg.Register(metric)
@@ -76,6 +84,10 @@ func reportLedgerCompleted(err error) {
 	ledgerLastCompletedTime.WithLabelValues(outcomeFromErr(err)).SetToCurrentTime()
 }
 
+func reportExceededSpendingLimits(count int) {
+	exceededSpendingLimits.Set(float64(count))
+}
+
 func outcomeFromErr(err error) string {
 	out := "success"
 	if err != nil {
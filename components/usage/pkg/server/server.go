@@ -20,6 +20,8 @@ import (
 	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	v1 "github.com/gitpod-io/gitpod/usage-api/v1"
 	"github.com/gitpod-io/gitpod/usage/pkg/apiv1"
+	"github.com/gitpod-io/gitpod/usage/pkg/billing"
+	"github.com/gitpod-io/gitpod/usage/pkg/budget"
 	"github.com/gitpod-io/gitpod/usage/pkg/stripe"
 	"gorm.io/gorm"
 )
@@ -33,10 +35,21 @@ type Config struct {
 	// When empty, the job is disabled.
 	ResetUsageSchedule string `json:"resetUsageSchedule,omitempty"`
 
+	// BudgetAlerts configures alerts for cost centers approaching their spending limit.
+	// When BudgetAlerts or its schedule is empty, budget alerts are disabled.
+	BudgetAlerts *budget.Config `json:"budgetAlerts,omitempty"`
+
 	CreditsPerMinuteByWorkspaceClass map[string]float64 `json:"creditsPerMinuteByWorkspaceClass,omitempty"`
 
+	// ResourcePricing prices workspace instances by the resources they use instead of by CreditsPerMinuteByWorkspaceClass.
+	ResourcePricing *apiv1.ResourcePricingConfig `json:"resourcePricing,omitempty"`
+
 	StripeCredentialsFile string `json:"stripeCredentialsFile,omitempty"`
 
+	// BillingProvider invoices usage through a file drop or webhook instead of Stripe, e.g. for an ERP.
+	// When neither BillingProvider nor StripeCredentialsFile are set, billing is disabled.
+	BillingProvider *billing.Config `json:"billingProvider,omitempty"`
+
 	Server *baseserver.Configuration `json:"server,omitempty"`
 
 	DefaultSpendingLimit db.DefaultSpendingLimit `json:"defaultSpendingLimit"`
@@ -83,9 +96,17 @@ func Start(cfg Config, version string) error {
 		return fmt.Errorf("failed to create self-connection to grpc server: %w", err)
 	}
 
-	pricer, err := apiv1.NewWorkspacePricer(cfg.CreditsPerMinuteByWorkspaceClass)
-	if err != nil {
-		return fmt.Errorf("failed to create workspace pricer: %w", err)
+	var pricer apiv1.Pricer
+	if cfg.ResourcePricing != nil {
+		pricer, err = apiv1.NewResourcePricer(*cfg.ResourcePricing)
+		if err != nil {
+			return fmt.Errorf("failed to create resource pricer: %w", err)
+		}
+	} else {
+		pricer, err = apiv1.NewWorkspacePricer(cfg.CreditsPerMinuteByWorkspaceClass)
+		if err != nil {
+			return fmt.Errorf("failed to create workspace pricer: %w", err)
+		}
 	}
 
 	var stripeClient *stripe.Client
@@ -103,6 +124,17 @@ func Start(cfg Config, version string) error {
 		stripeClient = c
 	}
 
+	var billingProvider billing.BillingProvider
+	if cfg.BillingProvider != nil {
+		p, err := billing.NewDocumentProviderFromConfig(*cfg.BillingProvider, conn)
+		if err != nil {
+			return fmt.Errorf("failed to initialize billing provider: %w", err)
+		}
+		billingProvider = p
+	} else if stripeClient != nil {
+		billingProvider = stripe.NewBillingProvider(stripeClient)
+	}
+
 	var schedulerJobSpecs []scheduler.JobSpec
 	if cfg.LedgerSchedule != "" {
 		// we do not run the controller if there is no schedule defined.
@@ -138,11 +170,31 @@ func Start(cfg Config, version string) error {
 		schedulerJobSpecs = append(schedulerJobSpecs, spec)
 	}
 
+	if cfg.BudgetAlerts != nil && cfg.BudgetAlerts.Schedule != "" {
+		schedule, err := time.ParseDuration(cfg.BudgetAlerts.Schedule)
+		if err != nil {
+			return fmt.Errorf("failed to parse budget alerts schedule as duration: %w", err)
+		}
+
+		notifiers := []budget.Notifier{budget.LogNotifier{}}
+		if cfg.BudgetAlerts.WebhookURL != "" {
+			notifiers = append(notifiers, budget.NewWebhookNotifier(cfg.BudgetAlerts.WebhookURL))
+		}
+		alerter := budget.NewAlerter(conn, db.NewCostCenterManager(conn, cfg.DefaultSpendingLimit), notifiers, cfg.BudgetAlerts.Thresholds)
+
+		spec, err := scheduler.NewBudgetAlertJobSpec(schedule, alerter)
+		if err != nil {
+			return fmt.Errorf("failed to setup budget alerts job: %w", err)
+		}
+
+		schedulerJobSpecs = append(schedulerJobSpecs, spec)
+	}
+
 	sched := scheduler.New(schedulerJobSpecs...)
 	sched.Start()
 	defer sched.Stop()
 
-	err = registerGRPCServices(srv, conn, stripeClient, pricer, cfg)
+	err = registerGRPCServices(srv, conn, billingProvider, pricer, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to register gRPC services: %w", err)
 	}
@@ -165,13 +217,13 @@ func Start(cfg Config, version string) error {
 	return nil
 }
 
-func registerGRPCServices(srv *baseserver.Server, conn *gorm.DB, stripeClient *stripe.Client, pricer *apiv1.WorkspacePricer, cfg Config) error {
+func registerGRPCServices(srv *baseserver.Server, conn *gorm.DB, billingProvider billing.BillingProvider, pricer apiv1.Pricer, cfg Config) error {
 	ccManager := db.NewCostCenterManager(conn, cfg.DefaultSpendingLimit)
 	v1.RegisterUsageServiceServer(srv.GRPC(), apiv1.NewUsageService(conn, pricer, ccManager))
-	if stripeClient == nil {
+	if billingProvider == nil {
 		v1.RegisterBillingServiceServer(srv.GRPC(), &apiv1.BillingServiceNoop{})
 	} else {
-		v1.RegisterBillingServiceServer(srv.GRPC(), apiv1.NewBillingService(stripeClient, conn, ccManager, cfg.StripePrices))
+		v1.RegisterBillingServiceServer(srv.GRPC(), apiv1.NewBillingService(billingProvider, conn, ccManager, cfg.StripePrices))
 	}
 	return nil
 }
@@ -0,0 +1,225 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/usage/pkg/billing"
+	"github.com/stripe/stripe-go/v72"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func NewBillingProvider(client *Client) *BillingProvider {
+	return &BillingProvider{client: client}
+}
+
+var _ billing.BillingProvider = (*BillingProvider)(nil)
+
+// BillingProvider bills usage through Stripe subscriptions
+type BillingProvider struct {
+	client *Client
+}
+
+func (p *BillingProvider) GetCustomer(ctx context.Context, attributionID db.AttributionID) (*billing.Customer, error) {
+	customer, err := p.client.GetCustomerByAttributionID(ctx, string(attributionID))
+	if status.Code(err) == codes.NotFound {
+		return nil, fmt.Errorf("customer for %s: %w", attributionID, billing.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertCustomer(customer, attributionID), nil
+}
+
+func (p *BillingProvider) GetCustomerByID(ctx context.Context, customerID string) (*billing.Customer, error) {
+	customer, err := p.client.GetCustomer(ctx, customerID)
+	if status.Code(err) == codes.NotFound {
+		return nil, fmt.Errorf("customer %s: %w", customerID, billing.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	attributionID, err := GetAttributionID(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+	return convertCustomer(customer, attributionID), nil
+}
+
+func (p *BillingProvider) CreateCustomer(ctx context.Context, params billing.CreateCustomerParams) (*billing.Customer, error) {
+	customer, err := p.client.CreateCustomer(ctx, CreateCustomerParams{
+		AttributuonID: string(params.AttributionID),
+		Currency:      params.Currency,
+		Email:         params.Email,
+		Name:          params.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertCustomer(customer, params.AttributionID), nil
+}
+
+func (p *BillingProvider) CreateSubscription(ctx context.Context, customer *billing.Customer, params billing.CreateSubscriptionParams) (*billing.Subscription, error) {
+	if params.PaymentSetupID != "" {
+		_, err := p.client.SetDefaultPaymentForCustomer(ctx, customer.ID, params.PaymentSetupID)
+		if err != nil {
+			log.WithError(err).WithField("stripe_customer_id", customer.ID).Error("Failed to set default payment for customer.")
+			return nil, fmt.Errorf("failed to set default payment for customer %s: %w", customer.ID, billing.ErrPaymentSetupFailed)
+		}
+	}
+
+	stripeCustomer, err := p.client.GetCustomer(ctx, customer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var isAutomaticTaxSupported bool
+	if stripeCustomer.Tax != nil {
+		isAutomaticTaxSupported = stripeCustomer.Tax.AutomaticTax == "supported"
+	}
+
+	if !isAutomaticTaxSupported {
+		log.Warnf("Automatic Stripe tax is not supported for customer %s", customer.ID)
+	}
+
+	subscription, err := p.client.CreateSubscription(ctx, customer.ID, params.PriceID, isAutomaticTaxSupported)
+	if err != nil {
+		return nil, err
+	}
+	return &billing.Subscription{
+		ID:            subscription.ID,
+		CustomerID:    customer.ID,
+		AttributionID: customer.AttributionID,
+		PriceID:       params.PriceID,
+		Active:        subscription.Status != stripe.SubscriptionStatusCanceled,
+	}, nil
+}
+
+func (p *BillingProvider) GetSubscription(ctx context.Context, subscriptionID string) (*billing.Subscription, error) {
+	subscription, err := p.client.GetSubscriptionWithCustomer(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	attributionID, err := GetAttributionID(ctx, subscription.Customer)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &billing.Subscription{
+		ID:            subscription.ID,
+		CustomerID:    subscription.Customer.ID,
+		AttributionID: attributionID,
+		Active:        subscription.Status != stripe.SubscriptionStatusCanceled,
+	}
+	if subscription.Plan != nil {
+		res.PriceID = subscription.Plan.ID
+	}
+	return res, nil
+}
+
+func (p *BillingProvider) GetActiveSubscription(ctx context.Context, customerID string) (*billing.Subscription, error) {
+	customer, err := p.client.GetCustomer(ctx, customerID)
+	if status.Code(err) == codes.NotFound {
+		return nil, fmt.Errorf("customer %s: %w", customerID, billing.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	attributionID, err := GetAttributionID(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+	if customer.Subscriptions != nil {
+		for _, subscription := range customer.Subscriptions.Data {
+			if subscription.Status == stripe.SubscriptionStatusCanceled {
+				continue
+			}
+			res := &billing.Subscription{
+				ID:            subscription.ID,
+				CustomerID:    customer.ID,
+				AttributionID: attributionID,
+				Active:        true,
+			}
+			if subscription.Plan != nil {
+				res.PriceID = subscription.Plan.ID
+			}
+			return res, nil
+		}
+	}
+	return nil, fmt.Errorf("active subscription of customer %s: %w", customerID, billing.ErrNotFound)
+}
+
+func (p *BillingProvider) GetPriceDescription(ctx context.Context, priceID string) (string, error) {
+	price, err := p.client.GetPriceInformation(ctx, priceID)
+	if err != nil {
+		return "", err
+	}
+	return price.Metadata["human_readable_description"], nil
+}
+
+func (p *BillingProvider) ReportUsage(ctx context.Context, reports []billing.UsageReport) error {
+	creditsByAttributionID := make(map[db.AttributionID]int64, len(reports))
+	for _, report := range reports {
+		creditsByAttributionID[report.AttributionID] = report.Credits
+	}
+	return p.client.UpdateUsage(ctx, creditsByAttributionID)
+}
+
+// ListDueInvoices returns no invoices, as Stripe finalises invoices itself and notifies about them through webhooks
+func (p *BillingProvider) ListDueInvoices(ctx context.Context, now time.Time) ([]string, error) {
+	return nil, nil
+}
+
+func (p *BillingProvider) FinalizeInvoice(ctx context.Context, invoiceID string) (*billing.Invoice, error) {
+	invoice, err := p.client.GetInvoiceWithCustomer(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("invoice %s: %v: %w", invoiceID, err, billing.ErrNotFound)
+	}
+	return ConvertInvoice(ctx, invoice)
+}
+
+// ConvertInvoice converts a Stripe invoice, which must have its customer expanded
+func ConvertInvoice(ctx context.Context, invoice *stripe.Invoice) (*billing.Invoice, error) {
+	attributionID, err := GetAttributionID(ctx, invoice.Customer)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Lines == nil || len(invoice.Lines.Data) == 0 {
+		return nil, fmt.Errorf("invoice %s did not contain any lines", invoice.ID)
+	}
+
+	var credits int64
+	for _, line := range invoice.Lines.Data {
+		credits += line.Quantity
+	}
+
+	return &billing.Invoice{
+		ID:            invoice.ID,
+		CustomerID:    invoice.Customer.ID,
+		AttributionID: attributionID,
+		Status:        billing.InvoiceStatus_Finalized,
+		Credits:       credits,
+		PeriodStart:   time.Unix(invoice.PeriodStart, 0),
+		PeriodEnd:     time.Unix(invoice.PeriodEnd, 0),
+		FinalizedAt:   time.Unix(invoice.StatusTransitions.FinalizedAt, 0),
+	}, nil
+}
+
+func convertCustomer(customer *stripe.Customer, attributionID db.AttributionID) *billing.Customer {
+	return &billing.Customer{
+		ID:            customer.ID,
+		AttributionID: attributionID,
+		Name:          customer.Name,
+		Email:         customer.Email,
+		Currency:      customer.Metadata[PreferredCurrencyMetadataKey],
+		CreationTime:  time.Unix(customer.Created, 0),
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/hosts"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/iws"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/netlimit"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/nodehints"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -31,6 +32,7 @@ type Config struct {
 	OOMScores           cgroup.OOMScoreAdjConfig  `json:"oomScores"`
 	Hosts               hosts.Config              `json:"hosts"`
 	DiskSpaceGuard      diskguard.Config          `json:"disk"`
+	SchedulingHints     nodehints.Config          `json:"schedulingHints"`
 	WorkspaceController WorkspaceControllerConfig `json:"workspaceController"`
 }
 
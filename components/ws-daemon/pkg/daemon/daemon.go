@@ -35,6 +35,7 @@ import (
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/hosts"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/iws"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/netlimit"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/nodehints"
 )
 
 var (
@@ -136,6 +137,11 @@ func NewDaemon(config Config, reg prometheus.Registerer) (*Daemon, error) {
 		cgroupPlugins,
 	}
 
+	hints := nodehints.NewPublisher(config.SchedulingHints, clientset, nodename)
+	if hints != nil {
+		listener = append(listener, hints)
+	}
+
 	netlimiter := netlimit.NewConnLimiter(config.NetLimit, reg)
 	if config.NetLimit.Enabled {
 		listener = append(listener, netlimiter)
@@ -216,6 +222,7 @@ func NewDaemon(config Config, reg prometheus.Registerer) (*Daemon, error) {
 		dispatch:       dsptch,
 		content:        contentService,
 		diskGuards:     dsk,
+		hints:          hints,
 		hosts:          hsts,
 		configReloader: configReloader,
 		mgr:            mgr,
@@ -237,6 +244,7 @@ type Daemon struct {
 	dispatch       *dispatch.Dispatch
 	content        *content.WorkspaceService
 	diskGuards     []*diskguard.Guard
+	hints          *nodehints.Publisher
 	hosts          hosts.Controller
 	configReloader ConfigReloader
 	mgr            ctrl.Manager
@@ -265,6 +273,10 @@ func (d *Daemon) Start() error {
 	var ctx context.Context
 	ctx, d.cancel = context.WithCancel(context.Background())
 
+	if d.hints != nil {
+		go d.hints.Start(ctx)
+	}
+
 	if d.Config.WorkspaceController.Enabled {
 		go func() {
 			err := d.mgr.Start(ctx)
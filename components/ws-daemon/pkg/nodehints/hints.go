@@ -0,0 +1,230 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package nodehints
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/xerrors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/util"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/dispatch"
+)
+
+// DefaultInterval is used if no publish interval is configured
+const DefaultInterval = time.Minute
+
+// Config configures the scheduling hints a node publishes
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// Interval is how often the hints are published, defaults to one minute
+	Interval util.Duration `json:"interval"`
+	// Location is the path whose available disk space is published, e.g. the working area
+	Location string `json:"location"`
+	// MaxImages is the maximum number of workspace images a node advertises
+	MaxImages int `json:"maxImages"`
+	// ImageTTL is how long an image is advertised after the last workspace using it started on this node
+	ImageTTL util.Duration `json:"imageTTL"`
+}
+
+// Publisher labels the node with hints which ws-manager turns into preferred node affinities.
+// It publishes the workspace images which recently ran on the node (and are therefore likely
+// cached) and the available disk space.
+type Publisher struct {
+	Config    Config
+	Clientset kubernetes.Interface
+	Nodename  string
+
+	mu     sync.Mutex
+	images map[string]time.Time
+
+	// published are the labels which were last published, only accessed from Start
+	published map[string]string
+}
+
+// NewPublisher produces a new hint publisher or nil if hints are disabled
+func NewPublisher(cfg Config, clientset kubernetes.Interface, nodeName string) *Publisher {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = util.Duration(DefaultInterval)
+	}
+
+	return &Publisher{
+		Config:    cfg,
+		Clientset: clientset,
+		Nodename:  nodeName,
+		images:    make(map[string]time.Time),
+	}
+}
+
+// WorkspaceAdded records the image of a workspace whose container is running on this node
+func (p *Publisher) WorkspaceAdded(ctx context.Context, ws *dispatch.Workspace) error {
+	hash, ok := ws.Pod.Labels[wsk8s.WorkspaceImageHashLabel]
+	if !ok {
+		return nil
+	}
+
+	p.mu.Lock()
+	p.images[hash] = time.Now()
+	p.mu.Unlock()
+	return nil
+}
+
+// Start regularly publishes the hints until the context is canceled
+func (p *Publisher) Start(ctx context.Context) {
+	t := time.NewTicker(time.Duration(p.Config.Interval))
+	defer t.Stop()
+	for {
+		var freeDiskGiB *uint64
+		bvail, err := getAvailableBytes(p.Config.Location)
+		if err != nil {
+			log.WithError(err).WithField("path", p.Config.Location).Warn("cannot check how much space is available")
+		} else {
+			gib := roundFreeDisk(bvail >> 30)
+			freeDiskGiB = &gib
+		}
+
+		err = p.publish(ctx, p.currentImages(time.Now()), freeDiskGiB)
+		if err != nil {
+			log.WithError(err).Error("cannot publish scheduling hints")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// freeDiskStepGiB is the granularity of the published free disk space. The available space changes
+// constantly, publishing it exactly would update the node on every interval.
+const freeDiskStepGiB = 10
+
+// roundFreeDisk rounds the free disk space down, so that the node never seems to have more space than it has
+func roundFreeDisk(gib uint64) uint64 {
+	return gib - gib%freeDiskStepGiB
+}
+
+// currentImages expires old images and returns the most recently used ones, up to MaxImages
+func (p *Publisher) currentImages(now time.Time) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ttl := time.Duration(p.Config.ImageTTL)
+	res := make([]string, 0, len(p.images))
+	for hash, lastUsed := range p.images {
+		if ttl > 0 && now.Sub(lastUsed) > ttl {
+			delete(p.images, hash)
+			continue
+		}
+		res = append(res, hash)
+	}
+
+	sort.Slice(res, func(i, j int) bool { return p.images[res[i]].After(p.images[res[j]]) })
+	if p.Config.MaxImages > 0 && len(res) > p.Config.MaxImages {
+		for _, hash := range res[p.Config.MaxImages:] {
+			delete(p.images, hash)
+		}
+		res = res[:p.Config.MaxImages]
+	}
+	return res
+}
+
+// publish patches the node labels which differ from the hints. The node is neither read nor
+// patched if the hints have not changed since they were last published.
+func (p *Publisher) publish(ctx context.Context, images []string, freeDiskGiB *uint64) error {
+	desired := desiredLabels(images, freeDiskGiB)
+	if p.published != nil && reflect.DeepEqual(p.published, desired) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	node, err := p.Clientset.CoreV1().Nodes().Get(ctx, p.Nodename, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	changes := labelChanges(node.Labels, desired)
+	if len(changes) > 0 {
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": changes,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		log.WithField("node", p.Nodename).WithField("images", len(images)).Debug("updating scheduling hints")
+		_, err = p.Clientset.CoreV1().Nodes().Patch(ctx, p.Nodename, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	p.published = desired
+	return nil
+}
+
+// desiredLabels returns the node labels which reflect the hints
+func desiredLabels(images []string, freeDiskGiB *uint64) map[string]string {
+	res := make(map[string]string, len(images)+1)
+	for _, img := range images {
+		res[wsk8s.NodeImageHintLabelPrefix+img] = "true"
+	}
+	if freeDiskGiB != nil {
+		res[wsk8s.NodeFreeDiskHintLabel] = strconv.FormatUint(*freeDiskGiB, 10)
+	}
+	return res
+}
+
+// labelChanges returns the label changes which turn the hints in labels into the desired ones, as a merge patch:
+// labels which are to be removed map to nil.
+func labelChanges(labels map[string]string, desired map[string]string) map[string]*string {
+	changes := make(map[string]*string)
+	for k := range labels {
+		if !strings.HasPrefix(k, wsk8s.NodeImageHintLabelPrefix) {
+			continue
+		}
+		if _, ok := desired[k]; !ok {
+			changes[k] = nil
+		}
+	}
+	for k, v := range desired {
+		if cur, ok := labels[k]; !ok || cur != v {
+			v := v
+			changes[k] = &v
+		}
+	}
+	return changes
+}
+
+func getAvailableBytes(path string) (bvail uint64, err error) {
+	var stat syscall.Statfs_t
+	err = syscall.Statfs(path, &stat)
+	if err != nil {
+		return 0, xerrors.Errorf("cannot stat %s: %w", path, err)
+	}
+
+	bvail = stat.Bavail * uint64(stat.Bsize)
+	return
+}
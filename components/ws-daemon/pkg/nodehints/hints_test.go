@@ -0,0 +1,176 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package nodehints
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+
+	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
+	"github.com/gitpod-io/gitpod/common-go/util"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/dispatch"
+)
+
+func TestLabelChanges(t *testing.T) {
+	freeDisk := uint64(120)
+	tests := []struct {
+		Name        string
+		Labels      map[string]string
+		Images      []string
+		FreeDiskGiB *uint64
+		Expectation map[string]*string
+	}{
+		{
+			Name:   "add hints",
+			Labels: map[string]string{"foo": "bar"},
+			Images: []string{"abc"},
+			Expectation: map[string]*string{
+				wsk8s.NodeImageHintLabelPrefix + "abc": pointer.String("true"),
+			},
+		},
+		{
+			Name: "remove stale image",
+			Labels: map[string]string{
+				wsk8s.NodeImageHintLabelPrefix + "abc": "true",
+				wsk8s.NodeImageHintLabelPrefix + "def": "true",
+			},
+			Images: []string{"def"},
+			Expectation: map[string]*string{
+				wsk8s.NodeImageHintLabelPrefix + "abc": nil,
+			},
+		},
+		{
+			Name: "unchanged",
+			Labels: map[string]string{
+				wsk8s.NodeImageHintLabelPrefix + "abc": "true",
+				wsk8s.NodeFreeDiskHintLabel:            "120",
+			},
+			Images:      []string{"abc"},
+			FreeDiskGiB: &freeDisk,
+			Expectation: map[string]*string{},
+		},
+		{
+			Name:        "free disk",
+			Labels:      map[string]string{wsk8s.NodeFreeDiskHintLabel: "10"},
+			FreeDiskGiB: &freeDisk,
+			Expectation: map[string]*string{
+				wsk8s.NodeFreeDiskHintLabel: pointer.String("120"),
+			},
+		},
+		{
+			Name:        "unknown free disk",
+			Labels:      map[string]string{wsk8s.NodeFreeDiskHintLabel: "10"},
+			Expectation: map[string]*string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := labelChanges(test.Labels, desiredLabels(test.Images, test.FreeDiskGiB))
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected label changes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPublishPatchesOnlyOnChange(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node", Labels: map[string]string{"foo": "bar"}},
+	})
+	p := NewPublisher(Config{Enabled: true}, clientset, "node")
+	ctx := context.Background()
+	freeDisk := uint64(120)
+
+	patches := func() (res int) {
+		for _, action := range clientset.Actions() {
+			if action.GetVerb() == "patch" {
+				res++
+			}
+		}
+		return
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := p.publish(ctx, []string{"abc"}, &freeDisk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := patches(); n != 1 {
+		t.Errorf("node was patched %d times, expected once", n)
+	}
+	if n := len(clientset.Actions()); n != 2 {
+		t.Errorf("%d API calls for unchanged hints, expected a get and a patch", n)
+	}
+
+	if err := p.publish(ctx, nil, &freeDisk); err != nil {
+		t.Fatal(err)
+	}
+	if n := patches(); n != 2 {
+		t.Errorf("node was patched %d times, expected twice", n)
+	}
+
+	node, err := clientset.CoreV1().Nodes().Get(ctx, "node", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]string{"foo": "bar", wsk8s.NodeFreeDiskHintLabel: "120"}
+	if diff := cmp.Diff(exp, node.Labels); diff != "" {
+		t.Errorf("unexpected labels (-want +got):\n%s", diff)
+	}
+}
+
+func TestRoundFreeDisk(t *testing.T) {
+	for in, exp := range map[uint64]uint64{0: 0, 9: 0, 10: 10, 127: 120} {
+		if act := roundFreeDisk(in); act != exp {
+			t.Errorf("roundFreeDisk(%d) = %d, expected %d", in, act, exp)
+		}
+	}
+}
+
+func TestCurrentImages(t *testing.T) {
+	p := NewPublisher(Config{
+		Enabled:   true,
+		MaxImages: 2,
+		ImageTTL:  util.Duration(time.Hour),
+	}, nil, "node")
+
+	for _, hash := range []string{"a", "b", "c"} {
+		err := p.WorkspaceAdded(context.Background(), &dispatch.Workspace{
+			Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{wsk8s.WorkspaceImageHashLabel: hash},
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	p.images["a"] = now.Add(-2 * time.Hour)
+	p.images["b"] = now.Add(-2 * time.Minute)
+	p.images["c"] = now.Add(-1 * time.Minute)
+
+	act := p.currentImages(now)
+	if diff := cmp.Diff([]string{"c", "b"}, act); diff != "" {
+		t.Errorf("unexpected images (-want +got):\n%s", diff)
+	}
+	if _, ok := p.images["a"]; ok {
+		t.Errorf("expired image was not removed")
+	}
+}
+
+func TestNewPublisherDefaultsInterval(t *testing.T) {
+	p := NewPublisher(Config{Enabled: true}, nil, "node")
+	if time.Duration(p.Config.Interval) != DefaultInterval {
+		t.Errorf("unexpected interval %s, expected %s", p.Config.Interval, DefaultInterval)
+	}
+}
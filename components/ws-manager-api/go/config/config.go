@@ -71,6 +71,13 @@ type ServiceConfiguration struct {
 	Health struct {
 		Addr string `json:"addr"`
 	} `json:"health"`
+	Webhook struct {
+		// Enabled registers the workspace admission webhooks
+		Enabled bool `json:"enabled"`
+		// PolicyPath optionally points to a workspace policy document, e.g. mounted from a ConfigMap.
+		// The file is reloaded when it changes.
+		PolicyPath string `json:"policyPath,omitempty"`
+	} `json:"webhook"`
 }
 
 // Configuration is the configuration of the ws-manager
@@ -133,6 +140,98 @@ type WorkspaceClass struct {
 	Templates   WorkspacePodTemplateConfiguration `json:"templates"`
 	PrebuildPVC PVCConfiguration                  `json:"prebuildPVC"`
 	PVC         PVCConfiguration                  `json:"pvc"`
+	Scheduling  SchedulingConfiguration           `json:"scheduling,omitempty"`
+	Sidecars    SidecarConfiguration              `json:"sidecars,omitempty"`
+}
+
+// SchedulingConfiguration turns the scheduling hints published by ws-daemon into preferred affinities.
+// All weights range from 0 to 100 where 0 disables the preference.
+type SchedulingConfiguration struct {
+	// CachedImageWeight prefers nodes which recently ran the same workspace image, e.g. of the same prebuild.
+	CachedImageWeight int32 `json:"cachedImageWeight,omitempty"`
+	// FreeDiskWeight prefers nodes with more than MinFreeDiskGiB of available disk space.
+	FreeDiskWeight int32 `json:"freeDiskWeight,omitempty"`
+	MinFreeDiskGiB int64 `json:"minFreeDiskGiB,omitempty"`
+	// TeamSpreadWeight prefers nodes which don't run other workspaces of the same team.
+	TeamSpreadWeight int32 `json:"teamSpreadWeight,omitempty"`
+}
+
+// Validate validates a scheduling configuration
+func (c *SchedulingConfiguration) Validate() error {
+	return ozzo.ValidateStruct(c,
+		ozzo.Field(&c.CachedImageWeight, ozzo.Min(int32(0)), ozzo.Max(int32(100))),
+		ozzo.Field(&c.FreeDiskWeight, ozzo.Min(int32(0)), ozzo.Max(int32(100))),
+		ozzo.Field(&c.MinFreeDiskGiB, ozzo.Min(int64(0))),
+		ozzo.Field(&c.TeamSpreadWeight, ozzo.Min(int32(0)), ozzo.Max(int32(100))),
+	)
+}
+
+// SidecarConfiguration limits the additional containers workspaces of a class may run next to the workspace container.
+type SidecarConfiguration struct {
+	// MaxCount is the maximum number of sidecars per workspace. Zero disables sidecars.
+	MaxCount int `json:"maxCount,omitempty"`
+	// Default are the resources of sidecars which don't specify their own.
+	Default ContainerConfiguration `json:"default,omitempty"`
+	// Budget caps the sum of the limits of all sidecars of a workspace.
+	Budget *ResourceRequestConfiguration `json:"budget,omitempty"`
+}
+
+// Validate validates a sidecar configuration
+func (c *SidecarConfiguration) Validate() error {
+	return ozzo.ValidateStruct(c,
+		ozzo.Field(&c.MaxCount, ozzo.Min(0)),
+		ozzo.Field(&c.Default),
+		ozzo.Field(&c.Budget, validResourceRequestConfig),
+	)
+}
+
+// SidecarResources returns the resources a sidecar runs with. Sidecars which don't specify
+// requests or limits get the default ones.
+func (c *SidecarConfiguration) SidecarResources(requests, limits corev1.ResourceList) (res corev1.ResourceRequirements, err error) {
+	res.Requests, res.Limits = requests, limits
+	if len(res.Requests) == 0 {
+		res.Requests, err = c.Default.Requests.ResourceList()
+		if err != nil {
+			return res, xerrors.Errorf("cannot parse default sidecar requests: %w", err)
+		}
+	}
+	if len(res.Limits) == 0 {
+		res.Limits, err = c.Default.Limits.ResourceList()
+		if err != nil {
+			return res, xerrors.Errorf("cannot parse default sidecar limits: %w", err)
+		}
+	}
+	return res, nil
+}
+
+// CheckBudget ensures the sidecars of a workspace stay within the configured count and budget.
+// Every resource the budget caps must be limited by all sidecars.
+func (c *SidecarConfiguration) CheckBudget(sidecars []corev1.ResourceRequirements) error {
+	if len(sidecars) == 0 {
+		return nil
+	}
+	if len(sidecars) > c.MaxCount {
+		return xerrors.Errorf("workspace has %d sidecars but at most %d are allowed", len(sidecars), c.MaxCount)
+	}
+
+	budget, err := c.Budget.ResourceList()
+	if err != nil {
+		return xerrors.Errorf("cannot parse sidecar budget: %w", err)
+	}
+	for name, max := range budget {
+		var total resource.Quantity
+		for _, s := range sidecars {
+			q, ok := s.Limits[name]
+			if !ok {
+				return xerrors.Errorf("all sidecars must have a %s limit", name)
+			}
+			total.Add(q)
+		}
+		if total.Cmp(max) > 0 {
+			return xerrors.Errorf("sidecars exceed the %s budget: %s > %s", name, total.String(), max.String())
+		}
+	}
+	return nil
 }
 
 // WorkspaceTimeoutConfiguration configures the timeout behaviour of workspaces
@@ -239,6 +338,12 @@ func (c *Configuration) Validate() error {
 		if err := class.Container.Validate(); err != nil {
 			return xerrors.Errorf("workspace class %s: %w", name, err)
 		}
+		if err := class.Scheduling.Validate(); err != nil {
+			return xerrors.Errorf("workspace class %s: scheduling: %w", name, err)
+		}
+		if err := class.Sidecars.Validate(); err != nil {
+			return xerrors.Errorf("workspace class %s: sidecars: %w", name, err)
+		}
 
 		err = ozzo.ValidateStruct(&class.Templates,
 			ozzo.Field(&class.Templates.DefaultPath, validPodTemplate),
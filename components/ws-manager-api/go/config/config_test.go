@@ -8,6 +8,9 @@ import (
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
 	"github.com/gitpod-io/gitpod/common-go/util"
 )
 
@@ -65,6 +68,20 @@ func TestValidate(t *testing.T) {
 			}),
 			Expectation: `workspace class name "not/a/valid/name" is invalid: [a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')]`,
 		},
+		{
+			Name: "invalid scheduling weight",
+			Cfg: fromValidConfig(func(c *Configuration) {
+				c.WorkspaceClasses[DefaultWorkspaceClass].Scheduling.CachedImageWeight = 101
+			}),
+			Expectation: `workspace class default: scheduling: cachedImageWeight: must be no greater than 100.`,
+		},
+		{
+			Name: "invalid sidecar budget",
+			Cfg: fromValidConfig(func(c *Configuration) {
+				c.WorkspaceClasses[DefaultWorkspaceClass].Sidecars.Budget = &ResourceRequestConfiguration{Memory: "lots"}
+			}),
+			Expectation: `workspace class default: sidecars: budget: cannot parse Memory quantity: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'.`,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
@@ -81,3 +98,60 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestSidecarCheckBudget(t *testing.T) {
+	cfg := SidecarConfiguration{
+		MaxCount: 2,
+		Budget:   &ResourceRequestConfiguration{Memory: "1Gi"},
+	}
+	sidecar := func(memory string) corev1.ResourceRequirements {
+		res := corev1.ResourceRequirements{Limits: corev1.ResourceList{}}
+		if memory != "" {
+			res.Limits[corev1.ResourceMemory] = resource.MustParse(memory)
+		}
+		return res
+	}
+
+	tests := []struct {
+		Name        string
+		Sidecars    []corev1.ResourceRequirements
+		Expectation string
+	}{
+		{
+			Name: "no sidecars",
+		},
+		{
+			Name:     "within budget",
+			Sidecars: []corev1.ResourceRequirements{sidecar("512Mi"), sidecar("512Mi")},
+		},
+		{
+			Name:        "too many",
+			Sidecars:    []corev1.ResourceRequirements{sidecar("1Mi"), sidecar("1Mi"), sidecar("1Mi")},
+			Expectation: "workspace has 3 sidecars but at most 2 are allowed",
+		},
+		{
+			Name:        "over budget",
+			Sidecars:    []corev1.ResourceRequirements{sidecar("512Mi"), sidecar("1Gi")},
+			Expectation: "sidecars exceed the memory budget: 1536Mi > 1Gi",
+		},
+		{
+			Name:        "unlimited",
+			Sidecars:    []corev1.ResourceRequirements{sidecar("")},
+			Expectation: "all sidecars must have a memory limit",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := cfg.CheckBudget(test.Sidecars)
+
+			var errMsg string
+			if err != nil {
+				errMsg = err.Error()
+			}
+
+			if errMsg != test.Expectation {
+				t.Errorf("unexpected budget check result: expect \"%s\", got \"%s\"", test.Expectation, errMsg)
+			}
+		})
+	}
+}
@@ -54,6 +54,8 @@ type WorkspaceManagerClient interface {
 	UpdateSSHKey(ctx context.Context, in *UpdateSSHKeyRequest, opts ...grpc.CallOption) (*UpdateSSHKeyResponse, error)
 	// describeCluster provides information about the cluster
 	DescribeCluster(ctx context.Context, in *DescribeClusterRequest, opts ...grpc.CallOption) (*DescribeClusterResponse, error)
+	// getSidecarLogs produces the log of a sidecar container of a workspace
+	GetSidecarLogs(ctx context.Context, in *GetSidecarLogsRequest, opts ...grpc.CallOption) (*GetSidecarLogsResponse, error)
 }
 
 type workspaceManagerClient struct {
@@ -213,6 +215,15 @@ func (c *workspaceManagerClient) DescribeCluster(ctx context.Context, in *Descri
 	return out, nil
 }
 
+func (c *workspaceManagerClient) GetSidecarLogs(ctx context.Context, in *GetSidecarLogsRequest, opts ...grpc.CallOption) (*GetSidecarLogsResponse, error) {
+	out := new(GetSidecarLogsResponse)
+	err := c.cc.Invoke(ctx, "/wsman.WorkspaceManager/GetSidecarLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkspaceManagerServer is the server API for WorkspaceManager service.
 // All implementations must embed UnimplementedWorkspaceManagerServer
 // for forward compatibility
@@ -245,6 +256,8 @@ type WorkspaceManagerServer interface {
 	UpdateSSHKey(context.Context, *UpdateSSHKeyRequest) (*UpdateSSHKeyResponse, error)
 	// describeCluster provides information about the cluster
 	DescribeCluster(context.Context, *DescribeClusterRequest) (*DescribeClusterResponse, error)
+	// getSidecarLogs produces the log of a sidecar container of a workspace
+	GetSidecarLogs(context.Context, *GetSidecarLogsRequest) (*GetSidecarLogsResponse, error)
 	mustEmbedUnimplementedWorkspaceManagerServer()
 }
 
@@ -294,6 +307,9 @@ func (UnimplementedWorkspaceManagerServer) UpdateSSHKey(context.Context, *Update
 func (UnimplementedWorkspaceManagerServer) DescribeCluster(context.Context, *DescribeClusterRequest) (*DescribeClusterResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DescribeCluster not implemented")
 }
+func (UnimplementedWorkspaceManagerServer) GetSidecarLogs(context.Context, *GetSidecarLogsRequest) (*GetSidecarLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSidecarLogs not implemented")
+}
 func (UnimplementedWorkspaceManagerServer) mustEmbedUnimplementedWorkspaceManagerServer() {}
 
 // UnsafeWorkspaceManagerServer may be embedded to opt out of forward compatibility for this service.
@@ -562,6 +578,24 @@ func _WorkspaceManager_DescribeCluster_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkspaceManager_GetSidecarLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSidecarLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceManagerServer).GetSidecarLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wsman.WorkspaceManager/GetSidecarLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceManagerServer).GetSidecarLogs(ctx, req.(*GetSidecarLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkspaceManager_ServiceDesc is the grpc.ServiceDesc for WorkspaceManager service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -621,6 +655,10 @@ var WorkspaceManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DescribeCluster",
 			Handler:    _WorkspaceManager_DescribeCluster_Handler,
 		},
+		{
+			MethodName: "GetSidecarLogs",
+			Handler:    _WorkspaceManager_GetSidecarLogs_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
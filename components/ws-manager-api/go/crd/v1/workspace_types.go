@@ -38,6 +38,11 @@ type WorkspaceSpec struct {
 
 	// +kubebuilder:validation:MinItems=0
 	Ports []PortSpec `json:"ports"`
+
+	// Sidecars are additional containers which run next to the workspace container
+	// in the pod's network namespace.
+	// +kubebuilder:validation:Optional
+	Sidecars []SidecarSpec `json:"sidecars,omitempty"`
 }
 
 type Ownership struct {
@@ -89,11 +94,13 @@ type AdmissionSpec struct {
 	Level AdmissionLevel `json:"level"`
 }
 
-// +kubebuilder:validation:Enum=Owner;Everyone
+// +kubebuilder:validation:Enum=Owner;Shared;Everyone
 type AdmissionLevel string
 
 const (
-	AdmissionLevelOwner    AdmissionLevel = "Owner"
+	AdmissionLevelOwner AdmissionLevel = "Owner"
+	// AdmissionLevelShared admits the owner and everyone with a valid share token. It only applies to ports.
+	AdmissionLevelShared   AdmissionLevel = "Shared"
 	AdmissionLevelEveryone AdmissionLevel = "Everyone"
 )
 
@@ -106,6 +113,32 @@ type PortSpec struct {
 	Visibility AdmissionLevel `json:"visibility"`
 }
 
+// SidecarContainerPrefix is prepended to the container names of sidecars so that they cannot clash with the workspace container
+const SidecarContainerPrefix = "sidecar-"
+
+type SidecarSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+
+	Command []string `json:"command,omitempty"`
+
+	Args []string `json:"args,omitempty"`
+
+	Envvars []corev1.EnvVar `json:"envvars,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Resources SidecarResources `json:"resources,omitempty"`
+}
+
+type SidecarResources struct {
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	Limits   corev1.ResourceList `json:"limits,omitempty"`
+}
+
 // WorkspaceStatus defines the observed state of Workspace
 type WorkspaceStatus struct {
 	PodStarts  int    `json:"podStarts"`
@@ -128,8 +161,29 @@ type WorkspaceStatus struct {
 
 	// +kubebuilder:validation:Optional
 	Runtime *WorkspaceRuntimeStatus `json:"runtime,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Sidecars []SidecarStatus `json:"sidecars,omitempty"`
 }
 
+type SidecarStatus struct {
+	Name  string       `json:"name"`
+	State SidecarState `json:"state"`
+	Ready bool         `json:"ready"`
+	// Message is the reason the sidecar is waiting or the tail of its log if it terminated.
+	Message  string `json:"message,omitempty"`
+	ExitCode int32  `json:"exitCode,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Waiting;Running;Terminated
+type SidecarState string
+
+const (
+	SidecarStateWaiting    SidecarState = "Waiting"
+	SidecarStateRunning    SidecarState = "Running"
+	SidecarStateTerminated SidecarState = "Terminated"
+)
+
 // +kubebuilder:validation:Enum=Deployed;Failed;Timeout;UserActivity;HeadlessTaskFailed;StoppedByRequest;EverReady;ContentReady;BackupComplete;BackupFailure
 type WorkspaceCondition string
 
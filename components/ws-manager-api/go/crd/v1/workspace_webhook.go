@@ -5,61 +5,111 @@
 package v1
 
 import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // log is for logging in this package.
 var workspacelog = logf.Log.WithName("workspace-resource")
 
-func (r *Workspace) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// AdmissionPolicy decides whether a workspace may be created or updated.
+// Implementations are expected to be safe for concurrent use.
+// +kubebuilder:object:generate=false
+type AdmissionPolicy interface {
+	// Default mutates the workspace spec, e.g. to add required environment variables.
+	Default(ws *Workspace)
+	// Validate returns an error describing why the workspace is not admitted.
+	Validate(ws *Workspace) error
+}
+
+// SetupWebhookWithManager registers the mutating and validating webhooks for workspaces.
+// If policy is nil, only the basic validation is applied.
+func (r *Workspace) SetupWebhookWithManager(mgr ctrl.Manager, policy AdmissionPolicy) error {
+	wh := &workspaceWebhook{Policy: policy}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(wh).
+		WithValidator(wh).
 		Complete()
 }
 
-// TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
-
 //+kubebuilder:webhook:path=/mutate-workspace-gitpod-io-v1-workspace,mutating=true,failurePolicy=fail,sideEffects=None,groups=workspace.gitpod.io,resources=workspaces,verbs=create;update,versions=v1,name=mworkspace.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-workspace-gitpod-io-v1-workspace,mutating=false,failurePolicy=fail,sideEffects=None,groups=workspace.gitpod.io,resources=workspaces,verbs=create;update,versions=v1,name=vworkspace.kb.io,admissionReviewVersions=v1
 
-var _ webhook.Defaulter = &Workspace{}
-
-// Default implements webhook.Defaulter so a webhook will be registered for the type
-func (r *Workspace) Default() {
-	workspacelog.Info("default", "name", r.Name)
-
-	// TODO(user): fill in your defaulting logic.
+type workspaceWebhook struct {
+	Policy AdmissionPolicy
 }
 
-// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
-//+kubebuilder:webhook:path=/validate-workspace-gitpod-io-v1-workspace,mutating=false,failurePolicy=fail,sideEffects=None,groups=workspace.gitpod.io,resources=workspaces,verbs=create;update,versions=v1,name=vworkspace.kb.io,admissionReviewVersions=v1
-
-var _ webhook.Validator = &Workspace{}
+var _ admission.CustomDefaulter = &workspaceWebhook{}
+var _ admission.CustomValidator = &workspaceWebhook{}
 
-// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
-func (r *Workspace) ValidateCreate() error {
-	workspacelog.Info("validate create", "name", r.Name)
+// Default implements admission.CustomDefaulter
+func (wh *workspaceWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	ws, ok := obj.(*Workspace)
+	if !ok {
+		return fmt.Errorf("expected a Workspace but got %T", obj)
+	}
+	workspacelog.Info("default", "name", ws.Name)
 
-	return r.validateWorkspace()
+	if wh.Policy != nil {
+		wh.Policy.Default(ws)
+	}
+	return nil
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *Workspace) ValidateUpdate(old runtime.Object) error {
-	workspacelog.Info("validate update", "name", r.Name)
+// ValidateCreate implements admission.CustomValidator
+func (wh *workspaceWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	ws, ok := obj.(*Workspace)
+	if !ok {
+		return fmt.Errorf("expected a Workspace but got %T", obj)
+	}
+	workspacelog.Info("validate create", "name", ws.Name)
 
-	return r.validateWorkspace()
+	return wh.validateWorkspace(ws)
 }
 
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
-func (r *Workspace) ValidateDelete() error {
-	workspacelog.Info("validate delete", "name", r.Name)
+// ValidateUpdate implements admission.CustomValidator
+func (wh *workspaceWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	ws, ok := newObj.(*Workspace)
+	if !ok {
+		return fmt.Errorf("expected a Workspace but got %T", newObj)
+	}
+	old, ok := oldObj.(*Workspace)
+	if !ok {
+		return fmt.Errorf("expected a Workspace but got %T", oldObj)
+	}
+	workspacelog.Info("validate update", "name", ws.Name)
+
+	// Policies can change while a workspace is running. We must not block metadata or
+	// status updates of existing workspaces, hence only re-validate when the spec changes.
+	if equality.Semantic.DeepEqual(old.Spec, ws.Spec) {
+		return nil
+	}
+
+	return wh.validateWorkspace(ws)
+}
 
-	// TODO(user): fill in your validation logic upon object deletion.
+// ValidateDelete implements admission.CustomValidator
+func (wh *workspaceWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
 	return nil
 }
 
-func (r *Workspace) validateWorkspace() error {
-	return nil
+func (wh *workspaceWebhook) validateWorkspace(ws *Workspace) error {
+	if ws.Spec.Ownership.Owner == "" {
+		return fmt.Errorf("workspace has no owner")
+	}
+	if ws.Spec.Admission.Level == AdmissionLevelShared {
+		return fmt.Errorf("admission level %s only applies to ports", AdmissionLevelShared)
+	}
+	if wh.Policy == nil {
+		return nil
+	}
+
+	return wh.Policy.Validate(ws)
 }
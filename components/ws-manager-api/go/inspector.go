@@ -0,0 +1,22 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// PortInspectorToken derives the token supervisor presents to ws-proxy to read the traffic captured for the
+// ports of a workspace from the workspace's owner token. Unlike the owner token it grants access to nothing else.
+func PortInspectorToken(ownerToken string) string {
+	if ownerToken == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(ownerToken))
+	_, _ = mac.Write([]byte("port-inspector"))
+	return hex.EncodeToString(mac.Sum(nil))
+}
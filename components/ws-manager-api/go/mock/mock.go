@@ -250,6 +250,21 @@ func (mr *MockWorkspaceManagerServerMockRecorder) DescribeWorkspace(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeWorkspace", reflect.TypeOf((*MockWorkspaceManagerServer)(nil).DescribeWorkspace), arg0, arg1)
 }
 
+// GetSidecarLogs mocks base method.
+func (m *MockWorkspaceManagerServer) GetSidecarLogs(arg0 context.Context, arg1 *api.GetSidecarLogsRequest) (*api.GetSidecarLogsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSidecarLogs", arg0, arg1)
+	ret0, _ := ret[0].(*api.GetSidecarLogsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSidecarLogs indicates an expected call of GetSidecarLogs.
+func (mr *MockWorkspaceManagerServerMockRecorder) GetSidecarLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSidecarLogs", reflect.TypeOf((*MockWorkspaceManagerServer)(nil).GetSidecarLogs), arg0, arg1)
+}
+
 // GetWorkspaces mocks base method.
 func (m *MockWorkspaceManagerServer) GetWorkspaces(arg0 context.Context, arg1 *api.GetWorkspacesRequest) (*api.GetWorkspacesResponse, error) {
 	m.ctrl.T.Helper()
@@ -647,6 +662,26 @@ func (mr *MockWorkspaceManagerClientMockRecorder) DescribeWorkspace(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeWorkspace", reflect.TypeOf((*MockWorkspaceManagerClient)(nil).DescribeWorkspace), varargs...)
 }
 
+// GetSidecarLogs mocks base method.
+func (m *MockWorkspaceManagerClient) GetSidecarLogs(arg0 context.Context, arg1 *api.GetSidecarLogsRequest, arg2 ...grpc.CallOption) (*api.GetSidecarLogsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSidecarLogs", varargs...)
+	ret0, _ := ret[0].(*api.GetSidecarLogsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSidecarLogs indicates an expected call of GetSidecarLogs.
+func (mr *MockWorkspaceManagerClientMockRecorder) GetSidecarLogs(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSidecarLogs", reflect.TypeOf((*MockWorkspaceManagerClient)(nil).GetSidecarLogs), varargs...)
+}
+
 // GetWorkspaces mocks base method.
 func (m *MockWorkspaceManagerClient) GetWorkspaces(arg0 context.Context, arg1 *api.GetWorkspacesRequest, arg2 ...grpc.CallOption) (*api.GetWorkspacesResponse, error) {
 	m.ctrl.T.Helper()
@@ -30,6 +30,7 @@ interface IWorkspaceManagerService extends grpc.ServiceDefinition<grpc.UntypedSe
     deleteVolumeSnapshot: IWorkspaceManagerService_IDeleteVolumeSnapshot;
     updateSSHKey: IWorkspaceManagerService_IUpdateSSHKey;
     describeCluster: IWorkspaceManagerService_IDescribeCluster;
+    getSidecarLogs: IWorkspaceManagerService_IGetSidecarLogs;
 }
 
 interface IWorkspaceManagerService_IGetWorkspaces
@@ -172,6 +173,16 @@ interface IWorkspaceManagerService_IDescribeCluster
     responseSerialize: grpc.serialize<core_pb.DescribeClusterResponse>;
     responseDeserialize: grpc.deserialize<core_pb.DescribeClusterResponse>;
 }
+interface IWorkspaceManagerService_IGetSidecarLogs
+    extends grpc.MethodDefinition<core_pb.GetSidecarLogsRequest, core_pb.GetSidecarLogsResponse> {
+    path: "/wsman.WorkspaceManager/GetSidecarLogs";
+    requestStream: false;
+    responseStream: false;
+    requestSerialize: grpc.serialize<core_pb.GetSidecarLogsRequest>;
+    requestDeserialize: grpc.deserialize<core_pb.GetSidecarLogsRequest>;
+    responseSerialize: grpc.serialize<core_pb.GetSidecarLogsResponse>;
+    responseDeserialize: grpc.deserialize<core_pb.GetSidecarLogsResponse>;
+}
 
 export const WorkspaceManagerService: IWorkspaceManagerService;
 
@@ -193,6 +204,7 @@ export interface IWorkspaceManagerServer extends grpc.UntypedServiceImplementati
     >;
     updateSSHKey: grpc.handleUnaryCall<core_pb.UpdateSSHKeyRequest, core_pb.UpdateSSHKeyResponse>;
     describeCluster: grpc.handleUnaryCall<core_pb.DescribeClusterRequest, core_pb.DescribeClusterResponse>;
+    getSidecarLogs: grpc.handleUnaryCall<core_pb.GetSidecarLogsRequest, core_pb.GetSidecarLogsResponse>;
 }
 
 export interface IWorkspaceManagerClient {
@@ -400,6 +412,21 @@ export interface IWorkspaceManagerClient {
         options: Partial<grpc.CallOptions>,
         callback: (error: grpc.ServiceError | null, response: core_pb.DescribeClusterResponse) => void,
     ): grpc.ClientUnaryCall;
+    getSidecarLogs(
+        request: core_pb.GetSidecarLogsRequest,
+        callback: (error: grpc.ServiceError | null, response: core_pb.GetSidecarLogsResponse) => void,
+    ): grpc.ClientUnaryCall;
+    getSidecarLogs(
+        request: core_pb.GetSidecarLogsRequest,
+        metadata: grpc.Metadata,
+        callback: (error: grpc.ServiceError | null, response: core_pb.GetSidecarLogsResponse) => void,
+    ): grpc.ClientUnaryCall;
+    getSidecarLogs(
+        request: core_pb.GetSidecarLogsRequest,
+        metadata: grpc.Metadata,
+        options: Partial<grpc.CallOptions>,
+        callback: (error: grpc.ServiceError | null, response: core_pb.GetSidecarLogsResponse) => void,
+    ): grpc.ClientUnaryCall;
 }
 
 export class WorkspaceManagerClient extends grpc.Client implements IWorkspaceManagerClient {
@@ -608,4 +635,19 @@ export class WorkspaceManagerClient extends grpc.Client implements IWorkspaceMan
         options: Partial<grpc.CallOptions>,
         callback: (error: grpc.ServiceError | null, response: core_pb.DescribeClusterResponse) => void,
     ): grpc.ClientUnaryCall;
+    public getSidecarLogs(
+        request: core_pb.GetSidecarLogsRequest,
+        callback: (error: grpc.ServiceError | null, response: core_pb.GetSidecarLogsResponse) => void,
+    ): grpc.ClientUnaryCall;
+    public getSidecarLogs(
+        request: core_pb.GetSidecarLogsRequest,
+        metadata: grpc.Metadata,
+        callback: (error: grpc.ServiceError | null, response: core_pb.GetSidecarLogsResponse) => void,
+    ): grpc.ClientUnaryCall;
+    public getSidecarLogs(
+        request: core_pb.GetSidecarLogsRequest,
+        metadata: grpc.Metadata,
+        options: Partial<grpc.CallOptions>,
+        callback: (error: grpc.ServiceError | null, response: core_pb.GetSidecarLogsResponse) => void,
+    ): grpc.ClientUnaryCall;
 }
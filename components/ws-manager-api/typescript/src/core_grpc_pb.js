@@ -144,6 +144,28 @@ function deserialize_wsman_DescribeWorkspaceResponse(buffer_arg) {
     return core_pb.DescribeWorkspaceResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_wsman_GetSidecarLogsRequest(arg) {
+    if (!(arg instanceof core_pb.GetSidecarLogsRequest)) {
+        throw new Error("Expected argument of type wsman.GetSidecarLogsRequest");
+    }
+    return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_wsman_GetSidecarLogsRequest(buffer_arg) {
+    return core_pb.GetSidecarLogsRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_wsman_GetSidecarLogsResponse(arg) {
+    if (!(arg instanceof core_pb.GetSidecarLogsResponse)) {
+        throw new Error("Expected argument of type wsman.GetSidecarLogsResponse");
+    }
+    return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_wsman_GetSidecarLogsResponse(buffer_arg) {
+    return core_pb.GetSidecarLogsResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_wsman_GetWorkspacesRequest(arg) {
     if (!(arg instanceof core_pb.GetWorkspacesRequest)) {
         throw new Error("Expected argument of type wsman.GetWorkspacesRequest");
@@ -489,6 +511,18 @@ var WorkspaceManagerService = (exports.WorkspaceManagerService = {
         responseSerialize: serialize_wsman_DescribeClusterResponse,
         responseDeserialize: deserialize_wsman_DescribeClusterResponse,
     },
+    // getSidecarLogs produces the log of a sidecar container of a workspace
+    getSidecarLogs: {
+        path: "/wsman.WorkspaceManager/GetSidecarLogs",
+        requestStream: false,
+        responseStream: false,
+        requestType: core_pb.GetSidecarLogsRequest,
+        responseType: core_pb.GetSidecarLogsResponse,
+        requestSerialize: serialize_wsman_GetSidecarLogsRequest,
+        requestDeserialize: deserialize_wsman_GetSidecarLogsRequest,
+        responseSerialize: serialize_wsman_GetSidecarLogsResponse,
+        responseDeserialize: deserialize_wsman_GetSidecarLogsResponse,
+    },
 });
 
 exports.WorkspaceManagerClient = grpc.makeGenericClientConstructor(WorkspaceManagerService);
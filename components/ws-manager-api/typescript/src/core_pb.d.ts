@@ -648,6 +648,61 @@ export namespace UpdateSSHKeyResponse {
     export type AsObject = {};
 }
 
+export class GetSidecarLogsRequest extends jspb.Message {
+    getId(): string;
+    setId(value: string): GetSidecarLogsRequest;
+    getName(): string;
+    setName(value: string): GetSidecarLogsRequest;
+    getTailLines(): number;
+    setTailLines(value: number): GetSidecarLogsRequest;
+    getPrevious(): boolean;
+    setPrevious(value: boolean): GetSidecarLogsRequest;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): GetSidecarLogsRequest.AsObject;
+    static toObject(includeInstance: boolean, msg: GetSidecarLogsRequest): GetSidecarLogsRequest.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: GetSidecarLogsRequest, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): GetSidecarLogsRequest;
+    static deserializeBinaryFromReader(
+        message: GetSidecarLogsRequest,
+        reader: jspb.BinaryReader,
+    ): GetSidecarLogsRequest;
+}
+
+export namespace GetSidecarLogsRequest {
+    export type AsObject = {
+        id: string;
+        name: string;
+        tailLines: number;
+        previous: boolean;
+    };
+}
+
+export class GetSidecarLogsResponse extends jspb.Message {
+    getLog(): string;
+    setLog(value: string): GetSidecarLogsResponse;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): GetSidecarLogsResponse.AsObject;
+    static toObject(includeInstance: boolean, msg: GetSidecarLogsResponse): GetSidecarLogsResponse.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: GetSidecarLogsResponse, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): GetSidecarLogsResponse;
+    static deserializeBinaryFromReader(
+        message: GetSidecarLogsResponse,
+        reader: jspb.BinaryReader,
+    ): GetSidecarLogsResponse;
+}
+
+export namespace GetSidecarLogsResponse {
+    export type AsObject = {
+        log: string;
+    };
+}
+
 export class WorkspaceStatus extends jspb.Message {
     getId(): string;
     setId(value: string): WorkspaceStatus;
@@ -687,6 +742,10 @@ export class WorkspaceStatus extends jspb.Message {
     clearAuth(): void;
     getAuth(): WorkspaceAuthentication | undefined;
     setAuth(value?: WorkspaceAuthentication): WorkspaceStatus;
+    clearSidecarsList(): void;
+    getSidecarsList(): Array<SidecarStatus>;
+    setSidecarsList(value: Array<SidecarStatus>): WorkspaceStatus;
+    addSidecars(value?: SidecarStatus, index?: number): SidecarStatus;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): WorkspaceStatus.AsObject;
@@ -710,6 +769,39 @@ export namespace WorkspaceStatus {
         repo?: content_service_api_initializer_pb.GitStatus.AsObject;
         runtime?: WorkspaceRuntimeInfo.AsObject;
         auth?: WorkspaceAuthentication.AsObject;
+        sidecarsList: Array<SidecarStatus.AsObject>;
+    };
+}
+
+export class SidecarStatus extends jspb.Message {
+    getName(): string;
+    setName(value: string): SidecarStatus;
+    getState(): SidecarState;
+    setState(value: SidecarState): SidecarStatus;
+    getReady(): boolean;
+    setReady(value: boolean): SidecarStatus;
+    getMessage(): string;
+    setMessage(value: string): SidecarStatus;
+    getExitCode(): number;
+    setExitCode(value: number): SidecarStatus;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): SidecarStatus.AsObject;
+    static toObject(includeInstance: boolean, msg: SidecarStatus): SidecarStatus.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: SidecarStatus, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): SidecarStatus;
+    static deserializeBinaryFromReader(message: SidecarStatus, reader: jspb.BinaryReader): SidecarStatus;
+}
+
+export namespace SidecarStatus {
+    export type AsObject = {
+        name: string;
+        state: SidecarState;
+        ready: boolean;
+        message: string;
+        exitCode: number;
     };
 }
 
@@ -1059,6 +1151,10 @@ export class StartWorkspaceSpec extends jspb.Message {
     getIdeImageLayersList(): Array<string>;
     setIdeImageLayersList(value: Array<string>): StartWorkspaceSpec;
     addIdeImageLayers(value: string, index?: number): string;
+    clearSidecarsList(): void;
+    getSidecarsList(): Array<SidecarSpec>;
+    setSidecarsList(value: Array<SidecarSpec>): StartWorkspaceSpec;
+    addSidecars(value?: SidecarSpec, index?: number): SidecarSpec;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): StartWorkspaceSpec.AsObject;
@@ -1088,6 +1184,80 @@ export namespace StartWorkspaceSpec {
         sshPublicKeysList: Array<string>;
         sysEnvvarsList: Array<EnvironmentVariable.AsObject>;
         ideImageLayersList: Array<string>;
+        sidecarsList: Array<SidecarSpec.AsObject>;
+    };
+}
+
+export class SidecarSpec extends jspb.Message {
+    getName(): string;
+    setName(value: string): SidecarSpec;
+    getImage(): string;
+    setImage(value: string): SidecarSpec;
+    clearCommandList(): void;
+    getCommandList(): Array<string>;
+    setCommandList(value: Array<string>): SidecarSpec;
+    addCommand(value: string, index?: number): string;
+    clearArgsList(): void;
+    getArgsList(): Array<string>;
+    setArgsList(value: Array<string>): SidecarSpec;
+    addArgs(value: string, index?: number): string;
+    clearEnvvarsList(): void;
+    getEnvvarsList(): Array<EnvironmentVariable>;
+    setEnvvarsList(value: Array<EnvironmentVariable>): SidecarSpec;
+    addEnvvars(value?: EnvironmentVariable, index?: number): EnvironmentVariable;
+
+    hasResources(): boolean;
+    clearResources(): void;
+    getResources(): SidecarResources | undefined;
+    setResources(value?: SidecarResources): SidecarSpec;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): SidecarSpec.AsObject;
+    static toObject(includeInstance: boolean, msg: SidecarSpec): SidecarSpec.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: SidecarSpec, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): SidecarSpec;
+    static deserializeBinaryFromReader(message: SidecarSpec, reader: jspb.BinaryReader): SidecarSpec;
+}
+
+export namespace SidecarSpec {
+    export type AsObject = {
+        name: string;
+        image: string;
+        commandList: Array<string>;
+        argsList: Array<string>;
+        envvarsList: Array<EnvironmentVariable.AsObject>;
+        resources?: SidecarResources.AsObject;
+    };
+}
+
+export class SidecarResources extends jspb.Message {
+    getCpuRequest(): string;
+    setCpuRequest(value: string): SidecarResources;
+    getMemoryRequest(): string;
+    setMemoryRequest(value: string): SidecarResources;
+    getCpuLimit(): string;
+    setCpuLimit(value: string): SidecarResources;
+    getMemoryLimit(): string;
+    setMemoryLimit(value: string): SidecarResources;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): SidecarResources.AsObject;
+    static toObject(includeInstance: boolean, msg: SidecarResources): SidecarResources.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: SidecarResources, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): SidecarResources;
+    static deserializeBinaryFromReader(message: SidecarResources, reader: jspb.BinaryReader): SidecarResources;
+}
+
+export namespace SidecarResources {
+    export type AsObject = {
+        cpuRequest: string;
+        memoryRequest: string;
+        cpuLimit: string;
+        memoryLimit: string;
     };
 }
 
@@ -1287,9 +1457,17 @@ export enum AdmissionLevel {
     ADMIT_EVERYONE = 1,
 }
 
+export enum SidecarState {
+    SIDECAR_STATE_UNSPECIFIED = 0,
+    SIDECAR_STATE_WAITING = 1,
+    SIDECAR_STATE_RUNNING = 2,
+    SIDECAR_STATE_TERMINATED = 3,
+}
+
 export enum PortVisibility {
     PORT_VISIBILITY_PRIVATE = 0,
     PORT_VISIBILITY_PUBLIC = 1,
+    PORT_VISIBILITY_SHARED = 2,
 }
 
 export enum WorkspaceConditionBool {
@@ -43,6 +43,8 @@ goog.exportSymbol("proto.wsman.DescribeWorkspaceResponse", null, global);
 goog.exportSymbol("proto.wsman.EnvironmentVariable", null, global);
 goog.exportSymbol("proto.wsman.EnvironmentVariable.SecretKeyRef", null, global);
 goog.exportSymbol("proto.wsman.ExposedPorts", null, global);
+goog.exportSymbol("proto.wsman.GetSidecarLogsRequest", null, global);
+goog.exportSymbol("proto.wsman.GetSidecarLogsResponse", null, global);
 goog.exportSymbol("proto.wsman.GetWorkspacesRequest", null, global);
 goog.exportSymbol("proto.wsman.GetWorkspacesResponse", null, global);
 goog.exportSymbol("proto.wsman.GitSpec", null, global);
@@ -55,6 +57,10 @@ goog.exportSymbol("proto.wsman.PortVisibility", null, global);
 goog.exportSymbol("proto.wsman.SSHPublicKeys", null, global);
 goog.exportSymbol("proto.wsman.SetTimeoutRequest", null, global);
 goog.exportSymbol("proto.wsman.SetTimeoutResponse", null, global);
+goog.exportSymbol("proto.wsman.SidecarResources", null, global);
+goog.exportSymbol("proto.wsman.SidecarSpec", null, global);
+goog.exportSymbol("proto.wsman.SidecarState", null, global);
+goog.exportSymbol("proto.wsman.SidecarStatus", null, global);
 goog.exportSymbol("proto.wsman.StartWorkspaceRequest", null, global);
 goog.exportSymbol("proto.wsman.StartWorkspaceResponse", null, global);
 goog.exportSymbol("proto.wsman.StartWorkspaceSpec", null, global);
@@ -656,9 +662,51 @@ if (goog.DEBUG && !COMPILED) {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.wsman.WorkspaceStatus = function (opt_data) {
+proto.wsman.GetSidecarLogsRequest = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.wsman.GetSidecarLogsRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.wsman.GetSidecarLogsRequest.displayName = "proto.wsman.GetSidecarLogsRequest";
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.wsman.GetSidecarLogsResponse = function (opt_data) {
     jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
+goog.inherits(proto.wsman.GetSidecarLogsResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.wsman.GetSidecarLogsResponse.displayName = "proto.wsman.GetSidecarLogsResponse";
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.wsman.WorkspaceStatus = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, proto.wsman.WorkspaceStatus.repeatedFields_, null);
+};
 goog.inherits(proto.wsman.WorkspaceStatus, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
     /**
@@ -667,6 +715,27 @@ if (goog.DEBUG && !COMPILED) {
      */
     proto.wsman.WorkspaceStatus.displayName = "proto.wsman.WorkspaceStatus";
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.wsman.SidecarStatus = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.wsman.SidecarStatus, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.wsman.SidecarStatus.displayName = "proto.wsman.SidecarStatus";
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -856,6 +925,48 @@ if (goog.DEBUG && !COMPILED) {
      */
     proto.wsman.StartWorkspaceSpec.displayName = "proto.wsman.StartWorkspaceSpec";
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.wsman.SidecarSpec = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, proto.wsman.SidecarSpec.repeatedFields_, null);
+};
+goog.inherits(proto.wsman.SidecarSpec, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.wsman.SidecarSpec.displayName = "proto.wsman.SidecarSpec";
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.wsman.SidecarResources = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.wsman.SidecarResources, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.wsman.SidecarResources.displayName = "proto.wsman.SidecarResources";
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -4778,8 +4889,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      *     http://goto/soy-param-migration
      * @return {!Object}
      */
-    proto.wsman.WorkspaceStatus.prototype.toObject = function (opt_includeInstance) {
-        return proto.wsman.WorkspaceStatus.toObject(opt_includeInstance, this);
+    proto.wsman.GetSidecarLogsRequest.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.GetSidecarLogsRequest.toObject(opt_includeInstance, this);
     };
 
     /**
@@ -4787,23 +4898,17 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      * @param {boolean|undefined} includeInstance Deprecated. Whether to include
      *     the JSPB instance for transitional soy proto support:
      *     http://goto/soy-param-migration
-     * @param {!proto.wsman.WorkspaceStatus} msg The msg instance to transform.
+     * @param {!proto.wsman.GetSidecarLogsRequest} msg The msg instance to transform.
      * @return {!Object}
      * @suppress {unusedLocalVariables} f is only used for nested messages
      */
-    proto.wsman.WorkspaceStatus.toObject = function (includeInstance, msg) {
+    proto.wsman.GetSidecarLogsRequest.toObject = function (includeInstance, msg) {
         var f,
             obj = {
                 id: jspb.Message.getFieldWithDefault(msg, 1, ""),
-                statusVersion: jspb.Message.getFieldWithDefault(msg, 10, 0),
-                metadata: (f = msg.getMetadata()) && proto.wsman.WorkspaceMetadata.toObject(includeInstance, f),
-                spec: (f = msg.getSpec()) && proto.wsman.WorkspaceSpec.toObject(includeInstance, f),
-                phase: jspb.Message.getFieldWithDefault(msg, 4, 0),
-                conditions: (f = msg.getConditions()) && proto.wsman.WorkspaceConditions.toObject(includeInstance, f),
-                message: jspb.Message.getFieldWithDefault(msg, 6, ""),
-                repo: (f = msg.getRepo()) && content$service$api_initializer_pb.GitStatus.toObject(includeInstance, f),
-                runtime: (f = msg.getRuntime()) && proto.wsman.WorkspaceRuntimeInfo.toObject(includeInstance, f),
-                auth: (f = msg.getAuth()) && proto.wsman.WorkspaceAuthentication.toObject(includeInstance, f),
+                name: jspb.Message.getFieldWithDefault(msg, 2, ""),
+                tailLines: jspb.Message.getFieldWithDefault(msg, 3, 0),
+                previous: jspb.Message.getBooleanFieldWithDefault(msg, 4, false),
             };
 
         if (includeInstance) {
@@ -4816,22 +4921,22 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.wsman.WorkspaceStatus}
+ * @return {!proto.wsman.GetSidecarLogsRequest}
  */
-proto.wsman.WorkspaceStatus.deserializeBinary = function (bytes) {
+proto.wsman.GetSidecarLogsRequest.deserializeBinary = function (bytes) {
     var reader = new jspb.BinaryReader(bytes);
-    var msg = new proto.wsman.WorkspaceStatus();
-    return proto.wsman.WorkspaceStatus.deserializeBinaryFromReader(msg, reader);
+    var msg = new proto.wsman.GetSidecarLogsRequest();
+    return proto.wsman.GetSidecarLogsRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.wsman.WorkspaceStatus} msg The message object to deserialize into.
+ * @param {!proto.wsman.GetSidecarLogsRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.wsman.WorkspaceStatus}
+ * @return {!proto.wsman.GetSidecarLogsRequest}
  */
-proto.wsman.WorkspaceStatus.deserializeBinaryFromReader = function (msg, reader) {
+proto.wsman.GetSidecarLogsRequest.deserializeBinaryFromReader = function (msg, reader) {
     while (reader.nextField()) {
         if (reader.isEndGroup()) {
             break;
@@ -4842,47 +4947,17 @@ proto.wsman.WorkspaceStatus.deserializeBinaryFromReader = function (msg, reader)
                 var value = /** @type {string} */ (reader.readString());
                 msg.setId(value);
                 break;
-            case 10:
-                var value = /** @type {number} */ (reader.readUint64());
-                msg.setStatusVersion(value);
-                break;
             case 2:
-                var value = new proto.wsman.WorkspaceMetadata();
-                reader.readMessage(value, proto.wsman.WorkspaceMetadata.deserializeBinaryFromReader);
-                msg.setMetadata(value);
+                var value = /** @type {string} */ (reader.readString());
+                msg.setName(value);
                 break;
             case 3:
-                var value = new proto.wsman.WorkspaceSpec();
-                reader.readMessage(value, proto.wsman.WorkspaceSpec.deserializeBinaryFromReader);
-                msg.setSpec(value);
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setTailLines(value);
                 break;
             case 4:
-                var value = /** @type {!proto.wsman.WorkspacePhase} */ (reader.readEnum());
-                msg.setPhase(value);
-                break;
-            case 5:
-                var value = new proto.wsman.WorkspaceConditions();
-                reader.readMessage(value, proto.wsman.WorkspaceConditions.deserializeBinaryFromReader);
-                msg.setConditions(value);
-                break;
-            case 6:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setMessage(value);
-                break;
-            case 7:
-                var value = new content$service$api_initializer_pb.GitStatus();
-                reader.readMessage(value, content$service$api_initializer_pb.GitStatus.deserializeBinaryFromReader);
-                msg.setRepo(value);
-                break;
-            case 8:
-                var value = new proto.wsman.WorkspaceRuntimeInfo();
-                reader.readMessage(value, proto.wsman.WorkspaceRuntimeInfo.deserializeBinaryFromReader);
-                msg.setRuntime(value);
-                break;
-            case 9:
-                var value = new proto.wsman.WorkspaceAuthentication();
-                reader.readMessage(value, proto.wsman.WorkspaceAuthentication.deserializeBinaryFromReader);
-                msg.setAuth(value);
+                var value = /** @type {boolean} */ (reader.readBool());
+                msg.setPrevious(value);
                 break;
             default:
                 reader.skipField();
@@ -4896,60 +4971,36 @@ proto.wsman.WorkspaceStatus.deserializeBinaryFromReader = function (msg, reader)
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.wsman.WorkspaceStatus.prototype.serializeBinary = function () {
+proto.wsman.GetSidecarLogsRequest.prototype.serializeBinary = function () {
     var writer = new jspb.BinaryWriter();
-    proto.wsman.WorkspaceStatus.serializeBinaryToWriter(this, writer);
+    proto.wsman.GetSidecarLogsRequest.serializeBinaryToWriter(this, writer);
     return writer.getResultBuffer();
 };
 
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.wsman.WorkspaceStatus} message
+ * @param {!proto.wsman.GetSidecarLogsRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.wsman.WorkspaceStatus.serializeBinaryToWriter = function (message, writer) {
+proto.wsman.GetSidecarLogsRequest.serializeBinaryToWriter = function (message, writer) {
     var f = undefined;
     f = message.getId();
     if (f.length > 0) {
         writer.writeString(1, f);
     }
-    f = message.getStatusVersion();
-    if (f !== 0) {
-        writer.writeUint64(10, f);
-    }
-    f = message.getMetadata();
-    if (f != null) {
-        writer.writeMessage(2, f, proto.wsman.WorkspaceMetadata.serializeBinaryToWriter);
-    }
-    f = message.getSpec();
-    if (f != null) {
-        writer.writeMessage(3, f, proto.wsman.WorkspaceSpec.serializeBinaryToWriter);
-    }
-    f = message.getPhase();
-    if (f !== 0.0) {
-        writer.writeEnum(4, f);
-    }
-    f = message.getConditions();
-    if (f != null) {
-        writer.writeMessage(5, f, proto.wsman.WorkspaceConditions.serializeBinaryToWriter);
-    }
-    f = message.getMessage();
+    f = message.getName();
     if (f.length > 0) {
-        writer.writeString(6, f);
-    }
-    f = message.getRepo();
-    if (f != null) {
-        writer.writeMessage(7, f, content$service$api_initializer_pb.GitStatus.serializeBinaryToWriter);
+        writer.writeString(2, f);
     }
-    f = message.getRuntime();
-    if (f != null) {
-        writer.writeMessage(8, f, proto.wsman.WorkspaceRuntimeInfo.serializeBinaryToWriter);
+    f = message.getTailLines();
+    if (f !== 0) {
+        writer.writeInt64(3, f);
     }
-    f = message.getAuth();
-    if (f != null) {
-        writer.writeMessage(9, f, proto.wsman.WorkspaceAuthentication.serializeBinaryToWriter);
+    f = message.getPrevious();
+    if (f) {
+        writer.writeBool(4, f);
     }
 };
 
@@ -4957,267 +5008,189 @@ proto.wsman.WorkspaceStatus.serializeBinaryToWriter = function (message, writer)
  * optional string id = 1;
  * @return {string}
  */
-proto.wsman.WorkspaceStatus.prototype.getId = function () {
+proto.wsman.GetSidecarLogsRequest.prototype.getId = function () {
     return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.WorkspaceStatus} returns this
+ * @return {!proto.wsman.GetSidecarLogsRequest} returns this
  */
-proto.wsman.WorkspaceStatus.prototype.setId = function (value) {
+proto.wsman.GetSidecarLogsRequest.prototype.setId = function (value) {
     return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 /**
- * optional uint64 status_version = 10;
- * @return {number}
- */
-proto.wsman.WorkspaceStatus.prototype.getStatusVersion = function () {
-    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 10, 0));
-};
-
-/**
- * @param {number} value
- * @return {!proto.wsman.WorkspaceStatus} returns this
+ * optional string name = 2;
+ * @return {string}
  */
-proto.wsman.WorkspaceStatus.prototype.setStatusVersion = function (value) {
-    return jspb.Message.setProto3IntField(this, 10, value);
+proto.wsman.GetSidecarLogsRequest.prototype.getName = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 /**
- * optional WorkspaceMetadata metadata = 2;
- * @return {?proto.wsman.WorkspaceMetadata}
+ * @param {string} value
+ * @return {!proto.wsman.GetSidecarLogsRequest} returns this
  */
-proto.wsman.WorkspaceStatus.prototype.getMetadata = function () {
-    return /** @type{?proto.wsman.WorkspaceMetadata} */ (
-        jspb.Message.getWrapperField(this, proto.wsman.WorkspaceMetadata, 2)
-    );
+proto.wsman.GetSidecarLogsRequest.prototype.setName = function (value) {
+    return jspb.Message.setProto3StringField(this, 2, value);
 };
 
 /**
- * @param {?proto.wsman.WorkspaceMetadata|undefined} value
- * @return {!proto.wsman.WorkspaceStatus} returns this
+ * optional int64 tail_lines = 3;
+ * @return {number}
  */
-proto.wsman.WorkspaceStatus.prototype.setMetadata = function (value) {
-    return jspb.Message.setWrapperField(this, 2, value);
+proto.wsman.GetSidecarLogsRequest.prototype.getTailLines = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
 };
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.wsman.WorkspaceStatus} returns this
+ * @param {number} value
+ * @return {!proto.wsman.GetSidecarLogsRequest} returns this
  */
-proto.wsman.WorkspaceStatus.prototype.clearMetadata = function () {
-    return this.setMetadata(undefined);
+proto.wsman.GetSidecarLogsRequest.prototype.setTailLines = function (value) {
+    return jspb.Message.setProto3IntField(this, 3, value);
 };
 
 /**
- * Returns whether this field is set.
+ * optional bool previous = 4;
  * @return {boolean}
  */
-proto.wsman.WorkspaceStatus.prototype.hasMetadata = function () {
-    return jspb.Message.getField(this, 2) != null;
+proto.wsman.GetSidecarLogsRequest.prototype.getPrevious = function () {
+    return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 4, false));
 };
 
 /**
- * optional WorkspaceSpec spec = 3;
- * @return {?proto.wsman.WorkspaceSpec}
+ * @param {boolean} value
+ * @return {!proto.wsman.GetSidecarLogsRequest} returns this
  */
-proto.wsman.WorkspaceStatus.prototype.getSpec = function () {
-    return /** @type{?proto.wsman.WorkspaceSpec} */ (jspb.Message.getWrapperField(this, proto.wsman.WorkspaceSpec, 3));
+proto.wsman.GetSidecarLogsRequest.prototype.setPrevious = function (value) {
+    return jspb.Message.setProto3BooleanField(this, 4, value);
 };
 
-/**
- * @param {?proto.wsman.WorkspaceSpec|undefined} value
- * @return {!proto.wsman.WorkspaceStatus} returns this
- */
-proto.wsman.WorkspaceStatus.prototype.setSpec = function (value) {
-    return jspb.Message.setWrapperField(this, 3, value);
-};
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.wsman.GetSidecarLogsResponse.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.GetSidecarLogsResponse.toObject(opt_includeInstance, this);
+    };
 
-/**
- * Clears the message field making it undefined.
- * @return {!proto.wsman.WorkspaceStatus} returns this
- */
-proto.wsman.WorkspaceStatus.prototype.clearSpec = function () {
-    return this.setSpec(undefined);
-};
-
-/**
- * Returns whether this field is set.
- * @return {boolean}
- */
-proto.wsman.WorkspaceStatus.prototype.hasSpec = function () {
-    return jspb.Message.getField(this, 3) != null;
-};
-
-/**
- * optional WorkspacePhase phase = 4;
- * @return {!proto.wsman.WorkspacePhase}
- */
-proto.wsman.WorkspaceStatus.prototype.getPhase = function () {
-    return /** @type {!proto.wsman.WorkspacePhase} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
-};
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.wsman.GetSidecarLogsResponse} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.wsman.GetSidecarLogsResponse.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                log: jspb.Message.getFieldWithDefault(msg, 1, ""),
+            };
 
-/**
- * @param {!proto.wsman.WorkspacePhase} value
- * @return {!proto.wsman.WorkspaceStatus} returns this
- */
-proto.wsman.WorkspaceStatus.prototype.setPhase = function (value) {
-    return jspb.Message.setProto3EnumField(this, 4, value);
-};
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
 
 /**
- * optional WorkspaceConditions conditions = 5;
- * @return {?proto.wsman.WorkspaceConditions}
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.wsman.GetSidecarLogsResponse}
  */
-proto.wsman.WorkspaceStatus.prototype.getConditions = function () {
-    return /** @type{?proto.wsman.WorkspaceConditions} */ (
-        jspb.Message.getWrapperField(this, proto.wsman.WorkspaceConditions, 5)
-    );
+proto.wsman.GetSidecarLogsResponse.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.wsman.GetSidecarLogsResponse();
+    return proto.wsman.GetSidecarLogsResponse.deserializeBinaryFromReader(msg, reader);
 };
 
 /**
- * @param {?proto.wsman.WorkspaceConditions|undefined} value
- * @return {!proto.wsman.WorkspaceStatus} returns this
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.wsman.GetSidecarLogsResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.wsman.GetSidecarLogsResponse}
  */
-proto.wsman.WorkspaceStatus.prototype.setConditions = function (value) {
-    return jspb.Message.setWrapperField(this, 5, value);
+proto.wsman.GetSidecarLogsResponse.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setLog(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
 };
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.wsman.WorkspaceStatus} returns this
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
  */
-proto.wsman.WorkspaceStatus.prototype.clearConditions = function () {
-    return this.setConditions(undefined);
+proto.wsman.GetSidecarLogsResponse.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.wsman.GetSidecarLogsResponse.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
 };
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.wsman.GetSidecarLogsResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.wsman.WorkspaceStatus.prototype.hasConditions = function () {
-    return jspb.Message.getField(this, 5) != null;
+proto.wsman.GetSidecarLogsResponse.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getLog();
+    if (f.length > 0) {
+        writer.writeString(1, f);
+    }
 };
 
 /**
- * optional string message = 6;
+ * optional string log = 1;
  * @return {string}
  */
-proto.wsman.WorkspaceStatus.prototype.getMessage = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 6, ""));
+proto.wsman.GetSidecarLogsResponse.prototype.getLog = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.WorkspaceStatus} returns this
- */
-proto.wsman.WorkspaceStatus.prototype.setMessage = function (value) {
-    return jspb.Message.setProto3StringField(this, 6, value);
-};
-
-/**
- * optional contentservice.GitStatus repo = 7;
- * @return {?proto.contentservice.GitStatus}
- */
-proto.wsman.WorkspaceStatus.prototype.getRepo = function () {
-    return /** @type{?proto.contentservice.GitStatus} */ (
-        jspb.Message.getWrapperField(this, content$service$api_initializer_pb.GitStatus, 7)
-    );
-};
-
-/**
- * @param {?proto.contentservice.GitStatus|undefined} value
- * @return {!proto.wsman.WorkspaceStatus} returns this
- */
-proto.wsman.WorkspaceStatus.prototype.setRepo = function (value) {
-    return jspb.Message.setWrapperField(this, 7, value);
-};
-
-/**
- * Clears the message field making it undefined.
- * @return {!proto.wsman.WorkspaceStatus} returns this
- */
-proto.wsman.WorkspaceStatus.prototype.clearRepo = function () {
-    return this.setRepo(undefined);
-};
-
-/**
- * Returns whether this field is set.
- * @return {boolean}
- */
-proto.wsman.WorkspaceStatus.prototype.hasRepo = function () {
-    return jspb.Message.getField(this, 7) != null;
-};
-
-/**
- * optional WorkspaceRuntimeInfo runtime = 8;
- * @return {?proto.wsman.WorkspaceRuntimeInfo}
- */
-proto.wsman.WorkspaceStatus.prototype.getRuntime = function () {
-    return /** @type{?proto.wsman.WorkspaceRuntimeInfo} */ (
-        jspb.Message.getWrapperField(this, proto.wsman.WorkspaceRuntimeInfo, 8)
-    );
-};
-
-/**
- * @param {?proto.wsman.WorkspaceRuntimeInfo|undefined} value
- * @return {!proto.wsman.WorkspaceStatus} returns this
- */
-proto.wsman.WorkspaceStatus.prototype.setRuntime = function (value) {
-    return jspb.Message.setWrapperField(this, 8, value);
-};
-
-/**
- * Clears the message field making it undefined.
- * @return {!proto.wsman.WorkspaceStatus} returns this
- */
-proto.wsman.WorkspaceStatus.prototype.clearRuntime = function () {
-    return this.setRuntime(undefined);
-};
-
-/**
- * Returns whether this field is set.
- * @return {boolean}
- */
-proto.wsman.WorkspaceStatus.prototype.hasRuntime = function () {
-    return jspb.Message.getField(this, 8) != null;
-};
-
-/**
- * optional WorkspaceAuthentication auth = 9;
- * @return {?proto.wsman.WorkspaceAuthentication}
- */
-proto.wsman.WorkspaceStatus.prototype.getAuth = function () {
-    return /** @type{?proto.wsman.WorkspaceAuthentication} */ (
-        jspb.Message.getWrapperField(this, proto.wsman.WorkspaceAuthentication, 9)
-    );
-};
-
-/**
- * @param {?proto.wsman.WorkspaceAuthentication|undefined} value
- * @return {!proto.wsman.WorkspaceStatus} returns this
- */
-proto.wsman.WorkspaceStatus.prototype.setAuth = function (value) {
-    return jspb.Message.setWrapperField(this, 9, value);
-};
-
-/**
- * Clears the message field making it undefined.
- * @return {!proto.wsman.WorkspaceStatus} returns this
+ * @return {!proto.wsman.GetSidecarLogsResponse} returns this
  */
-proto.wsman.WorkspaceStatus.prototype.clearAuth = function () {
-    return this.setAuth(undefined);
+proto.wsman.GetSidecarLogsResponse.prototype.setLog = function (value) {
+    return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
  */
-proto.wsman.WorkspaceStatus.prototype.hasAuth = function () {
-    return jspb.Message.getField(this, 9) != null;
-};
+proto.wsman.WorkspaceStatus.repeatedFields_ = [11];
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
     /**
@@ -5232,8 +5205,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      *     http://goto/soy-param-migration
      * @return {!Object}
      */
-    proto.wsman.IDEImage.prototype.toObject = function (opt_includeInstance) {
-        return proto.wsman.IDEImage.toObject(opt_includeInstance, this);
+    proto.wsman.WorkspaceStatus.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.WorkspaceStatus.toObject(opt_includeInstance, this);
     };
 
     /**
@@ -5241,17 +5214,28 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      * @param {boolean|undefined} includeInstance Deprecated. Whether to include
      *     the JSPB instance for transitional soy proto support:
      *     http://goto/soy-param-migration
-     * @param {!proto.wsman.IDEImage} msg The msg instance to transform.
+     * @param {!proto.wsman.WorkspaceStatus} msg The msg instance to transform.
      * @return {!Object}
      * @suppress {unusedLocalVariables} f is only used for nested messages
      */
-    proto.wsman.IDEImage.toObject = function (includeInstance, msg) {
+    proto.wsman.WorkspaceStatus.toObject = function (includeInstance, msg) {
         var f,
             obj = {
-                webRef: jspb.Message.getFieldWithDefault(msg, 1, ""),
-                desktopRef: jspb.Message.getFieldWithDefault(msg, 2, ""),
-                supervisorRef: jspb.Message.getFieldWithDefault(msg, 3, ""),
-                desktopPluginRef: jspb.Message.getFieldWithDefault(msg, 4, ""),
+                id: jspb.Message.getFieldWithDefault(msg, 1, ""),
+                statusVersion: jspb.Message.getFieldWithDefault(msg, 10, 0),
+                metadata: (f = msg.getMetadata()) && proto.wsman.WorkspaceMetadata.toObject(includeInstance, f),
+                spec: (f = msg.getSpec()) && proto.wsman.WorkspaceSpec.toObject(includeInstance, f),
+                phase: jspb.Message.getFieldWithDefault(msg, 4, 0),
+                conditions: (f = msg.getConditions()) && proto.wsman.WorkspaceConditions.toObject(includeInstance, f),
+                message: jspb.Message.getFieldWithDefault(msg, 6, ""),
+                repo: (f = msg.getRepo()) && content$service$api_initializer_pb.GitStatus.toObject(includeInstance, f),
+                runtime: (f = msg.getRuntime()) && proto.wsman.WorkspaceRuntimeInfo.toObject(includeInstance, f),
+                auth: (f = msg.getAuth()) && proto.wsman.WorkspaceAuthentication.toObject(includeInstance, f),
+                sidecarsList: jspb.Message.toObjectList(
+                    msg.getSidecarsList(),
+                    proto.wsman.SidecarStatus.toObject,
+                    includeInstance,
+                ),
             };
 
         if (includeInstance) {
@@ -5264,22 +5248,22 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.wsman.IDEImage}
+ * @return {!proto.wsman.WorkspaceStatus}
  */
-proto.wsman.IDEImage.deserializeBinary = function (bytes) {
+proto.wsman.WorkspaceStatus.deserializeBinary = function (bytes) {
     var reader = new jspb.BinaryReader(bytes);
-    var msg = new proto.wsman.IDEImage();
-    return proto.wsman.IDEImage.deserializeBinaryFromReader(msg, reader);
+    var msg = new proto.wsman.WorkspaceStatus();
+    return proto.wsman.WorkspaceStatus.deserializeBinaryFromReader(msg, reader);
 };
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.wsman.IDEImage} msg The message object to deserialize into.
+ * @param {!proto.wsman.WorkspaceStatus} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.wsman.IDEImage}
+ * @return {!proto.wsman.WorkspaceStatus}
  */
-proto.wsman.IDEImage.deserializeBinaryFromReader = function (msg, reader) {
+proto.wsman.WorkspaceStatus.deserializeBinaryFromReader = function (msg, reader) {
     while (reader.nextField()) {
         if (reader.isEndGroup()) {
             break;
@@ -5288,19 +5272,54 @@ proto.wsman.IDEImage.deserializeBinaryFromReader = function (msg, reader) {
         switch (field) {
             case 1:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setWebRef(value);
+                msg.setId(value);
+                break;
+            case 10:
+                var value = /** @type {number} */ (reader.readUint64());
+                msg.setStatusVersion(value);
                 break;
             case 2:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setDesktopRef(value);
+                var value = new proto.wsman.WorkspaceMetadata();
+                reader.readMessage(value, proto.wsman.WorkspaceMetadata.deserializeBinaryFromReader);
+                msg.setMetadata(value);
                 break;
             case 3:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setSupervisorRef(value);
+                var value = new proto.wsman.WorkspaceSpec();
+                reader.readMessage(value, proto.wsman.WorkspaceSpec.deserializeBinaryFromReader);
+                msg.setSpec(value);
                 break;
             case 4:
+                var value = /** @type {!proto.wsman.WorkspacePhase} */ (reader.readEnum());
+                msg.setPhase(value);
+                break;
+            case 5:
+                var value = new proto.wsman.WorkspaceConditions();
+                reader.readMessage(value, proto.wsman.WorkspaceConditions.deserializeBinaryFromReader);
+                msg.setConditions(value);
+                break;
+            case 6:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setDesktopPluginRef(value);
+                msg.setMessage(value);
+                break;
+            case 7:
+                var value = new content$service$api_initializer_pb.GitStatus();
+                reader.readMessage(value, content$service$api_initializer_pb.GitStatus.deserializeBinaryFromReader);
+                msg.setRepo(value);
+                break;
+            case 8:
+                var value = new proto.wsman.WorkspaceRuntimeInfo();
+                reader.readMessage(value, proto.wsman.WorkspaceRuntimeInfo.deserializeBinaryFromReader);
+                msg.setRuntime(value);
+                break;
+            case 9:
+                var value = new proto.wsman.WorkspaceAuthentication();
+                reader.readMessage(value, proto.wsman.WorkspaceAuthentication.deserializeBinaryFromReader);
+                msg.setAuth(value);
+                break;
+            case 11:
+                var value = new proto.wsman.SidecarStatus();
+                reader.readMessage(value, proto.wsman.SidecarStatus.deserializeBinaryFromReader);
+                msg.addSidecars(value);
                 break;
             default:
                 reader.skipField();
@@ -5314,508 +5333,366 @@ proto.wsman.IDEImage.deserializeBinaryFromReader = function (msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.wsman.IDEImage.prototype.serializeBinary = function () {
+proto.wsman.WorkspaceStatus.prototype.serializeBinary = function () {
     var writer = new jspb.BinaryWriter();
-    proto.wsman.IDEImage.serializeBinaryToWriter(this, writer);
+    proto.wsman.WorkspaceStatus.serializeBinaryToWriter(this, writer);
     return writer.getResultBuffer();
 };
 
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.wsman.IDEImage} message
+ * @param {!proto.wsman.WorkspaceStatus} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.wsman.IDEImage.serializeBinaryToWriter = function (message, writer) {
+proto.wsman.WorkspaceStatus.serializeBinaryToWriter = function (message, writer) {
     var f = undefined;
-    f = message.getWebRef();
+    f = message.getId();
     if (f.length > 0) {
         writer.writeString(1, f);
     }
-    f = message.getDesktopRef();
-    if (f.length > 0) {
-        writer.writeString(2, f);
+    f = message.getStatusVersion();
+    if (f !== 0) {
+        writer.writeUint64(10, f);
     }
-    f = message.getSupervisorRef();
+    f = message.getMetadata();
+    if (f != null) {
+        writer.writeMessage(2, f, proto.wsman.WorkspaceMetadata.serializeBinaryToWriter);
+    }
+    f = message.getSpec();
+    if (f != null) {
+        writer.writeMessage(3, f, proto.wsman.WorkspaceSpec.serializeBinaryToWriter);
+    }
+    f = message.getPhase();
+    if (f !== 0.0) {
+        writer.writeEnum(4, f);
+    }
+    f = message.getConditions();
+    if (f != null) {
+        writer.writeMessage(5, f, proto.wsman.WorkspaceConditions.serializeBinaryToWriter);
+    }
+    f = message.getMessage();
     if (f.length > 0) {
-        writer.writeString(3, f);
+        writer.writeString(6, f);
     }
-    f = message.getDesktopPluginRef();
+    f = message.getRepo();
+    if (f != null) {
+        writer.writeMessage(7, f, content$service$api_initializer_pb.GitStatus.serializeBinaryToWriter);
+    }
+    f = message.getRuntime();
+    if (f != null) {
+        writer.writeMessage(8, f, proto.wsman.WorkspaceRuntimeInfo.serializeBinaryToWriter);
+    }
+    f = message.getAuth();
+    if (f != null) {
+        writer.writeMessage(9, f, proto.wsman.WorkspaceAuthentication.serializeBinaryToWriter);
+    }
+    f = message.getSidecarsList();
     if (f.length > 0) {
-        writer.writeString(4, f);
+        writer.writeRepeatedMessage(11, f, proto.wsman.SidecarStatus.serializeBinaryToWriter);
     }
 };
 
 /**
- * optional string web_ref = 1;
+ * optional string id = 1;
  * @return {string}
  */
-proto.wsman.IDEImage.prototype.getWebRef = function () {
+proto.wsman.WorkspaceStatus.prototype.getId = function () {
     return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.IDEImage} returns this
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.IDEImage.prototype.setWebRef = function (value) {
+proto.wsman.WorkspaceStatus.prototype.setId = function (value) {
     return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 /**
- * optional string desktop_ref = 2;
- * @return {string}
+ * optional uint64 status_version = 10;
+ * @return {number}
  */
-proto.wsman.IDEImage.prototype.getDesktopRef = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+proto.wsman.WorkspaceStatus.prototype.getStatusVersion = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 10, 0));
 };
 
 /**
- * @param {string} value
- * @return {!proto.wsman.IDEImage} returns this
+ * @param {number} value
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.IDEImage.prototype.setDesktopRef = function (value) {
-    return jspb.Message.setProto3StringField(this, 2, value);
+proto.wsman.WorkspaceStatus.prototype.setStatusVersion = function (value) {
+    return jspb.Message.setProto3IntField(this, 10, value);
 };
 
 /**
- * optional string supervisor_ref = 3;
- * @return {string}
+ * optional WorkspaceMetadata metadata = 2;
+ * @return {?proto.wsman.WorkspaceMetadata}
  */
-proto.wsman.IDEImage.prototype.getSupervisorRef = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+proto.wsman.WorkspaceStatus.prototype.getMetadata = function () {
+    return /** @type{?proto.wsman.WorkspaceMetadata} */ (
+        jspb.Message.getWrapperField(this, proto.wsman.WorkspaceMetadata, 2)
+    );
 };
 
 /**
- * @param {string} value
- * @return {!proto.wsman.IDEImage} returns this
+ * @param {?proto.wsman.WorkspaceMetadata|undefined} value
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.IDEImage.prototype.setSupervisorRef = function (value) {
-    return jspb.Message.setProto3StringField(this, 3, value);
+proto.wsman.WorkspaceStatus.prototype.setMetadata = function (value) {
+    return jspb.Message.setWrapperField(this, 2, value);
 };
 
 /**
- * optional string desktop_plugin_ref = 4;
- * @return {string}
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.IDEImage.prototype.getDesktopPluginRef = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+proto.wsman.WorkspaceStatus.prototype.clearMetadata = function () {
+    return this.setMetadata(undefined);
 };
 
 /**
- * @param {string} value
- * @return {!proto.wsman.IDEImage} returns this
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.wsman.IDEImage.prototype.setDesktopPluginRef = function (value) {
-    return jspb.Message.setProto3StringField(this, 4, value);
+proto.wsman.WorkspaceStatus.prototype.hasMetadata = function () {
+    return jspb.Message.getField(this, 2) != null;
 };
 
 /**
- * List of repeated fields within this message type.
- * @private {!Array<number>}
- * @const
+ * optional WorkspaceSpec spec = 3;
+ * @return {?proto.wsman.WorkspaceSpec}
  */
-proto.wsman.WorkspaceSpec.repeatedFields_ = [5, 10];
+proto.wsman.WorkspaceStatus.prototype.getSpec = function () {
+    return /** @type{?proto.wsman.WorkspaceSpec} */ (jspb.Message.getWrapperField(this, proto.wsman.WorkspaceSpec, 3));
+};
 
-if (jspb.Message.GENERATE_TO_OBJECT) {
-    /**
-     * Creates an object representation of this proto.
-     * Field names that are reserved in JavaScript and will be renamed to pb_name.
-     * Optional fields that are not set will be set to undefined.
-     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
-     * For the list of reserved names please see:
-     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
-     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
-     *     JSPB instance for transitional soy proto support:
-     *     http://goto/soy-param-migration
-     * @return {!Object}
-     */
-    proto.wsman.WorkspaceSpec.prototype.toObject = function (opt_includeInstance) {
-        return proto.wsman.WorkspaceSpec.toObject(opt_includeInstance, this);
-    };
+/**
+ * @param {?proto.wsman.WorkspaceSpec|undefined} value
+ * @return {!proto.wsman.WorkspaceStatus} returns this
+ */
+proto.wsman.WorkspaceStatus.prototype.setSpec = function (value) {
+    return jspb.Message.setWrapperField(this, 3, value);
+};
 
-    /**
-     * Static version of the {@see toObject} method.
-     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
-     *     the JSPB instance for transitional soy proto support:
-     *     http://goto/soy-param-migration
-     * @param {!proto.wsman.WorkspaceSpec} msg The msg instance to transform.
-     * @return {!Object}
-     * @suppress {unusedLocalVariables} f is only used for nested messages
-     */
-    proto.wsman.WorkspaceSpec.toObject = function (includeInstance, msg) {
-        var f,
-            obj = {
-                workspaceImage: jspb.Message.getFieldWithDefault(msg, 1, ""),
-                deprecatedIdeImage: jspb.Message.getFieldWithDefault(msg, 2, ""),
-                headless: jspb.Message.getBooleanFieldWithDefault(msg, 3, false),
-                url: jspb.Message.getFieldWithDefault(msg, 4, ""),
-                exposedPortsList: jspb.Message.toObjectList(
-                    msg.getExposedPortsList(),
-                    proto.wsman.PortSpec.toObject,
-                    includeInstance,
-                ),
-                type: jspb.Message.getFieldWithDefault(msg, 6, 0),
-                timeout: jspb.Message.getFieldWithDefault(msg, 7, ""),
-                ideImage: (f = msg.getIdeImage()) && proto.wsman.IDEImage.toObject(includeInstance, f),
-                pb_class: jspb.Message.getFieldWithDefault(msg, 9, ""),
-                ideImageLayersList: (f = jspb.Message.getRepeatedField(msg, 10)) == null ? undefined : f,
-            };
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.WorkspaceStatus} returns this
+ */
+proto.wsman.WorkspaceStatus.prototype.clearSpec = function () {
+    return this.setSpec(undefined);
+};
 
-        if (includeInstance) {
-            obj.$jspbMessageInstance = msg;
-        }
-        return obj;
-    };
-}
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.WorkspaceStatus.prototype.hasSpec = function () {
+    return jspb.Message.getField(this, 3) != null;
+};
 
 /**
- * Deserializes binary data (in protobuf wire format).
- * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.wsman.WorkspaceSpec}
+ * optional WorkspacePhase phase = 4;
+ * @return {!proto.wsman.WorkspacePhase}
  */
-proto.wsman.WorkspaceSpec.deserializeBinary = function (bytes) {
-    var reader = new jspb.BinaryReader(bytes);
-    var msg = new proto.wsman.WorkspaceSpec();
-    return proto.wsman.WorkspaceSpec.deserializeBinaryFromReader(msg, reader);
+proto.wsman.WorkspaceStatus.prototype.getPhase = function () {
+    return /** @type {!proto.wsman.WorkspacePhase} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
 };
 
 /**
- * Deserializes binary data (in protobuf wire format) from the
- * given reader into the given message object.
- * @param {!proto.wsman.WorkspaceSpec} msg The message object to deserialize into.
- * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.wsman.WorkspaceSpec}
+ * @param {!proto.wsman.WorkspacePhase} value
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.WorkspaceSpec.deserializeBinaryFromReader = function (msg, reader) {
-    while (reader.nextField()) {
-        if (reader.isEndGroup()) {
-            break;
-        }
-        var field = reader.getFieldNumber();
-        switch (field) {
-            case 1:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setWorkspaceImage(value);
-                break;
-            case 2:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setDeprecatedIdeImage(value);
-                break;
-            case 3:
-                var value = /** @type {boolean} */ (reader.readBool());
-                msg.setHeadless(value);
-                break;
-            case 4:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setUrl(value);
-                break;
-            case 5:
-                var value = new proto.wsman.PortSpec();
-                reader.readMessage(value, proto.wsman.PortSpec.deserializeBinaryFromReader);
-                msg.addExposedPorts(value);
-                break;
-            case 6:
-                var value = /** @type {!proto.wsman.WorkspaceType} */ (reader.readEnum());
-                msg.setType(value);
-                break;
-            case 7:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setTimeout(value);
-                break;
-            case 8:
-                var value = new proto.wsman.IDEImage();
-                reader.readMessage(value, proto.wsman.IDEImage.deserializeBinaryFromReader);
-                msg.setIdeImage(value);
-                break;
-            case 9:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setClass(value);
-                break;
-            case 10:
-                var value = /** @type {string} */ (reader.readString());
-                msg.addIdeImageLayers(value);
-                break;
-            default:
-                reader.skipField();
-                break;
-        }
-    }
-    return msg;
+proto.wsman.WorkspaceStatus.prototype.setPhase = function (value) {
+    return jspb.Message.setProto3EnumField(this, 4, value);
 };
 
 /**
- * Serializes the message to binary data (in protobuf wire format).
- * @return {!Uint8Array}
+ * optional WorkspaceConditions conditions = 5;
+ * @return {?proto.wsman.WorkspaceConditions}
  */
-proto.wsman.WorkspaceSpec.prototype.serializeBinary = function () {
-    var writer = new jspb.BinaryWriter();
-    proto.wsman.WorkspaceSpec.serializeBinaryToWriter(this, writer);
-    return writer.getResultBuffer();
+proto.wsman.WorkspaceStatus.prototype.getConditions = function () {
+    return /** @type{?proto.wsman.WorkspaceConditions} */ (
+        jspb.Message.getWrapperField(this, proto.wsman.WorkspaceConditions, 5)
+    );
 };
 
 /**
- * Serializes the given message to binary data (in protobuf wire
- * format), writing to the given BinaryWriter.
- * @param {!proto.wsman.WorkspaceSpec} message
- * @param {!jspb.BinaryWriter} writer
- * @suppress {unusedLocalVariables} f is only used for nested messages
+ * @param {?proto.wsman.WorkspaceConditions|undefined} value
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.WorkspaceSpec.serializeBinaryToWriter = function (message, writer) {
-    var f = undefined;
-    f = message.getWorkspaceImage();
-    if (f.length > 0) {
-        writer.writeString(1, f);
-    }
-    f = message.getDeprecatedIdeImage();
-    if (f.length > 0) {
-        writer.writeString(2, f);
-    }
-    f = message.getHeadless();
-    if (f) {
-        writer.writeBool(3, f);
-    }
-    f = message.getUrl();
-    if (f.length > 0) {
-        writer.writeString(4, f);
-    }
-    f = message.getExposedPortsList();
-    if (f.length > 0) {
-        writer.writeRepeatedMessage(5, f, proto.wsman.PortSpec.serializeBinaryToWriter);
-    }
-    f = message.getType();
-    if (f !== 0.0) {
-        writer.writeEnum(6, f);
-    }
-    f = message.getTimeout();
-    if (f.length > 0) {
-        writer.writeString(7, f);
-    }
-    f = message.getIdeImage();
-    if (f != null) {
-        writer.writeMessage(8, f, proto.wsman.IDEImage.serializeBinaryToWriter);
-    }
-    f = message.getClass();
-    if (f.length > 0) {
-        writer.writeString(9, f);
-    }
-    f = message.getIdeImageLayersList();
-    if (f.length > 0) {
-        writer.writeRepeatedString(10, f);
-    }
+proto.wsman.WorkspaceStatus.prototype.setConditions = function (value) {
+    return jspb.Message.setWrapperField(this, 5, value);
 };
 
 /**
- * optional string workspace_image = 1;
- * @return {string}
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.WorkspaceSpec.prototype.getWorkspaceImage = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+proto.wsman.WorkspaceStatus.prototype.clearConditions = function () {
+    return this.setConditions(undefined);
 };
 
 /**
- * @param {string} value
- * @return {!proto.wsman.WorkspaceSpec} returns this
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.wsman.WorkspaceSpec.prototype.setWorkspaceImage = function (value) {
-    return jspb.Message.setProto3StringField(this, 1, value);
+proto.wsman.WorkspaceStatus.prototype.hasConditions = function () {
+    return jspb.Message.getField(this, 5) != null;
 };
 
 /**
- * optional string deprecated_ide_image = 2;
+ * optional string message = 6;
  * @return {string}
  */
-proto.wsman.WorkspaceSpec.prototype.getDeprecatedIdeImage = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+proto.wsman.WorkspaceStatus.prototype.getMessage = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 6, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.WorkspaceSpec} returns this
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.WorkspaceSpec.prototype.setDeprecatedIdeImage = function (value) {
-    return jspb.Message.setProto3StringField(this, 2, value);
+proto.wsman.WorkspaceStatus.prototype.setMessage = function (value) {
+    return jspb.Message.setProto3StringField(this, 6, value);
 };
 
 /**
- * optional bool headless = 3;
- * @return {boolean}
+ * optional contentservice.GitStatus repo = 7;
+ * @return {?proto.contentservice.GitStatus}
  */
-proto.wsman.WorkspaceSpec.prototype.getHeadless = function () {
-    return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 3, false));
+proto.wsman.WorkspaceStatus.prototype.getRepo = function () {
+    return /** @type{?proto.contentservice.GitStatus} */ (
+        jspb.Message.getWrapperField(this, content$service$api_initializer_pb.GitStatus, 7)
+    );
 };
 
 /**
- * @param {boolean} value
- * @return {!proto.wsman.WorkspaceSpec} returns this
+ * @param {?proto.contentservice.GitStatus|undefined} value
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.WorkspaceSpec.prototype.setHeadless = function (value) {
-    return jspb.Message.setProto3BooleanField(this, 3, value);
+proto.wsman.WorkspaceStatus.prototype.setRepo = function (value) {
+    return jspb.Message.setWrapperField(this, 7, value);
 };
 
 /**
- * optional string url = 4;
- * @return {string}
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.WorkspaceSpec.prototype.getUrl = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+proto.wsman.WorkspaceStatus.prototype.clearRepo = function () {
+    return this.setRepo(undefined);
 };
 
 /**
- * @param {string} value
- * @return {!proto.wsman.WorkspaceSpec} returns this
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.wsman.WorkspaceSpec.prototype.setUrl = function (value) {
-    return jspb.Message.setProto3StringField(this, 4, value);
+proto.wsman.WorkspaceStatus.prototype.hasRepo = function () {
+    return jspb.Message.getField(this, 7) != null;
 };
 
 /**
- * repeated PortSpec exposed_ports = 5;
- * @return {!Array<!proto.wsman.PortSpec>}
+ * optional WorkspaceRuntimeInfo runtime = 8;
+ * @return {?proto.wsman.WorkspaceRuntimeInfo}
  */
-proto.wsman.WorkspaceSpec.prototype.getExposedPortsList = function () {
-    return /** @type{!Array<!proto.wsman.PortSpec>} */ (
-        jspb.Message.getRepeatedWrapperField(this, proto.wsman.PortSpec, 5)
+proto.wsman.WorkspaceStatus.prototype.getRuntime = function () {
+    return /** @type{?proto.wsman.WorkspaceRuntimeInfo} */ (
+        jspb.Message.getWrapperField(this, proto.wsman.WorkspaceRuntimeInfo, 8)
     );
 };
 
 /**
- * @param {!Array<!proto.wsman.PortSpec>} value
- * @return {!proto.wsman.WorkspaceSpec} returns this
+ * @param {?proto.wsman.WorkspaceRuntimeInfo|undefined} value
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.WorkspaceSpec.prototype.setExposedPortsList = function (value) {
-    return jspb.Message.setRepeatedWrapperField(this, 5, value);
+proto.wsman.WorkspaceStatus.prototype.setRuntime = function (value) {
+    return jspb.Message.setWrapperField(this, 8, value);
 };
 
 /**
- * @param {!proto.wsman.PortSpec=} opt_value
- * @param {number=} opt_index
- * @return {!proto.wsman.PortSpec}
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.WorkspaceSpec.prototype.addExposedPorts = function (opt_value, opt_index) {
-    return jspb.Message.addToRepeatedWrapperField(this, 5, opt_value, proto.wsman.PortSpec, opt_index);
+proto.wsman.WorkspaceStatus.prototype.clearRuntime = function () {
+    return this.setRuntime(undefined);
 };
 
 /**
- * Clears the list making it empty but non-null.
- * @return {!proto.wsman.WorkspaceSpec} returns this
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.wsman.WorkspaceSpec.prototype.clearExposedPortsList = function () {
-    return this.setExposedPortsList([]);
+proto.wsman.WorkspaceStatus.prototype.hasRuntime = function () {
+    return jspb.Message.getField(this, 8) != null;
 };
 
 /**
- * optional WorkspaceType type = 6;
- * @return {!proto.wsman.WorkspaceType}
+ * optional WorkspaceAuthentication auth = 9;
+ * @return {?proto.wsman.WorkspaceAuthentication}
  */
-proto.wsman.WorkspaceSpec.prototype.getType = function () {
-    return /** @type {!proto.wsman.WorkspaceType} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+proto.wsman.WorkspaceStatus.prototype.getAuth = function () {
+    return /** @type{?proto.wsman.WorkspaceAuthentication} */ (
+        jspb.Message.getWrapperField(this, proto.wsman.WorkspaceAuthentication, 9)
+    );
 };
 
 /**
- * @param {!proto.wsman.WorkspaceType} value
- * @return {!proto.wsman.WorkspaceSpec} returns this
+ * @param {?proto.wsman.WorkspaceAuthentication|undefined} value
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.WorkspaceSpec.prototype.setType = function (value) {
-    return jspb.Message.setProto3EnumField(this, 6, value);
+proto.wsman.WorkspaceStatus.prototype.setAuth = function (value) {
+    return jspb.Message.setWrapperField(this, 9, value);
 };
 
 /**
- * optional string timeout = 7;
- * @return {string}
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.WorkspaceSpec.prototype.getTimeout = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 7, ""));
+proto.wsman.WorkspaceStatus.prototype.clearAuth = function () {
+    return this.setAuth(undefined);
 };
 
 /**
- * @param {string} value
- * @return {!proto.wsman.WorkspaceSpec} returns this
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.wsman.WorkspaceSpec.prototype.setTimeout = function (value) {
-    return jspb.Message.setProto3StringField(this, 7, value);
+proto.wsman.WorkspaceStatus.prototype.hasAuth = function () {
+    return jspb.Message.getField(this, 9) != null;
 };
 
 /**
- * optional IDEImage ide_image = 8;
- * @return {?proto.wsman.IDEImage}
+ * repeated SidecarStatus sidecars = 11;
+ * @return {!Array<!proto.wsman.SidecarStatus>}
  */
-proto.wsman.WorkspaceSpec.prototype.getIdeImage = function () {
-    return /** @type{?proto.wsman.IDEImage} */ (jspb.Message.getWrapperField(this, proto.wsman.IDEImage, 8));
+proto.wsman.WorkspaceStatus.prototype.getSidecarsList = function () {
+    return /** @type{!Array<!proto.wsman.SidecarStatus>} */ (
+        jspb.Message.getRepeatedWrapperField(this, proto.wsman.SidecarStatus, 11)
+    );
 };
 
 /**
- * @param {?proto.wsman.IDEImage|undefined} value
- * @return {!proto.wsman.WorkspaceSpec} returns this
+ * @param {!Array<!proto.wsman.SidecarStatus>} value
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.WorkspaceSpec.prototype.setIdeImage = function (value) {
-    return jspb.Message.setWrapperField(this, 8, value);
+proto.wsman.WorkspaceStatus.prototype.setSidecarsList = function (value) {
+    return jspb.Message.setRepeatedWrapperField(this, 11, value);
 };
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.wsman.WorkspaceSpec} returns this
+ * @param {!proto.wsman.SidecarStatus=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.SidecarStatus}
  */
-proto.wsman.WorkspaceSpec.prototype.clearIdeImage = function () {
-    return this.setIdeImage(undefined);
+proto.wsman.WorkspaceStatus.prototype.addSidecars = function (opt_value, opt_index) {
+    return jspb.Message.addToRepeatedWrapperField(this, 11, opt_value, proto.wsman.SidecarStatus, opt_index);
 };
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.WorkspaceStatus} returns this
  */
-proto.wsman.WorkspaceSpec.prototype.hasIdeImage = function () {
-    return jspb.Message.getField(this, 8) != null;
-};
-
-/**
- * optional string class = 9;
- * @return {string}
- */
-proto.wsman.WorkspaceSpec.prototype.getClass = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 9, ""));
-};
-
-/**
- * @param {string} value
- * @return {!proto.wsman.WorkspaceSpec} returns this
- */
-proto.wsman.WorkspaceSpec.prototype.setClass = function (value) {
-    return jspb.Message.setProto3StringField(this, 9, value);
-};
-
-/**
- * repeated string ide_image_layers = 10;
- * @return {!Array<string>}
- */
-proto.wsman.WorkspaceSpec.prototype.getIdeImageLayersList = function () {
-    return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 10));
-};
-
-/**
- * @param {!Array<string>} value
- * @return {!proto.wsman.WorkspaceSpec} returns this
- */
-proto.wsman.WorkspaceSpec.prototype.setIdeImageLayersList = function (value) {
-    return jspb.Message.setField(this, 10, value || []);
-};
-
-/**
- * @param {string} value
- * @param {number=} opt_index
- * @return {!proto.wsman.WorkspaceSpec} returns this
- */
-proto.wsman.WorkspaceSpec.prototype.addIdeImageLayers = function (value, opt_index) {
-    return jspb.Message.addToRepeatedField(this, 10, value, opt_index);
-};
-
-/**
- * Clears the list making it empty but non-null.
- * @return {!proto.wsman.WorkspaceSpec} returns this
- */
-proto.wsman.WorkspaceSpec.prototype.clearIdeImageLayersList = function () {
-    return this.setIdeImageLayersList([]);
+proto.wsman.WorkspaceStatus.prototype.clearSidecarsList = function () {
+    return this.setSidecarsList([]);
 };
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -5831,8 +5708,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      *     http://goto/soy-param-migration
      * @return {!Object}
      */
-    proto.wsman.PortSpec.prototype.toObject = function (opt_includeInstance) {
-        return proto.wsman.PortSpec.toObject(opt_includeInstance, this);
+    proto.wsman.SidecarStatus.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.SidecarStatus.toObject(opt_includeInstance, this);
     };
 
     /**
@@ -5840,16 +5717,18 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      * @param {boolean|undefined} includeInstance Deprecated. Whether to include
      *     the JSPB instance for transitional soy proto support:
      *     http://goto/soy-param-migration
-     * @param {!proto.wsman.PortSpec} msg The msg instance to transform.
+     * @param {!proto.wsman.SidecarStatus} msg The msg instance to transform.
      * @return {!Object}
      * @suppress {unusedLocalVariables} f is only used for nested messages
      */
-    proto.wsman.PortSpec.toObject = function (includeInstance, msg) {
+    proto.wsman.SidecarStatus.toObject = function (includeInstance, msg) {
         var f,
             obj = {
-                port: jspb.Message.getFieldWithDefault(msg, 1, 0),
-                visibility: jspb.Message.getFieldWithDefault(msg, 3, 0),
-                url: jspb.Message.getFieldWithDefault(msg, 4, ""),
+                name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+                state: jspb.Message.getFieldWithDefault(msg, 2, 0),
+                ready: jspb.Message.getBooleanFieldWithDefault(msg, 3, false),
+                message: jspb.Message.getFieldWithDefault(msg, 4, ""),
+                exitCode: jspb.Message.getFieldWithDefault(msg, 5, 0),
             };
 
         if (includeInstance) {
@@ -5862,22 +5741,22 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.wsman.PortSpec}
+ * @return {!proto.wsman.SidecarStatus}
  */
-proto.wsman.PortSpec.deserializeBinary = function (bytes) {
+proto.wsman.SidecarStatus.deserializeBinary = function (bytes) {
     var reader = new jspb.BinaryReader(bytes);
-    var msg = new proto.wsman.PortSpec();
-    return proto.wsman.PortSpec.deserializeBinaryFromReader(msg, reader);
+    var msg = new proto.wsman.SidecarStatus();
+    return proto.wsman.SidecarStatus.deserializeBinaryFromReader(msg, reader);
 };
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.wsman.PortSpec} msg The message object to deserialize into.
+ * @param {!proto.wsman.SidecarStatus} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.wsman.PortSpec}
+ * @return {!proto.wsman.SidecarStatus}
  */
-proto.wsman.PortSpec.deserializeBinaryFromReader = function (msg, reader) {
+proto.wsman.SidecarStatus.deserializeBinaryFromReader = function (msg, reader) {
     while (reader.nextField()) {
         if (reader.isEndGroup()) {
             break;
@@ -5885,16 +5764,24 @@ proto.wsman.PortSpec.deserializeBinaryFromReader = function (msg, reader) {
         var field = reader.getFieldNumber();
         switch (field) {
             case 1:
-                var value = /** @type {number} */ (reader.readUint32());
-                msg.setPort(value);
+                var value = /** @type {string} */ (reader.readString());
+                msg.setName(value);
+                break;
+            case 2:
+                var value = /** @type {!proto.wsman.SidecarState} */ (reader.readEnum());
+                msg.setState(value);
                 break;
             case 3:
-                var value = /** @type {!proto.wsman.PortVisibility} */ (reader.readEnum());
-                msg.setVisibility(value);
+                var value = /** @type {boolean} */ (reader.readBool());
+                msg.setReady(value);
                 break;
             case 4:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setUrl(value);
+                msg.setMessage(value);
+                break;
+            case 5:
+                var value = /** @type {number} */ (reader.readInt32());
+                msg.setExitCode(value);
                 break;
             default:
                 reader.skipField();
@@ -5908,83 +5795,123 @@ proto.wsman.PortSpec.deserializeBinaryFromReader = function (msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.wsman.PortSpec.prototype.serializeBinary = function () {
+proto.wsman.SidecarStatus.prototype.serializeBinary = function () {
     var writer = new jspb.BinaryWriter();
-    proto.wsman.PortSpec.serializeBinaryToWriter(this, writer);
+    proto.wsman.SidecarStatus.serializeBinaryToWriter(this, writer);
     return writer.getResultBuffer();
 };
 
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.wsman.PortSpec} message
+ * @param {!proto.wsman.SidecarStatus} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.wsman.PortSpec.serializeBinaryToWriter = function (message, writer) {
+proto.wsman.SidecarStatus.serializeBinaryToWriter = function (message, writer) {
     var f = undefined;
-    f = message.getPort();
-    if (f !== 0) {
-        writer.writeUint32(1, f);
+    f = message.getName();
+    if (f.length > 0) {
+        writer.writeString(1, f);
     }
-    f = message.getVisibility();
+    f = message.getState();
     if (f !== 0.0) {
-        writer.writeEnum(3, f);
+        writer.writeEnum(2, f);
     }
-    f = message.getUrl();
+    f = message.getReady();
+    if (f) {
+        writer.writeBool(3, f);
+    }
+    f = message.getMessage();
     if (f.length > 0) {
         writer.writeString(4, f);
     }
+    f = message.getExitCode();
+    if (f !== 0) {
+        writer.writeInt32(5, f);
+    }
 };
 
 /**
- * optional uint32 port = 1;
- * @return {number}
+ * optional string name = 1;
+ * @return {string}
  */
-proto.wsman.PortSpec.prototype.getPort = function () {
-    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+proto.wsman.SidecarStatus.prototype.getName = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 /**
- * @param {number} value
- * @return {!proto.wsman.PortSpec} returns this
+ * @param {string} value
+ * @return {!proto.wsman.SidecarStatus} returns this
  */
-proto.wsman.PortSpec.prototype.setPort = function (value) {
-    return jspb.Message.setProto3IntField(this, 1, value);
+proto.wsman.SidecarStatus.prototype.setName = function (value) {
+    return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 /**
- * optional PortVisibility visibility = 3;
- * @return {!proto.wsman.PortVisibility}
+ * optional SidecarState state = 2;
+ * @return {!proto.wsman.SidecarState}
  */
-proto.wsman.PortSpec.prototype.getVisibility = function () {
-    return /** @type {!proto.wsman.PortVisibility} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+proto.wsman.SidecarStatus.prototype.getState = function () {
+    return /** @type {!proto.wsman.SidecarState} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
 };
 
 /**
- * @param {!proto.wsman.PortVisibility} value
- * @return {!proto.wsman.PortSpec} returns this
+ * @param {!proto.wsman.SidecarState} value
+ * @return {!proto.wsman.SidecarStatus} returns this
  */
-proto.wsman.PortSpec.prototype.setVisibility = function (value) {
-    return jspb.Message.setProto3EnumField(this, 3, value);
+proto.wsman.SidecarStatus.prototype.setState = function (value) {
+    return jspb.Message.setProto3EnumField(this, 2, value);
 };
 
 /**
- * optional string url = 4;
+ * optional bool ready = 3;
+ * @return {boolean}
+ */
+proto.wsman.SidecarStatus.prototype.getReady = function () {
+    return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 3, false));
+};
+
+/**
+ * @param {boolean} value
+ * @return {!proto.wsman.SidecarStatus} returns this
+ */
+proto.wsman.SidecarStatus.prototype.setReady = function (value) {
+    return jspb.Message.setProto3BooleanField(this, 3, value);
+};
+
+/**
+ * optional string message = 4;
  * @return {string}
  */
-proto.wsman.PortSpec.prototype.getUrl = function () {
+proto.wsman.SidecarStatus.prototype.getMessage = function () {
     return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.PortSpec} returns this
+ * @return {!proto.wsman.SidecarStatus} returns this
  */
-proto.wsman.PortSpec.prototype.setUrl = function (value) {
+proto.wsman.SidecarStatus.prototype.setMessage = function (value) {
     return jspb.Message.setProto3StringField(this, 4, value);
 };
 
+/**
+ * optional int32 exit_code = 5;
+ * @return {number}
+ */
+proto.wsman.SidecarStatus.prototype.getExitCode = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.wsman.SidecarStatus} returns this
+ */
+proto.wsman.SidecarStatus.prototype.setExitCode = function (value) {
+    return jspb.Message.setProto3IntField(this, 5, value);
+};
+
 if (jspb.Message.GENERATE_TO_OBJECT) {
     /**
      * Creates an object representation of this proto.
@@ -5998,8 +5925,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      *     http://goto/soy-param-migration
      * @return {!Object}
      */
-    proto.wsman.VolumeSnapshotInfo.prototype.toObject = function (opt_includeInstance) {
-        return proto.wsman.VolumeSnapshotInfo.toObject(opt_includeInstance, this);
+    proto.wsman.IDEImage.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.IDEImage.toObject(opt_includeInstance, this);
     };
 
     /**
@@ -6007,15 +5934,17 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      * @param {boolean|undefined} includeInstance Deprecated. Whether to include
      *     the JSPB instance for transitional soy proto support:
      *     http://goto/soy-param-migration
-     * @param {!proto.wsman.VolumeSnapshotInfo} msg The msg instance to transform.
+     * @param {!proto.wsman.IDEImage} msg The msg instance to transform.
      * @return {!Object}
      * @suppress {unusedLocalVariables} f is only used for nested messages
      */
-    proto.wsman.VolumeSnapshotInfo.toObject = function (includeInstance, msg) {
+    proto.wsman.IDEImage.toObject = function (includeInstance, msg) {
         var f,
             obj = {
-                volumeSnapshotName: jspb.Message.getFieldWithDefault(msg, 1, ""),
-                volumeSnapshotHandle: jspb.Message.getFieldWithDefault(msg, 2, ""),
+                webRef: jspb.Message.getFieldWithDefault(msg, 1, ""),
+                desktopRef: jspb.Message.getFieldWithDefault(msg, 2, ""),
+                supervisorRef: jspb.Message.getFieldWithDefault(msg, 3, ""),
+                desktopPluginRef: jspb.Message.getFieldWithDefault(msg, 4, ""),
             };
 
         if (includeInstance) {
@@ -6028,22 +5957,22 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.wsman.VolumeSnapshotInfo}
+ * @return {!proto.wsman.IDEImage}
  */
-proto.wsman.VolumeSnapshotInfo.deserializeBinary = function (bytes) {
+proto.wsman.IDEImage.deserializeBinary = function (bytes) {
     var reader = new jspb.BinaryReader(bytes);
-    var msg = new proto.wsman.VolumeSnapshotInfo();
-    return proto.wsman.VolumeSnapshotInfo.deserializeBinaryFromReader(msg, reader);
+    var msg = new proto.wsman.IDEImage();
+    return proto.wsman.IDEImage.deserializeBinaryFromReader(msg, reader);
 };
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.wsman.VolumeSnapshotInfo} msg The message object to deserialize into.
+ * @param {!proto.wsman.IDEImage} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.wsman.VolumeSnapshotInfo}
+ * @return {!proto.wsman.IDEImage}
  */
-proto.wsman.VolumeSnapshotInfo.deserializeBinaryFromReader = function (msg, reader) {
+proto.wsman.IDEImage.deserializeBinaryFromReader = function (msg, reader) {
     while (reader.nextField()) {
         if (reader.isEndGroup()) {
             break;
@@ -6052,11 +5981,19 @@ proto.wsman.VolumeSnapshotInfo.deserializeBinaryFromReader = function (msg, read
         switch (field) {
             case 1:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setVolumeSnapshotName(value);
+                msg.setWebRef(value);
                 break;
             case 2:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setVolumeSnapshotHandle(value);
+                msg.setDesktopRef(value);
+                break;
+            case 3:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setSupervisorRef(value);
+                break;
+            case 4:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setDesktopPluginRef(value);
                 break;
             default:
                 reader.skipField();
@@ -6070,63 +6007,110 @@ proto.wsman.VolumeSnapshotInfo.deserializeBinaryFromReader = function (msg, read
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.wsman.VolumeSnapshotInfo.prototype.serializeBinary = function () {
+proto.wsman.IDEImage.prototype.serializeBinary = function () {
     var writer = new jspb.BinaryWriter();
-    proto.wsman.VolumeSnapshotInfo.serializeBinaryToWriter(this, writer);
+    proto.wsman.IDEImage.serializeBinaryToWriter(this, writer);
     return writer.getResultBuffer();
 };
 
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.wsman.VolumeSnapshotInfo} message
+ * @param {!proto.wsman.IDEImage} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.wsman.VolumeSnapshotInfo.serializeBinaryToWriter = function (message, writer) {
+proto.wsman.IDEImage.serializeBinaryToWriter = function (message, writer) {
     var f = undefined;
-    f = message.getVolumeSnapshotName();
+    f = message.getWebRef();
     if (f.length > 0) {
         writer.writeString(1, f);
     }
-    f = message.getVolumeSnapshotHandle();
+    f = message.getDesktopRef();
     if (f.length > 0) {
         writer.writeString(2, f);
     }
+    f = message.getSupervisorRef();
+    if (f.length > 0) {
+        writer.writeString(3, f);
+    }
+    f = message.getDesktopPluginRef();
+    if (f.length > 0) {
+        writer.writeString(4, f);
+    }
 };
 
 /**
- * optional string volume_snapshot_name = 1;
+ * optional string web_ref = 1;
  * @return {string}
  */
-proto.wsman.VolumeSnapshotInfo.prototype.getVolumeSnapshotName = function () {
+proto.wsman.IDEImage.prototype.getWebRef = function () {
     return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.VolumeSnapshotInfo} returns this
+ * @return {!proto.wsman.IDEImage} returns this
  */
-proto.wsman.VolumeSnapshotInfo.prototype.setVolumeSnapshotName = function (value) {
+proto.wsman.IDEImage.prototype.setWebRef = function (value) {
     return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 /**
- * optional string volume_snapshot_handle = 2;
+ * optional string desktop_ref = 2;
  * @return {string}
  */
-proto.wsman.VolumeSnapshotInfo.prototype.getVolumeSnapshotHandle = function () {
+proto.wsman.IDEImage.prototype.getDesktopRef = function () {
     return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.VolumeSnapshotInfo} returns this
+ * @return {!proto.wsman.IDEImage} returns this
  */
-proto.wsman.VolumeSnapshotInfo.prototype.setVolumeSnapshotHandle = function (value) {
+proto.wsman.IDEImage.prototype.setDesktopRef = function (value) {
     return jspb.Message.setProto3StringField(this, 2, value);
 };
 
+/**
+ * optional string supervisor_ref = 3;
+ * @return {string}
+ */
+proto.wsman.IDEImage.prototype.getSupervisorRef = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.IDEImage} returns this
+ */
+proto.wsman.IDEImage.prototype.setSupervisorRef = function (value) {
+    return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+/**
+ * optional string desktop_plugin_ref = 4;
+ * @return {string}
+ */
+proto.wsman.IDEImage.prototype.getDesktopPluginRef = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.IDEImage} returns this
+ */
+proto.wsman.IDEImage.prototype.setDesktopPluginRef = function (value) {
+    return jspb.Message.setProto3StringField(this, 4, value);
+};
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.wsman.WorkspaceSpec.repeatedFields_ = [5, 10];
+
 if (jspb.Message.GENERATE_TO_OBJECT) {
     /**
      * Creates an object representation of this proto.
@@ -6140,8 +6124,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      *     http://goto/soy-param-migration
      * @return {!Object}
      */
-    proto.wsman.WorkspaceConditions.prototype.toObject = function (opt_includeInstance) {
-        return proto.wsman.WorkspaceConditions.toObject(opt_includeInstance, this);
+    proto.wsman.WorkspaceSpec.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.WorkspaceSpec.toObject(opt_includeInstance, this);
     };
 
     /**
@@ -6149,28 +6133,27 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      * @param {boolean|undefined} includeInstance Deprecated. Whether to include
      *     the JSPB instance for transitional soy proto support:
      *     http://goto/soy-param-migration
-     * @param {!proto.wsman.WorkspaceConditions} msg The msg instance to transform.
+     * @param {!proto.wsman.WorkspaceSpec} msg The msg instance to transform.
      * @return {!Object}
      * @suppress {unusedLocalVariables} f is only used for nested messages
      */
-    proto.wsman.WorkspaceConditions.toObject = function (includeInstance, msg) {
+    proto.wsman.WorkspaceSpec.toObject = function (includeInstance, msg) {
         var f,
             obj = {
-                failed: jspb.Message.getFieldWithDefault(msg, 1, ""),
-                timeout: jspb.Message.getFieldWithDefault(msg, 2, ""),
-                pullingImages: jspb.Message.getFieldWithDefault(msg, 3, 0),
-                snapshot: jspb.Message.getFieldWithDefault(msg, 5, ""),
-                finalBackupComplete: jspb.Message.getFieldWithDefault(msg, 6, 0),
-                deployed: jspb.Message.getFieldWithDefault(msg, 7, 0),
-                networkNotReady: jspb.Message.getFieldWithDefault(msg, 8, 0),
-                firstUserActivity:
-                    (f = msg.getFirstUserActivity()) &&
-                    google_protobuf_timestamp_pb.Timestamp.toObject(includeInstance, f),
-                headlessTaskFailed: jspb.Message.getFieldWithDefault(msg, 10, ""),
-                stoppedByRequest: jspb.Message.getFieldWithDefault(msg, 11, 0),
-                volumeSnapshot:
-                    (f = msg.getVolumeSnapshot()) && proto.wsman.VolumeSnapshotInfo.toObject(includeInstance, f),
-                aborted: jspb.Message.getFieldWithDefault(msg, 13, 0),
+                workspaceImage: jspb.Message.getFieldWithDefault(msg, 1, ""),
+                deprecatedIdeImage: jspb.Message.getFieldWithDefault(msg, 2, ""),
+                headless: jspb.Message.getBooleanFieldWithDefault(msg, 3, false),
+                url: jspb.Message.getFieldWithDefault(msg, 4, ""),
+                exposedPortsList: jspb.Message.toObjectList(
+                    msg.getExposedPortsList(),
+                    proto.wsman.PortSpec.toObject,
+                    includeInstance,
+                ),
+                type: jspb.Message.getFieldWithDefault(msg, 6, 0),
+                timeout: jspb.Message.getFieldWithDefault(msg, 7, ""),
+                ideImage: (f = msg.getIdeImage()) && proto.wsman.IDEImage.toObject(includeInstance, f),
+                pb_class: jspb.Message.getFieldWithDefault(msg, 9, ""),
+                ideImageLayersList: (f = jspb.Message.getRepeatedField(msg, 10)) == null ? undefined : f,
             };
 
         if (includeInstance) {
@@ -6183,22 +6166,22 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.wsman.WorkspaceConditions}
+ * @return {!proto.wsman.WorkspaceSpec}
  */
-proto.wsman.WorkspaceConditions.deserializeBinary = function (bytes) {
+proto.wsman.WorkspaceSpec.deserializeBinary = function (bytes) {
     var reader = new jspb.BinaryReader(bytes);
-    var msg = new proto.wsman.WorkspaceConditions();
-    return proto.wsman.WorkspaceConditions.deserializeBinaryFromReader(msg, reader);
+    var msg = new proto.wsman.WorkspaceSpec();
+    return proto.wsman.WorkspaceSpec.deserializeBinaryFromReader(msg, reader);
 };
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.wsman.WorkspaceConditions} msg The message object to deserialize into.
+ * @param {!proto.wsman.WorkspaceSpec} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.wsman.WorkspaceConditions}
+ * @return {!proto.wsman.WorkspaceSpec}
  */
-proto.wsman.WorkspaceConditions.deserializeBinaryFromReader = function (msg, reader) {
+proto.wsman.WorkspaceSpec.deserializeBinaryFromReader = function (msg, reader) {
     while (reader.nextField()) {
         if (reader.isEndGroup()) {
             break;
@@ -6207,53 +6190,45 @@ proto.wsman.WorkspaceConditions.deserializeBinaryFromReader = function (msg, rea
         switch (field) {
             case 1:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setFailed(value);
+                msg.setWorkspaceImage(value);
                 break;
             case 2:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setTimeout(value);
+                msg.setDeprecatedIdeImage(value);
                 break;
             case 3:
-                var value = /** @type {!proto.wsman.WorkspaceConditionBool} */ (reader.readEnum());
-                msg.setPullingImages(value);
+                var value = /** @type {boolean} */ (reader.readBool());
+                msg.setHeadless(value);
                 break;
-            case 5:
+            case 4:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setSnapshot(value);
+                msg.setUrl(value);
+                break;
+            case 5:
+                var value = new proto.wsman.PortSpec();
+                reader.readMessage(value, proto.wsman.PortSpec.deserializeBinaryFromReader);
+                msg.addExposedPorts(value);
                 break;
             case 6:
-                var value = /** @type {!proto.wsman.WorkspaceConditionBool} */ (reader.readEnum());
-                msg.setFinalBackupComplete(value);
+                var value = /** @type {!proto.wsman.WorkspaceType} */ (reader.readEnum());
+                msg.setType(value);
                 break;
             case 7:
-                var value = /** @type {!proto.wsman.WorkspaceConditionBool} */ (reader.readEnum());
-                msg.setDeployed(value);
+                var value = /** @type {string} */ (reader.readString());
+                msg.setTimeout(value);
                 break;
             case 8:
-                var value = /** @type {!proto.wsman.WorkspaceConditionBool} */ (reader.readEnum());
-                msg.setNetworkNotReady(value);
+                var value = new proto.wsman.IDEImage();
+                reader.readMessage(value, proto.wsman.IDEImage.deserializeBinaryFromReader);
+                msg.setIdeImage(value);
                 break;
             case 9:
-                var value = new google_protobuf_timestamp_pb.Timestamp();
-                reader.readMessage(value, google_protobuf_timestamp_pb.Timestamp.deserializeBinaryFromReader);
-                msg.setFirstUserActivity(value);
+                var value = /** @type {string} */ (reader.readString());
+                msg.setClass(value);
                 break;
             case 10:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setHeadlessTaskFailed(value);
-                break;
-            case 11:
-                var value = /** @type {!proto.wsman.WorkspaceConditionBool} */ (reader.readEnum());
-                msg.setStoppedByRequest(value);
-                break;
-            case 12:
-                var value = new proto.wsman.VolumeSnapshotInfo();
-                reader.readMessage(value, proto.wsman.VolumeSnapshotInfo.deserializeBinaryFromReader);
-                msg.setVolumeSnapshot(value);
-                break;
-            case 13:
-                var value = /** @type {!proto.wsman.WorkspaceConditionBool} */ (reader.readEnum());
-                msg.setAborted(value);
+                msg.addIdeImageLayers(value);
                 break;
             default:
                 reader.skipField();
@@ -6267,297 +6242,273 @@ proto.wsman.WorkspaceConditions.deserializeBinaryFromReader = function (msg, rea
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.wsman.WorkspaceConditions.prototype.serializeBinary = function () {
+proto.wsman.WorkspaceSpec.prototype.serializeBinary = function () {
     var writer = new jspb.BinaryWriter();
-    proto.wsman.WorkspaceConditions.serializeBinaryToWriter(this, writer);
+    proto.wsman.WorkspaceSpec.serializeBinaryToWriter(this, writer);
     return writer.getResultBuffer();
 };
 
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.wsman.WorkspaceConditions} message
+ * @param {!proto.wsman.WorkspaceSpec} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.wsman.WorkspaceConditions.serializeBinaryToWriter = function (message, writer) {
+proto.wsman.WorkspaceSpec.serializeBinaryToWriter = function (message, writer) {
     var f = undefined;
-    f = message.getFailed();
+    f = message.getWorkspaceImage();
     if (f.length > 0) {
         writer.writeString(1, f);
     }
-    f = message.getTimeout();
+    f = message.getDeprecatedIdeImage();
     if (f.length > 0) {
         writer.writeString(2, f);
     }
-    f = message.getPullingImages();
-    if (f !== 0.0) {
-        writer.writeEnum(3, f);
+    f = message.getHeadless();
+    if (f) {
+        writer.writeBool(3, f);
     }
-    f = message.getSnapshot();
+    f = message.getUrl();
     if (f.length > 0) {
-        writer.writeString(5, f);
+        writer.writeString(4, f);
     }
-    f = message.getFinalBackupComplete();
-    if (f !== 0.0) {
-        writer.writeEnum(6, f);
+    f = message.getExposedPortsList();
+    if (f.length > 0) {
+        writer.writeRepeatedMessage(5, f, proto.wsman.PortSpec.serializeBinaryToWriter);
     }
-    f = message.getDeployed();
+    f = message.getType();
     if (f !== 0.0) {
-        writer.writeEnum(7, f);
+        writer.writeEnum(6, f);
     }
-    f = message.getNetworkNotReady();
-    if (f !== 0.0) {
-        writer.writeEnum(8, f);
+    f = message.getTimeout();
+    if (f.length > 0) {
+        writer.writeString(7, f);
     }
-    f = message.getFirstUserActivity();
+    f = message.getIdeImage();
     if (f != null) {
-        writer.writeMessage(9, f, google_protobuf_timestamp_pb.Timestamp.serializeBinaryToWriter);
+        writer.writeMessage(8, f, proto.wsman.IDEImage.serializeBinaryToWriter);
     }
-    f = message.getHeadlessTaskFailed();
+    f = message.getClass();
     if (f.length > 0) {
-        writer.writeString(10, f);
-    }
-    f = message.getStoppedByRequest();
-    if (f !== 0.0) {
-        writer.writeEnum(11, f);
-    }
-    f = message.getVolumeSnapshot();
-    if (f != null) {
-        writer.writeMessage(12, f, proto.wsman.VolumeSnapshotInfo.serializeBinaryToWriter);
+        writer.writeString(9, f);
     }
-    f = message.getAborted();
-    if (f !== 0.0) {
-        writer.writeEnum(13, f);
+    f = message.getIdeImageLayersList();
+    if (f.length > 0) {
+        writer.writeRepeatedString(10, f);
     }
 };
 
 /**
- * optional string failed = 1;
+ * optional string workspace_image = 1;
  * @return {string}
  */
-proto.wsman.WorkspaceConditions.prototype.getFailed = function () {
+proto.wsman.WorkspaceSpec.prototype.getWorkspaceImage = function () {
     return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.WorkspaceConditions} returns this
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.setFailed = function (value) {
+proto.wsman.WorkspaceSpec.prototype.setWorkspaceImage = function (value) {
     return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 /**
- * optional string timeout = 2;
+ * optional string deprecated_ide_image = 2;
  * @return {string}
  */
-proto.wsman.WorkspaceConditions.prototype.getTimeout = function () {
+proto.wsman.WorkspaceSpec.prototype.getDeprecatedIdeImage = function () {
     return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.WorkspaceConditions} returns this
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.setTimeout = function (value) {
+proto.wsman.WorkspaceSpec.prototype.setDeprecatedIdeImage = function (value) {
     return jspb.Message.setProto3StringField(this, 2, value);
 };
 
 /**
- * optional WorkspaceConditionBool pulling_images = 3;
- * @return {!proto.wsman.WorkspaceConditionBool}
+ * optional bool headless = 3;
+ * @return {boolean}
  */
-proto.wsman.WorkspaceConditions.prototype.getPullingImages = function () {
-    return /** @type {!proto.wsman.WorkspaceConditionBool} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+proto.wsman.WorkspaceSpec.prototype.getHeadless = function () {
+    return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 3, false));
 };
 
 /**
- * @param {!proto.wsman.WorkspaceConditionBool} value
- * @return {!proto.wsman.WorkspaceConditions} returns this
+ * @param {boolean} value
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.setPullingImages = function (value) {
-    return jspb.Message.setProto3EnumField(this, 3, value);
+proto.wsman.WorkspaceSpec.prototype.setHeadless = function (value) {
+    return jspb.Message.setProto3BooleanField(this, 3, value);
 };
 
 /**
- * optional string snapshot = 5;
+ * optional string url = 4;
  * @return {string}
  */
-proto.wsman.WorkspaceConditions.prototype.getSnapshot = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+proto.wsman.WorkspaceSpec.prototype.getUrl = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.WorkspaceConditions} returns this
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.setSnapshot = function (value) {
-    return jspb.Message.setProto3StringField(this, 5, value);
+proto.wsman.WorkspaceSpec.prototype.setUrl = function (value) {
+    return jspb.Message.setProto3StringField(this, 4, value);
 };
 
 /**
- * optional WorkspaceConditionBool final_backup_complete = 6;
- * @return {!proto.wsman.WorkspaceConditionBool}
+ * repeated PortSpec exposed_ports = 5;
+ * @return {!Array<!proto.wsman.PortSpec>}
  */
-proto.wsman.WorkspaceConditions.prototype.getFinalBackupComplete = function () {
-    return /** @type {!proto.wsman.WorkspaceConditionBool} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+proto.wsman.WorkspaceSpec.prototype.getExposedPortsList = function () {
+    return /** @type{!Array<!proto.wsman.PortSpec>} */ (
+        jspb.Message.getRepeatedWrapperField(this, proto.wsman.PortSpec, 5)
+    );
 };
 
 /**
- * @param {!proto.wsman.WorkspaceConditionBool} value
- * @return {!proto.wsman.WorkspaceConditions} returns this
+ * @param {!Array<!proto.wsman.PortSpec>} value
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.setFinalBackupComplete = function (value) {
-    return jspb.Message.setProto3EnumField(this, 6, value);
+proto.wsman.WorkspaceSpec.prototype.setExposedPortsList = function (value) {
+    return jspb.Message.setRepeatedWrapperField(this, 5, value);
 };
 
 /**
- * optional WorkspaceConditionBool deployed = 7;
- * @return {!proto.wsman.WorkspaceConditionBool}
+ * @param {!proto.wsman.PortSpec=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.PortSpec}
  */
-proto.wsman.WorkspaceConditions.prototype.getDeployed = function () {
-    return /** @type {!proto.wsman.WorkspaceConditionBool} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
+proto.wsman.WorkspaceSpec.prototype.addExposedPorts = function (opt_value, opt_index) {
+    return jspb.Message.addToRepeatedWrapperField(this, 5, opt_value, proto.wsman.PortSpec, opt_index);
 };
 
 /**
- * @param {!proto.wsman.WorkspaceConditionBool} value
- * @return {!proto.wsman.WorkspaceConditions} returns this
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.setDeployed = function (value) {
-    return jspb.Message.setProto3EnumField(this, 7, value);
+proto.wsman.WorkspaceSpec.prototype.clearExposedPortsList = function () {
+    return this.setExposedPortsList([]);
 };
 
 /**
- * optional WorkspaceConditionBool network_not_ready = 8;
- * @return {!proto.wsman.WorkspaceConditionBool}
+ * optional WorkspaceType type = 6;
+ * @return {!proto.wsman.WorkspaceType}
  */
-proto.wsman.WorkspaceConditions.prototype.getNetworkNotReady = function () {
-    return /** @type {!proto.wsman.WorkspaceConditionBool} */ (jspb.Message.getFieldWithDefault(this, 8, 0));
+proto.wsman.WorkspaceSpec.prototype.getType = function () {
+    return /** @type {!proto.wsman.WorkspaceType} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
 };
 
 /**
- * @param {!proto.wsman.WorkspaceConditionBool} value
- * @return {!proto.wsman.WorkspaceConditions} returns this
+ * @param {!proto.wsman.WorkspaceType} value
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.setNetworkNotReady = function (value) {
-    return jspb.Message.setProto3EnumField(this, 8, value);
+proto.wsman.WorkspaceSpec.prototype.setType = function (value) {
+    return jspb.Message.setProto3EnumField(this, 6, value);
 };
 
 /**
- * optional google.protobuf.Timestamp first_user_activity = 9;
- * @return {?proto.google.protobuf.Timestamp}
- */
-proto.wsman.WorkspaceConditions.prototype.getFirstUserActivity = function () {
-    return /** @type{?proto.google.protobuf.Timestamp} */ (
-        jspb.Message.getWrapperField(this, google_protobuf_timestamp_pb.Timestamp, 9)
-    );
-};
-
-/**
- * @param {?proto.google.protobuf.Timestamp|undefined} value
- * @return {!proto.wsman.WorkspaceConditions} returns this
- */
-proto.wsman.WorkspaceConditions.prototype.setFirstUserActivity = function (value) {
-    return jspb.Message.setWrapperField(this, 9, value);
-};
-
-/**
- * Clears the message field making it undefined.
- * @return {!proto.wsman.WorkspaceConditions} returns this
+ * optional string timeout = 7;
+ * @return {string}
  */
-proto.wsman.WorkspaceConditions.prototype.clearFirstUserActivity = function () {
-    return this.setFirstUserActivity(undefined);
+proto.wsman.WorkspaceSpec.prototype.getTimeout = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 7, ""));
 };
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.hasFirstUserActivity = function () {
-    return jspb.Message.getField(this, 9) != null;
+proto.wsman.WorkspaceSpec.prototype.setTimeout = function (value) {
+    return jspb.Message.setProto3StringField(this, 7, value);
 };
 
 /**
- * optional string headless_task_failed = 10;
- * @return {string}
+ * optional IDEImage ide_image = 8;
+ * @return {?proto.wsman.IDEImage}
  */
-proto.wsman.WorkspaceConditions.prototype.getHeadlessTaskFailed = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 10, ""));
+proto.wsman.WorkspaceSpec.prototype.getIdeImage = function () {
+    return /** @type{?proto.wsman.IDEImage} */ (jspb.Message.getWrapperField(this, proto.wsman.IDEImage, 8));
 };
 
 /**
- * @param {string} value
- * @return {!proto.wsman.WorkspaceConditions} returns this
+ * @param {?proto.wsman.IDEImage|undefined} value
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.setHeadlessTaskFailed = function (value) {
-    return jspb.Message.setProto3StringField(this, 10, value);
+proto.wsman.WorkspaceSpec.prototype.setIdeImage = function (value) {
+    return jspb.Message.setWrapperField(this, 8, value);
 };
 
 /**
- * optional WorkspaceConditionBool stopped_by_request = 11;
- * @return {!proto.wsman.WorkspaceConditionBool}
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.getStoppedByRequest = function () {
-    return /** @type {!proto.wsman.WorkspaceConditionBool} */ (jspb.Message.getFieldWithDefault(this, 11, 0));
+proto.wsman.WorkspaceSpec.prototype.clearIdeImage = function () {
+    return this.setIdeImage(undefined);
 };
 
 /**
- * @param {!proto.wsman.WorkspaceConditionBool} value
- * @return {!proto.wsman.WorkspaceConditions} returns this
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.wsman.WorkspaceConditions.prototype.setStoppedByRequest = function (value) {
-    return jspb.Message.setProto3EnumField(this, 11, value);
+proto.wsman.WorkspaceSpec.prototype.hasIdeImage = function () {
+    return jspb.Message.getField(this, 8) != null;
 };
 
 /**
- * optional VolumeSnapshotInfo volume_snapshot = 12;
- * @return {?proto.wsman.VolumeSnapshotInfo}
+ * optional string class = 9;
+ * @return {string}
  */
-proto.wsman.WorkspaceConditions.prototype.getVolumeSnapshot = function () {
-    return /** @type{?proto.wsman.VolumeSnapshotInfo} */ (
-        jspb.Message.getWrapperField(this, proto.wsman.VolumeSnapshotInfo, 12)
-    );
+proto.wsman.WorkspaceSpec.prototype.getClass = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 9, ""));
 };
 
 /**
- * @param {?proto.wsman.VolumeSnapshotInfo|undefined} value
- * @return {!proto.wsman.WorkspaceConditions} returns this
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.setVolumeSnapshot = function (value) {
-    return jspb.Message.setWrapperField(this, 12, value);
+proto.wsman.WorkspaceSpec.prototype.setClass = function (value) {
+    return jspb.Message.setProto3StringField(this, 9, value);
 };
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.wsman.WorkspaceConditions} returns this
+ * repeated string ide_image_layers = 10;
+ * @return {!Array<string>}
  */
-proto.wsman.WorkspaceConditions.prototype.clearVolumeSnapshot = function () {
-    return this.setVolumeSnapshot(undefined);
+proto.wsman.WorkspaceSpec.prototype.getIdeImageLayersList = function () {
+    return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 10));
 };
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * @param {!Array<string>} value
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.hasVolumeSnapshot = function () {
-    return jspb.Message.getField(this, 12) != null;
+proto.wsman.WorkspaceSpec.prototype.setIdeImageLayersList = function (value) {
+    return jspb.Message.setField(this, 10, value || []);
 };
 
 /**
- * optional WorkspaceConditionBool aborted = 13;
- * @return {!proto.wsman.WorkspaceConditionBool}
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.getAborted = function () {
-    return /** @type {!proto.wsman.WorkspaceConditionBool} */ (jspb.Message.getFieldWithDefault(this, 13, 0));
+proto.wsman.WorkspaceSpec.prototype.addIdeImageLayers = function (value, opt_index) {
+    return jspb.Message.addToRepeatedField(this, 10, value, opt_index);
 };
 
 /**
- * @param {!proto.wsman.WorkspaceConditionBool} value
- * @return {!proto.wsman.WorkspaceConditions} returns this
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.WorkspaceSpec} returns this
  */
-proto.wsman.WorkspaceConditions.prototype.setAborted = function (value) {
-    return jspb.Message.setProto3EnumField(this, 13, value);
+proto.wsman.WorkspaceSpec.prototype.clearIdeImageLayersList = function () {
+    return this.setIdeImageLayersList([]);
 };
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -6573,8 +6524,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      *     http://goto/soy-param-migration
      * @return {!Object}
      */
-    proto.wsman.WorkspaceMetadata.prototype.toObject = function (opt_includeInstance) {
-        return proto.wsman.WorkspaceMetadata.toObject(opt_includeInstance, this);
+    proto.wsman.PortSpec.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.PortSpec.toObject(opt_includeInstance, this);
     };
 
     /**
@@ -6582,20 +6533,16 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      * @param {boolean|undefined} includeInstance Deprecated. Whether to include
      *     the JSPB instance for transitional soy proto support:
      *     http://goto/soy-param-migration
-     * @param {!proto.wsman.WorkspaceMetadata} msg The msg instance to transform.
+     * @param {!proto.wsman.PortSpec} msg The msg instance to transform.
      * @return {!Object}
      * @suppress {unusedLocalVariables} f is only used for nested messages
      */
-    proto.wsman.WorkspaceMetadata.toObject = function (includeInstance, msg) {
+    proto.wsman.PortSpec.toObject = function (includeInstance, msg) {
         var f,
             obj = {
-                owner: jspb.Message.getFieldWithDefault(msg, 1, ""),
-                metaId: jspb.Message.getFieldWithDefault(msg, 2, ""),
-                startedAt:
-                    (f = msg.getStartedAt()) && google_protobuf_timestamp_pb.Timestamp.toObject(includeInstance, f),
-                annotationsMap: (f = msg.getAnnotationsMap()) ? f.toObject(includeInstance, undefined) : [],
-                team: jspb.Message.getFieldWithDefault(msg, 5, ""),
-                project: jspb.Message.getFieldWithDefault(msg, 6, ""),
+                port: jspb.Message.getFieldWithDefault(msg, 1, 0),
+                visibility: jspb.Message.getFieldWithDefault(msg, 3, 0),
+                url: jspb.Message.getFieldWithDefault(msg, 4, ""),
             };
 
         if (includeInstance) {
@@ -6608,22 +6555,22 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.wsman.WorkspaceMetadata}
+ * @return {!proto.wsman.PortSpec}
  */
-proto.wsman.WorkspaceMetadata.deserializeBinary = function (bytes) {
+proto.wsman.PortSpec.deserializeBinary = function (bytes) {
     var reader = new jspb.BinaryReader(bytes);
-    var msg = new proto.wsman.WorkspaceMetadata();
-    return proto.wsman.WorkspaceMetadata.deserializeBinaryFromReader(msg, reader);
+    var msg = new proto.wsman.PortSpec();
+    return proto.wsman.PortSpec.deserializeBinaryFromReader(msg, reader);
 };
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.wsman.WorkspaceMetadata} msg The message object to deserialize into.
+ * @param {!proto.wsman.PortSpec} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.wsman.WorkspaceMetadata}
+ * @return {!proto.wsman.PortSpec}
  */
-proto.wsman.WorkspaceMetadata.deserializeBinaryFromReader = function (msg, reader) {
+proto.wsman.PortSpec.deserializeBinaryFromReader = function (msg, reader) {
     while (reader.nextField()) {
         if (reader.isEndGroup()) {
             break;
@@ -6631,39 +6578,16 @@ proto.wsman.WorkspaceMetadata.deserializeBinaryFromReader = function (msg, reade
         var field = reader.getFieldNumber();
         switch (field) {
             case 1:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setOwner(value);
-                break;
-            case 2:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setMetaId(value);
+                var value = /** @type {number} */ (reader.readUint32());
+                msg.setPort(value);
                 break;
             case 3:
-                var value = new google_protobuf_timestamp_pb.Timestamp();
-                reader.readMessage(value, google_protobuf_timestamp_pb.Timestamp.deserializeBinaryFromReader);
-                msg.setStartedAt(value);
+                var value = /** @type {!proto.wsman.PortVisibility} */ (reader.readEnum());
+                msg.setVisibility(value);
                 break;
             case 4:
-                var value = msg.getAnnotationsMap();
-                reader.readMessage(value, function (message, reader) {
-                    jspb.Map.deserializeBinary(
-                        message,
-                        reader,
-                        jspb.BinaryReader.prototype.readString,
-                        jspb.BinaryReader.prototype.readString,
-                        null,
-                        "",
-                        "",
-                    );
-                });
-                break;
-            case 5:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setTeam(value);
-                break;
-            case 6:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setProject(value);
+                msg.setUrl(value);
                 break;
             default:
                 reader.skipField();
@@ -6677,194 +6601,81 @@ proto.wsman.WorkspaceMetadata.deserializeBinaryFromReader = function (msg, reade
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.wsman.WorkspaceMetadata.prototype.serializeBinary = function () {
+proto.wsman.PortSpec.prototype.serializeBinary = function () {
     var writer = new jspb.BinaryWriter();
-    proto.wsman.WorkspaceMetadata.serializeBinaryToWriter(this, writer);
+    proto.wsman.PortSpec.serializeBinaryToWriter(this, writer);
     return writer.getResultBuffer();
 };
 
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.wsman.WorkspaceMetadata} message
+ * @param {!proto.wsman.PortSpec} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.wsman.WorkspaceMetadata.serializeBinaryToWriter = function (message, writer) {
+proto.wsman.PortSpec.serializeBinaryToWriter = function (message, writer) {
     var f = undefined;
-    f = message.getOwner();
-    if (f.length > 0) {
-        writer.writeString(1, f);
-    }
-    f = message.getMetaId();
-    if (f.length > 0) {
-        writer.writeString(2, f);
-    }
-    f = message.getStartedAt();
-    if (f != null) {
-        writer.writeMessage(3, f, google_protobuf_timestamp_pb.Timestamp.serializeBinaryToWriter);
-    }
-    f = message.getAnnotationsMap(true);
-    if (f && f.getLength() > 0) {
-        f.serializeBinary(4, writer, jspb.BinaryWriter.prototype.writeString, jspb.BinaryWriter.prototype.writeString);
+    f = message.getPort();
+    if (f !== 0) {
+        writer.writeUint32(1, f);
     }
-    f = /** @type {string} */ (jspb.Message.getField(message, 5));
-    if (f != null) {
-        writer.writeString(5, f);
+    f = message.getVisibility();
+    if (f !== 0.0) {
+        writer.writeEnum(3, f);
     }
-    f = /** @type {string} */ (jspb.Message.getField(message, 6));
-    if (f != null) {
-        writer.writeString(6, f);
+    f = message.getUrl();
+    if (f.length > 0) {
+        writer.writeString(4, f);
     }
 };
 
 /**
- * optional string owner = 1;
- * @return {string}
+ * optional uint32 port = 1;
+ * @return {number}
  */
-proto.wsman.WorkspaceMetadata.prototype.getOwner = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+proto.wsman.PortSpec.prototype.getPort = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
 };
 
 /**
- * @param {string} value
- * @return {!proto.wsman.WorkspaceMetadata} returns this
+ * @param {number} value
+ * @return {!proto.wsman.PortSpec} returns this
  */
-proto.wsman.WorkspaceMetadata.prototype.setOwner = function (value) {
-    return jspb.Message.setProto3StringField(this, 1, value);
+proto.wsman.PortSpec.prototype.setPort = function (value) {
+    return jspb.Message.setProto3IntField(this, 1, value);
 };
 
 /**
- * optional string meta_id = 2;
- * @return {string}
+ * optional PortVisibility visibility = 3;
+ * @return {!proto.wsman.PortVisibility}
  */
-proto.wsman.WorkspaceMetadata.prototype.getMetaId = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+proto.wsman.PortSpec.prototype.getVisibility = function () {
+    return /** @type {!proto.wsman.PortVisibility} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
 };
 
 /**
- * @param {string} value
- * @return {!proto.wsman.WorkspaceMetadata} returns this
+ * @param {!proto.wsman.PortVisibility} value
+ * @return {!proto.wsman.PortSpec} returns this
  */
-proto.wsman.WorkspaceMetadata.prototype.setMetaId = function (value) {
-    return jspb.Message.setProto3StringField(this, 2, value);
+proto.wsman.PortSpec.prototype.setVisibility = function (value) {
+    return jspb.Message.setProto3EnumField(this, 3, value);
 };
 
 /**
- * optional google.protobuf.Timestamp started_at = 3;
- * @return {?proto.google.protobuf.Timestamp}
+ * optional string url = 4;
+ * @return {string}
  */
-proto.wsman.WorkspaceMetadata.prototype.getStartedAt = function () {
-    return /** @type{?proto.google.protobuf.Timestamp} */ (
-        jspb.Message.getWrapperField(this, google_protobuf_timestamp_pb.Timestamp, 3)
-    );
-};
-
-/**
- * @param {?proto.google.protobuf.Timestamp|undefined} value
- * @return {!proto.wsman.WorkspaceMetadata} returns this
- */
-proto.wsman.WorkspaceMetadata.prototype.setStartedAt = function (value) {
-    return jspb.Message.setWrapperField(this, 3, value);
-};
-
-/**
- * Clears the message field making it undefined.
- * @return {!proto.wsman.WorkspaceMetadata} returns this
- */
-proto.wsman.WorkspaceMetadata.prototype.clearStartedAt = function () {
-    return this.setStartedAt(undefined);
-};
-
-/**
- * Returns whether this field is set.
- * @return {boolean}
- */
-proto.wsman.WorkspaceMetadata.prototype.hasStartedAt = function () {
-    return jspb.Message.getField(this, 3) != null;
-};
-
-/**
- * map<string, string> annotations = 4;
- * @param {boolean=} opt_noLazyCreate Do not create the map if
- * empty, instead returning `undefined`
- * @return {!jspb.Map<string,string>}
- */
-proto.wsman.WorkspaceMetadata.prototype.getAnnotationsMap = function (opt_noLazyCreate) {
-    return /** @type {!jspb.Map<string,string>} */ (jspb.Message.getMapField(this, 4, opt_noLazyCreate, null));
-};
-
-/**
- * Clears values from the map. The map will be non-null.
- * @return {!proto.wsman.WorkspaceMetadata} returns this
- */
-proto.wsman.WorkspaceMetadata.prototype.clearAnnotationsMap = function () {
-    this.getAnnotationsMap().clear();
-    return this;
-};
-
-/**
- * optional string team = 5;
- * @return {string}
- */
-proto.wsman.WorkspaceMetadata.prototype.getTeam = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
-};
-
-/**
- * @param {string} value
- * @return {!proto.wsman.WorkspaceMetadata} returns this
- */
-proto.wsman.WorkspaceMetadata.prototype.setTeam = function (value) {
-    return jspb.Message.setField(this, 5, value);
-};
-
-/**
- * Clears the field making it undefined.
- * @return {!proto.wsman.WorkspaceMetadata} returns this
- */
-proto.wsman.WorkspaceMetadata.prototype.clearTeam = function () {
-    return jspb.Message.setField(this, 5, undefined);
-};
-
-/**
- * Returns whether this field is set.
- * @return {boolean}
- */
-proto.wsman.WorkspaceMetadata.prototype.hasTeam = function () {
-    return jspb.Message.getField(this, 5) != null;
-};
-
-/**
- * optional string project = 6;
- * @return {string}
- */
-proto.wsman.WorkspaceMetadata.prototype.getProject = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 6, ""));
+proto.wsman.PortSpec.prototype.getUrl = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.WorkspaceMetadata} returns this
- */
-proto.wsman.WorkspaceMetadata.prototype.setProject = function (value) {
-    return jspb.Message.setField(this, 6, value);
-};
-
-/**
- * Clears the field making it undefined.
- * @return {!proto.wsman.WorkspaceMetadata} returns this
- */
-proto.wsman.WorkspaceMetadata.prototype.clearProject = function () {
-    return jspb.Message.setField(this, 6, undefined);
-};
-
-/**
- * Returns whether this field is set.
- * @return {boolean}
+ * @return {!proto.wsman.PortSpec} returns this
  */
-proto.wsman.WorkspaceMetadata.prototype.hasProject = function () {
-    return jspb.Message.getField(this, 6) != null;
+proto.wsman.PortSpec.prototype.setUrl = function (value) {
+    return jspb.Message.setProto3StringField(this, 4, value);
 };
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -6880,8 +6691,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      *     http://goto/soy-param-migration
      * @return {!Object}
      */
-    proto.wsman.WorkspaceRuntimeInfo.prototype.toObject = function (opt_includeInstance) {
-        return proto.wsman.WorkspaceRuntimeInfo.toObject(opt_includeInstance, this);
+    proto.wsman.VolumeSnapshotInfo.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.VolumeSnapshotInfo.toObject(opt_includeInstance, this);
     };
 
     /**
@@ -6889,16 +6700,15 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      * @param {boolean|undefined} includeInstance Deprecated. Whether to include
      *     the JSPB instance for transitional soy proto support:
      *     http://goto/soy-param-migration
-     * @param {!proto.wsman.WorkspaceRuntimeInfo} msg The msg instance to transform.
+     * @param {!proto.wsman.VolumeSnapshotInfo} msg The msg instance to transform.
      * @return {!Object}
      * @suppress {unusedLocalVariables} f is only used for nested messages
      */
-    proto.wsman.WorkspaceRuntimeInfo.toObject = function (includeInstance, msg) {
+    proto.wsman.VolumeSnapshotInfo.toObject = function (includeInstance, msg) {
         var f,
             obj = {
-                nodeName: jspb.Message.getFieldWithDefault(msg, 1, ""),
-                podName: jspb.Message.getFieldWithDefault(msg, 2, ""),
-                nodeIp: jspb.Message.getFieldWithDefault(msg, 3, ""),
+                volumeSnapshotName: jspb.Message.getFieldWithDefault(msg, 1, ""),
+                volumeSnapshotHandle: jspb.Message.getFieldWithDefault(msg, 2, ""),
             };
 
         if (includeInstance) {
@@ -6911,22 +6721,22 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.wsman.WorkspaceRuntimeInfo}
+ * @return {!proto.wsman.VolumeSnapshotInfo}
  */
-proto.wsman.WorkspaceRuntimeInfo.deserializeBinary = function (bytes) {
+proto.wsman.VolumeSnapshotInfo.deserializeBinary = function (bytes) {
     var reader = new jspb.BinaryReader(bytes);
-    var msg = new proto.wsman.WorkspaceRuntimeInfo();
-    return proto.wsman.WorkspaceRuntimeInfo.deserializeBinaryFromReader(msg, reader);
+    var msg = new proto.wsman.VolumeSnapshotInfo();
+    return proto.wsman.VolumeSnapshotInfo.deserializeBinaryFromReader(msg, reader);
 };
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.wsman.WorkspaceRuntimeInfo} msg The message object to deserialize into.
+ * @param {!proto.wsman.VolumeSnapshotInfo} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.wsman.WorkspaceRuntimeInfo}
+ * @return {!proto.wsman.VolumeSnapshotInfo}
  */
-proto.wsman.WorkspaceRuntimeInfo.deserializeBinaryFromReader = function (msg, reader) {
+proto.wsman.VolumeSnapshotInfo.deserializeBinaryFromReader = function (msg, reader) {
     while (reader.nextField()) {
         if (reader.isEndGroup()) {
             break;
@@ -6935,15 +6745,11 @@ proto.wsman.WorkspaceRuntimeInfo.deserializeBinaryFromReader = function (msg, re
         switch (field) {
             case 1:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setNodeName(value);
+                msg.setVolumeSnapshotName(value);
                 break;
             case 2:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setPodName(value);
-                break;
-            case 3:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setNodeIp(value);
+                msg.setVolumeSnapshotHandle(value);
                 break;
             default:
                 reader.skipField();
@@ -6957,83 +6763,63 @@ proto.wsman.WorkspaceRuntimeInfo.deserializeBinaryFromReader = function (msg, re
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.wsman.WorkspaceRuntimeInfo.prototype.serializeBinary = function () {
+proto.wsman.VolumeSnapshotInfo.prototype.serializeBinary = function () {
     var writer = new jspb.BinaryWriter();
-    proto.wsman.WorkspaceRuntimeInfo.serializeBinaryToWriter(this, writer);
+    proto.wsman.VolumeSnapshotInfo.serializeBinaryToWriter(this, writer);
     return writer.getResultBuffer();
 };
 
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.wsman.WorkspaceRuntimeInfo} message
+ * @param {!proto.wsman.VolumeSnapshotInfo} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.wsman.WorkspaceRuntimeInfo.serializeBinaryToWriter = function (message, writer) {
+proto.wsman.VolumeSnapshotInfo.serializeBinaryToWriter = function (message, writer) {
     var f = undefined;
-    f = message.getNodeName();
+    f = message.getVolumeSnapshotName();
     if (f.length > 0) {
         writer.writeString(1, f);
     }
-    f = message.getPodName();
+    f = message.getVolumeSnapshotHandle();
     if (f.length > 0) {
         writer.writeString(2, f);
     }
-    f = message.getNodeIp();
-    if (f.length > 0) {
-        writer.writeString(3, f);
-    }
 };
 
 /**
- * optional string node_name = 1;
+ * optional string volume_snapshot_name = 1;
  * @return {string}
  */
-proto.wsman.WorkspaceRuntimeInfo.prototype.getNodeName = function () {
+proto.wsman.VolumeSnapshotInfo.prototype.getVolumeSnapshotName = function () {
     return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.WorkspaceRuntimeInfo} returns this
+ * @return {!proto.wsman.VolumeSnapshotInfo} returns this
  */
-proto.wsman.WorkspaceRuntimeInfo.prototype.setNodeName = function (value) {
+proto.wsman.VolumeSnapshotInfo.prototype.setVolumeSnapshotName = function (value) {
     return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 /**
- * optional string pod_name = 2;
+ * optional string volume_snapshot_handle = 2;
  * @return {string}
  */
-proto.wsman.WorkspaceRuntimeInfo.prototype.getPodName = function () {
+proto.wsman.VolumeSnapshotInfo.prototype.getVolumeSnapshotHandle = function () {
     return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.WorkspaceRuntimeInfo} returns this
+ * @return {!proto.wsman.VolumeSnapshotInfo} returns this
  */
-proto.wsman.WorkspaceRuntimeInfo.prototype.setPodName = function (value) {
+proto.wsman.VolumeSnapshotInfo.prototype.setVolumeSnapshotHandle = function (value) {
     return jspb.Message.setProto3StringField(this, 2, value);
 };
 
-/**
- * optional string node_ip = 3;
- * @return {string}
- */
-proto.wsman.WorkspaceRuntimeInfo.prototype.getNodeIp = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
-};
-
-/**
- * @param {string} value
- * @return {!proto.wsman.WorkspaceRuntimeInfo} returns this
- */
-proto.wsman.WorkspaceRuntimeInfo.prototype.setNodeIp = function (value) {
-    return jspb.Message.setProto3StringField(this, 3, value);
-};
-
 if (jspb.Message.GENERATE_TO_OBJECT) {
     /**
      * Creates an object representation of this proto.
@@ -7047,8 +6833,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      *     http://goto/soy-param-migration
      * @return {!Object}
      */
-    proto.wsman.WorkspaceAuthentication.prototype.toObject = function (opt_includeInstance) {
-        return proto.wsman.WorkspaceAuthentication.toObject(opt_includeInstance, this);
+    proto.wsman.WorkspaceConditions.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.WorkspaceConditions.toObject(opt_includeInstance, this);
     };
 
     /**
@@ -7056,15 +6842,28 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
      * @param {boolean|undefined} includeInstance Deprecated. Whether to include
      *     the JSPB instance for transitional soy proto support:
      *     http://goto/soy-param-migration
-     * @param {!proto.wsman.WorkspaceAuthentication} msg The msg instance to transform.
+     * @param {!proto.wsman.WorkspaceConditions} msg The msg instance to transform.
      * @return {!Object}
      * @suppress {unusedLocalVariables} f is only used for nested messages
      */
-    proto.wsman.WorkspaceAuthentication.toObject = function (includeInstance, msg) {
+    proto.wsman.WorkspaceConditions.toObject = function (includeInstance, msg) {
         var f,
             obj = {
-                admission: jspb.Message.getFieldWithDefault(msg, 1, 0),
-                ownerToken: jspb.Message.getFieldWithDefault(msg, 2, ""),
+                failed: jspb.Message.getFieldWithDefault(msg, 1, ""),
+                timeout: jspb.Message.getFieldWithDefault(msg, 2, ""),
+                pullingImages: jspb.Message.getFieldWithDefault(msg, 3, 0),
+                snapshot: jspb.Message.getFieldWithDefault(msg, 5, ""),
+                finalBackupComplete: jspb.Message.getFieldWithDefault(msg, 6, 0),
+                deployed: jspb.Message.getFieldWithDefault(msg, 7, 0),
+                networkNotReady: jspb.Message.getFieldWithDefault(msg, 8, 0),
+                firstUserActivity:
+                    (f = msg.getFirstUserActivity()) &&
+                    google_protobuf_timestamp_pb.Timestamp.toObject(includeInstance, f),
+                headlessTaskFailed: jspb.Message.getFieldWithDefault(msg, 10, ""),
+                stoppedByRequest: jspb.Message.getFieldWithDefault(msg, 11, 0),
+                volumeSnapshot:
+                    (f = msg.getVolumeSnapshot()) && proto.wsman.VolumeSnapshotInfo.toObject(includeInstance, f),
+                aborted: jspb.Message.getFieldWithDefault(msg, 13, 0),
             };
 
         if (includeInstance) {
@@ -7077,22 +6876,22 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.wsman.WorkspaceAuthentication}
+ * @return {!proto.wsman.WorkspaceConditions}
  */
-proto.wsman.WorkspaceAuthentication.deserializeBinary = function (bytes) {
+proto.wsman.WorkspaceConditions.deserializeBinary = function (bytes) {
     var reader = new jspb.BinaryReader(bytes);
-    var msg = new proto.wsman.WorkspaceAuthentication();
-    return proto.wsman.WorkspaceAuthentication.deserializeBinaryFromReader(msg, reader);
+    var msg = new proto.wsman.WorkspaceConditions();
+    return proto.wsman.WorkspaceConditions.deserializeBinaryFromReader(msg, reader);
 };
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.wsman.WorkspaceAuthentication} msg The message object to deserialize into.
+ * @param {!proto.wsman.WorkspaceConditions} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.wsman.WorkspaceAuthentication}
+ * @return {!proto.wsman.WorkspaceConditions}
  */
-proto.wsman.WorkspaceAuthentication.deserializeBinaryFromReader = function (msg, reader) {
+proto.wsman.WorkspaceConditions.deserializeBinaryFromReader = function (msg, reader) {
     while (reader.nextField()) {
         if (reader.isEndGroup()) {
             break;
@@ -7100,260 +6899,54 @@ proto.wsman.WorkspaceAuthentication.deserializeBinaryFromReader = function (msg,
         var field = reader.getFieldNumber();
         switch (field) {
             case 1:
-                var value = /** @type {!proto.wsman.AdmissionLevel} */ (reader.readEnum());
-                msg.setAdmission(value);
+                var value = /** @type {string} */ (reader.readString());
+                msg.setFailed(value);
                 break;
             case 2:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setOwnerToken(value);
+                msg.setTimeout(value);
                 break;
-            default:
-                reader.skipField();
-                break;
-        }
-    }
-    return msg;
-};
-
-/**
- * Serializes the message to binary data (in protobuf wire format).
- * @return {!Uint8Array}
- */
-proto.wsman.WorkspaceAuthentication.prototype.serializeBinary = function () {
-    var writer = new jspb.BinaryWriter();
-    proto.wsman.WorkspaceAuthentication.serializeBinaryToWriter(this, writer);
-    return writer.getResultBuffer();
-};
-
-/**
- * Serializes the given message to binary data (in protobuf wire
- * format), writing to the given BinaryWriter.
- * @param {!proto.wsman.WorkspaceAuthentication} message
- * @param {!jspb.BinaryWriter} writer
- * @suppress {unusedLocalVariables} f is only used for nested messages
- */
-proto.wsman.WorkspaceAuthentication.serializeBinaryToWriter = function (message, writer) {
-    var f = undefined;
-    f = message.getAdmission();
-    if (f !== 0.0) {
-        writer.writeEnum(1, f);
-    }
-    f = message.getOwnerToken();
-    if (f.length > 0) {
-        writer.writeString(2, f);
-    }
-};
-
-/**
- * optional AdmissionLevel admission = 1;
- * @return {!proto.wsman.AdmissionLevel}
- */
-proto.wsman.WorkspaceAuthentication.prototype.getAdmission = function () {
-    return /** @type {!proto.wsman.AdmissionLevel} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
-};
-
-/**
- * @param {!proto.wsman.AdmissionLevel} value
- * @return {!proto.wsman.WorkspaceAuthentication} returns this
- */
-proto.wsman.WorkspaceAuthentication.prototype.setAdmission = function (value) {
-    return jspb.Message.setProto3EnumField(this, 1, value);
-};
-
-/**
- * optional string owner_token = 2;
- * @return {string}
- */
-proto.wsman.WorkspaceAuthentication.prototype.getOwnerToken = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
-};
-
-/**
- * @param {string} value
- * @return {!proto.wsman.WorkspaceAuthentication} returns this
- */
-proto.wsman.WorkspaceAuthentication.prototype.setOwnerToken = function (value) {
-    return jspb.Message.setProto3StringField(this, 2, value);
-};
-
-/**
- * List of repeated fields within this message type.
- * @private {!Array<number>}
- * @const
- */
-proto.wsman.StartWorkspaceSpec.repeatedFields_ = [3, 5, 6, 15, 16, 17];
-
-if (jspb.Message.GENERATE_TO_OBJECT) {
-    /**
-     * Creates an object representation of this proto.
-     * Field names that are reserved in JavaScript and will be renamed to pb_name.
-     * Optional fields that are not set will be set to undefined.
-     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
-     * For the list of reserved names please see:
-     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
-     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
-     *     JSPB instance for transitional soy proto support:
-     *     http://goto/soy-param-migration
-     * @return {!Object}
-     */
-    proto.wsman.StartWorkspaceSpec.prototype.toObject = function (opt_includeInstance) {
-        return proto.wsman.StartWorkspaceSpec.toObject(opt_includeInstance, this);
-    };
-
-    /**
-     * Static version of the {@see toObject} method.
-     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
-     *     the JSPB instance for transitional soy proto support:
-     *     http://goto/soy-param-migration
-     * @param {!proto.wsman.StartWorkspaceSpec} msg The msg instance to transform.
-     * @return {!Object}
-     * @suppress {unusedLocalVariables} f is only used for nested messages
-     */
-    proto.wsman.StartWorkspaceSpec.toObject = function (includeInstance, msg) {
-        var f,
-            obj = {
-                workspaceImage: jspb.Message.getFieldWithDefault(msg, 1, ""),
-                deprecatedIdeImage: jspb.Message.getFieldWithDefault(msg, 2, ""),
-                featureFlagsList: (f = jspb.Message.getRepeatedField(msg, 3)) == null ? undefined : f,
-                initializer:
-                    (f = msg.getInitializer()) &&
-                    content$service$api_initializer_pb.WorkspaceInitializer.toObject(includeInstance, f),
-                portsList: jspb.Message.toObjectList(
-                    msg.getPortsList(),
-                    proto.wsman.PortSpec.toObject,
-                    includeInstance,
-                ),
-                envvarsList: jspb.Message.toObjectList(
-                    msg.getEnvvarsList(),
-                    proto.wsman.EnvironmentVariable.toObject,
-                    includeInstance,
-                ),
-                workspaceLocation: jspb.Message.getFieldWithDefault(msg, 8, ""),
-                git: (f = msg.getGit()) && proto.wsman.GitSpec.toObject(includeInstance, f),
-                timeout: jspb.Message.getFieldWithDefault(msg, 10, ""),
-                admission: jspb.Message.getFieldWithDefault(msg, 11, 0),
-                ideImage: (f = msg.getIdeImage()) && proto.wsman.IDEImage.toObject(includeInstance, f),
-                pb_class: jspb.Message.getFieldWithDefault(msg, 13, ""),
-                volumeSnapshot:
-                    (f = msg.getVolumeSnapshot()) && proto.wsman.VolumeSnapshotInfo.toObject(includeInstance, f),
-                sshPublicKeysList: (f = jspb.Message.getRepeatedField(msg, 15)) == null ? undefined : f,
-                sysEnvvarsList: jspb.Message.toObjectList(
-                    msg.getSysEnvvarsList(),
-                    proto.wsman.EnvironmentVariable.toObject,
-                    includeInstance,
-                ),
-                ideImageLayersList: (f = jspb.Message.getRepeatedField(msg, 17)) == null ? undefined : f,
-            };
-
-        if (includeInstance) {
-            obj.$jspbMessageInstance = msg;
-        }
-        return obj;
-    };
-}
-
-/**
- * Deserializes binary data (in protobuf wire format).
- * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.wsman.StartWorkspaceSpec}
- */
-proto.wsman.StartWorkspaceSpec.deserializeBinary = function (bytes) {
-    var reader = new jspb.BinaryReader(bytes);
-    var msg = new proto.wsman.StartWorkspaceSpec();
-    return proto.wsman.StartWorkspaceSpec.deserializeBinaryFromReader(msg, reader);
-};
-
-/**
- * Deserializes binary data (in protobuf wire format) from the
- * given reader into the given message object.
- * @param {!proto.wsman.StartWorkspaceSpec} msg The message object to deserialize into.
- * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.wsman.StartWorkspaceSpec}
- */
-proto.wsman.StartWorkspaceSpec.deserializeBinaryFromReader = function (msg, reader) {
-    while (reader.nextField()) {
-        if (reader.isEndGroup()) {
-            break;
-        }
-        var field = reader.getFieldNumber();
-        switch (field) {
-            case 1:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setWorkspaceImage(value);
-                break;
-            case 2:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setDeprecatedIdeImage(value);
-                break;
-            case 3:
-                var values = /** @type {!Array<!proto.wsman.WorkspaceFeatureFlag>} */ (
-                    reader.isDelimited() ? reader.readPackedEnum() : [reader.readEnum()]
-                );
-                for (var i = 0; i < values.length; i++) {
-                    msg.addFeatureFlags(values[i]);
-                }
-                break;
-            case 4:
-                var value = new content$service$api_initializer_pb.WorkspaceInitializer();
-                reader.readMessage(
-                    value,
-                    content$service$api_initializer_pb.WorkspaceInitializer.deserializeBinaryFromReader,
-                );
-                msg.setInitializer(value);
+            case 3:
+                var value = /** @type {!proto.wsman.WorkspaceConditionBool} */ (reader.readEnum());
+                msg.setPullingImages(value);
                 break;
             case 5:
-                var value = new proto.wsman.PortSpec();
-                reader.readMessage(value, proto.wsman.PortSpec.deserializeBinaryFromReader);
-                msg.addPorts(value);
+                var value = /** @type {string} */ (reader.readString());
+                msg.setSnapshot(value);
                 break;
             case 6:
-                var value = new proto.wsman.EnvironmentVariable();
-                reader.readMessage(value, proto.wsman.EnvironmentVariable.deserializeBinaryFromReader);
-                msg.addEnvvars(value);
+                var value = /** @type {!proto.wsman.WorkspaceConditionBool} */ (reader.readEnum());
+                msg.setFinalBackupComplete(value);
+                break;
+            case 7:
+                var value = /** @type {!proto.wsman.WorkspaceConditionBool} */ (reader.readEnum());
+                msg.setDeployed(value);
                 break;
             case 8:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setWorkspaceLocation(value);
+                var value = /** @type {!proto.wsman.WorkspaceConditionBool} */ (reader.readEnum());
+                msg.setNetworkNotReady(value);
                 break;
             case 9:
-                var value = new proto.wsman.GitSpec();
-                reader.readMessage(value, proto.wsman.GitSpec.deserializeBinaryFromReader);
-                msg.setGit(value);
+                var value = new google_protobuf_timestamp_pb.Timestamp();
+                reader.readMessage(value, google_protobuf_timestamp_pb.Timestamp.deserializeBinaryFromReader);
+                msg.setFirstUserActivity(value);
                 break;
             case 10:
                 var value = /** @type {string} */ (reader.readString());
-                msg.setTimeout(value);
+                msg.setHeadlessTaskFailed(value);
                 break;
             case 11:
-                var value = /** @type {!proto.wsman.AdmissionLevel} */ (reader.readEnum());
-                msg.setAdmission(value);
+                var value = /** @type {!proto.wsman.WorkspaceConditionBool} */ (reader.readEnum());
+                msg.setStoppedByRequest(value);
                 break;
             case 12:
-                var value = new proto.wsman.IDEImage();
-                reader.readMessage(value, proto.wsman.IDEImage.deserializeBinaryFromReader);
-                msg.setIdeImage(value);
-                break;
-            case 13:
-                var value = /** @type {string} */ (reader.readString());
-                msg.setClass(value);
-                break;
-            case 14:
                 var value = new proto.wsman.VolumeSnapshotInfo();
                 reader.readMessage(value, proto.wsman.VolumeSnapshotInfo.deserializeBinaryFromReader);
                 msg.setVolumeSnapshot(value);
                 break;
-            case 15:
-                var value = /** @type {string} */ (reader.readString());
-                msg.addSshPublicKeys(value);
-                break;
-            case 16:
-                var value = new proto.wsman.EnvironmentVariable();
-                reader.readMessage(value, proto.wsman.EnvironmentVariable.deserializeBinaryFromReader);
-                msg.addSysEnvvars(value);
-                break;
-            case 17:
-                var value = /** @type {string} */ (reader.readString());
-                msg.addIdeImageLayers(value);
+            case 13:
+                var value = /** @type {!proto.wsman.WorkspaceConditionBool} */ (reader.readEnum());
+                msg.setAborted(value);
                 break;
             default:
                 reader.skipField();
@@ -7367,517 +6960,2184 @@ proto.wsman.StartWorkspaceSpec.deserializeBinaryFromReader = function (msg, read
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.wsman.StartWorkspaceSpec.prototype.serializeBinary = function () {
+proto.wsman.WorkspaceConditions.prototype.serializeBinary = function () {
     var writer = new jspb.BinaryWriter();
-    proto.wsman.StartWorkspaceSpec.serializeBinaryToWriter(this, writer);
+    proto.wsman.WorkspaceConditions.serializeBinaryToWriter(this, writer);
     return writer.getResultBuffer();
 };
 
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.wsman.StartWorkspaceSpec} message
+ * @param {!proto.wsman.WorkspaceConditions} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.wsman.StartWorkspaceSpec.serializeBinaryToWriter = function (message, writer) {
+proto.wsman.WorkspaceConditions.serializeBinaryToWriter = function (message, writer) {
     var f = undefined;
-    f = message.getWorkspaceImage();
+    f = message.getFailed();
     if (f.length > 0) {
         writer.writeString(1, f);
     }
-    f = message.getDeprecatedIdeImage();
+    f = message.getTimeout();
     if (f.length > 0) {
         writer.writeString(2, f);
     }
-    f = message.getFeatureFlagsList();
-    if (f.length > 0) {
-        writer.writePackedEnum(3, f);
-    }
-    f = message.getInitializer();
-    if (f != null) {
-        writer.writeMessage(4, f, content$service$api_initializer_pb.WorkspaceInitializer.serializeBinaryToWriter);
+    f = message.getPullingImages();
+    if (f !== 0.0) {
+        writer.writeEnum(3, f);
     }
-    f = message.getPortsList();
+    f = message.getSnapshot();
     if (f.length > 0) {
-        writer.writeRepeatedMessage(5, f, proto.wsman.PortSpec.serializeBinaryToWriter);
+        writer.writeString(5, f);
     }
-    f = message.getEnvvarsList();
-    if (f.length > 0) {
-        writer.writeRepeatedMessage(6, f, proto.wsman.EnvironmentVariable.serializeBinaryToWriter);
+    f = message.getFinalBackupComplete();
+    if (f !== 0.0) {
+        writer.writeEnum(6, f);
     }
-    f = message.getWorkspaceLocation();
-    if (f.length > 0) {
-        writer.writeString(8, f);
+    f = message.getDeployed();
+    if (f !== 0.0) {
+        writer.writeEnum(7, f);
     }
-    f = message.getGit();
+    f = message.getNetworkNotReady();
+    if (f !== 0.0) {
+        writer.writeEnum(8, f);
+    }
+    f = message.getFirstUserActivity();
     if (f != null) {
-        writer.writeMessage(9, f, proto.wsman.GitSpec.serializeBinaryToWriter);
+        writer.writeMessage(9, f, google_protobuf_timestamp_pb.Timestamp.serializeBinaryToWriter);
     }
-    f = message.getTimeout();
+    f = message.getHeadlessTaskFailed();
     if (f.length > 0) {
         writer.writeString(10, f);
     }
-    f = message.getAdmission();
+    f = message.getStoppedByRequest();
     if (f !== 0.0) {
         writer.writeEnum(11, f);
     }
-    f = message.getIdeImage();
-    if (f != null) {
-        writer.writeMessage(12, f, proto.wsman.IDEImage.serializeBinaryToWriter);
-    }
-    f = message.getClass();
-    if (f.length > 0) {
-        writer.writeString(13, f);
-    }
     f = message.getVolumeSnapshot();
     if (f != null) {
-        writer.writeMessage(14, f, proto.wsman.VolumeSnapshotInfo.serializeBinaryToWriter);
-    }
-    f = message.getSshPublicKeysList();
-    if (f.length > 0) {
-        writer.writeRepeatedString(15, f);
-    }
-    f = message.getSysEnvvarsList();
-    if (f.length > 0) {
-        writer.writeRepeatedMessage(16, f, proto.wsman.EnvironmentVariable.serializeBinaryToWriter);
+        writer.writeMessage(12, f, proto.wsman.VolumeSnapshotInfo.serializeBinaryToWriter);
     }
-    f = message.getIdeImageLayersList();
-    if (f.length > 0) {
-        writer.writeRepeatedString(17, f);
+    f = message.getAborted();
+    if (f !== 0.0) {
+        writer.writeEnum(13, f);
     }
 };
 
 /**
- * optional string workspace_image = 1;
+ * optional string failed = 1;
  * @return {string}
  */
-proto.wsman.StartWorkspaceSpec.prototype.getWorkspaceImage = function () {
+proto.wsman.WorkspaceConditions.prototype.getFailed = function () {
     return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 /**
- * @param {string} value
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.setFailed = function (value) {
+    return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+/**
+ * optional string timeout = 2;
+ * @return {string}
+ */
+proto.wsman.WorkspaceConditions.prototype.getTimeout = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.setTimeout = function (value) {
+    return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+/**
+ * optional WorkspaceConditionBool pulling_images = 3;
+ * @return {!proto.wsman.WorkspaceConditionBool}
+ */
+proto.wsman.WorkspaceConditions.prototype.getPullingImages = function () {
+    return /** @type {!proto.wsman.WorkspaceConditionBool} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+/**
+ * @param {!proto.wsman.WorkspaceConditionBool} value
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.setPullingImages = function (value) {
+    return jspb.Message.setProto3EnumField(this, 3, value);
+};
+
+/**
+ * optional string snapshot = 5;
+ * @return {string}
+ */
+proto.wsman.WorkspaceConditions.prototype.getSnapshot = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.setSnapshot = function (value) {
+    return jspb.Message.setProto3StringField(this, 5, value);
+};
+
+/**
+ * optional WorkspaceConditionBool final_backup_complete = 6;
+ * @return {!proto.wsman.WorkspaceConditionBool}
+ */
+proto.wsman.WorkspaceConditions.prototype.getFinalBackupComplete = function () {
+    return /** @type {!proto.wsman.WorkspaceConditionBool} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+/**
+ * @param {!proto.wsman.WorkspaceConditionBool} value
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.setFinalBackupComplete = function (value) {
+    return jspb.Message.setProto3EnumField(this, 6, value);
+};
+
+/**
+ * optional WorkspaceConditionBool deployed = 7;
+ * @return {!proto.wsman.WorkspaceConditionBool}
+ */
+proto.wsman.WorkspaceConditions.prototype.getDeployed = function () {
+    return /** @type {!proto.wsman.WorkspaceConditionBool} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
+};
+
+/**
+ * @param {!proto.wsman.WorkspaceConditionBool} value
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.setDeployed = function (value) {
+    return jspb.Message.setProto3EnumField(this, 7, value);
+};
+
+/**
+ * optional WorkspaceConditionBool network_not_ready = 8;
+ * @return {!proto.wsman.WorkspaceConditionBool}
+ */
+proto.wsman.WorkspaceConditions.prototype.getNetworkNotReady = function () {
+    return /** @type {!proto.wsman.WorkspaceConditionBool} */ (jspb.Message.getFieldWithDefault(this, 8, 0));
+};
+
+/**
+ * @param {!proto.wsman.WorkspaceConditionBool} value
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.setNetworkNotReady = function (value) {
+    return jspb.Message.setProto3EnumField(this, 8, value);
+};
+
+/**
+ * optional google.protobuf.Timestamp first_user_activity = 9;
+ * @return {?proto.google.protobuf.Timestamp}
+ */
+proto.wsman.WorkspaceConditions.prototype.getFirstUserActivity = function () {
+    return /** @type{?proto.google.protobuf.Timestamp} */ (
+        jspb.Message.getWrapperField(this, google_protobuf_timestamp_pb.Timestamp, 9)
+    );
+};
+
+/**
+ * @param {?proto.google.protobuf.Timestamp|undefined} value
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.setFirstUserActivity = function (value) {
+    return jspb.Message.setWrapperField(this, 9, value);
+};
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.clearFirstUserActivity = function () {
+    return this.setFirstUserActivity(undefined);
+};
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.WorkspaceConditions.prototype.hasFirstUserActivity = function () {
+    return jspb.Message.getField(this, 9) != null;
+};
+
+/**
+ * optional string headless_task_failed = 10;
+ * @return {string}
+ */
+proto.wsman.WorkspaceConditions.prototype.getHeadlessTaskFailed = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 10, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.setHeadlessTaskFailed = function (value) {
+    return jspb.Message.setProto3StringField(this, 10, value);
+};
+
+/**
+ * optional WorkspaceConditionBool stopped_by_request = 11;
+ * @return {!proto.wsman.WorkspaceConditionBool}
+ */
+proto.wsman.WorkspaceConditions.prototype.getStoppedByRequest = function () {
+    return /** @type {!proto.wsman.WorkspaceConditionBool} */ (jspb.Message.getFieldWithDefault(this, 11, 0));
+};
+
+/**
+ * @param {!proto.wsman.WorkspaceConditionBool} value
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.setStoppedByRequest = function (value) {
+    return jspb.Message.setProto3EnumField(this, 11, value);
+};
+
+/**
+ * optional VolumeSnapshotInfo volume_snapshot = 12;
+ * @return {?proto.wsman.VolumeSnapshotInfo}
+ */
+proto.wsman.WorkspaceConditions.prototype.getVolumeSnapshot = function () {
+    return /** @type{?proto.wsman.VolumeSnapshotInfo} */ (
+        jspb.Message.getWrapperField(this, proto.wsman.VolumeSnapshotInfo, 12)
+    );
+};
+
+/**
+ * @param {?proto.wsman.VolumeSnapshotInfo|undefined} value
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.setVolumeSnapshot = function (value) {
+    return jspb.Message.setWrapperField(this, 12, value);
+};
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.clearVolumeSnapshot = function () {
+    return this.setVolumeSnapshot(undefined);
+};
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.WorkspaceConditions.prototype.hasVolumeSnapshot = function () {
+    return jspb.Message.getField(this, 12) != null;
+};
+
+/**
+ * optional WorkspaceConditionBool aborted = 13;
+ * @return {!proto.wsman.WorkspaceConditionBool}
+ */
+proto.wsman.WorkspaceConditions.prototype.getAborted = function () {
+    return /** @type {!proto.wsman.WorkspaceConditionBool} */ (jspb.Message.getFieldWithDefault(this, 13, 0));
+};
+
+/**
+ * @param {!proto.wsman.WorkspaceConditionBool} value
+ * @return {!proto.wsman.WorkspaceConditions} returns this
+ */
+proto.wsman.WorkspaceConditions.prototype.setAborted = function (value) {
+    return jspb.Message.setProto3EnumField(this, 13, value);
+};
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.wsman.WorkspaceMetadata.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.WorkspaceMetadata.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.wsman.WorkspaceMetadata} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.wsman.WorkspaceMetadata.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                owner: jspb.Message.getFieldWithDefault(msg, 1, ""),
+                metaId: jspb.Message.getFieldWithDefault(msg, 2, ""),
+                startedAt:
+                    (f = msg.getStartedAt()) && google_protobuf_timestamp_pb.Timestamp.toObject(includeInstance, f),
+                annotationsMap: (f = msg.getAnnotationsMap()) ? f.toObject(includeInstance, undefined) : [],
+                team: jspb.Message.getFieldWithDefault(msg, 5, ""),
+                project: jspb.Message.getFieldWithDefault(msg, 6, ""),
+            };
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.wsman.WorkspaceMetadata}
+ */
+proto.wsman.WorkspaceMetadata.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.wsman.WorkspaceMetadata();
+    return proto.wsman.WorkspaceMetadata.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.wsman.WorkspaceMetadata} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.wsman.WorkspaceMetadata}
+ */
+proto.wsman.WorkspaceMetadata.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setOwner(value);
+                break;
+            case 2:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setMetaId(value);
+                break;
+            case 3:
+                var value = new google_protobuf_timestamp_pb.Timestamp();
+                reader.readMessage(value, google_protobuf_timestamp_pb.Timestamp.deserializeBinaryFromReader);
+                msg.setStartedAt(value);
+                break;
+            case 4:
+                var value = msg.getAnnotationsMap();
+                reader.readMessage(value, function (message, reader) {
+                    jspb.Map.deserializeBinary(
+                        message,
+                        reader,
+                        jspb.BinaryReader.prototype.readString,
+                        jspb.BinaryReader.prototype.readString,
+                        null,
+                        "",
+                        "",
+                    );
+                });
+                break;
+            case 5:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setTeam(value);
+                break;
+            case 6:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setProject(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.wsman.WorkspaceMetadata.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.wsman.WorkspaceMetadata.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.wsman.WorkspaceMetadata} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.wsman.WorkspaceMetadata.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getOwner();
+    if (f.length > 0) {
+        writer.writeString(1, f);
+    }
+    f = message.getMetaId();
+    if (f.length > 0) {
+        writer.writeString(2, f);
+    }
+    f = message.getStartedAt();
+    if (f != null) {
+        writer.writeMessage(3, f, google_protobuf_timestamp_pb.Timestamp.serializeBinaryToWriter);
+    }
+    f = message.getAnnotationsMap(true);
+    if (f && f.getLength() > 0) {
+        f.serializeBinary(4, writer, jspb.BinaryWriter.prototype.writeString, jspb.BinaryWriter.prototype.writeString);
+    }
+    f = /** @type {string} */ (jspb.Message.getField(message, 5));
+    if (f != null) {
+        writer.writeString(5, f);
+    }
+    f = /** @type {string} */ (jspb.Message.getField(message, 6));
+    if (f != null) {
+        writer.writeString(6, f);
+    }
+};
+
+/**
+ * optional string owner = 1;
+ * @return {string}
+ */
+proto.wsman.WorkspaceMetadata.prototype.getOwner = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceMetadata} returns this
+ */
+proto.wsman.WorkspaceMetadata.prototype.setOwner = function (value) {
+    return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+/**
+ * optional string meta_id = 2;
+ * @return {string}
+ */
+proto.wsman.WorkspaceMetadata.prototype.getMetaId = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceMetadata} returns this
+ */
+proto.wsman.WorkspaceMetadata.prototype.setMetaId = function (value) {
+    return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+/**
+ * optional google.protobuf.Timestamp started_at = 3;
+ * @return {?proto.google.protobuf.Timestamp}
+ */
+proto.wsman.WorkspaceMetadata.prototype.getStartedAt = function () {
+    return /** @type{?proto.google.protobuf.Timestamp} */ (
+        jspb.Message.getWrapperField(this, google_protobuf_timestamp_pb.Timestamp, 3)
+    );
+};
+
+/**
+ * @param {?proto.google.protobuf.Timestamp|undefined} value
+ * @return {!proto.wsman.WorkspaceMetadata} returns this
+ */
+proto.wsman.WorkspaceMetadata.prototype.setStartedAt = function (value) {
+    return jspb.Message.setWrapperField(this, 3, value);
+};
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.WorkspaceMetadata} returns this
+ */
+proto.wsman.WorkspaceMetadata.prototype.clearStartedAt = function () {
+    return this.setStartedAt(undefined);
+};
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.WorkspaceMetadata.prototype.hasStartedAt = function () {
+    return jspb.Message.getField(this, 3) != null;
+};
+
+/**
+ * map<string, string> annotations = 4;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<string,string>}
+ */
+proto.wsman.WorkspaceMetadata.prototype.getAnnotationsMap = function (opt_noLazyCreate) {
+    return /** @type {!jspb.Map<string,string>} */ (jspb.Message.getMapField(this, 4, opt_noLazyCreate, null));
+};
+
+/**
+ * Clears values from the map. The map will be non-null.
+ * @return {!proto.wsman.WorkspaceMetadata} returns this
+ */
+proto.wsman.WorkspaceMetadata.prototype.clearAnnotationsMap = function () {
+    this.getAnnotationsMap().clear();
+    return this;
+};
+
+/**
+ * optional string team = 5;
+ * @return {string}
+ */
+proto.wsman.WorkspaceMetadata.prototype.getTeam = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceMetadata} returns this
+ */
+proto.wsman.WorkspaceMetadata.prototype.setTeam = function (value) {
+    return jspb.Message.setField(this, 5, value);
+};
+
+/**
+ * Clears the field making it undefined.
+ * @return {!proto.wsman.WorkspaceMetadata} returns this
+ */
+proto.wsman.WorkspaceMetadata.prototype.clearTeam = function () {
+    return jspb.Message.setField(this, 5, undefined);
+};
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.WorkspaceMetadata.prototype.hasTeam = function () {
+    return jspb.Message.getField(this, 5) != null;
+};
+
+/**
+ * optional string project = 6;
+ * @return {string}
+ */
+proto.wsman.WorkspaceMetadata.prototype.getProject = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 6, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceMetadata} returns this
+ */
+proto.wsman.WorkspaceMetadata.prototype.setProject = function (value) {
+    return jspb.Message.setField(this, 6, value);
+};
+
+/**
+ * Clears the field making it undefined.
+ * @return {!proto.wsman.WorkspaceMetadata} returns this
+ */
+proto.wsman.WorkspaceMetadata.prototype.clearProject = function () {
+    return jspb.Message.setField(this, 6, undefined);
+};
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.WorkspaceMetadata.prototype.hasProject = function () {
+    return jspb.Message.getField(this, 6) != null;
+};
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.wsman.WorkspaceRuntimeInfo.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.WorkspaceRuntimeInfo.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.wsman.WorkspaceRuntimeInfo} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.wsman.WorkspaceRuntimeInfo.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                nodeName: jspb.Message.getFieldWithDefault(msg, 1, ""),
+                podName: jspb.Message.getFieldWithDefault(msg, 2, ""),
+                nodeIp: jspb.Message.getFieldWithDefault(msg, 3, ""),
+            };
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.wsman.WorkspaceRuntimeInfo}
+ */
+proto.wsman.WorkspaceRuntimeInfo.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.wsman.WorkspaceRuntimeInfo();
+    return proto.wsman.WorkspaceRuntimeInfo.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.wsman.WorkspaceRuntimeInfo} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.wsman.WorkspaceRuntimeInfo}
+ */
+proto.wsman.WorkspaceRuntimeInfo.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setNodeName(value);
+                break;
+            case 2:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setPodName(value);
+                break;
+            case 3:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setNodeIp(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.wsman.WorkspaceRuntimeInfo.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.wsman.WorkspaceRuntimeInfo.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.wsman.WorkspaceRuntimeInfo} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.wsman.WorkspaceRuntimeInfo.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getNodeName();
+    if (f.length > 0) {
+        writer.writeString(1, f);
+    }
+    f = message.getPodName();
+    if (f.length > 0) {
+        writer.writeString(2, f);
+    }
+    f = message.getNodeIp();
+    if (f.length > 0) {
+        writer.writeString(3, f);
+    }
+};
+
+/**
+ * optional string node_name = 1;
+ * @return {string}
+ */
+proto.wsman.WorkspaceRuntimeInfo.prototype.getNodeName = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceRuntimeInfo} returns this
+ */
+proto.wsman.WorkspaceRuntimeInfo.prototype.setNodeName = function (value) {
+    return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+/**
+ * optional string pod_name = 2;
+ * @return {string}
+ */
+proto.wsman.WorkspaceRuntimeInfo.prototype.getPodName = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceRuntimeInfo} returns this
+ */
+proto.wsman.WorkspaceRuntimeInfo.prototype.setPodName = function (value) {
+    return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+/**
+ * optional string node_ip = 3;
+ * @return {string}
+ */
+proto.wsman.WorkspaceRuntimeInfo.prototype.getNodeIp = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceRuntimeInfo} returns this
+ */
+proto.wsman.WorkspaceRuntimeInfo.prototype.setNodeIp = function (value) {
+    return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.wsman.WorkspaceAuthentication.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.WorkspaceAuthentication.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.wsman.WorkspaceAuthentication} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.wsman.WorkspaceAuthentication.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                admission: jspb.Message.getFieldWithDefault(msg, 1, 0),
+                ownerToken: jspb.Message.getFieldWithDefault(msg, 2, ""),
+            };
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.wsman.WorkspaceAuthentication}
+ */
+proto.wsman.WorkspaceAuthentication.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.wsman.WorkspaceAuthentication();
+    return proto.wsman.WorkspaceAuthentication.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.wsman.WorkspaceAuthentication} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.wsman.WorkspaceAuthentication}
+ */
+proto.wsman.WorkspaceAuthentication.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {!proto.wsman.AdmissionLevel} */ (reader.readEnum());
+                msg.setAdmission(value);
+                break;
+            case 2:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setOwnerToken(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.wsman.WorkspaceAuthentication.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.wsman.WorkspaceAuthentication.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.wsman.WorkspaceAuthentication} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.wsman.WorkspaceAuthentication.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getAdmission();
+    if (f !== 0.0) {
+        writer.writeEnum(1, f);
+    }
+    f = message.getOwnerToken();
+    if (f.length > 0) {
+        writer.writeString(2, f);
+    }
+};
+
+/**
+ * optional AdmissionLevel admission = 1;
+ * @return {!proto.wsman.AdmissionLevel}
+ */
+proto.wsman.WorkspaceAuthentication.prototype.getAdmission = function () {
+    return /** @type {!proto.wsman.AdmissionLevel} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+/**
+ * @param {!proto.wsman.AdmissionLevel} value
+ * @return {!proto.wsman.WorkspaceAuthentication} returns this
+ */
+proto.wsman.WorkspaceAuthentication.prototype.setAdmission = function (value) {
+    return jspb.Message.setProto3EnumField(this, 1, value);
+};
+
+/**
+ * optional string owner_token = 2;
+ * @return {string}
+ */
+proto.wsman.WorkspaceAuthentication.prototype.getOwnerToken = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.WorkspaceAuthentication} returns this
+ */
+proto.wsman.WorkspaceAuthentication.prototype.setOwnerToken = function (value) {
+    return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.wsman.StartWorkspaceSpec.repeatedFields_ = [3, 5, 6, 15, 16, 17, 18];
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.wsman.StartWorkspaceSpec.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.StartWorkspaceSpec.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.wsman.StartWorkspaceSpec} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.wsman.StartWorkspaceSpec.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                workspaceImage: jspb.Message.getFieldWithDefault(msg, 1, ""),
+                deprecatedIdeImage: jspb.Message.getFieldWithDefault(msg, 2, ""),
+                featureFlagsList: (f = jspb.Message.getRepeatedField(msg, 3)) == null ? undefined : f,
+                initializer:
+                    (f = msg.getInitializer()) &&
+                    content$service$api_initializer_pb.WorkspaceInitializer.toObject(includeInstance, f),
+                portsList: jspb.Message.toObjectList(
+                    msg.getPortsList(),
+                    proto.wsman.PortSpec.toObject,
+                    includeInstance,
+                ),
+                envvarsList: jspb.Message.toObjectList(
+                    msg.getEnvvarsList(),
+                    proto.wsman.EnvironmentVariable.toObject,
+                    includeInstance,
+                ),
+                workspaceLocation: jspb.Message.getFieldWithDefault(msg, 8, ""),
+                git: (f = msg.getGit()) && proto.wsman.GitSpec.toObject(includeInstance, f),
+                timeout: jspb.Message.getFieldWithDefault(msg, 10, ""),
+                admission: jspb.Message.getFieldWithDefault(msg, 11, 0),
+                ideImage: (f = msg.getIdeImage()) && proto.wsman.IDEImage.toObject(includeInstance, f),
+                pb_class: jspb.Message.getFieldWithDefault(msg, 13, ""),
+                volumeSnapshot:
+                    (f = msg.getVolumeSnapshot()) && proto.wsman.VolumeSnapshotInfo.toObject(includeInstance, f),
+                sshPublicKeysList: (f = jspb.Message.getRepeatedField(msg, 15)) == null ? undefined : f,
+                sysEnvvarsList: jspb.Message.toObjectList(
+                    msg.getSysEnvvarsList(),
+                    proto.wsman.EnvironmentVariable.toObject,
+                    includeInstance,
+                ),
+                ideImageLayersList: (f = jspb.Message.getRepeatedField(msg, 17)) == null ? undefined : f,
+                sidecarsList: jspb.Message.toObjectList(
+                    msg.getSidecarsList(),
+                    proto.wsman.SidecarSpec.toObject,
+                    includeInstance,
+                ),
+            };
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.wsman.StartWorkspaceSpec}
+ */
+proto.wsman.StartWorkspaceSpec.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.wsman.StartWorkspaceSpec();
+    return proto.wsman.StartWorkspaceSpec.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.wsman.StartWorkspaceSpec} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.wsman.StartWorkspaceSpec}
+ */
+proto.wsman.StartWorkspaceSpec.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setWorkspaceImage(value);
+                break;
+            case 2:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setDeprecatedIdeImage(value);
+                break;
+            case 3:
+                var values = /** @type {!Array<!proto.wsman.WorkspaceFeatureFlag>} */ (
+                    reader.isDelimited() ? reader.readPackedEnum() : [reader.readEnum()]
+                );
+                for (var i = 0; i < values.length; i++) {
+                    msg.addFeatureFlags(values[i]);
+                }
+                break;
+            case 4:
+                var value = new content$service$api_initializer_pb.WorkspaceInitializer();
+                reader.readMessage(
+                    value,
+                    content$service$api_initializer_pb.WorkspaceInitializer.deserializeBinaryFromReader,
+                );
+                msg.setInitializer(value);
+                break;
+            case 5:
+                var value = new proto.wsman.PortSpec();
+                reader.readMessage(value, proto.wsman.PortSpec.deserializeBinaryFromReader);
+                msg.addPorts(value);
+                break;
+            case 6:
+                var value = new proto.wsman.EnvironmentVariable();
+                reader.readMessage(value, proto.wsman.EnvironmentVariable.deserializeBinaryFromReader);
+                msg.addEnvvars(value);
+                break;
+            case 8:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setWorkspaceLocation(value);
+                break;
+            case 9:
+                var value = new proto.wsman.GitSpec();
+                reader.readMessage(value, proto.wsman.GitSpec.deserializeBinaryFromReader);
+                msg.setGit(value);
+                break;
+            case 10:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setTimeout(value);
+                break;
+            case 11:
+                var value = /** @type {!proto.wsman.AdmissionLevel} */ (reader.readEnum());
+                msg.setAdmission(value);
+                break;
+            case 12:
+                var value = new proto.wsman.IDEImage();
+                reader.readMessage(value, proto.wsman.IDEImage.deserializeBinaryFromReader);
+                msg.setIdeImage(value);
+                break;
+            case 13:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setClass(value);
+                break;
+            case 14:
+                var value = new proto.wsman.VolumeSnapshotInfo();
+                reader.readMessage(value, proto.wsman.VolumeSnapshotInfo.deserializeBinaryFromReader);
+                msg.setVolumeSnapshot(value);
+                break;
+            case 15:
+                var value = /** @type {string} */ (reader.readString());
+                msg.addSshPublicKeys(value);
+                break;
+            case 16:
+                var value = new proto.wsman.EnvironmentVariable();
+                reader.readMessage(value, proto.wsman.EnvironmentVariable.deserializeBinaryFromReader);
+                msg.addSysEnvvars(value);
+                break;
+            case 17:
+                var value = /** @type {string} */ (reader.readString());
+                msg.addIdeImageLayers(value);
+                break;
+            case 18:
+                var value = new proto.wsman.SidecarSpec();
+                reader.readMessage(value, proto.wsman.SidecarSpec.deserializeBinaryFromReader);
+                msg.addSidecars(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.wsman.StartWorkspaceSpec.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.wsman.StartWorkspaceSpec} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.wsman.StartWorkspaceSpec.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getWorkspaceImage();
+    if (f.length > 0) {
+        writer.writeString(1, f);
+    }
+    f = message.getDeprecatedIdeImage();
+    if (f.length > 0) {
+        writer.writeString(2, f);
+    }
+    f = message.getFeatureFlagsList();
+    if (f.length > 0) {
+        writer.writePackedEnum(3, f);
+    }
+    f = message.getInitializer();
+    if (f != null) {
+        writer.writeMessage(4, f, content$service$api_initializer_pb.WorkspaceInitializer.serializeBinaryToWriter);
+    }
+    f = message.getPortsList();
+    if (f.length > 0) {
+        writer.writeRepeatedMessage(5, f, proto.wsman.PortSpec.serializeBinaryToWriter);
+    }
+    f = message.getEnvvarsList();
+    if (f.length > 0) {
+        writer.writeRepeatedMessage(6, f, proto.wsman.EnvironmentVariable.serializeBinaryToWriter);
+    }
+    f = message.getWorkspaceLocation();
+    if (f.length > 0) {
+        writer.writeString(8, f);
+    }
+    f = message.getGit();
+    if (f != null) {
+        writer.writeMessage(9, f, proto.wsman.GitSpec.serializeBinaryToWriter);
+    }
+    f = message.getTimeout();
+    if (f.length > 0) {
+        writer.writeString(10, f);
+    }
+    f = message.getAdmission();
+    if (f !== 0.0) {
+        writer.writeEnum(11, f);
+    }
+    f = message.getIdeImage();
+    if (f != null) {
+        writer.writeMessage(12, f, proto.wsman.IDEImage.serializeBinaryToWriter);
+    }
+    f = message.getClass();
+    if (f.length > 0) {
+        writer.writeString(13, f);
+    }
+    f = message.getVolumeSnapshot();
+    if (f != null) {
+        writer.writeMessage(14, f, proto.wsman.VolumeSnapshotInfo.serializeBinaryToWriter);
+    }
+    f = message.getSshPublicKeysList();
+    if (f.length > 0) {
+        writer.writeRepeatedString(15, f);
+    }
+    f = message.getSysEnvvarsList();
+    if (f.length > 0) {
+        writer.writeRepeatedMessage(16, f, proto.wsman.EnvironmentVariable.serializeBinaryToWriter);
+    }
+    f = message.getIdeImageLayersList();
+    if (f.length > 0) {
+        writer.writeRepeatedString(17, f);
+    }
+    f = message.getSidecarsList();
+    if (f.length > 0) {
+        writer.writeRepeatedMessage(18, f, proto.wsman.SidecarSpec.serializeBinaryToWriter);
+    }
+};
+
+/**
+ * optional string workspace_image = 1;
+ * @return {string}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getWorkspaceImage = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.setWorkspaceImage = function (value) {
+    return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+/**
+ * optional string deprecated_ide_image = 2;
+ * @return {string}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getDeprecatedIdeImage = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.setDeprecatedIdeImage = function (value) {
+    return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+/**
+ * repeated WorkspaceFeatureFlag feature_flags = 3;
+ * @return {!Array<!proto.wsman.WorkspaceFeatureFlag>}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getFeatureFlagsList = function () {
+    return /** @type {!Array<!proto.wsman.WorkspaceFeatureFlag>} */ (jspb.Message.getRepeatedField(this, 3));
+};
+
+/**
+ * @param {!Array<!proto.wsman.WorkspaceFeatureFlag>} value
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.setFeatureFlagsList = function (value) {
+    return jspb.Message.setField(this, 3, value || []);
+};
+
+/**
+ * @param {!proto.wsman.WorkspaceFeatureFlag} value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.addFeatureFlags = function (value, opt_index) {
+    return jspb.Message.addToRepeatedField(this, 3, value, opt_index);
+};
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.clearFeatureFlagsList = function () {
+    return this.setFeatureFlagsList([]);
+};
+
+/**
+ * optional contentservice.WorkspaceInitializer initializer = 4;
+ * @return {?proto.contentservice.WorkspaceInitializer}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getInitializer = function () {
+    return /** @type{?proto.contentservice.WorkspaceInitializer} */ (
+        jspb.Message.getWrapperField(this, content$service$api_initializer_pb.WorkspaceInitializer, 4)
+    );
+};
+
+/**
+ * @param {?proto.contentservice.WorkspaceInitializer|undefined} value
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.setInitializer = function (value) {
+    return jspb.Message.setWrapperField(this, 4, value);
+};
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.clearInitializer = function () {
+    return this.setInitializer(undefined);
+};
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.hasInitializer = function () {
+    return jspb.Message.getField(this, 4) != null;
+};
+
+/**
+ * repeated PortSpec ports = 5;
+ * @return {!Array<!proto.wsman.PortSpec>}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getPortsList = function () {
+    return /** @type{!Array<!proto.wsman.PortSpec>} */ (
+        jspb.Message.getRepeatedWrapperField(this, proto.wsman.PortSpec, 5)
+    );
+};
+
+/**
+ * @param {!Array<!proto.wsman.PortSpec>} value
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.setPortsList = function (value) {
+    return jspb.Message.setRepeatedWrapperField(this, 5, value);
+};
+
+/**
+ * @param {!proto.wsman.PortSpec=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.PortSpec}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.addPorts = function (opt_value, opt_index) {
+    return jspb.Message.addToRepeatedWrapperField(this, 5, opt_value, proto.wsman.PortSpec, opt_index);
+};
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.clearPortsList = function () {
+    return this.setPortsList([]);
+};
+
+/**
+ * repeated EnvironmentVariable envvars = 6;
+ * @return {!Array<!proto.wsman.EnvironmentVariable>}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getEnvvarsList = function () {
+    return /** @type{!Array<!proto.wsman.EnvironmentVariable>} */ (
+        jspb.Message.getRepeatedWrapperField(this, proto.wsman.EnvironmentVariable, 6)
+    );
+};
+
+/**
+ * @param {!Array<!proto.wsman.EnvironmentVariable>} value
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.setEnvvarsList = function (value) {
+    return jspb.Message.setRepeatedWrapperField(this, 6, value);
+};
+
+/**
+ * @param {!proto.wsman.EnvironmentVariable=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.EnvironmentVariable}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.addEnvvars = function (opt_value, opt_index) {
+    return jspb.Message.addToRepeatedWrapperField(this, 6, opt_value, proto.wsman.EnvironmentVariable, opt_index);
+};
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.clearEnvvarsList = function () {
+    return this.setEnvvarsList([]);
+};
+
+/**
+ * optional string workspace_location = 8;
+ * @return {string}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getWorkspaceLocation = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 8, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.setWorkspaceLocation = function (value) {
+    return jspb.Message.setProto3StringField(this, 8, value);
+};
+
+/**
+ * optional GitSpec git = 9;
+ * @return {?proto.wsman.GitSpec}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getGit = function () {
+    return /** @type{?proto.wsman.GitSpec} */ (jspb.Message.getWrapperField(this, proto.wsman.GitSpec, 9));
+};
+
+/**
+ * @param {?proto.wsman.GitSpec|undefined} value
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.setGit = function (value) {
+    return jspb.Message.setWrapperField(this, 9, value);
+};
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.clearGit = function () {
+    return this.setGit(undefined);
+};
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.hasGit = function () {
+    return jspb.Message.getField(this, 9) != null;
+};
+
+/**
+ * optional string timeout = 10;
+ * @return {string}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getTimeout = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 10, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.setTimeout = function (value) {
+    return jspb.Message.setProto3StringField(this, 10, value);
+};
+
+/**
+ * optional AdmissionLevel admission = 11;
+ * @return {!proto.wsman.AdmissionLevel}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getAdmission = function () {
+    return /** @type {!proto.wsman.AdmissionLevel} */ (jspb.Message.getFieldWithDefault(this, 11, 0));
+};
+
+/**
+ * @param {!proto.wsman.AdmissionLevel} value
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.setAdmission = function (value) {
+    return jspb.Message.setProto3EnumField(this, 11, value);
+};
+
+/**
+ * optional IDEImage ide_image = 12;
+ * @return {?proto.wsman.IDEImage}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getIdeImage = function () {
+    return /** @type{?proto.wsman.IDEImage} */ (jspb.Message.getWrapperField(this, proto.wsman.IDEImage, 12));
+};
+
+/**
+ * @param {?proto.wsman.IDEImage|undefined} value
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.setIdeImage = function (value) {
+    return jspb.Message.setWrapperField(this, 12, value);
+};
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.clearIdeImage = function () {
+    return this.setIdeImage(undefined);
+};
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.hasIdeImage = function () {
+    return jspb.Message.getField(this, 12) != null;
+};
+
+/**
+ * optional string class = 13;
+ * @return {string}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getClass = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 13, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ */
+proto.wsman.StartWorkspaceSpec.prototype.setClass = function (value) {
+    return jspb.Message.setProto3StringField(this, 13, value);
+};
+
+/**
+ * optional VolumeSnapshotInfo volume_snapshot = 14;
+ * @return {?proto.wsman.VolumeSnapshotInfo}
+ */
+proto.wsman.StartWorkspaceSpec.prototype.getVolumeSnapshot = function () {
+    return /** @type{?proto.wsman.VolumeSnapshotInfo} */ (
+        jspb.Message.getWrapperField(this, proto.wsman.VolumeSnapshotInfo, 14)
+    );
+};
+
+/**
+ * @param {?proto.wsman.VolumeSnapshotInfo|undefined} value
  * @return {!proto.wsman.StartWorkspaceSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setWorkspaceImage = function (value) {
-    return jspb.Message.setProto3StringField(this, 1, value);
+proto.wsman.StartWorkspaceSpec.prototype.setVolumeSnapshot = function (value) {
+    return jspb.Message.setWrapperField(this, 14, value);
 };
 
 /**
- * optional string deprecated_ide_image = 2;
- * @return {string}
+ * Clears the message field making it undefined.
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.getDeprecatedIdeImage = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+proto.wsman.StartWorkspaceSpec.prototype.clearVolumeSnapshot = function () {
+    return this.setVolumeSnapshot(undefined);
 };
 
 /**
- * @param {string} value
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.wsman.StartWorkspaceSpec.prototype.setDeprecatedIdeImage = function (value) {
-    return jspb.Message.setProto3StringField(this, 2, value);
+proto.wsman.StartWorkspaceSpec.prototype.hasVolumeSnapshot = function () {
+    return jspb.Message.getField(this, 14) != null;
 };
 
 /**
- * repeated WorkspaceFeatureFlag feature_flags = 3;
- * @return {!Array<!proto.wsman.WorkspaceFeatureFlag>}
+ * repeated string ssh_public_keys = 15;
+ * @return {!Array<string>}
  */
-proto.wsman.StartWorkspaceSpec.prototype.getFeatureFlagsList = function () {
-    return /** @type {!Array<!proto.wsman.WorkspaceFeatureFlag>} */ (jspb.Message.getRepeatedField(this, 3));
+proto.wsman.StartWorkspaceSpec.prototype.getSshPublicKeysList = function () {
+    return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 15));
 };
 
 /**
- * @param {!Array<!proto.wsman.WorkspaceFeatureFlag>} value
+ * @param {!Array<string>} value
  * @return {!proto.wsman.StartWorkspaceSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setFeatureFlagsList = function (value) {
-    return jspb.Message.setField(this, 3, value || []);
+proto.wsman.StartWorkspaceSpec.prototype.setSshPublicKeysList = function (value) {
+    return jspb.Message.setField(this, 15, value || []);
 };
 
 /**
- * @param {!proto.wsman.WorkspaceFeatureFlag} value
+ * @param {string} value
  * @param {number=} opt_index
  * @return {!proto.wsman.StartWorkspaceSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.addFeatureFlags = function (value, opt_index) {
-    return jspb.Message.addToRepeatedField(this, 3, value, opt_index);
+proto.wsman.StartWorkspaceSpec.prototype.addSshPublicKeys = function (value, opt_index) {
+    return jspb.Message.addToRepeatedField(this, 15, value, opt_index);
 };
 
 /**
  * Clears the list making it empty but non-null.
  * @return {!proto.wsman.StartWorkspaceSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.clearFeatureFlagsList = function () {
-    return this.setFeatureFlagsList([]);
+proto.wsman.StartWorkspaceSpec.prototype.clearSshPublicKeysList = function () {
+    return this.setSshPublicKeysList([]);
 };
 
 /**
- * optional contentservice.WorkspaceInitializer initializer = 4;
- * @return {?proto.contentservice.WorkspaceInitializer}
+ * repeated EnvironmentVariable sys_envvars = 16;
+ * @return {!Array<!proto.wsman.EnvironmentVariable>}
  */
-proto.wsman.StartWorkspaceSpec.prototype.getInitializer = function () {
-    return /** @type{?proto.contentservice.WorkspaceInitializer} */ (
-        jspb.Message.getWrapperField(this, content$service$api_initializer_pb.WorkspaceInitializer, 4)
+proto.wsman.StartWorkspaceSpec.prototype.getSysEnvvarsList = function () {
+    return /** @type{!Array<!proto.wsman.EnvironmentVariable>} */ (
+        jspb.Message.getRepeatedWrapperField(this, proto.wsman.EnvironmentVariable, 16)
     );
 };
 
 /**
- * @param {?proto.contentservice.WorkspaceInitializer|undefined} value
+ * @param {!Array<!proto.wsman.EnvironmentVariable>} value
  * @return {!proto.wsman.StartWorkspaceSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setInitializer = function (value) {
-    return jspb.Message.setWrapperField(this, 4, value);
+proto.wsman.StartWorkspaceSpec.prototype.setSysEnvvarsList = function (value) {
+    return jspb.Message.setRepeatedWrapperField(this, 16, value);
 };
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * @param {!proto.wsman.EnvironmentVariable=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.EnvironmentVariable}
  */
-proto.wsman.StartWorkspaceSpec.prototype.clearInitializer = function () {
-    return this.setInitializer(undefined);
+proto.wsman.StartWorkspaceSpec.prototype.addSysEnvvars = function (opt_value, opt_index) {
+    return jspb.Message.addToRepeatedWrapperField(this, 16, opt_value, proto.wsman.EnvironmentVariable, opt_index);
 };
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.hasInitializer = function () {
-    return jspb.Message.getField(this, 4) != null;
+proto.wsman.StartWorkspaceSpec.prototype.clearSysEnvvarsList = function () {
+    return this.setSysEnvvarsList([]);
 };
 
 /**
- * repeated PortSpec ports = 5;
- * @return {!Array<!proto.wsman.PortSpec>}
+ * repeated string ide_image_layers = 17;
+ * @return {!Array<string>}
  */
-proto.wsman.StartWorkspaceSpec.prototype.getPortsList = function () {
-    return /** @type{!Array<!proto.wsman.PortSpec>} */ (
-        jspb.Message.getRepeatedWrapperField(this, proto.wsman.PortSpec, 5)
-    );
+proto.wsman.StartWorkspaceSpec.prototype.getIdeImageLayersList = function () {
+    return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 17));
 };
 
 /**
- * @param {!Array<!proto.wsman.PortSpec>} value
+ * @param {!Array<string>} value
  * @return {!proto.wsman.StartWorkspaceSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setPortsList = function (value) {
-    return jspb.Message.setRepeatedWrapperField(this, 5, value);
+proto.wsman.StartWorkspaceSpec.prototype.setIdeImageLayersList = function (value) {
+    return jspb.Message.setField(this, 17, value || []);
 };
 
 /**
- * @param {!proto.wsman.PortSpec=} opt_value
+ * @param {string} value
  * @param {number=} opt_index
- * @return {!proto.wsman.PortSpec}
+ * @return {!proto.wsman.StartWorkspaceSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.addPorts = function (opt_value, opt_index) {
-    return jspb.Message.addToRepeatedWrapperField(this, 5, opt_value, proto.wsman.PortSpec, opt_index);
+proto.wsman.StartWorkspaceSpec.prototype.addIdeImageLayers = function (value, opt_index) {
+    return jspb.Message.addToRepeatedField(this, 17, value, opt_index);
 };
 
 /**
  * Clears the list making it empty but non-null.
  * @return {!proto.wsman.StartWorkspaceSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.clearPortsList = function () {
-    return this.setPortsList([]);
+proto.wsman.StartWorkspaceSpec.prototype.clearIdeImageLayersList = function () {
+    return this.setIdeImageLayersList([]);
 };
 
 /**
- * repeated EnvironmentVariable envvars = 6;
- * @return {!Array<!proto.wsman.EnvironmentVariable>}
+ * repeated SidecarSpec sidecars = 18;
+ * @return {!Array<!proto.wsman.SidecarSpec>}
  */
-proto.wsman.StartWorkspaceSpec.prototype.getEnvvarsList = function () {
-    return /** @type{!Array<!proto.wsman.EnvironmentVariable>} */ (
-        jspb.Message.getRepeatedWrapperField(this, proto.wsman.EnvironmentVariable, 6)
+proto.wsman.StartWorkspaceSpec.prototype.getSidecarsList = function () {
+    return /** @type{!Array<!proto.wsman.SidecarSpec>} */ (
+        jspb.Message.getRepeatedWrapperField(this, proto.wsman.SidecarSpec, 18)
     );
 };
 
 /**
- * @param {!Array<!proto.wsman.EnvironmentVariable>} value
+ * @param {!Array<!proto.wsman.SidecarSpec>} value
  * @return {!proto.wsman.StartWorkspaceSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setEnvvarsList = function (value) {
-    return jspb.Message.setRepeatedWrapperField(this, 6, value);
+proto.wsman.StartWorkspaceSpec.prototype.setSidecarsList = function (value) {
+    return jspb.Message.setRepeatedWrapperField(this, 18, value);
 };
 
 /**
- * @param {!proto.wsman.EnvironmentVariable=} opt_value
+ * @param {!proto.wsman.SidecarSpec=} opt_value
  * @param {number=} opt_index
- * @return {!proto.wsman.EnvironmentVariable}
+ * @return {!proto.wsman.SidecarSpec}
  */
-proto.wsman.StartWorkspaceSpec.prototype.addEnvvars = function (opt_value, opt_index) {
-    return jspb.Message.addToRepeatedWrapperField(this, 6, opt_value, proto.wsman.EnvironmentVariable, opt_index);
+proto.wsman.StartWorkspaceSpec.prototype.addSidecars = function (opt_value, opt_index) {
+    return jspb.Message.addToRepeatedWrapperField(this, 18, opt_value, proto.wsman.SidecarSpec, opt_index);
 };
 
 /**
  * Clears the list making it empty but non-null.
  * @return {!proto.wsman.StartWorkspaceSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.clearEnvvarsList = function () {
-    return this.setEnvvarsList([]);
+proto.wsman.StartWorkspaceSpec.prototype.clearSidecarsList = function () {
+    return this.setSidecarsList([]);
 };
 
 /**
- * optional string workspace_location = 8;
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.wsman.SidecarSpec.repeatedFields_ = [3, 4, 5];
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.wsman.SidecarSpec.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.SidecarSpec.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.wsman.SidecarSpec} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.wsman.SidecarSpec.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+                image: jspb.Message.getFieldWithDefault(msg, 2, ""),
+                commandList: (f = jspb.Message.getRepeatedField(msg, 3)) == null ? undefined : f,
+                argsList: (f = jspb.Message.getRepeatedField(msg, 4)) == null ? undefined : f,
+                envvarsList: jspb.Message.toObjectList(
+                    msg.getEnvvarsList(),
+                    proto.wsman.EnvironmentVariable.toObject,
+                    includeInstance,
+                ),
+                resources: (f = msg.getResources()) && proto.wsman.SidecarResources.toObject(includeInstance, f),
+            };
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.wsman.SidecarSpec}
+ */
+proto.wsman.SidecarSpec.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.wsman.SidecarSpec();
+    return proto.wsman.SidecarSpec.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.wsman.SidecarSpec} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.wsman.SidecarSpec}
+ */
+proto.wsman.SidecarSpec.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setName(value);
+                break;
+            case 2:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setImage(value);
+                break;
+            case 3:
+                var value = /** @type {string} */ (reader.readString());
+                msg.addCommand(value);
+                break;
+            case 4:
+                var value = /** @type {string} */ (reader.readString());
+                msg.addArgs(value);
+                break;
+            case 5:
+                var value = new proto.wsman.EnvironmentVariable();
+                reader.readMessage(value, proto.wsman.EnvironmentVariable.deserializeBinaryFromReader);
+                msg.addEnvvars(value);
+                break;
+            case 6:
+                var value = new proto.wsman.SidecarResources();
+                reader.readMessage(value, proto.wsman.SidecarResources.deserializeBinaryFromReader);
+                msg.setResources(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.wsman.SidecarSpec.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.wsman.SidecarSpec.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.wsman.SidecarSpec} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.wsman.SidecarSpec.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getName();
+    if (f.length > 0) {
+        writer.writeString(1, f);
+    }
+    f = message.getImage();
+    if (f.length > 0) {
+        writer.writeString(2, f);
+    }
+    f = message.getCommandList();
+    if (f.length > 0) {
+        writer.writeRepeatedString(3, f);
+    }
+    f = message.getArgsList();
+    if (f.length > 0) {
+        writer.writeRepeatedString(4, f);
+    }
+    f = message.getEnvvarsList();
+    if (f.length > 0) {
+        writer.writeRepeatedMessage(5, f, proto.wsman.EnvironmentVariable.serializeBinaryToWriter);
+    }
+    f = message.getResources();
+    if (f != null) {
+        writer.writeMessage(6, f, proto.wsman.SidecarResources.serializeBinaryToWriter);
+    }
+};
+
+/**
+ * optional string name = 1;
  * @return {string}
  */
-proto.wsman.StartWorkspaceSpec.prototype.getWorkspaceLocation = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 8, ""));
+proto.wsman.SidecarSpec.prototype.getName = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 /**
  * @param {string} value
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * @return {!proto.wsman.SidecarSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setWorkspaceLocation = function (value) {
-    return jspb.Message.setProto3StringField(this, 8, value);
+proto.wsman.SidecarSpec.prototype.setName = function (value) {
+    return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 /**
- * optional GitSpec git = 9;
- * @return {?proto.wsman.GitSpec}
+ * optional string image = 2;
+ * @return {string}
  */
-proto.wsman.StartWorkspaceSpec.prototype.getGit = function () {
-    return /** @type{?proto.wsman.GitSpec} */ (jspb.Message.getWrapperField(this, proto.wsman.GitSpec, 9));
+proto.wsman.SidecarSpec.prototype.getImage = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 /**
- * @param {?proto.wsman.GitSpec|undefined} value
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * @param {string} value
+ * @return {!proto.wsman.SidecarSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setGit = function (value) {
-    return jspb.Message.setWrapperField(this, 9, value);
+proto.wsman.SidecarSpec.prototype.setImage = function (value) {
+    return jspb.Message.setProto3StringField(this, 2, value);
 };
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * repeated string command = 3;
+ * @return {!Array<string>}
  */
-proto.wsman.StartWorkspaceSpec.prototype.clearGit = function () {
-    return this.setGit(undefined);
+proto.wsman.SidecarSpec.prototype.getCommandList = function () {
+    return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 3));
 };
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * @param {!Array<string>} value
+ * @return {!proto.wsman.SidecarSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.hasGit = function () {
-    return jspb.Message.getField(this, 9) != null;
+proto.wsman.SidecarSpec.prototype.setCommandList = function (value) {
+    return jspb.Message.setField(this, 3, value || []);
 };
 
 /**
- * optional string timeout = 10;
- * @return {string}
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.SidecarSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.getTimeout = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 10, ""));
+proto.wsman.SidecarSpec.prototype.addCommand = function (value, opt_index) {
+    return jspb.Message.addToRepeatedField(this, 3, value, opt_index);
 };
 
 /**
- * @param {string} value
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.SidecarSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setTimeout = function (value) {
-    return jspb.Message.setProto3StringField(this, 10, value);
+proto.wsman.SidecarSpec.prototype.clearCommandList = function () {
+    return this.setCommandList([]);
 };
 
 /**
- * optional AdmissionLevel admission = 11;
- * @return {!proto.wsman.AdmissionLevel}
+ * repeated string args = 4;
+ * @return {!Array<string>}
  */
-proto.wsman.StartWorkspaceSpec.prototype.getAdmission = function () {
-    return /** @type {!proto.wsman.AdmissionLevel} */ (jspb.Message.getFieldWithDefault(this, 11, 0));
+proto.wsman.SidecarSpec.prototype.getArgsList = function () {
+    return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 4));
 };
 
 /**
- * @param {!proto.wsman.AdmissionLevel} value
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * @param {!Array<string>} value
+ * @return {!proto.wsman.SidecarSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setAdmission = function (value) {
-    return jspb.Message.setProto3EnumField(this, 11, value);
+proto.wsman.SidecarSpec.prototype.setArgsList = function (value) {
+    return jspb.Message.setField(this, 4, value || []);
 };
 
 /**
- * optional IDEImage ide_image = 12;
- * @return {?proto.wsman.IDEImage}
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.SidecarSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.getIdeImage = function () {
-    return /** @type{?proto.wsman.IDEImage} */ (jspb.Message.getWrapperField(this, proto.wsman.IDEImage, 12));
+proto.wsman.SidecarSpec.prototype.addArgs = function (value, opt_index) {
+    return jspb.Message.addToRepeatedField(this, 4, value, opt_index);
 };
 
 /**
- * @param {?proto.wsman.IDEImage|undefined} value
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.SidecarSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setIdeImage = function (value) {
-    return jspb.Message.setWrapperField(this, 12, value);
+proto.wsman.SidecarSpec.prototype.clearArgsList = function () {
+    return this.setArgsList([]);
 };
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * repeated EnvironmentVariable envvars = 5;
+ * @return {!Array<!proto.wsman.EnvironmentVariable>}
  */
-proto.wsman.StartWorkspaceSpec.prototype.clearIdeImage = function () {
-    return this.setIdeImage(undefined);
+proto.wsman.SidecarSpec.prototype.getEnvvarsList = function () {
+    return /** @type{!Array<!proto.wsman.EnvironmentVariable>} */ (
+        jspb.Message.getRepeatedWrapperField(this, proto.wsman.EnvironmentVariable, 5)
+    );
 };
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * @param {!Array<!proto.wsman.EnvironmentVariable>} value
+ * @return {!proto.wsman.SidecarSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.hasIdeImage = function () {
-    return jspb.Message.getField(this, 12) != null;
+proto.wsman.SidecarSpec.prototype.setEnvvarsList = function (value) {
+    return jspb.Message.setRepeatedWrapperField(this, 5, value);
 };
 
 /**
- * optional string class = 13;
- * @return {string}
+ * @param {!proto.wsman.EnvironmentVariable=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.wsman.EnvironmentVariable}
  */
-proto.wsman.StartWorkspaceSpec.prototype.getClass = function () {
-    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 13, ""));
+proto.wsman.SidecarSpec.prototype.addEnvvars = function (opt_value, opt_index) {
+    return jspb.Message.addToRepeatedWrapperField(this, 5, opt_value, proto.wsman.EnvironmentVariable, opt_index);
 };
 
 /**
- * @param {string} value
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * Clears the list making it empty but non-null.
+ * @return {!proto.wsman.SidecarSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setClass = function (value) {
-    return jspb.Message.setProto3StringField(this, 13, value);
+proto.wsman.SidecarSpec.prototype.clearEnvvarsList = function () {
+    return this.setEnvvarsList([]);
 };
 
 /**
- * optional VolumeSnapshotInfo volume_snapshot = 14;
- * @return {?proto.wsman.VolumeSnapshotInfo}
+ * optional SidecarResources resources = 6;
+ * @return {?proto.wsman.SidecarResources}
  */
-proto.wsman.StartWorkspaceSpec.prototype.getVolumeSnapshot = function () {
-    return /** @type{?proto.wsman.VolumeSnapshotInfo} */ (
-        jspb.Message.getWrapperField(this, proto.wsman.VolumeSnapshotInfo, 14)
+proto.wsman.SidecarSpec.prototype.getResources = function () {
+    return /** @type{?proto.wsman.SidecarResources} */ (
+        jspb.Message.getWrapperField(this, proto.wsman.SidecarResources, 6)
     );
 };
 
 /**
- * @param {?proto.wsman.VolumeSnapshotInfo|undefined} value
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * @param {?proto.wsman.SidecarResources|undefined} value
+ * @return {!proto.wsman.SidecarSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setVolumeSnapshot = function (value) {
-    return jspb.Message.setWrapperField(this, 14, value);
+proto.wsman.SidecarSpec.prototype.setResources = function (value) {
+    return jspb.Message.setWrapperField(this, 6, value);
 };
 
 /**
  * Clears the message field making it undefined.
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * @return {!proto.wsman.SidecarSpec} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.clearVolumeSnapshot = function () {
-    return this.setVolumeSnapshot(undefined);
+proto.wsman.SidecarSpec.prototype.clearResources = function () {
+    return this.setResources(undefined);
 };
 
 /**
  * Returns whether this field is set.
  * @return {boolean}
  */
-proto.wsman.StartWorkspaceSpec.prototype.hasVolumeSnapshot = function () {
-    return jspb.Message.getField(this, 14) != null;
+proto.wsman.SidecarSpec.prototype.hasResources = function () {
+    return jspb.Message.getField(this, 6) != null;
 };
 
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.wsman.SidecarResources.prototype.toObject = function (opt_includeInstance) {
+        return proto.wsman.SidecarResources.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.wsman.SidecarResources} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.wsman.SidecarResources.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                cpuRequest: jspb.Message.getFieldWithDefault(msg, 1, ""),
+                memoryRequest: jspb.Message.getFieldWithDefault(msg, 2, ""),
+                cpuLimit: jspb.Message.getFieldWithDefault(msg, 3, ""),
+                memoryLimit: jspb.Message.getFieldWithDefault(msg, 4, ""),
+            };
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
 /**
- * repeated string ssh_public_keys = 15;
- * @return {!Array<string>}
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.wsman.SidecarResources}
  */
-proto.wsman.StartWorkspaceSpec.prototype.getSshPublicKeysList = function () {
-    return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 15));
+proto.wsman.SidecarResources.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.wsman.SidecarResources();
+    return proto.wsman.SidecarResources.deserializeBinaryFromReader(msg, reader);
 };
 
 /**
- * @param {!Array<string>} value
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.wsman.SidecarResources} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.wsman.SidecarResources}
  */
-proto.wsman.StartWorkspaceSpec.prototype.setSshPublicKeysList = function (value) {
-    return jspb.Message.setField(this, 15, value || []);
+proto.wsman.SidecarResources.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setCpuRequest(value);
+                break;
+            case 2:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setMemoryRequest(value);
+                break;
+            case 3:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setCpuLimit(value);
+                break;
+            case 4:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setMemoryLimit(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
 };
 
 /**
- * @param {string} value
- * @param {number=} opt_index
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
  */
-proto.wsman.StartWorkspaceSpec.prototype.addSshPublicKeys = function (value, opt_index) {
-    return jspb.Message.addToRepeatedField(this, 15, value, opt_index);
+proto.wsman.SidecarResources.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.wsman.SidecarResources.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
 };
 
 /**
- * Clears the list making it empty but non-null.
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.wsman.SidecarResources} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.wsman.StartWorkspaceSpec.prototype.clearSshPublicKeysList = function () {
-    return this.setSshPublicKeysList([]);
+proto.wsman.SidecarResources.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getCpuRequest();
+    if (f.length > 0) {
+        writer.writeString(1, f);
+    }
+    f = message.getMemoryRequest();
+    if (f.length > 0) {
+        writer.writeString(2, f);
+    }
+    f = message.getCpuLimit();
+    if (f.length > 0) {
+        writer.writeString(3, f);
+    }
+    f = message.getMemoryLimit();
+    if (f.length > 0) {
+        writer.writeString(4, f);
+    }
 };
 
 /**
- * repeated EnvironmentVariable sys_envvars = 16;
- * @return {!Array<!proto.wsman.EnvironmentVariable>}
+ * optional string cpu_request = 1;
+ * @return {string}
  */
-proto.wsman.StartWorkspaceSpec.prototype.getSysEnvvarsList = function () {
-    return /** @type{!Array<!proto.wsman.EnvironmentVariable>} */ (
-        jspb.Message.getRepeatedWrapperField(this, proto.wsman.EnvironmentVariable, 16)
-    );
+proto.wsman.SidecarResources.prototype.getCpuRequest = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 /**
- * @param {!Array<!proto.wsman.EnvironmentVariable>} value
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * @param {string} value
+ * @return {!proto.wsman.SidecarResources} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setSysEnvvarsList = function (value) {
-    return jspb.Message.setRepeatedWrapperField(this, 16, value);
+proto.wsman.SidecarResources.prototype.setCpuRequest = function (value) {
+    return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 /**
- * @param {!proto.wsman.EnvironmentVariable=} opt_value
- * @param {number=} opt_index
- * @return {!proto.wsman.EnvironmentVariable}
+ * optional string memory_request = 2;
+ * @return {string}
  */
-proto.wsman.StartWorkspaceSpec.prototype.addSysEnvvars = function (opt_value, opt_index) {
-    return jspb.Message.addToRepeatedWrapperField(this, 16, opt_value, proto.wsman.EnvironmentVariable, opt_index);
+proto.wsman.SidecarResources.prototype.getMemoryRequest = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 /**
- * Clears the list making it empty but non-null.
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * @param {string} value
+ * @return {!proto.wsman.SidecarResources} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.clearSysEnvvarsList = function () {
-    return this.setSysEnvvarsList([]);
+proto.wsman.SidecarResources.prototype.setMemoryRequest = function (value) {
+    return jspb.Message.setProto3StringField(this, 2, value);
 };
 
 /**
- * repeated string ide_image_layers = 17;
- * @return {!Array<string>}
+ * optional string cpu_limit = 3;
+ * @return {string}
  */
-proto.wsman.StartWorkspaceSpec.prototype.getIdeImageLayersList = function () {
-    return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 17));
+proto.wsman.SidecarResources.prototype.getCpuLimit = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
 };
 
 /**
- * @param {!Array<string>} value
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * @param {string} value
+ * @return {!proto.wsman.SidecarResources} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.setIdeImageLayersList = function (value) {
-    return jspb.Message.setField(this, 17, value || []);
+proto.wsman.SidecarResources.prototype.setCpuLimit = function (value) {
+    return jspb.Message.setProto3StringField(this, 3, value);
 };
 
 /**
- * @param {string} value
- * @param {number=} opt_index
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * optional string memory_limit = 4;
+ * @return {string}
  */
-proto.wsman.StartWorkspaceSpec.prototype.addIdeImageLayers = function (value, opt_index) {
-    return jspb.Message.addToRepeatedField(this, 17, value, opt_index);
+proto.wsman.SidecarResources.prototype.getMemoryLimit = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
 };
 
 /**
- * Clears the list making it empty but non-null.
- * @return {!proto.wsman.StartWorkspaceSpec} returns this
+ * @param {string} value
+ * @return {!proto.wsman.SidecarResources} returns this
  */
-proto.wsman.StartWorkspaceSpec.prototype.clearIdeImageLayersList = function () {
-    return this.setIdeImageLayersList([]);
+proto.wsman.SidecarResources.prototype.setMemoryLimit = function (value) {
+    return jspb.Message.setProto3StringField(this, 4, value);
 };
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -9038,12 +10298,23 @@ proto.wsman.AdmissionLevel = {
     ADMIT_EVERYONE: 1,
 };
 
+/**
+ * @enum {number}
+ */
+proto.wsman.SidecarState = {
+    SIDECAR_STATE_UNSPECIFIED: 0,
+    SIDECAR_STATE_WAITING: 1,
+    SIDECAR_STATE_RUNNING: 2,
+    SIDECAR_STATE_TERMINATED: 3,
+};
+
 /**
  * @enum {number}
  */
 proto.wsman.PortVisibility = {
     PORT_VISIBILITY_PRIVATE: 0,
     PORT_VISIBILITY_PUBLIC: 1,
+    PORT_VISIBILITY_SHARED: 2,
 };
 
 /**
@@ -30,6 +30,8 @@ import {
     TakeSnapshotResponse,
     UpdateSSHKeyRequest,
     UpdateSSHKeyResponse,
+    GetSidecarLogsRequest,
+    GetSidecarLogsResponse,
 } from "./core_pb";
 import { TraceContext } from "@gitpod/gitpod-protocol/lib/util/tracing";
 import * as opentracing from "opentracing";
@@ -400,6 +402,30 @@ export class PromisifiedWorkspaceManagerClient implements Disposable {
         );
     }
 
+    public getSidecarLogs(ctx: TraceContext, request: GetSidecarLogsRequest): Promise<GetSidecarLogsResponse> {
+        return this.retryIfUnavailable(
+            (attempt: number) =>
+                new Promise<GetSidecarLogsResponse>((resolve, reject) => {
+                    const span = TraceContext.startSpan(`/ws-manager/getSidecarLogs`, ctx);
+                    span.log({ attempt });
+                    this.client.getSidecarLogs(
+                        request,
+                        withTracing({ span }),
+                        this.getDefaultUnaryOptions(),
+                        (err, resp) => {
+                            span.finish();
+                            if (err) {
+                                TraceContext.setError(ctx, err);
+                                reject(err);
+                            } else {
+                                resolve(resp);
+                            }
+                        },
+                    );
+                }),
+        );
+    }
+
     protected getDefaultUnaryOptions(): Partial<grpc.CallOptions> {
         let deadline = new Date(new Date().getTime() + 30000);
         return {
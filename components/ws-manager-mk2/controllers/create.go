@@ -28,6 +28,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/tracing"
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	regapi "github.com/gitpod-io/gitpod/registry-facade/api"
+	wsapi "github.com/gitpod-io/gitpod/ws-manager/api"
 	config "github.com/gitpod-io/gitpod/ws-manager/api/config"
 	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
 )
@@ -249,6 +250,11 @@ func createDefiniteWorkspacePod(sctx *startWorkspaceContext) (*corev1.Pod, error
 	// However: the whole user workload now runs in a user namespace, which makes this acceptable.
 	workspaceContainer.SecurityContext.AllowPrivilegeEscalation = pointer.Bool(true)
 
+	sidecars, err := createSidecarContainers(sctx)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create sidecar containers: %w", err)
+	}
+
 	workspaceVolume, err := createWorkspaceVolumes(sctx)
 	if err != nil {
 		return nil, xerrors.Errorf("cannot create workspace volumes: %w", err)
@@ -392,6 +398,8 @@ func createDefiniteWorkspacePod(sctx *startWorkspaceContext) (*corev1.Pod, error
 		},
 	}
 
+	affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, affinity.PodAntiAffinity = createSchedulingPreferences(sctx)
+
 	pod := corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        fmt.Sprintf("%s-%s", prefix, sctx.Workspace.Name),
@@ -415,9 +423,7 @@ func createDefiniteWorkspacePod(sctx *startWorkspaceContext) (*corev1.Pod, error
 					LocalhostProfile: pointer.String(sctx.Config.SeccompProfile),
 				},
 			},
-			Containers: []corev1.Container{
-				*workspaceContainer,
-			},
+			Containers:    append([]corev1.Container{*workspaceContainer}, sidecars...),
 			RestartPolicy: corev1.RestartPolicyNever,
 			Volumes:       volumes,
 			Tolerations: []corev1.Toleration{
@@ -446,6 +452,67 @@ func createDefiniteWorkspacePod(sctx *startWorkspaceContext) (*corev1.Pod, error
 	return &pod, nil
 }
 
+// createSchedulingPreferences turns the scheduling hints ws-daemon publishes on the nodes into
+// preferred affinities, as configured for the workspace class.
+func createSchedulingPreferences(sctx *startWorkspaceContext) ([]corev1.PreferredSchedulingTerm, *corev1.PodAntiAffinity) {
+	class, ok := sctx.Config.WorkspaceClasses[sctx.Workspace.Spec.Class]
+	if !ok {
+		return nil, nil
+	}
+	cfg := class.Scheduling
+
+	var nodePrefs []corev1.PreferredSchedulingTerm
+	if imageHash, ok := sctx.Labels[wsk8s.WorkspaceImageHashLabel]; ok && cfg.CachedImageWeight > 0 {
+		nodePrefs = append(nodePrefs, corev1.PreferredSchedulingTerm{
+			Weight: cfg.CachedImageWeight,
+			Preference: corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{
+						Key:      wsk8s.NodeImageHintLabelPrefix + imageHash,
+						Operator: corev1.NodeSelectorOpExists,
+					},
+				},
+			},
+		})
+	}
+	if cfg.FreeDiskWeight > 0 {
+		nodePrefs = append(nodePrefs, corev1.PreferredSchedulingTerm{
+			Weight: cfg.FreeDiskWeight,
+			Preference: corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{
+						Key:      wsk8s.NodeFreeDiskHintLabel,
+						Operator: corev1.NodeSelectorOpGt,
+						Values:   []string{strconv.FormatInt(cfg.MinFreeDiskGiB, 10)},
+					},
+				},
+			},
+		})
+	}
+
+	var antiAffinity *corev1.PodAntiAffinity
+	if team, ok := sctx.Labels[wsk8s.TeamLabel]; ok && cfg.TeamSpreadWeight > 0 {
+		antiAffinity = &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: cfg.TeamSpreadWeight,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"component":     "workspace",
+								wsk8s.TeamLabel: team,
+							},
+						},
+						TopologyKey: "kubernetes.io/hostname",
+					},
+				},
+			},
+		}
+	}
+
+	return nodePrefs, antiAffinity
+}
+
 func createWorkspaceContainer(sctx *startWorkspaceContext) (*corev1.Container, error) {
 	class, ok := sctx.Config.WorkspaceClasses[sctx.Workspace.Spec.Class]
 	if !ok {
@@ -525,6 +592,56 @@ func createWorkspaceContainer(sctx *startWorkspaceContext) (*corev1.Container, e
 	}, nil
 }
 
+// sidecarHost is the address processes in the workspace reach sidecars on. workspacekit moves the workspace
+// into a network namespace of its own, which ws-daemon connects to the pod's through a veth pair. This is the
+// pod's end of that pair, hence sidecars listening on all interfaces can be reached on it.
+const sidecarHost = "10.0.5.1"
+
+// createSidecarContainers produces the additional containers of a workspace. They run in the pod's
+// network namespace, which is not the one of the workspace processes - see sidecarHost.
+func createSidecarContainers(sctx *startWorkspaceContext) ([]corev1.Container, error) {
+	if len(sctx.Workspace.Spec.Sidecars) == 0 {
+		return nil, nil
+	}
+
+	class, ok := sctx.Config.WorkspaceClasses[sctx.Workspace.Spec.Class]
+	if !ok {
+		return nil, xerrors.Errorf("unknown workspace class: %s", sctx.Workspace.Spec.Class)
+	}
+
+	var (
+		res       = make([]corev1.Container, 0, len(sctx.Workspace.Spec.Sidecars))
+		resources = make([]corev1.ResourceRequirements, 0, len(sctx.Workspace.Spec.Sidecars))
+	)
+	for _, spec := range sctx.Workspace.Spec.Sidecars {
+		rr, err := class.Sidecars.SidecarResources(spec.Resources.Requests, spec.Resources.Limits)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, rr)
+
+		res = append(res, corev1.Container{
+			Name:            workspacev1.SidecarContainerPrefix + spec.Name,
+			Image:           spec.Image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         spec.Command,
+			Args:            spec.Args,
+			Env:             spec.Envvars,
+			Resources:       rr,
+			SecurityContext: createSidecarSecurityContext(),
+			// sidecars don't write a termination message - their last log lines end up in the workspace status instead
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		})
+	}
+
+	err := class.Sidecars.CheckBudget(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func createWorkspaceEnvironment(sctx *startWorkspaceContext) ([]corev1.EnvVar, error) {
 	class, ok := sctx.Config.WorkspaceClasses[sctx.Workspace.Spec.Class]
 	if !ok {
@@ -564,6 +681,8 @@ func createWorkspaceEnvironment(sctx *startWorkspaceContext) ([]corev1.EnvVar, e
 	result = append(result, corev1.EnvVar{Name: "GITPOD_WORKSPACE_URL", Value: sctx.Workspace.Status.URL})
 	result = append(result, corev1.EnvVar{Name: "GITPOD_WORKSPACE_CLUSTER_HOST", Value: sctx.Config.WorkspaceClusterHost})
 	result = append(result, corev1.EnvVar{Name: "THEIA_SUPERVISOR_ENDPOINT", Value: fmt.Sprintf(":%d", sctx.SupervisorPort)})
+	// supervisor does not pass THEIA_SUPERVISOR_ variables on to the processes in the workspace
+	result = append(result, corev1.EnvVar{Name: "THEIA_SUPERVISOR_PORT_INSPECTOR_TOKEN", Value: wsapi.PortInspectorToken(sctx.Workspace.Status.OwnerToken)})
 	// TODO(ak) remove THEIA_WEBVIEW_EXTERNAL_ENDPOINT and THEIA_MINI_BROWSER_HOST_PATTERN when Theia is removed
 	result = append(result, corev1.EnvVar{Name: "THEIA_WEBVIEW_EXTERNAL_ENDPOINT", Value: "webview-{{hostname}}"})
 	result = append(result, corev1.EnvVar{Name: "THEIA_MINI_BROWSER_HOST_PATTERN", Value: "browser-{{hostname}}"})
@@ -574,6 +693,10 @@ func createWorkspaceEnvironment(sctx *startWorkspaceContext) ([]corev1.EnvVar, e
 		result = append(result, corev1.EnvVar{Name: "GITPOD_GIT_USER_EMAIL", Value: sctx.Workspace.Spec.Git.Email})
 	}
 
+	if len(sctx.Workspace.Spec.Sidecars) > 0 {
+		result = append(result, corev1.EnvVar{Name: "GITPOD_SIDECAR_HOST", Value: sidecarHost})
+	}
+
 	// User-defined env vars (i.e. those coming from the request)
 	for _, e := range sctx.Workspace.Spec.Envvars {
 		switch e.Name {
@@ -675,6 +798,32 @@ func createDefaultSecurityContext() (*corev1.SecurityContext, error) {
 	return res, nil
 }
 
+// createSidecarSecurityContext confines sidecars more than the workspace container: unlike the user workload
+// they don't run in a user namespace. They run as the user of their image because the entrypoints of common
+// images like postgres or mysql start as root to prepare their data directory and then switch to their own user.
+// Sidecars only keep the capabilities those entrypoints need for that.
+func createSidecarSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: pointer.Bool(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+			Add: []corev1.Capability{
+				"CHOWN",        // Make arbitrary changes to file UIDs and GIDs.
+				"DAC_OVERRIDE", // Bypass file read, write, and execute permission checks.
+				"FOWNER",       // Bypass permission checks on operations that normally require the file system UID of the process to match the UID of the file.
+				"SETUID",       // Make arbitrary manipulations of process UIDs.
+				"SETGID",       // Make arbitrary manipulations of process GIDs and supplementary GID list.
+			},
+		},
+		Privileged: pointer.Bool(false),
+		// The pod's seccomp profile is tailored to the user namespace of the workspace container
+		// and allows syscalls sidecars don't need.
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+}
+
 func newStartWorkspaceContext(ctx context.Context, cfg *config.Configuration, ws *workspacev1.Workspace) (res *startWorkspaceContext, err error) {
 	// we deliberately do not shadow ctx here as we need the original context later to extract the TraceID
 	span, _ := tracing.FromContext(ctx, "newStartWorkspaceContext")
@@ -698,16 +847,24 @@ func newStartWorkspaceContext(ctx context.Context, cfg *config.Configuration, ws
 		}
 	}
 
+	labels := map[string]string{
+		"app":                  "gitpod",
+		"component":            "workspace",
+		wsk8s.WorkspaceIDLabel: ws.Spec.Ownership.WorkspaceID,
+		wsk8s.OwnerLabel:       ws.Spec.Ownership.Owner,
+		wsk8s.TypeLabel:        string(ws.Spec.Type),
+		instanceIDLabel:        ws.Name,
+		headlessLabel:          strconv.FormatBool(ws.Status.Headless),
+	}
+	if ws.Spec.Ownership.Team != "" {
+		labels[wsk8s.TeamLabel] = ws.Spec.Ownership.Team
+	}
+	if ws.Spec.Image.Workspace.Ref != nil && *ws.Spec.Image.Workspace.Ref != "" {
+		labels[wsk8s.WorkspaceImageHashLabel] = wsk8s.WorkspaceImageHash(*ws.Spec.Image.Workspace.Ref)
+	}
+
 	return &startWorkspaceContext{
-		Labels: map[string]string{
-			"app":                  "gitpod",
-			"component":            "workspace",
-			wsk8s.WorkspaceIDLabel: ws.Spec.Ownership.WorkspaceID,
-			wsk8s.OwnerLabel:       ws.Spec.Ownership.Owner,
-			wsk8s.TypeLabel:        string(ws.Spec.Type),
-			instanceIDLabel:        ws.Name,
-			headlessLabel:          strconv.FormatBool(ws.Status.Headless),
-		},
+		Labels:         labels,
 		Config:         cfg,
 		Workspace:      ws,
 		IDEPort:        23000,
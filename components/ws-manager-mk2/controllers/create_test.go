@@ -0,0 +1,197 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
+	"github.com/gitpod-io/gitpod/ws-manager/api/config"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+func TestCreateSchedulingPreferences(t *testing.T) {
+	type Expectation struct {
+		NodePrefs    []corev1.PreferredSchedulingTerm
+		AntiAffinity *corev1.PodAntiAffinity
+	}
+
+	tests := []struct {
+		Name        string
+		Scheduling  config.SchedulingConfiguration
+		Labels      map[string]string
+		Expectation Expectation
+	}{
+		{
+			Name:   "disabled",
+			Labels: map[string]string{wsk8s.WorkspaceImageHashLabel: "abc", wsk8s.TeamLabel: "team"},
+		},
+		{
+			Name: "all hints",
+			Scheduling: config.SchedulingConfiguration{
+				CachedImageWeight: 50,
+				FreeDiskWeight:    10,
+				MinFreeDiskGiB:    100,
+				TeamSpreadWeight:  20,
+			},
+			Labels: map[string]string{wsk8s.WorkspaceImageHashLabel: "abc", wsk8s.TeamLabel: "team"},
+			Expectation: Expectation{
+				NodePrefs: []corev1.PreferredSchedulingTerm{
+					{
+						Weight: 50,
+						Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: wsk8s.NodeImageHintLabelPrefix + "abc", Operator: corev1.NodeSelectorOpExists},
+						}},
+					},
+					{
+						Weight: 10,
+						Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: wsk8s.NodeFreeDiskHintLabel, Operator: corev1.NodeSelectorOpGt, Values: []string{"100"}},
+						}},
+					},
+				},
+				AntiAffinity: &corev1.PodAntiAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+						{
+							Weight: 20,
+							PodAffinityTerm: corev1.PodAffinityTerm{
+								LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{
+									"component":     "workspace",
+									wsk8s.TeamLabel: "team",
+								}},
+								TopologyKey: "kubernetes.io/hostname",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "no team and image",
+			Scheduling: config.SchedulingConfiguration{
+				CachedImageWeight: 50,
+				TeamSpreadWeight:  20,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			sctx := &startWorkspaceContext{
+				Config: &config.Configuration{
+					WorkspaceClasses: map[string]*config.WorkspaceClass{
+						"default": {Scheduling: test.Scheduling},
+					},
+				},
+				Workspace: &workspacev1.Workspace{Spec: workspacev1.WorkspaceSpec{Class: "default"}},
+				Labels:    test.Labels,
+			}
+
+			var act Expectation
+			act.NodePrefs, act.AntiAffinity = createSchedulingPreferences(sctx)
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected scheduling preferences (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreateSidecarContainers(t *testing.T) {
+	cfg := &config.Configuration{
+		WorkspaceClasses: map[string]*config.WorkspaceClass{
+			"default": {
+				Sidecars: config.SidecarConfiguration{
+					MaxCount: 2,
+					Default: config.ContainerConfiguration{
+						Limits: &config.ResourceLimitConfiguration{Memory: "256Mi"},
+					},
+					Budget: &config.ResourceRequestConfiguration{Memory: "1Gi"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		Name          string
+		Sidecars      []workspacev1.SidecarSpec
+		ExpectedNames []string
+		ExpectError   bool
+	}{
+		{
+			Name: "no sidecars",
+		},
+		{
+			Name: "default resources",
+			Sidecars: []workspacev1.SidecarSpec{
+				{Name: "db", Image: "postgres:14"},
+				{Name: "cache", Image: "redis:7", Resources: workspacev1.SidecarResources{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+				}},
+			},
+			ExpectedNames: []string{"sidecar-db", "sidecar-cache"},
+		},
+		{
+			Name: "over budget",
+			Sidecars: []workspacev1.SidecarSpec{
+				{Name: "db", Image: "postgres:14", Resources: workspacev1.SidecarResources{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+				}},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			sctx := &startWorkspaceContext{
+				Config:    cfg,
+				Workspace: &workspacev1.Workspace{Spec: workspacev1.WorkspaceSpec{Class: "default", Sidecars: test.Sidecars}},
+			}
+
+			act, err := createSidecarContainers(sctx)
+			if (err != nil) != test.ExpectError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var names []string
+			for _, c := range act {
+				names = append(names, c.Name)
+				if _, ok := c.Resources.Limits[corev1.ResourceMemory]; !ok {
+					t.Errorf("sidecar %s has no memory limit", c.Name)
+				}
+				sc := c.SecurityContext
+				if sc == nil || sc.RunAsUser != nil || sc.RunAsGroup != nil {
+					t.Errorf("sidecar %s does not run as the user of its image", c.Name)
+				}
+				if sc == nil || sc.Privileged == nil || *sc.Privileged || sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+					t.Errorf("sidecar %s may gain privileges", c.Name)
+				}
+				if sc == nil || sc.Capabilities == nil || !cmp.Equal(sc.Capabilities.Drop, []corev1.Capability{"ALL"}) {
+					t.Errorf("sidecar %s keeps the default capabilities", c.Name)
+				}
+				if sc != nil && sc.Capabilities != nil {
+					for _, cp := range sc.Capabilities.Add {
+						switch cp {
+						case "CHOWN", "DAC_OVERRIDE", "FOWNER", "SETUID", "SETGID":
+						default:
+							t.Errorf("sidecar %s has capability %s", c.Name, cp)
+						}
+					}
+				}
+				if sc == nil || sc.SeccompProfile == nil || sc.SeccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault {
+					t.Errorf("sidecar %s does not use the runtime's default seccomp profile", c.Name)
+				}
+			}
+			if diff := cmp.Diff(test.ExpectedNames, names); diff != "" {
+				t.Errorf("unexpected sidecar containers (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
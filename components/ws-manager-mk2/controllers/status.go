@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -78,6 +79,8 @@ func updateWorkspaceStatus(ctx context.Context, workspace *workspacev1.Workspace
 		workspace.Status.Runtime.PodName = pod.Name
 	}
 
+	workspace.Status.Sidecars = extractSidecarStatus(pod)
+
 	failure, phase := extractFailure(workspace, pod)
 	if phase != nil {
 		workspace.Status.Phase = *phase
@@ -143,7 +146,7 @@ func updateWorkspaceStatus(ctx context.Context, workspace *workspacev1.Workspace
 	case pod.Status.Phase == corev1.PodRunning:
 		var ready bool
 		for _, cs := range pod.Status.ContainerStatuses {
-			if cs.Ready {
+			if cs.Ready && !isSidecarContainer(cs.Name) {
 				ready = true
 				break
 			}
@@ -182,6 +185,11 @@ func extractFailure(ws *workspacev1.Workspace, pod *corev1.Pod) (string, *worksp
 	}
 
 	for _, cs := range status.ContainerStatuses {
+		if isSidecarContainer(cs.Name) {
+			// sidecars don't fail the workspace - their state is reported in the sidecar status
+			continue
+		}
+
 		if cs.State.Waiting != nil {
 			if cs.State.Waiting.Reason == "ImagePullBackOff" || cs.State.Waiting.Reason == "ErrImagePull" {
 				// If the image pull failed we were definitely in the api.WorkspacePhase_CREATING phase,
@@ -246,6 +254,46 @@ func extractFailure(ws *workspacev1.Workspace, pod *corev1.Pod) (string, *worksp
 	return "", nil
 }
 
+// extractSidecarStatus reports the state of the sidecar containers of a workspace pod
+func extractSidecarStatus(pod *corev1.Pod) []workspacev1.SidecarStatus {
+	var res []workspacev1.SidecarStatus
+	for _, cs := range pod.Status.ContainerStatuses {
+		if !isSidecarContainer(cs.Name) {
+			continue
+		}
+
+		s := workspacev1.SidecarStatus{
+			Name:  strings.TrimPrefix(cs.Name, workspacev1.SidecarContainerPrefix),
+			Ready: cs.Ready,
+		}
+		switch {
+		case cs.State.Running != nil:
+			s.State = workspacev1.SidecarStateRunning
+		case cs.State.Terminated != nil:
+			s.State = workspacev1.SidecarStateTerminated
+			s.ExitCode = cs.State.Terminated.ExitCode
+			s.Message = cs.State.Terminated.Message
+			if s.Message == "" {
+				s.Message = cs.State.Terminated.Reason
+			}
+		default:
+			s.State = workspacev1.SidecarStateWaiting
+			if cs.State.Waiting != nil {
+				s.Message = cs.State.Waiting.Message
+				if s.Message == "" {
+					s.Message = cs.State.Waiting.Reason
+				}
+			}
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+func isSidecarContainer(name string) bool {
+	return strings.HasPrefix(name, workspacev1.SidecarContainerPrefix)
+}
+
 // extractFailureFromLogs attempts to extract the last error message from a workspace
 // container's log output.
 func extractFailureFromLogs(logs []byte) string {
@@ -0,0 +1,39 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+func TestExtractSidecarStatus(t *testing.T) {
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "workspace", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+				{Name: "sidecar-db", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+				{Name: "sidecar-cache", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull"}}},
+				{Name: "sidecar-queue", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1,
+					Message:  "panic: cannot open config",
+				}}},
+			},
+		},
+	}
+
+	expectation := []workspacev1.SidecarStatus{
+		{Name: "db", State: workspacev1.SidecarStateRunning, Ready: true},
+		{Name: "cache", State: workspacev1.SidecarStateWaiting, Message: "ErrImagePull"},
+		{Name: "queue", State: workspacev1.SidecarStateTerminated, Message: "panic: cannot open config", ExitCode: 1},
+	}
+	if diff := cmp.Diff(expectation, extractSidecarStatus(pod)); diff != "" {
+		t.Errorf("unexpected sidecar status (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/watch"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+// Document is the policy document which is usually mounted from a ConfigMap.
+type Document struct {
+	// Default is applied to all workspaces whose team has no rules of its own.
+	Default *Rules `json:"default,omitempty"`
+	// Teams maps team IDs to the rules for workspaces owned by that team.
+	Teams map[string]*Rules `json:"teams,omitempty"`
+	// ClassOrder lists the workspace classes from smallest to largest. It's required to enforce MaxClass.
+	ClassOrder []string `json:"classOrder,omitempty"`
+}
+
+// Rules restrict what a workspace spec may contain. Empty fields impose no restriction.
+type Rules struct {
+	// AllowedRegistries lists the registry hosts (e.g. eu.gcr.io) workspace images may come from.
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+	// MaxClass is the largest workspace class (according to ClassOrder) a workspace may use.
+	MaxClass string `json:"maxClass,omitempty"`
+	// AllowedPortVisibility lists the admission levels ports may be exposed with.
+	AllowedPortVisibility []workspacev1.AdmissionLevel `json:"allowedPortVisibility,omitempty"`
+	// RequiredEnvvars must be present on every workspace.
+	RequiredEnvvars []RequiredEnvvar `json:"requiredEnvvars,omitempty"`
+}
+
+// RequiredEnvvar is an environment variable a workspace must have
+type RequiredEnvvar struct {
+	Name string `json:"name"`
+	// Default is added to workspaces which don't set the variable. If Default is empty,
+	// such workspaces are rejected instead.
+	Default string `json:"default,omitempty"`
+}
+
+// Validate ensures the document is internally consistent
+func (d *Document) Validate() error {
+	validate := func(name string, r *Rules) error {
+		if r == nil || r.MaxClass == "" {
+			return nil
+		}
+		if classIndex(d.ClassOrder, r.MaxClass) < 0 {
+			return fmt.Errorf("%s: maxClass %q is not part of classOrder", name, r.MaxClass)
+		}
+		return nil
+	}
+
+	if err := validate("default", d.Default); err != nil {
+		return err
+	}
+	for team, r := range d.Teams {
+		if err := validate("team "+team, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RulesFor returns the rules which apply to a workspace owned by the given team
+func (d *Document) RulesFor(team string) *Rules {
+	if d == nil {
+		return nil
+	}
+	if r, ok := d.Teams[team]; ok && team != "" {
+		return r
+	}
+	return d.Default
+}
+
+// Check returns a list of all policy violations of the workspace
+func (d *Document) Check(ws *workspacev1.Workspace) []string {
+	rules := d.RulesFor(ws.Spec.Ownership.Team)
+	if rules == nil {
+		return nil
+	}
+
+	var violations []string
+	if len(rules.AllowedRegistries) > 0 && ws.Spec.Image.Workspace.Ref != nil {
+		registry := registryHost(*ws.Spec.Image.Workspace.Ref)
+		if !contains(rules.AllowedRegistries, registry) {
+			violations = append(violations, fmt.Sprintf("image registry %q is not allowed", registry))
+		}
+	}
+
+	if rules.MaxClass != "" {
+		cls := ws.Spec.Class
+		if idx := classIndex(d.ClassOrder, cls); idx < 0 || idx > classIndex(d.ClassOrder, rules.MaxClass) {
+			violations = append(violations, fmt.Sprintf("workspace class %q exceeds the maximum class %q", cls, rules.MaxClass))
+		}
+	}
+
+	if len(rules.AllowedPortVisibility) > 0 {
+		for _, p := range ws.Spec.Ports {
+			if !containsLevel(rules.AllowedPortVisibility, p.Visibility) {
+				violations = append(violations, fmt.Sprintf("port %d must not have visibility %s", p.Port, p.Visibility))
+			}
+		}
+	}
+
+	for _, req := range rules.RequiredEnvvars {
+		if !hasEnvvar(ws.Spec.Envvars, req.Name) {
+			violations = append(violations, fmt.Sprintf("required environment variable %s is missing", req.Name))
+		}
+	}
+
+	return violations
+}
+
+// Provider serves the current policy document and reloads it when the file changes
+type Provider struct {
+	mu  sync.RWMutex
+	doc *Document
+}
+
+var _ workspacev1.AdmissionPolicy = &Provider{}
+
+// NewProvider loads the policy document from fn and watches it for changes
+func NewProvider(ctx context.Context, fn string) (*Provider, error) {
+	doc, err := LoadDocument(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Provider{doc: doc}
+	err = watch.File(ctx, fn, func() {
+		doc, err := LoadDocument(fn)
+		if err != nil {
+			log.WithError(err).WithField("path", fn).Warn("cannot reload workspace policy - keeping the previous one")
+			return
+		}
+
+		p.mu.Lock()
+		p.doc = doc
+		p.mu.Unlock()
+		log.WithField("path", fn).Info("reloaded workspace policy")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// NewStaticProvider produces a provider which always serves the same document
+func NewStaticProvider(doc *Document) *Provider {
+	return &Provider{doc: doc}
+}
+
+// LoadDocument reads and validates a policy document
+func LoadDocument(fn string) (*Document, error) {
+	fc, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read workspace policy: %w", err)
+	}
+
+	var doc Document
+	err = json.Unmarshal(fc, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal workspace policy from %s: %w", fn, err)
+	}
+	err = doc.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspace policy: %w", err)
+	}
+
+	return &doc, nil
+}
+
+func (p *Provider) document() *Document {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.doc
+}
+
+// Default adds required environment variables with a default value
+func (p *Provider) Default(ws *workspacev1.Workspace) {
+	rules := p.document().RulesFor(ws.Spec.Ownership.Team)
+	if rules == nil {
+		return
+	}
+
+	for _, req := range rules.RequiredEnvvars {
+		if req.Default == "" || hasEnvvar(ws.Spec.Envvars, req.Name) {
+			continue
+		}
+		ws.Spec.Envvars = append(ws.Spec.Envvars, corev1.EnvVar{Name: req.Name, Value: req.Default})
+	}
+}
+
+// Validate rejects workspaces which violate the policy
+func (p *Provider) Validate(ws *workspacev1.Workspace) error {
+	violations := p.document().Check(ws)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("workspace violates policy: %s", strings.Join(violations, "; "))
+}
+
+// registryHost returns the registry part of an image reference following the Docker conventions,
+// i.e. references without an explicit host refer to docker.io.
+func registryHost(ref string) string {
+	segs := strings.SplitN(ref, "/", 2)
+	if len(segs) == 1 {
+		return "docker.io"
+	}
+	host := segs[0]
+	if host == "localhost" || strings.ContainsAny(host, ".:") {
+		return host
+	}
+	return "docker.io"
+}
+
+func classIndex(order []string, cls string) int {
+	for i, c := range order {
+		if c == cls {
+			return i
+		}
+	}
+	return -1
+}
+
+func contains(s []string, e string) bool {
+	for _, v := range s {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+func containsLevel(s []workspacev1.AdmissionLevel, e workspacev1.AdmissionLevel) bool {
+	for _, v := range s {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEnvvar(envs []corev1.EnvVar, name string) bool {
+	for _, e := range envs {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+func TestCheck(t *testing.T) {
+	doc := &Document{
+		ClassOrder: []string{"small", "default", "large"},
+		Default: &Rules{
+			MaxClass: "large",
+		},
+		Teams: map[string]*Rules{
+			"team-a": {
+				AllowedRegistries:     []string{"eu.gcr.io"},
+				MaxClass:              "default",
+				AllowedPortVisibility: []workspacev1.AdmissionLevel{workspacev1.AdmissionLevelOwner},
+				RequiredEnvvars:       []RequiredEnvvar{{Name: "COST_CENTER"}},
+			},
+		},
+	}
+
+	newWorkspace := func(mod func(ws *workspacev1.Workspace)) *workspacev1.Workspace {
+		ws := &workspacev1.Workspace{
+			Spec: workspacev1.WorkspaceSpec{
+				Ownership: workspacev1.Ownership{Owner: "foo", Team: "team-a"},
+				Class:     "default",
+				Image: workspacev1.WorkspaceImages{
+					Workspace: workspacev1.WorkspaceImage{Ref: pointer.String("eu.gcr.io/gitpod/workspace:latest")},
+				},
+				Envvars: []corev1.EnvVar{{Name: "COST_CENTER", Value: "42"}},
+				Ports:   []workspacev1.PortSpec{{Port: 8080, Visibility: workspacev1.AdmissionLevelOwner}},
+			},
+		}
+		if mod != nil {
+			mod(ws)
+		}
+		return ws
+	}
+
+	tests := []struct {
+		Name        string
+		Workspace   *workspacev1.Workspace
+		Expectation []string
+	}{
+		{
+			Name:      "compliant",
+			Workspace: newWorkspace(nil),
+		},
+		{
+			Name: "registry not allowed",
+			Workspace: newWorkspace(func(ws *workspacev1.Workspace) {
+				ws.Spec.Image.Workspace.Ref = pointer.String("gitpod/workspace-full:latest")
+			}),
+			Expectation: []string{`image registry "docker.io" is not allowed`},
+		},
+		{
+			Name: "class too large",
+			Workspace: newWorkspace(func(ws *workspacev1.Workspace) {
+				ws.Spec.Class = "large"
+			}),
+			Expectation: []string{`workspace class "large" exceeds the maximum class "default"`},
+		},
+		{
+			Name: "unknown class",
+			Workspace: newWorkspace(func(ws *workspacev1.Workspace) {
+				ws.Spec.Class = "gpu"
+			}),
+			Expectation: []string{`workspace class "gpu" exceeds the maximum class "default"`},
+		},
+		{
+			Name: "public port and missing envvar",
+			Workspace: newWorkspace(func(ws *workspacev1.Workspace) {
+				ws.Spec.Envvars = nil
+				ws.Spec.Ports = append(ws.Spec.Ports, workspacev1.PortSpec{Port: 3000, Visibility: workspacev1.AdmissionLevelEveryone})
+			}),
+			Expectation: []string{
+				"port 3000 must not have visibility Everyone",
+				"required environment variable COST_CENTER is missing",
+			},
+		},
+		{
+			Name: "other team falls back to default",
+			Workspace: newWorkspace(func(ws *workspacev1.Workspace) {
+				ws.Spec.Ownership.Team = "team-b"
+				ws.Spec.Class = "large"
+				ws.Spec.Envvars = nil
+				ws.Spec.Image.Workspace.Ref = pointer.String("gitpod/workspace-full:latest")
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := doc.Check(test.Workspace)
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected violations (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDefault(t *testing.T) {
+	p := NewStaticProvider(&Document{
+		Default: &Rules{
+			RequiredEnvvars: []RequiredEnvvar{
+				{Name: "REGION", Default: "eu"},
+				{Name: "COST_CENTER"},
+				{Name: "PRESENT", Default: "overwritten"},
+			},
+		},
+	})
+
+	ws := &workspacev1.Workspace{
+		Spec: workspacev1.WorkspaceSpec{
+			Envvars: []corev1.EnvVar{{Name: "PRESENT", Value: "original"}},
+		},
+	}
+	p.Default(ws)
+
+	expectation := []corev1.EnvVar{
+		{Name: "PRESENT", Value: "original"},
+		{Name: "REGION", Value: "eu"},
+	}
+	if diff := cmp.Diff(expectation, ws.Spec.Envvars); diff != "" {
+		t.Errorf("unexpected envvars (-want +got):\n%s", diff)
+	}
+
+	err := p.Validate(ws)
+	if err == nil || err.Error() != "workspace violates policy: required environment variable COST_CENTER is missing" {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+}
+
+func TestValidateDocument(t *testing.T) {
+	doc := &Document{
+		ClassOrder: []string{"default"},
+		Teams: map[string]*Rules{
+			"team-a": {MaxClass: "large"},
+		},
+	}
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected an error for an unknown maxClass")
+	}
+}
+
+func TestRegistryHost(t *testing.T) {
+	tests := map[string]string{
+		"alpine":                          "docker.io",
+		"gitpod/workspace-full":           "docker.io",
+		"eu.gcr.io/gitpod/workspace:tag":  "eu.gcr.io",
+		"localhost/foo":                   "localhost",
+		"registry:5000/foo@sha256:abcdef": "registry:5000",
+	}
+	for ref, expectation := range tests {
+		if act := registryHost(ref); act != expectation {
+			t.Errorf("registryHost(%q) = %q, expected %q", ref, act, expectation)
+		}
+	}
+}
@@ -6,8 +6,10 @@ package service
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,24 +33,28 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func NewWorkspaceManagerServer(clnt client.Client, cfg *config.Configuration, reg prometheus.Registerer) *WorkspaceManagerServer {
+func NewWorkspaceManagerServer(clnt client.Client, clientset kubernetes.Interface, cfg *config.Configuration, reg prometheus.Registerer) *WorkspaceManagerServer {
 	metrics := newWorkspaceMetrics()
 	reg.MustRegister(metrics)
 
 	return &WorkspaceManagerServer{
-		Client:  clnt,
-		Config:  cfg,
-		metrics: metrics,
+		Client:    clnt,
+		Clientset: clientset,
+		Config:    cfg,
+		metrics:   metrics,
 		subs: subscriptions{
 			subscribers: make(map[string]chan *wsmanapi.SubscribeResponse),
 		},
@@ -56,9 +62,11 @@ func NewWorkspaceManagerServer(clnt client.Client, cfg *config.Configuration, re
 }
 
 type WorkspaceManagerServer struct {
-	Client  client.Client
-	Config  *config.Configuration
-	metrics *workspaceMetrics
+	Client client.Client
+	// Clientset reads pod logs, which the controller-runtime client cannot
+	Clientset kubernetes.Interface
+	Config    *config.Configuration
+	metrics   *workspaceMetrics
 
 	subs subscriptions
 	wsmanapi.UnimplementedWorkspaceManagerServer
@@ -100,19 +108,7 @@ func (wsm *WorkspaceManagerServer) StartWorkspace(ctx context.Context, req *wsma
 		return nil, status.Errorf(codes.InvalidArgument, "cannot serialise content initializer: %v", err)
 	}
 
-	envvars := make([]corev1.EnvVar, 0, len(req.Spec.Envvars))
-	for _, e := range req.Spec.Envvars {
-		env := corev1.EnvVar{Name: e.Name, Value: e.Value}
-		if len(e.Value) == 0 && e.Secret != nil {
-			env.ValueFrom = &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{Name: e.Secret.SecretName},
-					Key:                  e.Secret.Key,
-				},
-			}
-		}
-		envvars = append(envvars, env)
-	}
+	envvars := convertEnvvars(req.Spec.Envvars)
 
 	var git *workspacev1.GitSpec
 	if req.Spec.Git != nil {
@@ -143,16 +139,17 @@ func (wsm *WorkspaceManagerServer) StartWorkspace(ctx context.Context, req *wsma
 
 	ports := make([]workspacev1.PortSpec, 0, len(req.Spec.Ports))
 	for _, p := range req.Spec.Ports {
-		v := workspacev1.AdmissionLevelOwner
-		if p.Visibility == api.PortVisibility_PORT_VISIBILITY_PUBLIC {
-			v = workspacev1.AdmissionLevelEveryone
-		}
 		ports = append(ports, workspacev1.PortSpec{
 			Port:       p.Port,
-			Visibility: v,
+			Visibility: portVisibilityToAdmissionLevel(p.Visibility),
 		})
 	}
 
+	sidecars, err := wsm.convertSidecars(req.Spec.Class, req.Spec.Sidecars)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid sidecars: %v", err)
+	}
+
 	ws := workspacev1.Workspace{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: workspacev1.GroupVersion.String(),
@@ -171,6 +168,7 @@ func (wsm *WorkspaceManagerServer) StartWorkspace(ctx context.Context, req *wsma
 			Ownership: workspacev1.Ownership{
 				Owner:       req.Metadata.Owner,
 				WorkspaceID: req.Metadata.MetaId,
+				Team:        req.Metadata.GetTeam(),
 			},
 			Type:  workspaceType,
 			Class: req.Spec.Class,
@@ -194,12 +192,18 @@ func (wsm *WorkspaceManagerServer) StartWorkspace(ctx context.Context, req *wsma
 			Admission: workspacev1.AdmissionSpec{
 				Level: admissionLevel,
 			},
-			Ports: ports,
+			Ports:    ports,
+			Sidecars: sidecars,
 		},
 	}
 
 	wsm.metrics.recordWorkspaceStart(&ws)
 	err = wsm.Client.Create(ctx, &ws)
+	if errors.IsForbidden(err) {
+		// the admission webhook rejected the workspace, e.g. because it violates the workspace policy
+		log.WithError(err).WithFields(owi).Info("workspace was not admitted")
+		return nil, status.Errorf(codes.PermissionDenied, "workspace was not admitted: %s", admissionDenialReason(err))
+	}
 	if err != nil {
 		log.WithError(err).WithFields(owi).Error("error creating workspace")
 		return nil, status.Errorf(codes.FailedPrecondition, "cannot create workspace")
@@ -334,13 +338,9 @@ func (wsm *WorkspaceManagerServer) ControlPort(ctx context.Context, req *wsmanap
 		ws.Spec.Ports = ws.Spec.Ports[:n]
 
 		if req.Expose {
-			visibility := workspacev1.AdmissionLevelOwner
-			if req.Spec.Visibility == api.PortVisibility_PORT_VISIBILITY_PUBLIC {
-				visibility = workspacev1.AdmissionLevelEveryone
-			}
 			ws.Spec.Ports = append(ws.Spec.Ports, workspacev1.PortSpec{
 				Port:       port,
-				Visibility: visibility,
+				Visibility: portVisibilityToAdmissionLevel(req.Spec.Visibility),
 			})
 		}
 
@@ -374,6 +374,52 @@ func (wsm *WorkspaceManagerServer) ControlAdmission(ctx context.Context, req *ws
 	return &wsmanapi.ControlAdmissionResponse{}, nil
 }
 
+func (wsm *WorkspaceManagerServer) GetSidecarLogs(ctx context.Context, req *wsmanapi.GetSidecarLogsRequest) (*wsmanapi.GetSidecarLogsResponse, error) {
+	if req.TailLines < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "tail lines must not be negative")
+	}
+
+	var ws workspacev1.Workspace
+	err := wsm.Client.Get(ctx, types.NamespacedName{Namespace: wsm.Config.Namespace, Name: req.Id}, &ws)
+	if errors.IsNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "workspace %s not found", req.Id)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot lookup workspace: %v", err)
+	}
+
+	var found bool
+	for _, sc := range ws.Spec.Sidecars {
+		if sc.Name == req.Name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "workspace %s has no sidecar %s", req.Id, req.Name)
+	}
+	if ws.Status.Runtime == nil || ws.Status.Runtime.PodName == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "workspace %s has no pod yet", req.Id)
+	}
+
+	opts := &corev1.PodLogOptions{
+		Container: workspacev1.SidecarContainerPrefix + req.Name,
+		Previous:  req.Previous,
+	}
+	if req.TailLines > 0 {
+		opts.TailLines = &req.TailLines
+	}
+	logs, err := wsm.Clientset.CoreV1().Pods(wsm.Config.Namespace).GetLogs(ws.Status.Runtime.PodName, opts).DoRaw(ctx)
+	if errors.IsNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "sidecar %s of workspace %s has no log yet", req.Name, req.Id)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get sidecar log: %v", err)
+	}
+
+	return &wsmanapi.GetSidecarLogsResponse{Log: string(logs)}, nil
+}
+
 // modifyWorkspace modifies a workspace object using the mod function. If the mod function returns a gRPC status error, that error
 // is returned directly. If mod returns a non-gRPC error it is turned into one.
 func (wsm *WorkspaceManagerServer) modifyWorkspace(ctx context.Context, id string, updateStatus bool, mod func(ws *workspacev1.Workspace) error) error {
@@ -437,6 +483,132 @@ func validateStartWorkspaceRequest(req *api.StartWorkspaceRequest) error {
 	return nil
 }
 
+func portVisibilityToAdmissionLevel(v wsmanapi.PortVisibility) workspacev1.AdmissionLevel {
+	switch v {
+	case wsmanapi.PortVisibility_PORT_VISIBILITY_PUBLIC:
+		return workspacev1.AdmissionLevelEveryone
+	case wsmanapi.PortVisibility_PORT_VISIBILITY_SHARED:
+		return workspacev1.AdmissionLevelShared
+	default:
+		return workspacev1.AdmissionLevelOwner
+	}
+}
+
+func convertEnvvars(envs []*wsmanapi.EnvironmentVariable) []corev1.EnvVar {
+	res := make([]corev1.EnvVar, 0, len(envs))
+	for _, e := range envs {
+		env := corev1.EnvVar{Name: e.Name, Value: e.Value}
+		if len(e.Value) == 0 && e.Secret != nil {
+			env.ValueFrom = &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: e.Secret.SecretName},
+					Key:                  e.Secret.Key,
+				},
+			}
+		}
+		res = append(res, env)
+	}
+	return res
+}
+
+// convertSidecars turns the sidecars of a start request into their CRD representation and
+// ensures they fit into the sidecar budget of the workspace class.
+func (wsm *WorkspaceManagerServer) convertSidecars(class string, specs []*wsmanapi.SidecarSpec) ([]workspacev1.SidecarSpec, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	cls, ok := wsm.Config.WorkspaceClasses[class]
+	if !ok {
+		return nil, fmt.Errorf("unknown workspace class %q", class)
+	}
+
+	var (
+		res       = make([]workspacev1.SidecarSpec, 0, len(specs))
+		resources = make([]corev1.ResourceRequirements, 0, len(specs))
+		names     = make(map[string]struct{}, len(specs))
+	)
+	for _, spec := range specs {
+		if errs := k8svalidation.IsDNS1123Label(spec.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("sidecar name %q is invalid: %s", spec.Name, strings.Join(errs, ", "))
+		}
+		if _, exists := names[spec.Name]; exists {
+			return nil, fmt.Errorf("duplicate sidecar %q", spec.Name)
+		}
+		names[spec.Name] = struct{}{}
+		if spec.Image == "" {
+			return nil, fmt.Errorf("sidecar %s: image is required", spec.Name)
+		}
+
+		var requests, limits corev1.ResourceList
+		if r := spec.Resources; r != nil {
+			var err error
+			requests, err = parseResourceList(r.CpuRequest, r.MemoryRequest)
+			if err != nil {
+				return nil, fmt.Errorf("sidecar %s: requests: %w", spec.Name, err)
+			}
+			limits, err = parseResourceList(r.CpuLimit, r.MemoryLimit)
+			if err != nil {
+				return nil, fmt.Errorf("sidecar %s: limits: %w", spec.Name, err)
+			}
+		}
+		rr, err := cls.Sidecars.SidecarResources(requests, limits)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, rr)
+
+		res = append(res, workspacev1.SidecarSpec{
+			Name:    spec.Name,
+			Image:   spec.Image,
+			Command: spec.Command,
+			Args:    spec.Args,
+			Envvars: convertEnvvars(spec.Envvars),
+			Resources: workspacev1.SidecarResources{
+				Requests: requests,
+				Limits:   limits,
+			},
+		})
+	}
+
+	err := cls.Sidecars.CheckBudget(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func parseResourceList(cpu, memory string) (corev1.ResourceList, error) {
+	res := make(corev1.ResourceList)
+	for name, v := range map[corev1.ResourceName]string{corev1.ResourceCPU: cpu, corev1.ResourceMemory: memory} {
+		if v == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		res[name] = q
+	}
+	return res, nil
+}
+
+// admissionDenialReason extracts the message an admission webhook produced when denying a request
+func admissionDenialReason(err error) string {
+	var apiStatus errors.APIStatus
+	if !goerrors.As(err, &apiStatus) {
+		return err.Error()
+	}
+
+	msg := apiStatus.Status().Message
+	const deniedPrefix = "denied the request: "
+	if idx := strings.Index(msg, deniedPrefix); idx >= 0 {
+		return msg[idx+len(deniedPrefix):]
+	}
+	return msg
+}
+
 func isValidWorkspaceType(value interface{}) error {
 	s, ok := value.(api.WorkspaceType)
 	if !ok {
@@ -541,6 +713,26 @@ func extractWorkspaceStatus(ws *workspacev1.Workspace) *wsmanapi.WorkspaceStatus
 		}
 	}
 
+	var sidecars []*wsmanapi.SidecarStatus
+	for _, sc := range ws.Status.Sidecars {
+		state := wsmanapi.SidecarState_SIDECAR_STATE_UNSPECIFIED
+		switch sc.State {
+		case workspacev1.SidecarStateWaiting:
+			state = wsmanapi.SidecarState_SIDECAR_STATE_WAITING
+		case workspacev1.SidecarStateRunning:
+			state = wsmanapi.SidecarState_SIDECAR_STATE_RUNNING
+		case workspacev1.SidecarStateTerminated:
+			state = wsmanapi.SidecarState_SIDECAR_STATE_TERMINATED
+		}
+		sidecars = append(sidecars, &wsmanapi.SidecarStatus{
+			Name:     sc.Name,
+			State:    state,
+			Ready:    sc.Ready,
+			Message:  sc.Message,
+			ExitCode: sc.ExitCode,
+		})
+	}
+
 	var admissionLevel wsmanapi.AdmissionLevel
 	switch ws.Spec.Admission.Level {
 	case workspacev1.AdmissionLevelEveryone:
@@ -580,7 +772,8 @@ func extractWorkspaceStatus(ws *workspacev1.Workspace) *wsmanapi.WorkspaceStatus
 			HeadlessTaskFailed: getConditionMessageIfTrue(ws.Status.Conditions, string(workspacev1.WorkspaceConditionsHeadlessTaskFailed)),
 			StoppedByRequest:   convertCondition(ws.Status.Conditions, string(workspacev1.WorkspaceConditionStoppedByRequest)),
 		},
-		Runtime: runtime,
+		Runtime:  runtime,
+		Sidecars: sidecars,
 		Auth: &wsmanapi.WorkspaceAuthentication{
 			Admission:  admissionLevel,
 			OwnerToken: ws.Status.OwnerToken,
@@ -158,6 +158,34 @@ var runCmd = &cobra.Command{
 			}
 			if len(signers) > 0 {
 				server := sshproxy.New(signers, infoprov, heartbeat)
+				server.ProxyProtocol = cfg.SSHGateway.ProxyProtocol
+				// the hostname is the name of the ws-proxy pod
+				server.Name, _ = os.Hostname()
+				if ca := cfg.SSHGateway.UserCA; ca != nil {
+					server.CertificateAuthority, err = sshproxy.NewCertificateAuthority(*ca)
+					if err != nil {
+						log.WithError(err).Fatal("cannot load SSH user CA")
+					}
+					log.WithField("keys", len(server.CertificateAuthority.Keys)).Info("accepting SSH user certificates")
+				}
+				if rec := cfg.SSHGateway.Recording; rec != nil {
+					recorder, err := sshproxy.NewRecorder(*rec)
+					if err != nil {
+						log.WithError(err).Fatal("cannot start ssh session recording")
+					}
+					server.Recorder = recorder
+					server.RecordOutput = rec.RecordOutput
+					server.MaxOutputBytes = rec.MaxOutputBytes
+					log.WithField("sink", rec.Sink).Info("recording SSH sessions")
+				}
+				if addr := cfg.SSHGateway.AdminAddr; addr != "" {
+					go func() {
+						err := http.ListenAndServe(addr, server.AdminHandler())
+						if err != nil {
+							log.WithError(err).Error("cannot serve SSH gateway admin API")
+						}
+					}()
+				}
 				l, err := net.Listen("tcp", ":2200")
 				if err != nil {
 					panic(err)
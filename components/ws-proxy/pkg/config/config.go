@@ -6,11 +6,13 @@ package config
 
 import (
 	"encoding/json"
+	"net"
 	"os"
 
 	"golang.org/x/xerrors"
 
 	"github.com/gitpod-io/gitpod/ws-proxy/pkg/proxy"
+	"github.com/gitpod-io/gitpod/ws-proxy/pkg/sshproxy"
 )
 
 // Config configures this service.
@@ -23,6 +25,23 @@ type Config struct {
 	Namespace          string                       `json:"namespace"`
 	WorkspaceManager   *WorkspaceManagerConn        `json:"wsManager"`
 	EnableWorkspaceCRD bool                         `json:"enableWorkspaceCRD"`
+	SSHGateway         SSHGatewayConfig             `json:"sshGateway"`
+}
+
+// SSHGatewayConfig configures the SSH gateway.
+type SSHGatewayConfig struct {
+	// AdminAddr is the address the admin API which lists and disconnects live sessions is served on.
+	// The API is not authenticated, hence this must be a localhost address. Every ws-proxy replica serves
+	// the API for its own sessions only.
+	AdminAddr string `json:"adminAddr,omitempty"`
+	// UserCA enables authentication with OpenSSH user certificates issued by an existing SSH CA.
+	UserCA *sshproxy.UserCAConfig `json:"userCA,omitempty"`
+	// Recording enables recording sessions for auditing.
+	Recording *sshproxy.RecordingConfig `json:"recording,omitempty"`
+	// ProxyProtocol makes the gateway read the client address from the PROXY protocol header the SSH tunnel of
+	// proxy sends. Certificates restricted to source addresses are rejected without it, as the gateway cannot
+	// tell where their clients connect from. Only enable this if the gateway is reachable through proxy alone.
+	ProxyProtocol bool `json:"proxyProtocol,omitempty"`
 }
 
 type WorkspaceManagerConn struct {
@@ -44,6 +63,28 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if addr := c.SSHGateway.AdminAddr; addr != "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return xerrors.Errorf("invalid sshGateway.adminAddr: %w", err)
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return xerrors.Errorf("sshGateway.adminAddr must be a localhost address because the admin API is not authenticated")
+		}
+	}
+
+	if c.SSHGateway.UserCA != nil {
+		if err := c.SSHGateway.UserCA.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.SSHGateway.Recording != nil {
+		if err := c.SSHGateway.Recording.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
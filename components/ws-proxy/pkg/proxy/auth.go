@@ -5,13 +5,16 @@
 package proxy
 
 import (
+	"crypto/hmac"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
+	"golang.org/x/xerrors"
 
 	"github.com/gitpod-io/gitpod/ws-manager/api"
 )
@@ -55,10 +58,11 @@ func WorkspaceAuthHandler(domain string, info WorkspaceInfoProvider) mux.Middlew
 			}
 
 			if port != "" {
-				// this is a workspace port request and ports can be public or private.
-				// For public ports no tokens or cookies matter, private ports are subject
+				// this is a workspace port request and ports can be public, shared or private.
+				// For public ports no tokens or cookies matter, shared ports are accessible with a
+				// valid share token. Private ports (and shared ports without a share token) are subject
 				// to the same access policies as the workspace itself is.
-				var isPublic bool
+				visibility := api.PortVisibility_PORT_VISIBILITY_PRIVATE
 
 				prt, err := strconv.ParseUint(port, 10, 16)
 				if err != nil {
@@ -66,18 +70,29 @@ func WorkspaceAuthHandler(domain string, info WorkspaceInfoProvider) mux.Middlew
 				} else {
 					for _, p := range ws.Ports {
 						if p.Port == uint32(prt) {
-							isPublic = p.Visibility == api.PortVisibility_PORT_VISIBILITY_PUBLIC
+							visibility = p.Visibility
 
 							break
 						}
 					}
 				}
 
-				if isPublic {
+				switch visibility {
+				case api.PortVisibility_PORT_VISIBILITY_PUBLIC:
 					// workspace port is free for all - no tokens or cookies matter
 					h.ServeHTTP(resp, req)
 
 					return
+				case api.PortVisibility_PORT_VISIBILITY_SHARED:
+					authorized, handled := checkPortShareToken(resp, req, ws, uint32(prt), cookiePrefix, domain)
+					if handled {
+						return
+					}
+					if authorized {
+						h.ServeHTTP(resp, req)
+
+						return
+					}
 				}
 
 				// port seems to be private - subject it to the same access policy as the workspace itself
@@ -115,3 +130,141 @@ func WorkspaceAuthHandler(domain string, info WorkspaceInfoProvider) mux.Middlew
 		})
 	}
 }
+
+// PortInspectorAuthHandler rejects requests which do not carry the port inspector token of the workspace.
+// Only supervisor holds that token, hence neither the owner token nor the workspace's admission level grant access
+// to the captured traffic.
+func PortInspectorAuthHandler(info WorkspaceInfoProvider) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			var (
+				log  = getLog(req.Context())
+				wsID = mux.Vars(req)[workspaceIDIdentifier]
+			)
+			ws := info.WorkspaceInfo(wsID)
+			if ws == nil {
+				log.WithField("workspaceId", wsID).Warn("did not find workspace info")
+				resp.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			tkn := req.Header.Get("x-gitpod-port-inspector-token")
+			if tkn == "" {
+				resp.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+			if ws.Auth == nil || !hmac.Equal([]byte(tkn), []byte(api.PortInspectorToken(ws.Auth.OwnerToken))) {
+				log.Warn("port inspector token mismatch")
+				resp.WriteHeader(http.StatusForbidden)
+
+				return
+			}
+
+			h.ServeHTTP(resp, req)
+		})
+	}
+}
+
+// checkPortShareToken authorizes requests to shared ports which carry a port share token in a cookie.
+// Requests with a share token in the query are answered directly: valid tokens are moved into a cookie,
+// invalid ones are rejected. Requests with a restricted share link in the query are sent to the Gitpod server
+// to redeem the link for a token bound to this browser. Unauthorized requests are subject to the regular access policy.
+func checkPortShareToken(resp http.ResponseWriter, req *http.Request, ws *WorkspaceInfo, port uint32, cookiePrefix, domain string) (authorized, handled bool) {
+	var (
+		log             = getLog(req.Context())
+		cookieName      = fmt.Sprintf("%s%s_port_%d_share_", cookiePrefix, ws.InstanceID, port)
+		nonceCookieName = fmt.Sprintf("%s%s_port_%d_share_nonce_", cookiePrefix, ws.InstanceID, port)
+		ownerToken      string
+	)
+	if ws.Auth != nil {
+		ownerToken = ws.Auth.OwnerToken
+	}
+
+	// hasBinding checks that this browser holds the nonce a bound token was minted for
+	hasBinding := func(binding string) bool {
+		if binding == "" {
+			return true
+		}
+		c, err := req.Cookie(nonceCookieName)
+		if err != nil {
+			return false
+		}
+		return hmac.Equal([]byte(portShareBinding(c.Value)), []byte(binding))
+	}
+
+	if grant := req.URL.Query().Get(portShareGrantParam); grant != "" {
+		nonce, err := newPortShareNonce()
+		if err != nil {
+			log.WithError(err).Error("cannot create port share nonce")
+			resp.WriteHeader(http.StatusInternalServerError)
+
+			return false, true
+		}
+		http.SetCookie(resp, &http.Cookie{
+			Name:     nonceCookieName,
+			Value:    nonce,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteLaxMode,
+		})
+		redeem := url.URL{
+			Scheme:   "https",
+			Host:     domain,
+			Path:     portShareOpenPath,
+			RawQuery: url.Values{"grant": {grant}, "binding": {portShareBinding(nonce)}}.Encode(),
+		}
+		http.Redirect(resp, req, redeem.String(), http.StatusSeeOther)
+
+		return false, true
+	}
+
+	if tkn := req.URL.Query().Get(portShareTokenParam); tkn != "" {
+		expires, binding, err := verifyPortShareToken(tkn, ownerToken, ws.InstanceID, port, time.Now())
+		if err == nil && !hasBinding(binding) {
+			err = xerrors.Errorf("token is bound to another browser")
+		}
+		if err != nil {
+			log.WithError(err).WithField("port", port).Debug("rejecting port share token")
+			resp.WriteHeader(http.StatusForbidden)
+
+			return false, true
+		}
+
+		// remember the token in a cookie and remove it from the URL so that it doesn't end up in the
+		// browser history or in the logs of the application serving the port.
+		http.SetCookie(resp, &http.Cookie{
+			Name:     cookieName,
+			Value:    tkn,
+			Path:     "/",
+			Expires:  expires,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteLaxMode,
+		})
+		query := req.URL.Query()
+		query.Del(portShareTokenParam)
+		target := *req.URL
+		target.RawQuery = query.Encode()
+		http.Redirect(resp, req, target.RequestURI(), http.StatusSeeOther)
+
+		return false, true
+	}
+
+	c, err := req.Cookie(cookieName)
+	if err != nil {
+		return false, false
+	}
+	_, binding, err := verifyPortShareToken(c.Value, ownerToken, ws.InstanceID, port, time.Now())
+	if err == nil && !hasBinding(binding) {
+		err = xerrors.Errorf("token is bound to another browser")
+	}
+	if err != nil {
+		log.WithError(err).WithField("port", port).Debug("ignoring invalid port share cookie")
+		return false, false
+	}
+
+	return true, false
+}
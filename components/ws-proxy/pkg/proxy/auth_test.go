@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/gorilla/mux"
@@ -56,6 +58,20 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 				Ports: []*api.PortSpec{{Port: testPort, Visibility: api.PortVisibility_PORT_VISIBILITY_PUBLIC}},
 			},
 		}
+		sharedPortInfos = map[string]*WorkspaceInfo{
+			workspaceID: {
+				WorkspaceID: workspaceID,
+				InstanceID:  instanceID,
+				Auth: &api.WorkspaceAuthentication{
+					Admission:  api.AdmissionLevel_ADMIT_OWNER_ONLY,
+					OwnerToken: ownerToken,
+				},
+				Ports: []*api.PortSpec{{Port: testPort, Visibility: api.PortVisibility_PORT_VISIBILITY_SHARED}},
+			},
+		}
+		validShareToken    = signPortShareToken(ownerToken, instanceID, testPort, time.Now().Add(time.Hour), "")
+		expiredShareToken  = signPortShareToken(ownerToken, instanceID, testPort, time.Now().Add(-time.Hour), "")
+		boundShareToken    = signPortShareToken(ownerToken, instanceID, testPort, time.Now().Add(time.Hour), portShareBinding("nonce"))
 		admitEveryoneInfos = map[string]*WorkspaceInfo{
 			workspaceID: {
 				WorkspaceID: workspaceID,
@@ -68,6 +84,10 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 		Name        string
 		Infos       map[string]*WorkspaceInfo
 		OwnerCookie string
+		ShareToken  string
+		ShareCookie string
+		ShareGrant  string
+		NonceCookie string
 		WorkspaceID string
 		Port        string
 		Expected    testResult
@@ -197,6 +217,140 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 				StatusCode:    http.StatusOK,
 			},
 		},
+		{
+			Name:        "shared port without token",
+			Infos:       sharedPortInfos,
+			WorkspaceID: workspaceID,
+			Port:        strconv.Itoa(testPort),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusUnauthorized,
+			},
+		},
+		{
+			Name:        "shared port with owner cookie",
+			Infos:       sharedPortInfos,
+			WorkspaceID: workspaceID,
+			OwnerCookie: ownerToken,
+			Port:        strconv.Itoa(testPort),
+			Expected: testResult{
+				HandlerCalled: true,
+				StatusCode:    http.StatusOK,
+			},
+		},
+		{
+			Name:        "shared port with share token",
+			Infos:       sharedPortInfos,
+			WorkspaceID: workspaceID,
+			ShareToken:  validShareToken,
+			Port:        strconv.Itoa(testPort),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusSeeOther,
+			},
+		},
+		{
+			Name:        "shared port with expired share token",
+			Infos:       sharedPortInfos,
+			WorkspaceID: workspaceID,
+			ShareToken:  expiredShareToken,
+			Port:        strconv.Itoa(testPort),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusForbidden,
+			},
+		},
+		{
+			Name:        "shared port with share cookie",
+			Infos:       sharedPortInfos,
+			WorkspaceID: workspaceID,
+			ShareCookie: validShareToken,
+			Port:        strconv.Itoa(testPort),
+			Expected: testResult{
+				HandlerCalled: true,
+				StatusCode:    http.StatusOK,
+			},
+		},
+		{
+			Name:        "shared port with expired share cookie",
+			Infos:       sharedPortInfos,
+			WorkspaceID: workspaceID,
+			ShareCookie: expiredShareToken,
+			Port:        strconv.Itoa(testPort),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusUnauthorized,
+			},
+		},
+		{
+			Name:        "shared port with bound share token",
+			Infos:       sharedPortInfos,
+			WorkspaceID: workspaceID,
+			ShareToken:  boundShareToken,
+			NonceCookie: "nonce",
+			Port:        strconv.Itoa(testPort),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusSeeOther,
+			},
+		},
+		{
+			Name:        "shared port with bound share token without nonce",
+			Infos:       sharedPortInfos,
+			WorkspaceID: workspaceID,
+			ShareToken:  boundShareToken,
+			Port:        strconv.Itoa(testPort),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusForbidden,
+			},
+		},
+		{
+			Name:        "shared port with bound share cookie",
+			Infos:       sharedPortInfos,
+			WorkspaceID: workspaceID,
+			ShareCookie: boundShareToken,
+			NonceCookie: "nonce",
+			Port:        strconv.Itoa(testPort),
+			Expected: testResult{
+				HandlerCalled: true,
+				StatusCode:    http.StatusOK,
+			},
+		},
+		{
+			Name:        "shared port with bound share cookie and other nonce",
+			Infos:       sharedPortInfos,
+			WorkspaceID: workspaceID,
+			ShareCookie: boundShareToken,
+			NonceCookie: "other",
+			Port:        strconv.Itoa(testPort),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusUnauthorized,
+			},
+		},
+		{
+			Name:        "shared port with share grant",
+			Infos:       sharedPortInfos,
+			WorkspaceID: workspaceID,
+			ShareGrant:  "grant",
+			Port:        strconv.Itoa(testPort),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusSeeOther,
+			},
+		},
+		{
+			Name:        "private port with share cookie",
+			Infos:       ownerOnlyInfos,
+			WorkspaceID: workspaceID,
+			ShareCookie: validShareToken,
+			Port:        strconv.Itoa(testPort),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusUnauthorized,
+			},
+		},
 		{
 			Name:        "broken port",
 			Infos:       publicPortInfos,
@@ -229,10 +383,23 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 			}))
 
 			rr := httptest.NewRecorder()
-			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://%s/", domain), nil)
+			target := fmt.Sprintf("http://%s/", domain)
+			if test.ShareToken != "" {
+				target += "?" + portShareTokenParam + "=" + test.ShareToken
+			}
+			if test.ShareGrant != "" {
+				target += "?" + portShareGrantParam + "=" + test.ShareGrant
+			}
+			req := httptest.NewRequest(http.MethodGet, target, nil)
 			if test.OwnerCookie != "" {
 				setOwnerTokenCookie(req, instanceID, test.OwnerCookie)
 			}
+			if test.ShareCookie != "" {
+				req.AddCookie(&http.Cookie{Name: fmt.Sprintf("_test_domain_com_ws_%s_port_%d_share_", instanceID, testPort), Value: test.ShareCookie})
+			}
+			if test.NonceCookie != "" {
+				req.AddCookie(&http.Cookie{Name: fmt.Sprintf("_test_domain_com_ws_%s_port_%d_share_nonce_", instanceID, testPort), Value: test.NonceCookie})
+			}
 			vars := map[string]string{
 				workspaceIDIdentifier: test.WorkspaceID,
 			}
@@ -254,3 +421,86 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 func setOwnerTokenCookie(r *http.Request, instanceID, token string) {
 	r.AddCookie(&http.Cookie{Name: "_test_domain_com_ws_" + instanceID + "_owner_", Value: token})
 }
+
+func TestPortShareGrantRedirect(t *testing.T) {
+	const (
+		domain     = "test-domain.com"
+		instanceID = "instance-fce1-4ff6-9364-cf6dff0c4ecf"
+		testPort   = 8080
+	)
+	ws := &WorkspaceInfo{
+		InstanceID: instanceID,
+		Auth:       &api.WorkspaceAuthentication{OwnerToken: "owner-token"},
+	}
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "http://"+domain+"/?"+portShareGrantParam+"=some%2Bgrant", nil)
+	authorized, handled := checkPortShareToken(rr, req, ws, testPort, "_test_domain_com_ws_", domain)
+	if authorized || !handled {
+		t.Fatalf("unexpected result: authorized=%v handled=%v", authorized, handled)
+	}
+
+	var nonce string
+	for _, c := range rr.Result().Cookies() {
+		if c.Name == fmt.Sprintf("_test_domain_com_ws_%s_port_%d_share_nonce_", instanceID, testPort) {
+			nonce = c.Value
+		}
+	}
+	if nonce == "" {
+		t.Fatal("no nonce cookie set")
+	}
+
+	loc, err := url.Parse(rr.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.Host != domain || loc.Path != portShareOpenPath {
+		t.Errorf("unexpected redirect: %s", loc)
+	}
+	if grant := loc.Query().Get("grant"); grant != "some+grant" {
+		t.Errorf("unexpected grant: %q", grant)
+	}
+	if binding := loc.Query().Get("binding"); binding != portShareBinding(nonce) {
+		t.Errorf("binding %q does not match nonce cookie", binding)
+	}
+}
+
+func TestPortInspectorAuthHandler(t *testing.T) {
+	const wsID = "amaranth-smelt-9ba20cc1"
+	infos := map[string]*WorkspaceInfo{
+		wsID: {
+			WorkspaceID: wsID,
+			Auth:        &api.WorkspaceAuthentication{Admission: api.AdmissionLevel_ADMIT_EVERYONE, OwnerToken: "owner-token"},
+		},
+	}
+	tests := []struct {
+		Name        string
+		WorkspaceID string
+		Token       string
+		Expectation int
+	}{
+		{Name: "port inspector token", WorkspaceID: wsID, Token: api.PortInspectorToken("owner-token"), Expectation: http.StatusOK},
+		{Name: "no token", WorkspaceID: wsID, Expectation: http.StatusUnauthorized},
+		{Name: "owner token", WorkspaceID: wsID, Token: "owner-token", Expectation: http.StatusForbidden},
+		{Name: "unknown workspace", WorkspaceID: "unknown", Token: api.PortInspectorToken("owner-token"), Expectation: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			handler := PortInspectorAuthHandler(&fixedInfoProvider{Infos: infos})(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+				resp.WriteHeader(http.StatusOK)
+			}))
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "http://test-domain.com/_inspect/3000", nil)
+			if test.Token != "" {
+				req.Header.Set("x-gitpod-port-inspector-token", test.Token)
+			}
+			req = mux.SetURLVars(req, map[string]string{workspaceIDIdentifier: test.WorkspaceID})
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != test.Expectation {
+				t.Errorf("unexpected status code %d, expected %d", rr.Code, test.Expectation)
+			}
+		})
+	}
+}
@@ -28,6 +28,9 @@ type Config struct {
 	WorkspacePodConfig *WorkspacePodConfig `json:"workspacePodConfig"`
 
 	BuiltinPages BuiltinPagesConfig `json:"builtinPages"`
+
+	// Inspector enables capturing the traffic of workspace ports. Capturing is disabled if this is nil.
+	Inspector *InspectorConfig `json:"inspector,omitempty"`
 }
 
 // Validate validates the configuration to catch issues during startup and not at runtime.
@@ -46,6 +49,12 @@ func (c *Config) Validate() error {
 			return err
 		}
 	}
+	if c.Inspector != nil {
+		err := c.Inspector.Validate()
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -211,3 +220,22 @@ func validateFileExists(addition string) validation.RuleFunc {
 		return nil
 	}
 }
+
+// InspectorConfig configures the traffic inspector for workspace ports.
+type InspectorConfig struct {
+	// MaxBodySize is the number of bytes captured of each request and response body.
+	MaxBodySize int64 `json:"maxBodySize"`
+	// MaxCaptures is the number of captures retained per workspace port.
+	MaxCaptures int `json:"maxCaptures"`
+	// Retention is how long captures are kept. Ports stay captured for that long after the last inspector detached.
+	Retention util.Duration `json:"retention"`
+}
+
+// Validate validates the configuration to catch issues during startup and not at runtime.
+func (c *InspectorConfig) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.MaxBodySize, validation.Min(int64(0))),
+		validation.Field(&c.MaxCaptures, validation.Required, validation.Min(1)),
+		validation.Field(&c.Retention, validation.Required),
+	)
+}
@@ -267,8 +267,11 @@ func (r *CRDWorkspaceInfoProvider) Reconcile(ctx context.Context, req ctrl.Reque
 	ports := make([]*wsapi.PortSpec, 0, len(ws.Spec.Ports))
 	for _, p := range ws.Spec.Ports {
 		v := wsapi.PortVisibility_PORT_VISIBILITY_PRIVATE
-		if p.Visibility == workspacev1.AdmissionLevelEveryone {
+		switch p.Visibility {
+		case workspacev1.AdmissionLevelEveryone:
 			v = wsapi.PortVisibility_PORT_VISIBILITY_PUBLIC
+		case workspacev1.AdmissionLevelShared:
+			v = wsapi.PortVisibility_PORT_VISIBILITY_SHARED
 		}
 		ports = append(ports, &wsapi.PortSpec{
 			Port:       p.Port,
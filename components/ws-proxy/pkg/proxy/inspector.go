@@ -0,0 +1,366 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// inspectPortIdentifier is the mux variable of the port an inspector attaches to.
+	inspectPortIdentifier = "inspectPort"
+
+	// inspectorSubscriberBuffer is the number of captures buffered per inspector before captures are dropped.
+	inspectorSubscriberBuffer = 64
+)
+
+// Capture is the record of a single request to a workspace port.
+type Capture struct {
+	ID       uint64        `json:"id"`
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration"`
+
+	Method               string      `json:"method"`
+	URL                  string      `json:"url"`
+	Proto                string      `json:"proto"`
+	RequestHeader        http.Header `json:"requestHeader,omitempty"`
+	RequestBody          []byte      `json:"requestBody,omitempty"`
+	RequestBodyTruncated bool        `json:"requestBodyTruncated,omitempty"`
+
+	StatusCode            int         `json:"statusCode"`
+	ResponseHeader        http.Header `json:"responseHeader,omitempty"`
+	ResponseBody          []byte      `json:"responseBody,omitempty"`
+	ResponseBodyTruncated bool        `json:"responseBodyTruncated,omitempty"`
+
+	// Upgraded is true if the connection was upgraded, e.g. to a websocket. The traffic after the upgrade is not captured.
+	Upgraded bool `json:"upgraded,omitempty"`
+}
+
+type inspectorKey struct {
+	WorkspaceID string
+	Port        uint32
+}
+
+type captureBuffer struct {
+	// captures is ordered oldest first
+	captures    []*Capture
+	subscribers map[chan *Capture]struct{}
+	// capturing continues until activeUntil even if no inspector is attached anymore
+	activeUntil time.Time
+}
+
+// Inspector captures the traffic of workspace ports which are being inspected.
+// Captures are kept in memory, i.e. every ws-proxy replica only knows about the traffic it has proxied itself.
+type Inspector struct {
+	Config InspectorConfig
+
+	mu      sync.Mutex
+	buffers map[inspectorKey]*captureBuffer
+	lastID  uint64
+	now     func() time.Time
+}
+
+// NewInspector creates a new inspector.
+func NewInspector(cfg InspectorConfig) *Inspector {
+	return &Inspector{
+		Config:  cfg,
+		buffers: make(map[inspectorKey]*captureBuffer),
+		now:     time.Now,
+	}
+}
+
+// Subscribe starts capturing the traffic of a workspace port. It returns the captures retained so far
+// and a channel on which new captures are published until cancel is called.
+func (i *Inspector) Subscribe(workspaceID string, port uint32) (captures []*Capture, updates <-chan *Capture, cancel func()) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := i.now()
+	i.gc(now)
+
+	key := inspectorKey{WorkspaceID: workspaceID, Port: port}
+	buf, ok := i.buffers[key]
+	if !ok {
+		buf = &captureBuffer{subscribers: make(map[chan *Capture]struct{})}
+		i.buffers[key] = buf
+	}
+	ch := make(chan *Capture, inspectorSubscriberBuffer)
+	buf.subscribers[ch] = struct{}{}
+
+	captures = make([]*Capture, len(buf.captures))
+	copy(captures, buf.captures)
+
+	var once sync.Once
+	cancel = func() {
+		once.Do(func() {
+			i.mu.Lock()
+			defer i.mu.Unlock()
+
+			delete(buf.subscribers, ch)
+			if len(buf.subscribers) == 0 {
+				buf.activeUntil = i.now().Add(time.Duration(i.Config.Retention))
+			}
+			close(ch)
+		})
+	}
+	return captures, ch, cancel
+}
+
+// capturing returns true if the traffic of the port is to be captured.
+func (i *Inspector) capturing(key inspectorKey) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	buf, ok := i.buffers[key]
+	if !ok {
+		return false
+	}
+	return len(buf.subscribers) > 0 || i.now().Before(buf.activeUntil)
+}
+
+func (i *Inspector) record(key inspectorKey, c *Capture) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := i.now()
+	i.gc(now)
+
+	buf, ok := i.buffers[key]
+	if !ok {
+		// the inspection has ended while the request was in flight
+		return
+	}
+
+	i.lastID++
+	c.ID = i.lastID
+
+	buf.captures = append(buf.captures, c)
+	if over := len(buf.captures) - i.Config.MaxCaptures; over > 0 {
+		buf.captures = buf.captures[over:]
+	}
+	for sub := range buf.subscribers {
+		select {
+		case sub <- c:
+		default:
+			// the inspector can't keep up - it will miss this capture
+		}
+	}
+}
+
+// gc removes captures which have exceeded their retention, and ports which are no longer inspected.
+// Callers must hold the lock.
+func (i *Inspector) gc(now time.Time) {
+	cutoff := now.Add(-time.Duration(i.Config.Retention))
+	for key, buf := range i.buffers {
+		var keep int
+		for keep < len(buf.captures) && buf.captures[keep].Time.Before(cutoff) {
+			keep++
+		}
+		buf.captures = buf.captures[keep:]
+
+		if len(buf.subscribers) == 0 && !now.Before(buf.activeUntil) {
+			delete(i.buffers, key)
+		}
+	}
+}
+
+// Handler captures requests to workspace ports while they're being inspected.
+// It must run after the sensitiveCookieHandler so that captures don't contain session cookies.
+func (i *Inspector) Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		coords := getWorkspaceCoords(req)
+		port, err := strconv.ParseUint(coords.Port, 10, 16)
+		if err != nil || coords.Debug {
+			h.ServeHTTP(resp, req)
+			return
+		}
+		key := inspectorKey{WorkspaceID: coords.ID, Port: uint32(port)}
+		if !i.capturing(key) {
+			h.ServeHTTP(resp, req)
+			return
+		}
+
+		c := &Capture{
+			Time:          i.now(),
+			Method:        req.Method,
+			URL:           req.URL.RequestURI(),
+			Proto:         req.Proto,
+			RequestHeader: req.Header.Clone(),
+		}
+		c.RequestHeader.Del("X-Gitpod-Owner-Token")
+
+		reqBody := &limitedBuffer{Max: i.Config.MaxBodySize}
+		if req.Body != nil && req.Body != http.NoBody {
+			req.Body = &captureReader{ReadCloser: req.Body, buf: reqBody}
+		}
+		cw := &captureWriter{ResponseWriter: resp, body: &limitedBuffer{Max: i.Config.MaxBodySize}}
+
+		h.ServeHTTP(cw, req)
+
+		c.Duration = i.now().Sub(c.Time)
+		c.RequestBody, c.RequestBodyTruncated = reqBody.Bytes(), reqBody.Truncated
+		c.StatusCode = cw.status
+		if c.StatusCode == 0 {
+			c.StatusCode = http.StatusOK
+		}
+		c.ResponseHeader = cw.header
+		c.ResponseBody, c.ResponseBodyTruncated = cw.body.Bytes(), cw.body.Truncated
+		c.Upgraded = cw.hijacked
+		i.record(key, c)
+	})
+}
+
+// ServeHTTP streams the captures of a workspace port as newline-delimited JSON.
+// Unless the follow query parameter is false, new captures are streamed until the client disconnects.
+func (i *Inspector) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	var (
+		coords = getWorkspaceCoords(req)
+		vars   = mux.Vars(req)
+	)
+	port, err := strconv.ParseUint(vars[inspectPortIdentifier], 10, 16)
+	if err != nil {
+		http.Error(resp, "invalid port", http.StatusBadRequest)
+		return
+	}
+
+	captures, updates, cancel := i.Subscribe(coords.ID, uint32(port))
+	defer cancel()
+
+	resp.Header().Set("Content-Type", "application/x-ndjson")
+	resp.Header().Set("Cache-Control", "no-store")
+	resp.WriteHeader(http.StatusOK)
+	flusher, _ := resp.(http.Flusher)
+	enc := json.NewEncoder(resp)
+	send := func(c *Capture) error {
+		err := enc.Encode(c)
+		if err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	}
+
+	for _, c := range captures {
+		if err := send(c); err != nil {
+			return
+		}
+	}
+	if flusher != nil {
+		flusher.Flush()
+	}
+	if req.URL.Query().Get("follow") == "false" {
+		return
+	}
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case c, ok := <-updates:
+			if !ok {
+				return
+			}
+			if err := send(c); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// limitedBuffer keeps the first Max bytes written to it.
+type limitedBuffer struct {
+	Max       int64
+	Truncated bool
+
+	buf bytes.Buffer
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.Max - int64(b.buf.Len()); int64(len(p)) > remaining {
+		b.Truncated = true
+		if remaining > 0 {
+			b.buf.Write(p[:remaining])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) Bytes() []byte {
+	if b.buf.Len() == 0 {
+		return nil
+	}
+	return b.buf.Bytes()
+}
+
+type captureReader struct {
+	io.ReadCloser
+	buf *limitedBuffer
+}
+
+func (r *captureReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		_, _ = r.buf.Write(p[:n])
+	}
+	return n, err
+}
+
+type captureWriter struct {
+	http.ResponseWriter
+
+	status   int
+	header   http.Header
+	body     *limitedBuffer
+	hijacked bool
+}
+
+func (w *captureWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+		w.header = w.ResponseWriter.Header().Clone()
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *captureWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	_, _ = w.body.Write(p)
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *captureWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *captureWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err == nil {
+		w.hijacked = true
+		w.status = http.StatusSwitchingProtocols
+		w.header = w.ResponseWriter.Header().Clone()
+	}
+	return conn, rw, err
+}
+
+func (w *captureWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/gorilla/mux"
+
+	"github.com/gitpod-io/gitpod/common-go/util"
+)
+
+func TestInspectorHandler(t *testing.T) {
+	const (
+		domain = "test-domain.com"
+		wsID   = "amaranth-smelt-9ba20cc1"
+	)
+	// the sensitiveCookieHandler always sets the cookie header
+	noCookies := http.Header{"Cookie": {""}}
+	type Request struct {
+		Port   string
+		Body   string
+		Cookie string
+	}
+	tests := []struct {
+		Name        string
+		Inspect     bool
+		MaxCaptures int
+		Requests    []Request
+		Response    string
+		Expected    []*Capture
+	}{
+		{
+			Name:     "not inspected",
+			Requests: []Request{{Port: "3000"}},
+		},
+		{
+			Name:     "other port inspected",
+			Inspect:  true,
+			Requests: []Request{{Port: "8080"}},
+		},
+		{
+			Name:     "inspected",
+			Inspect:  true,
+			Requests: []Request{{Port: "3000", Body: "hello"}},
+			Response: "world",
+			Expected: []*Capture{
+				{ID: 1, Method: "POST", URL: "/hook", Proto: "HTTP/1.1", RequestHeader: noCookies, RequestBody: []byte("hello"), StatusCode: http.StatusAccepted, ResponseHeader: http.Header{"X-Test": {"true"}}, ResponseBody: []byte("world")},
+			},
+		},
+		{
+			Name:     "truncated bodies",
+			Inspect:  true,
+			Requests: []Request{{Port: "3000", Body: "hello world"}},
+			Response: "goodbye world",
+			Expected: []*Capture{
+				{ID: 1, Method: "POST", URL: "/hook", Proto: "HTTP/1.1", RequestHeader: noCookies, RequestBody: []byte("hello"), RequestBodyTruncated: true, StatusCode: http.StatusAccepted, ResponseHeader: http.Header{"X-Test": {"true"}}, ResponseBody: []byte("goodb"), ResponseBodyTruncated: true},
+			},
+		},
+		{
+			Name:     "sensitive cookies are redacted",
+			Inspect:  true,
+			Requests: []Request{{Port: "3000", Cookie: "_test_domain_com_=session; other=value"}},
+			Expected: []*Capture{
+				{ID: 1, Method: "POST", URL: "/hook", Proto: "HTTP/1.1", RequestHeader: http.Header{"Cookie": {"other=value"}}, StatusCode: http.StatusAccepted, ResponseHeader: http.Header{"X-Test": {"true"}}},
+			},
+		},
+		{
+			Name:        "ring buffer",
+			Inspect:     true,
+			MaxCaptures: 2,
+			Requests:    []Request{{Port: "3000", Body: "1"}, {Port: "3000", Body: "2"}, {Port: "3000", Body: "3"}},
+			Expected: []*Capture{
+				{ID: 2, Method: "POST", URL: "/hook", Proto: "HTTP/1.1", RequestHeader: noCookies, RequestBody: []byte("2"), StatusCode: http.StatusAccepted, ResponseHeader: http.Header{"X-Test": {"true"}}},
+				{ID: 3, Method: "POST", URL: "/hook", Proto: "HTTP/1.1", RequestHeader: noCookies, RequestBody: []byte("3"), StatusCode: http.StatusAccepted, ResponseHeader: http.Header{"X-Test": {"true"}}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			maxCaptures := test.MaxCaptures
+			if maxCaptures == 0 {
+				maxCaptures = 10
+			}
+			inspector := NewInspector(InspectorConfig{
+				MaxBodySize: 5,
+				MaxCaptures: maxCaptures,
+				Retention:   util.Duration(time.Minute),
+			})
+			if test.Inspect {
+				_, _, cancel := inspector.Subscribe(wsID, 3000)
+				cancel()
+			}
+
+			handler := sensitiveCookieHandler(domain)(inspector.Handler(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+				_, _ = io.ReadAll(req.Body)
+				resp.Header().Set("X-Test", "true")
+				resp.WriteHeader(http.StatusAccepted)
+				_, _ = resp.Write([]byte(test.Response))
+			})))
+			for _, r := range test.Requests {
+				req := httptest.NewRequest("POST", "https://"+r.Port+"-"+wsID+"."+domain+"/hook", strings.NewReader(r.Body))
+				if r.Cookie != "" {
+					req.Header.Set("Cookie", r.Cookie)
+				}
+				req = mux.SetURLVars(req, map[string]string{
+					workspaceIDIdentifier:   wsID,
+					workspacePortIdentifier: r.Port,
+				})
+				handler.ServeHTTP(httptest.NewRecorder(), req)
+			}
+
+			act, _, cancel := inspector.Subscribe(wsID, 3000)
+			defer cancel()
+			if len(act) == 0 {
+				act = nil
+			}
+			if diff := cmp.Diff(test.Expected, act, cmpopts.IgnoreFields(Capture{}, "Time", "Duration")); diff != "" {
+				t.Errorf("unexpected captures (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInspectorRetention(t *testing.T) {
+	now := time.Now()
+	inspector := NewInspector(InspectorConfig{
+		MaxCaptures: 10,
+		Retention:   util.Duration(time.Minute),
+	})
+	inspector.now = func() time.Time { return now }
+	key := inspectorKey{WorkspaceID: "foo", Port: 3000}
+
+	_, updates, cancel := inspector.Subscribe(key.WorkspaceID, key.Port)
+	inspector.record(key, &Capture{Time: now})
+	if c := <-updates; c.ID != 1 {
+		t.Errorf("expected capture to be published, got %v", c)
+	}
+	cancel()
+
+	now = now.Add(30 * time.Second)
+	if !inspector.capturing(key) {
+		t.Errorf("expected port to be captured within retention after the inspector detached")
+	}
+	if captures, _, cancel := inspector.Subscribe(key.WorkspaceID, key.Port); len(captures) != 1 {
+		t.Errorf("expected one capture within retention, got %d", len(captures))
+	} else {
+		cancel()
+	}
+
+	now = now.Add(2 * time.Minute)
+	inspector.mu.Lock()
+	inspector.gc(now)
+	inspector.mu.Unlock()
+	if inspector.capturing(key) {
+		t.Errorf("expected port not to be captured after retention")
+	}
+}
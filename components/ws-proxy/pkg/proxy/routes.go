@@ -35,6 +35,10 @@ type RouteHandlerConfig struct {
 	DefaultTransport     http.RoundTripper
 	CorsHandler          mux.MiddlewareFunc
 	WorkspaceAuthHandler mux.MiddlewareFunc
+	// PortInspectorAuthHandler authenticates supervisor to the inspector
+	PortInspectorAuthHandler mux.MiddlewareFunc
+	// Inspector captures the traffic of inspected workspace ports. It's nil if the inspector is disabled.
+	Inspector *Inspector
 }
 
 // RouteHandlerConfigOpt modifies the router handler config.
@@ -44,6 +48,7 @@ type RouteHandlerConfigOpt func(*Config, *RouteHandlerConfig)
 func WithDefaultAuth(infoprov WorkspaceInfoProvider) RouteHandlerConfigOpt {
 	return func(config *Config, c *RouteHandlerConfig) {
 		c.WorkspaceAuthHandler = WorkspaceAuthHandler(config.GitpodInstallation.HostName, infoprov)
+		c.PortInspectorAuthHandler = PortInspectorAuthHandler(infoprov)
 	}
 }
 
@@ -55,10 +60,14 @@ func NewRouteHandlerConfig(config *Config, opts ...RouteHandlerConfigOpt) (*Rout
 	}
 
 	cfg := &RouteHandlerConfig{
-		Config:               config,
-		DefaultTransport:     createDefaultTransport(config.TransportConfig),
-		CorsHandler:          corsHandler,
-		WorkspaceAuthHandler: func(h http.Handler) http.Handler { return h },
+		Config:                   config,
+		DefaultTransport:         createDefaultTransport(config.TransportConfig),
+		CorsHandler:              corsHandler,
+		WorkspaceAuthHandler:     func(h http.Handler) http.Handler { return h },
+		PortInspectorAuthHandler: func(h http.Handler) http.Handler { return h },
+	}
+	if config.Inspector != nil {
+		cfg.Inspector = NewInspector(*config.Inspector)
 	}
 	for _, o := range opts {
 		o(config, cfg)
@@ -82,6 +91,10 @@ func installWorkspaceRoutes(r *mux.Router, config *RouteHandlerConfig, ip Worksp
 		routes.HandleSSHHostKeyRoute(r.Path("/_ssh/host_keys"), hostKeyList)
 	}
 
+	if config.Inspector != nil {
+		routes.HandleInspectorRoute(r.Path("/_inspect/{" + inspectPortIdentifier + ":[0-9]+}"))
+	}
+
 	// The favicon warants special handling, because we pull that from the supervisor frontend
 	// rather than the IDE.
 	faviconRouter := r.Path("/favicon.ico").Subrouter()
@@ -169,6 +182,14 @@ func (ir *ideRoutes) HandleSSHHostKeyRoute(route *mux.Route, hostKeyList []ssh.S
 	})
 }
 
+func (ir *ideRoutes) HandleInspectorRoute(route *mux.Route) {
+	r := route.Subrouter()
+	r.Use(logRouteHandlerHandler("HandleInspectorRoute"))
+	r.Use(ir.workspaceMustExistHandler)
+	r.Use(ir.Config.PortInspectorAuthHandler)
+	r.NewRoute().Handler(ir.Config.Inspector)
+}
+
 func (ir *ideRoutes) HandleDirectSupervisorRoute(route *mux.Route, authenticated bool) {
 	r := route.Subrouter()
 	r.Use(logRouteHandlerHandler(fmt.Sprintf("HandleDirectSupervisorRoute (authenticated: %v)", authenticated)))
@@ -407,6 +428,10 @@ func installWorkspacePortRoutes(r *mux.Router, config *RouteHandlerConfig, infoP
 	r.Use(config.WorkspaceAuthHandler)
 	// filter all session cookies
 	r.Use(sensitiveCookieHandler(config.Config.GitpodInstallation.HostName))
+	if config.Inspector != nil {
+		// capture after the session cookies were filtered so that they never show up in captures
+		r.Use(config.Inspector.Handler)
+	}
 
 	// forward request to workspace port
 	r.NewRoute().HandlerFunc(
@@ -680,6 +705,14 @@ func removeSensitiveCookies(cookies []*http.Cookie, domain string) []*http.Cooki
 			// skip owner token
 			continue
 		}
+		if strings.HasPrefix(c.Name, hostnamePrefix) && strings.HasSuffix(c.Name, "_share_") {
+			// skip port share token
+			continue
+		}
+		if strings.HasPrefix(c.Name, hostnamePrefix) && strings.HasSuffix(c.Name, "_share_nonce_") {
+			// skip port share nonce
+			continue
+		}
 		log.WithField("hostnamePrefix", hostnamePrefix).WithField("name", c.Name).Debug("keeping cookie")
 		cookies[n] = c
 		n++
@@ -969,6 +969,8 @@ func TestRemoveSensitiveCookies(t *testing.T) {
 		sessionCookie     = &http.Cookie{Domain: domain, Name: "_test_domain_com_", Value: "fobar"}
 		portAuthCookie    = &http.Cookie{Domain: domain, Name: "_test_domain_com_ws_77f6b236_3456_4b88_8284_81ca543a9d65_port_auth_", Value: "some-token"}
 		ownerCookie       = &http.Cookie{Domain: domain, Name: "_test_domain_com_ws_77f6b236_3456_4b88_8284_81ca543a9d65_owner_", Value: "some-other-token"}
+		shareCookie       = &http.Cookie{Domain: domain, Name: "_test_domain_com_ws_77f6b236_3456_4b88_8284_81ca543a9d65_port_3000_share_", Value: "123.signature"}
+		shareNonceCookie  = &http.Cookie{Domain: domain, Name: "_test_domain_com_ws_77f6b236_3456_4b88_8284_81ca543a9d65_port_3000_share_nonce_", Value: "some-nonce"}
 		miscCookie        = &http.Cookie{Domain: domain, Name: "some-other-cookie", Value: "I like cookies"}
 		invalidCookieName = &http.Cookie{Domain: domain, Name: "foobar[0]", Value: "violates RFC6266"}
 	)
@@ -982,6 +984,8 @@ func TestRemoveSensitiveCookies(t *testing.T) {
 		{"session cookie", []*http.Cookie{sessionCookie, miscCookie}, []*http.Cookie{miscCookie}},
 		{"portAuth cookie", []*http.Cookie{portAuthCookie, miscCookie}, []*http.Cookie{miscCookie}},
 		{"owner cookie", []*http.Cookie{ownerCookie, miscCookie}, []*http.Cookie{miscCookie}},
+		{"share cookie", []*http.Cookie{shareCookie, miscCookie}, []*http.Cookie{miscCookie}},
+		{"share nonce cookie", []*http.Cookie{shareNonceCookie, miscCookie}, []*http.Cookie{miscCookie}},
 		{"misc cookie", []*http.Cookie{miscCookie}, []*http.Cookie{miscCookie}},
 		{"invalid cookie name", []*http.Cookie{invalidCookieName}, []*http.Cookie{invalidCookieName}},
 	}
@@ -0,0 +1,107 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package proxy
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// portShareTokenParam is the query parameter which carries a port share token
+	portShareTokenParam = "gitpod_port_token"
+	// portShareGrantParam is the query parameter which carries a restricted share link the server still has to redeem
+	portShareGrantParam = "gitpod_port_grant"
+	// portShareOpenPath is where the Gitpod server redeems restricted share links
+	portShareOpenPath = "/api/port-share/open"
+)
+
+// Port share tokens grant access to a shared workspace port until they expire. The Gitpod server mints
+// them in the form <expiry>.<signature> where expiry is a Unix timestamp (seconds) and signature is the
+// unpadded base64url encoded HMAC-SHA256 of "<instanceID>:<port>:<expiry>", keyed with the owner token
+// of the workspace instance. Tokens therefore become invalid once the instance stops.
+//
+// Tokens of share links which are restricted to users or team members are bound to the browser which
+// redeemed the link: they have the form <expiry>.<binding>.<signature>, where binding is the unpadded base64url
+// encoded SHA-256 of a nonce ws-proxy keeps in a cookie, and the signature covers "<instanceID>:<port>:<expiry>:<binding>".
+// Such tokens are only accepted together with the nonce cookie, so that they are useless when forwarded.
+
+func signPortShareToken(ownerToken, instanceID string, port uint32, expires time.Time, binding string) string {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	if binding == "" {
+		return exp + "." + portShareSignature(ownerToken, instanceID, port, exp, "")
+	}
+	return exp + "." + binding + "." + portShareSignature(ownerToken, instanceID, port, exp, binding)
+}
+
+// verifyPortShareToken checks a port share token and returns its expiry time and binding
+func verifyPortShareToken(tkn, ownerToken, instanceID string, port uint32, now time.Time) (expires time.Time, binding string, err error) {
+	if ownerToken == "" {
+		return time.Time{}, "", xerrors.Errorf("workspace has no owner token")
+	}
+
+	var exp, sig string
+	segs := strings.Split(tkn, ".")
+	switch len(segs) {
+	case 2:
+		exp, sig = segs[0], segs[1]
+	case 3:
+		exp, binding, sig = segs[0], segs[1], segs[2]
+		if binding == "" {
+			return time.Time{}, "", xerrors.Errorf("malformed token binding")
+		}
+	default:
+		return time.Time{}, "", xerrors.Errorf("malformed token")
+	}
+	expUnix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return time.Time{}, "", xerrors.Errorf("malformed token expiry: %w", err)
+	}
+
+	expected := portShareSignature(ownerToken, instanceID, port, exp, binding)
+	if !hmac.Equal([]byte(sig), []byte(expected)) {
+		return time.Time{}, "", xerrors.Errorf("invalid token signature")
+	}
+
+	expires = time.Unix(expUnix, 0)
+	if !now.Before(expires) {
+		return time.Time{}, "", xerrors.Errorf("token expired at %s", expires.UTC().Format(time.RFC3339))
+	}
+	return expires, binding, nil
+}
+
+func portShareSignature(ownerToken, instanceID string, port uint32, exp, binding string) string {
+	mac := hmac.New(sha256.New, []byte(ownerToken))
+	if binding == "" {
+		fmt.Fprintf(mac, "%s:%d:%s", instanceID, port, exp)
+	} else {
+		fmt.Fprintf(mac, "%s:%d:%s:%s", instanceID, port, exp, binding)
+	}
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// newPortShareNonce creates the nonce a browser keeps to prove it redeemed a restricted share link
+func newPortShareNonce() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// portShareBinding is the binding of tokens minted for the browser holding nonce
+func portShareBinding(nonce string) string {
+	h := sha256.Sum256([]byte(nonce))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
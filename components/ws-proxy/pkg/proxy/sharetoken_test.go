@@ -0,0 +1,60 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package proxy
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyPortShareToken(t *testing.T) {
+	const (
+		ownerToken = "owner-token"
+		instanceID = "instance-id"
+		port       = 3000
+	)
+	now := time.Unix(1672531200, 0)
+	valid := signPortShareToken(ownerToken, instanceID, port, now.Add(2*time.Hour), "")
+	binding := portShareBinding("nonce")
+	bound := signPortShareToken(ownerToken, instanceID, port, now.Add(2*time.Hour), binding)
+
+	tests := []struct {
+		Name       string
+		Token      string
+		OwnerToken string
+		InstanceID string
+		Port       uint32
+		Binding    string
+		ExpectErr  bool
+	}{
+		{Name: "valid", Token: valid, OwnerToken: ownerToken, InstanceID: instanceID, Port: port},
+		{Name: "other port", Token: valid, OwnerToken: ownerToken, InstanceID: instanceID, Port: port + 1, ExpectErr: true},
+		{Name: "other instance", Token: valid, OwnerToken: ownerToken, InstanceID: "other", Port: port, ExpectErr: true},
+		{Name: "other owner token", Token: valid, OwnerToken: "rotated", InstanceID: instanceID, Port: port, ExpectErr: true},
+		{Name: "no owner token", Token: valid, InstanceID: instanceID, Port: port, ExpectErr: true},
+		{Name: "bound", Token: bound, OwnerToken: ownerToken, InstanceID: instanceID, Port: port, Binding: binding},
+		{Name: "other binding", Token: "1672538400." + portShareBinding("other") + bound[strings.LastIndex(bound, "."):], OwnerToken: ownerToken, InstanceID: instanceID, Port: port, ExpectErr: true},
+		{Name: "binding removed", Token: "1672538400" + bound[strings.LastIndex(bound, "."):], OwnerToken: ownerToken, InstanceID: instanceID, Port: port, ExpectErr: true},
+		{Name: "expired", Token: signPortShareToken(ownerToken, instanceID, port, now, ""), OwnerToken: ownerToken, InstanceID: instanceID, Port: port, ExpectErr: true},
+		{Name: "extended expiry", Token: "9999999999" + valid[len("1672538400"):], OwnerToken: ownerToken, InstanceID: instanceID, Port: port, ExpectErr: true},
+		{Name: "malformed", Token: "foobar", OwnerToken: ownerToken, InstanceID: instanceID, Port: port, ExpectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			expires, binding, err := verifyPortShareToken(test.Token, test.OwnerToken, test.InstanceID, test.Port, now)
+			if (err != nil) != test.ExpectErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err == nil && !expires.Equal(now.Add(2*time.Hour)) {
+				t.Errorf("unexpected expiry: %v", expires)
+			}
+			if binding != test.Binding {
+				t.Errorf("unexpected binding: %q", binding)
+			}
+		})
+	}
+}
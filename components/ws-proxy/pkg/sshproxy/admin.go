@@ -0,0 +1,61 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package sshproxy
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// AdminHandler serves the admin API of the SSH gateway:
+//
+//	GET    /sessions[?workspaceId=<id>]   lists the live sessions
+//	DELETE /sessions/<id>[?reason=<text>] disconnects a live session
+//
+// The handler does not authenticate requests, it must only be served on a localhost address
+// and rejects requests from other hosts.
+//
+// Sessions are not shared between ws-proxy replicas: the API of a replica only lists and disconnects the sessions
+// served by that replica, and each session names its replica in the gateway field. To see all sessions of a cluster,
+// query the API of every ws-proxy pod, e.g. through kubectl exec.
+func (s *Server) AdminHandler() http.Handler {
+	r := mux.NewRouter()
+	r.Use(localhostOnly)
+	r.Path("/sessions").Methods(http.MethodGet).HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(s.Sessions(req.URL.Query().Get("workspaceId")))
+		if err != nil {
+			log.WithError(err).Debug("cannot write ssh sessions")
+		}
+	})
+	r.Path("/sessions/{id}").Methods(http.MethodDelete).HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		reason := req.URL.Query().Get("reason")
+		if reason == "" {
+			reason = "disconnected by admin"
+		}
+		if !s.Disconnect(mux.Vars(req)["id"], reason) {
+			http.Error(w, "session not found", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return r
+}
+
+func localhostOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		host, _, err := net.SplitHostPort(req.RemoteAddr)
+		if ip := net.ParseIP(host); err != nil || ip == nil || !ip.IsLoopback() {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
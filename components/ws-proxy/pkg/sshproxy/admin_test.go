@@ -0,0 +1,37 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package sshproxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminHandlerLocalhostOnly(t *testing.T) {
+	tests := []struct {
+		RemoteAddr string
+		Expected   int
+	}{
+		{RemoteAddr: "127.0.0.1:41234", Expected: http.StatusOK},
+		{RemoteAddr: "[::1]:41234", Expected: http.StatusOK},
+		{RemoteAddr: "10.0.0.12:41234", Expected: http.StatusForbidden},
+		{RemoteAddr: "invalid", Expected: http.StatusForbidden},
+	}
+
+	s := &Server{}
+	handler := s.AdminHandler()
+	for _, test := range tests {
+		t.Run(test.RemoteAddr, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/sessions", nil)
+			req.RemoteAddr = test.RemoteAddr
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != test.Expected {
+				t.Errorf("got status %d, expected %d", rec.Code, test.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package sshproxy
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gitpod-io/golang-crypto/ssh"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"golang.org/x/xerrors"
+)
+
+// UserCAConfig configures the certificate authority whose OpenSSH user certificates the SSH gateway accepts.
+type UserCAConfig struct {
+	// TrustedCAKeysFile contains the public keys of the trusted CAs in authorized_keys format.
+	TrustedCAKeysFile string `json:"trustedCAKeysFile"`
+	// PrincipalPrefix is stripped from certificate principals before they're matched against Gitpod user IDs,
+	// e.g. a certificate with principal "gitpod:<userID>" is accepted for the workspaces of <userID> if the prefix is "gitpod:".
+	PrincipalPrefix string `json:"principalPrefix,omitempty"`
+	// Principals maps certificate principals to Gitpod user IDs, e.g. to use existing user names as principals.
+	Principals map[string]string `json:"principals,omitempty"`
+}
+
+// Validate validates the certificate authority configuration.
+func (c *UserCAConfig) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.TrustedCAKeysFile, validation.Required),
+	)
+}
+
+// sourceAddressCriticalOption restricts the client addresses a certificate may be used from
+const sourceAddressCriticalOption = "source-address"
+
+// CertificateAuthority verifies OpenSSH user certificates against a set of trusted CAs.
+type CertificateAuthority struct {
+	Keys            []ssh.PublicKey
+	PrincipalPrefix string
+	Principals      map[string]string
+
+	clock func() time.Time
+}
+
+// NewCertificateAuthority loads the trusted CA keys.
+func NewCertificateAuthority(cfg UserCAConfig) (*CertificateAuthority, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, xerrors.Errorf("invalid user CA config: %w", err)
+	}
+	fc, err := os.ReadFile(cfg.TrustedCAKeysFile)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read trusted CA keys: %w", err)
+	}
+
+	var keys []ssh.PublicKey
+	for rest := fc; len(bytes.TrimSpace(rest)) > 0; {
+		var key ssh.PublicKey
+		key, _, _, rest, err = ssh.ParseAuthorizedKey(rest)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot parse trusted CA keys: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, xerrors.Errorf("%s contains no CA keys", cfg.TrustedCAKeysFile)
+	}
+
+	return &CertificateAuthority{
+		Keys:            keys,
+		PrincipalPrefix: cfg.PrincipalPrefix,
+		Principals:      cfg.Principals,
+	}, nil
+}
+
+// IsUserAuthority returns true if the key belongs to a trusted CA.
+func (ca *CertificateAuthority) IsUserAuthority(auth ssh.PublicKey) bool {
+	authData := auth.Marshal()
+	for _, k := range ca.Keys {
+		if bytes.Equal(k.Marshal(), authData) {
+			return true
+		}
+	}
+	return false
+}
+
+// UserID returns the Gitpod user a certificate principal stands for.
+func (ca *CertificateAuthority) UserID(principal string) string {
+	if userID, ok := ca.Principals[principal]; ok {
+		return userID
+	}
+	if ca.PrincipalPrefix == "" {
+		return principal
+	}
+	if !strings.HasPrefix(principal, ca.PrincipalPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(principal, ca.PrincipalPrefix)
+}
+
+// Verify checks that the certificate was issued by a trusted CA to the given Gitpod user, and that it is valid now.
+// It returns the principal the certificate was accepted for, and its critical options. The ssh server enforces the
+// source-address option based on the critical options of the permissions returned from the authentication callback,
+// against the remote address of the connection. That is the client only if the gateway speaks the PROXY protocol.
+func (ca *CertificateAuthority) Verify(cert *ssh.Certificate, userID string) (principal string, criticalOptions map[string]string, err error) {
+	if cert.CertType != ssh.UserCert {
+		return "", nil, xerrors.Errorf("certificate is not a user certificate")
+	}
+	if !ca.IsUserAuthority(cert.SignatureKey) {
+		return "", nil, xerrors.Errorf("certificate is not signed by a trusted CA")
+	}
+	// certificates without principals are valid for everyone, which is never what we want here
+	for _, p := range cert.ValidPrincipals {
+		if id := ca.UserID(p); id != "" && id == userID {
+			principal = p
+			break
+		}
+	}
+	if principal == "" {
+		return "", nil, xerrors.Errorf("certificate has no principal for user %s", userID)
+	}
+
+	checker := &ssh.CertChecker{
+		IsUserAuthority:          ca.IsUserAuthority,
+		SupportedCriticalOptions: []string{sourceAddressCriticalOption},
+		Clock:                    ca.clock,
+	}
+	err = checker.CheckCert(principal, cert)
+	if err != nil {
+		return "", nil, err
+	}
+	return principal, cert.CriticalOptions, nil
+}
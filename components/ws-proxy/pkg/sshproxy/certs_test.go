@@ -0,0 +1,165 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package sshproxy
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/golang-crypto/ssh"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCertificateAuthorityVerify(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	newSigner := func() ssh.Signer {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer, err := ssh.NewSignerFromKey(priv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signer
+	}
+	var (
+		caKey        = newSigner()
+		untrustedKey = newSigner()
+		userKey      = newSigner()
+	)
+
+	type Cert struct {
+		Type            uint32
+		Principals      []string
+		ValidAfter      time.Time
+		ValidBefore     time.Time
+		CriticalOptions map[string]string
+		Untrusted       bool
+	}
+	type Expectation struct {
+		Principal       string
+		CriticalOptions map[string]string
+		Error           bool
+	}
+	valid := Cert{
+		Type:        ssh.UserCert,
+		Principals:  []string{"gitpod:owner"},
+		ValidAfter:  now.Add(-time.Hour),
+		ValidBefore: now.Add(time.Hour),
+	}
+	tests := []struct {
+		Name        string
+		Cert        func(c Cert) Cert
+		Principals  map[string]string
+		Expectation Expectation
+	}{
+		{
+			Name:        "valid",
+			Expectation: Expectation{Principal: "gitpod:owner"},
+		},
+		{
+			Name:        "mapped principal",
+			Cert:        func(c Cert) Cert { c.Principals = []string{"alice"}; return c },
+			Principals:  map[string]string{"alice": "owner"},
+			Expectation: Expectation{Principal: "alice"},
+		},
+		{
+			Name:        "other user",
+			Cert:        func(c Cert) Cert { c.Principals = []string{"gitpod:someone-else"}; return c },
+			Expectation: Expectation{Error: true},
+		},
+		{
+			Name:        "principal without prefix",
+			Cert:        func(c Cert) Cert { c.Principals = []string{"owner"}; return c },
+			Expectation: Expectation{Error: true},
+		},
+		{
+			Name:        "no principals",
+			Cert:        func(c Cert) Cert { c.Principals = nil; return c },
+			Expectation: Expectation{Error: true},
+		},
+		{
+			Name:        "expired",
+			Cert:        func(c Cert) Cert { c.ValidBefore = now.Add(-time.Minute); return c },
+			Expectation: Expectation{Error: true},
+		},
+		{
+			Name:        "not yet valid",
+			Cert:        func(c Cert) Cert { c.ValidAfter = now.Add(time.Minute); return c },
+			Expectation: Expectation{Error: true},
+		},
+		{
+			Name:        "untrusted CA",
+			Cert:        func(c Cert) Cert { c.Untrusted = true; return c },
+			Expectation: Expectation{Error: true},
+		},
+		{
+			Name:        "host certificate",
+			Cert:        func(c Cert) Cert { c.Type = ssh.HostCert; return c },
+			Expectation: Expectation{Error: true},
+		},
+		{
+			Name: "source address",
+			Cert: func(c Cert) Cert {
+				c.CriticalOptions = map[string]string{"source-address": "10.0.0.0/8"}
+				return c
+			},
+			Expectation: Expectation{Principal: "gitpod:owner", CriticalOptions: map[string]string{"source-address": "10.0.0.0/8"}},
+		},
+		{
+			Name: "unsupported critical option",
+			Cert: func(c Cert) Cert {
+				c.CriticalOptions = map[string]string{"force-command": "/bin/true"}
+				return c
+			},
+			Expectation: Expectation{Error: true},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			c := valid
+			if test.Cert != nil {
+				c = test.Cert(c)
+			}
+			cert := &ssh.Certificate{
+				Key:             userKey.PublicKey(),
+				CertType:        c.Type,
+				KeyId:           "test",
+				ValidPrincipals: c.Principals,
+				ValidAfter:      uint64(c.ValidAfter.Unix()),
+				ValidBefore:     uint64(c.ValidBefore.Unix()),
+				Permissions:     ssh.Permissions{CriticalOptions: c.CriticalOptions},
+			}
+			signer := caKey
+			if c.Untrusted {
+				signer = untrustedKey
+			}
+			if err := cert.SignCert(rand.Reader, signer); err != nil {
+				t.Fatal(err)
+			}
+
+			ca := &CertificateAuthority{
+				Keys:            []ssh.PublicKey{caKey.PublicKey()},
+				PrincipalPrefix: "gitpod:",
+				Principals:      test.Principals,
+				clock:           func() time.Time { return now },
+			}
+			var act Expectation
+			principal, opts, err := ca.Verify(cert, "owner")
+			if err != nil {
+				act.Error = true
+			} else {
+				act.Principal = principal
+				act.CriticalOptions = opts
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
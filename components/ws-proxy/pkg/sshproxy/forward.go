@@ -6,6 +6,8 @@ package sshproxy
 
 import (
 	"io"
+	"net"
+	"strconv"
 	"sync"
 	"time"
 
@@ -35,6 +37,24 @@ func (s *Server) ChannelForward(ctx context.Context, session *Session, targetCon
 	}
 	defer originChan.Close()
 
+	// only channels opened by the client are recorded
+	var (
+		recorded           = session.recording != nil && targetConn != ssh.Conn(session.Conn)
+		record             = func(evt RecordingEvent) {}
+		output   io.Writer = originChan
+	)
+	if recorded {
+		channelIdx := session.nextChannelIdx()
+		record = func(evt RecordingEvent) {
+			evt.Channel = channelIdx
+			session.Record(evt)
+		}
+		record(recordChannelOpen(originChannel))
+		if s.RecordOutput {
+			output = newOutputRecorder(originChan, s.MaxOutputBytes, record)
+		}
+	}
+
 	maskedReqs := make(chan *ssh.Request, 1)
 
 	go func() {
@@ -48,6 +68,14 @@ func (s *Server) ChannelForward(ctx context.Context, session *Session, targetCon
 					channel.mux.Unlock()
 				}
 			}
+			if recorded {
+				if evt, ok := recordRequest(req); ok {
+					record(evt)
+				}
+				if o, ok := output.(*outputRecorder); ok && req.Type == "pty-req" {
+					o.Enable()
+				}
+			}
 			maskedReqs <- req
 		}
 		close(maskedReqs)
@@ -59,7 +87,7 @@ func (s *Server) ChannelForward(ctx context.Context, session *Session, targetCon
 	}()
 
 	go func() {
-		io.Copy(originChan, targetChan)
+		io.Copy(output, targetChan)
 		originChan.CloseWrite()
 	}()
 
@@ -92,6 +120,65 @@ func (s *Server) ChannelForward(ctx context.Context, session *Session, targetCon
 	log.WithFields(log.OWI("", session.WorkspaceID, session.InstanceID)).Debug("session forward stop")
 }
 
+// recordChannelOpen describes a new channel for the session recording.
+func recordChannelOpen(c ssh.NewChannel) RecordingEvent {
+	evt := RecordingEvent{Type: RecordingEventChannelOpen, ChannelType: c.ChannelType()}
+	if c.ChannelType() == "direct-tcpip" {
+		var msg struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if err := ssh.Unmarshal(c.ExtraData(), &msg); err == nil {
+			evt.Target = net.JoinHostPort(msg.Host, strconv.FormatUint(uint64(msg.Port), 10))
+		}
+	}
+	return evt
+}
+
+// recordRequest describes the channel requests which are relevant for auditing.
+func recordRequest(req *ssh.Request) (RecordingEvent, bool) {
+	switch req.Type {
+	case "pty-req":
+		var msg struct {
+			Term     string
+			Columns  uint32
+			Rows     uint32
+			Width    uint32
+			Height   uint32
+			Modelist string
+		}
+		evt := RecordingEvent{Type: RecordingEventPTY}
+		if err := ssh.Unmarshal(req.Payload, &msg); err == nil {
+			evt.Term = msg.Term
+		}
+		return evt, true
+	case "shell":
+		return RecordingEvent{Type: RecordingEventShell}, true
+	case "exec":
+		var msg struct {
+			Command string
+		}
+		evt := RecordingEvent{Type: RecordingEventExec}
+		if err := ssh.Unmarshal(req.Payload, &msg); err == nil {
+			evt.Command = msg.Command
+		}
+		return evt, true
+	case "subsystem":
+		var msg struct {
+			Name string
+		}
+		evt := RecordingEvent{Type: RecordingEventSubsystem}
+		if err := ssh.Unmarshal(req.Payload, &msg); err == nil {
+			evt.Subsystem = msg.Name
+		}
+		return evt, true
+	default:
+		return RecordingEvent{}, false
+	}
+}
+
 func TrackIDECloseSignal(session *Session) {
 	propertics := make(map[string]interface{})
 	propertics["workspaceId"] = session.WorkspaceID
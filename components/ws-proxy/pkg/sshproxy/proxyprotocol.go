@@ -0,0 +1,87 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package sshproxy
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// proxyProtocolMaxHeaderLen is the maximum length of a PROXY protocol v1 header including its CRLF
+	proxyProtocolMaxHeaderLen = 107
+	// proxyProtocolHeaderTimeout limits how long we wait for the PROXY protocol header
+	proxyProtocolHeaderTimeout = 10 * time.Second
+)
+
+// proxyProtocolConn is a connection whose remote address is the client address announced in its PROXY protocol header
+type proxyProtocolConn struct {
+	net.Conn
+	r      *bufio.Reader
+	remote net.Addr
+}
+
+func (c *proxyProtocolConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+func (c *proxyProtocolConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+// acceptProxyProtocol reads the PROXY protocol v1 header the SSH tunnel of proxy sends ahead of the connection
+// of the client, and returns a connection whose remote address is that of the client.
+func acceptProxyProtocol(conn net.Conn) (net.Conn, error) {
+	err := conn.SetReadDeadline(time.Now().Add(proxyProtocolHeaderTimeout))
+	if err != nil {
+		return nil, err
+	}
+	r := bufio.NewReaderSize(conn, proxyProtocolMaxHeaderLen)
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read PROXY protocol header: %w", err)
+	}
+	remote, err := parseProxyProtocolHeader(string(line))
+	if err != nil {
+		return nil, err
+	}
+	err = conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	return &proxyProtocolConn{Conn: conn, r: r, remote: remote}, nil
+}
+
+// parseProxyProtocolHeader returns the source address of a PROXY protocol v1 header, e.g.
+// "PROXY TCP4 192.0.2.1 198.51.100.1 56324 22\r\n". Headers which do not name the source are rejected.
+func parseProxyProtocolHeader(header string) (*net.TCPAddr, error) {
+	if !strings.HasSuffix(header, "\r\n") {
+		return nil, xerrors.Errorf("PROXY protocol header does not end with CRLF")
+	}
+	fields := strings.Split(strings.TrimSuffix(header, "\r\n"), " ")
+	if len(fields) != 6 || fields[0] != "PROXY" {
+		return nil, xerrors.Errorf("invalid PROXY protocol header")
+	}
+
+	ip := net.ParseIP(fields[2])
+	switch {
+	case ip == nil:
+		return nil, xerrors.Errorf("invalid PROXY protocol source address %q", fields[2])
+	case fields[1] == "TCP4" && ip.To4() == nil:
+		return nil, xerrors.Errorf("PROXY protocol source address %s does not match %s", fields[2], fields[1])
+	case fields[1] != "TCP4" && fields[1] != "TCP6":
+		return nil, xerrors.Errorf("unsupported PROXY protocol family %q", fields[1])
+	}
+	port, err := strconv.ParseUint(fields[4], 10, 16)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid PROXY protocol source port %q", fields[4])
+	}
+	return &net.TCPAddr{IP: ip, Port: int(port)}, nil
+}
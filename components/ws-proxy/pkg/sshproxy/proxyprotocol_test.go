@@ -0,0 +1,65 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package sshproxy
+
+import (
+	"io"
+	"net"
+	"testing"
+)
+
+func TestParseProxyProtocolHeader(t *testing.T) {
+	tests := []struct {
+		Header        string
+		Expectation   string
+		ExpectedError bool
+	}{
+		{Header: "PROXY TCP4 192.0.2.1 198.51.100.1 56324 22\r\n", Expectation: "192.0.2.1:56324"},
+		{Header: "PROXY TCP6 2001:db8::1 2001:db8::2 56324 22\r\n", Expectation: "[2001:db8::1]:56324"},
+		{Header: "PROXY UNKNOWN\r\n", ExpectedError: true},
+		{Header: "PROXY UNKNOWN 192.0.2.1 198.51.100.1 56324 22\r\n", ExpectedError: true},
+		{Header: "PROXY TCP4 2001:db8::1 198.51.100.1 56324 22\r\n", ExpectedError: true},
+		{Header: "PROXY TCP6 192.0.2.1 2001:db8::2 56324 22\r\n", Expectation: "192.0.2.1:56324"},
+		{Header: "PROXY TCP4 192.0.2.1 198.51.100.1 99999 22\r\n", ExpectedError: true},
+		{Header: "PROXY TCP4 192.0.2.1 198.51.100.1 56324 22\n", ExpectedError: true},
+		{Header: "SSH-2.0-OpenSSH_9.0\r\n", ExpectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Header, func(t *testing.T) {
+			act, err := parseProxyProtocolHeader(test.Header)
+			if (err != nil) != test.ExpectedError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err == nil && act.String() != test.Expectation {
+				t.Errorf("unexpected address %s, expected %s", act, test.Expectation)
+			}
+		})
+	}
+}
+
+func TestAcceptProxyProtocol(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	go func() {
+		_, _ = client.Write([]byte("PROXY TCP4 192.0.2.1 198.51.100.1 56324 22\r\nSSH-2.0-OpenSSH_9.0\r\n"))
+	}()
+
+	conn, err := acceptProxyProtocol(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if act := conn.RemoteAddr().String(); act != "192.0.2.1:56324" {
+		t.Errorf("unexpected remote address %s", act)
+	}
+	banner := make([]byte, len("SSH-2.0-OpenSSH_9.0\r\n"))
+	_, err = io.ReadFull(conn, banner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(banner) != "SSH-2.0-OpenSSH_9.0\r\n" {
+		t.Errorf("unexpected data after the header: %q", banner)
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package sshproxy
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// RecordingSinkFile writes one JSONL file per session into a directory.
+	RecordingSinkFile = "file"
+	// RecordingSinkJSONL appends the events of all sessions to a single JSONL file, e.g. /dev/stdout.
+	RecordingSinkJSONL = "jsonl"
+)
+
+// RecordingConfig configures the recording of SSH sessions.
+type RecordingConfig struct {
+	// Sink is where recordings are written to, either "file" or "jsonl".
+	Sink string `json:"sink"`
+	// Directory is the directory the file sink writes session recordings to.
+	Directory string `json:"directory,omitempty"`
+	// Path is the file the jsonl sink appends to.
+	Path string `json:"path,omitempty"`
+	// RecordOutput enables recording the output of interactive (PTY) sessions.
+	RecordOutput bool `json:"recordOutput"`
+	// MaxOutputBytes limits the output recorded per channel. Zero means no limit.
+	MaxOutputBytes int64 `json:"maxOutputBytes,omitempty"`
+}
+
+// Validate validates the recording configuration.
+func (c *RecordingConfig) Validate() error {
+	var directoryRules, pathRules []validation.Rule
+	switch c.Sink {
+	case RecordingSinkFile:
+		directoryRules = append(directoryRules, validation.Required)
+	case RecordingSinkJSONL:
+		pathRules = append(pathRules, validation.Required)
+	}
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Sink, validation.Required, validation.In(RecordingSinkFile, RecordingSinkJSONL)),
+		validation.Field(&c.Directory, directoryRules...),
+		validation.Field(&c.Path, pathRules...),
+		validation.Field(&c.MaxOutputBytes, validation.Min(int64(0))),
+	)
+}
+
+// RecordingEventType describes what happened in a recorded SSH session.
+type RecordingEventType string
+
+const (
+	RecordingEventSessionStart RecordingEventType = "session_start"
+	RecordingEventSessionEnd   RecordingEventType = "session_end"
+	RecordingEventChannelOpen  RecordingEventType = "channel_open"
+	RecordingEventPTY          RecordingEventType = "pty"
+	RecordingEventShell        RecordingEventType = "shell"
+	RecordingEventExec         RecordingEventType = "exec"
+	RecordingEventSubsystem    RecordingEventType = "subsystem"
+	RecordingEventOutput       RecordingEventType = "output"
+)
+
+// SessionInfo describes a live SSH session.
+type SessionInfo struct {
+	ID            string `json:"id"`
+	WorkspaceID   string `json:"workspaceId"`
+	InstanceID    string `json:"instanceId"`
+	OwnerUserID   string `json:"ownerUserId"`
+	CertPrincipal string `json:"certPrincipal,omitempty"`
+	// RemoteAddr is the address of the client, if the gateway knows it. See Server.ProxyProtocol.
+	RemoteAddr string `json:"remoteAddr,omitempty"`
+	// Gateway identifies the ws-proxy replica which serves the session.
+	Gateway       string    `json:"gateway,omitempty"`
+	ClientVersion string    `json:"clientVersion"`
+	StartedAt     time.Time `json:"startedAt"`
+}
+
+// RecordingEvent is a single entry of a session recording.
+type RecordingEvent struct {
+	Time      time.Time          `json:"time"`
+	SessionID string             `json:"sessionId"`
+	Type      RecordingEventType `json:"type"`
+
+	// Session is set for session_start events.
+	Session *SessionInfo `json:"session,omitempty"`
+	// Channel identifies the channel within the session the event happened on.
+	Channel uint32 `json:"channel,omitempty"`
+	// ChannelType is set for channel_open events.
+	ChannelType string `json:"channelType,omitempty"`
+	// Target is the forwarding destination of direct-tcpip channels.
+	Target string `json:"target,omitempty"`
+
+	Term      string `json:"term,omitempty"`
+	Command   string `json:"command,omitempty"`
+	Subsystem string `json:"subsystem,omitempty"`
+
+	Data      []byte `json:"data,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+
+	// Reason is set for session_end events.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Recorder starts recordings of SSH sessions.
+type Recorder interface {
+	// Open starts the recording of a session.
+	Open(session SessionInfo) (Recording, error)
+}
+
+// Recording records the events of a single SSH session.
+type Recording interface {
+	Record(evt RecordingEvent) error
+	Close() error
+}
+
+// NewRecorder creates a recorder for the configured sink.
+func NewRecorder(cfg RecordingConfig) (Recorder, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, xerrors.Errorf("invalid recording config: %w", err)
+	}
+	switch cfg.Sink {
+	case RecordingSinkFile:
+		err = os.MkdirAll(cfg.Directory, 0700)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create recording directory: %w", err)
+		}
+		return &FileRecorder{Directory: cfg.Directory}, nil
+	case RecordingSinkJSONL:
+		f, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot open recording file: %w", err)
+		}
+		return NewJSONLRecorder(f), nil
+	default:
+		return nil, xerrors.Errorf("unknown recording sink %q", cfg.Sink)
+	}
+}
+
+// FileRecorder writes every session into its own JSONL file.
+type FileRecorder struct {
+	Directory string
+}
+
+// Open creates the recording file of a session.
+func (r *FileRecorder) Open(session SessionInfo) (Recording, error) {
+	fn := filepath.Join(r.Directory, filepath.Base(session.ID)+".jsonl")
+	f, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create recording: %w", err)
+	}
+	return &jsonlRecording{enc: json.NewEncoder(f), mu: &sync.Mutex{}, closer: f}, nil
+}
+
+// JSONLRecorder writes the events of all sessions into a single stream.
+type JSONLRecorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONLRecorder creates a recorder which writes to out.
+func NewJSONLRecorder(out io.Writer) *JSONLRecorder {
+	return &JSONLRecorder{enc: json.NewEncoder(out)}
+}
+
+// Open starts the recording of a session.
+func (r *JSONLRecorder) Open(session SessionInfo) (Recording, error) {
+	return &jsonlRecording{enc: r.enc, mu: &r.mu}, nil
+}
+
+type jsonlRecording struct {
+	mu     *sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+	closed bool
+}
+
+func (r *jsonlRecording) Record(evt RecordingEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return xerrors.Errorf("recording is closed")
+	}
+	return r.enc.Encode(evt)
+}
+
+func (r *jsonlRecording) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// outputRecorder records the data written to a channel while recording is enabled.
+type outputRecorder struct {
+	io.Writer
+
+	mu        sync.Mutex
+	enabled   bool
+	remaining int64
+	limited   bool
+	truncated bool
+	record    func(evt RecordingEvent)
+}
+
+func newOutputRecorder(w io.Writer, maxBytes int64, record func(evt RecordingEvent)) *outputRecorder {
+	return &outputRecorder{
+		Writer:    w,
+		remaining: maxBytes,
+		limited:   maxBytes > 0,
+		record:    record,
+	}
+}
+
+// Enable starts recording the output.
+func (o *outputRecorder) Enable() {
+	o.mu.Lock()
+	o.enabled = true
+	o.mu.Unlock()
+}
+
+func (o *outputRecorder) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	if o.enabled && !o.truncated && len(p) > 0 {
+		data := p
+		if o.limited && int64(len(data)) > o.remaining {
+			data = data[:o.remaining]
+			o.truncated = true
+		}
+		o.remaining -= int64(len(data))
+		evt := RecordingEvent{Type: RecordingEventOutput, Truncated: o.truncated}
+		// the slice is reused by io.Copy, hence we must copy it
+		evt.Data = append([]byte(nil), data...)
+		o.record(evt)
+	}
+	o.mu.Unlock()
+	return o.Writer.Write(p)
+}
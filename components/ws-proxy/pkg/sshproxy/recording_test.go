@@ -0,0 +1,134 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package sshproxy
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gitpod-io/golang-crypto/ssh"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRecordRequest(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Request  *ssh.Request
+		Expected *RecordingEvent
+	}{
+		{
+			Name: "exec",
+			Request: &ssh.Request{Type: "exec", Payload: ssh.Marshal(struct {
+				Command string
+			}{"cat /etc/passwd"})},
+			Expected: &RecordingEvent{Type: RecordingEventExec, Command: "cat /etc/passwd"},
+		},
+		{
+			Name: "subsystem",
+			Request: &ssh.Request{Type: "subsystem", Payload: ssh.Marshal(struct {
+				Name string
+			}{"sftp"})},
+			Expected: &RecordingEvent{Type: RecordingEventSubsystem, Subsystem: "sftp"},
+		},
+		{
+			Name: "pty",
+			Request: &ssh.Request{Type: "pty-req", Payload: ssh.Marshal(struct {
+				Term                         string
+				Columns, Rows, Width, Height uint32
+				Modelist                     string
+			}{Term: "xterm-256color", Columns: 80, Rows: 24})},
+			Expected: &RecordingEvent{Type: RecordingEventPTY, Term: "xterm-256color"},
+		},
+		{
+			Name:     "shell",
+			Request:  &ssh.Request{Type: "shell"},
+			Expected: &RecordingEvent{Type: RecordingEventShell},
+		},
+		{
+			Name:    "window change is not recorded",
+			Request: &ssh.Request{Type: "window-change"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var act *RecordingEvent
+			if evt, ok := recordRequest(test.Request); ok {
+				act = &evt
+			}
+			if diff := cmp.Diff(test.Expected, act); diff != "" {
+				t.Errorf("unexpected event (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOutputRecorder(t *testing.T) {
+	var events []RecordingEvent
+	o := newOutputRecorder(io.Discard, 8, func(evt RecordingEvent) {
+		events = append(events, evt)
+	})
+
+	_, _ = o.Write([]byte("before pty"))
+	o.Enable()
+	_, _ = o.Write([]byte("hello"))
+	_, _ = o.Write([]byte("world"))
+	_, _ = o.Write([]byte("dropped"))
+
+	expected := []RecordingEvent{
+		{Type: RecordingEventOutput, Data: []byte("hello")},
+		{Type: RecordingEventOutput, Data: []byte("wor"), Truncated: true},
+	}
+	if diff := cmp.Diff(expected, events); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
+
+func TestFileRecorder(t *testing.T) {
+	rec, err := NewRecorder(RecordingConfig{Sink: RecordingSinkFile, Directory: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recording, err := rec.Open(SessionInfo{ID: "session-id", OwnerUserID: "owner"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	evts := []RecordingEvent{
+		{SessionID: "session-id", Type: RecordingEventExec, Channel: 1, Command: "ls"},
+		{SessionID: "session-id", Type: RecordingEventSessionEnd, Reason: "closed"},
+	}
+	for _, evt := range evts {
+		if err := recording.Record(evt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := recording.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := recording.Record(RecordingEvent{}); err == nil {
+		t.Errorf("expected an error when recording into a closed recording")
+	}
+
+	f, err := os.Open(filepath.Join(rec.(*FileRecorder).Directory, "session-id.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var act []RecordingEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var evt RecordingEvent
+		if err := json.Unmarshal(scanner.Bytes(), &evt); err != nil {
+			t.Fatal(err)
+		}
+		act = append(act, evt)
+	}
+	if diff := cmp.Diff(evts, act); diff != "" {
+		t.Errorf("unexpected recording (-want +got):\n%s", diff)
+	}
+}
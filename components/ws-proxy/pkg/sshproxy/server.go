@@ -9,7 +9,9 @@ import (
 	"crypto/subtle"
 	"net"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gitpod-io/gitpod/common-go/analytics"
@@ -18,6 +20,7 @@ import (
 	tracker "github.com/gitpod-io/gitpod/ws-proxy/pkg/analytics"
 	"github.com/gitpod-io/gitpod/ws-proxy/pkg/proxy"
 	"github.com/gitpod-io/golang-crypto/ssh"
+	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/xerrors"
 	"google.golang.org/grpc"
@@ -49,6 +52,7 @@ var (
 	ErrMissPrivateKey     = NewSSHErrorWithReject("MISS_KEY", "missing privateKey")
 	ErrConnFailed         = NewSSHError("CONN_FAILED", "cannot to connect with workspace")
 	ErrCreateSSHKey       = NewSSHError("CREATE_KEY_FAILED", "cannot create private pair in workspace")
+	ErrCertificateInvalid = NewSSHError("CERT_INVALID", "certificate invalid")
 
 	ErrAuthFailed = NewSSHError("AUTH_FAILED", "auth failed")
 	// ErrAuthFailedWithReject is same with ErrAuthFailed, it will just disconnect immediately to avoid pointless retries
@@ -83,19 +87,91 @@ func NewSSHErrorWithReject(shortName string, description string) SSHError {
 type Session struct {
 	Conn *ssh.ServerConn
 
+	ID          string
+	StartedAt   time.Time
 	WorkspaceID string
 	InstanceID  string
 	OwnerUserId string
+	// CertPrincipal is the principal of the certificate the client authenticated with, if any.
+	CertPrincipal string
+	// ClientAddr is the address of the client. It's empty unless the gateway receives the client address
+	// through the PROXY protocol: otherwise the connection comes from the proxy the client connected through.
+	ClientAddr string
+	// Gateway identifies the gateway which serves the session.
+	Gateway string
 
 	PublicKey           ssh.PublicKey
 	WorkspacePrivateKey ssh.Signer
+
+	recording  Recording
+	channelIdx uint32
+	endReason  string
+	mu         sync.Mutex
+}
+
+// Info describes the session.
+func (s *Session) Info() SessionInfo {
+	return SessionInfo{
+		ID:            s.ID,
+		WorkspaceID:   s.WorkspaceID,
+		InstanceID:    s.InstanceID,
+		OwnerUserID:   s.OwnerUserId,
+		CertPrincipal: s.CertPrincipal,
+		RemoteAddr:    s.ClientAddr,
+		Gateway:       s.Gateway,
+		ClientVersion: string(s.Conn.ClientVersion()),
+		StartedAt:     s.StartedAt,
+	}
+}
+
+// Record adds an event to the session recording, if the session is recorded.
+func (s *Session) Record(evt RecordingEvent) {
+	if s.recording == nil {
+		return
+	}
+	if evt.Time.IsZero() {
+		evt.Time = time.Now()
+	}
+	evt.SessionID = s.ID
+	err := s.recording.Record(evt)
+	if err != nil {
+		log.WithFields(log.OWI(s.OwnerUserId, s.WorkspaceID, s.InstanceID)).WithField("sessionId", s.ID).WithError(err).Warn("cannot record ssh session event")
+	}
+}
+
+func (s *Session) nextChannelIdx() uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.channelIdx++
+	return s.channelIdx
 }
 
 type Server struct {
 	Heartbeater Heartbeat
 
+	// CertificateAuthority enables authentication with OpenSSH user certificates if set.
+	CertificateAuthority *CertificateAuthority
+
+	// Recorder records sessions for auditing if set.
+	Recorder Recorder
+	// RecordOutput enables recording the output of interactive sessions.
+	RecordOutput bool
+	// MaxOutputBytes limits the recorded output per channel. Zero means no limit.
+	MaxOutputBytes int64
+
+	// ProxyProtocol expects every connection to start with a PROXY protocol header which names the client.
+	// Without it, the gateway only sees the proxy the client connected through.
+	ProxyProtocol bool
+
+	// Name identifies this gateway, e.g. the ws-proxy replica, in the sessions it serves.
+	// Each replica only knows and manages its own sessions.
+	Name string
+
 	sshConfig             *ssh.ServerConfig
 	workspaceInfoProvider proxy.WorkspaceInfoProvider
+
+	mu       sync.RWMutex
+	sessions map[string]*Session
 }
 
 func init() {
@@ -111,6 +187,7 @@ func New(signers []ssh.Signer, workspaceInfoProvider proxy.WorkspaceInfoProvider
 	server := &Server{
 		workspaceInfoProvider: workspaceInfoProvider,
 		Heartbeater:           &noHeartbeat{},
+		sessions:              make(map[string]*Session),
 	}
 	if heartbeat != nil {
 		server.Heartbeater = heartbeat
@@ -184,6 +261,30 @@ func New(signers []ssh.Signer, workspaceInfoProvider proxy.WorkspaceInfoProvider
 			defer func() {
 				server.TrackSSHConnection(wsInfo, "auth", err)
 			}()
+			extensions := map[string]string{
+				"workspaceId":    workspaceId,
+				"debugWorkspace": debugWorkspace,
+			}
+			if cert, isCert := pk.(*ssh.Certificate); isCert {
+				if server.CertificateAuthority == nil {
+					return nil, ErrCertificateInvalid
+				}
+				principal, criticalOptions, verr := server.CertificateAuthority.Verify(cert, wsInfo.OwnerUserId)
+				if verr != nil {
+					log.WithFields(log.OWI(wsInfo.OwnerUserId, wsInfo.WorkspaceID, wsInfo.InstanceID)).WithField("keyId", cert.KeyId).WithError(verr).Debug("rejected ssh certificate")
+					return nil, ErrCertificateInvalid
+				}
+				if _, ok := criticalOptions[sourceAddressCriticalOption]; ok && !server.ProxyProtocol {
+					// we'd check the address of proxy rather than that of the client
+					log.WithFields(log.OWI(wsInfo.OwnerUserId, wsInfo.WorkspaceID, wsInfo.InstanceID)).WithField("keyId", cert.KeyId).Debug("rejected ssh certificate with source-address because the client address is unknown")
+					return nil, ErrCertificateInvalid
+				}
+				extensions["certPrincipal"] = principal
+				return &ssh.Permissions{
+					CriticalOptions: criticalOptions,
+					Extensions:      extensions,
+				}, nil
+			}
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 			ok, _ := server.VerifyPublicKey(ctx, wsInfo, pk)
@@ -191,10 +292,7 @@ func New(signers []ssh.Signer, workspaceInfoProvider proxy.WorkspaceInfoProvider
 				return nil, ErrAuthFailed
 			}
 			return &ssh.Permissions{
-				Extensions: map[string]string{
-					"workspaceId":    workspaceId,
-					"debugWorkspace": debugWorkspace,
-				},
+				Extensions: extensions,
 			}, nil
 		},
 	}
@@ -231,6 +329,16 @@ func (s *Server) RequestForward(reqs <-chan *ssh.Request, targetConn ssh.Conn) {
 }
 
 func (s *Server) HandleConn(c net.Conn) {
+	if s.ProxyProtocol {
+		pc, err := acceptProxyProtocol(c)
+		if err != nil {
+			log.WithError(err).WithField("remoteAddr", c.RemoteAddr().String()).Debug("rejected ssh connection without PROXY protocol header")
+			c.Close()
+			return
+		}
+		c = pc
+	}
+
 	clientConn, clientChans, clientReqs, err := ssh.NewServerConn(c, s.sshConfig)
 	if err != nil {
 		c.Close()
@@ -266,11 +374,18 @@ func (s *Server) HandleConn(c net.Conn) {
 
 	session := &Session{
 		Conn:                clientConn,
+		ID:                  uuid.New().String(),
+		StartedAt:           time.Now(),
 		WorkspaceID:         workspaceId,
 		InstanceID:          wsInfo.InstanceID,
 		OwnerUserId:         wsInfo.OwnerUserId,
+		CertPrincipal:       clientConn.Permissions.Extensions["certPrincipal"],
+		Gateway:             s.Name,
 		WorkspacePrivateKey: key,
 	}
+	if s.ProxyProtocol {
+		session.ClientAddr = clientConn.RemoteAddr().String()
+	}
 	sshPort := "23001"
 	if debugWorkspace {
 		sshPort = "25001"
@@ -308,6 +423,9 @@ func (s *Server) HandleConn(c net.Conn) {
 	SSHConnectionCount.Inc()
 	ReportSSHAttemptMetrics(nil)
 
+	s.startSession(session)
+	defer s.endSession(session)
+
 	forwardRequests := func(reqs <-chan *ssh.Request, targetConn ssh.Conn) {
 		for req := range reqs {
 			result, payload, err := targetConn.SendRequest(req.Type, req.WantReply, req.Payload)
@@ -349,6 +467,78 @@ func (s *Server) HandleConn(c net.Conn) {
 	cancel()
 }
 
+// startSession registers a live session and starts its recording.
+func (s *Server) startSession(session *Session) {
+	info := session.Info()
+	if s.Recorder != nil {
+		rec, err := s.Recorder.Open(info)
+		if err != nil {
+			log.WithFields(log.OWI(session.OwnerUserId, session.WorkspaceID, session.InstanceID)).WithField("sessionId", session.ID).WithError(err).Error("cannot start ssh session recording")
+		} else {
+			session.recording = rec
+		}
+	}
+	session.Record(RecordingEvent{Time: session.StartedAt, Type: RecordingEventSessionStart, Session: &info})
+
+	s.mu.Lock()
+	s.sessions[session.ID] = session
+	s.mu.Unlock()
+}
+
+// endSession removes a session from the live sessions and finishes its recording.
+func (s *Server) endSession(session *Session) {
+	s.mu.Lock()
+	delete(s.sessions, session.ID)
+	s.mu.Unlock()
+
+	session.mu.Lock()
+	reason := session.endReason
+	session.mu.Unlock()
+	if reason == "" {
+		reason = "closed"
+	}
+	session.Record(RecordingEvent{Type: RecordingEventSessionEnd, Reason: reason})
+	if session.recording != nil {
+		err := session.recording.Close()
+		if err != nil {
+			log.WithField("sessionId", session.ID).WithError(err).Warn("cannot close ssh session recording")
+		}
+	}
+}
+
+// Sessions lists the live sessions, optionally filtered by workspace.
+func (s *Server) Sessions(workspaceID string) []SessionInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]SessionInfo, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		if workspaceID != "" && session.WorkspaceID != workspaceID {
+			continue
+		}
+		res = append(res, session.Info())
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].StartedAt.Before(res[j].StartedAt) })
+	return res
+}
+
+// Disconnect closes a live session. It returns false if there is no such session.
+func (s *Server) Disconnect(sessionID, reason string) bool {
+	s.mu.RLock()
+	session, ok := s.sessions[sessionID]
+	s.mu.RUnlock()
+	if !ok {
+		return false
+	}
+
+	session.mu.Lock()
+	session.endReason = reason
+	session.mu.Unlock()
+	log.WithFields(log.OWI(session.OwnerUserId, session.WorkspaceID, session.InstanceID)).WithField("sessionId", sessionID).WithField("reason", reason).Info("disconnecting ssh session")
+	session.Conn.Close()
+	return true
+}
+
 func (s *Server) GetWorkspaceInfo(workspaceId string) (*proxy.WorkspaceInfo, error) {
 	wsInfo := s.workspaceInfoProvider.WorkspaceInfo(workspaceId)
 	if wsInfo == nil {
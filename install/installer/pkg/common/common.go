@@ -732,6 +732,14 @@ var (
 		APIVersion: "batch/v1",
 		Kind:       "CronJob",
 	}
+	TypeMetaMutatingWebhookConfiguration = metav1.TypeMeta{
+		APIVersion: "admissionregistration.k8s.io/v1",
+		Kind:       "MutatingWebhookConfiguration",
+	}
+	TypeMetaValidatingWebhookConfiguration = metav1.TypeMeta{
+		APIVersion: "admissionregistration.k8s.io/v1",
+		Kind:       "ValidatingWebhookConfiguration",
+	}
 )
 
 // validCookieChars contains all characters which may occur in an HTTP Cookie value (unicode \u0021 through \u007E),
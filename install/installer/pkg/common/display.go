@@ -55,6 +55,8 @@ var sortOrder = []string{
 	"CronJob",
 	"Ingress",
 	"APIService",
+	"MutatingWebhookConfiguration",
+	"ValidatingWebhookConfiguration",
 }
 
 type RuntimeObject struct {
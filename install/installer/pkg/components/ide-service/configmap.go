@@ -6,6 +6,7 @@ package ide_service
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/gitpod-io/gitpod/common-go/baseserver"
 	"github.com/gitpod-io/gitpod/ide-service-api/config"
@@ -28,6 +29,12 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 		},
 		IDEConfigPath: "/ide-config/config.json",
 	}
+	if pluginsConfigMap(ctx) != "" {
+		cfg.IDEPluginsPath = PluginsMountPath
+	}
+	if rolloutConfigMap(ctx) != "" {
+		cfg.RolloutPath = filepath.Join(RolloutMountPath, "rollout.json")
+	}
 
 	fc, err := common.ToJSONString(cfg)
 	if err != nil {
@@ -50,3 +57,19 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 	}
 	return res, nil
 }
+
+// pluginsConfigMap returns the name of the ConfigMap with the IDE plugin manifests, if there is one
+func pluginsConfigMap(ctx *common.RenderContext) string {
+	if ctx.Config.Components == nil || ctx.Config.Components.IDE == nil {
+		return ""
+	}
+	return ctx.Config.Components.IDE.PluginsConfigMap
+}
+
+// rolloutConfigMap returns the name of the ConfigMap with the IDE config rollout, if there is one
+func rolloutConfigMap(ctx *common.RenderContext) string {
+	if ctx.Config.Components == nil || ctx.Config.Components.IDE == nil {
+		return ""
+	}
+	return ctx.Config.Components.IDE.RolloutConfigMap
+}
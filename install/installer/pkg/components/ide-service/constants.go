@@ -5,8 +5,13 @@
 package ide_service
 
 const (
-	Component    = "ide-service"
-	VolumeConfig = "config"
+	Component     = "ide-service"
+	VolumeConfig  = "config"
+	VolumePlugins = "ide-plugins"
+	VolumeRollout = "ide-rollout"
+
+	PluginsMountPath = "/ide-plugins"
+	RolloutMountPath = "/ide-rollout"
 
 	GRPCPortName    = "grpc"
 	GRPCServicePort = 9001
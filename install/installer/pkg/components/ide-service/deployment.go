@@ -26,6 +26,70 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 		return nil, err
 	}
 
+	volumes := []corev1.Volume{
+		{
+			Name: VolumeConfig,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: Component},
+				},
+			},
+		},
+		{
+			Name: "ide-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "ide-config"},
+				},
+			},
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      VolumeConfig,
+			MountPath: "/config",
+			ReadOnly:  true,
+		},
+		{
+			Name:      "ide-config",
+			MountPath: "/ide-config",
+			ReadOnly:  true,
+		},
+	}
+	if name := pluginsConfigMap(ctx); name != "" {
+		// the ConfigMap is managed by the admins of the installation, ide-service picks up changes without a restart
+		volumes = append(volumes, corev1.Volume{
+			Name: VolumePlugins,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+					Optional:             pointer.Bool(true),
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      VolumePlugins,
+			MountPath: PluginsMountPath,
+			ReadOnly:  true,
+		})
+	}
+	if name := rolloutConfigMap(ctx); name != "" {
+		// the ConfigMap is not optional: ide-service cannot watch a rollout file which does not exist
+		volumes = append(volumes, corev1.Volume{
+			Name: VolumeRollout,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      VolumeRollout,
+			MountPath: RolloutMountPath,
+			ReadOnly:  true,
+		})
+	}
+
 	return []runtime.Object{
 		&appsv1.Deployment{
 			TypeMeta: common.TypeMetaDeployment,
@@ -79,18 +143,7 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 							Env: common.CustomizeEnvvar(ctx, Component, common.MergeEnv(
 								common.DefaultEnv(&ctx.Config),
 							)),
-							VolumeMounts: []corev1.VolumeMount{
-								{
-									Name:      VolumeConfig,
-									MountPath: "/config",
-									ReadOnly:  true,
-								},
-								{
-									Name:      "ide-config",
-									MountPath: "/ide-config",
-									ReadOnly:  true,
-								},
-							},
+							VolumeMounts: volumeMounts,
 							ReadinessProbe: &corev1.Probe{
 								ProbeHandler: corev1.ProbeHandler{
 									HTTPGet: &corev1.HTTPGetAction{
@@ -105,24 +158,7 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 						},
 							*common.KubeRBACProxyContainerWithConfig(ctx),
 						},
-						Volumes: []corev1.Volume{
-							{
-								Name: VolumeConfig,
-								VolumeSource: corev1.VolumeSource{
-									ConfigMap: &corev1.ConfigMapVolumeSource{
-										LocalObjectReference: corev1.LocalObjectReference{Name: Component},
-									},
-								},
-							},
-							{
-								Name: "ide-config",
-								VolumeSource: corev1.VolumeSource{
-									ConfigMap: &corev1.ConfigMapVolumeSource{
-										LocalObjectReference: corev1.LocalObjectReference{Name: "ide-config"},
-									},
-								},
-							},
-						},
+						Volumes: volumes,
 					},
 				},
 			},
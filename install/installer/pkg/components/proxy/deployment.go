@@ -267,6 +267,9 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 								}, {
 									Name:  "WORKSPACE_HANDLER_FILE",
 									Value: strings.ToLower(string(ctx.Config.Kind)),
+								}, {
+									Name:  "SSH_TUNNEL_PROXY_PROTOCOL",
+									Value: "true",
 								}},
 							)),
 						}},
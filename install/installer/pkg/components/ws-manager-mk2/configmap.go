@@ -270,6 +270,12 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 			Addr string `json:"addr"`
 		}{Addr: fmt.Sprintf(":%d", HealthPort)},
 	}
+	if adm := admission(ctx); adm != nil {
+		wsmcfg.Webhook.Enabled = true
+		if adm.Policy != "" {
+			wsmcfg.Webhook.PolicyPath = filepath.Join(PolicyPath, PolicyFileName)
+		}
+	}
 
 	fc, err := common.ToJSONString(wsmcfg)
 	if err != nil {
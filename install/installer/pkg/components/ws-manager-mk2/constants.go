@@ -13,9 +13,20 @@ const (
 	HealthPort                 = 9090
 	TLSSecretNameSecret        = "ws-manager-mk2-tls"
 	TLSSecretNameClient        = "ws-manager-mk2-client-tls"
+	TLSSecretNameWebhook       = "ws-manager-mk2-webhook-tls"
 	VolumeConfig               = "config"
 	VolumeTLSCerts             = "tls-certs"
 	VolumeWorkspaceTemplate    = "workspace-template"
 	WorkspaceTemplatePath      = "/workspace-templates"
 	WorkspaceTemplateConfigMap = "workspace-templates"
+
+	WebhookPort     = 9443
+	WebhookPortName = "webhook"
+	// WebhookCertsPath is where controller-runtime expects the serving certificate of the webhook server
+	WebhookCertsPath   = "/tmp/k8s-webhook-server/serving-certs"
+	VolumeWebhookCerts = "webhook-certs"
+	VolumePolicy       = "policy"
+	PolicyConfigMap    = "ws-manager-mk2-policy"
+	PolicyPath         = "/policy"
+	PolicyFileName     = "policy.json"
 )
@@ -45,6 +45,46 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 		})
 	}
 
+	ports := []corev1.ContainerPort{
+		{
+			Name:          RPCPortName,
+			ContainerPort: RPCPort,
+		},
+	}
+	if adm := admission(ctx); adm != nil {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          WebhookPortName,
+			ContainerPort: WebhookPort,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: VolumeWebhookCerts,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: TLSSecretNameWebhook},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      VolumeWebhookCerts,
+			MountPath: WebhookCertsPath,
+			ReadOnly:  true,
+		})
+		if adm.Policy != "" {
+			// ws-manager-mk2 reloads the policy when the ConfigMap changes
+			volumes = append(volumes, corev1.Volume{
+				Name: VolumePolicy,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: PolicyConfigMap},
+					},
+				},
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      VolumePolicy,
+				MountPath: PolicyPath,
+				ReadOnly:  true,
+			})
+		}
+	}
+
 	podSpec := corev1.PodSpec{
 		PriorityClassName:  common.SystemNodeCritical,
 		Affinity:           common.NodeAffinity(cluster.AffinityLabelServices),
@@ -84,12 +124,7 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 				InitialDelaySeconds: 5,
 				PeriodSeconds:       10,
 			},
-			Ports: []corev1.ContainerPort{
-				{
-					Name:          RPCPortName,
-					ContainerPort: RPCPort,
-				},
-			},
+			Ports: ports,
 			SecurityContext: &corev1.SecurityContext{
 				Privileged: pointer.Bool(false),
 			},
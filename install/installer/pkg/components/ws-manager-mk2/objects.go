@@ -22,6 +22,21 @@ var Objects common.RenderFunc = func(cfg *common.RenderContext) ([]runtime.Objec
 		return nil, nil
 	}
 
+	ports := []common.ServicePort{
+		{
+			Name:          RPCPortName,
+			ContainerPort: RPCPort,
+			ServicePort:   RPCPort,
+		},
+	}
+	if admission(cfg) != nil {
+		ports = append(ports, common.ServicePort{
+			Name:          WebhookPortName,
+			ContainerPort: WebhookPort,
+			ServicePort:   WebhookPort,
+		})
+	}
+
 	return common.CompositeRenderFunc(
 		crd,
 		configmap,
@@ -30,14 +45,9 @@ var Objects common.RenderFunc = func(cfg *common.RenderContext) ([]runtime.Objec
 		role,
 		rolebinding,
 		common.DefaultServiceAccount(Component),
-		common.GenerateService(Component, []common.ServicePort{
-			{
-				Name:          RPCPortName,
-				ContainerPort: RPCPort,
-				ServicePort:   RPCPort,
-			},
-		}),
+		common.GenerateService(Component, ports),
 		tlssecret,
 		unprivilegedRolebinding,
+		webhook,
 	)(cfg)
 }
@@ -42,6 +42,11 @@ func role(ctx *common.RenderContext) ([]runtime.Object, error) {
 					APIGroups: []string{""},
 					Resources: []string{"pod/status"},
 				},
+				{
+					Verbs:     []string{"get"},
+					APIGroups: []string{""},
+					Resources: []string{"pods/log"},
+				},
 				{
 					APIGroups: []string{"workspace.gitpod.io"},
 					Resources: []string{"workspaces"},
@@ -0,0 +1,151 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package wsmanagermk2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
+
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+)
+
+// admission returns the admission webhook config if the webhook is enabled
+func admission(ctx *common.RenderContext) *experimental.WorkspaceAdmission {
+	var res *experimental.WorkspaceAdmission
+	_ = ctx.WithExperimental(func(ucfg *experimental.Config) error {
+		if ucfg.Workspace != nil && ucfg.Workspace.Admission != nil && ucfg.Workspace.Admission.Enabled {
+			res = ucfg.Workspace.Admission
+		}
+		return nil
+	})
+	return res
+}
+
+func webhook(ctx *common.RenderContext) ([]runtime.Object, error) {
+	adm := admission(ctx)
+	if adm == nil {
+		return nil, nil
+	}
+
+	res := []runtime.Object{
+		&certmanagerv1.Certificate{
+			TypeMeta: common.TypeMetaCertificate,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      TLSSecretNameWebhook,
+				Namespace: ctx.Namespace,
+				Labels:    common.DefaultLabels(Component),
+			},
+			Spec: certmanagerv1.CertificateSpec{
+				Duration:   common.InternalCertDuration,
+				SecretName: TLSSecretNameWebhook,
+				DNSNames: []string{
+					fmt.Sprintf("%s.%s.svc", Component, ctx.Namespace),
+					fmt.Sprintf("%s.%s.svc.cluster.local", Component, ctx.Namespace),
+				},
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  common.CertManagerCAIssuer,
+					Kind:  "Issuer",
+					Group: "cert-manager.io",
+				},
+			},
+		},
+	}
+
+	if adm.Policy != "" {
+		if !json.Valid([]byte(adm.Policy)) {
+			return nil, fmt.Errorf("workspace admission policy is not valid JSON")
+		}
+		res = append(res, &corev1.ConfigMap{
+			TypeMeta: common.TypeMetaConfigmap,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      PolicyConfigMap,
+				Namespace: ctx.Namespace,
+				Labels:    common.DefaultLabels(Component),
+			},
+			Data: map[string]string{
+				PolicyFileName: adm.Policy,
+			},
+		})
+	}
+
+	var (
+		// cert-manager's CA injector fills in the CA of the webhook certificate
+		annotations = map[string]string{
+			"cert-manager.io/inject-ca-from": fmt.Sprintf("%s/%s", ctx.Namespace, TLSSecretNameWebhook),
+		}
+		// webhook configurations are cluster-wide, but only workspaces of this installation are ours to admit
+		namespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"kubernetes.io/metadata.name": ctx.Namespace},
+		}
+		rules = []admissionv1.RuleWithOperations{{
+			Operations: []admissionv1.OperationType{admissionv1.Create, admissionv1.Update},
+			Rule: admissionv1.Rule{
+				APIGroups:   []string{"workspace.gitpod.io"},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"workspaces"},
+			},
+		}}
+		clientConfig = func(path string) admissionv1.WebhookClientConfig {
+			return admissionv1.WebhookClientConfig{
+				Service: &admissionv1.ServiceReference{
+					Name:      Component,
+					Namespace: ctx.Namespace,
+					Path:      pointer.String(path),
+					Port:      pointer.Int32(WebhookPort),
+				},
+			}
+		}
+		failurePolicy = admissionv1.Fail
+		sideEffects   = admissionv1.SideEffectClassNone
+	)
+
+	// the paths and names match the kubebuilder markers in ws-manager-api/go/crd/v1/workspace_webhook.go
+	res = append(res,
+		&admissionv1.MutatingWebhookConfiguration{
+			TypeMeta: common.TypeMetaMutatingWebhookConfiguration,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fmt.Sprintf("%s-%s", ctx.Namespace, Component),
+				Labels:      common.DefaultLabels(Component),
+				Annotations: annotations,
+			},
+			Webhooks: []admissionv1.MutatingWebhook{{
+				Name:                    "mworkspace.kb.io",
+				ClientConfig:            clientConfig("/mutate-workspace-gitpod-io-v1-workspace"),
+				Rules:                   rules,
+				FailurePolicy:           &failurePolicy,
+				SideEffects:             &sideEffects,
+				NamespaceSelector:       namespaceSelector,
+				AdmissionReviewVersions: []string{"v1"},
+			}},
+		},
+		&admissionv1.ValidatingWebhookConfiguration{
+			TypeMeta: common.TypeMetaValidatingWebhookConfiguration,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fmt.Sprintf("%s-%s", ctx.Namespace, Component),
+				Labels:      common.DefaultLabels(Component),
+				Annotations: annotations,
+			},
+			Webhooks: []admissionv1.ValidatingWebhook{{
+				Name:                    "vworkspace.kb.io",
+				ClientConfig:            clientConfig("/validate-workspace-gitpod-io-v1-workspace"),
+				Rules:                   rules,
+				FailurePolicy:           &failurePolicy,
+				SideEffects:             &sideEffects,
+				NamespaceSelector:       namespaceSelector,
+				AdmissionReviewVersions: []string{"v1"},
+			}},
+		},
+	)
+	return res, nil
+}
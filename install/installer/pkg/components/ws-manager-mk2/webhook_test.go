@@ -0,0 +1,65 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package wsmanagermk2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	config "github.com/gitpod-io/gitpod/installer/pkg/config/v1"
+	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
+	"github.com/gitpod-io/gitpod/installer/pkg/config/versions"
+)
+
+func TestWebhook(t *testing.T) {
+	render := func(adm *experimental.WorkspaceAdmission) *common.RenderContext {
+		ctx, err := common.NewRenderContext(config.Config{
+			Experimental: &experimental.Config{
+				Workspace: &experimental.WorkspaceConfig{
+					UseWsmanagerMk2: true,
+					Admission:       adm,
+				},
+			},
+		}, versions.Manifest{}, "test_namespace")
+		require.NoError(t, err)
+		return ctx
+	}
+
+	objs, err := webhook(render(&experimental.WorkspaceAdmission{Enabled: false, Policy: "{}"}))
+	require.NoError(t, err)
+	require.Empty(t, objs, "disabled webhook must not render anything")
+
+	objs, err = webhook(render(&experimental.WorkspaceAdmission{Enabled: true, Policy: `{"default": {"maxClass": "g1-standard"}}`}))
+	require.NoError(t, err)
+
+	var (
+		policy     *corev1.ConfigMap
+		mutating   *admissionv1.MutatingWebhookConfiguration
+		validating *admissionv1.ValidatingWebhookConfiguration
+	)
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *corev1.ConfigMap:
+			policy = o
+		case *admissionv1.MutatingWebhookConfiguration:
+			mutating = o
+		case *admissionv1.ValidatingWebhookConfiguration:
+			validating = o
+		}
+	}
+	require.NotNil(t, policy, "policy ConfigMap")
+	require.Contains(t, policy.Data, PolicyFileName)
+	require.NotNil(t, mutating, "MutatingWebhookConfiguration")
+	require.NotNil(t, validating, "ValidatingWebhookConfiguration")
+	require.Equal(t, "/validate-workspace-gitpod-io-v1-workspace", *validating.Webhooks[0].ClientConfig.Service.Path)
+	require.Equal(t, "test_namespace/"+TLSSecretNameWebhook, validating.Annotations["cert-manager.io/inject-ca-from"])
+
+	_, err = webhook(render(&experimental.WorkspaceAdmission{Enabled: true, Policy: "not json"}))
+	require.Error(t, err, "invalid policy")
+}
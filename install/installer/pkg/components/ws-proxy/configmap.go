@@ -60,7 +60,10 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 		},
 	}
 
-	var enableWorkspaceCRD bool
+	var (
+		enableWorkspaceCRD bool
+		inspector          *proxy.InspectorConfig
+	)
 
 	ctx.WithExperimental(func(ucfg *experimental.Config) error {
 		if ucfg.Workspace == nil {
@@ -82,6 +85,14 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 			gitpodInstallationWorkspaceHostSuffixRegex = ucfg.Workspace.WSProxy.GitpodInstallationWorkspaceHostSuffixRegex
 		}
 
+		if ucfg.Workspace.WSProxy.EnableInspector {
+			inspector = &proxy.InspectorConfig{
+				MaxBodySize: 64 * 1024,
+				MaxCaptures: 100,
+				Retention:   util.Duration(time.Hour),
+			}
+		}
+
 		enableWorkspaceCRD = ucfg.Workspace.UseWsmanagerMk2
 
 		return nil
@@ -131,12 +142,17 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 			BuiltinPages: proxy.BuiltinPagesConfig{
 				Location: "/app/public",
 			},
+			Inspector: inspector,
 		},
 		PProfAddr:          common.LocalhostAddressFromPort(baseserver.BuiltinDebugPort),
 		PrometheusAddr:     common.LocalhostPrometheusAddr(),
 		ReadinessProbeAddr: fmt.Sprintf(":%v", ReadinessPort),
 		WorkspaceManager:   wsManagerConfig,
 		EnableWorkspaceCRD: enableWorkspaceCRD,
+		SSHGateway: config.SSHGatewayConfig{
+			// proxy tunnels SSH connections and announces their clients
+			ProxyProtocol: true,
+		},
 	}
 
 	fc, err := common.ToJSONString(wspcfg)
@@ -34,11 +34,21 @@ func networkpolicy(ctx *common.RenderContext) ([]runtime.Object, error) {
 					}, {
 						Protocol: common.TCPProtocol,
 						Port:     &intstr.IntOrString{IntVal: HTTPSProxyPort},
-					}, {
+					},
+				},
+			}, {
+				// the SSH gateway trusts the PROXY protocol header, which only proxy may send
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
 						Protocol: common.TCPProtocol,
 						Port:     &intstr.IntOrString{IntVal: SSHTargetPort},
 					},
 				},
+				From: []networkingv1.NetworkPolicyPeer{{
+					PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{
+						"component": common.ProxyComponent,
+					}},
+				}},
 			}},
 		},
 	}}, nil
@@ -448,6 +448,12 @@ type IDEComponents struct {
 	Metrics       *IDEMetrics `json:"metrics,omitempty"`
 	Proxy         *Proxy      `json:"proxy,omitempty"`
 	ResolveLatest *bool       `json:"resolveLatest,omitempty"`
+	// PluginsConfigMap is the name of a ConfigMap with IDE plugin manifests, one manifest per key ending in ".json".
+	// An IDE is registered for an organization by adding a manifest which lists the organization's ID in "organizations".
+	PluginsConfigMap string `json:"pluginsConfigMap,omitempty"`
+	// RolloutConfigMap is the name of a ConfigMap which configures the staged rollout of IDE config versions.
+	// It must have a "rollout.json" key, the IDE configs of the versions are further keys referenced by their "path".
+	RolloutConfigMap string `json:"rolloutConfigMap,omitempty"`
 }
 
 type IDEMetrics struct {
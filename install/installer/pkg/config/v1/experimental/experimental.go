@@ -125,6 +125,8 @@ type WorkspaceConfig struct {
 		GitpodInstallationHostName                 string `json:"gitpodInstallationHostName"`
 		GitpodInstallationWorkspaceHostSuffix      string `json:"gitpodInstallationWorkspaceHostSuffix"`
 		GitpodInstallationWorkspaceHostSuffixRegex string `json:"gitpodInstallationWorkspaceHostSuffixRegex"`
+		// EnableInspector allows users to inspect the traffic of their workspace ports
+		EnableInspector bool `json:"enableInspector"`
 	} `json:"wsProxy"`
 
 	ContentService struct {
@@ -134,6 +136,16 @@ type WorkspaceConfig struct {
 
 	EnableProtectedSecrets *bool `json:"enableProtectedSecrets"`
 	UseWsmanagerMk2        bool  `json:"useWsmanagerMk2,omitempty"`
+
+	// Admission configures the admission webhook of ws-manager-mk2 which enforces policies on workspaces
+	Admission *WorkspaceAdmission `json:"admission,omitempty"`
+}
+
+type WorkspaceAdmission struct {
+	Enabled bool `json:"enabled"`
+	// Policy is the JSON policy document the webhook enforces, see components/ws-manager-mk2/policy.
+	// Without it, the webhook only validates the workspaces.
+	Policy string `json:"policy,omitempty"`
 }
 
 type PersistentVolumeClaim struct {